@@ -6,10 +6,12 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"os/signal"
 	"path"
 	"runtime/debug"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/security/idp/providers"
@@ -18,6 +20,7 @@ import (
 
 	"gopkg.in/natefinch/lumberjack.v2"
 
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/logging"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/metrics"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
@@ -25,6 +28,7 @@ import (
 	mController "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/migration/controller"
 	mRepository "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/migration/repository"
 	mService "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/migration/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/tracing"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/cache"
@@ -59,12 +63,19 @@ func init() {
 	} else {
 		mw = os.Stdout
 	}
-	log.SetFormatter(&prefixed.TextFormatter{
-		DisableColors:   true,
-		TimestampFormat: "2006-01-02 15:04:05",
-		FullTimestamp:   true,
-		ForceFormatting: true,
-	})
+	if strings.EqualFold(os.Getenv("LOG_FORMAT"), "json") {
+		log.SetFormatter(&log.JSONFormatter{
+			TimestampFormat: "2006-01-02 15:04:05.000",
+		})
+	} else {
+		log.SetFormatter(&prefixed.TextFormatter{
+			DisableColors:   true,
+			TimestampFormat: "2006-01-02 15:04:05",
+			FullTimestamp:   true,
+			ForceFormatting: true,
+		})
+	}
+	log.AddHook(logging.ContextFieldsHook{})
 	logLevel, err := log.ParseLevel(os.Getenv("LOG_LEVEL"))
 	if err != nil {
 		logLevel = log.InfoLevel
@@ -79,6 +90,19 @@ func main() {
 		panic(err)
 	}
 	basePath := systemInfoService.GetBasePath()
+	shutdownService := service.NewShutdownService(systemInfoService.GetShutdownConfig())
+
+	tracingConfig := systemInfoService.GetTracingConfig()
+	shutdownTracing, err := tracing.Init(tracing.Options{
+		Enabled:        tracingConfig.Enabled,
+		OtlpEndpoint:   tracingConfig.OtlpEndpoint,
+		ServiceName:    tracingConfig.ServiceName,
+		ServiceVersion: systemInfoService.GetBackendVersion(),
+		SampleRatio:    tracingConfig.SampleRatio,
+	})
+	if err != nil {
+		panic("Failed to init tracing: " + err.Error())
+	}
 
 	// Create router and server to expose live and ready endpoints during initialization
 	readyChan := make(chan bool)
@@ -86,19 +110,29 @@ func main() {
 	initSrvStoppedChan := make(chan bool)
 	r := mux.NewRouter()
 	// r.Use(midldleware.PrometheusMiddleware) todo figure out why breaks streaming
+	r.Use(midldleware.TracingMiddleware)
+	r.Use(midldleware.RequestIdMiddleware)
 	r.Use(midldleware.WriteDeadlineMiddleware)
 	r.SkipClean(true)
 	r.UseEncodedPath()
-	healthController := controller.NewHealthController(readyChan)
+	healthController := controller.NewHealthController(readyChan, shutdownService)
 	r.HandleFunc("/live", healthController.HandleLiveRequest).Methods(http.MethodGet)
 	r.HandleFunc("/ready", healthController.HandleReadyRequest).Methods(http.MethodGet)
 	initSrv := makeServer(systemInfoService, r)
 
 	creds := systemInfoService.GetCredsFromEnv()
 
-	cp := db.NewConnectionProvider(creds)
+	cp, err := db.NewRoutingConnectionProvider(db.NewConnectionProvider(creds), db.ReadReplicaOptions{
+		Urls:              systemInfoService.GetPGReadReplicaUrls(),
+		MaxLag:            time.Duration(systemInfoService.GetPGReplicaMaxLagSec()) * time.Second,
+		PrimaryStickiness: time.Duration(systemInfoService.GetPGPrimaryStickinessSec()) * time.Second,
+	})
+	if err != nil {
+		panic("Failed to create ConnectionProvider: " + err.Error())
+	}
 
 	migrationRunRepository := mRepository.NewMigrationRunRepository(cp)
+	backgroundMigrationRepository := mRepository.NewBackgroundMigrationRepository(cp)
 	buildCleanupRepository := repository.NewBuildCleanupRepository(cp)
 	transitionRepository := repository.NewTransitionRepository(cp)
 	buildResultRepository := repository.NewBuildResultRepository(cp)
@@ -109,7 +143,9 @@ func main() {
 	}
 	minioStorageCreds := systemInfoService.GetMinioStorageCreds()
 	minioStorageService := service.NewMinioStorageService(buildResultRepository, publishedRepository, minioStorageCreds)
-	dbMigrationService, err := mService.NewDBMigrationService(cp, migrationRunRepository, buildCleanupRepository, transitionRepository, systemInfoService, minioStorageService)
+	blobRepository := repository.NewBlobRepositoryPG(cp)
+	blobStorageService := service.NewBlobStorageService(systemInfoService, blobRepository)
+	dbMigrationService, err := mService.NewDBMigrationService(cp, migrationRunRepository, backgroundMigrationRepository, buildCleanupRepository, transitionRepository, systemInfoService, blobStorageService)
 	if err != nil {
 		log.Error("Failed create dbMigrationService: " + err.Error())
 		panic("Failed create dbMigrationService: " + err.Error())
@@ -187,10 +223,17 @@ func main() {
 	}
 
 	roleRepository := repository.NewRoleRepository(cp)
+	tenantRepository := repository.NewTenantRepository(cp)
 	operationRepository := repository.NewOperationRepository(cp)
 	businessMetricRepository := repository.NewBusinessMetricRepository(cp)
 
 	activityTrackingRepository := repository.NewActivityTrackingRepository(cp)
+	deprecationPolicyRepository := repository.NewDeprecationPolicyRepositoryPG(cp)
+	operationConsumerRepository := repository.NewOperationConsumerRepositoryPG(cp)
+	dependencyGraphRepository := repository.NewDependencyGraphRepositoryPG(cp)
+	dashboardTrackingRepository := repository.NewDashboardTrackingRepositoryPG(cp)
+	savedComparisonRepository := repository.NewSavedComparisonRepositoryPG(cp)
+	operationHistoryRepository := repository.NewOperationHistoryRepositoryPG(cp)
 
 	versionCleanupRepository := repository.NewVersionCleanupRepository(cp)
 	comparisonCleanupRepository := repository.NewComparisonCleanupRepository(cp)
@@ -207,6 +250,12 @@ func main() {
 
 	unreferencedDataCleanupRepository := repository.NewUnreferencedDataCleanupRepository(cp)
 
+	cleanupRetentionRepository := repository.NewCleanupRetentionRepository(cp)
+	cleanupJobRepository := repository.NewCleanupJobRepository(cp)
+	backgroundJobRepository := repository.NewBackgroundJobRepository(cp)
+	runtimeSettingsRepository := repository.NewRuntimeSettingsRepository(cp)
+	aiSpecReviewRepository := repository.NewAiSpecReviewRepositoryPG(cp)
+
 	lockRepo := repository.NewLockRepository(cp)
 
 	olricProvider, err := cache.NewOlricProvider(systemInfoService.GetOlricConfig())
@@ -214,64 +263,103 @@ func main() {
 		log.Error("Failed to create olricProvider: " + err.Error())
 		panic("Failed to create olricProvider: " + err.Error())
 	}
+	responseCacheConfig := systemInfoService.GetOlricConfig().ResponseCache
+	responseCache := cache.NewResponseCache(olricProvider, responseCacheConfig.Enabled, responseCacheConfig.TTLSec)
+	if responseCacheConfig.Enabled {
+		publishedRepository = repository.NewCachedPublishedRepository(publishedRepository, responseCache)
+		operationRepository = repository.NewCachedOperationRepository(operationRepository, responseCache)
+	}
 
 	privateUserPackageService := service.NewPrivateUserPackageService(publishedRepository, usersRepository, roleRepository, favoritesRepository)
 	userService := service.NewUserService(usersRepository, systemInfoService, privateUserPackageService)
 
 	lockService := service.NewLockService(lockRepo, systemInfoService.GetInstanceId())
+	backgroundJobService := service.NewBackgroundJobService(backgroundJobRepository, lockService, shutdownService, systemInfoService.GetInstanceId())
 
-	monitoringService := service.NewMonitoringService(cp)
+	// runtime settings are applied to systemInfoService before the services which read them are created
+	runtimeSettingsService := service.NewRuntimeSettingsService(runtimeSettingsRepository, systemInfoService, olricProvider)
+	if err := runtimeSettingsService.StartReloadJob(backgroundJobService); err != nil {
+		log.Errorf("Failed to start runtime settings reload job: %v", err)
+	}
+
+	monitoringService := service.NewMonitoringService(cp, backgroundJobService)
+	if err := dbMigrationService.StartBackgroundMigrationsJob(backgroundJobService); err != nil {
+		log.Errorf("Failed to start background schema migrations job: %v", err)
+	}
 
-	cleanupService := cleanup.NewCleanupService(cp)
-	if err := cleanupService.CreateRevisionsCleanupJob(publishedRepository, migrationRunRepository, versionCleanupRepository, monitoringService, lockService, systemInfoService.GetInstanceId(), systemInfoService.GetRevisionsCleanupSchedule(), systemInfoService.GetRevisionsCleanupDeleteLastRevision(), systemInfoService.GetRevisionsCleanupDeleteReleaseRevisions(), systemInfoService.GetRevisionsTTLDays()); err != nil {
+	cleanupService := cleanup.NewCleanupService(cp, cleanupRetentionRepository, cleanupJobRepository, backgroundJobService)
+	if err := cleanupService.CreateRevisionsCleanupJob(publishedRepository, migrationRunRepository, versionCleanupRepository, monitoringService, systemInfoService.GetInstanceId(), systemInfoService.GetRevisionsCleanupSchedule(), systemInfoService.GetRevisionsCleanupDeleteLastRevision(), systemInfoService.GetRevisionsCleanupDeleteReleaseRevisions(), systemInfoService.GetRevisionsTTLDays()); err != nil {
 		log.Error("Failed to start revisions cleaning job" + err.Error())
 	}
-	if err := cleanupService.CreateComparisonsCleanupJob(publishedRepository, migrationRunRepository, comparisonCleanupRepository, lockService, systemInfoService.GetInstanceId(), systemInfoService.GetComparisonCleanupSchedule(), systemInfoService.GetComparisonCleanupTimeout(), systemInfoService.GetComparisonsTTLDays()); err != nil {
+	if err := cleanupService.CreateComparisonsCleanupJob(publishedRepository, migrationRunRepository, comparisonCleanupRepository, systemInfoService.GetInstanceId(), systemInfoService.GetComparisonCleanupSchedule(), systemInfoService.GetComparisonCleanupTimeout(), systemInfoService.GetComparisonsTTLDays()); err != nil {
 		log.Error("Failed to start comparisons cleaning job" + err.Error())
 	}
-	if err := cleanupService.CreateSoftDeletedDataCleanupJob(publishedRepository, migrationRunRepository, deletedDataCleanupRepository, lockService, systemInfoService.GetInstanceId(), systemInfoService.GetSoftDeletedDataCleanupSchedule(), systemInfoService.GetSoftDeletedDataCleanupTimeout(), systemInfoService.GetSoftDeletedDataTTLDays()); err != nil {
+	if err := cleanupService.CreateSoftDeletedDataCleanupJob(publishedRepository, migrationRunRepository, deletedDataCleanupRepository, systemInfoService.GetInstanceId(), systemInfoService.GetSoftDeletedDataCleanupSchedule(), systemInfoService.GetSoftDeletedDataCleanupTimeout(), systemInfoService.GetSoftDeletedDataTTLDays()); err != nil {
 		log.Error("Failed to start soft deleted data cleaning job" + err.Error())
 	}
-	if err := cleanupService.CreateUnreferencedDataCleanupJob(migrationRunRepository, unreferencedDataCleanupRepository, lockService, systemInfoService.GetInstanceId(), systemInfoService.GetUnreferencedDataCleanupSchedule(), systemInfoService.GetUnreferencedDataCleanupTimeout()); err != nil {
+	if err := cleanupService.CreateUnreferencedDataCleanupJob(migrationRunRepository, unreferencedDataCleanupRepository, systemInfoService.GetInstanceId(), systemInfoService.GetUnreferencedDataCleanupSchedule(), systemInfoService.GetUnreferencedDataCleanupTimeout()); err != nil {
 		log.Error("Failed to start unreferenced data cleaning job" + err.Error())
 	}
-	if err := cleanupService.CreateMaintenanceVacuumCleanupJob(migrationRunRepository, lockService, systemInfoService.GetInstanceId(), systemInfoService.GetMaintenanceVacuumCleanupSchedule(), systemInfoService.GetMaintenanceVacuumCleanupTimeout()); err != nil {
+	if err := cleanupService.CreateMaintenanceVacuumCleanupJob(migrationRunRepository, systemInfoService.GetInstanceId(), systemInfoService.GetMaintenanceVacuumCleanupSchedule(), systemInfoService.GetMaintenanceVacuumCleanupTimeout()); err != nil {
 		log.Error("Failed to start maintenance vacuum cleaning job" + err.Error())
 	}
 
 	packageVersionEnrichmentService := service.NewPackageVersionEnrichmentService(publishedRepository)
 	activityTrackingService := service.NewActivityTrackingService(activityTrackingRepository, publishedRepository, userService)
 	operationService := service.NewOperationService(operationRepository, publishedRepository, packageVersionEnrichmentService)
-	roleService := service.NewRoleService(roleRepository, userService, activityTrackingService, publishedRepository)
+	deprecationService := service.NewDeprecationService(deprecationPolicyRepository, publishedRepository, activityTrackingService, backgroundJobService)
+	if err := deprecationService.StartSunsetAlertsJob(systemInfoService.GetSunsetAlertsSchedule()); err != nil {
+		log.Warnf("Failed to start sunset alerts job: %v", err)
+	}
+	roleService := service.NewRoleService(roleRepository, tenantRepository, userService, activityTrackingService, publishedRepository)
+	tenantService := service.NewTenantService(tenantRepository, publishedRepository, systemStatsRepository, roleService, userService)
+	if err := tenantService.StartStorageUsageJob(backgroundJobService); err != nil {
+		log.Errorf("Failed to start workspace storage usage job: %v", err)
+	}
 	ptHandler := service.NewPackageTransitionHandler(transitionRepository)
 	publishNotificationService := service.NewPublishNotificationService(olricProvider)
-	publishedService := service.NewPublishedService(publishedRepository, buildRepository, favoritesRepository, operationRepository, activityTrackingService, monitoringService, minioStorageService, systemInfoService, publishNotificationService)
+	responseCacheService := service.NewResponseCacheService(responseCache)
+	responseCacheService.ListenVersionEvents(publishNotificationService)
+	readReplicaRoutingService := service.NewReadReplicaRoutingService(cp)
+	readReplicaRoutingService.ListenVersionPublished(publishNotificationService)
+	publishedService := service.NewPublishedService(publishedRepository, buildRepository, favoritesRepository, operationRepository, activityTrackingService, monitoringService, blobStorageService, systemInfoService, publishNotificationService, deprecationService)
 	portalService := service.NewPortalService(basePath, publishedService, publishedRepository)
 
-	operationGroupService := service.NewOperationGroupService(operationRepository, publishedRepository, exportRepository, packageVersionEnrichmentService, activityTrackingService)
-	versionService := service.NewVersionService(favoritesRepository, publishedRepository, publishedService, operationRepository, exportRepository, operationService, activityTrackingService, systemInfoService, packageVersionEnrichmentService, portalService, versionCleanupRepository, operationGroupService, monitoringService, roleService)
-	packageService := service.NewPackageService(favoritesRepository, publishedRepository, versionService, roleService, activityTrackingService, monitoringService, operationGroupService, usersRepository, ptHandler, systemInfoService)
+	operationGroupService := service.NewOperationGroupService(operationRepository, publishedRepository, exportRepository, packageVersionEnrichmentService, activityTrackingService, blobStorageService)
+	versionService := service.NewVersionService(favoritesRepository, publishedRepository, publishedService, operationRepository, exportRepository, operationService, activityTrackingService, systemInfoService, packageVersionEnrichmentService, portalService, versionCleanupRepository, operationGroupService, monitoringService, roleService, aiSpecReviewRepository)
+	operationConsumerService := service.NewOperationConsumerService(operationConsumerRepository, apihubApiKeyRepository, versionService)
+	dependencyGraphService := service.NewDependencyGraphService(dependencyGraphRepository, publishedRepository, packageVersionEnrichmentService, roleService)
+	packageService := service.NewPackageService(favoritesRepository, publishedRepository, versionService, roleService, activityTrackingService, monitoringService, operationGroupService, usersRepository, ptHandler, systemInfoService, publishNotificationService, tenantService)
 
 	logsService := service.NewLogsService()
 	apihubApiKeyService := service.NewApihubApiKeyService(apihubApiKeyRepository, publishedRepository, activityTrackingService, userService, roleRepository, roleService.IsSysadm, systemInfoService)
 
 	refResolverService := service.NewRefResolverService(publishedRepository)
 	buildProcessorService := service.NewBuildProcessorService(buildRepository, refResolverService)
-	buildService := service.NewBuildService(buildRepository, buildProcessorService, publishedService, systemInfoService, packageService, refResolverService)
+	buildService := service.NewBuildService(buildRepository, buildProcessorService, publishedService, systemInfoService, packageService, refResolverService, shutdownService, tenantService)
+	dashboardTrackingService := service.NewDashboardTrackingService(dashboardTrackingRepository, publishedRepository, buildService)
+	dashboardTrackingService.ListenVersionPublished(publishNotificationService)
 
 	packageExportConfigService := service.NewPackageExportConfigService(packageExportConfigRepository, packageService)
 
-	exportService := service.NewExportService(exportRepository, buildService, packageExportConfigService)
+	exportService := service.NewExportService(exportRepository, buildService, packageExportConfigService, blobStorageService, backgroundJobService, operationGroupService)
+	if err := exportService.StartCleanupOldResultsJob(); err != nil {
+		log.Error("Failed to start export results cleanup job: " + err.Error())
+	}
 
-	buildResultService := service.NewBuildResultService(buildResultRepository, buildRepository, publishedRepository, systemInfoService, minioStorageService, publishedService, exportService)
+	buildResultService := service.NewBuildResultService(buildResultRepository, buildRepository, publishedRepository, systemInfoService, blobStorageService, publishedService, exportService)
 	versionService.SetBuildService(buildService)
 	operationGroupService.SetBuildService(buildService)
 
-	excelService := service.NewExcelService(publishedRepository, versionService, operationService, packageService)
+	excelService := service.NewExcelService(publishedRepository, versionService, operationService, packageService, deprecationService)
+	changelogReportService := service.NewChangelogReportService(publishedRepository, versionService)
 	comparisonService := service.NewComparisonService(publishedRepository, operationRepository, packageVersionEnrichmentService)
+	savedComparisonService := service.NewSavedComparisonService(savedComparisonRepository, publishedRepository, comparisonService, buildService, versionService, roleService)
+	savedComparisonService.ListenVersionPublished(publishNotificationService)
+	operationHistoryService := service.NewOperationHistoryService(operationHistoryRepository, publishedRepository)
 	businessMetricService := service.NewBusinessMetricService(businessMetricRepository)
 
-	dbCleanupService := service.NewDBCleanupService(buildCleanupRepository, migrationRunRepository, minioStorageService, systemInfoService)
+	dbCleanupService := service.NewDBCleanupService(buildCleanupRepository, migrationRunRepository, blobStorageService, systemInfoService, backgroundJobService)
 	if err := dbCleanupService.CreateCleanupJob(systemInfoService.GetBuildsCleanupSchedule()); err != nil {
 		log.Error("Failed to start cleaning job" + err.Error())
 	}
@@ -289,13 +377,18 @@ func main() {
 	mcpService := service.NewMCPService(systemInfoService, operationService, packageService, versionService, monitoringService, roleService)
 
 	ephemeralFileRepository := repository.NewEphemeralFileRepositoryPG(cp)
-	ephemeralFileService := service.NewEphemeralFileService(systemInfoService, ephemeralFileRepository)
+	ephemeralFileService := service.NewEphemeralFileService(systemInfoService, ephemeralFileRepository, blobStorageService)
 	ephemeralFileController := controller.NewEphemeralFileController(ephemeralFileService)
-	ephemeralFileCleanup := service.NewEphemeralFileCleanupService(ephemeralFileRepository, lockService)
+	ephemeralFileCleanup := service.NewEphemeralFileCleanupService(ephemeralFileRepository, backgroundJobService, blobStorageService)
 	if err := ephemeralFileCleanup.StartCleanupJob(systemInfoService.GetEphemeralFilesCleanupSchedule(), systemInfoService.GetEphemeralFileDirectory()); err != nil {
 		log.Warnf("Failed to start ephemeral files cleanup: %v", err)
 	}
 
+	operationDataCompressionService := service.NewOperationDataCompressionService(repository.NewOperationDataCompressionRepositoryPG(cp), backgroundJobService)
+	if err := operationDataCompressionService.StartCompressionJob(); err != nil {
+		log.Warnf("Failed to start operation data compression: %v", err)
+	}
+
 	aiChatEnabled := isAiChatEnabled(systemInfoService)
 	var aiChatController *controller.AiChatController
 	if aiChatEnabled {
@@ -310,15 +403,29 @@ func main() {
 		if err != nil {
 			log.Fatalf("Failed to create AiChatTurnService: %v", err)
 		}
-		aiChatController = controller.NewAiChatController(aiChatsService, aiChatTurnService, monitoringService)
-		aiChatCleanup := service.NewAiChatCleanupService(aiChatRepository, lockService)
+		aiChatShareService := service.NewAiChatShareService(aiChatRepository, roleService, ephemeralFileService)
+		aiChatController = controller.NewAiChatController(aiChatsService, aiChatTurnService, monitoringService, aiChatShareService, shutdownService)
+		aiChatCleanup := service.NewAiChatCleanupService(aiChatRepository, backgroundJobService)
 		aiCfg := systemInfoService.GetAiChatConfig()
 		if err := aiChatCleanup.StartChatRetentionJob(aiCfg.CleanupSchedule, aiCfg.RetentionDays, aiCfg.PinnedForeverCount); err != nil {
 			log.Warnf("Failed to start ai chat retention cleanup: %v", err)
 		}
 	}
 
-	idpManager, err := providers.NewIDPManager(systemInfoService.GetAuthConfig(), systemInfoService.GetAllowedHosts(), systemInfoService.IsProductionMode(), userService)
+	aiSpecReviewEnabled := systemInfoService.GetAiSpecReviewConfig().Enabled
+	var aiSpecReviewController controller.AiSpecReviewController
+	if aiSpecReviewEnabled {
+		log.Info("ai-spec-review: routes and version published listener are ENABLED")
+		aiSpecReviewLlmClient, err := client.NewOpenAILlmClient(systemInfoService.GetAiChatConfig().OpenAI)
+		if err != nil {
+			log.Fatalf("Failed to create OpenAI LLM client for AI spec review: %v", err)
+		}
+		aiSpecReviewService := service.NewAiSpecReviewService(systemInfoService.GetAiSpecReviewConfig(), aiSpecReviewRepository, publishedRepository, packageService, tenantService, aiSpecReviewLlmClient)
+		aiSpecReviewService.ListenVersionPublished(publishNotificationService)
+		aiSpecReviewController = controller.NewAiSpecReviewController(roleService, aiSpecReviewService, ptHandler)
+	}
+
+	idpManager, err := providers.NewIDPManager(systemInfoService.GetAuthConfig(), systemInfoService.GetAllowedHosts, systemInfoService.IsProductionMode(), userService)
 	if err != nil {
 		log.Error("Failed to initialize external IDP: " + err.Error())
 		panic("Failed to initialize external IDP: " + err.Error())
@@ -327,14 +434,22 @@ func main() {
 	publishedController := controller.NewPublishedController(publishedService, portalService, roleService)
 
 	logsController := controller.NewLogsController(logsService, roleService)
-	systemInfoController := controller.NewSystemInfoController(systemInfoService, dbMigrationService)
+	systemInfoController := controller.NewSystemInfoController(systemInfoService, dbMigrationService, runtimeSettingsService)
 	sysAdminController := controller.NewSysAdminController(roleService)
 	apihubApiKeyController := controller.NewApihubApiKeyController(apihubApiKeyService, roleService)
 	cleanupController := controller.NewCleanupController(cleanupService)
+	retentionPolicyService := cleanup.NewRetentionPolicyService(cleanupRetentionRepository, publishedRepository)
+	cleanupAdminController := controller.NewCleanupAdminController(cleanupService, retentionPolicyService, roleService)
+	backgroundJobAdminController := controller.NewBackgroundJobAdminController(backgroundJobService, roleService)
+	tenantController := controller.NewTenantController(tenantService, roleService)
+	runtimeSettingsController := controller.NewRuntimeSettingsController(runtimeSettingsService, roleService)
+	blobMigrationRepository := repository.NewBlobMigrationRepository(cp)
+	blobMigrationService := service.NewBlobMigrationService(blobMigrationRepository, blobStorageService, systemInfoService)
+	blobStorageAdminController := controller.NewBlobStorageAdminController(blobStorageService, blobMigrationService, roleService)
 
 	playgroundProxyController := controller.NewPlaygroundProxyController(systemInfoService)
 	publishV2Controller := controller.NewPublishV2Controller(buildService, publishedService, buildResultService, roleService, systemInfoService)
-	exportController := controller.NewExportController(publishedService, portalService, roleService, excelService, versionService, monitoringService, exportService, packageService)
+	exportController := controller.NewExportController(publishedService, portalService, roleService, excelService, versionService, monitoringService, exportService, packageService, changelogReportService)
 
 	packageController := controller.NewPackageController(packageService, publishedService, portalService, roleService, monitoringService, ptHandler)
 	versionController := controller.NewVersionController(versionService, roleService, monitoringService, ptHandler, roleService.IsSysadm, excelService, systemInfoService.GetShareabilityReportSizeLimitMB())
@@ -348,7 +463,14 @@ func main() {
 	operationGroupController := controller.NewOperationGroupController(roleService, operationGroupService, versionService, systemInfoService)
 	searchController := controller.NewSearchController(operationService, versionService, monitoringService)
 	dataMigrationController := mController.NewTempMigrationController(dbMigrationService, roleService.IsSysadm)
+	schemaMigrationController := mController.NewSchemaMigrationController(dbMigrationService, roleService.IsSysadm)
 	activityTrackingController := controller.NewActivityTrackingController(activityTrackingService, roleService, ptHandler)
+	deprecationController := controller.NewDeprecationController(roleService, deprecationService, ptHandler)
+	operationConsumerController := controller.NewOperationConsumerController(roleService, operationConsumerService, ptHandler)
+	dependencyGraphController := controller.NewDependencyGraphController(roleService, dependencyGraphService, ptHandler)
+	dashboardTrackingController := controller.NewDashboardTrackingController(roleService, dashboardTrackingService, ptHandler)
+	savedComparisonController := controller.NewSavedComparisonController(savedComparisonService, excelService)
+	operationHistoryController := controller.NewOperationHistoryController(roleService, operationHistoryService, ptHandler)
 	comparisonController := controller.NewComparisonController(operationService, versionService, buildService, roleService, comparisonService, monitoringService, ptHandler)
 	transitionController := controller.NewTransitionController(transitionService, roleService.IsSysadm)
 	businessMetricController := controller.NewBusinessMetricController(businessMetricService, excelService, roleService.IsSysadm)
@@ -360,7 +482,7 @@ func main() {
 	internalDocsController := controller.NewInternalDocumentController(publishedService, roleService)
 	mcpController := controller.NewMCPController(mcpService)
 	buildController := controller.NewBuildController(buildResultService, buildService, roleService.IsSysadm)
-	adminPublishedController := controller.NewAdminPublishedController(publishedService, roleService.IsSysadm, systemInfoService.GetPublishArchiveSizeLimitMB())
+	adminPublishedController := controller.NewAdminPublishedController(publishedService, roleService.IsSysadm, systemInfoService.GetPublishArchiveSizeLimitMB)
 
 	r.HandleFunc("/api/v1/system/info", security.Secure(systemInfoController.GetSystemInfo)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v1/system/configuration", samlAuthController.GetSystemSSOInfo_deprecated).Methods(http.MethodGet) //deprecated
@@ -501,6 +623,10 @@ func main() {
 	r.HandleFunc("/api/v2/packages/{packageId}/availableRoles", security.Secure(roleController.GetAvailablePackageRoles)).Methods(http.MethodGet)
 
 	r.HandleFunc("/api/internal/migrate/operations", security.Secure(dataMigrationController.StartOpsMigration)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/admin/schemaMigrations", security.Secure(schemaMigrationController.GetSchemaMigrations)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/schemaMigrations/background/{num}", security.Secure(schemaMigrationController.UpdateBackgroundMigrationThrottling)).Methods(http.MethodPatch)
+	r.HandleFunc("/api/v2/admin/schemaMigrations/background/{num}/pause", security.Secure(schemaMigrationController.PauseBackgroundMigration)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/admin/schemaMigrations/background/{num}/resume", security.Secure(schemaMigrationController.ResumeBackgroundMigration)).Methods(http.MethodPost)
 	r.HandleFunc("/api/internal/migrate/operations/{migrationId}", security.Secure(dataMigrationController.GetMigrationReport)).Methods(http.MethodGet)
 	r.HandleFunc("/api/internal/migrate/operations/{migrationId}/suspiciousBuilds", security.Secure(dataMigrationController.GetSuspiciousBuilds)).Methods(http.MethodGet)
 	r.HandleFunc("/api/internal/migrate/operations/{migrationId}/perf", security.Secure(dataMigrationController.GetMigrationPerfReport)).Methods(http.MethodGet)
@@ -517,6 +643,46 @@ func main() {
 	r.HandleFunc("/api/v2/admin/packages/{packageId}/versions/{version}/sources", security.Secure(adminPublishedController.ReplaceVersionSources)).Methods(http.MethodPut)
 
 	r.HandleFunc("/api/v2/admin/system/stats", security.Secure(systemStatsController.GetSystemStats)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/system/storage", security.Secure(systemStatsController.GetStorageUsage)).Methods(http.MethodGet)
+
+	r.HandleFunc("/api/v2/admin/cleanup/retentionPolicies", security.Secure(cleanupAdminController.GetRetentionPolicies)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/cleanup/retentionPolicies/{packageId}/{dataClass}", security.Secure(cleanupAdminController.SetRetentionPolicy)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v2/admin/cleanup/retentionPolicies/{packageId}/{dataClass}", security.Secure(cleanupAdminController.DeleteRetentionPolicy)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v2/admin/cleanup/jobs/history", security.Secure(cleanupAdminController.GetJobRuns)).Methods(http.MethodGet)
+
+	r.HandleFunc("/api/v2/admin/jobs", security.Secure(backgroundJobAdminController.GetJobs)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/jobs/history", security.Secure(backgroundJobAdminController.GetJobRuns)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/jobs/{jobName}/run", security.Secure(backgroundJobAdminController.TriggerJob)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/admin/jobs/{jobName}/disable", security.Secure(backgroundJobAdminController.DisableJob)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/admin/jobs/{jobName}/enable", security.Secure(backgroundJobAdminController.EnableJob)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/admin/jobs/{jobName}/cancel", security.Secure(backgroundJobAdminController.CancelJob)).Methods(http.MethodPost)
+
+	r.HandleFunc("/api/v2/admin/tenants/usage", security.Secure(tenantController.GetTenantsUsage)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/tenants/{workspaceId}/quotas", security.Secure(tenantController.UpdateQuotas)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v2/workspaces/{workspaceId}/tenant", security.Secure(tenantController.GetTenant)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/workspaces/{workspaceId}/tenant/settings", security.Secure(tenantController.UpdateSettings)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v2/workspaces/{workspaceId}/tenant/usage", security.Secure(tenantController.GetUsage)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/workspaces/{workspaceId}/tenant/admins", security.Secure(tenantController.GetAdmins)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/workspaces/{workspaceId}/tenant/admins", security.Secure(tenantController.AddAdmins)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/workspaces/{workspaceId}/tenant/admins/{userId}", security.Secure(tenantController.DeleteAdmin)).Methods(http.MethodDelete)
+
+	r.HandleFunc("/api/v2/admin/settings", security.Secure(runtimeSettingsController.GetSettings)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/settings/history", security.Secure(runtimeSettingsController.GetHistory)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/settings/{key}", security.Secure(runtimeSettingsController.UpdateSetting)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v2/admin/settings/{key}", security.Secure(runtimeSettingsController.ResetSetting)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v2/admin/systemNotifications", security.Secure(runtimeSettingsController.GetNotifications)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/systemNotifications", security.Secure(runtimeSettingsController.CreateNotification)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/admin/systemNotifications/{id}", security.Secure(runtimeSettingsController.UpdateNotification)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v2/admin/systemNotifications/{id}", security.Secure(runtimeSettingsController.DeleteNotification)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v2/admin/cleanup/jobs/{jobType}/dryRun", security.Secure(cleanupAdminController.StartDryRun)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/admin/cleanup/dryRuns/{runId}", security.Secure(cleanupAdminController.GetDryRun)).Methods(http.MethodGet)
+
+	r.HandleFunc("/api/v2/admin/blobStorage", security.Secure(blobStorageAdminController.GetBlobStorageInfo)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/blobStorage/migrations", security.Secure(blobStorageAdminController.GetMigrations)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/blobStorage/migrations", security.Secure(blobStorageAdminController.StartMigration)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/admin/blobStorage/migrations/{runId}", security.Secure(blobStorageAdminController.GetMigration)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/blobStorage/migrations/{runId}/cancel", security.Secure(blobStorageAdminController.CancelMigration)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/admin/blobStorage/migrations/{runId}/resume", security.Secure(blobStorageAdminController.ResumeMigration)).Methods(http.MethodPost)
 
 	r.HandleFunc("/api/v2/compare", security.Secure(comparisonController.CompareTwoVersions)).Methods(http.MethodPost)
 
@@ -585,6 +751,49 @@ func main() {
 		r.HandleFunc("/api/v1/ai-chat/chats/{chatId}/messages", security.Secure(aiChatController.ListMessages)).Methods(http.MethodGet)
 		r.HandleFunc("/api/v1/ai-chat/chats/{chatId}/messages", security.Secure(aiChatController.SendMessage)).Methods(http.MethodPost)
 		r.HandleFunc("/api/v1/ai-chat/chats/{chatId}/messages/stream", security.Secure(aiChatController.SendMessageStream)).Methods(http.MethodPost)
+		r.HandleFunc("/api/v1/ai-chat/chats/{chatId}/shares", security.Secure(aiChatController.ListShares)).Methods(http.MethodGet)
+		r.HandleFunc("/api/v1/ai-chat/chats/{chatId}/shares", security.Secure(aiChatController.CreateShare)).Methods(http.MethodPost)
+		r.HandleFunc("/api/v1/ai-chat/chats/{chatId}/export", security.Secure(aiChatController.ExportChat)).Methods(http.MethodGet)
+		r.HandleFunc("/api/v1/ai-chat/shares/{shareId}", security.Secure(aiChatController.GetSharedChat)).Methods(http.MethodGet)
+		r.HandleFunc("/api/v1/ai-chat/shares/{shareId}", security.Secure(aiChatController.DeleteShare)).Methods(http.MethodDelete)
+		r.HandleFunc("/api/v1/ai-chat/shares/{shareId}/export", security.Secure(aiChatController.ExportSharedChat)).Methods(http.MethodGet)
+		r.HandleFunc("/api/v1/ai-chat/shares/{shareId}/files/{fileId}", security.Secure(aiChatController.DownloadSharedFile)).Methods(http.MethodGet)
+	}
+
+	r.HandleFunc("/api/v1/packages/{packageId}/{apiType}/operations/{operationId}/deprecationPolicy", security.Secure(deprecationController.GetPolicy)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/packages/{packageId}/{apiType}/operations/{operationId}/deprecationPolicy", security.Secure(deprecationController.SetPolicy)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v1/packages/{packageId}/{apiType}/operations/{operationId}/deprecationPolicy", security.Secure(deprecationController.DeletePolicy)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v1/workspaces/{workspaceId}/sunsetCalendar", security.Secure(deprecationController.GetSunsetCalendar)).Methods(http.MethodGet)
+
+	r.HandleFunc("/api/v1/packages/{packageId}/consumedOperations", security.Secure(operationConsumerController.GetPackageConsumedOperations)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/packages/{packageId}/consumedOperations", security.Secure(operationConsumerController.SetPackageConsumedOperations)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v1/packages/{packageId}/apiKeys/{id}/consumedOperations", security.Secure(operationConsumerController.GetApiKeyConsumedOperations)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/packages/{packageId}/apiKeys/{id}/consumedOperations", security.Secure(operationConsumerController.SetApiKeyConsumedOperations)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v1/packages/{packageId}/consumers", security.Secure(operationConsumerController.GetOperationConsumers)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/packages/{packageId}/versions/{version}/consumerImpact", security.Secure(operationConsumerController.GetConsumerImpact)).Methods(http.MethodGet)
+
+	r.HandleFunc("/api/v1/packages/{packageId}/versions/{version}/dependencyGraph", security.Secure(dependencyGraphController.GetDependencyGraph)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/packages/{packageId}/versions/{version}/dependents", security.Secure(dependencyGraphController.GetDependents)).Methods(http.MethodGet)
+
+	r.HandleFunc("/api/v1/packages/{packageId}/versions/{version}/tracking", security.Secure(dashboardTrackingController.GetTracking)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/packages/{packageId}/versions/{version}/tracking", security.Secure(dashboardTrackingController.SetTracking)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v1/packages/{packageId}/versions/{version}/tracking", security.Secure(dashboardTrackingController.DeleteTracking)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v1/packages/{packageId}/rollForwards", security.Secure(dashboardTrackingController.GetRollForwards)).Methods(http.MethodGet)
+
+	r.HandleFunc("/api/v1/savedComparisons", security.Secure(savedComparisonController.CreateSavedComparison)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v1/savedComparisons", security.Secure(savedComparisonController.GetSavedComparisons)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/savedComparisons/{comparisonId}", security.Secure(savedComparisonController.GetSavedComparison)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/savedComparisons/{comparisonId}", security.Secure(savedComparisonController.UpdateSavedComparison)).Methods(http.MethodPatch)
+	r.HandleFunc("/api/v1/savedComparisons/{comparisonId}", security.Secure(savedComparisonController.DeleteSavedComparison)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v1/savedComparisons/{comparisonId}/export", security.Secure(savedComparisonController.ExportSavedComparison)).Methods(http.MethodGet)
+
+	r.HandleFunc("/api/v1/packages/{packageId}/operations/{operationId}/history", security.Secure(operationHistoryController.GetOperationHistory)).Methods(http.MethodGet)
+
+	if aiSpecReviewEnabled {
+		r.HandleFunc("/api/v1/packages/{packageId}/aiReviewConfig", security.Secure(aiSpecReviewController.GetConfig)).Methods(http.MethodGet)
+		r.HandleFunc("/api/v1/packages/{packageId}/aiReviewConfig", security.Secure(aiSpecReviewController.SetConfig)).Methods(http.MethodPatch)
+		r.HandleFunc("/api/v1/packages/{packageId}/versions/{version}/aiReview", security.Secure(aiSpecReviewController.GetReview)).Methods(http.MethodGet)
+		r.HandleFunc("/api/v1/packages/{packageId}/versions/{version}/aiReview", security.Secure(aiSpecReviewController.StartReview)).Methods(http.MethodPost)
 	}
 
 	mcpHandler := mcpController.MakeMCPServer()
@@ -683,13 +892,35 @@ func main() {
 		})
 	}
 
-	utils.SafeAsync(func() {
-		exportService.StartCleanupOldResultsJob()
-	})
+	dbMigrationService.StartOpsMigrationRestoreProc(shutdownService.StoppingContext())
 
-	dbMigrationService.StartOpsMigrationRestoreProc(context.Background())
+	// hooks are executed after the drain in the registration order, locks are released last since the other steps may still use them
+	shutdownService.RegisterHook("monitoring flush", monitoringService.Flush)
+	shutdownService.RegisterHook("operations migrations hand-off", dbMigrationService.HandOffMigrations)
+	shutdownService.RegisterHook("locks release", lockService.ReleaseAllLocks)
 
-	log.Fatalf("Http server returned error: %v", srv.ListenAndServe())
+	serverErrChan := make(chan error, 1)
+	go func() { // Do not use safe async here to enable panic
+		serverErrChan <- srv.ListenAndServe()
+	}()
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	select {
+	case err = <-serverErrChan:
+		if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+			log.Errorf("Failed to flush traces: %v", shutdownErr)
+		}
+		log.Fatalf("Http server returned error: %v", err)
+	case <-signalCtx.Done():
+		// the repeated signal terminates the process immediately
+		stopSignals()
+		log.Infof("Received %v signal", context.Cause(signalCtx))
+	}
+	if err = shutdownService.Shutdown(srv); err != nil {
+		log.Errorf("Graceful shutdown finished with errors: %v", err)
+	}
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		log.Errorf("Failed to flush traces: %v", shutdownErr)
+	}
 }
 
 func isAiChatEnabled(sis service.SystemInfoService) bool {
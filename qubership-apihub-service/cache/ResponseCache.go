@@ -0,0 +1,194 @@
+package cache
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/metrics"
+	"github.com/buraksezer/olric"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ResponseCacheDMapName            = "ResponseCache"
+	ResponseCacheGenerationsDMapName = "ResponseCacheGenerations"
+
+	responseCacheHit  = "hit"
+	responseCacheMiss = "miss"
+)
+
+// ResponseCache stores immutable published data in Olric DMaps shared by all instances of the cluster.
+// Entries of a package are keyed with the package generation, so all of them become unreachable when the generation is changed.
+type ResponseCache interface {
+	// Get reads the entry into dest. Returns false on a miss or if the cache is not available.
+	Get(region string, key string, dest interface{}) bool
+	Put(region string, key string, value interface{})
+	Delete(region string, keys ...string)
+	// PackageKey builds a key of the package entry which includes the current package generation
+	PackageKey(packageId string, parts ...interface{}) string
+	// InvalidatePackage changes the package generation. Setting the same generation several times is idempotent.
+	InvalidatePackage(packageId string, generation string)
+}
+
+func NewResponseCache(op OlricProvider, enabled bool, ttlSec int) ResponseCache {
+	c := &responseCacheImpl{
+		op:  op,
+		ttl: time.Duration(ttlSec) * time.Second,
+	}
+	if enabled {
+		go c.initWhenOlricReady()
+	} else {
+		log.Info("Response cache is disabled")
+	}
+	return c
+}
+
+type responseCacheImpl struct {
+	op          OlricProvider
+	ttl         time.Duration
+	mutex       sync.RWMutex
+	entries     *olric.DMap
+	generations *olric.DMap
+}
+
+func (c *responseCacheImpl) initWhenOlricReady() {
+	olricC := c.op.Get()
+	for {
+		entries, err := olricC.NewDMap(ResponseCacheDMapName)
+		if err == nil {
+			var generations *olric.DMap
+			generations, err = olricC.NewDMap(ResponseCacheGenerationsDMapName)
+			if err == nil {
+				c.mutex.Lock()
+				c.entries = entries
+				c.generations = generations
+				c.mutex.Unlock()
+				log.Infof("Response cache is ready")
+				return
+			}
+		}
+		log.Errorf("Failed to create response cache dmaps, going to retry: %s", err.Error())
+		time.Sleep(time.Second * 5)
+	}
+}
+
+func (c *responseCacheImpl) getDMaps() (*olric.DMap, *olric.DMap) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.entries, c.generations
+}
+
+func (c *responseCacheImpl) Get(region string, key string, dest interface{}) bool {
+	entries, _ := c.getDMaps()
+	if entries == nil {
+		return false
+	}
+	val, err := entries.Get(makeResponseCacheKey(region, key))
+	if err != nil {
+		if !errors.Is(err, olric.ErrKeyNotFound) {
+			log.Errorf("Failed to get %s entry from response cache: %s", region, err.Error())
+		}
+		metrics.ResponseCacheRequests.WithLabelValues(region, responseCacheMiss).Inc()
+		return false
+	}
+	data, ok := val.([]byte)
+	if !ok {
+		metrics.ResponseCacheRequests.WithLabelValues(region, responseCacheMiss).Inc()
+		return false
+	}
+	if err = json.Unmarshal(data, dest); err != nil {
+		log.Errorf("Failed to unmarshal %s entry from response cache: %s", region, err.Error())
+		metrics.ResponseCacheRequests.WithLabelValues(region, responseCacheMiss).Inc()
+		return false
+	}
+	metrics.ResponseCacheRequests.WithLabelValues(region, responseCacheHit).Inc()
+	return true
+}
+
+func (c *responseCacheImpl) Put(region string, key string, value interface{}) {
+	entries, _ := c.getDMaps()
+	if entries == nil {
+		return
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Errorf("Failed to marshal %s entry for response cache: %s", region, err.Error())
+		return
+	}
+	if err = c.put(entries, makeResponseCacheKey(region, key), data, c.ttl); err != nil {
+		log.Errorf("Failed to put %s entry to response cache: %s", region, err.Error())
+	}
+}
+
+func (c *responseCacheImpl) Delete(region string, keys ...string) {
+	entries, _ := c.getDMaps()
+	if entries == nil {
+		return
+	}
+	for _, key := range keys {
+		if err := entries.Delete(makeResponseCacheKey(region, key)); err != nil {
+			log.Errorf("Failed to delete %s entry from response cache: %s", region, err.Error())
+		}
+	}
+}
+
+func (c *responseCacheImpl) PackageKey(packageId string, parts ...interface{}) string {
+	_, generations := c.getDMaps()
+	generation := ""
+	if generations != nil {
+		val, err := generations.Get(packageId)
+		if err != nil && !errors.Is(err, olric.ErrKeyNotFound) {
+			log.Errorf("Failed to get response cache generation of package %s: %s", packageId, err.Error())
+		}
+		generation, _ = val.(string)
+	}
+	return makeResponseCachePackageKey(packageId, generation, parts...)
+}
+
+func (c *responseCacheImpl) InvalidatePackage(packageId string, generation string) {
+	_, generations := c.getDMaps()
+	if generations == nil {
+		return
+	}
+	// generation outlives the entries, so the entries put with the previous generation cannot become reachable again
+	if err := c.put(generations, packageId, generation, 2*c.ttl); err != nil {
+		log.Errorf("Failed to invalidate response cache of package %s: %s", packageId, err.Error())
+	}
+}
+
+// put stores the value without expiration if ttl is not set
+func (c *responseCacheImpl) put(dm *olric.DMap, key string, value interface{}, ttl time.Duration) error {
+	if ttl <= 0 {
+		return dm.Put(key, value)
+	}
+	return dm.PutEx(key, value, ttl)
+}
+
+func makeResponseCacheKey(region string, key string) string {
+	return region + "|" + key
+}
+
+func makeResponseCachePackageKey(packageId string, generation string, parts ...interface{}) string {
+	keyParts := make([]string, 0, len(parts)+2)
+	keyParts = append(keyParts, packageId, generation)
+	for _, part := range parts {
+		keyParts = append(keyParts, fmt.Sprint(part))
+	}
+	return strings.Join(keyParts, "|")
+}
+
+// HashResponseCacheKeyPart returns a short stable representation of the complex key part, e.g. search request.
+func HashResponseCacheKeyPart(part interface{}) string {
+	data, err := json.Marshal(part)
+	if err != nil {
+		return fmt.Sprint(part)
+	}
+	hash := sha1.Sum(data)
+	return hex.EncodeToString(hash[:])
+}
@@ -47,7 +47,7 @@ func NewOpenAILlmClient(cfg config.OpenAIConfig) (LlmClient, error) {
 	}
 
 	c := openai.NewClient(opts...)
-	return &OpenAILlmClient{client: c, cfg: cfg}, nil
+	return NewTracingLlmClient(&OpenAILlmClient{client: c, cfg: cfg}, "openai", cfg.Model), nil
 }
 
 func (c *OpenAILlmClient) Execute(ctx context.Context, req LLMRequest) (*LLMResponse, error) {
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// attributes follow OpenTelemetry GenAI semantic conventions
+const (
+	genAiSystemKey            = attribute.Key("gen_ai.system")
+	genAiRequestModelKey      = attribute.Key("gen_ai.request.model")
+	genAiUsageInputTokensKey  = attribute.Key("gen_ai.usage.input_tokens")
+	genAiUsageOutputTokensKey = attribute.Key("gen_ai.usage.output_tokens")
+	llmToolCallsKey           = attribute.Key("apihub.llm.tool_calls")
+	llmStreamingKey           = attribute.Key("apihub.llm.streaming")
+)
+
+// NewTracingLlmClient wraps the client with a span for each LLM request
+func NewTracingLlmClient(client LlmClient, system string, model string) LlmClient {
+	return &tracingLlmClient{LlmClient: client, system: system, model: model}
+}
+
+type tracingLlmClient struct {
+	LlmClient
+	system string
+	model  string
+}
+
+func (c *tracingLlmClient) Execute(ctx context.Context, req LLMRequest) (*LLMResponse, error) {
+	ctx, span := c.startSpan(ctx, false)
+	resp, err := c.LlmClient.Execute(ctx, req)
+	c.endSpan(span, resp, err)
+	return resp, err
+}
+
+func (c *tracingLlmClient) ExecuteStreaming(
+	ctx context.Context,
+	req LLMRequest,
+	onDelta func(delta string),
+	onToolStart func(callID, name string),
+) (*LLMResponse, error) {
+	ctx, span := c.startSpan(ctx, true)
+	resp, err := c.LlmClient.ExecuteStreaming(ctx, req, onDelta, onToolStart)
+	c.endSpan(span, resp, err)
+	return resp, err
+}
+
+func (c *tracingLlmClient) startSpan(ctx context.Context, streaming bool) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "chat "+c.model,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(genAiSystemKey.String(c.system), genAiRequestModelKey.String(c.model), llmStreamingKey.Bool(streaming)))
+}
+
+func (c *tracingLlmClient) endSpan(span trace.Span, resp *LLMResponse, err error) {
+	if resp != nil {
+		span.SetAttributes(
+			genAiUsageInputTokensKey.Int(resp.Usage.PromptTokens),
+			genAiUsageOutputTokensKey.Int(resp.Usage.CompletionTokens),
+			llmToolCallsKey.Int(len(resp.ToolCalls)))
+	}
+	tracing.EndSpan(span, err)
+}
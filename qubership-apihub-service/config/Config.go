@@ -17,6 +17,7 @@ type Config struct {
 	BusinessParameters   BusinessParameters
 	Monitoring           MonitoringConfig
 	S3Storage            S3Config
+	BlobStorage          BlobStorageConfig
 	Olric                OlricConfig
 	Cleanup              CleanupConfig
 	Extensions           []view.Extension
@@ -25,11 +26,14 @@ type Config struct {
 }
 
 type DatabaseConfig struct {
-	Host     string `validate:"required"`
-	Port     int    `validate:"required"`
-	Name     string `validate:"required"`
-	Username string `validate:"required"`
-	Password string `validate:"required" sensitive:"true"`
+	Host                 string   `validate:"required"`
+	Port                 int      `validate:"required"`
+	Name                 string   `validate:"required"`
+	Username             string   `validate:"required"`
+	Password             string   `validate:"required" sensitive:"true"`
+	ReadReplicaUrls      []string `sensitive:"true"`
+	ReplicaMaxLagSec     int      `validate:"gte=0"`
+	PrimaryStickinessSec int      `validate:"gte=0"`
 }
 
 type SecurityConfig struct {
@@ -95,6 +99,13 @@ type TechnicalParameters struct {
 	ApiSpecDirectory            string
 	MigrationLockMaxWaitMinutes int
 	EphemeralFileDirectory      string
+	Shutdown                    ShutdownConfig
+}
+
+type ShutdownConfig struct {
+	ReadinessDelaySec int `validate:"gte=0"`
+	DrainTimeoutSec   int `validate:"gt=0"`
+	HooksTimeoutSec   int `validate:"gt=0"`
 }
 
 type BusinessParameters struct {
@@ -105,14 +116,23 @@ type BusinessParameters struct {
 	PublishFileSizeLimitMb        int `validate:"gt=0,lte=8796093022207"` //validation was added based on security scan results to avoid integer overflow, 8796093022207 * 1048576 is safely below MaxInt64
 	TemplateSizeLimitMb           int `validate:"gt=0,lte=8796093022207"` //validation was added based on security scan results to avoid integer overflow, 8796093022207 * 1048576 is safely below MaxInt64
 	ShareabilityReportSizeLimitMb int `validate:"gt=0,lte=8796093022207"` //validation was added based on security scan results to avoid integer overflow, 8796093022207 * 1048576 is safely below MaxInt64
-	SystemNotification            string //TODO: replace with db impl
+	SystemNotification            string // shown when there is no active system notification stored in DB
 	FailBuildOnBrokenRefs         bool
 	EphemeralFileMaxSizeMb        int `validate:"gt=0,lte=8796093022207"` //validation was added based on security scan results to avoid integer overflow, 8796093022207 * 1048576 is safely below MaxInt64
 	EphemeralFileTTLMinutes       int `validate:"gt=0"`
+	SunsetAlertsSchedule          string
 }
 
 type MonitoringConfig struct {
 	Enabled bool
+	Tracing TracingConfig
+}
+
+type TracingConfig struct {
+	Enabled      bool
+	OtlpEndpoint string
+	ServiceName  string
+	SampleRatio  float64 `validate:"gte=0,lte=1"`
 }
 
 type S3Config struct {
@@ -125,10 +145,30 @@ type S3Config struct {
 	StoreOnlyBuildResult bool
 }
 
+// BlobStorageConfig selects where large blobs (build results, sources archives, export results, ephemeral files) are kept.
+// Operation data is always kept in the database since it is used by the database queries and the full text search.
+// If Backend is not set, S3 is used when S3Storage is enabled (respecting S3Storage.StoreOnlyBuildResult) and the database otherwise.
+// FallbackBackend is used to read blobs which were not moved to Backend by the blob migration yet.
+type BlobStorageConfig struct {
+	Backend         string `validate:"omitempty,oneof=postgres s3 filesystem"`
+	FallbackBackend string `validate:"omitempty,oneof=postgres s3 filesystem,nefield=Backend"`
+	Filesystem      BlobStorageFilesystemConfig
+}
+
+type BlobStorageFilesystemConfig struct {
+	RootDirectory string `validate:"required"`
+}
+
 type OlricConfig struct {
 	DiscoveryMode string
 	ReplicaCount  int
 	Namespace     string
+	ResponseCache ResponseCacheConfig
+}
+
+type ResponseCacheConfig struct {
+	Enabled bool
+	TTLSec  int `validate:"gte=0"`
 }
 
 type CleanupConfig struct {
@@ -146,8 +186,9 @@ type EphemeralFilesCleanupConfig struct {
 }
 
 type AIConfig struct {
-	MCP  MCPConfig
-	Chat ChatConfig
+	MCP        MCPConfig
+	Chat       ChatConfig
+	SpecReview SpecReviewConfig
 }
 
 type MCPConfig struct {
@@ -166,6 +207,15 @@ type ChatConfig struct {
 	CleanupSchedule         string
 }
 
+// SpecReviewConfig holds settings of the post-publish AI spec review. The review reuses the chat LLM client settings (Chat.OpenAI).
+// Packages must opt in individually; TokenBudget is the default per-version budget for packages that do not set their own.
+type SpecReviewConfig struct {
+	Enabled                bool
+	TokenBudget            int `validate:"gt=0"`
+	MaxOperations          int `validate:"gt=0"`
+	MaxOperationSpecLength int `validate:"gt=0"`
+}
+
 type OpenAIConfig struct {
 	ApiKey          string `sensitive:"true"`
 	Model           string
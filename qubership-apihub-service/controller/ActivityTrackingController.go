@@ -120,7 +120,7 @@ func (a activityTrackingControllerImpl) GetActivityHistory(w http.ResponseWriter
 	}
 	result, err := a.activityTrackingService.GetActivityHistory(context.Create(r), activityHistoryReq)
 	if err != nil {
-		log.Error("Failed to get activity events for favourite packages: ", err.Error())
+		log.WithContext(r.Context()).Error("Failed to get activity events for favourite packages: ", err.Error())
 		if customError, ok := err.(*exception.CustomError); ok {
 			utils.RespondWithCustomError(w, customError)
 		} else {
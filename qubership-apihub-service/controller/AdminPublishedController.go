@@ -16,18 +16,18 @@ type AdminPublishedController interface {
 	ReplaceVersionSources(w http.ResponseWriter, r *http.Request)
 }
 
-func NewAdminPublishedController(publishedService service.PublishedService, isSysadm func(ctx context.SecurityContext) bool, publishArchiveSizeLimit int64) AdminPublishedController {
+func NewAdminPublishedController(publishedService service.PublishedService, isSysadm func(ctx context.SecurityContext) bool, getPublishArchiveSizeLimit func() int64) AdminPublishedController {
 	return &adminPublishedControllerImpl{
-		publishedService:        publishedService,
-		isSysadm:                isSysadm,
-		publishArchiveSizeLimit: publishArchiveSizeLimit,
+		publishedService:           publishedService,
+		isSysadm:                   isSysadm,
+		getPublishArchiveSizeLimit: getPublishArchiveSizeLimit,
 	}
 }
 
 type adminPublishedControllerImpl struct {
-	publishedService        service.PublishedService
-	isSysadm                func(ctx context.SecurityContext) bool
-	publishArchiveSizeLimit int64
+	publishedService           service.PublishedService
+	isSysadm                   func(ctx context.SecurityContext) bool
+	getPublishArchiveSizeLimit func() int64
 }
 
 func (c adminPublishedControllerImpl) ReplaceVersionSources(w http.ResponseWriter, r *http.Request) {
@@ -41,12 +41,13 @@ func (c adminPublishedControllerImpl) ReplaceVersionSources(w http.ResponseWrite
 		return
 	}
 
-	if r.ContentLength > c.publishArchiveSizeLimit {
+	publishArchiveSizeLimit := c.getPublishArchiveSizeLimit()
+	if r.ContentLength > publishArchiveSizeLimit {
 		utils.RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusBadRequest,
 			Code:    exception.ArchiveSizeExceeded,
 			Message: exception.ArchiveSizeExceededMsg,
-			Params:  map[string]interface{}{"size": c.publishArchiveSizeLimit},
+			Params:  map[string]interface{}{"size": publishArchiveSizeLimit},
 		})
 		return
 	}
@@ -64,7 +65,7 @@ func (c adminPublishedControllerImpl) ReplaceVersionSources(w http.ResponseWrite
 		return
 	}
 
-	r.Body = http.MaxBytesReader(w, r.Body, c.publishArchiveSizeLimit)
+	r.Body = http.MaxBytesReader(w, r.Body, publishArchiveSizeLimit)
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		utils.RespondWithCustomError(w, &exception.CustomError{
@@ -85,7 +86,7 @@ func (c adminPublishedControllerImpl) ReplaceVersionSources(w http.ResponseWrite
 		return
 	}
 
-	log.Infof("Replacing published version sources: packageId=%s version=%s archiveSize=%d", packageId, versionName, len(body))
+	log.WithContext(r.Context()).Infof("Replacing published version sources: packageId=%s version=%s archiveSize=%d", packageId, versionName, len(body))
 
 	err = c.publishedService.ReplaceVersionSources(ctx, packageId, versionName, body)
 	if err != nil {
@@ -1,9 +1,12 @@
 package controller
 
 import (
+	stdctx "context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
@@ -18,10 +21,12 @@ type AiChatController struct {
 	chatsSvc      service.AiChatsService
 	aiSvc         service.AiChatTurnService
 	monitoringSvc service.MonitoringService
+	shareSvc      service.AiChatShareService
+	shutdownSvc   service.ShutdownService
 }
 
-func NewAiChatController(chatsSvc service.AiChatsService, aiSvc service.AiChatTurnService, monitoringSvc service.MonitoringService) *AiChatController {
-	return &AiChatController{chatsSvc: chatsSvc, aiSvc: aiSvc, monitoringSvc: monitoringSvc}
+func NewAiChatController(chatsSvc service.AiChatsService, aiSvc service.AiChatTurnService, monitoringSvc service.MonitoringService, shareSvc service.AiChatShareService, shutdownSvc service.ShutdownService) *AiChatController {
+	return &AiChatController{chatsSvc: chatsSvc, aiSvc: aiSvc, monitoringSvc: monitoringSvc, shareSvc: shareSvc, shutdownSvc: shutdownSvc}
 }
 
 func (c *AiChatController) ListChats(w http.ResponseWriter, r *http.Request) {
@@ -180,7 +185,11 @@ func (c *AiChatController) SendMessageStream(w http.ResponseWriter, r *http.Requ
 
 	c.monitoringSvc.IncreaseBusinessMetricCounter(uid, metrics.AIChatCalled, "chat messages")
 
-	ctx := r.Context()
+	// the stream is drained on shutdown and the turn is cancelled if it is not finished within the drain timeout
+	ctx, cancel := stdctx.WithCancelCause(r.Context())
+	defer cancel(nil)
+	stopAbort := stdctx.AfterFunc(c.shutdownSvc.AbortContext(), func() { cancel(service.ErrServiceShuttingDown) })
+	defer stopAbort()
 	ctx = service.SetSecCtxOnMCPCtx(ctx, secCtx)
 	ctx = service.SetMCPClientLabel(ctx, service.MCPClientLabelInternalAIChat)
 	ch, err := c.aiSvc.SendMessageStream(ctx, uid, chatID, &body)
@@ -190,9 +199,103 @@ func (c *AiChatController) SendMessageStream(w http.ResponseWriter, r *http.Requ
 	}
 
 	for item := range ch {
-		b, _ := json.Marshal(item.Data)
-		_, _ = io.WriteString(w, "event: "+item.EventName+"\n")
-		_, _ = io.WriteString(w, "data: "+string(b)+"\n\n")
-		fl.Flush()
+		writeAiChatSSEEvent(w, fl, item.EventName, item.Data)
+	}
+	if errors.Is(stdctx.Cause(ctx), service.ErrServiceShuttingDown) {
+		// the client can retry the message with the same clientMessageId on another instance
+		writeAiChatSSEEvent(w, fl, "error", map[string]interface{}{
+			"type":    "error",
+			"code":    exception.ServiceShuttingDown,
+			"message": exception.ServiceShuttingDownMsg,
+		})
+	}
+}
+
+func writeAiChatSSEEvent(w http.ResponseWriter, fl http.Flusher, eventName string, data interface{}) {
+	b, _ := json.Marshal(data)
+	_, _ = io.WriteString(w, "event: "+eventName+"\n")
+	_, _ = io.WriteString(w, "data: "+string(b)+"\n\n")
+	fl.Flush()
+}
+
+func (c *AiChatController) CreateShare(w http.ResponseWriter, r *http.Request) {
+	uid := context.Create(r).GetUserId()
+	chatID := getStringParam(r, "chatId")
+	res, err := c.shareSvc.CreateShare(r.Context(), uid, chatID)
+	if err != nil {
+		utils.RespondWithError(w, "create chat share", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusCreated, res)
+}
+
+func (c *AiChatController) ListShares(w http.ResponseWriter, r *http.Request) {
+	uid := context.Create(r).GetUserId()
+	chatID := getStringParam(r, "chatId")
+	res, err := c.shareSvc.ListChatShares(r.Context(), uid, chatID)
+	if err != nil {
+		utils.RespondWithError(w, "list chat shares", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, res)
+}
+
+func (c *AiChatController) DeleteShare(w http.ResponseWriter, r *http.Request) {
+	uid := context.Create(r).GetUserId()
+	shareID := getStringParam(r, "shareId")
+	if err := c.shareSvc.DeleteShare(r.Context(), uid, shareID); err != nil {
+		utils.RespondWithError(w, "delete chat share", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *AiChatController) GetSharedChat(w http.ResponseWriter, r *http.Request) {
+	shareID := getStringParam(r, "shareId")
+	res, err := c.shareSvc.GetSharedChat(r.Context(), context.Create(r), shareID)
+	if err != nil {
+		utils.RespondWithError(w, "get shared chat", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, res)
+}
+
+func (c *AiChatController) DownloadSharedFile(w http.ResponseWriter, r *http.Request) {
+	shareID := getStringParam(r, "shareId")
+	fileID := getStringParam(r, "fileId")
+	f, err := c.shareSvc.GetSharedFile(r.Context(), context.Create(r), shareID, fileID)
+	if err != nil {
+		utils.RespondWithError(w, "download shared chat file", err)
+		return
+	}
+	if f.MimeType != nil {
+		w.Header().Set("Content-Type", *f.MimeType)
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+escapeFilename(f.Filename)+"\"")
+	w.Header().Set("Content-Length", strconv.Itoa(len(f.Data)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(f.Data)
+}
+
+func (c *AiChatController) ExportChat(w http.ResponseWriter, r *http.Request) {
+	uid := context.Create(r).GetUserId()
+	chatID := getStringParam(r, "chatId")
+	res, err := c.shareSvc.ExportChat(r.Context(), uid, chatID, getAiChatExportFormatQueryParam(r))
+	if err != nil {
+		utils.RespondWithError(w, "export chat", err)
+		return
+	}
+	respondWithAiChatExport(w, res)
+}
+
+func (c *AiChatController) ExportSharedChat(w http.ResponseWriter, r *http.Request) {
+	shareID := getStringParam(r, "shareId")
+	res, err := c.shareSvc.ExportSharedChat(r.Context(), context.Create(r), shareID, getAiChatExportFormatQueryParam(r))
+	if err != nil {
+		utils.RespondWithError(w, "export shared chat", err)
+		return
 	}
+	respondWithAiChatExport(w, res)
 }
@@ -90,3 +90,18 @@ func decodeAiChatJSONBody(r *http.Request, dest interface{}, allowEOF bool) *exc
 	}
 	return aiChatBadRequestBodyErr(err)
 }
+
+func getAiChatExportFormatQueryParam(r *http.Request) string {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		return view.AiChatExportFormatMarkdown
+	}
+	return format
+}
+
+func respondWithAiChatExport(w http.ResponseWriter, res *aiservice.AiChatExportResult) {
+	w.Header().Set("Content-Type", res.ContentType)
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+escapeFilename(res.Filename)+"\"")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(res.Data)
+}
@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type AiSpecReviewController interface {
+	GetConfig(w http.ResponseWriter, r *http.Request)
+	SetConfig(w http.ResponseWriter, r *http.Request)
+	GetReview(w http.ResponseWriter, r *http.Request)
+	StartReview(w http.ResponseWriter, r *http.Request)
+}
+
+func NewAiSpecReviewController(roleService service.RoleService,
+	reviewSvc service.AiSpecReviewService,
+	ptHandler service.PackageTransitionHandler) AiSpecReviewController {
+	return aiSpecReviewControllerImpl{roleService: roleService, reviewSvc: reviewSvc, ptHandler: ptHandler}
+}
+
+type aiSpecReviewControllerImpl struct {
+	roleService service.RoleService
+	reviewSvc   service.AiSpecReviewService
+	ptHandler   service.PackageTransitionHandler
+}
+
+func (a aiSpecReviewControllerImpl) GetConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !a.checkPermission(w, r, ctx, packageId, view.ReadPermission) {
+		return
+	}
+
+	result, err := a.reviewSvc.GetConfig(r.Context(), packageId)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, a.ptHandler, packageId, "Failed to get AI review config", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (a aiSpecReviewControllerImpl) SetConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !a.checkPermission(w, r, ctx, packageId, view.CreateAndUpdatePackagePermission) {
+		return
+	}
+
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.AiSpecReviewConfigUpdateReq
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	validationErr := utils.ValidateObject(req)
+	if validationErr != nil {
+		var customError *exception.CustomError
+		if errors.As(validationErr, &customError) {
+			utils.RespondWithCustomError(w, customError)
+			return
+		}
+	}
+
+	result, err := a.reviewSvc.UpdateConfig(r.Context(), ctx, packageId, req)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to update AI review config", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (a aiSpecReviewControllerImpl) GetReview(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !a.checkPermission(w, r, ctx, packageId, view.ReadPermission) {
+		return
+	}
+	version, err := getUnescapedStringParam(r, "version")
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "version"},
+			Debug:   err.Error(),
+		})
+		return
+	}
+
+	result, err := a.reviewSvc.GetReview(r.Context(), packageId, version)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get AI review", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (a aiSpecReviewControllerImpl) StartReview(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !a.checkPermission(w, r, ctx, packageId, view.CreateAndUpdatePackagePermission) {
+		return
+	}
+	version, err := getUnescapedStringParam(r, "version")
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "version"},
+			Debug:   err.Error(),
+		})
+		return
+	}
+
+	result, err := a.reviewSvc.StartReview(r.Context(), packageId, version)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to start AI review", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusAccepted, result)
+}
+
+func (a aiSpecReviewControllerImpl) checkPermission(w http.ResponseWriter, r *http.Request, ctx context.SecurityContext, packageId string, permission view.RolePermission) bool {
+	sufficientPrivileges, err := a.roleService.HasRequiredPermissions(ctx, packageId, permission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, a.ptHandler, packageId, "Failed to check user privileges", err)
+		return false
+	}
+	if !sufficientPrivileges {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return false
+	}
+	return true
+}
@@ -36,7 +36,7 @@ func (a *authControllerImpl) ServeMetadata(w http.ResponseWriter, r *http.Reques
 	if exists {
 		provider.ServeMetadata(w, r)
 	} else {
-		log.Debugf("Cannot find IDP with id: %s", idpId)
+		log.WithContext(r.Context()).Debugf("Cannot find IDP with id: %s", idpId)
 		utils.RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusNotFound,
 			Code:    exception.ExternalIDPNotFound,
@@ -53,7 +53,7 @@ func (a *authControllerImpl) StartAuthentication(w http.ResponseWriter, r *http.
 	if exists {
 		provider.StartAuthentication(w, r)
 	} else {
-		log.Debugf("Cannot find IDP with id: %s", idpId)
+		log.WithContext(r.Context()).Debugf("Cannot find IDP with id: %s", idpId)
 		utils.RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusNotFound,
 			Code:    exception.ExternalIDPNotFound,
@@ -70,7 +70,7 @@ func (a *authControllerImpl) SAMLAssertionConsumerHandler(w http.ResponseWriter,
 	if exists {
 		provider.CallbackHandler(w, r)
 	} else {
-		log.Debugf("Cannot find IDP with id: %s", idpId)
+		log.WithContext(r.Context()).Debugf("Cannot find IDP with id: %s", idpId)
 		utils.RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusNotFound,
 			Code:    exception.ExternalIDPNotFound,
@@ -86,7 +86,7 @@ func (a *authControllerImpl) OIDCCallbackHandler(w http.ResponseWriter, r *http.
 	if exists {
 		provider.CallbackHandler(w, r)
 	} else {
-		log.Debugf("Cannot find IDP with id: %s", idpId)
+		log.WithContext(r.Context()).Debugf("Cannot find IDP with id: %s", idpId)
 		utils.RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusNotFound,
 			Code:    exception.ExternalIDPNotFound,
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type BackgroundJobAdminController interface {
+	GetJobs(w http.ResponseWriter, r *http.Request)
+	GetJobRuns(w http.ResponseWriter, r *http.Request)
+	TriggerJob(w http.ResponseWriter, r *http.Request)
+	DisableJob(w http.ResponseWriter, r *http.Request)
+	EnableJob(w http.ResponseWriter, r *http.Request)
+	CancelJob(w http.ResponseWriter, r *http.Request)
+}
+
+func NewBackgroundJobAdminController(backgroundJobService service.BackgroundJobService, roleService service.RoleService) BackgroundJobAdminController {
+	return &backgroundJobAdminControllerImpl{
+		backgroundJobService: backgroundJobService,
+		roleService:          roleService,
+	}
+}
+
+type backgroundJobAdminControllerImpl struct {
+	backgroundJobService service.BackgroundJobService
+	roleService          service.RoleService
+}
+
+func (b backgroundJobAdminControllerImpl) GetJobs(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, b.roleService) {
+		return
+	}
+	jobs, err := b.backgroundJobService.GetJobs(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get background jobs", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, jobs)
+}
+
+func (b backgroundJobAdminControllerImpl) GetJobRuns(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, b.roleService) {
+		return
+	}
+	limit, customError := getLimitQueryParam(r)
+	if customError != nil {
+		utils.RespondWithCustomError(w, customError)
+		return
+	}
+	page := 0
+	if r.URL.Query().Get("page") != "" {
+		var err error
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "page", "type": "int"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+	runs, err := b.backgroundJobService.GetJobRuns(r.Context(), view.BackgroundJobRunsReq{
+		JobName: r.URL.Query().Get("jobName"),
+		Status:  r.URL.Query().Get("status"),
+		Limit:   limit,
+		Page:    page,
+	})
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get background job runs", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, runs)
+}
+
+func (b backgroundJobAdminControllerImpl) TriggerJob(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, b.roleService) {
+		return
+	}
+	run, err := b.backgroundJobService.TriggerJob(r.Context(), getStringParam(r, "jobName"), context.Create(r).GetUserId())
+	if err != nil {
+		utils.RespondWithError(w, "Failed to trigger background job", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusAccepted, run)
+}
+
+func (b backgroundJobAdminControllerImpl) DisableJob(w http.ResponseWriter, r *http.Request) {
+	b.setJobDisabled(w, r, true)
+}
+
+func (b backgroundJobAdminControllerImpl) EnableJob(w http.ResponseWriter, r *http.Request) {
+	b.setJobDisabled(w, r, false)
+}
+
+func (b backgroundJobAdminControllerImpl) setJobDisabled(w http.ResponseWriter, r *http.Request, disabled bool) {
+	if !checkSysadm(w, r, b.roleService) {
+		return
+	}
+	job, err := b.backgroundJobService.SetJobDisabled(r.Context(), getStringParam(r, "jobName"), disabled, context.Create(r).GetUserId())
+	if err != nil {
+		utils.RespondWithError(w, "Failed to change background job state", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, job)
+}
+
+func (b backgroundJobAdminControllerImpl) CancelJob(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, b.roleService) {
+		return
+	}
+	run, err := b.backgroundJobService.CancelJob(r.Context(), getStringParam(r, "jobName"), context.Create(r).GetUserId())
+	if err != nil {
+		utils.RespondWithError(w, "Failed to cancel background job", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusAccepted, run)
+}
@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type BlobStorageAdminController interface {
+	GetBlobStorageInfo(w http.ResponseWriter, r *http.Request)
+	StartMigration(w http.ResponseWriter, r *http.Request)
+	GetMigrations(w http.ResponseWriter, r *http.Request)
+	GetMigration(w http.ResponseWriter, r *http.Request)
+	CancelMigration(w http.ResponseWriter, r *http.Request)
+	ResumeMigration(w http.ResponseWriter, r *http.Request)
+}
+
+func NewBlobStorageAdminController(blobStorageService service.BlobStorageService, blobMigrationService service.BlobMigrationService, roleService service.RoleService) BlobStorageAdminController {
+	return &blobStorageAdminControllerImpl{
+		blobStorageService:   blobStorageService,
+		blobMigrationService: blobMigrationService,
+		roleService:          roleService,
+	}
+}
+
+type blobStorageAdminControllerImpl struct {
+	blobStorageService   service.BlobStorageService
+	blobMigrationService service.BlobMigrationService
+	roleService          service.RoleService
+}
+
+func (b blobStorageAdminControllerImpl) GetBlobStorageInfo(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, b.roleService) {
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, b.blobStorageService.GetBlobStorageInfo())
+}
+
+func (b blobStorageAdminControllerImpl) StartMigration(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, b.roleService) {
+		return
+	}
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.BlobMigrationReq
+	if err = json.Unmarshal(body, &req); err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	if err = utils.ValidateObject(req); err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	migration, err := b.blobMigrationService.StartMigration(r.Context(), req, context.Create(r).GetUserId())
+	if err != nil {
+		utils.RespondWithError(w, "Failed to start blob migration", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusAccepted, migration)
+}
+
+func (b blobStorageAdminControllerImpl) GetMigrations(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, b.roleService) {
+		return
+	}
+	limit, customError := getLimitQueryParam(r)
+	if customError != nil {
+		utils.RespondWithCustomError(w, customError)
+		return
+	}
+	page := 0
+	if r.URL.Query().Get("page") != "" {
+		var err error
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "page", "type": "int"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+	migrations, err := b.blobMigrationService.GetMigrations(r.Context(), limit, page)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get blob migrations", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, migrations)
+}
+
+func (b blobStorageAdminControllerImpl) GetMigration(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, b.roleService) {
+		return
+	}
+	migration, err := b.blobMigrationService.GetMigration(r.Context(), getStringParam(r, "runId"))
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get blob migration", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, migration)
+}
+
+func (b blobStorageAdminControllerImpl) CancelMigration(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, b.roleService) {
+		return
+	}
+	err := b.blobMigrationService.CancelMigration(r.Context(), getStringParam(r, "runId"), context.Create(r).GetUserId())
+	if err != nil {
+		utils.RespondWithError(w, "Failed to cancel blob migration", err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (b blobStorageAdminControllerImpl) ResumeMigration(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, b.roleService) {
+		return
+	}
+	migration, err := b.blobMigrationService.ResumeMigration(r.Context(), getStringParam(r, "runId"))
+	if err != nil {
+		utils.RespondWithError(w, "Failed to resume blob migration", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusAccepted, migration)
+}
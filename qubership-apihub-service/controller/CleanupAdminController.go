@@ -0,0 +1,190 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service/cleanup"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type CleanupAdminController interface {
+	GetRetentionPolicies(w http.ResponseWriter, r *http.Request)
+	SetRetentionPolicy(w http.ResponseWriter, r *http.Request)
+	DeleteRetentionPolicy(w http.ResponseWriter, r *http.Request)
+	StartDryRun(w http.ResponseWriter, r *http.Request)
+	GetDryRun(w http.ResponseWriter, r *http.Request)
+	GetJobRuns(w http.ResponseWriter, r *http.Request)
+}
+
+func NewCleanupAdminController(cleanupService cleanup.CleanupService, retentionPolicyService cleanup.RetentionPolicyService, roleService service.RoleService) CleanupAdminController {
+	return &cleanupAdminControllerImpl{
+		cleanupService:         cleanupService,
+		retentionPolicyService: retentionPolicyService,
+		roleService:            roleService,
+	}
+}
+
+type cleanupAdminControllerImpl struct {
+	cleanupService         cleanup.CleanupService
+	retentionPolicyService cleanup.RetentionPolicyService
+	roleService            service.RoleService
+}
+
+func (c cleanupAdminControllerImpl) GetRetentionPolicies(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, c.roleService) {
+		return
+	}
+	policies, err := c.retentionPolicyService.GetPolicies(r.Context(), r.URL.Query().Get("dataClass"), r.URL.Query().Get("packageId"))
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get retention policies", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, policies)
+}
+
+func (c cleanupAdminControllerImpl) SetRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, c.roleService) {
+		return
+	}
+	packageId := getStringParam(r, "packageId")
+	dataClass := getStringParam(r, "dataClass")
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.CleanupRetentionPolicyReq
+	if err = json.Unmarshal(body, &req); err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	if err = utils.ValidateObject(req); err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	policy, err := c.retentionPolicyService.SetPolicy(r.Context(), packageId, dataClass, req, context.Create(r).GetUserId())
+	if err != nil {
+		utils.RespondWithError(w, "Failed to set retention policy", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, policy)
+}
+
+func (c cleanupAdminControllerImpl) DeleteRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, c.roleService) {
+		return
+	}
+	err := c.retentionPolicyService.DeletePolicy(r.Context(), getStringParam(r, "packageId"), getStringParam(r, "dataClass"))
+	if err != nil {
+		utils.RespondWithError(w, "Failed to delete retention policy", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c cleanupAdminControllerImpl) StartDryRun(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, c.roleService) {
+		return
+	}
+	var req view.CleanupDryRunReq
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	if len(body) > 0 {
+		if err = json.Unmarshal(body, &req); err != nil {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.BadRequestBody,
+				Message: exception.BadRequestBodyMsg,
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+	dryRun, err := c.cleanupService.StartDryRun(r.Context(), getStringParam(r, "jobType"), req.PackageId, context.Create(r).GetUserId())
+	if err != nil {
+		utils.RespondWithError(w, "Failed to start cleanup dry run", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusAccepted, dryRun)
+}
+
+func (c cleanupAdminControllerImpl) GetDryRun(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, c.roleService) {
+		return
+	}
+	dryRun, err := c.cleanupService.GetDryRun(r.Context(), getStringParam(r, "runId"))
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get cleanup dry run", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, dryRun)
+}
+
+func (c cleanupAdminControllerImpl) GetJobRuns(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, c.roleService) {
+		return
+	}
+	limit, customError := getLimitQueryParam(r)
+	if customError != nil {
+		utils.RespondWithCustomError(w, customError)
+		return
+	}
+	page := 0
+	if r.URL.Query().Get("page") != "" {
+		var err error
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "page", "type": "int"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+	runs, err := c.cleanupService.GetJobRuns(r.Context(), view.CleanupJobRunsReq{
+		JobType: r.URL.Query().Get("jobType"),
+		Status:  r.URL.Query().Get("status"),
+		Limit:   limit,
+		Page:    page,
+	})
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get cleanup job runs", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, runs)
+}
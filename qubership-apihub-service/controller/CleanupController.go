@@ -38,7 +38,7 @@ func (c cleanupControllerImpl) ClearTestData(w http.ResponseWriter, r *http.Requ
 	}
 	err = c.cleanupService.ClearTestData(testId)
 	if err != nil {
-		log.Error("Failed to clear test data: ", err.Error())
+		log.WithContext(r.Context()).Error("Failed to clear test data: ", err.Error())
 		if customError, ok := err.(*exception.CustomError); ok {
 			utils.RespondWithCustomError(w, customError)
 		} else {
@@ -2,8 +2,10 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -12,7 +14,9 @@ import (
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
 
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
 	"github.com/gorilla/mux"
 )
 
@@ -201,3 +205,57 @@ func getTemplatePath(r *http.Request) string {
 	templatePath, _ := route.GetPathTemplate()
 	return templatePath
 }
+
+// makePublishedETag builds the strong ETag of the published resource.
+// resource parts distinguish representations of the same revision, e.g. document slug or operation id.
+func makePublishedETag(cacheInfo *view.PublishedCacheInfo, resource ...string) string {
+	parts := append([]string{cacheInfo.PackageId, cacheInfo.Version, strconv.Itoa(cacheInfo.Revision), cacheInfo.DataHash}, resource...)
+	return utils.MakeStrongETag(parts...)
+}
+
+// decodeAndValidateBody reads the request body to req and validates it, responds with an error if the body is not valid
+func decodeAndValidateBody(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return false
+	}
+	err = json.Unmarshal(body, req)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return false
+	}
+	validationErr := utils.ValidateObject(req)
+	if validationErr != nil {
+		var customError *exception.CustomError
+		if errors.As(validationErr, &customError) {
+			utils.RespondWithCustomError(w, customError)
+			return false
+		}
+	}
+	return true
+}
+
+// checkSysadm responds with 403 if the current user is not a system administrator
+func checkSysadm(w http.ResponseWriter, r *http.Request, roleService service.RoleService) bool {
+	if !roleService.IsSysadm(context.Create(r)) {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type DashboardTrackingController interface {
+	GetTracking(w http.ResponseWriter, r *http.Request)
+	SetTracking(w http.ResponseWriter, r *http.Request)
+	DeleteTracking(w http.ResponseWriter, r *http.Request)
+	GetRollForwards(w http.ResponseWriter, r *http.Request)
+}
+
+func NewDashboardTrackingController(roleService service.RoleService,
+	trackingService service.DashboardTrackingService,
+	ptHandler service.PackageTransitionHandler) DashboardTrackingController {
+	return dashboardTrackingControllerImpl{roleService: roleService, trackingService: trackingService, ptHandler: ptHandler}
+}
+
+type dashboardTrackingControllerImpl struct {
+	roleService     service.RoleService
+	trackingService service.DashboardTrackingService
+	ptHandler       service.PackageTransitionHandler
+}
+
+func (d dashboardTrackingControllerImpl) GetTracking(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !d.checkPermission(w, r, ctx, packageId, view.ReadPermission) {
+		return
+	}
+	version, ok := getVersionParam(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := d.trackingService.GetTracking(r.Context(), packageId, version)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, d.ptHandler, packageId, "Failed to get dashboard tracking", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (d dashboardTrackingControllerImpl) SetTracking(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !d.checkPermission(w, r, ctx, packageId, view.ManageDraftVersionPermission) {
+		return
+	}
+	version, ok := getVersionParam(w, r)
+	if !ok {
+		return
+	}
+
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.DashboardTrackingReq
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	validationErr := utils.ValidateObject(req)
+	if validationErr != nil {
+		var customError *exception.CustomError
+		if errors.As(validationErr, &customError) {
+			utils.RespondWithCustomError(w, customError)
+			return
+		}
+	}
+
+	result, err := d.trackingService.SetTracking(r.Context(), ctx, packageId, version, req)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to set dashboard tracking", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (d dashboardTrackingControllerImpl) DeleteTracking(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !d.checkPermission(w, r, ctx, packageId, view.ManageDraftVersionPermission) {
+		return
+	}
+	version, ok := getVersionParam(w, r)
+	if !ok {
+		return
+	}
+
+	err := d.trackingService.DeleteTracking(r.Context(), packageId, version)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to delete dashboard tracking", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (d dashboardTrackingControllerImpl) GetRollForwards(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !d.checkPermission(w, r, ctx, packageId, view.ReadPermission) {
+		return
+	}
+	limit, customError := getLimitQueryParam(r)
+	if customError != nil {
+		utils.RespondWithCustomError(w, customError)
+		return
+	}
+	page := 0
+	if r.URL.Query().Get("page") != "" {
+		var err error
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "page", "type": "int"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+
+	result, err := d.trackingService.GetRollForwards(r.Context(), packageId, view.DashboardRollForwardsReq{
+		Version: r.URL.Query().Get("version"),
+		Limit:   limit,
+		Page:    page,
+	})
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, d.ptHandler, packageId, "Failed to get dashboard roll forwards", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (d dashboardTrackingControllerImpl) checkPermission(w http.ResponseWriter, r *http.Request, ctx context.SecurityContext, packageId string, permission view.RolePermission) bool {
+	sufficientPrivileges, err := d.roleService.HasRequiredPermissions(ctx, packageId, permission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, d.ptHandler, packageId, "Failed to check user privileges", err)
+		return false
+	}
+	if !sufficientPrivileges {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return false
+	}
+	return true
+}
+
+func getVersionParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	version, err := getUnescapedStringParam(r, "version")
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "version"},
+			Debug:   err.Error(),
+		})
+		return "", false
+	}
+	return version, true
+}
@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type DependencyGraphController interface {
+	GetDependencyGraph(w http.ResponseWriter, r *http.Request)
+	GetDependents(w http.ResponseWriter, r *http.Request)
+}
+
+func NewDependencyGraphController(roleService service.RoleService,
+	dependencyGraphService service.DependencyGraphService,
+	ptHandler service.PackageTransitionHandler) DependencyGraphController {
+	return dependencyGraphControllerImpl{roleService: roleService, dependencyGraphService: dependencyGraphService, ptHandler: ptHandler}
+}
+
+type dependencyGraphControllerImpl struct {
+	roleService            service.RoleService
+	dependencyGraphService service.DependencyGraphService
+	ptHandler              service.PackageTransitionHandler
+}
+
+func (d dependencyGraphControllerImpl) GetDependencyGraph(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !d.checkPermission(w, r, ctx, packageId, view.ReadPermission) {
+		return
+	}
+	version, err := getUnescapedStringParam(r, "version")
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "version"},
+			Debug:   err.Error(),
+		})
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = view.ExportFormatJson
+	}
+	if format != view.ExportFormatJson && format != view.DependencyGraphFormatGraphML && format != view.DependencyGraphFormatDot {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.UnsupportedFormat,
+			Message: exception.UnsupportedFormatMsg,
+			Params:  map[string]interface{}{"format": format},
+		})
+		return
+	}
+
+	graph, err := d.dependencyGraphService.GetDependencyGraph(packageId, version)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, d.ptHandler, packageId, "Failed to get dependency graph", err)
+		return
+	}
+
+	var content []byte
+	var contentType string
+	switch format {
+	case view.ExportFormatJson:
+		utils.RespondWithJson(w, http.StatusOK, graph)
+		return
+	case view.DependencyGraphFormatGraphML:
+		content, err = service.MakeDependencyGraphML(graph)
+		if err != nil {
+			utils.RespondWithError(w, "Failed to export dependency graph as graphml", err)
+			return
+		}
+		contentType = "application/graphml+xml"
+	case view.DependencyGraphFormatDot:
+		content = service.MakeDependencyGraphDot(graph)
+		contentType = "text/vnd.graphviz"
+	}
+	filename := fmt.Sprintf("%s_%s_dependencies.%s", packageId, strings.ReplaceAll(version, "@", "_"), format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%v"`, filename))
+	w.Header().Set("Content-Transfer-Encoding", "binary")
+	w.Header().Set("Expires", "0")
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+func (d dependencyGraphControllerImpl) GetDependents(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !d.checkPermission(w, r, ctx, packageId, view.ReadPermission) {
+		return
+	}
+	version, err := getUnescapedStringParam(r, "version")
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "version"},
+			Debug:   err.Error(),
+		})
+		return
+	}
+	allRevisions := false
+	if r.URL.Query().Get("allRevisions") != "" {
+		allRevisions, err = strconv.ParseBool(r.URL.Query().Get("allRevisions"))
+		if err != nil {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "allRevisions", "type": "boolean"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+	limit, customError := getLimitQueryParam(r)
+	if customError != nil {
+		utils.RespondWithCustomError(w, customError)
+		return
+	}
+	page := 0
+	if r.URL.Query().Get("page") != "" {
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "page", "type": "int"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+
+	result, err := d.dependencyGraphService.GetDependents(r.Context(), ctx, packageId, version, view.PackageVersionDependentsReq{
+		AllRevisions: allRevisions,
+		Limit:        limit,
+		Page:         page,
+	})
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, d.ptHandler, packageId, "Failed to get package version dependents", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (d dependencyGraphControllerImpl) checkPermission(w http.ResponseWriter, r *http.Request, ctx context.SecurityContext, packageId string, permission view.RolePermission) bool {
+	sufficientPrivileges, err := d.roleService.HasRequiredPermissions(ctx, packageId, permission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, d.ptHandler, packageId, "Failed to check user privileges", err)
+		return false
+	}
+	if !sufficientPrivileges {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return false
+	}
+	return true
+}
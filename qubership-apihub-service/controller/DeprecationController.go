@@ -0,0 +1,275 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+// sunset calendar covers a year from today if the range is not set
+const defaultSunsetCalendarDays = 365
+
+type DeprecationController interface {
+	GetPolicy(w http.ResponseWriter, r *http.Request)
+	SetPolicy(w http.ResponseWriter, r *http.Request)
+	DeletePolicy(w http.ResponseWriter, r *http.Request)
+	GetSunsetCalendar(w http.ResponseWriter, r *http.Request)
+}
+
+func NewDeprecationController(roleService service.RoleService,
+	deprecationService service.DeprecationService,
+	ptHandler service.PackageTransitionHandler) DeprecationController {
+	return deprecationControllerImpl{roleService: roleService, deprecationService: deprecationService, ptHandler: ptHandler}
+}
+
+type deprecationControllerImpl struct {
+	roleService        service.RoleService
+	deprecationService service.DeprecationService
+	ptHandler          service.PackageTransitionHandler
+}
+
+func (d deprecationControllerImpl) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !d.checkPermission(w, r, ctx, packageId, view.ReadPermission) {
+		return
+	}
+	apiType, ok := getApiTypeParam(w, r)
+	if !ok {
+		return
+	}
+	operationId, ok := getOperationIdParam(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := d.deprecationService.GetPolicy(r.Context(), packageId, apiType, operationId)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, d.ptHandler, packageId, "Failed to get deprecation policy", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (d deprecationControllerImpl) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !d.checkPermission(w, r, ctx, packageId, view.ManageReleaseVersionPermission) {
+		return
+	}
+	apiType, ok := getApiTypeParam(w, r)
+	if !ok {
+		return
+	}
+	operationId, ok := getOperationIdParam(w, r)
+	if !ok {
+		return
+	}
+
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.OperationDeprecationPolicyReq
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	validationErr := utils.ValidateObject(req)
+	if validationErr != nil {
+		var customError *exception.CustomError
+		if errors.As(validationErr, &customError) {
+			utils.RespondWithCustomError(w, customError)
+			return
+		}
+	}
+
+	result, err := d.deprecationService.SetPolicy(r.Context(), ctx, packageId, apiType, operationId, req)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to set deprecation policy", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (d deprecationControllerImpl) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !d.checkPermission(w, r, ctx, packageId, view.ManageReleaseVersionPermission) {
+		return
+	}
+	apiType, ok := getApiTypeParam(w, r)
+	if !ok {
+		return
+	}
+	operationId, ok := getOperationIdParam(w, r)
+	if !ok {
+		return
+	}
+
+	err := d.deprecationService.DeletePolicy(r.Context(), packageId, apiType, operationId)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to delete deprecation policy", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (d deprecationControllerImpl) GetSunsetCalendar(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	workspaceId := getStringParam(r, "workspaceId")
+	if !d.checkPermission(w, r, ctx, workspaceId, view.ReadPermission) {
+		return
+	}
+	from, ok := getDateQueryParam(w, r, "from")
+	if !ok {
+		return
+	}
+	if from.IsZero() {
+		y, m, day := time.Now().UTC().Date()
+		from = time.Date(y, m, day, 0, 0, 0, 0, time.UTC)
+	}
+	to, ok := getDateQueryParam(w, r, "to")
+	if !ok {
+		return
+	}
+	if to.IsZero() {
+		to = from.AddDate(0, 0, defaultSunsetCalendarDays)
+	}
+	limit, customError := getLimitQueryParam(r)
+	if customError != nil {
+		utils.RespondWithCustomError(w, customError)
+		return
+	}
+	page := 0
+	if r.URL.Query().Get("page") != "" {
+		var err error
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "page", "type": "int"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+
+	result, err := d.deprecationService.GetSunsetCalendar(r.Context(), workspaceId, view.SunsetCalendarReq{
+		From:  from,
+		To:    to,
+		Limit: limit,
+		Page:  page,
+	})
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, d.ptHandler, workspaceId, "Failed to get sunset calendar", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (d deprecationControllerImpl) checkPermission(w http.ResponseWriter, r *http.Request, ctx context.SecurityContext, packageId string, permission view.RolePermission) bool {
+	sufficientPrivileges, err := d.roleService.HasRequiredPermissions(ctx, packageId, permission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, d.ptHandler, packageId, "Failed to check user privileges", err)
+		return false
+	}
+	if !sufficientPrivileges {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return false
+	}
+	return true
+}
+
+func getApiTypeParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	apiType, err := getUnescapedStringParam(r, "apiType")
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "apiType"},
+			Debug:   err.Error(),
+		})
+		return "", false
+	}
+	_, err = view.ParseApiType(apiType)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "apiType", "value": apiType},
+			Debug:   err.Error(),
+		})
+		return "", false
+	}
+	return apiType, true
+}
+
+func getOperationIdParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	operationId, err := getUnescapedStringParam(r, "operationId")
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "operationId"},
+			Debug:   err.Error(),
+		})
+		return "", false
+	}
+	return operationId, true
+}
+
+// getDateQueryParam returns zero time if the parameter is not set
+func getDateQueryParam(w http.ResponseWriter, r *http.Request, param string) (time.Time, bool) {
+	value := r.URL.Query().Get(param)
+	if value == "" {
+		return time.Time{}, true
+	}
+	date, err := time.Parse(view.SunsetDateFormat, value)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.IncorrectParamType,
+			Message: exception.IncorrectParamTypeMsg,
+			Params:  map[string]interface{}{"param": param, "type": "date"},
+			Debug:   err.Error(),
+		})
+		return time.Time{}, false
+	}
+	return date, true
+}
@@ -1,8 +1,8 @@
 package controller
 
 import (
+	"bytes"
 	"net/http"
-	"os"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -60,17 +60,11 @@ func (c *EphemeralFileController) Download(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	file, err := os.Open(f.StoragePath)
+	data, err := c.svc.ReadFileData(r.Context(), f)
 	if err != nil {
 		utils.RespondWithCustomError(w, errEphemeralFileNotFound(fileID))
 		return
 	}
-	defer file.Close()
-	st, err := file.Stat()
-	if err != nil || st.IsDir() {
-		utils.RespondWithCustomError(w, errEphemeralFileNotFound(fileID))
-		return
-	}
 
 	if f.MimeType != nil {
 		w.Header().Set("Content-Type", *f.MimeType)
@@ -78,7 +72,7 @@ func (c *EphemeralFileController) Download(w http.ResponseWriter, r *http.Reques
 		w.Header().Set("Content-Type", "application/octet-stream")
 	}
 	w.Header().Set("Content-Disposition", "attachment; filename=\""+escapeFilename(f.Filename)+"\"")
-	http.ServeContent(w, r, "", st.ModTime(), file)
+	http.ServeContent(w, r, "", f.CreatedAt, bytes.NewReader(data))
 }
 
 func errEphemeralFileNotFound(fileID string) *exception.CustomError {
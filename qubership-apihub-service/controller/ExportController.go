@@ -41,7 +41,8 @@ func NewExportController(publishedService service.PublishedService,
 	versionService service.VersionService,
 	monitoringService service.MonitoringService,
 	exportService service.ExportService,
-	packageService service.PackageService) ExportController {
+	packageService service.PackageService,
+	changelogReportService service.ChangelogReportService) ExportController {
 	return &exportControllerImpl{
 		publishedService:  publishedService,
 		portalService:     portalService,
@@ -51,6 +52,8 @@ func NewExportController(publishedService service.PublishedService,
 		monitoringService: monitoringService,
 		exportService:     exportService,
 		packageService:    packageService,
+
+		changelogReportService: changelogReportService,
 	}
 }
 
@@ -63,6 +66,8 @@ type exportControllerImpl struct {
 	monitoringService service.MonitoringService
 	exportService     service.ExportService
 	packageService    service.PackageService
+
+	changelogReportService service.ChangelogReportService
 }
 
 func (e exportControllerImpl) ExportOperationGroupAsOpenAPIDocuments_deprecated_2(w http.ResponseWriter, r *http.Request) {
@@ -410,12 +415,12 @@ func (e exportControllerImpl) GenerateApiChangesExcelReport(w http.ResponseWrite
 	}
 	apiChangesReport, versionName, err := e.excelService.ExportApiChanges(packageId, version, "", []string{}, exportApiChangesRequestView)
 	if err != nil {
-		log.Errorf("Failed to export api changes error - %s", err.Error())
+		log.WithContext(r.Context()).Errorf("Failed to export api changes error - %s", err.Error())
 		utils.RespondWithError(w, "Failed to export api changes", err)
 		return
 	}
 	if apiChangesReport == nil {
-		log.Info("ApiChangeReport is empty")
+		log.WithContext(r.Context()).Info("ApiChangeReport is empty")
 		utils.RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusNotFound,
 			Code:    exception.ChangesAreEmpty,
@@ -468,6 +473,19 @@ func (e exportControllerImpl) GenerateApiChangesExcelReportV3(w http.ResponseWri
 		})
 		return
 	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = view.ExportFormatXlsx
+	}
+	if format != view.ExportFormatXlsx && !view.ValidateChangelogReportFormat(format) {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.UnsupportedFormat,
+			Message: exception.UnsupportedFormatMsg,
+			Params:  map[string]interface{}{"format": format},
+		})
+		return
+	}
 	previousVersion, err := url.QueryUnescape(r.URL.Query().Get("previousVersion"))
 	if err != nil {
 		utils.RespondWithCustomError(w, &exception.CustomError{
@@ -624,14 +642,18 @@ func (e exportControllerImpl) GenerateApiChangesExcelReportV3(w http.ResponseWri
 		AsyncapiChannel:          asyncapiChannel,
 		AsyncapiProtocol:         asyncapiProtocol,
 	}
+	if format != view.ExportFormatXlsx {
+		e.writeChangelogReport(w, packageId, version, apiType, severities, format, exportApiChangesRequestView)
+		return
+	}
 	apiChangesReport, versionName, err := e.excelService.ExportApiChanges(packageId, version, apiType, severities, exportApiChangesRequestView)
 	if err != nil {
-		log.Errorf("Failed to export api changes error - %s", err.Error())
+		log.WithContext(r.Context()).Errorf("Failed to export api changes error - %s", err.Error())
 		utils.RespondWithError(w, "Failed to export api changes", err)
 		return
 	}
 	if apiChangesReport == nil {
-		log.Info("ApiChangeReport is empty")
+		log.WithContext(r.Context()).Info("ApiChangeReport is empty")
 		utils.RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusNotFound,
 			Code:    exception.ChangesAreEmpty,
@@ -646,6 +668,30 @@ func (e exportControllerImpl) GenerateApiChangesExcelReportV3(w http.ResponseWri
 	apiChangesReport.Write(w)
 }
 
+func (e exportControllerImpl) writeChangelogReport(w http.ResponseWriter, packageId, version, apiType string, severities []string, format string, req view.ExportApiChangesRequestView) {
+	content, versionName, err := e.changelogReportService.ExportChangelogReport(packageId, version, apiType, severities, format, req)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to export changelog report", err)
+		return
+	}
+	var contentType, extension string
+	switch format {
+	case view.ExportFormatMarkdown:
+		contentType, extension = "text/markdown", "md"
+	case view.ExportFormatJunit:
+		contentType, extension = "application/xml", "xml"
+	case view.ExportFormatSarif:
+		contentType, extension = "application/sarif+json", "sarif"
+	default:
+		contentType, extension = "application/json", "json"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=APIChanges_%s_%s.%s", packageId, versionName, extension))
+	w.Header().Set("Expires", "0")
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
 func (e exportControllerImpl) GenerateOperationsExcelReport(w http.ResponseWriter, r *http.Request) {
 	packageId := getStringParam(r, "packageId")
 	ctx := context.Create(r)
@@ -827,14 +873,14 @@ func (e exportControllerImpl) GenerateOperationsExcelReport(w http.ResponseWrite
 		AsyncapiProtocol: asyncapiProtocol,
 		AsyncapiChannel:  asyncapiChannel,
 	}
-	operationsReport, versionName, err := e.excelService.ExportOperations(packageId, version, apiType, exportOperationsRequestView)
+	operationsReport, versionName, err := e.excelService.ExportOperations(r.Context(), packageId, version, apiType, exportOperationsRequestView)
 	if err != nil {
-		log.Errorf("Excel error - %s", err.Error())
+		log.WithContext(r.Context()).Errorf("Excel error - %s", err.Error())
 		utils.RespondWithError(w, "Failed to export operations", err)
 		return
 	}
 	if operationsReport == nil {
-		log.Info("Operations are empty")
+		log.WithContext(r.Context()).Info("Operations are empty")
 		utils.RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusNotFound,
 			Code:    exception.OperationsAreEmpty,
@@ -1023,12 +1069,12 @@ func (e exportControllerImpl) GenerateDeprecatedOperationsExcelReport(w http.Res
 	}
 	deprecatedOperationsReport, versionName, err := e.excelService.ExportDeprecatedOperations(packageId, version, apiType, exportOperationsRequestView)
 	if err != nil {
-		log.Errorf("Excel error - %s", err.Error())
+		log.WithContext(r.Context()).Errorf("Excel error - %s", err.Error())
 		utils.RespondWithError(w, "Failed to export operations", err)
 		return
 	}
 	if deprecatedOperationsReport == nil {
-		log.Info("Deprecated operations are empty")
+		log.WithContext(r.Context()).Info("Deprecated operations are empty")
 		utils.RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusNotFound,
 			Code:    exception.OperationsAreEmpty,
@@ -1081,6 +1127,8 @@ func (e exportControllerImpl) StartAsyncExport(w http.ResponseWriter, r *http.Re
 		exportRequest = &view.ExportGraphqlOperationsGroupReq{}
 	case view.ExportEntityAsyncapiOperationsGroup:
 		exportRequest = &view.ExportAsyncapiOperationsGroupReq{}
+	case view.ExportEntityProtobufOperationsGroup:
+		exportRequest = &view.ExportProtobufOperationsGroupReq{}
 	default:
 		utils.RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusBadRequest,
@@ -1144,6 +1192,8 @@ func (e exportControllerImpl) StartAsyncExport(w http.ResponseWriter, r *http.Re
 		exportID, err = e.exportService.StartGraphQLOpGroupExport(ctx, *exportRequest.(*view.ExportGraphqlOperationsGroupReq))
 	case view.ExportEntityAsyncapiOperationsGroup:
 		exportID, err = e.exportService.StartAsyncAPIOpGroupExport(ctx, *exportRequest.(*view.ExportAsyncapiOperationsGroupReq))
+	case view.ExportEntityProtobufOperationsGroup:
+		exportID, err = e.exportService.StartProtobufOpGroupExport(ctx, *exportRequest.(*view.ExportProtobufOperationsGroupReq))
 	}
 	if err != nil {
 		utils.RespondWithError(w, "Failed to start export process", err)
@@ -1264,7 +1314,7 @@ func (e exportControllerImpl) GenerateShareabilityReport(w http.ResponseWriter,
 	}
 	defer func() {
 		if cerr := report.Close(); cerr != nil {
-			log.Errorf("Failed to close shareability report workbook: %v", cerr.Error())
+			log.WithContext(r.Context()).Errorf("Failed to close shareability report workbook: %v", cerr.Error())
 		}
 	}()
 
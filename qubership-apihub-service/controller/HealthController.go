@@ -2,7 +2,9 @@ package controller
 
 import (
 	"net/http"
+	"sync/atomic"
 
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
 )
 
@@ -11,8 +13,8 @@ type HealthController interface {
 	HandleLiveRequest(w http.ResponseWriter, r *http.Request)
 }
 
-func NewHealthController(readyChan chan bool) HealthController {
-	c := healthControllerImpl{ready: false}
+func NewHealthController(readyChan chan bool, shutdownService service.ShutdownService) HealthController {
+	c := healthControllerImpl{shutdownService: shutdownService}
 	utils.SafeAsync(func() {
 		c.watchReady(readyChan)
 	})
@@ -20,11 +22,13 @@ func NewHealthController(readyChan chan bool) HealthController {
 }
 
 type healthControllerImpl struct {
-	ready bool
+	ready           atomic.Bool
+	shutdownService service.ShutdownService
 }
 
-func (h healthControllerImpl) HandleReadyRequest(w http.ResponseWriter, r *http.Request) {
-	if h.ready {
+func (h *healthControllerImpl) HandleReadyRequest(w http.ResponseWriter, r *http.Request) {
+	// the instance is not ready during the graceful shutdown to let the load balancer stop routing requests to it
+	if h.ready.Load() && !h.shutdownService.IsShuttingDown() {
 		w.WriteHeader(http.StatusOK) // any code in (>=200 & <400)
 		return
 	} else {
@@ -32,12 +36,12 @@ func (h healthControllerImpl) HandleReadyRequest(w http.ResponseWriter, r *http.
 	}
 }
 
-func (h healthControllerImpl) HandleLiveRequest(w http.ResponseWriter, r *http.Request) {
+func (h *healthControllerImpl) HandleLiveRequest(w http.ResponseWriter, r *http.Request) {
 	// Just return 200 at this moment
 	// TODO: but maybe need to check some internal status
 	w.WriteHeader(http.StatusOK)
 }
 
 func (h *healthControllerImpl) watchReady(readyChan chan bool) {
-	h.ready = <-readyChan
+	h.ready.Store(<-readyChan)
 }
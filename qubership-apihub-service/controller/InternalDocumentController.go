@@ -3,6 +3,7 @@ package controller
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
@@ -70,6 +71,11 @@ func (c *internalDocumentControllerImpl) GetVersionInternalDocuments(w http.Resp
 
 func (c *internalDocumentControllerImpl) GetVersionInternalDocumentData(w http.ResponseWriter, r *http.Request) {
 	hash := getStringParam(r, "hash")
+	// internal document data is addressed by its hash, so it never changes
+	etag := utils.MakeStrongETag(hash)
+	if utils.RespondNotModified(w, r, etag, time.Time{}, view.CacheControlImmutable) {
+		return
+	}
 
 	data, filename, err := c.publishedService.GetVersionInternalDocumentData(hash)
 	if err != nil {
@@ -77,6 +83,7 @@ func (c *internalDocumentControllerImpl) GetVersionInternalDocumentData(w http.R
 		return
 	}
 
+	utils.SetCacheHeaders(w, etag, time.Time{}, view.CacheControlImmutable)
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", filename))
 	w.WriteHeader(http.StatusOK)
@@ -126,6 +133,11 @@ func (c *internalDocumentControllerImpl) GetComparisonInternalDocuments(w http.R
 
 func (c *internalDocumentControllerImpl) GetComparisonInternalDocumentData(w http.ResponseWriter, r *http.Request) {
 	hash := getStringParam(r, "hash")
+	// internal document data is addressed by its hash, so it never changes
+	etag := utils.MakeStrongETag(hash)
+	if utils.RespondNotModified(w, r, etag, time.Time{}, view.CacheControlImmutable) {
+		return
+	}
 
 	data, filename, err := c.publishedService.GetComparisonInternalDocumentData(hash)
 	if err != nil {
@@ -133,6 +145,7 @@ func (c *internalDocumentControllerImpl) GetComparisonInternalDocumentData(w htt
 		return
 	}
 
+	utils.SetCacheHeaders(w, etag, time.Time{}, view.CacheControlImmutable)
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", filename))
 	w.WriteHeader(http.StatusOK)
@@ -97,7 +97,7 @@ func (l logsControllerImpl) SetLogLevel(w http.ResponseWriter, r *http.Request)
 	}
 
 	log.SetLevel(req.Level)
-	log.Infof("Log level was set to %s", req.Level.String())
+	log.WithContext(r.Context()).Infof("Log level was set to %s", req.Level.String())
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -49,7 +49,7 @@ func (m minioStorageControllerImpl) DownloadFilesFromMinioToDatabase(w http.Resp
 	}
 	err := m.minioStorageService.DownloadFilesFromBucketToDatabase()
 	if err != nil {
-		log.Error("Failed to download data from minio: ", err.Error())
+		log.WithContext(r.Context()).Error("Failed to download data from minio: ", err.Error())
 		if customError, ok := err.(*exception.CustomError); ok {
 			utils.RespondWithCustomError(w, customError)
 		} else {
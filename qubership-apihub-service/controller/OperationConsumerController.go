@@ -0,0 +1,243 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type OperationConsumerController interface {
+	GetPackageConsumedOperations(w http.ResponseWriter, r *http.Request)
+	SetPackageConsumedOperations(w http.ResponseWriter, r *http.Request)
+	GetApiKeyConsumedOperations(w http.ResponseWriter, r *http.Request)
+	SetApiKeyConsumedOperations(w http.ResponseWriter, r *http.Request)
+	GetOperationConsumers(w http.ResponseWriter, r *http.Request)
+	GetConsumerImpact(w http.ResponseWriter, r *http.Request)
+}
+
+func NewOperationConsumerController(roleService service.RoleService,
+	consumerService service.OperationConsumerService,
+	ptHandler service.PackageTransitionHandler) OperationConsumerController {
+	return operationConsumerControllerImpl{roleService: roleService, consumerService: consumerService, ptHandler: ptHandler}
+}
+
+type operationConsumerControllerImpl struct {
+	roleService     service.RoleService
+	consumerService service.OperationConsumerService
+	ptHandler       service.PackageTransitionHandler
+}
+
+func (o operationConsumerControllerImpl) GetPackageConsumedOperations(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !o.checkPermission(w, r, ctx, packageId, view.ReadPermission) {
+		return
+	}
+
+	result, err := o.consumerService.GetConsumedOperations(r.Context(), view.ConsumerTypePackage, packageId)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, o.ptHandler, packageId, "Failed to get consumed operations", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (o operationConsumerControllerImpl) SetPackageConsumedOperations(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !o.checkPermission(w, r, ctx, packageId, view.CreateAndUpdatePackagePermission) {
+		return
+	}
+	req, ok := readConsumedOperationsReq(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := o.consumerService.SetConsumedOperations(r.Context(), ctx, view.ConsumerTypePackage, packageId, *req)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to set consumed operations", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (o operationConsumerControllerImpl) GetApiKeyConsumedOperations(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	apiKeyId := getStringParam(r, "id")
+	if !o.checkApiKeyPermission(w, r, ctx, packageId, apiKeyId, view.ReadPermission) {
+		return
+	}
+
+	result, err := o.consumerService.GetConsumedOperations(r.Context(), view.ConsumerTypeApiKey, apiKeyId)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get consumed operations", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (o operationConsumerControllerImpl) SetApiKeyConsumedOperations(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	apiKeyId := getStringParam(r, "id")
+	if !o.checkApiKeyPermission(w, r, ctx, packageId, apiKeyId, view.AccessTokenManagementPermission) {
+		return
+	}
+	req, ok := readConsumedOperationsReq(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := o.consumerService.SetConsumedOperations(r.Context(), ctx, view.ConsumerTypeApiKey, apiKeyId, *req)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to set consumed operations", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (o operationConsumerControllerImpl) GetOperationConsumers(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !o.checkPermission(w, r, ctx, packageId, view.ReadPermission) {
+		return
+	}
+	limit, customError := getLimitQueryParam(r)
+	if customError != nil {
+		utils.RespondWithCustomError(w, customError)
+		return
+	}
+	page := 0
+	if r.URL.Query().Get("page") != "" {
+		var err error
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "page", "type": "int"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+
+	result, err := o.consumerService.GetOperationConsumers(r.Context(), packageId, view.OperationConsumersReq{
+		OperationId: r.URL.Query().Get("operationId"),
+		Limit:       limit,
+		Page:        page,
+	})
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, o.ptHandler, packageId, "Failed to get operation consumers", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (o operationConsumerControllerImpl) GetConsumerImpact(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !o.checkPermission(w, r, ctx, packageId, view.ReadPermission) {
+		return
+	}
+	version, err := getUnescapedStringParam(r, "version")
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "version"},
+			Debug:   err.Error(),
+		})
+		return
+	}
+
+	result, err := o.consumerService.GetConsumerImpact(r.Context(), packageId, version, view.ConsumerImpactReq{
+		PreviousVersion:          r.URL.Query().Get("previousVersion"),
+		PreviousVersionPackageId: r.URL.Query().Get("previousVersionPackageId"),
+	})
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, o.ptHandler, packageId, "Failed to get consumer impact", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (o operationConsumerControllerImpl) checkPermission(w http.ResponseWriter, r *http.Request, ctx context.SecurityContext, packageId string, permission view.RolePermission) bool {
+	sufficientPrivileges, err := o.roleService.HasRequiredPermissions(ctx, packageId, permission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, o.ptHandler, packageId, "Failed to check user privileges", err)
+		return false
+	}
+	if !sufficientPrivileges {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return false
+	}
+	return true
+}
+
+// checkApiKeyPermission allows the api key to manage its own consumed operations, otherwise the permission in the api key package is required
+func (o operationConsumerControllerImpl) checkApiKeyPermission(w http.ResponseWriter, r *http.Request, ctx context.SecurityContext, packageId string, apiKeyId string, permission view.RolePermission) bool {
+	if ctx.GetApiKeyId() != apiKeyId && !o.checkPermission(w, r, ctx, packageId, permission) {
+		return false
+	}
+	if err := o.consumerService.CheckApiKeyConsumer(packageId, apiKeyId); err != nil {
+		utils.RespondWithError(w, "Failed to check api key", err)
+		return false
+	}
+	return true
+}
+
+func readConsumedOperationsReq(w http.ResponseWriter, r *http.Request) (*view.ConsumedOperationsReq, bool) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return nil, false
+	}
+	var req view.ConsumedOperationsReq
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return nil, false
+	}
+	validationErr := utils.ValidateObject(req)
+	if validationErr != nil {
+		var customError *exception.CustomError
+		if errors.As(validationErr, &customError) {
+			utils.RespondWithCustomError(w, customError)
+			return nil, false
+		}
+	}
+	return &req, true
+}
@@ -314,7 +314,7 @@ func (o operationControllerImpl) GetOperationList(w http.ResponseWriter, r *http
 		AsyncapiProtocol: asyncapiProtocol,
 	}
 
-	operations, err := o.operationService.GetOperations(packageId, versionName, skipRefs, restOperationListReq)
+	operations, err := o.operationService.GetOperations(r.Context(), packageId, versionName, skipRefs, restOperationListReq)
 	if err != nil {
 		handlePkgRedirectOrRespondWithError(w, r, o.ptHandler, packageId, "Failed to get operations", err)
 		return
@@ -397,11 +397,22 @@ func (o operationControllerImpl) GetOperation(w http.ResponseWriter, r *http.Req
 		IncludeData: includeData,
 	}
 
-	operation, err := o.operationService.GetOperation(basicSearchFilter)
+	cacheInfo, err := o.operationService.GetOperationCacheInfo(r.Context(), basicSearchFilter)
 	if err != nil {
 		handlePkgRedirectOrRespondWithError(w, r, o.ptHandler, packageId, "Failed to get operation", err)
 		return
 	}
+	etag := makePublishedETag(cacheInfo, "operation", apiType, operationId, strconv.FormatBool(includeData))
+	if utils.RespondNotModified(w, r, etag, cacheInfo.PublishedAt, cacheInfo.GetCacheControl()) {
+		return
+	}
+
+	operation, err := o.operationService.GetOperation(r.Context(), basicSearchFilter)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, o.ptHandler, packageId, "Failed to get operation", err)
+		return
+	}
+	utils.SetCacheHeaders(w, etag, cacheInfo.PublishedAt, cacheInfo.GetCacheControl())
 	utils.RespondWithJson(w, http.StatusOK, operation)
 }
 
@@ -1207,7 +1218,7 @@ func (o operationControllerImpl) GetOperationModelUsages(w http.ResponseWriter,
 		})
 		return
 	}
-	modelUsages, err := o.operationService.GetOperationModelUsages(packageId, version, apiType, operationId, modelName)
+	modelUsages, err := o.operationService.GetOperationModelUsages(r.Context(), packageId, version, apiType, operationId, modelName)
 	if err != nil {
 		handlePkgRedirectOrRespondWithError(w, r, o.ptHandler, packageId, "Failed to get operation model usages", err)
 		return
@@ -368,7 +368,7 @@ func (o operationGroupControllerImpl) CreateOperationGroup(w http.ResponseWriter
 	defer func() {
 		err := r.MultipartForm.RemoveAll()
 		if err != nil {
-			log.Debugf("failed to remove temporal data: %+v", err)
+			log.WithContext(r.Context()).Debugf("failed to remove temporal data: %+v", err)
 		}
 	}()
 	createOperationGroupReq := view.CreateOperationGroupReq{}
@@ -387,7 +387,7 @@ func (o operationGroupControllerImpl) CreateOperationGroup(w http.ResponseWriter
 		templateData, err := io.ReadAll(io.LimitReader(template, o.templateSizeLimit+1))
 		closeErr := template.Close()
 		if closeErr != nil {
-			log.Debugf("failed to close temporal file: %+v", err)
+			log.WithContext(r.Context()).Debugf("failed to close temporal file: %+v", err)
 		}
 		if err != nil {
 			utils.RespondWithCustomError(w, &exception.CustomError{
@@ -626,7 +626,7 @@ func (o operationGroupControllerImpl) UpdateOperationGroup(w http.ResponseWriter
 	defer func() {
 		err := r.MultipartForm.RemoveAll()
 		if err != nil {
-			log.Debugf("failed to remove temporal data: %+v", err)
+			log.WithContext(r.Context()).Debugf("failed to remove temporal data: %+v", err)
 		}
 	}()
 	updateOperationGroupReq := view.UpdateOperationGroupReq{}
@@ -651,7 +651,7 @@ func (o operationGroupControllerImpl) UpdateOperationGroup(w http.ResponseWriter
 		templateData, err := io.ReadAll(io.LimitReader(template, o.templateSizeLimit+1))
 		closeErr := template.Close()
 		if closeErr != nil {
-			log.Debugf("failed to close temporal file: %+v", err)
+			log.WithContext(r.Context()).Debugf("failed to close temporal file: %+v", err)
 		}
 		if err != nil {
 			utils.RespondWithCustomError(w, &exception.CustomError{
@@ -853,7 +853,7 @@ func (o operationGroupControllerImpl) StartOperationGroupPublish(w http.Response
 		})
 		return
 	}
-	if apiType != string(view.RestApiType) && apiType != string(view.GraphqlApiType) && apiType != string(view.AsyncapiApiType) {
+	if apiType != string(view.RestApiType) && apiType != string(view.GraphqlApiType) && apiType != string(view.AsyncapiApiType) && apiType != string(view.ProtobufApiType) {
 		utils.RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusBadRequest,
 			Code:    exception.UnsupportedApiType,
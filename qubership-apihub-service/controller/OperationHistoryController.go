@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type OperationHistoryController interface {
+	GetOperationHistory(w http.ResponseWriter, r *http.Request)
+}
+
+func NewOperationHistoryController(roleService service.RoleService,
+	operationHistoryService service.OperationHistoryService,
+	ptHandler service.PackageTransitionHandler) OperationHistoryController {
+	return operationHistoryControllerImpl{roleService: roleService, operationHistoryService: operationHistoryService, ptHandler: ptHandler}
+}
+
+type operationHistoryControllerImpl struct {
+	roleService             service.RoleService
+	operationHistoryService service.OperationHistoryService
+	ptHandler               service.PackageTransitionHandler
+}
+
+func (o operationHistoryControllerImpl) GetOperationHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	sufficientPrivileges, err := o.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, o.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	operationId, ok := getOperationIdParam(w, r)
+	if !ok {
+		return
+	}
+	allRevisions := false
+	if r.URL.Query().Get("allRevisions") != "" {
+		allRevisions, err = strconv.ParseBool(r.URL.Query().Get("allRevisions"))
+		if err != nil {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "allRevisions", "type": "boolean"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+	severities, customErr := getListFromParam(r, "severity")
+	if customErr != nil {
+		utils.RespondWithCustomError(w, customErr)
+		return
+	}
+	for _, severity := range severities {
+		if !view.ValidSeverity(severity) {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.InvalidParameterValue,
+				Message: exception.InvalidParameterValueMsg,
+				Params:  map[string]interface{}{"param": "severity", "value": severity},
+			})
+			return
+		}
+	}
+
+	history, err := o.operationHistoryService.GetOperationHistory(r.Context(), packageId, operationId, view.OperationHistoryReq{
+		AllRevisions: allRevisions,
+		Severities:   severities,
+	})
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, o.ptHandler, packageId, "Failed to get operation history", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, history)
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	stdctx "context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -15,8 +16,10 @@ import (
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/tracing"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type PublishV2Controller interface {
@@ -32,18 +35,12 @@ func NewPublishV2Controller(buildService service.BuildService,
 	buildResultService service.BuildResultService,
 	roleService service.RoleService,
 	systemInfoService service.SystemInfoService) PublishV2Controller {
-
-	publishArchiveSizeLimit := systemInfoService.GetPublishArchiveSizeLimitMB()
-	publishFileSizeLimit := systemInfoService.GetPublishFileSizeLimitMB()
-
 	return &publishV2ControllerImpl{
-		buildService:            buildService,
-		publishedService:        publishedService,
-		buildResultService:      buildResultService,
-		roleService:             roleService,
-		publishArchiveSizeLimit: publishArchiveSizeLimit,
-		publishFileSizeLimit:    publishFileSizeLimit,
-		systemInfoService:       systemInfoService,
+		buildService:       buildService,
+		publishedService:   publishedService,
+		buildResultService: buildResultService,
+		roleService:        roleService,
+		systemInfoService:  systemInfoService,
 	}
 }
 
@@ -53,22 +50,21 @@ type publishV2ControllerImpl struct {
 	buildResultService service.BuildResultService
 	roleService        service.RoleService
 	systemInfoService  service.SystemInfoService
-
-	publishArchiveSizeLimit int64
-	publishFileSizeLimit    int64 //TODO: why is not used?
 }
 
 func (p publishV2ControllerImpl) Publish(w http.ResponseWriter, r *http.Request) {
 	packageId := getStringParam(r, "packageId")
 	ctx := context.Create(r)
-	r.Body = http.MaxBytesReader(w, r.Body, p.publishArchiveSizeLimit)
+	// the limit is a runtime setting, so it is read on each request
+	publishArchiveSizeLimit := p.systemInfoService.GetPublishArchiveSizeLimitMB()
+	r.Body = http.MaxBytesReader(w, r.Body, publishArchiveSizeLimit)
 
-	if r.ContentLength > p.publishArchiveSizeLimit {
+	if r.ContentLength > publishArchiveSizeLimit {
 		utils.RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusBadRequest,
 			Code:    exception.ArchiveSizeExceeded,
 			Message: exception.ArchiveSizeExceededMsg,
-			Params:  map[string]interface{}{"size": p.publishArchiveSizeLimit},
+			Params:  map[string]interface{}{"size": publishArchiveSizeLimit},
 		})
 		return
 	}
@@ -80,7 +76,7 @@ func (p publishV2ControllerImpl) Publish(w http.ResponseWriter, r *http.Request)
 				Status:  http.StatusBadRequest,
 				Code:    exception.ArchiveSizeExceeded,
 				Message: exception.ArchiveSizeExceededMsg,
-				Params:  map[string]interface{}{"size": p.publishArchiveSizeLimit},
+				Params:  map[string]interface{}{"size": publishArchiveSizeLimit},
 			})
 		} else {
 			utils.RespondWithCustomError(w, &exception.CustomError{
@@ -95,7 +91,7 @@ func (p publishV2ControllerImpl) Publish(w http.ResponseWriter, r *http.Request)
 	defer func() {
 		err := r.MultipartForm.RemoveAll()
 		if err != nil {
-			log.Debugf("failed to remove temporal data: %+v", err)
+			log.WithContext(r.Context()).Debugf("failed to remove temporal data: %+v", err)
 		}
 	}()
 
@@ -163,7 +159,7 @@ func (p publishV2ControllerImpl) Publish(w http.ResponseWriter, r *http.Request)
 		sourcesData, err = ioutil.ReadAll(sourcesFile)
 		closeErr := sourcesFile.Close()
 		if closeErr != nil {
-			log.Debugf("failed to close temporal file: %+v", err)
+			log.WithContext(r.Context()).Debugf("failed to close temporal file: %+v", err)
 		}
 		if err != nil {
 			utils.RespondWithCustomError(w, &exception.CustomError{
@@ -291,7 +287,13 @@ func (p publishV2ControllerImpl) Publish(w http.ResponseWriter, r *http.Request)
 		})
 		return
 	}
+	spanCtx, span := tracing.StartSpan(r.Context(), "build.enqueue", tracing.PackageIdKey.String(config.PackageId), tracing.VersionKey.String(config.Version))
+	config.TraceContext = tracing.InjectContext(spanCtx)
 	result, err := p.buildService.PublishVersion(ctx, config, sourcesData, clientBuild, builderId, dependencies, resolveRefs, resolveConflicts)
+	if result != nil {
+		span.SetAttributes(tracing.BuildIdKey.String(result.PublishId))
+	}
+	tracing.EndSpan(span, err)
 	if err != nil {
 		utils.RespondWithError(w, "Failed to publish package", err)
 		return
@@ -418,7 +420,7 @@ func (p publishV2ControllerImpl) SetPublishStatus(w http.ResponseWriter, r *http
 		return
 	}
 
-	limit := p.publishArchiveSizeLimit * 20
+	limit := p.systemInfoService.GetPublishArchiveSizeLimitMB() * 20
 
 	r.Body = http.MaxBytesReader(w, r.Body, limit)
 
@@ -445,7 +447,7 @@ func (p publishV2ControllerImpl) SetPublishStatus(w http.ResponseWriter, r *http
 	defer func() {
 		err := r.MultipartForm.RemoveAll()
 		if err != nil {
-			log.Debugf("failed to remove temporal data: %+v", err)
+			log.WithContext(r.Context()).Debugf("failed to remove temporal data: %+v", err)
 		}
 	}()
 
@@ -483,7 +485,9 @@ func (p publishV2ControllerImpl) SetPublishStatus(w http.ResponseWriter, r *http
 	switch status {
 	case view.StatusError:
 		details = r.FormValue("errors")
+		_, span := startBuildCompleteSpan(r, packageId, buildId, status)
 		err = p.buildService.UpdateBuildStatus(buildId, status, details)
+		tracing.EndSpan(span, err)
 		if err != nil {
 			utils.RespondWithError(w, "Failed to update build status", err)
 			return
@@ -511,7 +515,7 @@ func (p publishV2ControllerImpl) SetPublishStatus(w http.ResponseWriter, r *http
 		data, err = ioutil.ReadAll(sourcesFile)
 		closeErr := sourcesFile.Close()
 		if closeErr != nil {
-			log.Debugf("failed to close temporal file: %+v", err)
+			log.WithContext(r.Context()).Debugf("failed to close temporal file: %+v", err)
 		}
 		if err != nil {
 			utils.RespondWithCustomError(w, &exception.CustomError{
@@ -538,7 +542,9 @@ func (p publishV2ControllerImpl) SetPublishStatus(w http.ResponseWriter, r *http
 			utils.RespondWithError(w, "Failed to check user privileges", err)
 			return
 		}
+		_, span := startBuildCompleteSpan(r, packageId, buildId, status)
 		err = p.buildResultService.SaveBuildResult(packageId, data, fileHeader.Filename, buildId, availableVersionStatuses)
+		tracing.EndSpan(span, err)
 		if err != nil {
 			utils.RespondWithError(w, "Failed to publish build package", err)
 			return
@@ -574,7 +580,7 @@ func (p publishV2ControllerImpl) GetFreeBuild(w http.ResponseWriter, r *http.Req
 	builderId := getStringParam(r, "builderId")
 	start := time.Now()
 
-	src, err := p.buildService.GetFreeBuild(builderId)
+	src, err := p.buildService.GetFreeBuild(r.Context(), builderId)
 
 	if err != nil {
 		utils.RespondWithError(w, "Failed to get free build", err)
@@ -587,5 +593,14 @@ func (p publishV2ControllerImpl) GetFreeBuild(w http.ResponseWriter, r *http.Req
 	} else {
 		w.WriteHeader(http.StatusNoContent)
 	}
-	log.Debugf("GetFreeBuild took %dms", time.Since(start).Milliseconds())
+	log.WithContext(r.Context()).Debugf("GetFreeBuild took %dms", time.Since(start).Milliseconds())
+}
+
+// startBuildCompleteSpan starts a span in the trace of the builder request. Builder continues the trace of the publish request
+// if it sends the trace context received in the build config.
+func startBuildCompleteSpan(r *http.Request, packageId string, buildId string, status view.BuildStatusEnum) (stdctx.Context, trace.Span) {
+	return tracing.StartSpan(r.Context(), "build.complete",
+		tracing.PackageIdKey.String(packageId),
+		tracing.BuildIdKey.String(buildId),
+		tracing.BuildStatusKey.String(string(status)))
 }
@@ -62,7 +62,7 @@ func (v publishControllerImpl) GetVersionSources(w http.ResponseWriter, r *http.
 	}
 	srcArchive, err := v.publishedService.GetVersionSources(packageId, versionName)
 	if err != nil {
-		log.Error("Failed to get package version sources: ", err.Error())
+		log.WithContext(r.Context()).Error("Failed to get package version sources: ", err.Error())
 		if customError, ok := err.(*exception.CustomError); ok {
 			utils.RespondWithCustomError(w, customError)
 		} else {
@@ -109,7 +109,7 @@ func (v publishControllerImpl) GetPublishedVersionSourceDataConfig(w http.Respon
 	}
 	publishedVersionSourceDataConfig, err := v.publishedService.GetPublishedVersionSourceDataConfig(packageId, versionName)
 	if err != nil {
-		log.Error("Failed to get package version sources: ", err.Error())
+		log.WithContext(r.Context()).Error("Failed to get package version sources: ", err.Error())
 		if customError, ok := err.(*exception.CustomError); ok {
 			utils.RespondWithCustomError(w, customError)
 		} else {
@@ -155,7 +155,7 @@ func (v publishControllerImpl) GetPublishedVersionBuildConfig(w http.ResponseWri
 
 	publishedVersionBuildConfig, err := v.publishedService.GetPublishedVersionBuildConfig(packageId, versionName)
 	if err != nil {
-		log.Error("Failed to get package version build config: ", err.Error())
+		log.WithContext(r.Context()).Error("Failed to get package version build config: ", err.Error())
 		if customError, ok := err.(*exception.CustomError); ok {
 			utils.RespondWithCustomError(w, customError)
 		} else {
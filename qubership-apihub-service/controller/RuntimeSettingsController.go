@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type RuntimeSettingsController interface {
+	GetSettings(w http.ResponseWriter, r *http.Request)
+	UpdateSetting(w http.ResponseWriter, r *http.Request)
+	ResetSetting(w http.ResponseWriter, r *http.Request)
+	GetHistory(w http.ResponseWriter, r *http.Request)
+	GetNotifications(w http.ResponseWriter, r *http.Request)
+	CreateNotification(w http.ResponseWriter, r *http.Request)
+	UpdateNotification(w http.ResponseWriter, r *http.Request)
+	DeleteNotification(w http.ResponseWriter, r *http.Request)
+}
+
+func NewRuntimeSettingsController(runtimeSettingsService service.RuntimeSettingsService, roleService service.RoleService) RuntimeSettingsController {
+	return &runtimeSettingsControllerImpl{
+		runtimeSettingsService: runtimeSettingsService,
+		roleService:            roleService,
+	}
+}
+
+type runtimeSettingsControllerImpl struct {
+	runtimeSettingsService service.RuntimeSettingsService
+	roleService            service.RoleService
+}
+
+func (c runtimeSettingsControllerImpl) GetSettings(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, c.roleService) {
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, c.runtimeSettingsService.GetSettings())
+}
+
+func (c runtimeSettingsControllerImpl) UpdateSetting(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, c.roleService) {
+		return
+	}
+	var req view.RuntimeSettingUpdateReq
+	if !decodeAndValidateBody(w, r, &req) {
+		return
+	}
+	result, err := c.runtimeSettingsService.UpdateSetting(r.Context(), context.Create(r), getStringParam(r, "key"), req.Value)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to update runtime setting", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (c runtimeSettingsControllerImpl) ResetSetting(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, c.roleService) {
+		return
+	}
+	result, err := c.runtimeSettingsService.ResetSetting(r.Context(), context.Create(r), getStringParam(r, "key"))
+	if err != nil {
+		utils.RespondWithError(w, "Failed to reset runtime setting", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (c runtimeSettingsControllerImpl) GetHistory(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, c.roleService) {
+		return
+	}
+	limit, customError := getLimitQueryParam(r)
+	if customError != nil {
+		utils.RespondWithCustomError(w, customError)
+		return
+	}
+	page := 0
+	if r.URL.Query().Get("page") != "" {
+		var err error
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "page", "type": "int"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+	result, err := c.runtimeSettingsService.GetHistory(r.Context(), r.URL.Query().Get("key"), limit, page)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get runtime settings history", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (c runtimeSettingsControllerImpl) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, c.roleService) {
+		return
+	}
+	result, err := c.runtimeSettingsService.GetNotifications(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get system notifications", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (c runtimeSettingsControllerImpl) CreateNotification(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, c.roleService) {
+		return
+	}
+	var req view.SystemNotificationReq
+	if !decodeAndValidateBody(w, r, &req) {
+		return
+	}
+	result, err := c.runtimeSettingsService.CreateNotification(r.Context(), context.Create(r), req)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to create system notification", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusCreated, result)
+}
+
+func (c runtimeSettingsControllerImpl) UpdateNotification(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, c.roleService) {
+		return
+	}
+	var req view.SystemNotificationReq
+	if !decodeAndValidateBody(w, r, &req) {
+		return
+	}
+	result, err := c.runtimeSettingsService.UpdateNotification(r.Context(), context.Create(r), getStringParam(r, "id"), req)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to update system notification", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (c runtimeSettingsControllerImpl) DeleteNotification(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, c.roleService) {
+		return
+	}
+	err := c.runtimeSettingsService.DeleteNotification(r.Context(), getStringParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, "Failed to delete system notification", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,276 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type SavedComparisonController interface {
+	CreateSavedComparison(w http.ResponseWriter, r *http.Request)
+	GetSavedComparisons(w http.ResponseWriter, r *http.Request)
+	GetSavedComparison(w http.ResponseWriter, r *http.Request)
+	UpdateSavedComparison(w http.ResponseWriter, r *http.Request)
+	DeleteSavedComparison(w http.ResponseWriter, r *http.Request)
+	ExportSavedComparison(w http.ResponseWriter, r *http.Request)
+}
+
+func NewSavedComparisonController(savedComparisonService service.SavedComparisonService,
+	excelService service.ExcelService) SavedComparisonController {
+	return savedComparisonControllerImpl{savedComparisonService: savedComparisonService, excelService: excelService}
+}
+
+type savedComparisonControllerImpl struct {
+	savedComparisonService service.SavedComparisonService
+	excelService           service.ExcelService
+}
+
+func (s savedComparisonControllerImpl) CreateSavedComparison(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.SavedComparisonReq
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	validationErr := utils.ValidateObject(req)
+	if validationErr != nil {
+		var customError *exception.CustomError
+		if errors.As(validationErr, &customError) {
+			utils.RespondWithCustomError(w, customError)
+			return
+		}
+	}
+
+	result, err := s.savedComparisonService.CreateSavedComparison(r.Context(), ctx, req)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to create saved comparison", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusCreated, result)
+}
+
+func (s savedComparisonControllerImpl) GetSavedComparisons(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	limit, customError := getLimitQueryParam(r)
+	if customError != nil {
+		utils.RespondWithCustomError(w, customError)
+		return
+	}
+	page := 0
+	if r.URL.Query().Get("page") != "" {
+		var err error
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "page", "type": "int"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+
+	result, err := s.savedComparisonService.GetSavedComparisons(r.Context(), ctx, view.SavedComparisonsReq{
+		PackageId: r.URL.Query().Get("packageId"),
+		Limit:     limit,
+		Page:      page,
+	})
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get saved comparisons", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (s savedComparisonControllerImpl) GetSavedComparison(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	id := getStringParam(r, "comparisonId")
+
+	result, err := s.savedComparisonService.GetSavedComparison(r.Context(), ctx, id)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get saved comparison", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (s savedComparisonControllerImpl) UpdateSavedComparison(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	id := getStringParam(r, "comparisonId")
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.SavedComparisonUpdateReq
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	validationErr := utils.ValidateObject(req)
+	if validationErr != nil {
+		var customError *exception.CustomError
+		if errors.As(validationErr, &customError) {
+			utils.RespondWithCustomError(w, customError)
+			return
+		}
+	}
+
+	result, err := s.savedComparisonService.UpdateSavedComparison(r.Context(), ctx, id, req)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to update saved comparison", err)
+		return
+	}
+
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (s savedComparisonControllerImpl) DeleteSavedComparison(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	id := getStringParam(r, "comparisonId")
+
+	err := s.savedComparisonService.DeleteSavedComparison(r.Context(), ctx, id)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to delete saved comparison", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s savedComparisonControllerImpl) ExportSavedComparison(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	id := getStringParam(r, "comparisonId")
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = view.ExportFormatJson
+	}
+	if format != view.ExportFormatJson && format != view.ExportFormatXlsx {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.UnsupportedFormat,
+			Message: exception.UnsupportedFormatMsg,
+			Params:  map[string]interface{}{"format": format},
+		})
+		return
+	}
+	apiType := r.URL.Query().Get("apiType")
+	if apiType != "" {
+		if _, err := view.ParseApiType(apiType); err != nil {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.InvalidParameterValue,
+				Message: exception.InvalidParameterValueMsg,
+				Params:  map[string]interface{}{"param": "apiType", "value": apiType},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+	severities, customErr := getListFromParam(r, "severity")
+	if customErr != nil {
+		utils.RespondWithCustomError(w, customErr)
+		return
+	}
+	for _, severity := range severities {
+		if !view.ValidSeverity(severity) {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.InvalidParameterValue,
+				Message: exception.InvalidParameterValueMsg,
+				Params:  map[string]interface{}{"param": "severity", "value": severity},
+			})
+			return
+		}
+	}
+
+	switch format {
+	case view.ExportFormatJson:
+		changes, err := s.savedComparisonService.GetSavedComparisonChanges(r.Context(), ctx, id, apiType, severities)
+		if err != nil {
+			utils.RespondWithError(w, "Failed to get saved comparison changes", err)
+			return
+		}
+		utils.RespondWithJson(w, http.StatusOK, changes)
+	case view.ExportFormatXlsx:
+		comparison, err := s.savedComparisonService.GetSavedComparison(r.Context(), ctx, id)
+		if err != nil {
+			utils.RespondWithError(w, "Failed to get saved comparison", err)
+			return
+		}
+		if comparison.Status != view.SavedComparisonStatusComplete {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusNotFound,
+				Code:    exception.SavedComparisonNotCalculated,
+				Message: exception.SavedComparisonNotCalculatedMsg,
+				Params:  map[string]interface{}{"id": id, "status": comparison.Status},
+			})
+			return
+		}
+		report, versionName, err := s.excelService.ExportApiChanges(comparison.PackageId, comparison.Version, apiType, severities, view.ExportApiChangesRequestView{
+			PreviousVersion:          comparison.PreviousVersion,
+			PreviousVersionPackageId: comparison.PreviousVersionPackageId,
+		})
+		if err != nil {
+			utils.RespondWithError(w, "Failed to export saved comparison", err)
+			return
+		}
+		if report == nil {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusNotFound,
+				Code:    exception.ChangesAreEmpty,
+				Message: exception.ChangesAreEmptyMsg,
+			})
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="APIChanges_%s_%s.xlsx"`, comparison.PackageId, versionName))
+		w.Header().Set("Content-Transfer-Encoding", "binary")
+		w.Header().Set("Expires", "0")
+		report.Write(w)
+		report.Close()
+	}
+}
@@ -12,13 +12,14 @@ type SystemInfoController interface {
 	GetSystemInfo(w http.ResponseWriter, r *http.Request)
 }
 
-func NewSystemInfoController(service service.SystemInfoService, migrationService mservice.DBMigrationService) SystemInfoController {
-	return &systemInfoControllerImpl{service: service, migrationService: migrationService}
+func NewSystemInfoController(service service.SystemInfoService, migrationService mservice.DBMigrationService, runtimeSettingsService service.RuntimeSettingsService) SystemInfoController {
+	return &systemInfoControllerImpl{service: service, migrationService: migrationService, runtimeSettingsService: runtimeSettingsService}
 }
 
 type systemInfoControllerImpl struct {
-	service          service.SystemInfoService
-	migrationService mservice.DBMigrationService
+	service                service.SystemInfoService
+	migrationService       mservice.DBMigrationService
+	runtimeSettingsService service.RuntimeSettingsService
 }
 
 func (g systemInfoControllerImpl) GetSystemInfo(w http.ResponseWriter, r *http.Request) {
@@ -29,5 +30,9 @@ func (g systemInfoControllerImpl) GetSystemInfo(w http.ResponseWriter, r *http.R
 	}
 	systemInfo := g.service.GetSystemInfo()
 	systemInfo.MigrationInProgress = migrationInProgress
+	systemInfo.Notifications = g.runtimeSettingsService.GetActiveNotifications()
+	if len(systemInfo.Notifications) > 0 {
+		systemInfo.Notification = systemInfo.Notifications[0].Message
+	}
 	utils.RespondWithJson(w, http.StatusOK, systemInfo)
 }
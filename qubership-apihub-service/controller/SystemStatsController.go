@@ -2,15 +2,18 @@ package controller
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
 )
 
 type SystemStatsController interface {
 	GetSystemStats(w http.ResponseWriter, r *http.Request)
+	GetStorageUsage(w http.ResponseWriter, r *http.Request)
 }
 
 func NewSystemStatsController(statsService service.SystemStatsService, roleService service.RoleService) SystemStatsController {
@@ -43,3 +46,55 @@ func (s systemStatsControllerImpl) GetSystemStats(w http.ResponseWriter, r *http
 	}
 	utils.RespondWithJson(w, http.StatusOK, stats)
 }
+
+func (s systemStatsControllerImpl) GetStorageUsage(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	sufficientPrivileges := s.roleService.IsSysadm(ctx)
+	if !sufficientPrivileges {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	groupBy := r.URL.Query().Get("groupBy")
+	if groupBy == "" {
+		groupBy = view.StorageUsageGroupByPackage
+	}
+	if groupBy != view.StorageUsageGroupByPackage && groupBy != view.StorageUsageGroupByWorkspace {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "groupBy", "value": groupBy},
+		})
+		return
+	}
+	limit, customError := getLimitQueryParam(r)
+	if customError != nil {
+		utils.RespondWithCustomError(w, customError)
+		return
+	}
+	page := 0
+	if r.URL.Query().Get("page") != "" {
+		var err error
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			utils.RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "page", "type": "int"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+	storageUsage, err := s.statsService.GetStorageUsage(r.Context(), groupBy, limit, page)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get storage usage", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, storageUsage)
+}
@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type TenantController interface {
+	GetTenant(w http.ResponseWriter, r *http.Request)
+	UpdateQuotas(w http.ResponseWriter, r *http.Request)
+	UpdateSettings(w http.ResponseWriter, r *http.Request)
+	GetUsage(w http.ResponseWriter, r *http.Request)
+	GetTenantsUsage(w http.ResponseWriter, r *http.Request)
+	GetAdmins(w http.ResponseWriter, r *http.Request)
+	AddAdmins(w http.ResponseWriter, r *http.Request)
+	DeleteAdmin(w http.ResponseWriter, r *http.Request)
+}
+
+func NewTenantController(tenantService service.TenantService, roleService service.RoleService) TenantController {
+	return &tenantControllerImpl{
+		tenantService: tenantService,
+		roleService:   roleService,
+	}
+}
+
+type tenantControllerImpl struct {
+	tenantService service.TenantService
+	roleService   service.RoleService
+}
+
+func (t tenantControllerImpl) GetTenant(w http.ResponseWriter, r *http.Request) {
+	workspaceId := getStringParam(r, "workspaceId")
+	if !t.checkTenantAdmin(w, r, workspaceId) {
+		return
+	}
+	result, err := t.tenantService.GetTenant(r.Context(), workspaceId)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get workspace tenant", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (t tenantControllerImpl) UpdateQuotas(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, t.roleService) {
+		return
+	}
+	workspaceId := getStringParam(r, "workspaceId")
+	var req view.TenantQuotas
+	if !decodeAndValidateBody(w, r, &req) {
+		return
+	}
+	result, err := t.tenantService.UpdateQuotas(r.Context(), context.Create(r), workspaceId, req)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to update workspace quotas", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (t tenantControllerImpl) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	workspaceId := getStringParam(r, "workspaceId")
+	if !t.checkTenantAdmin(w, r, workspaceId) {
+		return
+	}
+	var req view.TenantSettings
+	if !decodeAndValidateBody(w, r, &req) {
+		return
+	}
+	result, err := t.tenantService.UpdateSettings(r.Context(), context.Create(r), workspaceId, req)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to update workspace settings", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (t tenantControllerImpl) GetUsage(w http.ResponseWriter, r *http.Request) {
+	workspaceId := getStringParam(r, "workspaceId")
+	if !t.checkTenantAdmin(w, r, workspaceId) {
+		return
+	}
+	result, err := t.tenantService.GetUsage(r.Context(), workspaceId)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get workspace usage", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (t tenantControllerImpl) GetTenantsUsage(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, t.roleService) {
+		return
+	}
+	result, err := t.tenantService.GetTenantsUsage(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get workspaces usage", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (t tenantControllerImpl) GetAdmins(w http.ResponseWriter, r *http.Request) {
+	workspaceId := getStringParam(r, "workspaceId")
+	if !t.checkTenantAdmin(w, r, workspaceId) {
+		return
+	}
+	result, err := t.tenantService.GetAdmins(r.Context(), workspaceId)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get workspace administrators", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (t tenantControllerImpl) AddAdmins(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, t.roleService) {
+		return
+	}
+	workspaceId := getStringParam(r, "workspaceId")
+	var req view.AddTenantAdminsReq
+	if !decodeAndValidateBody(w, r, &req) {
+		return
+	}
+	result, err := t.tenantService.AddAdmins(r.Context(), context.Create(r), workspaceId, req.UserIds)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to add workspace administrators", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (t tenantControllerImpl) DeleteAdmin(w http.ResponseWriter, r *http.Request) {
+	if !checkSysadm(w, r, t.roleService) {
+		return
+	}
+	workspaceId := getStringParam(r, "workspaceId")
+	userId := getStringParam(r, "userId")
+	err := t.tenantService.DeleteAdmin(r.Context(), workspaceId, userId)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to delete workspace administrator", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (t tenantControllerImpl) checkTenantAdmin(w http.ResponseWriter, r *http.Request, workspaceId string) bool {
+	tenantAdmin, err := t.tenantService.IsTenantAdmin(context.Create(r), workspaceId)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to check user privileges", err)
+		return false
+	}
+	if !tenantAdmin {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return false
+	}
+	return true
+}
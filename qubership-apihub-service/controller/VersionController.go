@@ -167,11 +167,25 @@ func (v versionControllerImpl) GetVersionedDocument(w http.ResponseWriter, r *ht
 	v.monitoringService.AddDocumentOpenCount(packageId, versionName, slug)
 	v.monitoringService.IncreaseBusinessMetricCounter(ctx.GetUserId(), metrics.DocumentsCalled, packageId)
 
+	cacheInfo, err := v.versionService.GetContentCacheInfo(packageId, versionName, slug)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to get versioned document", err)
+		return
+	}
+	// document includes mutable shareability status, so it has to be revalidated even for the explicit revision.
+	// Last-Modified is not used since the publication date does not change with shareability, only ETag includes it.
+	cacheInfo.RevisionPinned = false
+	etag := makePublishedETag(cacheInfo, "document", slug, cacheInfo.Shareability)
+	if utils.RespondNotModified(w, r, etag, time.Time{}, cacheInfo.GetCacheControl()) {
+		return
+	}
+
 	document, err := v.versionService.GetLatestDocumentBySlug(packageId, versionName, slug)
 	if err != nil {
 		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to get versioned document", err)
 		return
 	}
+	utils.SetCacheHeaders(w, etag, time.Time{}, cacheInfo.GetCacheControl())
 	utils.RespondWithJson(w, http.StatusOK, document)
 }
 
@@ -728,11 +742,22 @@ func (v versionControllerImpl) GetVersionedContentFileRaw(w http.ResponseWriter,
 	v.monitoringService.AddDocumentOpenCount(packageId, versionName, slug)
 	v.monitoringService.IncreaseBusinessMetricCounter(ctx.GetUserId(), metrics.DocumentsCalled, packageId)
 
+	cacheInfo, err := v.versionService.GetContentCacheInfo(packageId, versionName, slug)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to get published content", err)
+		return
+	}
+	etag := makePublishedETag(cacheInfo, "raw", slug)
+	if utils.RespondNotModified(w, r, etag, cacheInfo.PublishedAt, cacheInfo.GetCacheControl()) {
+		return
+	}
+
 	content, contentData, err := v.versionService.GetLatestContentDataBySlug(packageId, versionName, slug)
 	if err != nil {
 		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to get published content", err)
 		return
 	}
+	utils.SetCacheHeaders(w, etag, cacheInfo.PublishedAt, cacheInfo.GetCacheControl())
 	w.Header().Set("Content-Type", contentData.DataType)
 	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", content.Name))
 	w.WriteHeader(http.StatusOK)
@@ -1173,7 +1198,7 @@ func (v versionControllerImpl) parseCSVPublishRequest(w http.ResponseWriter, r *
 	defer func() {
 		err := r.MultipartForm.RemoveAll()
 		if err != nil {
-			log.Debugf("failed to remove temporary data: %+v", err)
+			log.WithContext(r.Context()).Debugf("failed to remove temporary data: %+v", err)
 		}
 	}()
 	csvPublishReq := view.PublishFromCSVReq{}
@@ -1210,7 +1235,7 @@ func (v versionControllerImpl) parseCSVPublishRequest(w http.ResponseWriter, r *
 		csvData, err := io.ReadAll(csvFile)
 		closeErr := csvFile.Close()
 		if closeErr != nil {
-			log.Errorf("failed to close temporary file: %+v", err)
+			log.WithContext(r.Context()).Errorf("failed to close temporary file: %+v", err)
 		}
 		if err != nil {
 			utils.RespondWithCustomError(w, &exception.CustomError{
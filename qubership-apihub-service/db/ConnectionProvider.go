@@ -1,16 +1,32 @@
 package db
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/tracing"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
 	"github.com/go-pg/pg/v10"
+	log "github.com/sirupsen/logrus"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ConnectionProvider interface {
 	GetConnection() *pg.DB
+	// GetReadConnection returns connection for read-only queries which tolerate bounded staleness.
+	// It may point to a read replica, so it must not be used for reads which are followed by writes.
+	GetReadConnection(ctx context.Context) *pg.DB
+	// MarkUserWrite routes read-only queries of the user to the primary database for a while, so the user sees own changes
+	MarkUserWrite(userId string)
 }
 
+const (
+	poolSize   = 50
+	maxRetries = 5
+)
+
 type connectionProviderImpl struct {
 	creds view.DbCredentials
 	db    *pg.DB
@@ -27,51 +43,53 @@ func (c *connectionProviderImpl) GetConnection() *pg.DB {
 			User:       c.creds.Username,
 			Password:   c.creds.Password,
 			Database:   c.creds.Database,
-			PoolSize:   50,
-			MaxRetries: 5,
+			PoolSize:   poolSize,
+			MaxRetries: maxRetries,
 		})
+		c.db.AddQueryHook(dbLogger{})
 	}
-	//c.db.AddQueryHook(&dbLogger{cpi: c})
 	return c.db
 }
 
-//TODO: is this still needed ?
-/*
-type dbLogger struct {
-	cpi *connectionProviderImpl
+func (c *connectionProviderImpl) GetReadConnection(ctx context.Context) *pg.DB {
+	return c.GetConnection()
+}
+
+func (c *connectionProviderImpl) MarkUserWrite(userId string) {
 }
 
+type dbSpanStashKey struct{}
+
+// dbLogger traces queries executed within a traced request or job and logs them on trace level
+type dbLogger struct{}
+
 func (d dbLogger) BeforeQuery(ctx context.Context, q *pg.QueryEvent) (context.Context, error) {
+	ctx, span := tracing.StartChildSpan(ctx, "db.query", semconv.DBSystemPostgreSQL)
+	if span.IsRecording() {
+		if q.Stash == nil {
+			q.Stash = make(map[interface{}]interface{})
+		}
+		q.Stash[dbSpanStashKey{}] = span
+	}
 	return ctx, nil
 }
 
 func (d dbLogger) AfterQuery(ctx context.Context, q *pg.QueryEvent) error {
-	if query, _ := q.FormattedQuery(); bytes.Compare(query, []byte("SELECT 1")) != 0 {
-		log.Trace(string(query))
+	if span, ok := q.Stash[dbSpanStashKey{}].(trace.Span); ok {
+		// unformatted query does not contain parameter values
+		if query, err := q.UnformattedQuery(); err == nil {
+			span.SetAttributes(semconv.DBQueryText(string(query)))
+		}
+		err := q.Err
+		if err == pg.ErrNoRows {
+			err = nil
+		}
+		tracing.EndSpan(span, err)
 	}
-
-	if q.Err != nil && strings.Contains(q.Err.Error(), "Conn is in a bad state") {
-		if d.cpi != nil {
-			if d.cpi.conn != nil {
-				err := d.cpi.conn.Close()
-				if err != nil {
-					log.Errorf("Failed to close conn for bad state: %s", err)
-				}
-			}
-			if d.cpi.db != nil {
-				err := d.cpi.db.Close()
-				if err != nil {
-					log.Errorf("Failed to close DB for bad state: %s", err)
-				}
-			}
+	if log.IsLevelEnabled(log.TraceLevel) {
+		if query, err := q.FormattedQuery(); err == nil && !bytes.Equal(query, []byte("SELECT 1")) {
+			log.WithContext(ctx).Trace(string(query))
 		}
-		d.cpi.db = nil
-		d.cpi.conn = nil
 	}
-
-	// for dev purposes
-	//queryStr, _ := q.FormattedQuery()
-	//log.Infof("DB query: %s", queryStr)
-
 	return nil
-}*/
+}
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutingConnectionProviderGetReadConnection(t *testing.T) {
+	primary := NewConnectionProvider(&view.DbCredentials{Host: "primary", Port: 5432})
+	replica0 := &readReplica{name: "replica-0", db: pg.Connect(&pg.Options{Addr: "replica-0:5432"})}
+	replica1 := &readReplica{name: "replica-1", db: pg.Connect(&pg.Options{Addr: "replica-1:5432"})}
+	c := &routingConnectionProviderImpl{
+		primary:           primary,
+		replicas:          []*readReplica{replica0, replica1},
+		maxLag:            5 * time.Second,
+		primaryStickiness: time.Minute,
+	}
+	ctx := auth.CtxWithUser(context.Background(), auth.NewUserInfo("user", "user", nil, nil))
+
+	require.Same(t, primary.GetConnection(), c.GetReadConnection(ctx), "replicas are not available before the first check")
+
+	replica0.available.Store(true)
+	replica1.available.Store(true)
+	first := c.GetReadConnection(ctx)
+	second := c.GetReadConnection(ctx)
+	require.NotSame(t, first, second, "replicas are used in round-robin order")
+	require.NotSame(t, primary.GetConnection(), first)
+
+	replica1.available.Store(false)
+	for i := 0; i < 3; i++ {
+		require.Same(t, replica0.db, c.GetReadConnection(ctx))
+	}
+
+	c.MarkUserWrite("user")
+	require.Same(t, primary.GetConnection(), c.GetReadConnection(ctx), "user who has published recently reads from primary")
+	require.Same(t, replica0.db, c.GetReadConnection(context.Background()), "other users still read from replica")
+
+	c.userWrites.Store("user", time.Now().Add(-2*time.Minute))
+	c.cleanupUserWrites()
+	require.Same(t, replica0.db, c.GetReadConnection(ctx))
+	require.Same(t, primary.GetConnection(), c.GetConnection())
+}
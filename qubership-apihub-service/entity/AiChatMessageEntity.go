@@ -15,6 +15,7 @@ type AiChatMessageEntity struct {
 	Content         string                      `pg:"content, type:text"`
 	ClientMessageID *string                     `pg:"client_message_id, type:uuid"`
 	ToolInvocations []view.AiChatToolInvocation `pg:"tool_invocations, type:jsonb"`
+	Citations       []view.AiChatCitation       `pg:"citations, type:jsonb"`
 	CreatedAt       time.Time                   `pg:"created_at"`
 }
 
@@ -31,5 +32,6 @@ func MakeAiChatMessageView(m *AiChatMessageEntity) *view.AiChatMessage {
 		Content:         m.Content,
 		CreatedAt:       m.CreatedAt.UTC().Format(time.RFC3339),
 		ToolInvocations: m.ToolInvocations,
+		Citations:       m.Citations,
 	}
 }
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type AiChatShareEntity struct {
+	tableName struct{} `pg:"ai_chat_share, alias:ai_chat_share"`
+
+	ID         string               `pg:"id, pk, type:uuid"`
+	ChatID     string               `pg:"chat_id, type:uuid"`
+	UserID     string               `pg:"user_id, type:varchar"`
+	Title      string               `pg:"title, type:text, use_zero"`
+	Messages   []view.AiChatMessage `pg:"messages, type:jsonb"`
+	PackageIDs []string             `pg:"package_ids, type:varchar[], array"`
+	// PackageIDsComplete is false if the packages referenced by the chat are unknown, such share is available to its owner only
+	PackageIDsComplete bool      `pg:"package_ids_complete, use_zero"`
+	CreatedAt          time.Time `pg:"created_at"`
+}
+
+type AiChatShareFileEntity struct {
+	tableName struct{} `pg:"ai_chat_share_file, alias:ai_chat_share_file"`
+
+	ShareID   string  `pg:"share_id, pk, type:uuid"`
+	FileID    string  `pg:"file_id, pk, type:uuid"`
+	Filename  string  `pg:"filename, type:text"`
+	MimeType  *string `pg:"mime_type, type:varchar"`
+	SizeBytes int64   `pg:"size_bytes, use_zero"`
+	Data      []byte  `pg:"data, type:bytea"`
+}
+
+func MakeAiChatShareView(e *AiChatShareEntity) *view.AiChatShare {
+	return &view.AiChatShare{
+		ShareID:       e.ID,
+		ChatID:        e.ChatID,
+		Title:         e.Title,
+		SharedBy:      e.UserID,
+		PackageIds:    e.PackageIDs,
+		MessagesCount: len(e.Messages),
+		CreatedAt:     e.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func MakeAiChatShareFileView(e *AiChatShareFileEntity) view.AiChatSharedFile {
+	return view.AiChatSharedFile{
+		FileID:    e.FileID,
+		Filename:  e.Filename,
+		SizeBytes: e.SizeBytes,
+	}
+}
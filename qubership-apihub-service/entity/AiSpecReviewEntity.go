@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type AiSpecReviewConfigEntity struct {
+	tableName struct{} `pg:"ai_spec_review_config"`
+
+	PackageId   string    `pg:"package_id, pk, type:varchar"`
+	Enabled     bool      `pg:"enabled, type:boolean, use_zero"`
+	TokenBudget *int      `pg:"token_budget, type:integer"`
+	UpdatedBy   string    `pg:"updated_by, type:varchar"`
+	UpdatedAt   time.Time `pg:"updated_at, type:timestamp without time zone"`
+}
+
+type AiSpecReviewEntity struct {
+	tableName struct{} `pg:"ai_spec_review"`
+
+	PackageId          string     `pg:"package_id, pk, type:varchar"`
+	Version            string     `pg:"version, pk, type:varchar"`
+	Revision           int        `pg:"revision, pk, type:integer"`
+	Status             string     `pg:"status, type:varchar"`
+	Details            string     `pg:"details, type:text"`
+	OperationsTotal    int        `pg:"operations_total, type:integer, use_zero"`
+	OperationsReviewed int        `pg:"operations_reviewed, type:integer, use_zero"`
+	TokenBudget        int        `pg:"token_budget, type:integer, use_zero"`
+	TokensUsed         int        `pg:"tokens_used, type:integer, use_zero"`
+	StartedAt          time.Time  `pg:"started_at, type:timestamp without time zone"`
+	FinishedAt         *time.Time `pg:"finished_at, type:timestamp without time zone"`
+}
+
+type AiSpecReviewNotificationEntity struct {
+	tableName struct{} `pg:"ai_spec_review_notification"`
+
+	PackageId   string `pg:"package_id, type:varchar"`
+	Version     string `pg:"version, type:varchar"`
+	Revision    int    `pg:"revision, type:integer"`
+	Severity    int    `pg:"severity, type:integer, use_zero"`
+	Category    string `pg:"category, type:varchar"`
+	Message     string `pg:"message, type:varchar"`
+	ApiType     string `pg:"api_type, type:varchar"`
+	OperationId string `pg:"operation_id, type:varchar"`
+}
+
+// AiSpecReviewOperationEntity is an operation of the reviewed revision together with its specification fragment.
+type AiSpecReviewOperationEntity struct {
+	tableName struct{} `pg:"operation, alias:o"`
+
+	OperationId string         `pg:"operation_id, type:varchar"`
+	ApiType     string         `pg:"type, type:varchar"`
+	Title       string         `pg:"title, type:varchar"`
+	Data        CompressedData `pg:"data, type:bytea"`
+}
+
+func MakeAiSpecReviewView(ent *AiSpecReviewEntity, notifications []AiSpecReviewNotificationEntity) *view.AiSpecReview {
+	result := &view.AiSpecReview{
+		PackageId:          ent.PackageId,
+		Version:            ent.Version,
+		Revision:           ent.Revision,
+		Status:             ent.Status,
+		Details:            ent.Details,
+		OperationsTotal:    ent.OperationsTotal,
+		OperationsReviewed: ent.OperationsReviewed,
+		TokenBudget:        ent.TokenBudget,
+		TokensUsed:         ent.TokensUsed,
+		StartedAt:          ent.StartedAt,
+		FinishedAt:         ent.FinishedAt,
+		Notifications:      make([]view.AiSpecReviewNotification, 0, len(notifications)),
+	}
+	for _, n := range notifications {
+		result.Notifications = append(result.Notifications, view.AiSpecReviewNotification{
+			Severity:    n.Severity,
+			Category:    n.Category,
+			Message:     n.Message,
+			ApiType:     n.ApiType,
+			OperationId: n.OperationId,
+		})
+	}
+	return result
+}
+
+// MakeAiSpecReviewProblemView makes the validation message of the version revision from the AI spec review finding
+func MakeAiSpecReviewProblemView(ent AiSpecReviewNotificationEntity) view.VersionSpectralData_deprecated {
+	return view.VersionSpectralData_deprecated{
+		Message:  fmt.Sprintf("AI review (%s) of %s operation %s: %s", ent.Category, ent.ApiType, ent.OperationId, ent.Message),
+		Severity: ent.Severity,
+	}
+}
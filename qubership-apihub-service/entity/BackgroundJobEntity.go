@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type BackgroundJobStateEntity struct {
+	tableName struct{} `pg:"background_job_state"`
+
+	JobName   string    `pg:"job_name, pk, type:varchar"`
+	Disabled  bool      `pg:"disabled, use_zero, type:boolean"`
+	UpdatedBy string    `pg:"updated_by, type:varchar"`
+	UpdatedAt time.Time `pg:"updated_at, type:timestamp without time zone"`
+}
+
+type BackgroundJobRunEntity struct {
+	tableName struct{} `pg:"background_job_run"`
+
+	RunId             string     `pg:"run_id, pk, type:uuid"`
+	JobName           string     `pg:"job_name, type:varchar"`
+	InstanceId        string     `pg:"instance_id, type:uuid"`
+	TriggeredBy       string     `pg:"triggered_by, type:varchar"`
+	Status            string     `pg:"status, type:varchar"`
+	Attempts          int        `pg:"attempts, use_zero, type:integer"`
+	Details           string     `pg:"details, type:varchar"`
+	StartedAt         time.Time  `pg:"started_at, type:timestamp without time zone"`
+	FinishedAt        *time.Time `pg:"finished_at, type:timestamp without time zone"`
+	CancelRequestedBy string     `pg:"cancel_requested_by, type:varchar"`
+}
+
+func MakeBackgroundJobRunView(ent BackgroundJobRunEntity) view.BackgroundJobRun {
+	return view.BackgroundJobRun{
+		RunId:             ent.RunId,
+		JobName:           ent.JobName,
+		InstanceId:        ent.InstanceId,
+		TriggeredBy:       ent.TriggeredBy,
+		Status:            ent.Status,
+		Attempts:          ent.Attempts,
+		Details:           ent.Details,
+		StartedAt:         ent.StartedAt,
+		FinishedAt:        ent.FinishedAt,
+		CancelRequestedBy: ent.CancelRequestedBy,
+	}
+}
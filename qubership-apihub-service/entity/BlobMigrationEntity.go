@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type BlobMigrationRunEntity struct {
+	tableName struct{} `pg:"blob_migration_run"`
+
+	RunId             string                                `pg:"run_id, pk, type:uuid"`
+	SourceBackend     string                                `pg:"source_backend, type:varchar"`
+	TargetBackend     string                                `pg:"target_backend, type:varchar"`
+	Namespaces        []string                              `pg:"namespaces, type:varchar[], array"`
+	DeleteSource      bool                                  `pg:"delete_source, use_zero, type:boolean"`
+	Status            string                                `pg:"status, type:varchar"`
+	Details           string                                `pg:"details, type:varchar"`
+	Progress          []view.BlobMigrationNamespaceProgress `pg:"progress, type:jsonb"`
+	InstanceId        string                                `pg:"instance_id, type:uuid"`
+	CreatedBy         string                                `pg:"created_by, type:varchar"`
+	CreatedAt         time.Time                             `pg:"created_at, type:timestamp without time zone"`
+	LastActive        time.Time                             `pg:"last_active, type:timestamp without time zone"`
+	FinishedAt        *time.Time                            `pg:"finished_at, type:timestamp without time zone"`
+	CancelRequestedBy string                                `pg:"cancel_requested_by, type:varchar"`
+}
+
+func MakeBlobMigrationView(ent BlobMigrationRunEntity) view.BlobMigration {
+	return view.BlobMigration{
+		RunId:             ent.RunId,
+		SourceBackend:     ent.SourceBackend,
+		TargetBackend:     ent.TargetBackend,
+		Namespaces:        ent.Namespaces,
+		DeleteSource:      ent.DeleteSource,
+		Status:            ent.Status,
+		Details:           ent.Details,
+		Progress:          ent.Progress,
+		CreatedBy:         ent.CreatedBy,
+		CreatedAt:         ent.CreatedAt,
+		LastActive:        ent.LastActive,
+		FinishedAt:        ent.FinishedAt,
+		CancelRequestedBy: ent.CancelRequestedBy,
+	}
+}
@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type CleanupRetentionPolicyEntity struct {
+	tableName struct{} `pg:"cleanup_retention_policy"`
+
+	PackageId              string    `pg:"package_id, pk, type:varchar"`
+	DataClass              string    `pg:"data_class, pk, type:varchar"`
+	TTLDays                *int      `pg:"ttl_days, type:integer"`
+	KeepLastDraftRevisions *int      `pg:"keep_last_draft_revisions, type:integer"`
+	KeepReleaseRevisions   *bool     `pg:"keep_release_revisions, type:boolean"`
+	ArchivedTTLDays        *int      `pg:"archived_ttl_days, type:integer"`
+	UpdatedBy              string    `pg:"updated_by, type:varchar"`
+	UpdatedAt              time.Time `pg:"updated_at, type:timestamp without time zone"`
+}
+
+type CleanupDryRunEntity struct {
+	tableName struct{} `pg:"cleanup_dry_run"`
+
+	RunId      string                    `pg:"run_id, pk, type:uuid"`
+	JobType    string                    `pg:"job_type, type:varchar"`
+	PackageId  string                    `pg:"package_id, type:varchar"`
+	Status     string                    `pg:"status, type:varchar"`
+	Details    string                    `pg:"details, type:varchar"`
+	CreatedBy  string                    `pg:"created_by, type:varchar"`
+	StartedAt  time.Time                 `pg:"started_at, type:timestamp without time zone"`
+	FinishedAt *time.Time                `pg:"finished_at, type:timestamp without time zone"`
+	Report     *view.CleanupDryRunReport `pg:"report, type:jsonb"`
+}
+
+// CleanupJobRunEntity is a row of the union of all cleanup job run tables, the run id matches the id of the background job run
+type CleanupJobRunEntity struct {
+	RunId        string     `pg:"run_id"`
+	JobType      string     `pg:"job_type"`
+	InstanceId   string     `pg:"instance_id"`
+	PackageId    string     `pg:"package_id"`
+	Status       string     `pg:"status"`
+	Details      string     `pg:"details"`
+	StartedAt    time.Time  `pg:"started_at"`
+	FinishedAt   *time.Time `pg:"finished_at"`
+	DeleteBefore *time.Time `pg:"delete_before"`
+	DeletedItems int        `pg:"deleted_items"`
+	TriggeredBy  string     `pg:"triggered_by"`
+}
+
+// RevisionCleanupCandidateEntity is a revision which the revisions cleanup would delete
+type RevisionCleanupCandidateEntity struct {
+	PackageId string
+	Version   string
+	Revision  int
+	Status    string
+	Reason    string
+	Bytes     int64
+}
+
+// ComparisonCleanupInfoEntity holds the data required to report a comparison in cleanup dry run
+type ComparisonCleanupInfoEntity struct {
+	Referenced bool  `pg:"referenced"`
+	Bytes      int64 `pg:"bytes"`
+}
+
+// SoftDeletedCleanupCandidateEntity is a package or package revision which the soft deleted data cleanup would delete
+// UnreferencedContentCandidateEntity is the content entry which would be deleted by the unreferenced data cleanup,
+// TotalCount and TotalBytes are calculated over all such entries of the content table
+type UnreferencedContentCandidateEntity struct {
+	PackageId  string `pg:"package_id"`
+	ContentKey string `pg:"content_key"`
+	Bytes      int64  `pg:"bytes"`
+	TotalCount int    `pg:"total_count"`
+	TotalBytes int64  `pg:"total_bytes"`
+}
+
+type SoftDeletedCleanupCandidateEntity struct {
+	PackageId string `pg:"package_id"`
+	Version   string `pg:"version"`
+	Revision  int    `pg:"revision"`
+	Status    string `pg:"status"`
+	Bytes     int64  `pg:"bytes"`
+}
+
+func MakeCleanupRetentionPolicyView(ent CleanupRetentionPolicyEntity) view.CleanupRetentionPolicy {
+	return view.CleanupRetentionPolicy{
+		PackageId:              ent.PackageId,
+		DataClass:              ent.DataClass,
+		TTLDays:                ent.TTLDays,
+		KeepLastDraftRevisions: ent.KeepLastDraftRevisions,
+		KeepReleaseRevisions:   ent.KeepReleaseRevisions,
+		ArchivedTTLDays:        ent.ArchivedTTLDays,
+		UpdatedBy:              ent.UpdatedBy,
+		UpdatedAt:              ent.UpdatedAt,
+	}
+}
+
+func MakeCleanupDryRunView(ent CleanupDryRunEntity) view.CleanupDryRun {
+	return view.CleanupDryRun{
+		RunId:      ent.RunId,
+		JobType:    ent.JobType,
+		PackageId:  ent.PackageId,
+		Status:     ent.Status,
+		Details:    ent.Details,
+		CreatedBy:  ent.CreatedBy,
+		StartedAt:  ent.StartedAt,
+		FinishedAt: ent.FinishedAt,
+		Report:     ent.Report,
+	}
+}
+
+func MakeCleanupJobRunView(ent CleanupJobRunEntity) view.CleanupJobRun {
+	return view.CleanupJobRun{
+		RunId:        ent.RunId,
+		JobType:      ent.JobType,
+		InstanceId:   ent.InstanceId,
+		PackageId:    ent.PackageId,
+		Status:       ent.Status,
+		Details:      ent.Details,
+		StartedAt:    ent.StartedAt,
+		FinishedAt:   ent.FinishedAt,
+		DeleteBefore: ent.DeleteBefore,
+		DeletedItems: ent.DeletedItems,
+		TriggeredBy:  ent.TriggeredBy,
+	}
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/go-pg/pg/v10/types"
+)
+
+// CompressedData is a bytea column which is transparently compressed with zstd on write and decompressed on read.
+// Rows written before compression was introduced are read as is.
+type CompressedData []byte
+
+var _ types.ValueAppender = CompressedData(nil)
+var _ types.ValueScanner = (*CompressedData)(nil)
+
+func (d CompressedData) AppendValue(b []byte, flags int) ([]byte, error) {
+	if d == nil {
+		return types.AppendNull(b, flags), nil
+	}
+	return types.AppendBytes(b, utils.CompressData(d), flags), nil
+}
+
+func (d *CompressedData) ScanValue(rd types.Reader, n int) error {
+	data, err := types.ScanBytes(rd, n)
+	if err != nil {
+		return err
+	}
+	data, err = utils.DecompressData(data)
+	if err != nil {
+		return fmt.Errorf("failed to decompress data: %w", err)
+	}
+	*d = data
+	return nil
+}
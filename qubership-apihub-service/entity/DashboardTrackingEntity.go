@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type DashboardTrackingEntity struct {
+	tableName struct{} `pg:"dashboard_tracking"`
+
+	PackageId      string    `pg:"package_id, pk, type:varchar"`
+	Version        string    `pg:"version, pk, type:varchar"`
+	Rule           string    `pg:"rule, type:varchar"`
+	VersionPattern string    `pg:"version_pattern, type:varchar, use_zero"`
+	Enabled        bool      `pg:"enabled, type:boolean, use_zero"`
+	UpdatedBy      string    `pg:"updated_by, type:varchar"`
+	UpdatedAt      time.Time `pg:"updated_at, type:timestamp without time zone"`
+}
+
+type DashboardRollForwardEntity struct {
+	tableName struct{} `pg:"dashboard_roll_forward"`
+
+	Id               string          `pg:"id, pk, type:varchar"`
+	PackageId        string          `pg:"package_id, type:varchar"`
+	Version          string          `pg:"version, type:varchar"`
+	Revision         int             `pg:"revision, type:integer"`
+	PublishId        string          `pg:"publish_id, type:varchar"`
+	TriggerPackageId string          `pg:"trigger_package_id, type:varchar"`
+	TriggerVersion   string          `pg:"trigger_version, type:varchar"`
+	TriggerRevision  int             `pg:"trigger_revision, type:integer"`
+	MovedRefs        []view.MovedRef `pg:"moved_refs, type:jsonb"`
+	Status           string          `pg:"status, type:varchar"`
+	Details          string          `pg:"details, type:text"`
+	CreatedAt        time.Time       `pg:"created_at, type:timestamp without time zone"`
+	FinishedAt       *time.Time      `pg:"finished_at, type:timestamp without time zone"`
+}
+
+// ReleaseVersionEntity is the latest revision of a release version
+type ReleaseVersionEntity struct {
+	Version  string `pg:"version, type:varchar"`
+	Revision int    `pg:"revision, type:integer"`
+}
+
+func MakeDashboardTrackingView(ent DashboardTrackingEntity) view.DashboardTracking {
+	return view.DashboardTracking{
+		PackageId:      ent.PackageId,
+		Version:        ent.Version,
+		Rule:           ent.Rule,
+		VersionPattern: ent.VersionPattern,
+		Enabled:        ent.Enabled,
+		UpdatedBy:      ent.UpdatedBy,
+		UpdatedAt:      ent.UpdatedAt,
+	}
+}
+
+func MakeDashboardRollForwardView(ent DashboardRollForwardEntity) view.DashboardRollForward {
+	movedRefs := ent.MovedRefs
+	if movedRefs == nil {
+		movedRefs = make([]view.MovedRef, 0)
+	}
+	return view.DashboardRollForward{
+		Id:               ent.Id,
+		PackageId:        ent.PackageId,
+		Version:          ent.Version,
+		Revision:         ent.Revision,
+		PublishId:        ent.PublishId,
+		TriggerPackageId: ent.TriggerPackageId,
+		TriggerVersion:   view.MakeVersionRefKey(ent.TriggerVersion, ent.TriggerRevision),
+		MovedRefs:        movedRefs,
+		Status:           ent.Status,
+		Details:          ent.Details,
+		CreatedAt:        ent.CreatedAt,
+		FinishedAt:       ent.FinishedAt,
+	}
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type PackageVersionDependentEntity struct {
+	PackageId         string    `pg:"package_id, type:varchar"`
+	Version           string    `pg:"version, type:varchar"`
+	Revision          int       `pg:"revision, type:integer"`
+	PackageName       string    `pg:"package_name, type:varchar"`
+	Kind              string    `pg:"kind, type:varchar"`
+	Status            string    `pg:"status, type:varchar"`
+	PublishedAt       time.Time `pg:"published_at, type:timestamp without time zone"`
+	NotLatestRevision bool      `pg:"not_latest_revision, type:boolean"`
+	RefVersion        string    `pg:"reference_version, type:varchar"`
+	RefRevision       int       `pg:"reference_revision, type:integer"`
+	Direct            bool      `pg:"direct, type:boolean"`
+	Excluded          bool      `pg:"excluded, type:boolean"`
+}
+
+func MakePackageVersionDependentView(ent PackageVersionDependentEntity) view.PackageVersionDependent {
+	return view.PackageVersionDependent{
+		PackageId:         ent.PackageId,
+		Name:              ent.PackageName,
+		Kind:              ent.Kind,
+		Version:           view.MakeVersionRefKey(ent.Version, ent.Revision),
+		Status:            ent.Status,
+		NotLatestRevision: ent.NotLatestRevision,
+		ReferencedVersion: view.MakeVersionRefKey(ent.RefVersion, ent.RefRevision),
+		Direct:            ent.Direct,
+		Excluded:          ent.Excluded,
+	}
+}
@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type OperationDeprecationPolicyEntity struct {
+	tableName struct{} `pg:"operation_deprecation_policy, alias:operation_deprecation_policy"`
+
+	PackageId              string     `pg:"package_id, pk, type:varchar"`
+	ApiType                string     `pg:"api_type, pk, type:varchar"`
+	OperationId            string     `pg:"operation_id, pk, type:varchar"`
+	DeprecatedSince        string     `pg:"deprecated_since, type:varchar"`
+	SunsetDate             *time.Time `pg:"sunset_date, type:date"`
+	ReplacementPackageId   string     `pg:"replacement_package_id, type:varchar"`
+	ReplacementOperationId string     `pg:"replacement_operation_id, type:varchar"`
+	Description            string     `pg:"description, type:varchar"`
+	Source                 string     `pg:"source, type:varchar"`
+	UpdatedBy              string     `pg:"updated_by, type:varchar"`
+	UpdatedAt              time.Time  `pg:"updated_at, type:timestamp without time zone"`
+	SunsetAlertedAt        *time.Time `pg:"sunset_alerted_at, type:timestamp without time zone"`
+}
+
+type SunsetCalendarItemEntity struct {
+	OperationDeprecationPolicyEntity
+	PackageName     string `pg:"package_name, type:varchar"`
+	Title           string `pg:"title, type:varchar"`
+	ReleaseVersion  string `pg:"release_version, type:varchar"`
+	ReleaseRevision int    `pg:"release_revision, type:integer"`
+}
+
+type PolicyReleaseVersionEntity struct {
+	Version                  string `pg:"version, type:varchar"`
+	PreviousVersion          string `pg:"previous_version, type:varchar"`
+	PreviousVersionPackageId string `pg:"previous_version_package_id, type:varchar"`
+}
+
+type PassedSunsetEntity struct {
+	PackageId       string    `pg:"package_id, type:varchar"`
+	OperationId     string    `pg:"operation_id, type:varchar"`
+	ApiType         string    `pg:"api_type, type:varchar"`
+	SunsetDate      time.Time `pg:"sunset_date, type:date"`
+	ReleaseVersion  string    `pg:"release_version, type:varchar"`
+	ReleaseRevision int       `pg:"release_revision, type:integer"`
+}
+
+func MakeOperationDeprecationPolicyView(ent OperationDeprecationPolicyEntity) view.OperationDeprecationPolicy {
+	policy := view.OperationDeprecationPolicy{
+		PackageId:       ent.PackageId,
+		OperationId:     ent.OperationId,
+		ApiType:         ent.ApiType,
+		DeprecatedSince: ent.DeprecatedSince,
+		Description:     ent.Description,
+		Source:          ent.Source,
+		UpdatedBy:       ent.UpdatedBy,
+		UpdatedAt:       ent.UpdatedAt,
+	}
+	if ent.SunsetDate != nil {
+		policy.SunsetDate = ent.SunsetDate.Format(view.SunsetDateFormat)
+	}
+	if ent.ReplacementOperationId != "" {
+		policy.Replacement = &view.DeprecationReplacement{
+			PackageId:   ent.ReplacementPackageId,
+			OperationId: ent.ReplacementOperationId,
+		}
+	}
+	return policy
+}
+
+func MakeSunsetCalendarItemView(ent SunsetCalendarItemEntity, today time.Time) view.SunsetCalendarItem {
+	item := view.SunsetCalendarItem{
+		OperationDeprecationPolicy: MakeOperationDeprecationPolicyView(ent.OperationDeprecationPolicyEntity),
+		PackageName:                ent.PackageName,
+		Title:                      ent.Title,
+	}
+	if ent.ReleaseVersion != "" {
+		item.ReleaseVersion = view.MakeVersionRefKey(ent.ReleaseVersion, ent.ReleaseRevision)
+	}
+	if ent.SunsetDate != nil {
+		item.SunsetPassed = ent.SunsetDate.Before(today)
+	}
+	return item
+}
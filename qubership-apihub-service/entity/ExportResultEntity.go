@@ -13,5 +13,5 @@ type ExportResultEntity struct {
 	CreatedAt time.Time        `pg:"created_at, type:timestamp without time zone"`
 	CreatedBy string           `pg:"created_by, type:varchar"`
 	Filename  string           `pg:"filename, type:varchar"`
-	Data      []byte           `pg:"data, type:bytea"`
+	Data      CompressedData   `pg:"data, type:bytea"`
 }
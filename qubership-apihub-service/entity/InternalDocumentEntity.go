@@ -18,8 +18,8 @@ type VersionInternalDocumentEntity struct {
 type VersionInternalDocumentDataEntity struct {
 	tableName struct{} `pg:"version_internal_document_data"`
 
-	Hash string `pg:"hash, pk, type:varchar"`
-	Data []byte `pg:"data, type:bytea"`
+	Hash string         `pg:"hash, pk, type:varchar"`
+	Data CompressedData `pg:"data, type:bytea"`
 }
 
 type EnrichedVersionInternalDocumentDataEntity struct {
@@ -44,8 +44,8 @@ type ComparisonInternalDocumentEntity struct {
 type ComparisonInternalDocumentDataEntity struct {
 	tableName struct{} `pg:"comparison_internal_document_data"`
 
-	Hash string `pg:"hash, pk, type:varchar"`
-	Data []byte `pg:"data, type:bytea"`
+	Hash string         `pg:"hash, pk, type:varchar"`
+	Data CompressedData `pg:"data, type:bytea"`
 }
 
 type EnrichedComparisonInternalDocumentDataEntity struct {
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type OperationConsumerEntity struct {
+	tableName struct{} `pg:"operation_consumer, alias:operation_consumer"`
+
+	ConsumerType string    `pg:"consumer_type, pk, type:varchar"`
+	ConsumerId   string    `pg:"consumer_id, pk, type:varchar"`
+	PackageId    string    `pg:"package_id, pk, type:varchar"`
+	OperationId  string    `pg:"operation_id, pk, type:varchar"`
+	Version      string    `pg:"version, type:varchar, use_zero"`
+	DeclaredBy   string    `pg:"declared_by, type:varchar"`
+	DeclaredAt   time.Time `pg:"declared_at, type:timestamp without time zone"`
+}
+
+type OperationConsumerInfoEntity struct {
+	tableName struct{} `pg:"operation_consumer, alias:operation_consumer"`
+
+	OperationConsumerEntity
+	ConsumerName      string `pg:"consumer_name, type:varchar"`
+	ConsumerPackageId string `pg:"consumer_package_id, type:varchar"`
+}
+
+func MakeConsumedOperationView(ent OperationConsumerEntity) view.ConsumedOperation {
+	return view.ConsumedOperation{
+		PackageId:   ent.PackageId,
+		Version:     ent.Version,
+		OperationId: ent.OperationId,
+	}
+}
+
+func MakeConsumerView(ent OperationConsumerInfoEntity) view.Consumer {
+	return view.Consumer{
+		ConsumerType:      ent.ConsumerType,
+		ConsumerId:        ent.ConsumerId,
+		ConsumerName:      ent.ConsumerName,
+		ConsumerPackageId: ent.ConsumerPackageId,
+	}
+}
+
+func MakeOperationConsumerView(ent OperationConsumerInfoEntity) view.OperationConsumer {
+	return view.OperationConsumer{
+		Consumer:    MakeConsumerView(ent),
+		OperationId: ent.OperationId,
+		Version:     ent.Version,
+		DeclaredBy:  ent.DeclaredBy,
+		DeclaredAt:  ent.DeclaredAt,
+	}
+}
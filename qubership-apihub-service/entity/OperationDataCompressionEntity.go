@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type OperationDataCompressionEntity struct {
+	tableName struct{} `pg:"operation_data_compression"`
+
+	Id           int        `pg:"id, pk"`
+	LastDataHash string     `pg:"last_data_hash, use_zero"`
+	FinishedAt   *time.Time `pg:"finished_at"`
+}
+
+// RawOperationDataEntity is operation data as it is stored in the database, without decompression
+type RawOperationDataEntity struct {
+	tableName struct{} `pg:"operation_data"`
+
+	DataHash string `pg:"data_hash, pk, type:varchar"`
+	Data     []byte `pg:"data, type:bytea"`
+}
@@ -76,7 +76,7 @@ type OperationDataEntity struct {
 	tableName struct{} `pg:"operation_data, alias:operation_data"`
 
 	DataHash    string                 `pg:"data_hash, pk, type:varchar"`
-	Data        []byte                 `pg:"data, type:bytea"`
+	Data        CompressedData         `pg:"data, type:bytea"`
 	SearchScope map[string]interface{} `pg:"search_scope, type:jsonb"`
 }
 
@@ -152,6 +152,7 @@ type VersionComparisonCleanupCandidateEntity struct {
 	ActualPreviousVersion   *string   `pg:"actual_previous_version"`
 	ActualPreviousPackageId *string   `pg:"actual_previous_package_id"`
 	PreviousMaxRevision     int       `pg:"previous_max_revision"`
+	Saved                   bool      `pg:"saved"`
 }
 
 func MakeRefComparisonView(entity VersionComparisonEntity) *view.RefComparison {
@@ -167,7 +168,7 @@ func MakeRefComparisonView(entity VersionComparisonEntity) *view.RefComparison {
 type OperationRichEntity struct {
 	tableName struct{} `pg:"operation, alias:operation"`
 	OperationEntity
-	Data []byte `pg:"data, type:bytea"`
+	Data CompressedData `pg:"data, type:bytea"`
 }
 
 type OperationComparisonChangelogEntity struct {
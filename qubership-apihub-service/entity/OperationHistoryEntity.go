@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type OperationHistoryVersionEntity struct {
+	Version                  string    `pg:"version, type:varchar"`
+	Revision                 int       `pg:"revision, type:integer"`
+	Status                   string    `pg:"status, type:varchar"`
+	PublishedAt              time.Time `pg:"published_at, type:timestamp without time zone"`
+	PreviousVersion          string    `pg:"previous_version, type:varchar"`
+	PreviousVersionPackageId string    `pg:"previous_version_package_id, type:varchar"`
+}
+
+type OperationHistoryStateEntity struct {
+	Version                 string   `pg:"version, type:varchar"`
+	Revision                int      `pg:"revision, type:integer"`
+	ApiType                 string   `pg:"type, type:varchar"`
+	Title                   string   `pg:"title, type:varchar"`
+	DataHash                string   `pg:"data_hash, type:varchar"`
+	Deprecated              bool     `pg:"deprecated, type:boolean"`
+	DeprecatedInfo          string   `pg:"deprecated_info, type:varchar"`
+	PreviousReleaseVersions []string `pg:"previous_release_versions, type:varchar[]"`
+	ApiAudience             string   `pg:"api_audience, type:varchar"`
+}
+
+type OperationHistoryComparisonEntity struct {
+	Version           string                 `pg:"version, type:varchar"`
+	Revision          int                    `pg:"revision, type:integer"`
+	PreviousPackageId string                 `pg:"previous_package_id, type:varchar"`
+	PreviousVersion   string                 `pg:"previous_version, type:varchar"`
+	PreviousRevision  int                    `pg:"previous_revision, type:integer"`
+	ChangesSummary    view.ChangeSummary     `pg:"changes_summary, type:jsonb"`
+	Changes           map[string]interface{} `pg:"changes, type:jsonb"`
+}
@@ -166,10 +166,10 @@ type PublishedContentWithDataEntity struct {
 type PublishedContentDataEntity struct {
 	tableName struct{} `pg:"published_data"`
 
-	PackageId string `pg:"package_id, pk, type:varchar"`
-	Checksum  string `pg:"checksum, pk, type:varchar"`
-	MediaType string `pg:"media_type, type:varchar"`
-	Data      []byte `pg:"data, type:bytea"`
+	PackageId string         `pg:"package_id, pk, type:varchar"`
+	Checksum  string         `pg:"checksum, pk, type:varchar"`
+	MediaType string         `pg:"media_type, type:varchar"`
+	Data      CompressedData `pg:"data, type:bytea"`
 }
 
 type TransformedContentDataEntity struct {
@@ -182,7 +182,7 @@ type TransformedContentDataEntity struct {
 	GroupId       string                 `pg:"group_id, pk, type:varchar"`
 	BuildType     view.BuildType         `pg:"build_type, pk, type:varchar"`
 	Format        string                 `pg:"format, pk, type:varchar"`
-	Data          []byte                 `pg:"data, type:bytea"`
+	Data          CompressedData         `pg:"data, type:bytea"`
 	DocumentsInfo []view.PackageDocument `pg:"documents_info, type:jsonb"`
 }
 
@@ -224,15 +224,15 @@ type PublishedSrcEntity struct {
 type PublishedSrcArchiveEntity struct {
 	tableName struct{} `pg:"published_sources_archives"`
 
-	Checksum string `pg:"checksum, pk, type:varchar"` // sha512
-	Data     []byte `pg:"data, type:bytea"`
+	Checksum string         `pg:"checksum, pk, type:varchar"` // sha512
+	Data     CompressedData `pg:"data, type:bytea"`
 }
 
 type PublishedSrcDataConfigEntity struct {
-	PackageId       string `pg:"package_id, pk, type:varchar"`
-	ArchiveChecksum string `pg:"archive_checksum, type:varchar"`
-	Data            []byte `pg:"data, type:bytea"`
-	Config          []byte `pg:"config, type:bytea"`
+	PackageId       string         `pg:"package_id, pk, type:varchar"`
+	ArchiveChecksum string         `pg:"archive_checksum, type:varchar"`
+	Data            CompressedData `pg:"data, type:bytea"`
+	Config          []byte         `pg:"config, type:bytea"`
 }
 
 type PublishedContentSearchQueryEntity struct {
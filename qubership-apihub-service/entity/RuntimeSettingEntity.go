@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type RuntimeSettingEntity struct {
+	tableName struct{} `pg:"runtime_setting"`
+
+	Key       string          `pg:"key, pk, type:varchar"`
+	Value     json.RawMessage `pg:"value, type:jsonb"`
+	UpdatedBy string          `pg:"updated_by, type:varchar"`
+	UpdatedAt time.Time       `pg:"updated_at, type:timestamp without time zone"`
+}
+
+type RuntimeSettingHistoryEntity struct {
+	tableName struct{} `pg:"runtime_setting_history"`
+
+	Id        string          `pg:"id, pk, type:varchar"`
+	Key       string          `pg:"key, type:varchar"`
+	OldValue  json.RawMessage `pg:"old_value, type:jsonb"`
+	NewValue  json.RawMessage `pg:"new_value, type:jsonb"`
+	ChangedBy string          `pg:"changed_by, type:varchar"`
+	ChangedAt time.Time       `pg:"changed_at, type:timestamp without time zone"`
+}
+
+type SystemNotificationEntity struct {
+	tableName struct{} `pg:"system_notification"`
+
+	Id        string     `pg:"id, pk, type:varchar"`
+	Message   string     `pg:"message, type:text"`
+	Severity  string     `pg:"severity, type:varchar"`
+	StartAt   time.Time  `pg:"start_at, type:timestamp without time zone"`
+	EndAt     *time.Time `pg:"end_at, type:timestamp without time zone"`
+	CreatedBy string     `pg:"created_by, type:varchar"`
+	CreatedAt time.Time  `pg:"created_at, type:timestamp without time zone"`
+	UpdatedBy string     `pg:"updated_by, type:varchar"`
+	UpdatedAt *time.Time `pg:"updated_at, type:timestamp without time zone"`
+}
+
+func MakeRuntimeSettingChangeView(ent RuntimeSettingHistoryEntity) view.RuntimeSettingChange {
+	return view.RuntimeSettingChange{
+		Id:        ent.Id,
+		Key:       ent.Key,
+		OldValue:  ent.OldValue,
+		NewValue:  ent.NewValue,
+		ChangedBy: ent.ChangedBy,
+		ChangedAt: ent.ChangedAt,
+	}
+}
+
+func MakeSystemNotificationView(ent SystemNotificationEntity) view.SystemNotification {
+	return view.SystemNotification{
+		Id:        ent.Id,
+		Message:   ent.Message,
+		Severity:  ent.Severity,
+		StartAt:   ent.StartAt,
+		EndAt:     ent.EndAt,
+		CreatedBy: ent.CreatedBy,
+		CreatedAt: ent.CreatedAt,
+		UpdatedBy: ent.UpdatedBy,
+		UpdatedAt: ent.UpdatedAt,
+	}
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type SavedComparisonEntity struct {
+	tableName struct{} `pg:"saved_comparison"`
+
+	Id                string     `pg:"id, pk, type:varchar"`
+	Name              string     `pg:"name, type:varchar"`
+	PackageId         string     `pg:"package_id, type:varchar"`
+	Version           string     `pg:"version, type:varchar"`
+	Revision          int        `pg:"revision, type:integer, use_zero"`
+	PreviousPackageId string     `pg:"previous_package_id, type:varchar"`
+	PreviousVersion   string     `pg:"previous_version, type:varchar"`
+	PreviousRevision  int        `pg:"previous_revision, type:integer, use_zero"`
+	ComparisonId      string     `pg:"comparison_id, type:varchar"`
+	Status            string     `pg:"status, type:varchar"`
+	Details           string     `pg:"details, type:text"`
+	BuildId           string     `pg:"build_id, type:varchar"`
+	CreatedBy         string     `pg:"created_by, type:varchar"`
+	CreatedAt         time.Time  `pg:"created_at, type:timestamp without time zone"`
+	CalculatedAt      *time.Time `pg:"calculated_at, type:timestamp without time zone"`
+}
+
+func MakeSavedComparisonView(ent SavedComparisonEntity) view.SavedComparison {
+	return view.SavedComparison{
+		Id:                       ent.Id,
+		Name:                     ent.Name,
+		PackageId:                ent.PackageId,
+		Version:                  view.MakeVersionRefKey(ent.Version, ent.Revision),
+		PreviousVersionPackageId: ent.PreviousPackageId,
+		PreviousVersion:          view.MakeVersionRefKey(ent.PreviousVersion, ent.PreviousRevision),
+		Status:                   ent.Status,
+		Details:                  ent.Details,
+		CreatedBy:                ent.CreatedBy,
+		CreatedAt:                ent.CreatedAt,
+		CalculatedAt:             ent.CalculatedAt,
+	}
+}
@@ -172,6 +172,11 @@ func MakeOperationSearchResultView(ent OperationSearchResult_deprecated) interfa
 			CommonOperationSearchResult_deprecated: operationSearchResult,
 			GraphQLOperationView:                   MakeGraphQLOperationView(&ent.OperationEntity),
 		}
+	case string(view.ProtobufApiType):
+		return view.ProtobufOperationSearchResult_deprecated{
+			CommonOperationSearchResult_deprecated: operationSearchResult,
+			ProtobufOperationView:                  MakeProtobufOperationView(&ent.OperationEntity),
+		}
 	}
 	return operationSearchResult
 }
@@ -197,6 +202,11 @@ func MakeGlobalOperationSearchResultView(ent OperationSearchResult) interface{}
 			CommonOperationSearchResult: operationSearchResult,
 			GraphQLOperationView:        MakeGraphQLOperationView(&ent.OperationEntity),
 		}
+	case string(view.ProtobufApiType):
+		return view.ProtobufOperationSearchResult{
+			CommonOperationSearchResult: operationSearchResult,
+			ProtobufOperationView:       MakeProtobufOperationView(&ent.OperationEntity),
+		}
 	case string(view.AsyncapiApiType):
 		return view.AsyncAPIOperationSearchResult{
 			CommonOperationSearchResult: operationSearchResult,
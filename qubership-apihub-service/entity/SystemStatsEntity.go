@@ -58,3 +58,45 @@ func (e *TableSizeEntity) MakeTableSizeInfoView() view.TableSizeInfo {
 		TotalSizeShare: e.TotalSizeShare,
 	}
 }
+
+type ContentStorageUsageEntity struct {
+	TableName               string `pg:"table_name"`
+	Entries                 int    `pg:"entries"`
+	StoredSize              int64  `pg:"stored_size"`
+	ReferencedSize          int64  `pg:"referenced_size"`
+	UnreferencedEntries     int    `pg:"unreferenced_entries"`
+	PendingReferenceChanges int    `pg:"pending_reference_changes"`
+}
+
+func (e *ContentStorageUsageEntity) MakeContentStorageUsageView() view.ContentStorageUsage {
+	return view.ContentStorageUsage{
+		TableName:               e.TableName,
+		Entries:                 e.Entries,
+		StoredSize:              e.StoredSize,
+		ReferencedSize:          e.ReferencedSize,
+		UnreferencedEntries:     e.UnreferencedEntries,
+		PendingReferenceChanges: e.PendingReferenceChanges,
+	}
+}
+
+type PackageStorageUsageEntity struct {
+	Id                   string `pg:"id"`
+	Documents            int    `pg:"documents"`
+	DocumentsSize        int64  `pg:"documents_size"`
+	SourcesSize          int64  `pg:"sources_size"`
+	OperationDataEntries int    `pg:"operation_data_entries"`
+	OperationDataSize    int64  `pg:"operation_data_size"`
+	TotalSize            int64  `pg:"total_size"`
+}
+
+func (e *PackageStorageUsageEntity) MakePackageStorageUsageView() view.PackageStorageUsage {
+	return view.PackageStorageUsage{
+		Id:                   e.Id,
+		Documents:            e.Documents,
+		DocumentsSize:        e.DocumentsSize,
+		SourcesSize:          e.SourcesSize,
+		OperationDataEntries: e.OperationDataEntries,
+		OperationDataSize:    e.OperationDataSize,
+		TotalSize:            e.TotalSize,
+	}
+}
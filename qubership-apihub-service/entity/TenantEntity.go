@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type WorkspaceTenantEntity struct {
+	tableName struct{} `pg:"workspace_tenant"`
+
+	WorkspaceId             string                        `pg:"workspace_id, pk, type:varchar"`
+	StorageLimitMb          *int64                        `pg:"storage_limit_mb, type:bigint"`
+	MaxPackages             *int                          `pg:"max_packages, type:integer"`
+	MaxVersions             *int                          `pg:"max_versions, type:integer"`
+	BuildsPerHour           *int                          `pg:"builds_per_hour, type:integer"`
+	AiTokensPerMonth        *int64                        `pg:"ai_tokens_per_month, type:bigint"`
+	ReleaseVersionPattern   string                        `pg:"release_version_pattern, type:varchar"`
+	DefaultRole             string                        `pg:"default_role, type:varchar"`
+	ValidationRulesSeverity *view.ValidationRulesSeverity `pg:"validation_rules_severity, type:jsonb"`
+	UpdatedBy               string                        `pg:"updated_by, type:varchar"`
+	UpdatedAt               time.Time                     `pg:"updated_at, type:timestamp without time zone"`
+}
+
+type WorkspaceTenantAdminEntity struct {
+	tableName struct{} `pg:"workspace_tenant_admin"`
+
+	WorkspaceId string    `pg:"workspace_id, pk, type:varchar"`
+	UserId      string    `pg:"user_id, pk, type:varchar"`
+	AddedBy     string    `pg:"added_by, type:varchar"`
+	AddedAt     time.Time `pg:"added_at, type:timestamp without time zone"`
+}
+
+type WorkspaceStorageUsageEntity struct {
+	tableName struct{} `pg:"workspace_storage_usage"`
+
+	WorkspaceId  string    `pg:"workspace_id, pk, type:varchar"`
+	TotalSize    int64     `pg:"total_size, type:bigint, use_zero"`
+	CalculatedAt time.Time `pg:"calculated_at, type:timestamp without time zone"`
+}
+
+func MakeWorkspaceTenantView(workspaceId string, ent *WorkspaceTenantEntity) *view.WorkspaceTenant {
+	result := &view.WorkspaceTenant{WorkspaceId: workspaceId}
+	if ent == nil {
+		return result
+	}
+	result.Quotas = view.TenantQuotas{
+		StorageLimitMb:   ent.StorageLimitMb,
+		MaxPackages:      ent.MaxPackages,
+		MaxVersions:      ent.MaxVersions,
+		BuildsPerHour:    ent.BuildsPerHour,
+		AiTokensPerMonth: ent.AiTokensPerMonth,
+	}
+	result.Settings = view.TenantSettings{
+		ReleaseVersionPattern:   ent.ReleaseVersionPattern,
+		DefaultRole:             ent.DefaultRole,
+		ValidationRulesSeverity: ent.ValidationRulesSeverity,
+	}
+	result.UpdatedBy = ent.UpdatedBy
+	updatedAt := ent.UpdatedAt
+	result.UpdatedAt = &updatedAt
+	return result
+}
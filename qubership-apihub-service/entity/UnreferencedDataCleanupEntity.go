@@ -23,4 +23,17 @@ type DeletedItemsCounts struct {
 	PublishedData                  int `json:"publishedData"`
 	VersionInternalDocumentData    int `json:"versionInternalDocumentData"`
 	ComparisonInternalDocumentData int `json:"comparisonInternalDocumentData"`
+	ReferenceCountsFixed           int `json:"referenceCountsFixed"`
+}
+
+func (d *DeletedItemsCounts) Add(other *DeletedItemsCounts) {
+	d.OperationData += other.OperationData
+	d.TSOperationData += other.TSOperationData
+	d.FTSOperationData += other.FTSOperationData
+	d.OperationGroupTemplate += other.OperationGroupTemplate
+	d.PublishedSrcArchives += other.PublishedSrcArchives
+	d.PublishedData += other.PublishedData
+	d.VersionInternalDocumentData += other.VersionInternalDocumentData
+	d.ComparisonInternalDocumentData += other.ComparisonInternalDocumentData
+	d.ReferenceCountsFixed += other.ReferenceCountsFixed
 }
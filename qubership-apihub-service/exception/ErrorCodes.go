@@ -826,6 +826,117 @@ const ShareabilityReportDocumentNotFoundMsg = "Document with slug '$slug' not fo
 const ShareabilityReportSizeExceeded = "8305"
 const ShareabilityReportSizeExceededMsg = "Shareability report file size exceeded. File size limit - $size"
 
+const UnknownCleanupJobType = "8400"
+const UnknownCleanupJobTypeMsg = "Unknown cleanup job type '$jobType'. Supported job types: $jobTypes"
+
+const CleanupDryRunNotSupported = "8401"
+const CleanupDryRunNotSupportedMsg = "Dry run is not supported for '$jobType' cleanup job"
+
+const CleanupDryRunNotFound = "8402"
+const CleanupDryRunNotFoundMsg = "Cleanup dry run with id $runId not found"
+
+const UnknownRetentionDataClass = "8403"
+const UnknownRetentionDataClassMsg = "Unknown retention data class '$dataClass'. Supported data classes: $dataClasses"
+
+const RetentionPolicyNotFound = "8404"
+const RetentionPolicyNotFoundMsg = "Retention policy for data class '$dataClass' of package $packageId not found"
+
+const RetentionPolicyParamNotApplicable = "8405"
+const RetentionPolicyParamNotApplicableMsg = "Parameter '$param' is not applicable to data class '$dataClass'"
+
+const EmptyRetentionPolicy = "8406"
+const EmptyRetentionPolicyMsg = "Retention policy must override at least one parameter"
+
+const CleanupJobNotConfigured = "8407"
+const CleanupJobNotConfiguredMsg = "Cleanup job '$jobType' is not configured"
+
+const BlobMigrationNotFound = "8500"
+const BlobMigrationNotFoundMsg = "Blob migration with id $runId not found"
+
+const BlobMigrationAlreadyRunning = "8501"
+const BlobMigrationAlreadyRunningMsg = "Another blob migration is running"
+
+const BlobMigrationNamespaceNotSupported = "8502"
+const BlobMigrationNamespaceNotSupportedMsg = "Blobs of '$namespace' could not be migrated from '$sourceBackend' to '$targetBackend'"
+
+const BlobMigrationDeleteSourceNotAllowed = "8503"
+const BlobMigrationDeleteSourceNotAllowedMsg = "Blobs of '$namespace' could not be deleted from the source backend since '$backend' is configured as the main backend for it, not '$targetBackend'"
+
+const BlobMigrationNotRunning = "8504"
+const BlobMigrationNotRunningMsg = "Blob migration with id $runId is not running"
+
+const BlobMigrationNotResumable = "8505"
+const BlobMigrationNotResumableMsg = "Blob migration with id $runId is in status '$status' and could not be resumed"
+
+const DeprecationPolicyNotFound = "8600"
+const DeprecationPolicyNotFoundMsg = "Deprecation policy for operation $operationId not found in package $packageId"
+
+const PackageOperationNotFound = "8601"
+const PackageOperationNotFoundMsg = "Operation $operationId not found in any published version of package $packageId"
+
+const InvalidSunsetDate = "8602"
+const InvalidSunsetDateMsg = "Invalid sunset date '$value', expected format is YYYY-MM-DD"
+
+const ConsumedOperationNotFound = "8700"
+const ConsumedOperationNotFoundMsg = "Consumed operation $operationId not found in package $packageId"
+
+const ConsumedOperationVersionNotFound = "8701"
+const ConsumedOperationVersionNotFoundMsg = "Consumed operation $operationId not found in version $version of package $packageId"
+
+const DashboardTrackingNotFound = "8800"
+const DashboardTrackingNotFoundMsg = "Tracking is not configured for version $version of dashboard $packageId"
+
+const InvalidTrackingVersionPattern = "8801"
+const InvalidTrackingVersionPatternMsg = "Tracking version pattern '$pattern' has invalid format"
+
+const SavedComparisonNotFound = "8900"
+const SavedComparisonNotFoundMsg = "Saved comparison with id $id not found"
+
+const SavedComparisonNotCalculated = "8901"
+const SavedComparisonNotCalculatedMsg = "Saved comparison with id $id is in status '$status'"
+
+const OperationHistoryNotFound = "9000"
+const OperationHistoryNotFoundMsg = "Operation $operationId not found in any published version of package $packageId"
+
+const BackgroundJobNotFound = "9100"
+const BackgroundJobNotFoundMsg = "Background job '$jobName' is not registered"
+
+const BackgroundJobAlreadyRunning = "9101"
+const BackgroundJobAlreadyRunningMsg = "Background job '$jobName' is already running"
+
+const BackgroundJobNotRunning = "9102"
+const BackgroundJobNotRunningMsg = "Background job '$jobName' is not running"
+
+const ServiceShuttingDown = "9200"
+const ServiceShuttingDownMsg = "Service instance is shutting down, please retry the request"
+
+const BackgroundMigrationNotFound = "9300"
+const BackgroundMigrationNotFoundMsg = "Background schema migration $num not found"
+
+const BackgroundMigrationStatusConflict = "9301"
+const BackgroundMigrationStatusConflictMsg = "Background schema migration $num is in status '$status' and could not be $action"
+
+const TenantQuotaExceeded = "9400"
+const TenantQuotaExceededMsg = "Quota '$quota' of workspace $workspaceId is exceeded: $used of $limit is used"
+
+const PackageIsNotWorkspace = "9401"
+const PackageIsNotWorkspaceMsg = "Package $packageId is not a workspace"
+
+const TenantAdminNotFound = "9402"
+const TenantAdminNotFoundMsg = "User $userId is not an administrator of workspace $workspaceId"
+
+const RuntimeSettingNotFound = "9500"
+const RuntimeSettingNotFoundMsg = "Runtime setting '$key' not found"
+
+const InvalidRuntimeSettingValue = "9501"
+const InvalidRuntimeSettingValueMsg = "Invalid value of runtime setting '$key': $error"
+
+const SystemNotificationNotFound = "9502"
+const SystemNotificationNotFoundMsg = "System notification $id not found"
+
+const InvalidSystemNotificationPeriod = "9503"
+const InvalidSystemNotificationPeriodMsg = "End of the system notification must be after its start"
+
 // AI Chat error codes (APIHUB-AI-*). Each public error has a Code constant; variant
 // messages reuse the parent Code (same pattern as InvalidParameterValue + InvalidLimitMsg).
 
@@ -841,6 +952,25 @@ const AiChatEmptyBodyMsg = "Empty body"
 const AiChatPinLimitExceeded = "APIHUB-AI-4003"
 const AiChatPinLimitExceededMsg = "Cannot pin chat: user already has $max pinned chats (the limit is $max)"
 
+const AiChatShareNotFound = "APIHUB-AI-3002"
+const AiChatShareNotFoundMsg = "shared chat with shareId = $shareId not found"
+const AiChatShareFileNotFoundMsg = "file with fileId = $fileId not found in shared chat $shareId"
+
+const AiChatShareAccessDenied = "APIHUB-AI-4004"
+const AiChatShareAccessDeniedMsg = "Insufficient privileges to view the shared chat"
+
+const AiChatExportFormatNotSupported = "APIHUB-AI-4005"
+const AiChatExportFormatNotSupportedMsg = "Chat export format '$format' is not supported. Supported formats: $formats"
+
+const AiSpecReviewNotFound = "APIHUB-AI-3003"
+const AiSpecReviewNotFoundMsg = "AI review for version $version of package $packageId not found"
+
+const AiSpecReviewNotEnabled = "APIHUB-AI-4006"
+const AiSpecReviewNotEnabledMsg = "AI review is not enabled for package $packageId"
+
+const AiSpecReviewInProgress = "APIHUB-AI-4007"
+const AiSpecReviewInProgressMsg = "AI review for version $version of package $packageId is already in progress"
+
 const AiChatInternalError = "APIHUB-AI-5000"
 const AiChatInternalErrorMsg = "Internal error"
 const AiChatIdempotentReplayFailedMsg = "Idempotent replay failed"
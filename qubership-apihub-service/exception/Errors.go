@@ -6,11 +6,13 @@ import (
 )
 
 type CustomError struct {
-	Status  int                    `json:"status"`
-	Code    string                 `json:"code,omitempty"`
-	Message string                 `json:"message,omitempty"`
-	Params  map[string]interface{} `json:"params,omitempty"`
-	Debug   string                 `json:"debug,omitempty"`
+	Status    int                    `json:"status"`
+	Code      string                 `json:"code,omitempty"`
+	Message   string                 `json:"message,omitempty"`
+	Params    map[string]interface{} `json:"params,omitempty"`
+	Debug     string                 `json:"debug,omitempty"`
+	RequestId string                 `json:"requestId,omitempty"`
+	TraceId   string                 `json:"traceId,omitempty"`
 }
 
 func (c CustomError) Error() string {
@@ -0,0 +1,127 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	RequestIdHeader = "X-Request-Id"
+
+	RequestIdField = "requestId"
+	UserIdField    = "userId"
+	ApiKeyIdField  = "apiKeyId"
+	PackageIdField = "packageId"
+	BuildIdField   = "buildId"
+)
+
+type requestFieldsKey struct{}
+
+// requestFields are shared by the request context and response writer, so the fields added after the request context
+// was created (e.g. user id after authentication) are visible to all loggers of the request
+type requestFields struct {
+	mutex  sync.RWMutex
+	fields log.Fields
+}
+
+func (f *requestFields) add(fields log.Fields) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for key, value := range fields {
+		if value != "" {
+			f.fields[key] = value
+		}
+	}
+}
+
+func (f *requestFields) get() log.Fields {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	result := make(log.Fields, len(f.fields))
+	for key, value := range f.fields {
+		result[key] = value
+	}
+	return result
+}
+
+// NewRequestContext returns context which carries log fields of the request
+func NewRequestContext(ctx context.Context, fields log.Fields) context.Context {
+	holder := &requestFields{fields: log.Fields{}}
+	holder.add(fields)
+	return context.WithValue(ctx, requestFieldsKey{}, holder)
+}
+
+// AddFields adds log fields to the request context created by NewRequestContext. Empty values are ignored.
+func AddFields(ctx context.Context, fields log.Fields) {
+	if holder, ok := ctx.Value(requestFieldsKey{}).(*requestFields); ok {
+		holder.add(fields)
+	}
+}
+
+// Fields returns log fields of the request context
+func Fields(ctx context.Context) log.Fields {
+	if ctx == nil {
+		return nil
+	}
+	if holder, ok := ctx.Value(requestFieldsKey{}).(*requestFields); ok {
+		return holder.get()
+	}
+	return nil
+}
+
+// RequestId returns id of the request the context belongs to or empty string
+func RequestId(ctx context.Context) string {
+	requestId, _ := Fields(ctx)[RequestIdField].(string)
+	return requestId
+}
+
+// ContextResponseWriter keeps request context, so the helpers which get only response writer (e.g. utils.RespondWithError)
+// can log with the request fields
+type ContextResponseWriter struct {
+	http.ResponseWriter
+	Ctx context.Context
+}
+
+func (w *ContextResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *ContextResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// ForResponse returns log entry with the fields of the request which is served by the response writer
+func ForResponse(w http.ResponseWriter) *log.Entry {
+	for w != nil {
+		if cw, ok := w.(*ContextResponseWriter); ok {
+			return log.WithContext(cw.Ctx)
+		}
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			break
+		}
+		w = unwrapper.Unwrap()
+	}
+	return log.NewEntry(log.StandardLogger())
+}
+
+// ContextFieldsHook adds request fields to the entries logged with context, e.g. log.WithContext(ctx).Errorf(...)
+type ContextFieldsHook struct{}
+
+func (h ContextFieldsHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h ContextFieldsHook) Fire(entry *log.Entry) error {
+	for key, value := range Fields(entry.Context) {
+		if _, exists := entry.Data[key]; !exists {
+			entry.Data[key] = value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+type wrappingResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w wrappingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func TestRequestFields(t *testing.T) {
+	ctx := NewRequestContext(context.Background(), log.Fields{RequestIdField: "req-1", PackageIdField: "QS.CQSS", BuildIdField: ""})
+	AddFields(ctx, log.Fields{UserIdField: "user1", ApiKeyIdField: ""})
+	require.Equal(t, log.Fields{RequestIdField: "req-1", PackageIdField: "QS.CQSS", UserIdField: "user1"}, Fields(ctx))
+	require.Equal(t, "req-1", RequestId(ctx))
+	require.Empty(t, RequestId(context.Background()))
+
+	var buf bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&buf)
+	logger.SetFormatter(&log.JSONFormatter{})
+	logger.AddHook(ContextFieldsHook{})
+	logger.WithContext(ctx).WithField(PackageIdField, "QS.OTHER").Info("message")
+	require.Contains(t, buf.String(), `"requestId":"req-1"`)
+	require.Contains(t, buf.String(), `"userId":"user1"`)
+	require.Contains(t, buf.String(), `"packageId":"QS.OTHER"`, "explicit field is not overridden")
+
+	w := &ContextResponseWriter{ResponseWriter: httptest.NewRecorder(), Ctx: ctx}
+	require.Equal(t, ctx, ForResponse(w).Context)
+	require.Equal(t, ctx, ForResponse(wrappingResponseWriter{w}).Context, "context is found through wrapping writers")
+	require.Nil(t, ForResponse(httptest.NewRecorder()).Context)
+}
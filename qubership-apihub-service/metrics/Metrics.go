@@ -138,6 +138,23 @@ var AiChatCompactionsTotal = prometheus.NewCounter(
 	},
 )
 
+// AI spec review metrics
+
+var AiSpecReviewsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "apihub_ai_spec_reviews_total",
+		Help: "Number of finished AI spec reviews, partitioned by status (complete/partial/error).",
+	},
+	[]string{"status"},
+)
+
+var AiSpecReviewTokens = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Name: "apihub_ai_spec_review_tokens_total",
+		Help: "Total tokens (prompt+completion) reported by the LLM provider for AI spec reviews.",
+	},
+)
+
 var EphemeralFilesTotal = prometheus.NewCounter(
 	prometheus.CounterOpts{
 		Name: "apihub_ephemeral_files_total",
@@ -169,6 +186,87 @@ var EphemeralFileCleanupDeleted = prometheus.NewCounterVec(
 	[]string{"kind"},
 )
 
+var ResponseCacheRequests = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "apihub_response_cache_requests_total",
+		Help: "Number of response cache lookups, partitioned by cache region and result (hit/miss).",
+	},
+	[]string{"region", "result"},
+)
+
+var DbPoolConnections = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "apihub_db_pool_connections",
+		Help: "Number of connections in the database pool, partitioned by pool (primary/replica) and state (total/idle/stale).",
+	},
+	[]string{"pool", "state"},
+)
+
+var DbPoolRequests = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "apihub_db_pool_requests",
+		Help: "Cumulative number of connection requests to the database pool, partitioned by pool and result (hit/miss/timeout).",
+	},
+	[]string{"pool", "result"},
+)
+
+var DbReplicaLagSeconds = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "apihub_db_replica_lag_seconds",
+		Help: "Replication lag of the database read replica in seconds.",
+	},
+	[]string{"pool"},
+)
+
+var DbReadQueriesRouted = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "apihub_db_read_queries_routed_total",
+		Help: "Number of read-only queries, partitioned by the pool they were routed to and the routing reason.",
+	},
+	[]string{"pool", "reason"},
+)
+
+var BackgroundJobRuns = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "apihub_background_job_runs_total",
+		Help: "Number of finished background job runs, partitioned by job and status (complete/error/timeout/skipped).",
+	},
+	[]string{"job", "status"},
+)
+
+var BackgroundJobDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "apihub_background_job_duration_seconds",
+		Help:    "Duration of background job runs including retries.",
+		Buckets: []float64{0.1, 0.5, 1, 5, 15, 30, 60, 300, 900, 1800, 3600},
+	},
+	[]string{"job"},
+)
+
+var BackgroundJobRetries = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "apihub_background_job_retries_total",
+		Help: "Number of retried attempts of background job runs.",
+	},
+	[]string{"job"},
+)
+
+var BackgroundJobRunning = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "apihub_background_job_running",
+		Help: "Number of background job runs executed by the instance at the moment.",
+	},
+	[]string{"job"},
+)
+
+var BackgroundSchemaMigrationRows = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "apihub_background_schema_migration_rows_total",
+		Help: "Number of rows processed by batches of background schema migrations.",
+	},
+	[]string{"migration"},
+)
+
 func RegisterAllPrometheusApplicationMetrics() {
 	prometheus.Register(TotalRequests)
 	prometheus.Register(HttpDuration)
@@ -186,8 +284,20 @@ func RegisterAllPrometheusApplicationMetrics() {
 	prometheus.Register(AiChatTurnTokens)
 	prometheus.Register(AiChatToolCallsTotal)
 	prometheus.Register(AiChatCompactionsTotal)
+	prometheus.Register(AiSpecReviewsTotal)
+	prometheus.Register(AiSpecReviewTokens)
 	prometheus.Register(EphemeralFilesTotal)
 	prometheus.Register(EphemeralFileBytes)
 	prometheus.Register(AiChatCleanupDeleted)
 	prometheus.Register(EphemeralFileCleanupDeleted)
+	prometheus.Register(ResponseCacheRequests)
+	prometheus.Register(DbPoolConnections)
+	prometheus.Register(DbPoolRequests)
+	prometheus.Register(DbReplicaLagSeconds)
+	prometheus.Register(DbReadQueriesRouted)
+	prometheus.Register(BackgroundJobRuns)
+	prometheus.Register(BackgroundJobDuration)
+	prometheus.Register(BackgroundJobRetries)
+	prometheus.Register(BackgroundJobRunning)
+	prometheus.Register(BackgroundSchemaMigrationRows)
 }
@@ -0,0 +1,54 @@
+package midldleware
+
+import (
+	"net/http"
+	"unicode"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/logging"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const maxRequestIdLength = 128
+
+// RequestIdMiddleware accepts X-Request-Id of the caller or generates a new one and returns it in the response header.
+// Request id and ids of the package and build from the path are attached to the log entries written with request context.
+func RequestIdMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId := r.Header.Get(logging.RequestIdHeader)
+		if !isValidRequestId(requestId) {
+			requestId = uuid.NewString()
+		}
+		w.Header().Set(logging.RequestIdHeader, requestId)
+
+		vars := mux.Vars(r)
+		buildId := vars["buildId"]
+		if buildId == "" {
+			buildId = vars["publishId"]
+		}
+		ctx := logging.NewRequestContext(r.Context(), log.Fields{
+			logging.RequestIdField: requestId,
+			logging.PackageIdField: vars["packageId"],
+			logging.BuildIdField:   buildId,
+		})
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("http.request.id", requestId))
+
+		next.ServeHTTP(&logging.ContextResponseWriter{ResponseWriter: w, Ctx: ctx}, r.WithContext(ctx))
+	})
+}
+
+// isValidRequestId rejects ids which are too long or may break log and header formats
+func isValidRequestId(requestId string) bool {
+	if requestId == "" || len(requestId) > maxRequestIdLength {
+		return false
+	}
+	for _, c := range requestId {
+		if c > unicode.MaxASCII || !unicode.IsPrint(c) {
+			return false
+		}
+	}
+	return true
+}
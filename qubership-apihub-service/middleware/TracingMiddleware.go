@@ -0,0 +1,66 @@
+package midldleware
+
+import (
+	"net/http"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/tracing"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracingResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *tracingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *tracingResponseWriter) WriteHeader(code int) {
+	w.statusCode = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *tracingResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// TracingMiddleware starts a server span for each matched route, continuing the trace of the caller if traceparent header is present.
+// Trace id is returned in X-Trace-Id response header.
+func TracingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				path = template
+			}
+		}
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracing.Tracer().Start(ctx, r.Method+" "+path,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.HTTPRoute(path),
+				semconv.URLPath(r.URL.Path),
+			))
+		defer span.End()
+		if traceId := tracing.TraceId(ctx); traceId != "" {
+			w.Header().Set(tracing.TraceIdHeader, traceId)
+		}
+
+		tw := &tracingResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(tw, r.WithContext(ctx))
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(tw.statusCode))
+		if tw.statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(tw.statusCode))
+		}
+	})
+}
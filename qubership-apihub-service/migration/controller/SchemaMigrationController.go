@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/migration/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/migration/view"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/gorilla/mux"
+)
+
+type SchemaMigrationController interface {
+	GetSchemaMigrations(w http.ResponseWriter, r *http.Request)
+	PauseBackgroundMigration(w http.ResponseWriter, r *http.Request)
+	ResumeBackgroundMigration(w http.ResponseWriter, r *http.Request)
+	UpdateBackgroundMigrationThrottling(w http.ResponseWriter, r *http.Request)
+}
+
+func NewSchemaMigrationController(migrationService service.DBMigrationService, isSysadmFunc func(context.SecurityContext) bool) SchemaMigrationController {
+	return &schemaMigrationControllerImpl{
+		migrationService: migrationService,
+		isSysadm:         isSysadmFunc,
+	}
+}
+
+type schemaMigrationControllerImpl struct {
+	migrationService service.DBMigrationService
+	isSysadm         func(context.SecurityContext) bool
+}
+
+func (s schemaMigrationControllerImpl) GetSchemaMigrations(w http.ResponseWriter, r *http.Request) {
+	if !s.isSysadm(context.Create(r)) {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	result, err := s.migrationService.GetSchemaMigrations(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, "Failed to get schema migrations", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (s schemaMigrationControllerImpl) PauseBackgroundMigration(w http.ResponseWriter, r *http.Request) {
+	if !s.isSysadm(context.Create(r)) {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	num, ok := getMigrationNum(w, r)
+	if !ok {
+		return
+	}
+	result, err := s.migrationService.PauseBackgroundMigration(r.Context(), num, context.Create(r).GetUserId())
+	if err != nil {
+		utils.RespondWithError(w, "Failed to pause background migration", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (s schemaMigrationControllerImpl) ResumeBackgroundMigration(w http.ResponseWriter, r *http.Request) {
+	if !s.isSysadm(context.Create(r)) {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	num, ok := getMigrationNum(w, r)
+	if !ok {
+		return
+	}
+	result, err := s.migrationService.ResumeBackgroundMigration(r.Context(), num)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to resume background migration", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func (s schemaMigrationControllerImpl) UpdateBackgroundMigrationThrottling(w http.ResponseWriter, r *http.Request) {
+	if !s.isSysadm(context.Create(r)) {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	num, ok := getMigrationNum(w, r)
+	if !ok {
+		return
+	}
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.BackgroundMigrationThrottlingReq
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	result, err := s.migrationService.UpdateBackgroundMigrationThrottling(r.Context(), num, req)
+	if err != nil {
+		utils.RespondWithError(w, "Failed to update background migration throttling", err)
+		return
+	}
+	utils.RespondWithJson(w, http.StatusOK, result)
+}
+
+func getMigrationNum(w http.ResponseWriter, r *http.Request) (int, bool) {
+	num, err := strconv.Atoi(mux.Vars(r)["num"])
+	if err != nil {
+		utils.RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.IncorrectParamType,
+			Message: exception.IncorrectParamTypeMsg,
+			Params:  map[string]interface{}{"param": "num", "type": "int"},
+			Debug:   err.Error(),
+		})
+		return 0, false
+	}
+	return num, true
+}
@@ -1,6 +1,9 @@
 package entity
 
 import (
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	view2 "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
@@ -13,7 +16,7 @@ type PublishedContentMigrationEntity struct {
 	tableName struct{} `pg:"published_version_revision_content, alias:published_version_revision_content"`
 
 	entity.PublishedContentEntity
-	Data []byte `pg:"data, type:bytea"`
+	Data entity.CompressedData `pg:"data, type:bytea"`
 }
 
 type MigrationRunEntity struct {
@@ -159,3 +162,60 @@ type StageExecution struct {
 	End         time.Time              `json:"end"`
 	BuildsCount int                    `json:"buildsCount"`
 }
+
+type BackgroundMigrationEntity struct {
+	tableName struct{} `pg:"background_schema_migration, alias:background_schema_migration"`
+
+	Num           int        `pg:"num, pk, type:integer"`
+	Name          string     `pg:"name, type:varchar"`
+	Hash          string     `pg:"hash, type:varchar"`
+	SqlBatch      string     `pg:"sql_batch, type:varchar"`
+	SqlCount      string     `pg:"sql_count, type:varchar"`
+	BatchSize     int        `pg:"batch_size, type:integer"`
+	BatchDelayMs  int        `pg:"batch_delay_ms, type:integer, use_zero"`
+	Status        string     `pg:"status, type:varchar"`
+	BatchesDone   int64      `pg:"batches_done, type:bigint, use_zero"`
+	RowsProcessed int64      `pg:"rows_processed, type:bigint, use_zero"`
+	TotalRows     *int64     `pg:"total_rows, type:bigint"`
+	Details       string     `pg:"details, type:varchar"`
+	PausedBy      string     `pg:"paused_by, type:varchar"`
+	CreatedAt     time.Time  `pg:"created_at, type:timestamp without time zone"`
+	StartedAt     *time.Time `pg:"started_at, type:timestamp without time zone"`
+	LastActive    *time.Time `pg:"last_active, type:timestamp without time zone"`
+	FinishedAt    *time.Time `pg:"finished_at, type:timestamp without time zone"`
+}
+
+// BackgroundMigrationBatchSizePlaceholder is replaced with the batch size in the batch query
+const BackgroundMigrationBatchSizePlaceholder = "{{batchSize}}"
+
+func (e BackgroundMigrationEntity) MakeBatchQuery() string {
+	return strings.ReplaceAll(e.SqlBatch, BackgroundMigrationBatchSizePlaceholder, strconv.Itoa(e.BatchSize))
+}
+
+func MakeBackgroundMigrationView(ent BackgroundMigrationEntity) view.BackgroundMigration {
+	result := view.BackgroundMigration{
+		Num:           ent.Num,
+		Name:          ent.Name,
+		Status:        ent.Status,
+		BatchSize:     ent.BatchSize,
+		BatchDelayMs:  ent.BatchDelayMs,
+		BatchesDone:   ent.BatchesDone,
+		RowsProcessed: ent.RowsProcessed,
+		TotalRows:     ent.TotalRows,
+		Details:       ent.Details,
+		PausedBy:      ent.PausedBy,
+		CreatedAt:     ent.CreatedAt,
+		StartedAt:     ent.StartedAt,
+		LastActive:    ent.LastActive,
+		FinishedAt:    ent.FinishedAt,
+	}
+	if ent.Status == view.BackgroundMigrationStatusComplete {
+		progress := 100.0
+		result.ProgressPercent = &progress
+	} else if ent.TotalRows != nil && *ent.TotalRows > 0 {
+		// the estimation could be lower than the actual number of rows, e.g. if rows were added after it was made
+		progress := math.Min(99.9, math.Round(float64(ent.RowsProcessed)*1000/float64(*ent.TotalRows))/10)
+		result.ProgressPercent = &progress
+	}
+	return result
+}
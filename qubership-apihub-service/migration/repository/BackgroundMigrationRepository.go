@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	mEntity "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/migration/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/migration/view"
+	"github.com/go-pg/pg/v10"
+)
+
+type BackgroundMigrationRepository interface {
+	// RegisterMigrations stores new background migrations, already registered migrations are not changed
+	RegisterMigrations(ctx context.Context, ents []mEntity.BackgroundMigrationEntity) (int, error)
+	GetMigrations(ctx context.Context) ([]mEntity.BackgroundMigrationEntity, error)
+	GetMigration(ctx context.Context, num int) (*mEntity.BackgroundMigrationEntity, error)
+	// StartMigration sets running status to the pending migration and stores the estimated number of rows if it was not stored before
+	StartMigration(ctx context.Context, num int, totalRows *int64) error
+	// ExecuteBatch executes the next batch holding the row lock of the migration, so batches of the migration are never executed concurrently.
+	// The migration is completed when the batch affects no rows. Returns nil if the migration is not pending or running.
+	ExecuteBatch(ctx context.Context, num int) (*mEntity.BackgroundMigrationEntity, error)
+	// UpdateStatus returns false if the migration is not in one of expected statuses
+	UpdateStatus(ctx context.Context, num int, expectedStatuses []string, status string, details string, pausedBy string) (bool, error)
+	UpdateThrottling(ctx context.Context, num int, batchSize int, batchDelayMs int) error
+}
+
+func NewBackgroundMigrationRepository(cp db.ConnectionProvider) BackgroundMigrationRepository {
+	return &backgroundMigrationRepositoryImpl{cp: cp}
+}
+
+type backgroundMigrationRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (b backgroundMigrationRepositoryImpl) RegisterMigrations(ctx context.Context, ents []mEntity.BackgroundMigrationEntity) (int, error) {
+	if len(ents) == 0 {
+		return 0, nil
+	}
+	result, err := b.cp.GetConnection().ModelContext(ctx, &ents).
+		OnConflict("(num) DO NOTHING").
+		Insert()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+func (b backgroundMigrationRepositoryImpl) GetMigrations(ctx context.Context) ([]mEntity.BackgroundMigrationEntity, error) {
+	var result []mEntity.BackgroundMigrationEntity
+	err := b.cp.GetConnection().ModelContext(ctx, &result).
+		Order("num").
+		Select()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b backgroundMigrationRepositoryImpl) GetMigration(ctx context.Context, num int) (*mEntity.BackgroundMigrationEntity, error) {
+	result := new(mEntity.BackgroundMigrationEntity)
+	err := b.cp.GetConnection().ModelContext(ctx, result).
+		Where("num = ?", num).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b backgroundMigrationRepositoryImpl) StartMigration(ctx context.Context, num int, totalRows *int64) error {
+	_, err := b.cp.GetConnection().ModelContext(ctx, (*mEntity.BackgroundMigrationEntity)(nil)).
+		Set("status = ?", view.BackgroundMigrationStatusRunning).
+		Set("started_at = coalesce(started_at, now())").
+		Set("total_rows = coalesce(total_rows, ?)", totalRows).
+		Set("last_active = now()").
+		Where("num = ?", num).
+		Where("status in (?)", pg.In([]string{view.BackgroundMigrationStatusPending, view.BackgroundMigrationStatusRunning})).
+		Update()
+	return err
+}
+
+func (b backgroundMigrationRepositoryImpl) ExecuteBatch(ctx context.Context, num int) (*mEntity.BackgroundMigrationEntity, error) {
+	var result *mEntity.BackgroundMigrationEntity
+	err := b.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		ent := new(mEntity.BackgroundMigrationEntity)
+		err := tx.ModelContext(ctx, ent).
+			Where("num = ?", num).
+			For("UPDATE").
+			First()
+		if err != nil {
+			if err == pg.ErrNoRows {
+				return nil
+			}
+			return err
+		}
+		if ent.Status != view.BackgroundMigrationStatusPending && ent.Status != view.BackgroundMigrationStatusRunning {
+			return nil
+		}
+		res, err := tx.ExecContext(ctx, ent.MakeBatchQuery())
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		ent.BatchesDone++
+		ent.RowsProcessed += int64(res.RowsAffected())
+		ent.LastActive = &now
+		ent.Status = view.BackgroundMigrationStatusRunning
+		if res.RowsAffected() == 0 {
+			ent.Status = view.BackgroundMigrationStatusComplete
+			ent.FinishedAt = &now
+		}
+		_, err = tx.ModelContext(ctx, ent).
+			Column("batches_done", "rows_processed", "last_active", "status", "finished_at").
+			WherePK().
+			Update()
+		if err != nil {
+			return err
+		}
+		result = ent
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b backgroundMigrationRepositoryImpl) UpdateStatus(ctx context.Context, num int, expectedStatuses []string, status string, details string, pausedBy string) (bool, error) {
+	result, err := b.cp.GetConnection().ModelContext(ctx, (*mEntity.BackgroundMigrationEntity)(nil)).
+		Set("status = ?", status).
+		Set("details = ?", details).
+		Set("paused_by = ?", pausedBy).
+		Set("last_active = now()").
+		Where("num = ?", num).
+		Where("status in (?)", pg.In(expectedStatuses)).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+func (b backgroundMigrationRepositoryImpl) UpdateThrottling(ctx context.Context, num int, batchSize int, batchDelayMs int) error {
+	_, err := b.cp.GetConnection().ModelContext(ctx, (*mEntity.BackgroundMigrationEntity)(nil)).
+		Set("batch_size = ?", batchSize).
+		Set("batch_delay_ms = ?", batchDelayMs).
+		Where("num = ?", num).
+		Update()
+	return err
+}
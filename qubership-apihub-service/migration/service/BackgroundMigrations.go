@@ -0,0 +1,481 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/metrics"
+	mEntity "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/migration/entity"
+	mView "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/migration/view"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/go-pg/pg/v10"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// backgroundMigrationsTableVersion is the schema migration which creates background_schema_migration table
+	backgroundMigrationsTableVersion = 48
+
+	defaultBackgroundMigrationBatchSize    = 1000
+	defaultBackgroundMigrationBatchDelayMs = 100
+	maxBackgroundMigrationBatchDelayMs     = 60000
+
+	backgroundMigrationsJobName     = "backgroundSchemaMigrations"
+	backgroundMigrationsJobSchedule = "@every 1m"
+	backgroundMigrationsJobTimeout  = time.Hour
+	// backgroundMigrationsRunBudget is less than the job timeout, so a long migration is continued by the next run instead of being reported as timed out
+	backgroundMigrationsRunBudget = 50 * time.Minute
+
+	backgroundMigrationFileSuffix = ".background.sql"
+)
+
+var backgroundMigrationFileRegexp = regexp.MustCompile(`^[0-9]+_.+\.background\.sql$`)
+
+// migrationDirectiveRegexp matches "-- apihub:<directive> <value>" lines of migration files
+var migrationDirectiveRegexp = regexp.MustCompile(`^--\s*apihub:(\w+)(?:\s+(.*))?$`)
+
+// loadBackgroundMigrations reads N_name.background.sql files and validates "requiresBackground" directives of up migrations
+func (d *dbMigrationServiceImpl) loadBackgroundMigrations() (map[int]mEntity.BackgroundMigrationEntity, error) {
+	fileNames, err := os.ReadDir(d.migrationsFolder)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int]mEntity.BackgroundMigrationEntity)
+	for _, file := range fileNames {
+		if !backgroundMigrationFileRegexp.MatchString(file.Name()) {
+			continue
+		}
+		num, _ := strconv.Atoi(strings.Split(file.Name(), `_`)[0])
+		if _, exists := result[num]; exists {
+			return nil, fmt.Errorf("found duplicate background migration number: %v", file.Name())
+		}
+		if _, exists := d.upMigrations[num]; !exists {
+			return nil, fmt.Errorf("background migration '%v' doesn't belong to any of up migrations", file.Name())
+		}
+		if num < backgroundMigrationsTableVersion {
+			return nil, fmt.Errorf("background migration '%v' must have number %v or higher", file.Name(), backgroundMigrationsTableVersion)
+		}
+		data, err := os.ReadFile(filepath.Join(d.migrationsFolder, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read background migration file %v: %w", file.Name(), err)
+		}
+		ent, err := parseBackgroundMigration(num, strings.TrimSuffix(file.Name(), backgroundMigrationFileSuffix), data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid background migration file %v: %w", file.Name(), err)
+		}
+		result[num] = *ent
+	}
+	for num, upMigrationFile := range d.upMigrations {
+		data, err := os.ReadFile(upMigrationFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read up migration file %v: %w", upMigrationFile, err)
+		}
+		required, err := parseRequiredBackgroundMigrations(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid up migration file %v: %w", upMigrationFile, err)
+		}
+		for _, requiredNum := range required {
+			if _, exists := result[requiredNum]; !exists {
+				return nil, fmt.Errorf("up migration %v requires background migration %v which doesn't exist", num, requiredNum)
+			}
+			if requiredNum >= num {
+				return nil, fmt.Errorf("up migration %v requires background migration %v which is not applied before it", num, requiredNum)
+			}
+		}
+	}
+	return result, nil
+}
+
+// parseBackgroundMigration parses the batch query of the background migration along with its directives:
+//
+//	-- apihub:batchSize 1000
+//	-- apihub:batchDelayMs 100
+//	-- apihub:count <query returning the estimated number of rows to process>
+func parseBackgroundMigration(num int, name string, data []byte) (*mEntity.BackgroundMigrationEntity, error) {
+	ent := &mEntity.BackgroundMigrationEntity{
+		Num:          num,
+		Name:         name,
+		Hash:         calculateMigrationHash(num, data),
+		BatchSize:    defaultBackgroundMigrationBatchSize,
+		BatchDelayMs: defaultBackgroundMigrationBatchDelayMs,
+		Status:       mView.BackgroundMigrationStatusPending,
+		CreatedAt:    time.Now(),
+	}
+	batchLines := make([]string, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		directive := migrationDirectiveRegexp.FindStringSubmatch(strings.TrimSpace(line))
+		if directive == nil {
+			batchLines = append(batchLines, line)
+			continue
+		}
+		value := strings.TrimSpace(directive[2])
+		switch directive[1] {
+		case "batchSize":
+			batchSize, err := strconv.Atoi(value)
+			if err != nil || batchSize <= 0 {
+				return nil, fmt.Errorf("batchSize must be a positive number, got '%v'", value)
+			}
+			ent.BatchSize = batchSize
+		case "batchDelayMs":
+			batchDelayMs, err := strconv.Atoi(value)
+			if err != nil || batchDelayMs < 0 || batchDelayMs > maxBackgroundMigrationBatchDelayMs {
+				return nil, fmt.Errorf("batchDelayMs must be a number from 0 to %v, got '%v'", maxBackgroundMigrationBatchDelayMs, value)
+			}
+			ent.BatchDelayMs = batchDelayMs
+		case "count":
+			if value == "" {
+				return nil, fmt.Errorf("count query is empty")
+			}
+			ent.SqlCount = value
+		default:
+			return nil, fmt.Errorf("unknown directive '%v'", directive[1])
+		}
+	}
+	ent.SqlBatch = strings.TrimSpace(strings.Join(batchLines, "\n"))
+	if !strings.Contains(ent.SqlBatch, mEntity.BackgroundMigrationBatchSizePlaceholder) {
+		return nil, fmt.Errorf("batch query must limit the number of processed rows with %v placeholder", mEntity.BackgroundMigrationBatchSizePlaceholder)
+	}
+	return ent, nil
+}
+
+// parseRequiredBackgroundMigrations returns background migrations listed in "-- apihub:requiresBackground N[,M]" directives of the up migration
+func parseRequiredBackgroundMigrations(sqlUp string) ([]int, error) {
+	result := make([]int, 0)
+	for _, line := range strings.Split(sqlUp, "\n") {
+		directive := migrationDirectiveRegexp.FindStringSubmatch(strings.TrimSpace(line))
+		if directive == nil {
+			continue
+		}
+		if directive[1] != "requiresBackground" {
+			return nil, fmt.Errorf("unknown directive '%v'", directive[1])
+		}
+		for _, value := range strings.FieldsFunc(directive[2], func(r rune) bool { return r == ',' || r == ' ' }) {
+			num, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("requiresBackground must contain migration numbers, got '%v'", directive[2])
+			}
+			result = append(result, num)
+		}
+	}
+	return result, nil
+}
+
+type migrationsSegment struct {
+	// requiredBackground migrations must be complete before the segment is applied
+	requiredBackground []int
+	requiredBy         int
+	upMigrations       []mEntity.SchemaMigrationEntity
+}
+
+// splitByRequiredBackgroundMigrations splits up migrations into segments, each segment except the first one starts with a migration
+// which requires background migrations, so they are finished after the previous segment is applied
+func splitByRequiredBackgroundMigrations(upMigrations []mEntity.SchemaMigrationEntity) ([]migrationsSegment, error) {
+	sort.Slice(upMigrations, func(i, j int) bool {
+		return upMigrations[i].Num < upMigrations[j].Num
+	})
+	segments := []migrationsSegment{{}}
+	for _, upMigration := range upMigrations {
+		required, err := parseRequiredBackgroundMigrations(upMigration.SqlUp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid up migration %v: %w", upMigration.Num, err)
+		}
+		if len(required) > 0 {
+			segments = append(segments, migrationsSegment{requiredBackground: required, requiredBy: upMigration.Num})
+		}
+		last := &segments[len(segments)-1]
+		last.upMigrations = append(last.upMigrations, upMigration)
+	}
+	return segments, nil
+}
+
+func (d *dbMigrationServiceImpl) getSchemaVersion() (int, error) {
+	version := 0
+	_, err := d.cp.GetConnection().QueryOne(pg.Scan(&version), `SELECT version FROM schema_migrations`)
+	if err != nil && err != pg.ErrNoRows {
+		return 0, err
+	}
+	return version, nil
+}
+
+// registerBackgroundMigrations stores background migrations whose up migrations are applied, so the background job picks them up
+func (d *dbMigrationServiceImpl) registerBackgroundMigrations(ctx context.Context) error {
+	version, err := d.getSchemaVersion()
+	if err != nil {
+		return err
+	}
+	if version < backgroundMigrationsTableVersion {
+		return nil
+	}
+	ents := make([]mEntity.BackgroundMigrationEntity, 0)
+	for num, ent := range d.backgroundMigrations {
+		if num <= version {
+			ents = append(ents, ent)
+		}
+	}
+	registered, err := d.backgroundMigrationRepo.RegisterMigrations(ctx, ents)
+	if err != nil {
+		return fmt.Errorf("failed to register background migrations: %w", err)
+	}
+	if registered > 0 {
+		log.Infof("Schema Migration: registered %v new background migrations", registered)
+	}
+	stored, err := d.backgroundMigrationRepo.GetMigrations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read background migrations: %w", err)
+	}
+	for _, storedEnt := range stored {
+		if localEnt, exists := d.backgroundMigrations[storedEnt.Num]; exists && localEnt.Hash != storedEnt.Hash {
+			log.Warnf("Schema Migration: background migration %v was changed after it had been registered, the registered version is used", storedEnt.Num)
+		}
+	}
+	return nil
+}
+
+// finishRequiredBackgroundMigrations synchronously executes the remaining batches of background migrations required by the contract migration
+func (d *dbMigrationServiceImpl) finishRequiredBackgroundMigrations(ctx context.Context, requiredBy int, nums []int) error {
+	if err := d.registerBackgroundMigrations(ctx); err != nil {
+		return err
+	}
+	for _, num := range nums {
+		ent, err := d.backgroundMigrationRepo.GetMigration(ctx, num)
+		if err != nil {
+			return err
+		}
+		if ent == nil {
+			return fmt.Errorf("background migration %v required by migration %v is not registered", num, requiredBy)
+		}
+		if ent.Status == mView.BackgroundMigrationStatusComplete {
+			continue
+		}
+		log.Infof("Schema Migration: migration %v requires background migration %v in status '%v', finishing it", requiredBy, num, ent.Status)
+		_, err = d.backgroundMigrationRepo.UpdateStatus(ctx, num,
+			[]string{mView.BackgroundMigrationStatusPaused, mView.BackgroundMigrationStatusFailed},
+			mView.BackgroundMigrationStatusPending, "", "")
+		if err != nil {
+			return err
+		}
+		if err = d.runBackgroundMigration(ctx, num, false); err != nil {
+			return fmt.Errorf("failed to finish background migration %v required by migration %v: %w", num, requiredBy, err)
+		}
+		ent, err = d.backgroundMigrationRepo.GetMigration(ctx, num)
+		if err != nil {
+			return err
+		}
+		if ent.Status != mView.BackgroundMigrationStatusComplete {
+			return fmt.Errorf("background migration %v required by migration %v is in status '%v' after finishing", num, requiredBy, ent.Status)
+		}
+	}
+	return nil
+}
+
+// runBackgroundMigration executes batches until the migration is complete, paused or failed.
+// SQL errors of the batch fail the migration, other errors (e.g. connection loss) leave it running to be continued later.
+func (d *dbMigrationServiceImpl) runBackgroundMigration(ctx context.Context, num int, throttle bool) error {
+	ent, err := d.backgroundMigrationRepo.GetMigration(ctx, num)
+	if err != nil {
+		return err
+	}
+	if ent == nil || (ent.Status != mView.BackgroundMigrationStatusPending && ent.Status != mView.BackgroundMigrationStatusRunning) {
+		return nil
+	}
+	var totalRows *int64
+	if ent.TotalRows == nil && ent.SqlCount != "" {
+		var count int64
+		if _, err = d.cp.GetConnection().QueryOneContext(ctx, pg.Scan(&count), ent.SqlCount); err != nil {
+			log.Warnf("[BackgroundMigration] %v: failed to estimate the number of rows, progress will not be available: %v", num, err)
+		} else {
+			totalRows = &count
+		}
+	}
+	if err = d.backgroundMigrationRepo.StartMigration(ctx, num, totalRows); err != nil {
+		return err
+	}
+	log.Infof("[BackgroundMigration] %v: started, %v rows processed so far", num, ent.RowsProcessed)
+	started := time.Now()
+	rowsProcessed := ent.RowsProcessed
+	for {
+		ent, err = d.backgroundMigrationRepo.ExecuteBatch(ctx, num)
+		if err != nil {
+			var pgErr pg.Error
+			if errors.As(err, &pgErr) && ctx.Err() == nil {
+				log.Errorf("[BackgroundMigration] %v: batch failed: %v", num, err)
+				if _, updErr := d.backgroundMigrationRepo.UpdateStatus(context.Background(), num,
+					[]string{mView.BackgroundMigrationStatusRunning}, mView.BackgroundMigrationStatusFailed, err.Error(), ""); updErr != nil {
+					log.Errorf("[BackgroundMigration] %v: failed to set failed status: %v", num, updErr)
+				}
+			}
+			return fmt.Errorf("background migration %v batch failed: %w", num, err)
+		}
+		if ent == nil {
+			log.Infof("[BackgroundMigration] %v: stopped since it was paused", num)
+			return nil
+		}
+		metrics.BackgroundSchemaMigrationRows.WithLabelValues(ent.Name).Add(float64(ent.RowsProcessed - rowsProcessed))
+		rowsProcessed = ent.RowsProcessed
+		if ent.Status == mView.BackgroundMigrationStatusComplete {
+			log.Infof("[BackgroundMigration] %v: complete in %v, %v rows processed in %v batches", num, time.Since(started), ent.RowsProcessed, ent.BatchesDone)
+			return nil
+		}
+		if throttle && ent.BatchDelayMs > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(ent.BatchDelayMs) * time.Millisecond):
+			}
+		} else if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+func (d *dbMigrationServiceImpl) StartBackgroundMigrationsJob(backgroundJobService service.BackgroundJobService) error {
+	return backgroundJobService.RegisterJob(service.BackgroundJobDefinition{
+		Name:        backgroundMigrationsJobName,
+		Description: "Executes batches of pending background schema migrations",
+		Schedule:    backgroundMigrationsJobSchedule,
+		Distributed: true,
+		Timeout:     backgroundMigrationsJobTimeout,
+		Job:         service.BackgroundJobFunc(d.runPendingBackgroundMigrations),
+	})
+}
+
+func (d *dbMigrationServiceImpl) runPendingBackgroundMigrations(ctx context.Context) error {
+	ents, err := d.backgroundMigrationRepo.GetMigrations(ctx)
+	if err != nil {
+		return err
+	}
+	budgetCtx, cancel := context.WithTimeout(ctx, backgroundMigrationsRunBudget)
+	defer cancel()
+	var errs []error
+	for _, ent := range ents {
+		if ent.Status != mView.BackgroundMigrationStatusPending && ent.Status != mView.BackgroundMigrationStatusRunning {
+			continue
+		}
+		err = d.runBackgroundMigration(budgetCtx, ent.Num, true)
+		if budgetCtx.Err() != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Infof("[BackgroundMigration] %v: run budget %v is exceeded, it will be continued by the next run", ent.Num, backgroundMigrationsRunBudget)
+			return nil
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *dbMigrationServiceImpl) GetSchemaMigrations(ctx context.Context) (*mView.SchemaMigrations, error) {
+	version, err := d.getSchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	ents, err := d.backgroundMigrationRepo.GetMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &mView.SchemaMigrations{
+		SchemaVersion:        version,
+		BackgroundMigrations: make([]mView.BackgroundMigration, 0, len(ents)),
+	}
+	for _, ent := range ents {
+		result.BackgroundMigrations = append(result.BackgroundMigrations, mEntity.MakeBackgroundMigrationView(ent))
+	}
+	return result, nil
+}
+
+func (d *dbMigrationServiceImpl) PauseBackgroundMigration(ctx context.Context, num int, userId string) (*mView.BackgroundMigration, error) {
+	return d.updateBackgroundMigrationStatus(ctx, num, "paused",
+		[]string{mView.BackgroundMigrationStatusPending, mView.BackgroundMigrationStatusRunning},
+		mView.BackgroundMigrationStatusPaused, userId)
+}
+
+func (d *dbMigrationServiceImpl) ResumeBackgroundMigration(ctx context.Context, num int) (*mView.BackgroundMigration, error) {
+	return d.updateBackgroundMigrationStatus(ctx, num, "resumed",
+		[]string{mView.BackgroundMigrationStatusPaused, mView.BackgroundMigrationStatusFailed},
+		mView.BackgroundMigrationStatusPending, "")
+}
+
+func (d *dbMigrationServiceImpl) updateBackgroundMigrationStatus(ctx context.Context, num int, action string, expectedStatuses []string, status string, userId string) (*mView.BackgroundMigration, error) {
+	if _, err := d.getBackgroundMigration(ctx, num); err != nil {
+		return nil, err
+	}
+	updated, err := d.backgroundMigrationRepo.UpdateStatus(ctx, num, expectedStatuses, status, "", userId)
+	if err != nil {
+		return nil, err
+	}
+	ent, err := d.getBackgroundMigration(ctx, num)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, &exception.CustomError{
+			Status:  http.StatusConflict,
+			Code:    exception.BackgroundMigrationStatusConflict,
+			Message: exception.BackgroundMigrationStatusConflictMsg,
+			Params:  map[string]interface{}{"num": num, "status": ent.Status, "action": action},
+		}
+	}
+	log.Infof("[BackgroundMigration] %v: %v by user %v", num, action, userId)
+	result := mEntity.MakeBackgroundMigrationView(*ent)
+	return &result, nil
+}
+
+func (d *dbMigrationServiceImpl) UpdateBackgroundMigrationThrottling(ctx context.Context, num int, req mView.BackgroundMigrationThrottlingReq) (*mView.BackgroundMigration, error) {
+	if req.BatchSize <= 0 {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "batchSize", "value": req.BatchSize},
+		}
+	}
+	if req.BatchDelayMs < 0 || req.BatchDelayMs > maxBackgroundMigrationBatchDelayMs {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "batchDelayMs", "value": req.BatchDelayMs},
+		}
+	}
+	if _, err := d.getBackgroundMigration(ctx, num); err != nil {
+		return nil, err
+	}
+	if err := d.backgroundMigrationRepo.UpdateThrottling(ctx, num, req.BatchSize, req.BatchDelayMs); err != nil {
+		return nil, err
+	}
+	log.Infof("[BackgroundMigration] %v: throttling is changed to batchSize=%v, batchDelayMs=%v", num, req.BatchSize, req.BatchDelayMs)
+	ent, err := d.getBackgroundMigration(ctx, num)
+	if err != nil {
+		return nil, err
+	}
+	result := mEntity.MakeBackgroundMigrationView(*ent)
+	return &result, nil
+}
+
+func (d *dbMigrationServiceImpl) getBackgroundMigration(ctx context.Context, num int) (*mEntity.BackgroundMigrationEntity, error) {
+	ent, err := d.backgroundMigrationRepo.GetMigration(ctx, num)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.BackgroundMigrationNotFound,
+			Message: exception.BackgroundMigrationNotFoundMsg,
+			Params:  map[string]interface{}{"num": num},
+		}
+	}
+	return ent, nil
+}
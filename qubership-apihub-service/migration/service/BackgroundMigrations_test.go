@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+
+	mEntity "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/migration/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBackgroundMigration(t *testing.T) {
+	data := []byte(`-- apihub:batchSize 500
+-- apihub:batchDelayMs 0
+-- apihub:count select count(*) from operation where new_col is null
+update operation set new_col = old_col
+where (package_id, version, revision, operation_id) in (
+    select package_id, version, revision, operation_id from operation where new_col is null limit {{batchSize}}
+);
+`)
+	ent, err := parseBackgroundMigration(49, "49_fill_new_col", data)
+	require.NoError(t, err)
+	require.Equal(t, 500, ent.BatchSize)
+	require.Equal(t, 0, ent.BatchDelayMs)
+	require.Equal(t, "select count(*) from operation where new_col is null", ent.SqlCount)
+	require.NotContains(t, ent.SqlBatch, "apihub:")
+	require.Contains(t, ent.MakeBatchQuery(), "limit 500")
+
+	ent, err = parseBackgroundMigration(49, "49_fill_new_col", []byte(`delete from build where build_id in (select build_id from build limit {{batchSize}})`))
+	require.NoError(t, err)
+	require.Equal(t, defaultBackgroundMigrationBatchSize, ent.BatchSize)
+	require.Equal(t, defaultBackgroundMigrationBatchDelayMs, ent.BatchDelayMs)
+
+	_, err = parseBackgroundMigration(49, "49_fill_new_col", []byte(`update operation set new_col = old_col`))
+	require.ErrorContains(t, err, mEntity.BackgroundMigrationBatchSizePlaceholder)
+
+	_, err = parseBackgroundMigration(49, "49_fill_new_col", []byte("-- apihub:batchSize 0\nselect {{batchSize}}"))
+	require.ErrorContains(t, err, "batchSize")
+
+	_, err = parseBackgroundMigration(49, "49_fill_new_col", []byte("-- apihub:batchsize 10\nselect {{batchSize}}"))
+	require.ErrorContains(t, err, "unknown directive")
+}
+
+func TestSplitByRequiredBackgroundMigrations(t *testing.T) {
+	required, err := parseRequiredBackgroundMigrations("-- apihub:requiresBackground 49, 50\nalter table operation drop column old_col;")
+	require.NoError(t, err)
+	require.Equal(t, []int{49, 50}, required)
+
+	segments, err := splitByRequiredBackgroundMigrations([]mEntity.SchemaMigrationEntity{
+		{Num: 52, SqlUp: "-- apihub:requiresBackground 49,50\nalter table operation drop column old_col;"},
+		{Num: 50, SqlUp: "alter table operation add column other_col varchar;"},
+		{Num: 49, SqlUp: "alter table operation add column new_col varchar;"},
+		{Num: 53, SqlUp: "create index on operation (new_col);"},
+		{Num: 51, SqlUp: "-- regular comment\ncreate table tmp (id varchar);"},
+	})
+	require.NoError(t, err)
+	require.Len(t, segments, 2)
+	require.Empty(t, segments[0].requiredBackground)
+	require.Equal(t, []int{49, 50, 51}, segmentNums(segments[0]))
+	require.Equal(t, 52, segments[1].requiredBy)
+	require.Equal(t, []int{49, 50}, segments[1].requiredBackground)
+	require.Equal(t, []int{52, 53}, segmentNums(segments[1]))
+}
+
+func segmentNums(segment migrationsSegment) []int {
+	nums := make([]int, 0, len(segment.upMigrations))
+	for _, m := range segment.upMigrations {
+		nums = append(nums, m.Num)
+	}
+	return nums
+}
@@ -34,21 +34,29 @@ type DBMigrationService interface {
 	GetSuspiciousBuilds(migrationId string, changedField string, limit int, page int) ([]mView.SuspiciousMigrationBuild, error)
 	IsMigrationInProgress() (bool, error)
 	StartOpsMigrationRestoreProc(ctx context.Context)
+	// HandOffMigrations releases operations migrations run by the instance so that other instances restart them without delay
+	HandOffMigrations(ctx context.Context) error
 	GetMigrationPerfReport(migrationId string, includeHourPackageData bool, stageFilter *mView.OpsMigrationStage) (*mView.MigrPerfData, error)
+	StartBackgroundMigrationsJob(backgroundJobService service.BackgroundJobService) error
+	GetSchemaMigrations(ctx context.Context) (*mView.SchemaMigrations, error)
+	PauseBackgroundMigration(ctx context.Context, num int, userId string) (*mView.BackgroundMigration, error)
+	ResumeBackgroundMigration(ctx context.Context, num int) (*mView.BackgroundMigration, error)
+	UpdateBackgroundMigrationThrottling(ctx context.Context, num int, req mView.BackgroundMigrationThrottlingReq) (*mView.BackgroundMigration, error)
 }
 
 func NewDBMigrationService(cp db.ConnectionProvider, mRRepo mRepository.MigrationRunRepository,
-	bCRepo repository.BuildCleanupRepository, transitionRepository repository.TransitionRepository,
-	systemInfoService service.SystemInfoService, minioStorageService service.MinioStorageService) (DBMigrationService, error) {
+	bMRepo mRepository.BackgroundMigrationRepository, bCRepo repository.BuildCleanupRepository, transitionRepository repository.TransitionRepository,
+	systemInfoService service.SystemInfoService, blobStorageService service.BlobStorageService) (DBMigrationService, error) {
 	service := &dbMigrationServiceImpl{
-		cp:                     cp,
-		systemInfoService:      systemInfoService,
-		repo:                   mRRepo,
-		buildCleanupRepository: bCRepo,
-		transitionRepository:   transitionRepository,
-		migrationsFolder:       filepath.Join(systemInfoService.GetBasePath(), "resources", "migrations"),
-		minioStorageService:    minioStorageService,
-		instanceId:             uuid.New().String(),
+		cp:                      cp,
+		systemInfoService:       systemInfoService,
+		repo:                    mRRepo,
+		backgroundMigrationRepo: bMRepo,
+		buildCleanupRepository:  bCRepo,
+		transitionRepository:    transitionRepository,
+		migrationsFolder:        filepath.Join(systemInfoService.GetBasePath(), "resources", "migrations"),
+		blobStorageService:      blobStorageService,
+		instanceId:              uuid.New().String(),
 	}
 	upMigrations, downMigrations, err := service.getMigrationFilenamesMap()
 	if err != nil {
@@ -56,20 +64,27 @@ func NewDBMigrationService(cp db.ConnectionProvider, mRRepo mRepository.Migratio
 	}
 	service.upMigrations = upMigrations
 	service.downMigrations = downMigrations
+	backgroundMigrations, err := service.loadBackgroundMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read background migration files: %v", err.Error())
+	}
+	service.backgroundMigrations = backgroundMigrations
 	return service, nil
 }
 
 type dbMigrationServiceImpl struct {
-	cp                     db.ConnectionProvider
-	systemInfoService      service.SystemInfoService
-	repo                   mRepository.MigrationRunRepository
-	buildCleanupRepository repository.BuildCleanupRepository
-	transitionRepository   repository.TransitionRepository
-	migrationsFolder       string
-	upMigrations           map[int]string
-	downMigrations         map[int]string
-	minioStorageService    service.MinioStorageService
-	instanceId             string
+	cp                      db.ConnectionProvider
+	systemInfoService       service.SystemInfoService
+	repo                    mRepository.MigrationRunRepository
+	backgroundMigrationRepo mRepository.BackgroundMigrationRepository
+	buildCleanupRepository  repository.BuildCleanupRepository
+	transitionRepository    repository.TransitionRepository
+	migrationsFolder        string
+	upMigrations            map[int]string
+	downMigrations          map[int]string
+	backgroundMigrations    map[int]mEntity.BackgroundMigrationEntity
+	blobStorageService      service.BlobStorageService
+	instanceId              string
 }
 
 const storedMigrationsTableMigrationVersion = 1 // migration table will be created at first migration
@@ -126,15 +141,39 @@ func (d *dbMigrationServiceImpl) Migrate(basePath string) (currentMigrationNum i
 	if err != nil {
 		return 0, 0, false, fmt.Errorf("failed to calculate required migrations to execute: %w", err)
 	}
+	ctx := context.Background()
 	if len(upMigrations)+len(downMigrations) == 0 {
 		log.Infof("Schema Migration: no migrations required")
+		if err = d.registerBackgroundMigrations(ctx); err != nil {
+			return 0, 0, false, err
+		}
 		return currentMigrationNumber, newMigrationNumber, false, nil
 	}
 
-	err = d.applyRequiredMigrations(upMigrations, downMigrations)
+	// contract migrations are applied only after background migrations required by them are finished
+	segments, err := splitByRequiredBackgroundMigrations(upMigrations)
 	if err != nil {
 		return 0, 0, false, err
 	}
+	for i, segment := range segments {
+		if len(segment.requiredBackground) > 0 {
+			err = d.finishRequiredBackgroundMigrations(ctx, segment.requiredBy, segment.requiredBackground)
+			if err != nil {
+				return 0, 0, false, err
+			}
+		}
+		segmentDownMigrations := []mEntity.SchemaMigrationEntity{}
+		if i == 0 {
+			segmentDownMigrations = downMigrations
+		}
+		err = d.applyRequiredMigrations(segment.upMigrations, segmentDownMigrations)
+		if err != nil {
+			return 0, 0, false, err
+		}
+	}
+	if err = d.registerBackgroundMigrations(ctx); err != nil {
+		return 0, 0, false, err
+	}
 	log.Infof("Schema Migration: finished successfully")
 	return currentMigrationNumber, newMigrationNumber, true, nil
 }
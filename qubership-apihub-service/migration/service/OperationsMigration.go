@@ -82,7 +82,7 @@ func (d *dbMigrationServiceImpl) StartMigrateOperations(req mView.MigrationReque
 			}
 		}
 
-		om = stages.NewOpsMigration(d.cp, d.systemInfoService, d.minioStorageService, d.repo, d.buildCleanupRepository, mrEnt, "")
+		om = stages.NewOpsMigration(d.cp, d.systemInfoService, d.blobStorageService, d.repo, d.buildCleanupRepository, mrEnt, "")
 
 		return nil
 	})
@@ -10,10 +10,14 @@ import (
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
 
 	"github.com/go-pg/pg/v10"
+	log "github.com/sirupsen/logrus"
 )
 
 const MaxMigrationRetries = 3
 
+// handedOffMigrationStaleInterval makes a handed off migration look stale for restartMigrations() of other instances
+const handedOffMigrationStaleInterval = "1 hour"
+
 func (d *dbMigrationServiceImpl) restartMigrations() error {
 	var om *stages.OpsMigration
 
@@ -61,7 +65,7 @@ func (d *dbMigrationServiceImpl) restartMigrations() error {
 
 		mrEnt.InstanceId = d.instanceId
 		mrEnt.RetryCount = mrEnt.RetryCount + 1
-		om = stages.NewOpsMigration(d.cp, d.systemInfoService, d.minioStorageService, d.repo, d.buildCleanupRepository, mrEnt, mrEnt.Stage)
+		om = stages.NewOpsMigration(d.cp, d.systemInfoService, d.blobStorageService, d.repo, d.buildCleanupRepository, mrEnt, mrEnt.Stage)
 
 		return nil
 	})
@@ -78,3 +82,22 @@ func (d *dbMigrationServiceImpl) restartMigrations() error {
 
 	return nil
 }
+
+func (d *dbMigrationServiceImpl) HandOffMigrations(ctx context.Context) error {
+	// The empty instance_id stops the keepalive and the stages processing of the local migration on the ownership check.
+	// The retry count is decreased since restartMigrations() increases it, a planned hand-off must not exhaust MaxMigrationRetries.
+	res, err := d.cp.GetConnection().ModelContext(ctx, &mEntity.MigrationRunEntity{}).
+		Set("instance_id=''").
+		Set("updated_at=now() - ?::interval", handedOffMigrationStaleInterval).
+		Set("retry_count=greatest(retry_count - 1, 0)").
+		Where("instance_id=?", d.instanceId).
+		Where("status in (?)", pg.In([]string{mView.MigrationStatusRunning, mView.MigrationStatusCancelling})).
+		Update()
+	if err != nil {
+		return fmt.Errorf("failed to hand off operations migrations: %w", err)
+	}
+	if res.RowsAffected() > 0 {
+		log.Infof("%d operations migrations were handed off to other instances", res.RowsAffected())
+	}
+	return nil
+}
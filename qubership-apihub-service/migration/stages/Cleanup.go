@@ -22,7 +22,7 @@ func (d OpsMigration) StageCleanupBefore() error {
 		}
 		log.Infof("ops migration %s: cleaned %d rows from transformed_content_data", d.ent.Id, deleted)
 
-		if d.systemInfoService.IsMinioStorageActive() {
+		if !d.blobStorageService.IsDatabaseOnly(view.BlobNamespaceBuildResults) {
 			ids, err := withDBRetry(d, func() ([]string, error) {
 				return d.buildCleanupRepository.GetRemoveMigrationBuildIds(d.migrationCtx)
 			})
@@ -32,7 +32,7 @@ func (d OpsMigration) StageCleanupBefore() error {
 			if len(ids) == 0 {
 				log.Infof("ops migration %s: No migration build data to clean up", d.ent.Id)
 			} else {
-				err = d.minioStorageService.RemoveFiles(d.migrationCtx, view.BUILD_RESULT_TABLE, ids)
+				err = d.blobStorageService.DeleteBlobs(d.migrationCtx, view.BlobNamespaceBuildResults, ids)
 				if err != nil {
 					return err
 				}
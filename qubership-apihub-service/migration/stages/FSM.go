@@ -24,7 +24,7 @@ import (
 type OpsMigration struct {
 	cp                     db.ConnectionProvider
 	systemInfoService      service.SystemInfoService
-	minioStorageService    service.MinioStorageService
+	blobStorageService     service.BlobStorageService
 	repo                   mRepository.MigrationRunRepository
 	buildCleanupRepository repository.BuildCleanupRepository
 
@@ -37,7 +37,7 @@ type OpsMigration struct {
 
 func NewOpsMigration(cp db.ConnectionProvider,
 	systemInfoService service.SystemInfoService,
-	minioStorageService service.MinioStorageService,
+	blobStorageService service.BlobStorageService,
 	repo mRepository.MigrationRunRepository,
 	buildCleanupRepository repository.BuildCleanupRepository,
 	ent mEntity.MigrationRunEntity, restartStage mView.OpsMigrationStage) *OpsMigration {
@@ -45,7 +45,7 @@ func NewOpsMigration(cp db.ConnectionProvider,
 	return &OpsMigration{
 		cp:                     cp,
 		systemInfoService:      systemInfoService,
-		minioStorageService:    minioStorageService,
+		blobStorageService:     blobStorageService,
 		repo:                   repo,
 		buildCleanupRepository: buildCleanupRepository,
 		ent:                    &ent,
@@ -36,30 +36,7 @@ func (d OpsMigration) StageTSRecalculate() error {
 		return fmt.Errorf("failed to calculate ts_operation_data: %w", err)
 	}
 
-	log.Info("Calculating fts_operation_data")
-	calculateFullTextSearchOperationsQuery := fmt.Sprintf(`
-	insert into fts_operation_data
-		select data_hash,
-		to_tsvector(convert_from(data,'UTF-8'))  data_vector
-		from operation_data
-		where data_hash in (
-			select distinct o.data_hash
-			from operation o
-			inner join migration."expired_ts_operation_data_%s"  exp
-			on exp.package_id = o.package_id
-			and exp.version = o.version
-			and exp.revision = o.revision
-		)
-        order by 1
-        for update skip locked
-	on conflict (data_hash) do update
-	set data_vector = EXCLUDED.data_vector`, d.ent.Id)
-	_, err = withDBRetry(d, func() (orm.Result, error) {
-		return d.cp.GetConnection().ExecContext(d.migrationCtx, calculateFullTextSearchOperationsQuery)
-	})
-	if err != nil {
-		return fmt.Errorf("failed to calculate fts_operation_data: %w", err)
-	}
+	// fts_operation_data is calculated on publish since operation data is stored compressed
 
 	log.Info("Calculating fts_latest_release_operation_data")
 	recalculateLiteSearchQuery := fmt.Sprintf(`
@@ -74,9 +51,9 @@ func (d OpsMigration) StageTSRecalculate() error {
 	)
 	INSERT INTO fts_latest_release_operation_data (package_id, version, revision, operation_id, api_type, data_vector)
 	SELECT o.package_id, o.version, o.revision, o.operation_id, o.type,
-		to_tsvector(convert_from(od.data, 'UTF-8') || ' ' || coalesce(o.title, ''))
+		fod.data_vector || to_tsvector(coalesce(o.title, ''))
 	FROM operation o
-	INNER JOIN operation_data od ON o.data_hash = od.data_hash
+	INNER JOIN fts_operation_data fod ON o.data_hash = fod.data_hash
 	INNER JOIN latest_rev lr ON o.package_id = lr.package_id AND o.version = lr.version AND o.revision = lr.revision
 	ON CONFLICT (package_id, version, revision, operation_id)
 	DO UPDATE SET data_vector = EXCLUDED.data_vector`, d.ent.Id)
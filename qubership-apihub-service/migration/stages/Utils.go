@@ -152,7 +152,7 @@ func (d OpsMigration) addTaskToRebuild(migrationId string, versionEnt entity.Pub
 
 	var config, data []byte
 	var err error
-	if d.systemInfoService.IsMinioStorageActive() && !d.systemInfoService.IsMinioStoreOnlyBuildResult() {
+	if !d.blobStorageService.IsDatabaseOnly(view.BlobNamespaceSourcesArchives) {
 		savedSourcesQuery := `
 		select config, archive_checksum
 		from published_sources
@@ -166,7 +166,7 @@ func (d OpsMigration) addTaskToRebuild(migrationId string, versionEnt entity.Pub
 			return "", err
 		}
 		if configEntity.ArchiveChecksum != "" {
-			file, err := d.minioStorageService.GetFile(d.migrationCtx, view.PUBLISHED_SOURCES_ARCHIVES_TABLE, configEntity.ArchiveChecksum)
+			file, err := d.blobStorageService.GetBlob(d.migrationCtx, view.BlobNamespaceSourcesArchives, configEntity.ArchiveChecksum)
 			if err != nil {
 				return "", err
 			}
@@ -0,0 +1,39 @@
+package view
+
+import "time"
+
+const BackgroundMigrationStatusPending = "pending"
+const BackgroundMigrationStatusRunning = "running"
+const BackgroundMigrationStatusPaused = "paused"
+const BackgroundMigrationStatusComplete = "complete"
+const BackgroundMigrationStatusFailed = "failed"
+
+type BackgroundMigration struct {
+	Num           int    `json:"num"`
+	Name          string `json:"name"`
+	Status        string `json:"status"`
+	BatchSize     int    `json:"batchSize"`
+	BatchDelayMs  int    `json:"batchDelayMs"`
+	BatchesDone   int64  `json:"batchesDone"`
+	RowsProcessed int64  `json:"rowsProcessed"`
+	// TotalRows is the estimation of rows to process made before the first batch
+	TotalRows *int64 `json:"totalRows,omitempty"`
+	// ProgressPercent is calculated from TotalRows, it is not set if the estimation is not available
+	ProgressPercent *float64   `json:"progressPercent,omitempty"`
+	Details         string     `json:"details,omitempty"`
+	PausedBy        string     `json:"pausedBy,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	StartedAt       *time.Time `json:"startedAt,omitempty"`
+	LastActive      *time.Time `json:"lastActive,omitempty"`
+	FinishedAt      *time.Time `json:"finishedAt,omitempty"`
+}
+
+type SchemaMigrations struct {
+	SchemaVersion        int                   `json:"schemaVersion"`
+	BackgroundMigrations []BackgroundMigration `json:"backgroundMigrations"`
+}
+
+type BackgroundMigrationThrottlingReq struct {
+	BatchSize    int `json:"batchSize"`
+	BatchDelayMs int `json:"batchDelayMs"`
+}
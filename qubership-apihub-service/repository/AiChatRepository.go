@@ -39,6 +39,13 @@ type AiChatRepository interface {
 	ListMessages(ctx context.Context, f AiMessagesListFilter) ([]entity.AiChatMessageEntity, error)
 	ListMessagesChronological(ctx context.Context, chatID string, maxMessages int) ([]entity.AiChatMessageEntity, error)
 
+	CreateShare(ctx context.Context, share *entity.AiChatShareEntity, files []entity.AiChatShareFileEntity) error
+	GetShare(ctx context.Context, shareID string) (*entity.AiChatShareEntity, error)
+	ListChatShares(ctx context.Context, chatID string) ([]entity.AiChatShareEntity, error)
+	DeleteShare(ctx context.Context, shareID, userID string) (int, error)
+	ListShareFiles(ctx context.Context, shareID string) ([]entity.AiChatShareFileEntity, error)
+	GetShareFile(ctx context.Context, shareID, fileID string) (*entity.AiChatShareFileEntity, error)
+
 	ListUserIDs(ctx context.Context) ([]string, error)
 	DeleteUserChatsByRetention(ctx context.Context, userID string, retentionDays, pinnedForeverCount int) (int, error)
 }
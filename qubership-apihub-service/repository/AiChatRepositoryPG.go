@@ -261,6 +261,86 @@ func (r *aiChatRepositoryImpl) ListMessagesChronological(ctx context.Context, ch
 	return rows, nil
 }
 
+func (r *aiChatRepositoryImpl) CreateShare(ctx context.Context, share *entity.AiChatShareEntity, files []entity.AiChatShareFileEntity) error {
+	return r.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.ModelContext(ctx, share).Insert(); err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			return nil
+		}
+		_, err := tx.ModelContext(ctx, &files).Insert()
+		return err
+	})
+}
+
+func (r *aiChatRepositoryImpl) GetShare(ctx context.Context, shareID string) (*entity.AiChatShareEntity, error) {
+	res := new(entity.AiChatShareEntity)
+	err := r.cp.GetConnection().ModelContext(ctx, res).
+		Where("id = ?", shareID).
+		Limit(1).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *aiChatRepositoryImpl) ListChatShares(ctx context.Context, chatID string) ([]entity.AiChatShareEntity, error) {
+	var rows []entity.AiChatShareEntity
+	err := r.cp.GetConnection().ModelContext(ctx, &rows).
+		Where("chat_id = ?", chatID).
+		OrderExpr("created_at DESC").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *aiChatRepositoryImpl) DeleteShare(ctx context.Context, shareID, userID string) (int, error) {
+	res, err := r.cp.GetConnection().ModelContext(ctx, (*entity.AiChatShareEntity)(nil)).
+		Where("id = ?", shareID).
+		Where("user_id = ?", userID).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
+
+func (r *aiChatRepositoryImpl) ListShareFiles(ctx context.Context, shareID string) ([]entity.AiChatShareFileEntity, error) {
+	var rows []entity.AiChatShareFileEntity
+	err := r.cp.GetConnection().ModelContext(ctx, &rows).
+		Column("share_id", "file_id", "filename", "mime_type", "size_bytes").
+		Where("share_id = ?", shareID).
+		OrderExpr("filename ASC").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *aiChatRepositoryImpl) GetShareFile(ctx context.Context, shareID, fileID string) (*entity.AiChatShareFileEntity, error) {
+	res := new(entity.AiChatShareFileEntity)
+	err := r.cp.GetConnection().ModelContext(ctx, res).
+		Where("share_id = ?", shareID).
+		Where("file_id = ?", fileID).
+		Limit(1).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
 func (r *aiChatRepositoryImpl) ListUserIDs(ctx context.Context) ([]string, error) {
 	var ids []string
 	_, err := r.cp.GetConnection().QueryContext(ctx, &ids, "SELECT DISTINCT user_id FROM ai_chat")
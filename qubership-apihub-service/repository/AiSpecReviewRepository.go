@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+)
+
+type AiSpecReviewRepository interface {
+	GetConfig(ctx context.Context, packageId string) (*entity.AiSpecReviewConfigEntity, error)
+	SaveConfig(ctx context.Context, ent *entity.AiSpecReviewConfigEntity) error
+	// CreateReview returns false if the review for the revision already exists.
+	CreateReview(ctx context.Context, ent *entity.AiSpecReviewEntity) (bool, error)
+	// RestartReview replaces a finished review or a running one started before staleBefore; returns false if another review is still in progress.
+	RestartReview(ctx context.Context, ent *entity.AiSpecReviewEntity, staleBefore time.Time) (bool, error)
+	FinishReview(ctx context.Context, ent *entity.AiSpecReviewEntity, notifications []entity.AiSpecReviewNotificationEntity) error
+	GetReview(ctx context.Context, packageId string, version string, revision int) (*entity.AiSpecReviewEntity, error)
+	GetReviewNotifications(ctx context.Context, packageId string, version string, revision int) ([]entity.AiSpecReviewNotificationEntity, error)
+	// GetChangedOperations returns operations added or changed since previousVersion, or all operations if previousVersion is empty.
+	GetChangedOperations(ctx context.Context, packageId string, version string, revision int, previousPackageId string, previousVersion string, limit int) ([]entity.AiSpecReviewOperationEntity, int, error)
+}
+
+func NewAiSpecReviewRepositoryPG(cp db.ConnectionProvider) AiSpecReviewRepository {
+	return &aiSpecReviewRepositoryImpl{cp: cp}
+}
+
+type aiSpecReviewRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (r *aiSpecReviewRepositoryImpl) GetConfig(ctx context.Context, packageId string) (*entity.AiSpecReviewConfigEntity, error) {
+	res := new(entity.AiSpecReviewConfigEntity)
+	err := r.cp.GetConnection().ModelContext(ctx, res).
+		Where("package_id = ?", packageId).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *aiSpecReviewRepositoryImpl) SaveConfig(ctx context.Context, ent *entity.AiSpecReviewConfigEntity) error {
+	_, err := r.cp.GetConnection().ModelContext(ctx, ent).
+		OnConflict("(package_id) DO UPDATE").
+		Set("enabled = EXCLUDED.enabled").
+		Set("token_budget = EXCLUDED.token_budget").
+		Set("updated_by = EXCLUDED.updated_by").
+		Set("updated_at = EXCLUDED.updated_at").
+		Insert()
+	return err
+}
+
+func (r *aiSpecReviewRepositoryImpl) CreateReview(ctx context.Context, ent *entity.AiSpecReviewEntity) (bool, error) {
+	res, err := r.cp.GetConnection().ModelContext(ctx, ent).
+		OnConflict("DO NOTHING").
+		Insert()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+func (r *aiSpecReviewRepositoryImpl) RestartReview(ctx context.Context, ent *entity.AiSpecReviewEntity, staleBefore time.Time) (bool, error) {
+	restarted := false
+	err := r.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			delete from ai_spec_review
+			where package_id = ? and version = ? and revision = ?
+			and (status != ? or started_at < ?)`,
+			ent.PackageId, ent.Version, ent.Revision, view.AiSpecReviewStatusRunning, staleBefore)
+		if err != nil {
+			return err
+		}
+		res, err := tx.ModelContext(ctx, ent).OnConflict("DO NOTHING").Insert()
+		if err != nil {
+			return err
+		}
+		restarted = res.RowsAffected() > 0
+		return nil
+	})
+	return restarted, err
+}
+
+func (r *aiSpecReviewRepositoryImpl) FinishReview(ctx context.Context, ent *entity.AiSpecReviewEntity, notifications []entity.AiSpecReviewNotificationEntity) error {
+	return r.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.ModelContext(ctx, ent).WherePK().Update()
+		if err != nil {
+			return err
+		}
+		if len(notifications) > 0 {
+			_, err = tx.ModelContext(ctx, &notifications).Insert()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *aiSpecReviewRepositoryImpl) GetReview(ctx context.Context, packageId string, version string, revision int) (*entity.AiSpecReviewEntity, error) {
+	res := new(entity.AiSpecReviewEntity)
+	err := r.cp.GetConnection().ModelContext(ctx, res).
+		Where("package_id = ?", packageId).
+		Where("version = ?", version).
+		Where("revision = ?", revision).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *aiSpecReviewRepositoryImpl) GetReviewNotifications(ctx context.Context, packageId string, version string, revision int) ([]entity.AiSpecReviewNotificationEntity, error) {
+	var result []entity.AiSpecReviewNotificationEntity
+	err := r.cp.GetConnection().ModelContext(ctx, &result).
+		Where("package_id = ?", packageId).
+		Where("version = ?", version).
+		Where("revision = ?", revision).
+		Order("severity ASC", "operation_id ASC").
+		Select()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *aiSpecReviewRepositoryImpl) GetChangedOperations(ctx context.Context, packageId string, version string, revision int, previousPackageId string, previousVersion string, limit int) ([]entity.AiSpecReviewOperationEntity, int, error) {
+	var result []entity.AiSpecReviewOperationEntity
+	query := r.cp.GetConnection().ModelContext(ctx, &result).
+		ColumnExpr("o.operation_id, o.type, o.title, od.data").
+		Join("inner join operation_data od").
+		JoinOn("od.data_hash = o.data_hash").
+		Where("o.package_id = ?", packageId).
+		Where("o.version = ?", version).
+		Where("o.revision = ?", revision)
+	if previousVersion != "" {
+		query = query.Where(`exists (
+			select 1 from operation_comparison oc
+			where oc.package_id = o.package_id and oc.version = o.version and oc.revision = o.revision
+			and oc.operation_id = o.operation_id
+			and oc.previous_package_id = ? and oc.previous_version = ?
+			and oc.data_hash is not null and oc.data_hash is distinct from oc.previous_data_hash)`,
+			previousPackageId, previousVersion)
+	}
+	total, err := query.Order("o.type", "o.operation_id").Limit(limit).SelectAndCount()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, 0, err
+	}
+	return result, total, nil
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+)
+
+type BackgroundJobRepository interface {
+	GetJobStates(ctx context.Context) ([]entity.BackgroundJobStateEntity, error)
+	GetJobState(ctx context.Context, jobName string) (*entity.BackgroundJobStateEntity, error)
+	SaveJobState(ctx context.Context, ent *entity.BackgroundJobStateEntity) error
+
+	StoreRun(ctx context.Context, ent *entity.BackgroundJobRunEntity) error
+	UpdateRunAttempts(ctx context.Context, runId string, attempts int) error
+	UpdateRunStatus(ctx context.Context, runId string, status string, details string, finishedAt *time.Time) error
+	// InterruptRunningRuns marks running runs of the job as interrupted. If instanceId is set, only runs of the instance are updated.
+	InterruptRunningRuns(ctx context.Context, jobName string, instanceId string) (int, error)
+	// RequestRunCancel stores the cancel request for running runs of the job and returns them
+	RequestRunCancel(ctx context.Context, jobName string, userId string) ([]entity.BackgroundJobRunEntity, error)
+	IsRunCancelRequested(ctx context.Context, runId string) (bool, error)
+	GetLastRuns(ctx context.Context) ([]entity.BackgroundJobRunEntity, error)
+	GetJobRuns(ctx context.Context, req view.BackgroundJobRunsReq) ([]entity.BackgroundJobRunEntity, error)
+	DeleteRunsBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+func NewBackgroundJobRepository(cp db.ConnectionProvider) BackgroundJobRepository {
+	return &backgroundJobRepositoryImpl{cp: cp}
+}
+
+type backgroundJobRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (b backgroundJobRepositoryImpl) GetJobStates(ctx context.Context) ([]entity.BackgroundJobStateEntity, error) {
+	var result []entity.BackgroundJobStateEntity
+	err := b.cp.GetConnection().ModelContext(ctx, &result).Select()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b backgroundJobRepositoryImpl) GetJobState(ctx context.Context, jobName string) (*entity.BackgroundJobStateEntity, error) {
+	result := new(entity.BackgroundJobStateEntity)
+	err := b.cp.GetConnection().ModelContext(ctx, result).
+		Where("job_name = ?", jobName).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b backgroundJobRepositoryImpl) SaveJobState(ctx context.Context, ent *entity.BackgroundJobStateEntity) error {
+	_, err := b.cp.GetConnection().ModelContext(ctx, ent).
+		OnConflict("(job_name) DO UPDATE").
+		Set("disabled = EXCLUDED.disabled").
+		Set("updated_by = EXCLUDED.updated_by").
+		Set("updated_at = EXCLUDED.updated_at").
+		Insert()
+	return err
+}
+
+func (b backgroundJobRepositoryImpl) StoreRun(ctx context.Context, ent *entity.BackgroundJobRunEntity) error {
+	_, err := b.cp.GetConnection().ModelContext(ctx, ent).Insert()
+	return err
+}
+
+func (b backgroundJobRepositoryImpl) UpdateRunAttempts(ctx context.Context, runId string, attempts int) error {
+	_, err := b.cp.GetConnection().ModelContext(ctx, (*entity.BackgroundJobRunEntity)(nil)).
+		Set("attempts = ?", attempts).
+		Where("run_id = ?", runId).
+		Update()
+	return err
+}
+
+func (b backgroundJobRepositoryImpl) UpdateRunStatus(ctx context.Context, runId string, status string, details string, finishedAt *time.Time) error {
+	_, err := b.cp.GetConnection().ModelContext(ctx, (*entity.BackgroundJobRunEntity)(nil)).
+		Set("status = ?", status).
+		Set("details = ?", details).
+		Set("finished_at = ?", finishedAt).
+		Where("run_id = ?", runId).
+		Update()
+	return err
+}
+
+func (b backgroundJobRepositoryImpl) InterruptRunningRuns(ctx context.Context, jobName string, instanceId string) (int, error) {
+	query := b.cp.GetConnection().ModelContext(ctx, (*entity.BackgroundJobRunEntity)(nil)).
+		Set("status = ?", view.BackgroundJobStatusInterrupted).
+		Set("finished_at = ?", time.Now()).
+		Where("job_name = ?", jobName).
+		Where("status = ?", view.BackgroundJobStatusRunning)
+	if instanceId != "" {
+		query.Where("instance_id = ?", instanceId)
+	}
+	result, err := query.Update()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+func (b backgroundJobRepositoryImpl) RequestRunCancel(ctx context.Context, jobName string, userId string) ([]entity.BackgroundJobRunEntity, error) {
+	var result []entity.BackgroundJobRunEntity
+	_, err := b.cp.GetConnection().ModelContext(ctx, &result).
+		Set("cancel_requested_by = ?", userId).
+		Where("job_name = ?", jobName).
+		Where("status = ?", view.BackgroundJobStatusRunning).
+		Returning("*").
+		Update()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b backgroundJobRepositoryImpl) IsRunCancelRequested(ctx context.Context, runId string) (bool, error) {
+	return b.cp.GetConnection().ModelContext(ctx, (*entity.BackgroundJobRunEntity)(nil)).
+		Where("run_id = ?", runId).
+		Where("cancel_requested_by is not null").
+		Exists()
+}
+
+func (b backgroundJobRepositoryImpl) GetLastRuns(ctx context.Context) ([]entity.BackgroundJobRunEntity, error) {
+	var result []entity.BackgroundJobRunEntity
+	_, err := b.cp.GetConnection().QueryContext(ctx, &result, `
+		SELECT DISTINCT ON (job_name) *
+		FROM background_job_run
+		ORDER BY job_name, started_at DESC`)
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b backgroundJobRepositoryImpl) GetJobRuns(ctx context.Context, req view.BackgroundJobRunsReq) ([]entity.BackgroundJobRunEntity, error) {
+	var result []entity.BackgroundJobRunEntity
+	query := b.cp.GetConnection().ModelContext(ctx, &result)
+	if req.JobName != "" {
+		query.Where("job_name = ?", req.JobName)
+	}
+	if req.Status != "" {
+		query.Where("status = ?", req.Status)
+	}
+	err := query.Order("started_at DESC").
+		Limit(req.Limit).
+		Offset(req.Limit * req.Page).
+		Select()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b backgroundJobRepositoryImpl) DeleteRunsBefore(ctx context.Context, before time.Time) (int, error) {
+	result, err := b.cp.GetConnection().ModelContext(ctx, (*entity.BackgroundJobRunEntity)(nil)).
+		Where("started_at < ?", before).
+		Where("status != ?", view.BackgroundJobStatusRunning).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
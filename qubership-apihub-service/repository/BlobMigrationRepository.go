@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+)
+
+type BlobMigrationRepository interface {
+	// CreateRun returns false if another migration is already running
+	CreateRun(ctx context.Context, ent *entity.BlobMigrationRunEntity) (bool, error)
+	GetRun(ctx context.Context, runId string) (*entity.BlobMigrationRunEntity, error)
+	GetRuns(ctx context.Context, limit int, page int) ([]entity.BlobMigrationRunEntity, error)
+	// ClaimRun sets the run status to running for the instance, if the run is still in one of expected statuses
+	// and (for running status) was not active since activeBefore. Returns false if another migration is running or the run was claimed by someone else.
+	ClaimRun(ctx context.Context, runId string, instanceId string, expectedStatuses []string, activeBefore time.Time) (bool, error)
+	UpdateProgress(ctx context.Context, runId string, progress []view.BlobMigrationNamespaceProgress) error
+	UpdateStatus(ctx context.Context, runId string, status string, details string, finishedAt *time.Time) error
+	RequestCancel(ctx context.Context, runId string, userId string) (bool, error)
+	IsCancelRequested(ctx context.Context, runId string) (bool, error)
+}
+
+func NewBlobMigrationRepository(cp db.ConnectionProvider) BlobMigrationRepository {
+	return &blobMigrationRepositoryImpl{cp: cp}
+}
+
+type blobMigrationRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (b blobMigrationRepositoryImpl) CreateRun(ctx context.Context, ent *entity.BlobMigrationRunEntity) (bool, error) {
+	_, err := b.cp.GetConnection().ModelContext(ctx, ent).Insert()
+	if err != nil {
+		if isUniqueViolation(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (b blobMigrationRepositoryImpl) GetRun(ctx context.Context, runId string) (*entity.BlobMigrationRunEntity, error) {
+	result := new(entity.BlobMigrationRunEntity)
+	err := b.cp.GetConnection().ModelContext(ctx, result).
+		Where("run_id = ?", runId).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b blobMigrationRepositoryImpl) GetRuns(ctx context.Context, limit int, page int) ([]entity.BlobMigrationRunEntity, error) {
+	var result []entity.BlobMigrationRunEntity
+	err := b.cp.GetConnection().ModelContext(ctx, &result).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(limit * page).
+		Select()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b blobMigrationRepositoryImpl) ClaimRun(ctx context.Context, runId string, instanceId string, expectedStatuses []string, activeBefore time.Time) (bool, error) {
+	result, err := b.cp.GetConnection().ModelContext(ctx, (*entity.BlobMigrationRunEntity)(nil)).
+		Set("status = ?", view.BlobMigrationStatusRunning).
+		Set("instance_id = ?", instanceId).
+		Set("details = null").
+		Set("finished_at = null").
+		Set("cancel_requested_by = null").
+		Set("last_active = now()").
+		Where("run_id = ?", runId).
+		Where("status in (?)", pg.In(expectedStatuses)).
+		Where("(status != ? or last_active < ?)", view.BlobMigrationStatusRunning, activeBefore).
+		Update()
+	if err != nil {
+		if isUniqueViolation(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+func (b blobMigrationRepositoryImpl) UpdateProgress(ctx context.Context, runId string, progress []view.BlobMigrationNamespaceProgress) error {
+	_, err := b.cp.GetConnection().ModelContext(ctx, &entity.BlobMigrationRunEntity{Progress: progress}).
+		Column("progress").
+		Set("last_active = now()").
+		Where("run_id = ?", runId).
+		Update()
+	return err
+}
+
+func (b blobMigrationRepositoryImpl) UpdateStatus(ctx context.Context, runId string, status string, details string, finishedAt *time.Time) error {
+	_, err := b.cp.GetConnection().ModelContext(ctx, (*entity.BlobMigrationRunEntity)(nil)).
+		Set("status = ?", status).
+		Set("details = ?", details).
+		Set("finished_at = ?", finishedAt).
+		Set("last_active = now()").
+		Where("run_id = ?", runId).
+		Update()
+	return err
+}
+
+func (b blobMigrationRepositoryImpl) RequestCancel(ctx context.Context, runId string, userId string) (bool, error) {
+	result, err := b.cp.GetConnection().ModelContext(ctx, (*entity.BlobMigrationRunEntity)(nil)).
+		Set("cancel_requested_by = ?", userId).
+		Where("run_id = ?", runId).
+		Where("status = ?", view.BlobMigrationStatusRunning).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+func (b blobMigrationRepositoryImpl) IsCancelRequested(ctx context.Context, runId string) (bool, error) {
+	return b.cp.GetConnection().ModelContext(ctx, (*entity.BlobMigrationRunEntity)(nil)).
+		Where("run_id = ?", runId).
+		Where("cancel_requested_by is not null").
+		Exists()
+}
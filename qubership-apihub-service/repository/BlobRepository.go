@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+)
+
+// BlobRepository provides generic access to the blob columns of the tables which keep large blobs in the database
+type BlobRepository interface {
+	IsBlobTable(namespace string) bool
+	// GetBlob returns nil if there is no blob with the key
+	GetBlob(ctx context.Context, namespace string, key string) ([]byte, error)
+	PutBlob(ctx context.Context, namespace string, key string, data []byte) error
+	DeleteBlobs(ctx context.Context, namespace string, keys []string) error
+	ListBlobKeys(ctx context.Context, namespace string, afterKey string, limit int) ([]string, error)
+}
+
+type blobTable struct {
+	table     string
+	keyColumn string
+	// standalone tables contain nothing but the blob, so the whole row is created and deleted with the blob.
+	// Otherwise, the row with metadata is managed by the owning repository and only the data column is updated.
+	standalone bool
+}
+
+var blobTables = map[string]blobTable{
+	view.BlobNamespaceBuildResults:    {table: "build_result", keyColumn: "build_id", standalone: true},
+	view.BlobNamespaceSourcesArchives: {table: "published_sources_archives", keyColumn: "checksum", standalone: true},
+	view.BlobNamespaceExportResults:   {table: "export_result", keyColumn: "export_id"},
+}
+
+func NewBlobRepositoryPG(cp db.ConnectionProvider) BlobRepository {
+	return &blobRepositoryImpl{cp: cp}
+}
+
+type blobRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (b blobRepositoryImpl) IsBlobTable(namespace string) bool {
+	_, exists := blobTables[namespace]
+	return exists
+}
+
+func (b blobRepositoryImpl) GetBlob(ctx context.Context, namespace string, key string) ([]byte, error) {
+	t, err := getBlobTable(namespace)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	_, err = b.cp.GetConnection().QueryOneContext(ctx, pg.Scan(&data),
+		`select data from ? where ? = ? and data is not null limit 1`,
+		pg.Ident(t.table), pg.Ident(t.keyColumn), key)
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (b blobRepositoryImpl) PutBlob(ctx context.Context, namespace string, key string, data []byte) error {
+	t, err := getBlobTable(namespace)
+	if err != nil {
+		return err
+	}
+	if !t.standalone {
+		result, err := b.cp.GetConnection().ExecContext(ctx, `update ? set data = ? where ? = ?`,
+			pg.Ident(t.table), data, pg.Ident(t.keyColumn), key)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("failed to store blob: %s with %s='%s' not found", t.table, t.keyColumn, key)
+		}
+		return nil
+	}
+	// build_result has no primary key, so upsert is not possible there
+	return b.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.ExecContext(ctx, `delete from ? where ? = ?`, pg.Ident(t.table), pg.Ident(t.keyColumn), key)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `insert into ? (?, data) values (?, ?)`,
+			pg.Ident(t.table), pg.Ident(t.keyColumn), key, data)
+		return err
+	})
+}
+
+func (b blobRepositoryImpl) DeleteBlobs(ctx context.Context, namespace string, keys []string) error {
+	t, err := getBlobTable(namespace)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	if t.standalone {
+		_, err = b.cp.GetConnection().ExecContext(ctx, `delete from ? where ? in (?)`,
+			pg.Ident(t.table), pg.Ident(t.keyColumn), pg.In(keys))
+	} else {
+		_, err = b.cp.GetConnection().ExecContext(ctx, `update ? set data = null where ? in (?)`,
+			pg.Ident(t.table), pg.Ident(t.keyColumn), pg.In(keys))
+	}
+	return err
+}
+
+func (b blobRepositoryImpl) ListBlobKeys(ctx context.Context, namespace string, afterKey string, limit int) ([]string, error) {
+	t, err := getBlobTable(namespace)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0)
+	_, err = b.cp.GetConnection().QueryContext(ctx, &keys,
+		`select distinct ? from ? where ? > ? and data is not null order by 1 limit ?`,
+		pg.Ident(t.keyColumn), pg.Ident(t.table), pg.Ident(t.keyColumn), afterKey, limit)
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func getBlobTable(namespace string) (blobTable, error) {
+	t, exists := blobTables[namespace]
+	if !exists {
+		return blobTable{}, fmt.Errorf("namespace '%s' is not stored in the database", namespace)
+	}
+	return t, nil
+}
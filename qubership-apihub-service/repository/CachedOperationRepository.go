@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/cache"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+const (
+	operationsCacheRegion = "operations"
+	operationCacheRegion  = "operation"
+)
+
+// NewCachedOperationRepository wraps the repository with the response cache for operation lists and operations with data
+// of published revisions.
+func NewCachedOperationRepository(repo OperationRepository, responseCache cache.ResponseCache) OperationRepository {
+	return &cachedOperationRepositoryImpl{OperationRepository: repo, responseCache: responseCache}
+}
+
+type cachedOperationRepositoryImpl struct {
+	OperationRepository
+	responseCache cache.ResponseCache
+}
+
+func (c cachedOperationRepositoryImpl) GetOperations(ctx context.Context, packageId string, version string, revision int, operationType string, skipRefs bool, searchReq view.OperationListReq) ([]entity.OperationRichEntity, error) {
+	if searchReq.Group != "" || searchReq.EmptyGroup {
+		// operation groups are mutable, so the operations filtered by group are not cached
+		return c.OperationRepository.GetOperations(ctx, packageId, version, revision, operationType, skipRefs, searchReq)
+	}
+	key := c.responseCache.PackageKey(packageId, version, revision, operationType, skipRefs, cache.HashResponseCacheKeyPart(searchReq))
+	var result []entity.OperationRichEntity
+	if c.responseCache.Get(operationsCacheRegion, key, &result) {
+		return result, nil
+	}
+	result, err := c.OperationRepository.GetOperations(ctx, packageId, version, revision, operationType, skipRefs, searchReq)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		// empty result is not cached: it is cheap to read again and it may come from a stale read of the just published revision
+		return result, nil
+	}
+	c.responseCache.Put(operationsCacheRegion, key, result)
+	return result, nil
+}
+
+func (c cachedOperationRepositoryImpl) GetOperationById(ctx context.Context, packageId string, version string, revision int, operationType string, operationId string, includeData bool) (*entity.OperationRichEntity, error) {
+	key := c.responseCache.PackageKey(packageId, version, revision, operationType, operationId, includeData)
+	result := new(entity.OperationRichEntity)
+	if c.responseCache.Get(operationCacheRegion, key, result) {
+		return result, nil
+	}
+	result, err := c.OperationRepository.GetOperationById(ctx, packageId, version, revision, operationType, operationId, includeData)
+	if err != nil || result == nil {
+		return result, err
+	}
+	c.responseCache.Put(operationCacheRegion, key, result)
+	return result, nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedOperationRepositoryLaggingReplica(t *testing.T) {
+	cp := &testRoutingConnectionProvider{
+		primary: pg.Connect(&pg.Options{Addr: "primary:5432"}),
+		replica: pg.Connect(&pg.Options{Addr: "replica:5432"}),
+	}
+	// the revision is published on the primary, the replica has not received it yet
+	repo := &testOperationRepository{cp: cp, published: map[*pg.DB]bool{cp.primary: true}}
+	responseCache := newTestResponseCache()
+	cached := NewCachedOperationRepository(repo, responseCache)
+	ctx := context.Background()
+
+	operations, err := cached.GetOperations(ctx, "QS.QUOTE", "2024.1", 1, "rest", true, view.OperationListReq{})
+	require.NoError(t, err)
+	require.Len(t, operations, 1, "empty result of the replica is re-checked on the primary")
+	operation, err := cached.GetOperationById(ctx, "QS.QUOTE", "2024.1", 1, "rest", "quote-get", false)
+	require.NoError(t, err)
+	require.NotNil(t, operation)
+	require.Equal(t, 2, responseCache.puts)
+
+	repo.reads = nil
+	operations, err = cached.GetOperations(ctx, "QS.QUOTE", "2024.1", 1, "rest", true, view.OperationListReq{})
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+	require.Empty(t, repo.reads, "operations are served from the cache")
+
+	// the revision is not found anywhere, e.g. it is being published, so the empty result must not be cached
+	repo.published = map[*pg.DB]bool{}
+	operations, err = cached.GetOperations(ctx, "QS.QUOTE", "2024.2", 1, "rest", true, view.OperationListReq{})
+	require.NoError(t, err)
+	require.Empty(t, operations)
+	operation, err = cached.GetOperationById(ctx, "QS.QUOTE", "2024.2", 1, "rest", "quote-get", false)
+	require.NoError(t, err)
+	require.Nil(t, operation)
+	require.Equal(t, 2, responseCache.puts)
+
+	repo.published = map[*pg.DB]bool{cp.primary: true, cp.replica: true}
+	repo.reads = nil
+	operations, err = cached.GetOperations(ctx, "QS.QUOTE", "2024.2", 1, "rest", true, view.OperationListReq{})
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+	require.Equal(t, []*pg.DB{cp.replica}, repo.reads, "found on the replica, the primary is not queried")
+}
+
+// testRoutingConnectionProvider routes reads to the replica like the routing provider does for users who have not written recently
+type testRoutingConnectionProvider struct {
+	primary *pg.DB
+	replica *pg.DB
+}
+
+func (c *testRoutingConnectionProvider) GetConnection() *pg.DB {
+	return c.primary
+}
+
+func (c *testRoutingConnectionProvider) GetReadConnection(ctx context.Context) *pg.DB {
+	return c.replica
+}
+
+func (c *testRoutingConnectionProvider) MarkUserWrite(userId string) {
+}
+
+// testOperationRepository reads through the connection provider like operationRepositoryImpl,
+// the operations are found on the connections where the revision is published
+type testOperationRepository struct {
+	OperationRepository
+	cp        *testRoutingConnectionProvider
+	published map[*pg.DB]bool
+	reads     []*pg.DB
+}
+
+func (r *testOperationRepository) read(conn *pg.DB) bool {
+	r.reads = append(r.reads, conn)
+	return r.published[conn]
+}
+
+func (r *testOperationRepository) GetOperations(ctx context.Context, packageId string, version string, revision int, operationType string, skipRefs bool, searchReq view.OperationListReq) ([]entity.OperationRichEntity, error) {
+	var result []entity.OperationRichEntity
+	err := readWithPrimaryFallback(ctx, r.cp, func(conn *pg.DB) (bool, error) {
+		result = nil
+		if r.read(conn) {
+			result = append(result, entity.OperationRichEntity{OperationEntity: entity.OperationEntity{PackageId: packageId, Version: version, Revision: revision, Type: operationType, OperationId: "quote-get"}})
+		}
+		return len(result) > 0, nil
+	})
+	return result, err
+}
+
+func (r *testOperationRepository) GetOperationById(ctx context.Context, packageId string, version string, revision int, operationType string, operationId string, includeData bool) (*entity.OperationRichEntity, error) {
+	var result *entity.OperationRichEntity
+	err := readWithPrimaryFallback(ctx, r.cp, func(conn *pg.DB) (bool, error) {
+		result = nil
+		if r.read(conn) {
+			result = &entity.OperationRichEntity{OperationEntity: entity.OperationEntity{PackageId: packageId, Version: version, Revision: revision, Type: operationType, OperationId: operationId}}
+		}
+		return result != nil, nil
+	})
+	return result, err
+}
+
+type testResponseCache struct {
+	entries map[string][]byte
+	puts    int
+}
+
+func newTestResponseCache() *testResponseCache {
+	return &testResponseCache{entries: make(map[string][]byte)}
+}
+
+func (c *testResponseCache) Get(region string, key string, dest interface{}) bool {
+	data, exists := c.entries[region+":"+key]
+	return exists && json.Unmarshal(data, dest) == nil
+}
+
+func (c *testResponseCache) Put(region string, key string, value interface{}) {
+	data, _ := json.Marshal(value)
+	c.entries[region+":"+key] = data
+	c.puts++
+}
+
+func (c *testResponseCache) Delete(region string, keys ...string) {
+	for _, key := range keys {
+		delete(c.entries, region+":"+key)
+	}
+}
+
+func (c *testResponseCache) PackageKey(packageId string, parts ...interface{}) string {
+	return fmt.Sprint(packageId, parts)
+}
+
+func (c *testResponseCache) InvalidatePackage(packageId string, generation string) {
+}
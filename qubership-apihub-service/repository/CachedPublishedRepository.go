@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/cache"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/google/uuid"
+)
+
+const (
+	documentContentCacheRegion   = "documentContent"
+	contentDataCacheRegion       = "contentData"
+	versionComparisonCacheRegion = "versionComparison"
+)
+
+// NewCachedPublishedRepository wraps the repository with the response cache for immutable published data:
+// document content by revision and slug, document data by checksum and version comparisons.
+func NewCachedPublishedRepository(repo PublishedRepository, responseCache cache.ResponseCache) PublishedRepository {
+	return &cachedPublishedRepositoryImpl{PublishedRepository: repo, responseCache: responseCache}
+}
+
+type cachedPublishedRepositoryImpl struct {
+	PublishedRepository
+	responseCache cache.ResponseCache
+}
+
+func (c cachedPublishedRepositoryImpl) GetRevisionContentBySlug(packageId string, versionName string, slug string, revision int) (*entity.PublishedContentEntity, error) {
+	key := c.responseCache.PackageKey(packageId, versionName, revision, slug)
+	result := new(entity.PublishedContentEntity)
+	if c.responseCache.Get(documentContentCacheRegion, key, result) {
+		return result, nil
+	}
+	result, err := c.PublishedRepository.GetRevisionContentBySlug(packageId, versionName, slug, revision)
+	if err != nil || result == nil {
+		return result, err
+	}
+	c.responseCache.Put(documentContentCacheRegion, key, result)
+	return result, nil
+}
+
+func (c cachedPublishedRepositoryImpl) GetContentData(packageId string, checksum string) (*entity.PublishedContentDataEntity, error) {
+	// content data is addressed by checksum, so it never changes
+	key := packageId + "|" + checksum
+	result := new(entity.PublishedContentDataEntity)
+	if c.responseCache.Get(contentDataCacheRegion, key, result) {
+		return result, nil
+	}
+	result, err := c.PublishedRepository.GetContentData(packageId, checksum)
+	if err != nil || result == nil {
+		return result, err
+	}
+	c.responseCache.Put(contentDataCacheRegion, key, result)
+	return result, nil
+}
+
+func (c cachedPublishedRepositoryImpl) GetVersionComparison(comparisonId string) (*entity.VersionComparisonEntity, error) {
+	result := new(entity.VersionComparisonEntity)
+	if c.responseCache.Get(versionComparisonCacheRegion, comparisonId, result) {
+		return result, nil
+	}
+	result, err := c.PublishedRepository.GetVersionComparison(comparisonId)
+	if err != nil || result == nil {
+		return result, err
+	}
+	c.responseCache.Put(versionComparisonCacheRegion, comparisonId, result)
+	return result, nil
+}
+
+func (c cachedPublishedRepositoryImpl) CreateVersionWithData(packageInfo view.PackageInfoFile, publishId string, version *entity.PublishedVersionEntity, content []*entity.PublishedContentEntity,
+	data []*entity.PublishedContentDataEntity, refs []*entity.PublishedReferenceEntity, src *entity.PublishedSrcEntity, srcArchive *entity.PublishedSrcArchiveEntity,
+	operations []*entity.OperationEntity, operationsData []*entity.OperationDataEntity,
+	operationComparisons []*entity.OperationComparisonEntity, builderNotifications []*entity.BuilderNotificationsEntity,
+	versionComparisonEntities []*entity.VersionComparisonEntity, serviceName string, pkg *entity.PackageEntity, versionComparisonsFromCache []string,
+	versionInternalDocEntities []*entity.VersionInternalDocumentEntity, versionInternalDocDataEntities []*entity.VersionInternalDocumentDataEntity,
+	comparisonInternalDocEntities []*entity.ComparisonInternalDocumentEntity, comparisonInternalDocDataEntities []*entity.ComparisonInternalDocumentDataEntity,
+	operationSearchTexts []*entity.OperationSearchTextEntity) error {
+	err := c.PublishedRepository.CreateVersionWithData(packageInfo, publishId, version, content, data, refs, src, srcArchive,
+		operations, operationsData, operationComparisons, builderNotifications, versionComparisonEntities, serviceName, pkg, versionComparisonsFromCache,
+		versionInternalDocEntities, versionInternalDocDataEntities, comparisonInternalDocEntities, comparisonInternalDocDataEntities, operationSearchTexts)
+	if err != nil {
+		return err
+	}
+	c.deleteVersionComparisons(versionComparisonEntities)
+	return nil
+}
+
+func (c cachedPublishedRepositoryImpl) SaveVersionChanges(packageInfo view.PackageInfoFile, publishId string, operationComparisons []*entity.OperationComparisonEntity, versionComparisons []*entity.VersionComparisonEntity, versionComparisonsFromCache []string, comparisonInternalDocEntities []*entity.ComparisonInternalDocumentEntity, comparisonInternalDocDataEntities []*entity.ComparisonInternalDocumentDataEntity) error {
+	err := c.PublishedRepository.SaveVersionChanges(packageInfo, publishId, operationComparisons, versionComparisons, versionComparisonsFromCache, comparisonInternalDocEntities, comparisonInternalDocDataEntities)
+	if err != nil {
+		return err
+	}
+	c.deleteVersionComparisons(versionComparisons)
+	return nil
+}
+
+func (c cachedPublishedRepositoryImpl) DeleteVersionComparison(ctx context.Context, comparisonId string) (bool, error) {
+	deleted, err := c.PublishedRepository.DeleteVersionComparison(ctx, comparisonId)
+	if err != nil {
+		return deleted, err
+	}
+	c.responseCache.Delete(versionComparisonCacheRegion, comparisonId)
+	return deleted, nil
+}
+
+func (c cachedPublishedRepositoryImpl) DeletePackageRevisionsBeforeDate(ctx context.Context, packageId string, beforeDate time.Time, deleteLastRevision bool, deleteReleaseRevisions bool, deletedBy string) (int, int, error) {
+	deleted, deletedReleases, err := c.PublishedRepository.DeletePackageRevisionsBeforeDate(ctx, packageId, beforeDate, deleteLastRevision, deleteReleaseRevisions, deletedBy)
+	if deleted > 0 {
+		c.responseCache.InvalidatePackage(packageId, uuid.NewString())
+	}
+	return deleted, deletedReleases, err
+}
+
+func (c cachedPublishedRepositoryImpl) DeletePackageRevisions(ctx context.Context, packageId string, rule view.RevisionsRetentionRule, deletedBy string) (int, int, error) {
+	deleted, deletedReleases, err := c.PublishedRepository.DeletePackageRevisions(ctx, packageId, rule, deletedBy)
+	if deleted > 0 {
+		c.responseCache.InvalidatePackage(packageId, uuid.NewString())
+	}
+	return deleted, deletedReleases, err
+}
+
+func (c cachedPublishedRepositoryImpl) BulkUpdateDocumentShareability(entities []*entity.PublishedContentEntity) error {
+	err := c.PublishedRepository.BulkUpdateDocumentShareability(entities)
+	if err != nil {
+		return err
+	}
+	// shareability is the only mutable attribute of the published document
+	packageIds := make(map[string]struct{})
+	for _, ent := range entities {
+		packageIds[ent.PackageId] = struct{}{}
+	}
+	for packageId := range packageIds {
+		c.responseCache.InvalidatePackage(packageId, uuid.NewString())
+	}
+	return nil
+}
+
+func (c cachedPublishedRepositoryImpl) deleteVersionComparisons(versionComparisons []*entity.VersionComparisonEntity) {
+	comparisonIds := make([]string, 0, len(versionComparisons))
+	for _, comparison := range versionComparisons {
+		comparisonIds = append(comparisonIds, comparison.ComparisonId)
+	}
+	c.responseCache.Delete(versionComparisonCacheRegion, comparisonIds...)
+}
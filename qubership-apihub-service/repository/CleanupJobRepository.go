@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+)
+
+// CleanupJobRepository provides the details of cleanup job runs stored by the job processors, the runs themselves are stored by BackgroundJobService
+type CleanupJobRepository interface {
+	GetJobRuns(ctx context.Context, req view.CleanupJobRunsReq) ([]entity.CleanupJobRunEntity, error)
+}
+
+func NewCleanupJobRepository(cp db.ConnectionProvider) CleanupJobRepository {
+	return &cleanupJobRepositoryImpl{cp: cp}
+}
+
+type cleanupJobRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (c cleanupJobRepositoryImpl) GetJobRuns(ctx context.Context, req view.CleanupJobRunsReq) ([]entity.CleanupJobRunEntity, error) {
+	var result []entity.CleanupJobRunEntity
+	_, err := c.cp.GetConnection().QueryContext(ctx, &result, `
+		SELECT runs.*, coalesce(bjr.triggered_by, '') AS triggered_by FROM (
+			SELECT run_id, ? AS job_type, instance_id, coalesce(package_id, '') AS package_id, status, coalesce(details, '') AS details,
+				started_at, finished_at, delete_before, coalesce(deleted_items, 0) AS deleted_items
+			FROM versions_cleanup_run
+			UNION ALL
+			SELECT run_id, ? AS job_type, instance_id, '' AS package_id, status, coalesce(details, '') AS details,
+				started_at, finished_at, delete_before, coalesce(deleted_items, 0) AS deleted_items
+			FROM comparisons_cleanup_run
+			UNION ALL
+			SELECT run_id, ? AS job_type, instance_id, '' AS package_id, status, coalesce(details, '') AS details,
+				started_at, finished_at, delete_before, coalesce((deleted_items ->> 'totalRecords')::int, 0) AS deleted_items
+			FROM soft_deleted_data_cleanup_run
+			UNION ALL
+			SELECT run_id, ? AS job_type, instance_id, '' AS package_id, status, coalesce(details, '') AS details,
+				started_at, finished_at, NULL AS delete_before,
+				coalesce((SELECT sum(value::int) FROM jsonb_each_text(deleted_items)), 0)::int AS deleted_items
+			FROM unreferenced_data_cleanup_run
+		) runs
+		LEFT JOIN background_job_run bjr ON bjr.run_id = runs.run_id
+		WHERE (? = '' OR runs.job_type = ?)
+		AND (? = '' OR runs.status = ?)
+		ORDER BY runs.started_at DESC
+		LIMIT ?
+		OFFSET ?`,
+		view.CleanupJobTypeRevisions, view.CleanupJobTypeComparisons, view.CleanupJobTypeSoftDeletedData, view.CleanupJobTypeUnreferencedData,
+		req.JobType, req.JobType, req.Status, req.Status, req.Limit, req.Limit*req.Page)
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
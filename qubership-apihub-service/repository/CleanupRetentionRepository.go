@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/go-pg/pg/v10"
+)
+
+type CleanupRetentionRepository interface {
+	GetPolicies(ctx context.Context, dataClass string, packageId string) ([]entity.CleanupRetentionPolicyEntity, error)
+	GetPolicy(ctx context.Context, packageId string, dataClass string) (*entity.CleanupRetentionPolicyEntity, error)
+	SavePolicy(ctx context.Context, ent *entity.CleanupRetentionPolicyEntity) error
+	DeletePolicy(ctx context.Context, packageId string, dataClass string) (bool, error)
+
+	CreateDryRun(ctx context.Context, ent *entity.CleanupDryRunEntity) error
+	UpdateDryRun(ctx context.Context, ent *entity.CleanupDryRunEntity) error
+	GetDryRun(ctx context.Context, runId string) (*entity.CleanupDryRunEntity, error)
+	DeleteDryRunsBefore(ctx context.Context, before time.Time) error
+}
+
+func NewCleanupRetentionRepository(cp db.ConnectionProvider) CleanupRetentionRepository {
+	return &cleanupRetentionRepositoryImpl{cp: cp}
+}
+
+type cleanupRetentionRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (c cleanupRetentionRepositoryImpl) GetPolicies(ctx context.Context, dataClass string, packageId string) ([]entity.CleanupRetentionPolicyEntity, error) {
+	var result []entity.CleanupRetentionPolicyEntity
+	query := c.cp.GetConnection().ModelContext(ctx, &result)
+	if dataClass != "" {
+		query = query.Where("data_class = ?", dataClass)
+	}
+	if packageId != "" {
+		query = query.Where("package_id = ?", packageId)
+	}
+	err := query.Order("package_id", "data_class").Select()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c cleanupRetentionRepositoryImpl) GetPolicy(ctx context.Context, packageId string, dataClass string) (*entity.CleanupRetentionPolicyEntity, error) {
+	result := new(entity.CleanupRetentionPolicyEntity)
+	err := c.cp.GetConnection().ModelContext(ctx, result).
+		Where("package_id = ?", packageId).
+		Where("data_class = ?", dataClass).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c cleanupRetentionRepositoryImpl) SavePolicy(ctx context.Context, ent *entity.CleanupRetentionPolicyEntity) error {
+	_, err := c.cp.GetConnection().ModelContext(ctx, ent).
+		OnConflict("(package_id, data_class) DO UPDATE").
+		Set("ttl_days = EXCLUDED.ttl_days").
+		Set("keep_last_draft_revisions = EXCLUDED.keep_last_draft_revisions").
+		Set("keep_release_revisions = EXCLUDED.keep_release_revisions").
+		Set("archived_ttl_days = EXCLUDED.archived_ttl_days").
+		Set("updated_by = EXCLUDED.updated_by").
+		Set("updated_at = EXCLUDED.updated_at").
+		Insert()
+	return err
+}
+
+func (c cleanupRetentionRepositoryImpl) DeletePolicy(ctx context.Context, packageId string, dataClass string) (bool, error) {
+	result, err := c.cp.GetConnection().ModelContext(ctx, (*entity.CleanupRetentionPolicyEntity)(nil)).
+		Where("package_id = ?", packageId).
+		Where("data_class = ?", dataClass).
+		Delete()
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+func (c cleanupRetentionRepositoryImpl) CreateDryRun(ctx context.Context, ent *entity.CleanupDryRunEntity) error {
+	_, err := c.cp.GetConnection().ModelContext(ctx, ent).Insert()
+	return err
+}
+
+func (c cleanupRetentionRepositoryImpl) UpdateDryRun(ctx context.Context, ent *entity.CleanupDryRunEntity) error {
+	_, err := c.cp.GetConnection().ModelContext(ctx, ent).
+		Column("status", "details", "finished_at", "report").
+		WherePK().
+		Update()
+	return err
+}
+
+func (c cleanupRetentionRepositoryImpl) GetDryRun(ctx context.Context, runId string) (*entity.CleanupDryRunEntity, error) {
+	result := new(entity.CleanupDryRunEntity)
+	err := c.cp.GetConnection().ModelContext(ctx, result).
+		Where("run_id = ?", runId).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c cleanupRetentionRepositoryImpl) DeleteDryRunsBefore(ctx context.Context, before time.Time) error {
+	_, err := c.cp.GetConnection().ModelContext(ctx, (*entity.CleanupDryRunEntity)(nil)).
+		Where("started_at < ?", before).
+		Delete()
+	return err
+}
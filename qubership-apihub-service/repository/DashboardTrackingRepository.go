@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+)
+
+type DashboardTrackingRepository interface {
+	GetTracking(ctx context.Context, packageId string, version string) (*entity.DashboardTrackingEntity, error)
+	SaveTracking(ctx context.Context, ent *entity.DashboardTrackingEntity) error
+	DeleteTracking(ctx context.Context, packageId string, version string) (bool, error)
+	// GetTrackingDashboards returns enabled trackings which latest revision references the package directly
+	GetTrackingDashboards(ctx context.Context, refPackageId string) ([]entity.DashboardTrackingEntity, error)
+	// GetReleaseVersions returns the latest revisions of not deleted release versions of the package, the most recently published first
+	GetReleaseVersions(ctx context.Context, packageId string) ([]entity.ReleaseVersionEntity, error)
+	// CreateRollForward returns false if the roll forward for the same trigger already exists
+	CreateRollForward(ctx context.Context, ent *entity.DashboardRollForwardEntity) (bool, error)
+	UpdateRollForward(ctx context.Context, ent *entity.DashboardRollForwardEntity) error
+	GetRollForwards(ctx context.Context, packageId string, version string, limit int, page int) ([]entity.DashboardRollForwardEntity, error)
+	// FailStaleRollForwards marks roll forwards which are running since before the given time as failed
+	FailStaleRollForwards(ctx context.Context, startedBefore time.Time, details string) (int, error)
+}
+
+func NewDashboardTrackingRepositoryPG(cp db.ConnectionProvider) DashboardTrackingRepository {
+	return &dashboardTrackingRepositoryImpl{cp: cp}
+}
+
+type dashboardTrackingRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (d *dashboardTrackingRepositoryImpl) GetTracking(ctx context.Context, packageId string, version string) (*entity.DashboardTrackingEntity, error) {
+	result := new(entity.DashboardTrackingEntity)
+	err := d.cp.GetConnection().ModelContext(ctx, result).
+		Where("package_id = ?", packageId).
+		Where("version = ?", version).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (d *dashboardTrackingRepositoryImpl) SaveTracking(ctx context.Context, ent *entity.DashboardTrackingEntity) error {
+	_, err := d.cp.GetConnection().ModelContext(ctx, ent).
+		OnConflict("(package_id, version) DO UPDATE").
+		Insert()
+	return err
+}
+
+func (d *dashboardTrackingRepositoryImpl) DeleteTracking(ctx context.Context, packageId string, version string) (bool, error) {
+	res, err := d.cp.GetConnection().ModelContext(ctx, (*entity.DashboardTrackingEntity)(nil)).
+		Where("package_id = ?", packageId).
+		Where("version = ?", version).
+		Delete()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+func (d *dashboardTrackingRepositoryImpl) GetTrackingDashboards(ctx context.Context, refPackageId string) ([]entity.DashboardTrackingEntity, error) {
+	var result []entity.DashboardTrackingEntity
+	_, err := d.cp.GetConnection().QueryContext(ctx, &result, `
+		SELECT t.* FROM dashboard_tracking t
+		WHERE t.enabled
+			AND EXISTS (
+				SELECT 1 FROM published_version_reference r
+				JOIN published_version pv ON pv.package_id = r.package_id AND pv.version = r.version
+					AND pv.revision = r.revision AND pv.deleted_at IS NULL
+				WHERE r.package_id = t.package_id
+					AND r.version = t.version
+					AND r.revision = get_latest_revision(t.package_id, t.version)
+					AND r.reference_id = ?
+					AND r.parent_reference_id = ''
+			)
+		ORDER BY t.package_id, t.version`, refPackageId)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (d *dashboardTrackingRepositoryImpl) GetReleaseVersions(ctx context.Context, packageId string) ([]entity.ReleaseVersionEntity, error) {
+	var result []entity.ReleaseVersionEntity
+	_, err := d.cp.GetConnection().QueryContext(ctx, &result, `
+		SELECT pv.version, pv.revision FROM published_version pv
+		WHERE pv.package_id = ?
+			AND pv.status = ?
+			AND pv.deleted_at IS NULL
+			AND pv.revision = get_latest_revision(pv.package_id, pv.version)
+		ORDER BY pv.published_at DESC`, packageId, string(view.Release))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (d *dashboardTrackingRepositoryImpl) CreateRollForward(ctx context.Context, ent *entity.DashboardRollForwardEntity) (bool, error) {
+	res, err := d.cp.GetConnection().ModelContext(ctx, ent).
+		OnConflict("DO NOTHING").
+		Insert()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+func (d *dashboardTrackingRepositoryImpl) UpdateRollForward(ctx context.Context, ent *entity.DashboardRollForwardEntity) error {
+	_, err := d.cp.GetConnection().ModelContext(ctx, ent).
+		Column("revision", "publish_id", "status", "details", "finished_at").
+		WherePK().
+		Update()
+	return err
+}
+
+func (d *dashboardTrackingRepositoryImpl) GetRollForwards(ctx context.Context, packageId string, version string, limit int, page int) ([]entity.DashboardRollForwardEntity, error) {
+	var result []entity.DashboardRollForwardEntity
+	query := d.cp.GetConnection().ModelContext(ctx, &result).
+		Where("package_id = ?", packageId)
+	if version != "" {
+		query.Where("version = ?", version)
+	}
+	query.Order("created_at DESC")
+	if limit > 0 {
+		query.Limit(limit).Offset(limit * page)
+	}
+	err := query.Select()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (d *dashboardTrackingRepositoryImpl) FailStaleRollForwards(ctx context.Context, startedBefore time.Time, details string) (int, error) {
+	res, err := d.cp.GetConnection().ModelContext(ctx, (*entity.DashboardRollForwardEntity)(nil)).
+		Set("status = ?", view.DashboardRollForwardStatusError).
+		Set("details = ?", details).
+		Set("finished_at = ?", time.Now()).
+		Where("status = ?", view.DashboardRollForwardStatusRunning).
+		Where("created_at < ?", startedBefore).
+		Update()
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
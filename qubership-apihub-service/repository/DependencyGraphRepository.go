@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+)
+
+type DependencyGraphRepository interface {
+	// GetDependents returns not deleted package versions which reference the version of the package directly or via other dashboards.
+	// Any revision of the version is considered if revision is 0.
+	GetDependents(ctx context.Context, packageId string, version string, revision int, allRevisions bool, limit int, page int) ([]entity.PackageVersionDependentEntity, error)
+}
+
+func NewDependencyGraphRepositoryPG(cp db.ConnectionProvider) DependencyGraphRepository {
+	return &dependencyGraphRepositoryImpl{cp: cp}
+}
+
+type dependencyGraphRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (d *dependencyGraphRepositoryImpl) GetDependents(ctx context.Context, packageId string, version string, revision int, allRevisions bool, limit int, page int) ([]entity.PackageVersionDependentEntity, error) {
+	var result []entity.PackageVersionDependentEntity
+	_, err := d.cp.GetConnection().QueryContext(ctx, &result, `
+		SELECT r.package_id, r.version, r.revision, pg.name AS package_name, pg.kind, pv.status, pv.published_at,
+			get_latest_revision(r.package_id, r.version) != r.revision AS not_latest_revision,
+			r.reference_version, r.reference_revision,
+			bool_or(r.parent_reference_id = '') AS direct,
+			bool_and(r.excluded) AS excluded
+		FROM published_version_reference r
+		JOIN published_version pv ON pv.package_id = r.package_id AND pv.version = r.version
+			AND pv.revision = r.revision AND pv.deleted_at IS NULL
+		JOIN package_group pg ON pg.id = r.package_id AND pg.deleted_at IS NULL
+		WHERE r.reference_id = ?0
+			AND r.reference_version = ?1
+			AND (?2 = 0 OR r.reference_revision = ?2)
+			AND (?3 OR r.revision = get_latest_revision(r.package_id, r.version))
+		GROUP BY r.package_id, r.version, r.revision, pg.name, pg.kind, pv.status, pv.published_at,
+			r.reference_version, r.reference_revision
+		ORDER BY pv.published_at DESC, r.package_id, r.reference_revision DESC
+		LIMIT ?4
+		OFFSET ?5`, packageId, version, revision, allRevisions, limit, limit*page)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
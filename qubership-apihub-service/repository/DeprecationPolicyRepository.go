@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+)
+
+type DeprecationPolicyRepository interface {
+	GetPolicy(ctx context.Context, packageId string, apiType string, operationId string) (*entity.OperationDeprecationPolicyEntity, error)
+	GetPolicies(ctx context.Context, packageIds []string) ([]entity.OperationDeprecationPolicyEntity, error)
+	// SavePolicy creates or replaces the policy. The sunset alert is sent again if the sunset date is changed.
+	SavePolicy(ctx context.Context, ent *entity.OperationDeprecationPolicyEntity) error
+	// SaveSpecPolicies creates or replaces the policies captured from the specification, manual policies are kept as is.
+	SaveSpecPolicies(ctx context.Context, ents []entity.OperationDeprecationPolicyEntity) error
+	// DeleteSpecPolicies deletes the policies of the operations which were captured from the specification, manual policies are kept.
+	DeleteSpecPolicies(ctx context.Context, packageId string, apiType string, operationIds []string) error
+	DeletePolicy(ctx context.Context, packageId string, apiType string, operationId string) (bool, error)
+	// GetReleaseVersions returns the latest revisions of not deleted release versions of the package,
+	// the most recently created version (by the publication of its first revision) first
+	GetReleaseVersions(ctx context.Context, packageId string) ([]entity.PolicyReleaseVersionEntity, error)
+	// OperationExists checks if the operation is present in any published version of the package
+	OperationExists(ctx context.Context, packageId string, apiType string, operationId string) (bool, error)
+	GetSunsetCalendar(ctx context.Context, workspaceId string, from time.Time, to time.Time, limit int, page int) ([]entity.SunsetCalendarItemEntity, error)
+	// MarkPassedSunsets marks the policies which sunset date is before today while the operation is still present in a release
+	// and returns them. Every policy is returned only once for the sunset date, even if it is called concurrently.
+	MarkPassedSunsets(ctx context.Context, today time.Time) ([]entity.PassedSunsetEntity, error)
+}
+
+func NewDeprecationPolicyRepositoryPG(cp db.ConnectionProvider) DeprecationPolicyRepository {
+	return &deprecationPolicyRepositoryImpl{cp: cp}
+}
+
+type deprecationPolicyRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+// latestReleaseWithOperation selects the latest revision of the most recent release version which contains the operation of policy p
+const latestReleaseWithOperation = `
+	SELECT o.version, o.revision, o.title
+	FROM published_version pv
+	JOIN operation o ON o.package_id = pv.package_id AND o.version = pv.version AND o.revision = pv.revision
+		AND o.type = p.api_type AND o.operation_id = p.operation_id
+	WHERE pv.package_id = p.package_id
+		AND pv.status = 'release'
+		AND pv.deleted_at IS NULL
+		AND pv.revision = (
+			SELECT max(revision) FROM published_version
+			WHERE package_id = pv.package_id AND version = pv.version AND deleted_at IS NULL
+		)
+	ORDER BY pv.published_at DESC
+	LIMIT 1`
+
+func (r *deprecationPolicyRepositoryImpl) GetPolicy(ctx context.Context, packageId string, apiType string, operationId string) (*entity.OperationDeprecationPolicyEntity, error) {
+	res := new(entity.OperationDeprecationPolicyEntity)
+	err := r.cp.GetConnection().ModelContext(ctx, res).
+		Where("package_id = ?", packageId).
+		Where("api_type = ?", apiType).
+		Where("operation_id = ?", operationId).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *deprecationPolicyRepositoryImpl) GetPolicies(ctx context.Context, packageIds []string) ([]entity.OperationDeprecationPolicyEntity, error) {
+	var result []entity.OperationDeprecationPolicyEntity
+	if len(packageIds) == 0 {
+		return result, nil
+	}
+	err := r.cp.GetConnection().ModelContext(ctx, &result).
+		Where("package_id IN (?)", pg.In(packageIds)).
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *deprecationPolicyRepositoryImpl) SavePolicy(ctx context.Context, ent *entity.OperationDeprecationPolicyEntity) error {
+	_, err := r.upsertPolicies(ctx, ent).
+		Insert()
+	return err
+}
+
+func (r *deprecationPolicyRepositoryImpl) SaveSpecPolicies(ctx context.Context, ents []entity.OperationDeprecationPolicyEntity) error {
+	if len(ents) == 0 {
+		return nil
+	}
+	_, err := r.upsertPolicies(ctx, &ents).
+		Where("operation_deprecation_policy.source = ?", view.DeprecationPolicySourceSpec).
+		Insert()
+	return err
+}
+
+func (r *deprecationPolicyRepositoryImpl) upsertPolicies(ctx context.Context, model interface{}) *pg.Query {
+	return r.cp.GetConnection().ModelContext(ctx, model).
+		OnConflict("(package_id, api_type, operation_id) DO UPDATE").
+		Set("deprecated_since = EXCLUDED.deprecated_since").
+		Set("sunset_date = EXCLUDED.sunset_date").
+		Set("replacement_package_id = EXCLUDED.replacement_package_id").
+		Set("replacement_operation_id = EXCLUDED.replacement_operation_id").
+		Set("description = EXCLUDED.description").
+		Set("source = EXCLUDED.source").
+		Set("updated_by = EXCLUDED.updated_by").
+		Set("updated_at = EXCLUDED.updated_at").
+		Set(`sunset_alerted_at = CASE WHEN operation_deprecation_policy.sunset_date IS DISTINCT FROM EXCLUDED.sunset_date
+			THEN NULL ELSE operation_deprecation_policy.sunset_alerted_at END`)
+}
+
+func (r *deprecationPolicyRepositoryImpl) DeleteSpecPolicies(ctx context.Context, packageId string, apiType string, operationIds []string) error {
+	if len(operationIds) == 0 {
+		return nil
+	}
+	_, err := r.cp.GetConnection().ModelContext(ctx, (*entity.OperationDeprecationPolicyEntity)(nil)).
+		Where("package_id = ?", packageId).
+		Where("api_type = ?", apiType).
+		Where("operation_id IN (?)", pg.In(operationIds)).
+		Where("source = ?", view.DeprecationPolicySourceSpec).
+		Delete()
+	return err
+}
+
+func (r *deprecationPolicyRepositoryImpl) DeletePolicy(ctx context.Context, packageId string, apiType string, operationId string) (bool, error) {
+	res, err := r.cp.GetConnection().ModelContext(ctx, (*entity.OperationDeprecationPolicyEntity)(nil)).
+		Where("package_id = ?", packageId).
+		Where("api_type = ?", apiType).
+		Where("operation_id = ?", operationId).
+		Delete()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+func (r *deprecationPolicyRepositoryImpl) GetReleaseVersions(ctx context.Context, packageId string) ([]entity.PolicyReleaseVersionEntity, error) {
+	var result []entity.PolicyReleaseVersionEntity
+	_, err := r.cp.GetConnection().QueryContext(ctx, &result, `
+		SELECT pv.version, pv.previous_version, pv.previous_version_package_id
+		FROM published_version pv
+		WHERE pv.package_id = ?
+			AND pv.status = ?
+			AND pv.deleted_at IS NULL
+			AND pv.revision = get_latest_revision(pv.package_id, pv.version)
+		ORDER BY (
+			SELECT min(first.published_at) FROM published_version first
+			WHERE first.package_id = pv.package_id AND first.version = pv.version
+		) DESC`, packageId, string(view.Release))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *deprecationPolicyRepositoryImpl) OperationExists(ctx context.Context, packageId string, apiType string, operationId string) (bool, error) {
+	var exists bool
+	_, err := r.cp.GetConnection().QueryOneContext(ctx, pg.Scan(&exists), `
+		SELECT EXISTS (
+			SELECT 1 FROM operation
+			WHERE package_id = ? AND type = ? AND operation_id = ?
+		)`, packageId, apiType, operationId)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *deprecationPolicyRepositoryImpl) GetSunsetCalendar(ctx context.Context, workspaceId string, from time.Time, to time.Time, limit int, page int) ([]entity.SunsetCalendarItemEntity, error) {
+	var result []entity.SunsetCalendarItemEntity
+	_, err := r.cp.GetConnection().QueryContext(ctx, &result, `
+		SELECT p.*, pg.name AS package_name,
+			rel.version AS release_version, rel.revision AS release_revision, rel.title
+		FROM operation_deprecation_policy p
+		JOIN package_group pg ON pg.id = p.package_id AND pg.deleted_at IS NULL
+		LEFT JOIN LATERAL (`+latestReleaseWithOperation+`) rel ON true
+		WHERE (p.package_id = ?0 OR p.package_id LIKE ?0 || '.%')
+			AND p.sunset_date BETWEEN ?1 AND ?2
+		ORDER BY p.sunset_date, p.package_id, p.api_type, p.operation_id
+		LIMIT ?3
+		OFFSET ?4`, workspaceId, from, to, limit, limit*page)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *deprecationPolicyRepositoryImpl) MarkPassedSunsets(ctx context.Context, today time.Time) ([]entity.PassedSunsetEntity, error) {
+	var result []entity.PassedSunsetEntity
+	_, err := r.cp.GetConnection().QueryContext(ctx, &result, `
+		WITH passed AS (
+			SELECT p.package_id, p.api_type, p.operation_id, rel.version, rel.revision
+			FROM operation_deprecation_policy p
+			JOIN LATERAL (`+latestReleaseWithOperation+`) rel ON true
+			WHERE p.sunset_date < ? AND p.sunset_alerted_at IS NULL
+		)
+		UPDATE operation_deprecation_policy p SET sunset_alerted_at = now()
+		FROM passed
+		WHERE p.package_id = passed.package_id AND p.api_type = passed.api_type AND p.operation_id = passed.operation_id
+			AND p.sunset_alerted_at IS NULL
+		RETURNING p.package_id, p.operation_id, p.api_type, p.sunset_date,
+			passed.version AS release_version, passed.revision AS release_revision`, today)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
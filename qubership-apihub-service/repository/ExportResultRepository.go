@@ -14,7 +14,8 @@ import (
 type ExportResultRepository interface {
 	SaveExportResult(ent entity.ExportResultEntity) error
 	GetExportResult(exportId string) (*entity.ExportResultEntity, error)
-	CleanupExportResults(ttl time.Duration) error
+	// CleanupExportResults returns ids of deleted export results
+	CleanupExportResults(ttl time.Duration) ([]string, error)
 
 	// deprecated??
 	SaveTransformedDocument(data *entity.TransformedContentDataEntity, publishId string) error
@@ -30,12 +31,20 @@ type exportRepositoryImpl struct {
 	cp db.ConnectionProvider
 }
 
-func (p exportRepositoryImpl) CleanupExportResults(ttl time.Duration) error {
-	var ent entity.ExportResultEntity
-	_, err := p.cp.GetConnection().Model(&ent).
+func (p exportRepositoryImpl) CleanupExportResults(ttl time.Duration) ([]string, error) {
+	var ents []entity.ExportResultEntity
+	_, err := p.cp.GetConnection().Model(&ents).
 		Where("created_at < (now() - interval '? seconds')", int(ttl.Seconds())).
+		Returning("export_id").
 		Delete()
-	return err
+	if err != nil {
+		return nil, err
+	}
+	exportIds := make([]string, 0, len(ents))
+	for _, ent := range ents {
+		exportIds = append(exportIds, ent.ExportId)
+	}
+	return exportIds, nil
 }
 
 func (p exportRepositoryImpl) SaveExportResult(exportResEnt entity.ExportResultEntity) error {
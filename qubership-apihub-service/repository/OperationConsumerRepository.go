@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+)
+
+type OperationConsumerRepository interface {
+	GetConsumedOperations(ctx context.Context, consumerType string, consumerId string) ([]entity.OperationConsumerEntity, error)
+	// ReplaceConsumedOperations replaces all operations declared by the consumer with the given ones
+	ReplaceConsumedOperations(ctx context.Context, consumerType string, consumerId string, ents []entity.OperationConsumerEntity) error
+	// GetMissingOperations returns the declarations which operation is not published in the package (in the declared version if it is set)
+	GetMissingOperations(ctx context.Context, ents []entity.OperationConsumerEntity) ([]entity.OperationConsumerEntity, error)
+	// GetConsumers returns consumers of the package operations, all operations are considered if operationIds is empty.
+	// Consumers which are deleted (packages) or revoked (api keys) are skipped.
+	GetConsumers(ctx context.Context, packageId string, operationIds []string, limit int, page int) ([]entity.OperationConsumerInfoEntity, error)
+}
+
+func NewOperationConsumerRepositoryPG(cp db.ConnectionProvider) OperationConsumerRepository {
+	return &operationConsumerRepositoryImpl{cp: cp}
+}
+
+type operationConsumerRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (r *operationConsumerRepositoryImpl) GetConsumedOperations(ctx context.Context, consumerType string, consumerId string) ([]entity.OperationConsumerEntity, error) {
+	var result []entity.OperationConsumerEntity
+	err := r.cp.GetConnection().ModelContext(ctx, &result).
+		Where("consumer_type = ?", consumerType).
+		Where("consumer_id = ?", consumerId).
+		Order("package_id", "operation_id").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *operationConsumerRepositoryImpl) ReplaceConsumedOperations(ctx context.Context, consumerType string, consumerId string, ents []entity.OperationConsumerEntity) error {
+	return r.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.ModelContext(ctx, (*entity.OperationConsumerEntity)(nil)).
+			Where("consumer_type = ?", consumerType).
+			Where("consumer_id = ?", consumerId).
+			Delete()
+		if err != nil {
+			return err
+		}
+		if len(ents) == 0 {
+			return nil
+		}
+		_, err = tx.ModelContext(ctx, &ents).Insert()
+		return err
+	})
+}
+
+func (r *operationConsumerRepositoryImpl) GetMissingOperations(ctx context.Context, ents []entity.OperationConsumerEntity) ([]entity.OperationConsumerEntity, error) {
+	var result []entity.OperationConsumerEntity
+	if len(ents) == 0 {
+		return result, nil
+	}
+	packageIds := make([]string, 0, len(ents))
+	versions := make([]string, 0, len(ents))
+	operationIds := make([]string, 0, len(ents))
+	for _, ent := range ents {
+		packageIds = append(packageIds, ent.PackageId)
+		versions = append(versions, ent.Version)
+		operationIds = append(operationIds, ent.OperationId)
+	}
+	_, err := r.cp.GetConnection().QueryContext(ctx, &result, `
+		SELECT c.package_id, c.version, c.operation_id
+		FROM unnest(?::varchar[], ?::varchar[], ?::varchar[]) AS c(package_id, version, operation_id)
+		WHERE NOT EXISTS (
+			SELECT 1 FROM operation o
+			JOIN published_version pv ON pv.package_id = o.package_id AND pv.version = o.version
+				AND pv.revision = o.revision AND pv.deleted_at IS NULL
+			WHERE o.package_id = c.package_id
+				AND o.operation_id = c.operation_id
+				AND (c.version = '' OR o.version = c.version)
+		)`, pg.Array(packageIds), pg.Array(versions), pg.Array(operationIds))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *operationConsumerRepositoryImpl) GetConsumers(ctx context.Context, packageId string, operationIds []string, limit int, page int) ([]entity.OperationConsumerInfoEntity, error) {
+	var result []entity.OperationConsumerInfoEntity
+	query := r.cp.GetConnection().ModelContext(ctx, &result).
+		ColumnExpr("operation_consumer.*").
+		ColumnExpr("coalesce(pg.name, ak.name) AS consumer_name").
+		ColumnExpr("ak.package_id AS consumer_package_id").
+		Join("LEFT JOIN package_group pg").
+		JoinOn("operation_consumer.consumer_type = ?", view.ConsumerTypePackage).
+		JoinOn("pg.id = operation_consumer.consumer_id").
+		Join("LEFT JOIN apihub_api_keys ak").
+		JoinOn("operation_consumer.consumer_type = ?", view.ConsumerTypeApiKey).
+		JoinOn("ak.id = operation_consumer.consumer_id").
+		Where("operation_consumer.package_id = ?", packageId).
+		Where("(pg.id IS NOT NULL AND pg.deleted_at IS NULL) OR (ak.id IS NOT NULL AND ak.deleted_at IS NULL)")
+	if len(operationIds) > 0 {
+		query.Where("operation_consumer.operation_id IN (?)", pg.In(operationIds))
+	}
+	query.Order("operation_consumer.consumer_type", "operation_consumer.consumer_id", "operation_consumer.operation_id")
+	if limit > 0 {
+		query.Limit(limit).Offset(limit * page)
+	}
+	err := query.Select()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
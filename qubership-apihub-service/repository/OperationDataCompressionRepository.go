@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/go-pg/pg/v10"
+)
+
+type OperationDataCompressionRepository interface {
+	GetProgress(ctx context.Context) (*entity.OperationDataCompressionEntity, error)
+	GetOperationData(ctx context.Context, afterDataHash string, limit int) ([]entity.RawOperationDataEntity, error)
+	SaveCompressedOperationData(ctx context.Context, data []entity.RawOperationDataEntity, lastDataHash string) error
+	SetFinished(ctx context.Context) error
+}
+
+func NewOperationDataCompressionRepositoryPG(cp db.ConnectionProvider) OperationDataCompressionRepository {
+	return &operationDataCompressionRepositoryImpl{cp: cp}
+}
+
+type operationDataCompressionRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (r *operationDataCompressionRepositoryImpl) GetProgress(ctx context.Context) (*entity.OperationDataCompressionEntity, error) {
+	result := new(entity.OperationDataCompressionEntity)
+	err := r.cp.GetConnection().ModelContext(ctx, result).
+		Where("id = 1").
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *operationDataCompressionRepositoryImpl) GetOperationData(ctx context.Context, afterDataHash string, limit int) ([]entity.RawOperationDataEntity, error) {
+	var result []entity.RawOperationDataEntity
+	err := r.cp.GetConnection().ModelContext(ctx, &result).
+		Where("data_hash > ?", afterDataHash).
+		Order("data_hash").
+		Limit(limit).
+		Select()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SaveCompressedOperationData replaces the data of the rows with its compressed version and saves the progress.
+// Full text search vectors which are missing for the rows are calculated from the uncompressed data before the update.
+func (r *operationDataCompressionRepositoryImpl) SaveCompressedOperationData(ctx context.Context, data []entity.RawOperationDataEntity, lastDataHash string) error {
+	return r.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if len(data) > 0 {
+			dataHashes := make([]string, 0, len(data))
+			for _, d := range data {
+				dataHashes = append(dataHashes, d.DataHash)
+			}
+			_, err := tx.ExecContext(ctx, `
+				insert into fts_operation_data
+				select data_hash, to_tsvector(convert_from(data, 'UTF-8'))
+				from operation_data
+				where data_hash in (?)
+				on conflict (data_hash) do nothing`, pg.In(dataHashes))
+			if err != nil {
+				return err
+			}
+			for _, d := range data {
+				_, err = tx.ExecContext(ctx, `update operation_data set data = ? where data_hash = ?`, d.Data, d.DataHash)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		_, err := tx.ExecContext(ctx, `update operation_data_compression set last_data_hash = ? where id = 1`, lastDataHash)
+		return err
+	})
+}
+
+func (r *operationDataCompressionRepositoryImpl) SetFinished(ctx context.Context) error {
+	_, err := r.cp.GetConnection().ExecContext(ctx, `update operation_data_compression set finished_at = ? where id = 1`, time.Now())
+	return err
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+)
+
+type OperationHistoryRepository interface {
+	// GetPublishedVersions returns not deleted versions of the package in the order of publication
+	GetPublishedVersions(ctx context.Context, packageId string, allRevisions bool) ([]entity.OperationHistoryVersionEntity, error)
+	GetOperationStates(ctx context.Context, packageId string, operationId string) ([]entity.OperationHistoryStateEntity, error)
+	// GetOperationComparisons returns stored changes of the operation in comparisons where the package is the current side
+	GetOperationComparisons(ctx context.Context, packageId string, operationId string) ([]entity.OperationHistoryComparisonEntity, error)
+}
+
+func NewOperationHistoryRepositoryPG(cp db.ConnectionProvider) OperationHistoryRepository {
+	return &operationHistoryRepositoryImpl{cp: cp}
+}
+
+type operationHistoryRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (o *operationHistoryRepositoryImpl) GetPublishedVersions(ctx context.Context, packageId string, allRevisions bool) ([]entity.OperationHistoryVersionEntity, error) {
+	var result []entity.OperationHistoryVersionEntity
+	_, err := o.cp.GetConnection().QueryContext(ctx, &result, `
+		SELECT pv.version, pv.revision, pv.status, pv.published_at, pv.previous_version, pv.previous_version_package_id
+		FROM published_version pv
+		WHERE pv.package_id = ?0
+			AND pv.deleted_at IS NULL
+			AND (?1 OR pv.revision = get_latest_revision(pv.package_id, pv.version))
+		ORDER BY pv.published_at, pv.revision`, packageId, allRevisions)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (o *operationHistoryRepositoryImpl) GetOperationStates(ctx context.Context, packageId string, operationId string) ([]entity.OperationHistoryStateEntity, error) {
+	var result []entity.OperationHistoryStateEntity
+	_, err := o.cp.GetConnection().QueryContext(ctx, &result, `
+		SELECT o.version, o.revision, o.type, o.title, o.data_hash, o.deprecated, o.deprecated_info,
+			o.previous_release_versions, o.api_audience
+		FROM operation o
+		WHERE o.package_id = ?0
+			AND o.operation_id = ?1`, packageId, operationId)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (o *operationHistoryRepositoryImpl) GetOperationComparisons(ctx context.Context, packageId string, operationId string) ([]entity.OperationHistoryComparisonEntity, error) {
+	var result []entity.OperationHistoryComparisonEntity
+	_, err := o.cp.GetConnection().QueryContext(ctx, &result, `
+		SELECT vc.version, vc.revision, vc.previous_package_id, vc.previous_version, vc.previous_revision,
+			oc.changes_summary, oc.changes
+		FROM operation_comparison oc
+		JOIN version_comparison vc ON vc.comparison_id = oc.comparison_id
+		WHERE vc.package_id = ?0
+			AND (oc.operation_id = ?1 OR oc.previous_operation_id = ?1)`, packageId, operationId)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
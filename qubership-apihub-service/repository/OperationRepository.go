@@ -23,8 +23,8 @@ const globalSearchScopeJoinPlaceholder = "/*scope_join*/"
 
 type OperationRepository interface {
 	GetOperationsByIds(packageId string, version string, revision int, operationIds []string) ([]entity.OperationEntity, error)
-	GetOperations(packageId string, version string, revision int, operationType string, skipRefs bool, searchReq view.OperationListReq) ([]entity.OperationRichEntity, error)
-	GetOperationById(packageId string, version string, revision int, operationType string, operationId string, includeData bool) (*entity.OperationRichEntity, error)
+	GetOperations(ctx context.Context, packageId string, version string, revision int, operationType string, skipRefs bool, searchReq view.OperationListReq) ([]entity.OperationRichEntity, error)
+	GetOperationById(ctx context.Context, packageId string, version string, revision int, operationType string, operationId string, includeData bool) (*entity.OperationRichEntity, error)
 	GetOperationsTags(searchQuery entity.OperationTagsSearchQueryEntity, skipRefs bool) ([]string, error)
 	GetOperationChanges(comparisonId string, operationId string, severities []string) (*entity.OperationComparisonEntity, error)
 	GetOperationChangesSummary(comparisonId string, operationId string, refPackageId string) (*entity.OperationComparisonSummaryEntity, error)
@@ -83,9 +83,35 @@ func (o operationRepositoryImpl) GetOperationsByIds(packageId string, version st
 	return result, nil
 }
 
-func (o operationRepositoryImpl) GetOperationById(packageId string, version string, revision int, operationType string, operationId string, includeData bool) (*entity.OperationRichEntity, error) {
+// readWithPrimaryFallback runs the read on a read connection and repeats it on the primary if nothing is found there,
+// since a replica lagging behind the primary may not have received the published revision yet.
+func readWithPrimaryFallback(ctx context.Context, cp db.ConnectionProvider, read func(conn *pg.DB) (bool, error)) error {
+	conn := cp.GetReadConnection(ctx)
+	found, err := read(conn)
+	if err != nil || found {
+		return err
+	}
+	if primary := cp.GetConnection(); conn != primary {
+		_, err = read(primary)
+	}
+	return err
+}
+
+// GetOperationById reads the operation from a read replica. Published revisions are immutable, so only a replica
+// which has not received the revision yet may miss the operation; such misses are re-checked on the primary.
+func (o operationRepositoryImpl) GetOperationById(ctx context.Context, packageId string, version string, revision int, operationType string, operationId string, includeData bool) (*entity.OperationRichEntity, error) {
+	var result *entity.OperationRichEntity
+	err := readWithPrimaryFallback(ctx, o.cp, func(conn *pg.DB) (bool, error) {
+		var err error
+		result, err = o.getOperationById(ctx, conn, packageId, version, revision, operationType, operationId, includeData)
+		return result != nil, err
+	})
+	return result, err
+}
+
+func (o operationRepositoryImpl) getOperationById(ctx context.Context, conn *pg.DB, packageId string, version string, revision int, operationType string, operationId string, includeData bool) (*entity.OperationRichEntity, error) {
 	result := new(entity.OperationRichEntity)
-	query := o.cp.GetConnection().Model(result).
+	query := conn.ModelContext(ctx, result).
 		ColumnExpr("operation.*").
 		Where("package_id = ?", packageId).
 		Where("version = ?", version).
@@ -128,9 +154,20 @@ func (o operationRepositoryImpl) GetOperationDeprecatedItems(packageId string, v
 	return result, nil
 }
 
-func (o operationRepositoryImpl) GetOperations(packageId string, version string, revision int, operationType string, skipRefs bool, searchReq view.OperationListReq) ([]entity.OperationRichEntity, error) {
+// GetOperations reads the operations from a read replica, an empty result is re-checked on the primary (see GetOperationById)
+func (o operationRepositoryImpl) GetOperations(ctx context.Context, packageId string, version string, revision int, operationType string, skipRefs bool, searchReq view.OperationListReq) ([]entity.OperationRichEntity, error) {
 	var result []entity.OperationRichEntity
-	query := o.cp.GetConnection().Model(&result).
+	err := readWithPrimaryFallback(ctx, o.cp, func(conn *pg.DB) (bool, error) {
+		var err error
+		result, err = o.getOperations(ctx, conn, packageId, version, revision, operationType, skipRefs, searchReq)
+		return len(result) > 0, err
+	})
+	return result, err
+}
+
+func (o operationRepositoryImpl) getOperations(ctx context.Context, conn *pg.DB, packageId string, version string, revision int, operationType string, skipRefs bool, searchReq view.OperationListReq) ([]entity.OperationRichEntity, error) {
+	var result []entity.OperationRichEntity
+	query := conn.ModelContext(ctx, &result).
 		ColumnExpr("operation.*")
 
 	if !skipRefs {
@@ -1706,7 +1743,7 @@ limit ?limit;
                 or (ts.package_id ~>=~ (scope_pkg.parent || '.') and ts.package_id ~<~ (scope_pkg.parent || '/'))`
 	}
 
-	err := o.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+	err := o.cp.GetReadConnection(ctx).RunInTransaction(ctx, func(tx *pg.Tx) error {
 		if _, err := tx.Exec("SET LOCAL work_mem = ?", globalSearchWorkMem); err != nil {
 			return err
 		}
@@ -42,6 +42,9 @@ type PublishedRepository interface {
 	GetReadonlyPackageVersionsWithLimit(searchQuery entity.PublishedVersionSearchQueryEntity, checkRevisions bool, showOnlyDeleted bool) ([]entity.PackageVersionRevisionEntity, error)
 	GetDefaultVersion(packageId string, status string) (*entity.PublishedVersionEntity, error)
 	DeletePackageRevisionsBeforeDate(ctx context.Context, packageId string, beforeDate time.Time, deleteLastRevision bool, deleteReleaseRevisions bool, deletedBy string) (int, int, error)
+	DeletePackageRevisions(ctx context.Context, packageId string, rule view.RevisionsRetentionRule, deletedBy string) (int, int, error)
+	GetPackageRevisionsCleanupCandidates(ctx context.Context, packageId string, rule view.RevisionsRetentionRule) ([]entity.RevisionCleanupCandidateEntity, error)
+	GetSoftDeletedDataCleanupCandidates(ctx context.Context, beforeDate time.Time, packageId string, limit int) ([]entity.SoftDeletedCleanupCandidateEntity, error)
 	DeleteSoftDeletedPackageRevisionsBeforeDate(ctx context.Context, runId string, beforeDate time.Time, batchSize int) (int, error)
 
 	GetFileSharedInfo(packageId string, fileId string, versionName string) (*entity.SharedUrlInfoEntity, error)
@@ -73,6 +76,7 @@ type PublishedRepository interface {
 	GetVersionRefsComparisons(comparisonId string) ([]entity.VersionComparisonEntity, error)
 	GetVersionComparisonsCleanupCandidates(ctx context.Context, limit int, offset int) ([]entity.VersionComparisonCleanupCandidateEntity, error)
 	DeleteVersionComparison(ctx context.Context, comparisonId string) (bool, error)
+	GetVersionComparisonCleanupInfo(ctx context.Context, comparisonId string) (*entity.ComparisonCleanupInfoEntity, error)
 	SaveVersionChanges(packageInfo view.PackageInfoFile, publishId string, operationComparisons []*entity.OperationComparisonEntity, versionComparisons []*entity.VersionComparisonEntity, versionComparisonsFromCache []string, comparisonInternalDocEntities []*entity.ComparisonInternalDocumentEntity, comparisonInternalDocDataEntities []*entity.ComparisonInternalDocumentDataEntity) error
 	GetLatestRevision(packageId, version string) (int, error)
 	GetDeletedPackageLatestRevision(packageId, version string) (int, error)
@@ -159,9 +159,9 @@ func (p publishedRepositoryImpl) PatchVersion(packageId string, versionName stri
 			calculateLiteSearchOperationsQuery := `
 								insert into fts_latest_release_operation_data
 								select o.package_id, o.version, o.revision, o.operation_id, o.type,
-									to_tsvector(convert_from(od.data,'UTF-8') || ' ' || coalesce(o.title,''))
+									fod.data_vector || to_tsvector(coalesce(o.title,''))
 									data_vector
-								from operation o inner join operation_data od on o.data_hash=od.data_hash
+								from operation o inner join fts_operation_data fod on o.data_hash=fod.data_hash
 									where package_id = ? and version = ? and revision = ?
 								on conflict (package_id, version, revision, operation_id) do update set data_vector = EXCLUDED.data_vector;`
 			_, err = tx.Exec(calculateLiteSearchOperationsQuery,
@@ -1406,7 +1406,17 @@ func (p publishedRepositoryImpl) CreateVersionWithData(packageInfo view.PackageI
 			if err != nil {
 				return fmt.Errorf("failed to insert operation_data: %w", err)
 			}
-			utils.PerfLog(time.Since(start).Milliseconds(), 50, "CreateVersionWithData: operationsData insert")
+			// operation data is stored compressed, so its full text search vector is calculated from the uncompressed data
+			for _, data := range newOperationsData {
+				_, err = tx.Exec(`insert into fts_operation_data (data_hash, data_vector)
+					values (?, to_tsvector(convert_from(?, 'UTF-8')))
+					on conflict (data_hash) do update set data_vector = EXCLUDED.data_vector`,
+					data.DataHash, []byte(data.Data))
+				if err != nil {
+					return fmt.Errorf("failed to insert fts_operation_data: %w", err)
+				}
+			}
+			utils.PerfLog(time.Since(start).Milliseconds(), 50+int64(len(newOperationsData)*10), "CreateVersionWithData: operationsData insert")
 		}
 
 		var existingGroupedOperations []entity.GroupedOperationEntity
@@ -1468,18 +1478,6 @@ func (p publishedRepositoryImpl) CreateVersionWithData(packageInfo view.PackageI
 					return fmt.Errorf("failed to insert ts_operation_data: %w", err)
 				}
 
-				calculateFullTextSearchOperationsQuery := `
-					insert into fts_operation_data
-					select data_hash,
-						to_tsvector(convert_from(data,'UTF-8'))  data_vector
-					from operation_data where operation_data.data_hash in (select distinct data_hash from operation where package_id = ? and version = ? and revision = ?)
-					on conflict (data_hash) do update set data_vector = EXCLUDED.data_vector`
-				_, err = tx.Exec(calculateFullTextSearchOperationsQuery,
-					version.PackageId, version.Version, version.Revision)
-				if err != nil {
-					return fmt.Errorf("failed to insert fts_operation_data: %w", err)
-				}
-
 				utils.PerfLog(time.Since(start).Milliseconds(), 1000, "CreateVersionWithData: ts_vectors insert")
 			}
 		}
@@ -1498,9 +1496,9 @@ func (p publishedRepositoryImpl) CreateVersionWithData(packageInfo view.PackageI
 			calculateLiteSearchOperationsQuery := `
 						insert into fts_latest_release_operation_data
 						select o.package_id, o.version, o.revision, o.operation_id, o.type,
-							to_tsvector(convert_from(od.data,'UTF-8') || ' ' || coalesce(o.title,''))
+							fod.data_vector || to_tsvector(coalesce(o.title,''))
 							data_vector
-						from operation o inner join operation_data od on o.data_hash=od.data_hash
+						from operation o inner join fts_operation_data fod on o.data_hash=fod.data_hash
 							where package_id = ? and version = ? and revision = ?
 						on conflict (package_id, version, revision, operation_id) do update set data_vector = EXCLUDED.data_vector;`
 			_, err = tx.Exec(calculateLiteSearchOperationsQuery,
@@ -2109,7 +2107,7 @@ func (p publishedRepositoryImpl) GetContentData(packageId string, checksum strin
 
 func (p publishedRepositoryImpl) GetVersionSources(packageId string, versionName string, revision int) (*entity.PublishedSrcArchiveEntity, error) {
 	query := `
-		select psa.*
+		select psa.checksum, psa.data
 		from published_sources_archives psa, published_sources ps
 		where ps.package_id = ?
 		and ps.version = ?
@@ -2735,9 +2733,9 @@ func (p publishedRepositoryImpl) updateFtsIndexForExcludeFromSearchChange(tx *pg
 			)
 			INSERT INTO fts_latest_release_operation_data (package_id, version, revision, operation_id, api_type, data_vector)
 			SELECT o.package_id, o.version, o.revision, o.operation_id, o.type,
-				   to_tsvector(convert_from(od.data, 'UTF-8'))
+				   fod.data_vector
 			FROM operation o
-			INNER JOIN operation_data od ON o.data_hash = od.data_hash
+			INNER JOIN fts_operation_data fod ON o.data_hash = fod.data_hash
 			INNER JOIN maxrev mr ON o.package_id = mr.package_id AND o.version = mr.version AND o.revision = mr.revision
 			ON CONFLICT (package_id, version, revision, operation_id) DO UPDATE SET data_vector = EXCLUDED.data_vector`
 		_, err := tx.Exec(repopulateLiteSearchQuery, packageId, packageId)
@@ -3407,7 +3405,7 @@ func (p publishedRepositoryImpl) GetVersionRefsComparisons(comparisonId string)
 func (p publishedRepositoryImpl) GetVersionRevisionContentForDocumentsTransformation(packageId string, versionName string, revision int, searchQuery entity.ContentForDocumentsTransformationSearchQueryEntity) ([]entity.PublishedContentWithDataEntity, error) {
 	var ents []entity.PublishedContentWithDataEntity
 	query := p.cp.GetConnection().Model(&ents).Distinct().
-		ColumnExpr("published_version_revision_content.*").ColumnExpr("pd.*").ColumnExpr("published_version_revision_content.package_id as content_package_id")
+		ColumnExpr("published_version_revision_content.*").ColumnExpr("pd.package_id, pd.checksum, pd.media_type, pd.data").ColumnExpr("published_version_revision_content.package_id as content_package_id")
 	query.Join(`inner join
 			(with refs as(
 				select s.reference_id as package_id, s.reference_version as version, s.reference_revision as revision
@@ -3738,6 +3736,14 @@ func (p publishedRepositoryImpl) GetCSVDashboardPublishReport(publishId string)
 }
 
 func (p publishedRepositoryImpl) DeletePackageRevisionsBeforeDate(ctx context.Context, packageId string, deleteBefore time.Time, deleteLastRevision bool, deleteReleaseRevisions bool, deletedBy string) (int, int, error) {
+	return p.DeletePackageRevisions(ctx, packageId, view.RevisionsRetentionRule{
+		DeleteBefore:         deleteBefore,
+		DeleteLastRevision:   deleteLastRevision,
+		KeepReleaseRevisions: !deleteReleaseRevisions,
+	}, deletedBy)
+}
+
+func (p publishedRepositoryImpl) DeletePackageRevisions(ctx context.Context, packageId string, rule view.RevisionsRetentionRule, deletedBy string) (int, int, error) {
 	var totalDeletedCount int
 	var totalReleaseDeletedCount int
 	var processingErrors []error
@@ -3755,7 +3761,7 @@ func (p publishedRepositoryImpl) DeletePackageRevisionsBeforeDate(ctx context.Co
 
 	for idx, version := range versions {
 		logger.Tracef(ctx, "Processing version %d/%d: %s", idx+1, len(versions), version)
-		deletedCount, releaseCount, err := p.deleteVersionRevisions(ctx, packageId, version, deleteBefore, deleteLastRevision, deleteReleaseRevisions, deletedBy)
+		deletedCount, releaseCount, err := p.deleteVersionRevisions(ctx, packageId, version, rule, deletedBy)
 		if err != nil {
 			if ctx.Err() != nil {
 				return totalDeletedCount, totalReleaseDeletedCount, ctx.Err()
@@ -3784,7 +3790,7 @@ func (p publishedRepositoryImpl) DeletePackageRevisionsBeforeDate(ctx context.Co
 	return totalDeletedCount, totalReleaseDeletedCount, nil
 }
 
-func (p publishedRepositoryImpl) deleteVersionRevisions(ctx context.Context, packageId string, version string, deleteBefore time.Time, deleteLastRevision bool, deleteReleaseRevisions bool, deletedBy string) (int, int, error) {
+func (p publishedRepositoryImpl) deleteVersionRevisions(ctx context.Context, packageId string, version string, rule view.RevisionsRetentionRule, deletedBy string) (int, int, error) {
 	var deletedCount int
 	var deletedReleaseCount int
 	err := p.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
@@ -3797,23 +3803,9 @@ func (p publishedRepositoryImpl) deleteVersionRevisions(ctx context.Context, pac
 			return fmt.Errorf("failed to get revisions: %w", err)
 		}
 
-		var candidates []*entity.PublishedVersionEntity
 		lastRevisionIndex := len(revisions) - 1
-		for i, revision := range revisions {
-			if !revision.PublishedAt.Before(deleteBefore) {
-				logger.Tracef(ctx, "package %s, version %s, revision %d is not before delete threshold %s, skipping", packageId, version, revision.Revision, deleteBefore)
-				break
-			}
-			if i == lastRevisionIndex && !deleteLastRevision {
-				logger.Tracef(ctx, "package %s, version %s, last revision %d, skipping because deleteLastRevision=false", packageId, version, revision.Revision)
-				break
-			}
-			if revision.Status == string(view.Release) && !deleteReleaseRevisions {
-				logger.Tracef(ctx, "package %s, version %s, release revision %d, skipping because deleteReleaseRevisions=false", packageId, version, revision.Revision)
-				break
-			}
-			candidates = append(candidates, &revision)
-		}
+		candidates := selectRevisionsForCleanup(revisions, rule)
+		logger.Tracef(ctx, "package %s, version %s: %d of %d revision(s) match retention rule", packageId, version, len(candidates), len(revisions))
 
 		if len(candidates) > 0 {
 			for _, revision := range candidates {
@@ -3890,6 +3882,131 @@ func (p publishedRepositoryImpl) deleteVersionRevisions(ctx context.Context, pac
 	return deletedCount, deletedReleaseCount, nil
 }
 
+func selectRevisionsForCleanup(revisions []entity.PublishedVersionEntity, rule view.RevisionsRetentionRule) []*entity.PublishedVersionEntity {
+	infos := make([]view.RevisionRetentionInfo, 0, len(revisions))
+	for _, revision := range revisions {
+		infos = append(infos, view.RevisionRetentionInfo{Status: revision.Status, PublishedAt: revision.PublishedAt})
+	}
+	count := rule.CountRevisionsToDelete(infos)
+	candidates := make([]*entity.PublishedVersionEntity, 0, count)
+	for i := 0; i < count; i++ {
+		candidates = append(candidates, &revisions[i])
+	}
+	return candidates
+}
+
+func (p publishedRepositoryImpl) GetPackageRevisionsCleanupCandidates(ctx context.Context, packageId string, rule view.RevisionsRetentionRule) ([]entity.RevisionCleanupCandidateEntity, error) {
+	var versions []string
+	err := p.cp.GetConnection().ModelContext(ctx, (*entity.PublishedVersionEntity)(nil)).
+		Column("version").
+		Where("package_id = ? AND deleted_at is null", packageId).
+		Order("version ASC").
+		Distinct().
+		Select(&versions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get versions: %w", err)
+	}
+
+	var result []entity.RevisionCleanupCandidateEntity
+	for _, version := range versions {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		var revisions []entity.PublishedVersionEntity
+		err := p.cp.GetConnection().ModelContext(ctx, &revisions).
+			Where("package_id = ? AND version = ? AND deleted_at is null", packageId, version).
+			Order("revision ASC").
+			Select()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get revisions of version %s: %w", version, err)
+		}
+		lastRevisionIndex := len(revisions) - 1
+		archived := rule.ArchivedDeleteBefore != nil && lastRevisionIndex >= 0 && revisions[lastRevisionIndex].Status == string(view.Archived)
+		var candidates []entity.RevisionCleanupCandidateEntity
+		for _, revision := range selectRevisionsForCleanup(revisions, rule) {
+			// same check as in deleteVersionRevisions: a revision referenced by a not deleted revision stops the cleanup of the version
+			referenced, err := p.cp.GetConnection().ModelContext(ctx, (*entity.PublishedReferenceEntity)(nil)).
+				Join("inner join published_version pv").
+				JoinOn("pv.package_id = published_version_reference.package_id and pv.version = published_version_reference.version and pv.revision = published_version_reference.revision").
+				Where("published_version_reference.reference_id = ? and published_version_reference.reference_version = ? and published_version_reference.reference_revision = ?",
+					revision.PackageId, revision.Version, revision.Revision).
+				Where("pv.deleted_at is null").
+				Exists()
+			if err != nil {
+				return nil, fmt.Errorf("failed to check references of revision %s@%d: %w", version, revision.Revision, err)
+			}
+			if referenced {
+				break
+			}
+			reason := "published before retention threshold"
+			if archived {
+				reason = "archived version published before archived versions retention threshold"
+			}
+			candidates = append(candidates, entity.RevisionCleanupCandidateEntity{
+				PackageId: revision.PackageId,
+				Version:   revision.Version,
+				Revision:  revision.Revision,
+				Status:    revision.Status,
+				Reason:    reason,
+			})
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		if err := p.calculateRevisionsCleanupBytes(ctx, candidates); err != nil {
+			return nil, err
+		}
+		result = append(result, candidates...)
+	}
+	return result, nil
+}
+
+// calculateRevisionsCleanupBytes sets the size of documents and sources of every candidate which are not shared with revisions that stay
+func (p publishedRepositoryImpl) calculateRevisionsCleanupBytes(ctx context.Context, candidates []entity.RevisionCleanupCandidateEntity) error {
+	packageId, version := candidates[0].PackageId, candidates[0].Version
+	revisions := make([]int, 0, len(candidates))
+	for _, candidate := range candidates {
+		revisions = append(revisions, candidate.Revision)
+	}
+	type revisionBytes struct {
+		Revision int   `pg:"revision"`
+		Bytes    int64 `pg:"bytes"`
+	}
+	var sizes []revisionBytes
+	_, err := p.cp.GetConnection().QueryContext(ctx, &sizes, `
+		SELECT r.revision,
+			coalesce((SELECT sum(octet_length(pd.data))
+				FROM published_data pd
+				WHERE pd.package_id = ?0
+				AND pd.checksum IN (SELECT c.checksum FROM published_version_revision_content c
+					WHERE c.package_id = ?0 AND c.version = ?1 AND c.revision = r.revision)
+				AND NOT EXISTS (SELECT 1 FROM published_version_revision_content c2
+					INNER JOIN published_version pv2 ON pv2.package_id = c2.package_id AND pv2.version = c2.version AND pv2.revision = c2.revision
+					WHERE c2.package_id = ?0 AND c2.checksum = pd.checksum AND pv2.deleted_at IS NULL
+					AND NOT (c2.version = ?1 AND c2.revision IN (?2)))), 0)
+			+ coalesce((SELECT sum(octet_length(psa.data))
+				FROM published_sources ps
+				INNER JOIN published_sources_archives psa ON psa.checksum = ps.archive_checksum
+				WHERE ps.package_id = ?0 AND ps.version = ?1 AND ps.revision = r.revision
+				AND NOT EXISTS (SELECT 1 FROM published_sources ps2
+					INNER JOIN published_version pv2 ON pv2.package_id = ps2.package_id AND pv2.version = ps2.version AND pv2.revision = ps2.revision
+					WHERE ps2.archive_checksum = ps.archive_checksum AND pv2.deleted_at IS NULL
+					AND NOT (ps2.package_id = ?0 AND ps2.version = ?1 AND ps2.revision IN (?2)))), 0) AS bytes
+		FROM unnest(?3::int[]) AS r(revision)`,
+		packageId, version, pg.In(revisions), pg.Array(revisions))
+	if err != nil {
+		return fmt.Errorf("failed to calculate size of revisions of version %s: %w", version, err)
+	}
+	bytesByRevision := make(map[int]int64, len(sizes))
+	for _, size := range sizes {
+		bytesByRevision[size.Revision] = size.Bytes
+	}
+	for i := range candidates {
+		candidates[i].Bytes = bytesByRevision[candidates[i].Revision]
+	}
+	return nil
+}
+
 func (p publishedRepositoryImpl) trackDeletion(tx *pg.Tx, packageId string, version string, revision int, status string, eventType string, deletedBy string) error {
 	dataMap := map[string]interface{}{}
 	dataMap["version"] = version
@@ -4015,7 +4132,10 @@ func (p publishedRepositoryImpl) GetVersionComparisonsCleanupCandidates(ctx cont
 				(SELECT MAX(revision)
 					FROM published_version
 					WHERE package_id = vc.previous_package_id
-					AND version = vc.previous_version) AS previous_max_revision
+					AND version = vc.previous_version) AS previous_max_revision,
+				EXISTS(SELECT 1
+					FROM saved_comparison sc
+					WHERE sc.comparison_id = vc.comparison_id) AS saved
 			FROM version_comparison vc
 			LEFT JOIN published_version pv ON
 				pv.package_id = vc.package_id AND
@@ -4069,6 +4189,56 @@ func (p publishedRepositoryImpl) DeleteVersionComparison(ctx context.Context, co
 	return deleted, nil
 }
 
+func (p publishedRepositoryImpl) GetVersionComparisonCleanupInfo(ctx context.Context, comparisonId string) (*entity.ComparisonCleanupInfoEntity, error) {
+	result := new(entity.ComparisonCleanupInfoEntity)
+	_, err := p.cp.GetConnection().QueryOneContext(ctx, result, `
+		SELECT
+			EXISTS (SELECT 1 FROM version_comparison WHERE ?0 = ANY(refs)) AS referenced,
+			coalesce((SELECT sum(pg_column_size(vc.*)) FROM version_comparison vc WHERE vc.comparison_id = ?0), 0)
+			+ coalesce((SELECT sum(pg_column_size(oc.*)) FROM operation_comparison oc WHERE oc.comparison_id = ?0), 0) AS bytes
+	`, comparisonId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cleanup info of comparison %s: %w", comparisonId, err)
+	}
+	return result, nil
+}
+
+func (p publishedRepositoryImpl) GetSoftDeletedDataCleanupCandidates(ctx context.Context, beforeDate time.Time, packageId string, limit int) ([]entity.SoftDeletedCleanupCandidateEntity, error) {
+	packageIdLike := utils.LikeEscaped(packageId) + ".%"
+	var result []entity.SoftDeletedCleanupCandidateEntity
+	_, err := p.cp.GetConnection().QueryContext(ctx, &result, `
+		SELECT * FROM (
+			SELECT pkg.id AS package_id, '' AS version, 0 AS revision, '' AS status,
+				coalesce((SELECT sum(octet_length(pd.data)) FROM published_data pd WHERE pd.package_id = pkg.id), 0) AS bytes,
+				pkg.deleted_at
+			FROM package_group pkg
+			WHERE pkg.deleted_at < ?0
+			AND (?1 = '' OR pkg.id = ?1 OR pkg.id LIKE ?2)
+			UNION ALL
+			SELECT pv.package_id, pv.version, pv.revision, pv.status,
+				coalesce((SELECT sum(octet_length(pd.data))
+					FROM published_data pd
+					WHERE pd.package_id = pv.package_id
+					AND pd.checksum IN (SELECT c.checksum FROM published_version_revision_content c
+						WHERE c.package_id = pv.package_id AND c.version = pv.version AND c.revision = pv.revision)
+					AND NOT EXISTS (SELECT 1 FROM published_version_revision_content c2
+						INNER JOIN published_version pv2 ON pv2.package_id = c2.package_id AND pv2.version = c2.version AND pv2.revision = c2.revision
+						WHERE c2.package_id = pd.package_id AND c2.checksum = pd.checksum
+						AND (pv2.deleted_at IS NULL OR pv2.deleted_at >= ?0))), 0) AS bytes,
+				pv.deleted_at
+			FROM published_version pv
+			WHERE pv.deleted_at < ?0
+			AND (?1 = '' OR pv.package_id = ?1 OR pv.package_id LIKE ?2)
+			AND NOT EXISTS (SELECT 1 FROM package_group pkg WHERE pkg.id = pv.package_id AND pkg.deleted_at < ?0)
+		) candidates
+		ORDER BY deleted_at ASC
+		LIMIT ?3`, beforeDate, packageId, packageIdLike, limit)
+	if err != nil && err != pg.ErrNoRows {
+		return nil, fmt.Errorf("failed to get soft deleted data cleanup candidates: %w", err)
+	}
+	return result, nil
+}
+
 func (p publishedRepositoryImpl) DeleteSoftDeletedPackagesBeforeDate(ctx context.Context, runId string, beforeDate time.Time, batchSize int) (int, error) {
 	deletedItemsStats := entity.NewDeletedItemsStats()
 
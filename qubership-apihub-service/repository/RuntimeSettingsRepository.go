@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/go-pg/pg/v10"
+)
+
+type RuntimeSettingsRepository interface {
+	GetSettings(ctx context.Context) ([]entity.RuntimeSettingEntity, error)
+	// SaveSetting stores the setting together with the record of its change
+	SaveSetting(ctx context.Context, ent *entity.RuntimeSettingEntity, change *entity.RuntimeSettingHistoryEntity) error
+	// DeleteSetting deletes the setting together with storing the record of its change, returns false if the setting is not stored
+	DeleteSetting(ctx context.Context, key string, change *entity.RuntimeSettingHistoryEntity) (bool, error)
+	GetHistory(ctx context.Context, key string, limit int, page int) ([]entity.RuntimeSettingHistoryEntity, error)
+
+	GetNotifications(ctx context.Context) ([]entity.SystemNotificationEntity, error)
+	// GetNotificationsEndingAfter returns the notifications which are not finished by the time
+	GetNotificationsEndingAfter(ctx context.Context, t time.Time) ([]entity.SystemNotificationEntity, error)
+	GetNotification(ctx context.Context, id string) (*entity.SystemNotificationEntity, error)
+	CreateNotification(ctx context.Context, ent *entity.SystemNotificationEntity) error
+	UpdateNotification(ctx context.Context, ent *entity.SystemNotificationEntity) (bool, error)
+	DeleteNotification(ctx context.Context, id string) (bool, error)
+}
+
+func NewRuntimeSettingsRepository(cp db.ConnectionProvider) RuntimeSettingsRepository {
+	return &runtimeSettingsRepositoryImpl{cp: cp}
+}
+
+type runtimeSettingsRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (r runtimeSettingsRepositoryImpl) GetSettings(ctx context.Context) ([]entity.RuntimeSettingEntity, error) {
+	var result []entity.RuntimeSettingEntity
+	err := r.cp.GetConnection().ModelContext(ctx, &result).
+		Order("key").
+		Select()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r runtimeSettingsRepositoryImpl) SaveSetting(ctx context.Context, ent *entity.RuntimeSettingEntity, change *entity.RuntimeSettingHistoryEntity) error {
+	return r.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.ModelContext(ctx, ent).
+			OnConflict("(key) DO UPDATE").
+			Set("value = EXCLUDED.value").
+			Set("updated_by = EXCLUDED.updated_by").
+			Set("updated_at = EXCLUDED.updated_at").
+			Insert()
+		if err != nil {
+			return err
+		}
+		_, err = tx.ModelContext(ctx, change).Insert()
+		return err
+	})
+}
+
+func (r runtimeSettingsRepositoryImpl) DeleteSetting(ctx context.Context, key string, change *entity.RuntimeSettingHistoryEntity) (bool, error) {
+	deleted := false
+	err := r.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		result, err := tx.ModelContext(ctx, (*entity.RuntimeSettingEntity)(nil)).
+			Where("key = ?", key).
+			Delete()
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return nil
+		}
+		deleted = true
+		_, err = tx.ModelContext(ctx, change).Insert()
+		return err
+	})
+	return deleted, err
+}
+
+func (r runtimeSettingsRepositoryImpl) GetHistory(ctx context.Context, key string, limit int, page int) ([]entity.RuntimeSettingHistoryEntity, error) {
+	var result []entity.RuntimeSettingHistoryEntity
+	query := r.cp.GetConnection().ModelContext(ctx, &result)
+	if key != "" {
+		query.Where("key = ?", key)
+	}
+	err := query.Order("changed_at DESC").
+		Limit(limit).
+		Offset(limit * page).
+		Select()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r runtimeSettingsRepositoryImpl) GetNotifications(ctx context.Context) ([]entity.SystemNotificationEntity, error) {
+	var result []entity.SystemNotificationEntity
+	err := r.cp.GetConnection().ModelContext(ctx, &result).
+		Order("start_at DESC").
+		Select()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r runtimeSettingsRepositoryImpl) GetNotificationsEndingAfter(ctx context.Context, t time.Time) ([]entity.SystemNotificationEntity, error) {
+	var result []entity.SystemNotificationEntity
+	err := r.cp.GetConnection().ModelContext(ctx, &result).
+		Where("end_at is null or end_at > ?", t).
+		Order("start_at DESC").
+		Select()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r runtimeSettingsRepositoryImpl) GetNotification(ctx context.Context, id string) (*entity.SystemNotificationEntity, error) {
+	result := new(entity.SystemNotificationEntity)
+	err := r.cp.GetConnection().ModelContext(ctx, result).
+		Where("id = ?", id).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r runtimeSettingsRepositoryImpl) CreateNotification(ctx context.Context, ent *entity.SystemNotificationEntity) error {
+	_, err := r.cp.GetConnection().ModelContext(ctx, ent).Insert()
+	return err
+}
+
+func (r runtimeSettingsRepositoryImpl) UpdateNotification(ctx context.Context, ent *entity.SystemNotificationEntity) (bool, error) {
+	result, err := r.cp.GetConnection().ModelContext(ctx, ent).
+		Column("message", "severity", "start_at", "end_at", "updated_by", "updated_at").
+		WherePK().
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+func (r runtimeSettingsRepositoryImpl) DeleteNotification(ctx context.Context, id string) (bool, error) {
+	result, err := r.cp.GetConnection().ModelContext(ctx, (*entity.SystemNotificationEntity)(nil)).
+		Where("id = ?", id).
+		Delete()
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/go-pg/pg/v10"
+)
+
+type SavedComparisonRepository interface {
+	CreateSavedComparison(ctx context.Context, ent *entity.SavedComparisonEntity) error
+	GetSavedComparison(ctx context.Context, id string) (*entity.SavedComparisonEntity, error)
+	// GetSavedComparisons returns comparisons with any side in the package if packageId is set, otherwise the comparisons created by the user
+	GetSavedComparisons(ctx context.Context, createdBy string, packageId string, limit int, page int) ([]entity.SavedComparisonEntity, error)
+	// GetSavedComparisonsByVersion returns comparisons with any side in the version of the package
+	GetSavedComparisonsByVersion(ctx context.Context, packageId string, version string) ([]entity.SavedComparisonEntity, error)
+	UpdateSavedComparisonName(ctx context.Context, id string, name string) error
+	// StartSavedComparisonCalculation moves the comparison to new revisions, returns false if it has been already moved to them
+	StartSavedComparisonCalculation(ctx context.Context, ent *entity.SavedComparisonEntity) (bool, error)
+	// UpdateSavedComparisonStatus updates the calculation status unless the comparison was moved to other revisions meanwhile
+	UpdateSavedComparisonStatus(ctx context.Context, ent *entity.SavedComparisonEntity) error
+	DeleteSavedComparison(ctx context.Context, id string) (bool, error)
+}
+
+func NewSavedComparisonRepositoryPG(cp db.ConnectionProvider) SavedComparisonRepository {
+	return &savedComparisonRepositoryImpl{cp: cp}
+}
+
+type savedComparisonRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (s *savedComparisonRepositoryImpl) CreateSavedComparison(ctx context.Context, ent *entity.SavedComparisonEntity) error {
+	_, err := s.cp.GetConnection().ModelContext(ctx, ent).Insert()
+	return err
+}
+
+func (s *savedComparisonRepositoryImpl) GetSavedComparison(ctx context.Context, id string) (*entity.SavedComparisonEntity, error) {
+	result := new(entity.SavedComparisonEntity)
+	err := s.cp.GetConnection().ModelContext(ctx, result).
+		Where("id = ?", id).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *savedComparisonRepositoryImpl) GetSavedComparisons(ctx context.Context, createdBy string, packageId string, limit int, page int) ([]entity.SavedComparisonEntity, error) {
+	var result []entity.SavedComparisonEntity
+	query := s.cp.GetConnection().ModelContext(ctx, &result)
+	if packageId != "" {
+		query.Where("package_id = ? OR previous_package_id = ?", packageId, packageId)
+	} else {
+		query.Where("created_by = ?", createdBy)
+	}
+	query.Order("created_at DESC", "id")
+	if limit > 0 {
+		query.Limit(limit).Offset(limit * page)
+	}
+	err := query.Select()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *savedComparisonRepositoryImpl) GetSavedComparisonsByVersion(ctx context.Context, packageId string, version string) ([]entity.SavedComparisonEntity, error) {
+	var result []entity.SavedComparisonEntity
+	err := s.cp.GetConnection().ModelContext(ctx, &result).
+		WhereOrGroup(func(q *pg.Query) (*pg.Query, error) {
+			return q.Where("package_id = ?", packageId).Where("version = ?", version), nil
+		}).
+		WhereOrGroup(func(q *pg.Query) (*pg.Query, error) {
+			return q.Where("previous_package_id = ?", packageId).Where("previous_version = ?", version), nil
+		}).
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *savedComparisonRepositoryImpl) UpdateSavedComparisonName(ctx context.Context, id string, name string) error {
+	_, err := s.cp.GetConnection().ModelContext(ctx, (*entity.SavedComparisonEntity)(nil)).
+		Set("name = ?", name).
+		Where("id = ?", id).
+		Update()
+	return err
+}
+
+func (s *savedComparisonRepositoryImpl) StartSavedComparisonCalculation(ctx context.Context, ent *entity.SavedComparisonEntity) (bool, error) {
+	res, err := s.cp.GetConnection().ModelContext(ctx, ent).
+		Column("revision", "previous_revision", "comparison_id", "status", "details", "build_id", "calculated_at").
+		Where("id = ?id").
+		Where("comparison_id != ?comparison_id").
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+func (s *savedComparisonRepositoryImpl) UpdateSavedComparisonStatus(ctx context.Context, ent *entity.SavedComparisonEntity) error {
+	_, err := s.cp.GetConnection().ModelContext(ctx, ent).
+		Column("status", "details", "build_id", "calculated_at").
+		Where("id = ?id").
+		Where("comparison_id = ?comparison_id").
+		Update()
+	return err
+}
+
+func (s *savedComparisonRepositoryImpl) DeleteSavedComparison(ctx context.Context, id string) (bool, error) {
+	res, err := s.cp.GetConnection().ModelContext(ctx, (*entity.SavedComparisonEntity)(nil)).
+		Where("id = ?", id).
+		Delete()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
@@ -16,6 +18,8 @@ type SystemStatsRepository interface {
 	GetVersionComparisonsCount(ctx context.Context) (int, error)
 	GetBuildsCountByType(ctx context.Context) ([]entity.BuildsCountEntity, error)
 	GetDatabaseSizePerTable(ctx context.Context) ([]entity.TableSizeEntity, error)
+	GetContentStorageUsage(ctx context.Context) ([]entity.ContentStorageUsageEntity, error)
+	GetPackageStorageUsage(ctx context.Context, groupByWorkspace bool, limit int, page int) ([]entity.PackageStorageUsageEntity, error)
 }
 
 func NewSystemStatsRepository(cp db.ConnectionProvider) SystemStatsRepository {
@@ -101,7 +105,8 @@ func (s *systemStatsRepositoryImpl) GetBuildsCountByType(ctx context.Context) ([
     		('exportRestDocument'),
     		('exportRestOperationsGroup'),
     		('exportGraphqlOperationsGroup'),
-			('exportAsyncapiOperationsGroup')
+			('exportAsyncapiOperationsGroup'),
+			('exportProtobufOperationsGroup')
 		),
 		build_stats AS (
   		SELECT
@@ -191,3 +196,89 @@ func (s *systemStatsRepositoryImpl) GetDatabaseSizePerTable(ctx context.Context)
 
 	return result, nil
 }
+
+func (s *systemStatsRepositoryImpl) GetContentStorageUsage(ctx context.Context) ([]entity.ContentStorageUsageEntity, error) {
+	var result []entity.ContentStorageUsageEntity
+
+	tableQueries := make([]string, 0, len(refCountedContents))
+	for _, content := range refCountedContents {
+		tableQueries = append(tableQueries, fmt.Sprintf(`
+		SELECT
+			'%[1]s' AS table_name,
+			COUNT(*) AS entries,
+			COALESCE(SUM(octet_length(%[2]s)), 0) AS stored_size,
+			COALESCE(SUM(octet_length(%[2]s)::bigint * GREATEST(ref_count, 0)), 0) AS referenced_size,
+			COUNT(*) FILTER (WHERE ref_count <= 0) AS unreferenced_entries,
+			(SELECT COUNT(*) FROM content_ref_delta WHERE content_table = '%[1]s') AS pending_reference_changes
+		FROM %[1]s`, content.table, content.dataColumn))
+	}
+	query := strings.Join(tableQueries, "\n\t\tUNION ALL") + "\n\t\tORDER BY stored_size DESC"
+
+	_, err := s.cp.GetConnection().QueryContext(ctx, &result, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetPackageStorageUsage calculates the size of the content referenced by the packages.
+// The content shared by several packages is counted for each of them.
+func (s *systemStatsRepositoryImpl) GetPackageStorageUsage(ctx context.Context, groupByWorkspace bool, limit int, page int) ([]entity.PackageStorageUsageEntity, error) {
+	var result []entity.PackageStorageUsageEntity
+
+	idExpr := "package_id"
+	if groupByWorkspace {
+		idExpr = "split_part(package_id, '.', 1)"
+	}
+	query := fmt.Sprintf(`
+		WITH documents AS (
+			SELECT package_id, COUNT(*) AS documents, SUM(octet_length(data)) AS documents_size
+			FROM published_data
+			GROUP BY package_id
+		),
+		sources AS (
+			SELECT ps.package_id, SUM(octet_length(psa.data)) AS sources_size
+			FROM (SELECT DISTINCT package_id, archive_checksum FROM published_sources) ps
+			JOIN published_sources_archives psa ON psa.checksum = ps.archive_checksum
+			GROUP BY ps.package_id
+		),
+		operations AS (
+			SELECT o.package_id, COUNT(*) AS operation_data_entries, SUM(octet_length(od.data)) AS operation_data_size
+			FROM (SELECT DISTINCT package_id, data_hash FROM operation) o
+			JOIN operation_data od ON od.data_hash = o.data_hash
+			GROUP BY o.package_id
+		),
+		usage AS (
+			SELECT
+				COALESCE(d.package_id, s.package_id, o.package_id) AS package_id,
+				COALESCE(d.documents, 0) AS documents,
+				COALESCE(d.documents_size, 0) AS documents_size,
+				COALESCE(s.sources_size, 0) AS sources_size,
+				COALESCE(o.operation_data_entries, 0) AS operation_data_entries,
+				COALESCE(o.operation_data_size, 0) AS operation_data_size
+			FROM documents d
+			FULL JOIN sources s ON s.package_id = d.package_id
+			FULL JOIN operations o ON o.package_id = COALESCE(d.package_id, s.package_id)
+		)
+		SELECT
+			%s AS id,
+			SUM(documents) AS documents,
+			SUM(documents_size) AS documents_size,
+			SUM(sources_size) AS sources_size,
+			SUM(operation_data_entries) AS operation_data_entries,
+			SUM(operation_data_size) AS operation_data_size,
+			SUM(documents_size + sources_size + operation_data_size) AS total_size
+		FROM usage
+		GROUP BY 1
+		ORDER BY total_size DESC, id
+		LIMIT ?
+		OFFSET ?`, idExpr)
+
+	_, err := s.cp.GetConnection().QueryContext(ctx, &result, query, limit, limit*page)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
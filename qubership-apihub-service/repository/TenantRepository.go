@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/go-pg/pg/v10"
+)
+
+type TenantRepository interface {
+	GetTenant(ctx context.Context, workspaceId string) (*entity.WorkspaceTenantEntity, error)
+	GetTenants(ctx context.Context) ([]entity.WorkspaceTenantEntity, error)
+	SaveQuotas(ctx context.Context, ent *entity.WorkspaceTenantEntity) error
+	SaveSettings(ctx context.Context, ent *entity.WorkspaceTenantEntity) error
+
+	GetAdmins(ctx context.Context, workspaceId string) ([]entity.UserEntity, error)
+	AddAdmins(ctx context.Context, ents []entity.WorkspaceTenantAdminEntity) error
+	DeleteAdmin(ctx context.Context, workspaceId string, userId string) (bool, error)
+	IsAdmin(workspaceId string, userId string) (bool, error)
+	IsAdminOfAnyWorkspace(userId string) (bool, error)
+
+	// CountPackages returns the number of packages and dashboards of the workspace
+	CountPackages(ctx context.Context, workspaceId string) (int64, error)
+	// CountVersions returns the number of published versions of the workspace, revisions of the same version are not counted
+	CountVersions(ctx context.Context, workspaceId string) (int64, error)
+	CountBuildsSince(ctx context.Context, workspaceId string, since time.Time) (int64, error)
+	SumAiTokensSince(ctx context.Context, workspaceId string, since time.Time) (int64, error)
+	GetStorageUsage(ctx context.Context, workspaceId string) (*entity.WorkspaceStorageUsageEntity, error)
+	// ReplaceStorageUsage replaces the stored storage usage of all workspaces
+	ReplaceStorageUsage(ctx context.Context, ents []entity.WorkspaceStorageUsageEntity) error
+}
+
+func NewTenantRepository(cp db.ConnectionProvider) TenantRepository {
+	return &tenantRepositoryImpl{cp: cp}
+}
+
+type tenantRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+// workspaceFilter matches the workspace itself and all its child packages
+const workspaceFilter = "(package_id = ? or package_id like ? || '.%')"
+
+func (t tenantRepositoryImpl) GetTenant(ctx context.Context, workspaceId string) (*entity.WorkspaceTenantEntity, error) {
+	result := new(entity.WorkspaceTenantEntity)
+	err := t.cp.GetConnection().ModelContext(ctx, result).
+		Where("workspace_id = ?", workspaceId).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (t tenantRepositoryImpl) GetTenants(ctx context.Context) ([]entity.WorkspaceTenantEntity, error) {
+	var result []entity.WorkspaceTenantEntity
+	err := t.cp.GetConnection().ModelContext(ctx, &result).
+		Order("workspace_id").
+		Select()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (t tenantRepositoryImpl) SaveQuotas(ctx context.Context, ent *entity.WorkspaceTenantEntity) error {
+	_, err := t.cp.GetConnection().ModelContext(ctx, ent).
+		OnConflict("(workspace_id) DO UPDATE").
+		Set("storage_limit_mb = EXCLUDED.storage_limit_mb").
+		Set("max_packages = EXCLUDED.max_packages").
+		Set("max_versions = EXCLUDED.max_versions").
+		Set("builds_per_hour = EXCLUDED.builds_per_hour").
+		Set("ai_tokens_per_month = EXCLUDED.ai_tokens_per_month").
+		Set("updated_by = EXCLUDED.updated_by").
+		Set("updated_at = EXCLUDED.updated_at").
+		Insert()
+	return err
+}
+
+func (t tenantRepositoryImpl) SaveSettings(ctx context.Context, ent *entity.WorkspaceTenantEntity) error {
+	_, err := t.cp.GetConnection().ModelContext(ctx, ent).
+		OnConflict("(workspace_id) DO UPDATE").
+		Set("release_version_pattern = EXCLUDED.release_version_pattern").
+		Set("default_role = EXCLUDED.default_role").
+		Set("validation_rules_severity = EXCLUDED.validation_rules_severity").
+		Set("updated_by = EXCLUDED.updated_by").
+		Set("updated_at = EXCLUDED.updated_at").
+		Insert()
+	return err
+}
+
+func (t tenantRepositoryImpl) GetAdmins(ctx context.Context, workspaceId string) ([]entity.UserEntity, error) {
+	var result []entity.UserEntity
+	err := t.cp.GetConnection().ModelContext(ctx, &result).
+		ColumnExpr("user_data.*").
+		Join("inner join workspace_tenant_admin wta").
+		JoinOn("wta.user_id = user_data.user_id").
+		JoinOn("wta.workspace_id = ?", workspaceId).
+		Order("user_data.name").
+		Select()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (t tenantRepositoryImpl) AddAdmins(ctx context.Context, ents []entity.WorkspaceTenantAdminEntity) error {
+	if len(ents) == 0 {
+		return nil
+	}
+	_, err := t.cp.GetConnection().ModelContext(ctx, &ents).
+		OnConflict("(workspace_id, user_id) DO NOTHING").
+		Insert()
+	return err
+}
+
+func (t tenantRepositoryImpl) DeleteAdmin(ctx context.Context, workspaceId string, userId string) (bool, error) {
+	result, err := t.cp.GetConnection().ModelContext(ctx, (*entity.WorkspaceTenantAdminEntity)(nil)).
+		Where("workspace_id = ?", workspaceId).
+		Where("user_id = ?", userId).
+		Delete()
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+func (t tenantRepositoryImpl) IsAdmin(workspaceId string, userId string) (bool, error) {
+	return t.cp.GetConnection().Model((*entity.WorkspaceTenantAdminEntity)(nil)).
+		Where("workspace_id = ?", workspaceId).
+		Where("user_id = ?", userId).
+		Exists()
+}
+
+func (t tenantRepositoryImpl) IsAdminOfAnyWorkspace(userId string) (bool, error) {
+	return t.cp.GetConnection().Model((*entity.WorkspaceTenantAdminEntity)(nil)).
+		Where("user_id = ?", userId).
+		Exists()
+}
+
+func (t tenantRepositoryImpl) CountPackages(ctx context.Context, workspaceId string) (int64, error) {
+	var count int64
+	_, err := t.cp.GetConnection().QueryOneContext(ctx, pg.Scan(&count), `
+		select count(*) from package_group
+		where id like ? || '.%'
+		and kind in (?, ?)
+		and deleted_at is null`,
+		workspaceId, entity.KIND_PACKAGE, entity.KIND_DASHBOARD)
+	return count, err
+}
+
+func (t tenantRepositoryImpl) CountVersions(ctx context.Context, workspaceId string) (int64, error) {
+	var count int64
+	_, err := t.cp.GetConnection().QueryOneContext(ctx, pg.Scan(&count), `
+		select count(distinct (package_id, version)) from published_version
+		where `+workspaceFilter+`
+		and deleted_at is null`,
+		workspaceId, workspaceId)
+	return count, err
+}
+
+func (t tenantRepositoryImpl) CountBuildsSince(ctx context.Context, workspaceId string, since time.Time) (int64, error) {
+	var count int64
+	_, err := t.cp.GetConnection().QueryOneContext(ctx, pg.Scan(&count), `
+		select count(*) from build
+		where created_at >= ?
+		and `+workspaceFilter,
+		since, workspaceId, workspaceId)
+	return count, err
+}
+
+func (t tenantRepositoryImpl) SumAiTokensSince(ctx context.Context, workspaceId string, since time.Time) (int64, error) {
+	var sum int64
+	_, err := t.cp.GetConnection().QueryOneContext(ctx, pg.Scan(&sum), `
+		select coalesce(sum(tokens_used), 0) from ai_spec_review
+		where started_at >= ?
+		and `+workspaceFilter,
+		since, workspaceId, workspaceId)
+	return sum, err
+}
+
+func (t tenantRepositoryImpl) GetStorageUsage(ctx context.Context, workspaceId string) (*entity.WorkspaceStorageUsageEntity, error) {
+	result := new(entity.WorkspaceStorageUsageEntity)
+	err := t.cp.GetConnection().ModelContext(ctx, result).
+		Where("workspace_id = ?", workspaceId).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (t tenantRepositoryImpl) ReplaceStorageUsage(ctx context.Context, ents []entity.WorkspaceStorageUsageEntity) error {
+	return t.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.ExecContext(ctx, `delete from workspace_storage_usage`)
+		if err != nil {
+			return err
+		}
+		if len(ents) == 0 {
+			return nil
+		}
+		_, err = tx.ModelContext(ctx, &ents).Insert()
+		return err
+	})
+}
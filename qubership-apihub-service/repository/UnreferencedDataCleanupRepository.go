@@ -16,6 +16,8 @@ type UnreferencedDataCleanupRepository interface {
 	StoreCleanupRun(ctx context.Context, entity entity.UnreferencedDataCleanupEntity) error
 	UpdateCleanupRun(ctx context.Context, runId string, status string, details string, finishedAt *time.Time) error
 
+	// ApplyReferenceCountChanges applies reference changes collected by the triggers to ref_count of the content tables and returns the number of applied changes
+	ApplyReferenceCountChanges(ctx context.Context, batchSize int) (int, error)
 	DeleteUnreferencedOperationData(ctx context.Context, runId string, batchSize int) (int, error)
 	DeleteUnreferencedOperationGroupTemplates(ctx context.Context, runId string, batchSize int) (int, error)
 	DeleteUnreferencedSrcArchives(ctx context.Context, runId string, batchSize int) (int, error)
@@ -23,6 +25,9 @@ type UnreferencedDataCleanupRepository interface {
 	DeleteUnreferencedVersionInternalDocumentData(ctx context.Context, runId string, batchSize int) (int, error)
 	DeleteUnreferencedComparisonInternalDocumentData(ctx context.Context, runId string, batchSize int) (int, error)
 	VacuumAffectedTables(ctx context.Context, runId string) error
+	// GetUnreferencedContentCandidates returns the first entries of the content table which would be deleted by the cleanup.
+	// Reference count changes which are not applied yet are taken into account without applying them.
+	GetUnreferencedContentCandidates(ctx context.Context, contentTable string, limit int) ([]entity.UnreferencedContentCandidateEntity, error)
 }
 
 func NewUnreferencedDataCleanupRepository(cp db.ConnectionProvider) UnreferencedDataCleanupRepository {
@@ -57,347 +62,328 @@ func (u unreferencedDataCleanupRepositoryImpl) UpdateCleanupRun(ctx context.Cont
 	return err
 }
 
-func (u unreferencedDataCleanupRepositoryImpl) DeleteUnreferencedOperationData(ctx context.Context, runId string, batchSize int) (int, error) {
-	var deletedItems entity.DeletedItemsCounts
-
-	err := u.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
-		getUnreferencedDataHashQuery := `
-			SELECT od.data_hash
-			FROM operation_data od
-			WHERE NOT EXISTS (
-    			SELECT 1 FROM operation o WHERE o.data_hash = od.data_hash
-			)
-			ORDER BY od.data_hash
-			LIMIT ?`
-
-		var dataHash []string
-		_, err := tx.QueryContext(ctx, &dataHash, getUnreferencedDataHashQuery, batchSize)
-		if err != nil {
-			return fmt.Errorf("failed to get unreferenced data hash: %w", err)
-		}
+// refCountedContent is a content-addressed table which ref_count is maintained by the triggers of the referencing table.
+// The triggers append reference changes to content_ref_delta, they are applied to ref_count by ApplyReferenceCountChanges.
+type refCountedContent struct {
+	table      string
+	keyColumn  string
+	dataColumn string
+	// set for the content which is addressed within the package
+	packageColumn string
+	refTable      string
+	refKeyColumn  string
+}
 
-		if len(dataHash) == 0 {
-			return nil
-		}
-		logger.Debugf(ctx, "Found %d operation data entities to delete in current batch", len(dataHash))
+var (
+	operationDataContent = refCountedContent{
+		table: "operation_data", keyColumn: "data_hash", dataColumn: "data",
+		refTable: "operation", refKeyColumn: "data_hash",
+	}
+	operationGroupTemplateContent = refCountedContent{
+		table: "operation_group_template", keyColumn: "checksum", dataColumn: "template",
+		refTable: "operation_group", refKeyColumn: "template_checksum",
+	}
+	srcArchivesContent = refCountedContent{
+		table: "published_sources_archives", keyColumn: "checksum", dataColumn: "data",
+		refTable: "published_sources", refKeyColumn: "archive_checksum",
+	}
+	publishedDataContent = refCountedContent{
+		table: "published_data", keyColumn: "checksum", dataColumn: "data", packageColumn: "package_id",
+		refTable: "published_version_revision_content", refKeyColumn: "checksum",
+	}
+	versionInternalDocumentDataContent = refCountedContent{
+		table: "version_internal_document_data", keyColumn: "hash", dataColumn: "data",
+		refTable: "version_internal_document", refKeyColumn: "hash",
+	}
+	comparisonInternalDocumentDataContent = refCountedContent{
+		table: "comparison_internal_document_data", keyColumn: "hash", dataColumn: "data",
+		refTable: "comparison_internal_document", refKeyColumn: "hash",
+	}
+)
 
-		logger.Tracef(ctx, "Deleting related data for operation data with hash: %v", dataHash)
+// UnreferencedContentTables are the content tables processed by the unreferenced data cleanup in the order of processing
+var UnreferencedContentTables = []string{
+	operationDataContent.table,
+	operationGroupTemplateContent.table,
+	srcArchivesContent.table,
+	publishedDataContent.table,
+	versionInternalDocumentDataContent.table,
+	comparisonInternalDocumentDataContent.table,
+}
 
-		logger.Debug(ctx, "Deleting related data from ts_operation_data")
-		deleteOpDataQuery := `DELETE FROM ts_operation_data WHERE data_hash IN (?)`
-		opResult, err := tx.ExecContext(ctx, deleteOpDataQuery, pg.In(dataHash))
-		if err != nil {
-			return fmt.Errorf("failed to delete records from ts_operation_data: %w", err)
-		}
-		deletedItems.TSOperationData = opResult.RowsAffected()
+var refCountedContents = []refCountedContent{
+	operationDataContent,
+	operationGroupTemplateContent,
+	srcArchivesContent,
+	publishedDataContent,
+	versionInternalDocumentDataContent,
+	comparisonInternalDocumentDataContent,
+}
 
-		logger.Debug(ctx, "Deleting related data from fts_operation_data")
-		deleteFTSDataQuery := `DELETE FROM fts_operation_data WHERE data_hash IN (?)`
-		ftsResult, err := tx.ExecContext(ctx, deleteFTSDataQuery, pg.In(dataHash))
-		if err != nil {
-			return fmt.Errorf("failed to delete records from fts_operation_data: %w", err)
-		}
-		deletedItems.FTSOperationData = ftsResult.RowsAffected()
+// packageExpr returns the package column of the content or an empty string for the content which is addressed globally
+func (c refCountedContent) packageExpr(alias string) string {
+	if c.packageColumn == "" {
+		return "''"
+	}
+	return alias + "." + c.packageColumn
+}
 
-		logger.Tracef(ctx, "Deleting operation data with hash: %v", dataHash)
+// keyCondition joins the content table with alias c to a relation with package_id and content_key columns
+func (c refCountedContent) keyCondition(alias string) string {
+	condition := fmt.Sprintf("c.%s = %s.content_key", c.keyColumn, alias)
+	if c.packageColumn != "" {
+		condition += fmt.Sprintf(" AND c.%s = %s.package_id", c.packageColumn, alias)
+	}
+	return condition
+}
 
-		logger.Debug(ctx, "Deleting unreferenced data from operation_data")
-		deleteOperationDataQuery := `DELETE FROM operation_data WHERE data_hash IN (?)`
-		_, err = tx.ExecContext(ctx, deleteOperationDataQuery, pg.In(dataHash))
-		if err != nil {
-			return fmt.Errorf("failed to delete operation data: %w", err)
-		}
-		deletedItems.OperationData = len(dataHash)
+type contentRefs struct {
+	PackageId  string `pg:"package_id"`
+	ContentKey string `pg:"content_key"`
+	Refs       int    `pg:"refs"`
+}
 
-		var cleanupRun entity.UnreferencedDataCleanupEntity
-		err = tx.Model(&cleanupRun).
-			Where("run_id = ?", runId).
-			Select()
-		if err != nil {
-			return fmt.Errorf("failed to get current state of cleanup run: %w", err)
-		}
-		if cleanupRun.DeletedItems == nil {
-			cleanupRun.DeletedItems = &deletedItems
-		} else {
-			cleanupRun.DeletedItems.OperationData += deletedItems.OperationData
-			cleanupRun.DeletedItems.TSOperationData += deletedItems.TSOperationData
-			cleanupRun.DeletedItems.FTSOperationData += deletedItems.FTSOperationData
-		}
-		_, err = tx.Model(&cleanupRun).
-			Column("deleted_items").
-			WherePK().
-			Update()
-		if err != nil {
-			return fmt.Errorf("failed to update cleanup run state: %w", err)
+func (u unreferencedDataCleanupRepositoryImpl) ApplyReferenceCountChanges(ctx context.Context, batchSize int) (int, error) {
+	total := 0
+	for _, content := range refCountedContents {
+		for {
+			var applied int
+			_, err := u.cp.GetConnection().QueryOneContext(ctx, pg.Scan(&applied), fmt.Sprintf(`
+				WITH applied AS (
+					DELETE FROM content_ref_delta
+					WHERE id IN (SELECT id FROM content_ref_delta WHERE content_table = ?0 ORDER BY id LIMIT ?1)
+					RETURNING package_id, content_key, delta
+				), changes AS (
+					SELECT package_id, content_key, sum(delta) AS delta FROM applied GROUP BY package_id, content_key
+				), updated AS (
+					UPDATE %[1]s c SET ref_count = c.ref_count + changes.delta
+					FROM changes
+					WHERE %[2]s
+					RETURNING 1
+				)
+				SELECT count(*) FROM applied`, content.table, content.keyCondition("changes")),
+				content.table, batchSize)
+			if err != nil {
+				return total, fmt.Errorf("failed to apply reference count changes of %s: %w", content.table, err)
+			}
+			total += applied
+			if applied < batchSize {
+				break
+			}
 		}
-
-		return nil
-	})
-
-	return deletedItems.OperationData +
-		deletedItems.TSOperationData +
-		deletedItems.FTSOperationData, err
+	}
+	return total, nil
 }
 
-func (u unreferencedDataCleanupRepositoryImpl) DeleteUnreferencedOperationGroupTemplates(ctx context.Context, runId string, batchSize int) (int, error) {
-	var deletedTemplates int
-
-	err := u.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
-		deleteUnreferencedOperationGroupTemplatesQuery := `
-			DELETE FROM operation_group_template ogt
-			USING (
-  				SELECT checksum
-  				FROM operation_group_template ogt2
-  				WHERE NOT EXISTS (
-    				SELECT 1 FROM operation_group og WHERE og.template_checksum = ogt2.checksum
-  				)
-  				ORDER BY ogt2.checksum
-  				LIMIT ?
-			) del
-			WHERE ogt.checksum = del.checksum;`
-
-		res, err := tx.ExecContext(ctx, deleteUnreferencedOperationGroupTemplatesQuery, batchSize)
-		if err != nil {
-			return fmt.Errorf("failed to delete unreferenced operation group templates: %w", err)
-		}
+// deleteUnreferencedContent deletes a batch of the content with no references. The references of every candidate are counted
+// before deletion, so the content is never deleted because of an outdated ref_count. The outdated counters are fixed instead.
+func (u unreferencedDataCleanupRepositoryImpl) deleteUnreferencedContent(ctx context.Context, runId string, content refCountedContent, batchSize int,
+	beforeDelete func(tx *pg.Tx, unreferenced []contentRefs, deletedItems *entity.DeletedItemsCounts) error,
+	countDeleted func(deletedItems *entity.DeletedItemsCounts, deleted int)) (int, error) {
+	for {
+		var deleted, fixed int
+		var deletedItems entity.DeletedItemsCounts
+		err := u.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+			refCondition := fmt.Sprintf("r.%s = c.%s", content.refKeyColumn, content.keyColumn)
+			if content.packageColumn != "" {
+				refCondition += fmt.Sprintf(" AND r.%[1]s = c.%[1]s", content.packageColumn)
+			}
+			var candidates []contentRefs
+			_, err := tx.QueryContext(ctx, &candidates, fmt.Sprintf(`
+				SELECT %[1]s AS package_id, c.%[2]s AS content_key,
+					(SELECT count(*) FROM %[3]s r WHERE %[4]s) AS refs
+				FROM %[5]s c
+				WHERE c.ref_count <= 0
+				ORDER BY 1, 2
+				LIMIT ?
+				FOR UPDATE OF c SKIP LOCKED`,
+				content.packageExpr("c"), content.keyColumn, content.refTable, refCondition, content.table), batchSize)
+			if err != nil {
+				return fmt.Errorf("failed to get unreferenced %s: %w", content.table, err)
+			}
+			if len(candidates) == 0 {
+				return nil
+			}
 
-		logger.Debugf(ctx, "Deleted %d operation group templates in current batch", res.RowsAffected())
-		if res.RowsAffected() == 0 {
-			return nil
+			var referenced, unreferenced []contentRefs
+			for _, candidate := range candidates {
+				if candidate.Refs > 0 {
+					referenced = append(referenced, candidate)
+				} else {
+					unreferenced = append(unreferenced, candidate)
+				}
+			}
+			if len(referenced) > 0 {
+				packageIds, keys, refs := splitContentRefs(referenced)
+				res, err := tx.ExecContext(ctx, fmt.Sprintf(`
+					UPDATE %s c SET ref_count = k.refs
+					FROM unnest(?::varchar[], ?::varchar[], ?::integer[]) AS k(package_id, content_key, refs)
+					WHERE %s`, content.table, content.keyCondition("k")),
+					pg.Array(packageIds), pg.Array(keys), pg.Array(refs))
+				if err != nil {
+					return fmt.Errorf("failed to fix reference counts of %s: %w", content.table, err)
+				}
+				fixed = res.RowsAffected()
+				logger.Debugf(ctx, "Fixed reference counts of %d %s entries in current batch", fixed, content.table)
+			}
+			if len(unreferenced) > 0 {
+				logger.Tracef(ctx, "Deleting unreferenced %s: %v", content.table, unreferenced)
+				if beforeDelete != nil {
+					if err = beforeDelete(tx, unreferenced, &deletedItems); err != nil {
+						return err
+					}
+				}
+				packageIds, keys, _ := splitContentRefs(unreferenced)
+				res, err := tx.ExecContext(ctx, fmt.Sprintf(`
+					DELETE FROM %s c
+					USING unnest(?::varchar[], ?::varchar[]) AS k(package_id, content_key)
+					WHERE %s AND c.ref_count <= 0`, content.table, content.keyCondition("k")),
+					pg.Array(packageIds), pg.Array(keys))
+				if err != nil {
+					return fmt.Errorf("failed to delete unreferenced %s: %w", content.table, err)
+				}
+				deleted = res.RowsAffected()
+				logger.Debugf(ctx, "Deleted %d unreferenced %s entries in current batch", deleted, content.table)
+			}
+			countDeleted(&deletedItems, deleted)
+			deletedItems.ReferenceCountsFixed = fixed
+			return updateDeletedItems(ctx, tx, runId, deletedItems)
+		})
+		if err != nil || deleted > 0 || fixed == 0 {
+			return deleted + deletedItems.TSOperationData + deletedItems.FTSOperationData, err
 		}
-		deletedTemplates = res.RowsAffected()
+	}
+}
 
-		var cleanupRun entity.UnreferencedDataCleanupEntity
-		err = tx.Model(&cleanupRun).
-			Where("run_id = ?", runId).
-			Select()
-		if err != nil {
-			return fmt.Errorf("failed to get current state of cleanup run: %w", err)
+func (u unreferencedDataCleanupRepositoryImpl) GetUnreferencedContentCandidates(ctx context.Context, contentTable string, limit int) ([]entity.UnreferencedContentCandidateEntity, error) {
+	var content *refCountedContent
+	for i := range refCountedContents {
+		if refCountedContents[i].table == contentTable {
+			content = &refCountedContents[i]
 		}
-		if cleanupRun.DeletedItems == nil {
-			cleanupRun.DeletedItems = &entity.DeletedItemsCounts{}
-		}
-		cleanupRun.DeletedItems.OperationGroupTemplate += deletedTemplates
-		_, err = tx.Model(&cleanupRun).
-			Column("deleted_items").
-			WherePK().
-			Update()
-		if err != nil {
-			return fmt.Errorf("failed to update cleanup run state: %w", err)
-		}
-
-		return nil
-	})
+	}
+	if content == nil {
+		return nil, fmt.Errorf("unknown content table %s", contentTable)
+	}
+	refCondition := fmt.Sprintf("r.%s = c.%s", content.refKeyColumn, content.keyColumn)
+	if content.packageColumn != "" {
+		refCondition += fmt.Sprintf(" AND r.%[1]s = c.%[1]s", content.packageColumn)
+	}
+	var result []entity.UnreferencedContentCandidateEntity
+	_, err := u.cp.GetConnection().QueryContext(ctx, &result, fmt.Sprintf(`
+		WITH pending AS (
+			SELECT %[1]s AS package_id, content_key, sum(delta) AS delta
+			FROM content_ref_delta d
+			WHERE content_table = ?0
+			GROUP BY 1, 2
+		), candidates AS (
+			SELECT %[2]s AS package_id, c.%[3]s AS content_key, octet_length(c.%[4]s) AS bytes
+			FROM %[5]s c
+			LEFT JOIN pending p ON %[6]s
+			WHERE c.ref_count + coalesce(p.delta, 0) <= 0
+			AND NOT EXISTS (SELECT 1 FROM %[7]s r WHERE %[8]s)
+		)
+		SELECT package_id, content_key, bytes, count(*) OVER () AS total_count, sum(bytes) OVER () AS total_bytes
+		FROM candidates
+		ORDER BY 1, 2
+		LIMIT ?1`,
+		content.packageExpr("d"), content.packageExpr("c"), content.keyColumn, content.dataColumn, content.table,
+		content.keyCondition("p"), content.refTable, refCondition),
+		content.table, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unreferenced %s: %w", content.table, err)
+	}
+	return result, nil
+}
 
-	return deletedTemplates, err
+func splitContentRefs(contentRefs []contentRefs) ([]string, []string, []int) {
+	packageIds := make([]string, 0, len(contentRefs))
+	keys := make([]string, 0, len(contentRefs))
+	refs := make([]int, 0, len(contentRefs))
+	for _, c := range contentRefs {
+		packageIds = append(packageIds, c.PackageId)
+		keys = append(keys, c.ContentKey)
+		refs = append(refs, c.Refs)
+	}
+	return packageIds, keys, refs
 }
 
-func (u unreferencedDataCleanupRepositoryImpl) DeleteUnreferencedSrcArchives(ctx context.Context, runId string, batchSize int) (int, error) {
-	var deletedArchives int
-
-	err := u.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
-		deleteUnreferencedSrcArchivesQuery := `
-			DELETE FROM published_sources_archives psa
-			USING (
-  				SELECT checksum
-  				FROM published_sources_archives psa2
-  				WHERE NOT EXISTS (
-    				SELECT 1 FROM published_sources ps WHERE ps.archive_checksum = psa2.checksum
-  				)
-  				ORDER BY psa2.checksum
-  				LIMIT ?
-			) del
-			WHERE psa.checksum = del.checksum;`
-		res, err := tx.ExecContext(ctx, deleteUnreferencedSrcArchivesQuery, batchSize)
-		if err != nil {
-			return fmt.Errorf("failed to delete unreferenced source archives: %w", err)
-		}
+func updateDeletedItems(ctx context.Context, tx *pg.Tx, runId string, deletedItems entity.DeletedItemsCounts) error {
+	var cleanupRun entity.UnreferencedDataCleanupEntity
+	err := tx.ModelContext(ctx, &cleanupRun).
+		Where("run_id = ?", runId).
+		Select()
+	if err != nil {
+		return fmt.Errorf("failed to get current state of cleanup run: %w", err)
+	}
+	if cleanupRun.DeletedItems == nil {
+		cleanupRun.DeletedItems = &entity.DeletedItemsCounts{}
+	}
+	cleanupRun.DeletedItems.Add(&deletedItems)
+	_, err = tx.ModelContext(ctx, &cleanupRun).
+		Column("deleted_items").
+		WherePK().
+		Update()
+	if err != nil {
+		return fmt.Errorf("failed to update cleanup run state: %w", err)
+	}
+	return nil
+}
 
-		logger.Debugf(ctx, "Deleted %d unreferenced source archives in current batch", res.RowsAffected())
-		if res.RowsAffected() == 0 {
-			return nil
-		}
-		deletedArchives = res.RowsAffected()
+func (u unreferencedDataCleanupRepositoryImpl) DeleteUnreferencedOperationData(ctx context.Context, runId string, batchSize int) (int, error) {
+	deleteRelatedData := func(tx *pg.Tx, unreferenced []contentRefs, deletedItems *entity.DeletedItemsCounts) error {
+		_, dataHash, _ := splitContentRefs(unreferenced)
 
-		var cleanupRun entity.UnreferencedDataCleanupEntity
-		err = tx.Model(&cleanupRun).
-			Where("run_id = ?", runId).
-			Select()
+		logger.Debug(ctx, "Deleting related data from ts_operation_data")
+		opResult, err := tx.ExecContext(ctx, `DELETE FROM ts_operation_data WHERE data_hash IN (?)`, pg.In(dataHash))
 		if err != nil {
-			return fmt.Errorf("failed to get current state of cleanup run: %w", err)
-		}
-		if cleanupRun.DeletedItems == nil {
-			cleanupRun.DeletedItems = &entity.DeletedItemsCounts{}
+			return fmt.Errorf("failed to delete records from ts_operation_data: %w", err)
 		}
-		cleanupRun.DeletedItems.PublishedSrcArchives += deletedArchives
-		_, err = tx.Model(&cleanupRun).
-			Column("deleted_items").
-			WherePK().
-			Update()
+		deletedItems.TSOperationData = opResult.RowsAffected()
+
+		logger.Debug(ctx, "Deleting related data from fts_operation_data")
+		ftsResult, err := tx.ExecContext(ctx, `DELETE FROM fts_operation_data WHERE data_hash IN (?)`, pg.In(dataHash))
 		if err != nil {
-			return fmt.Errorf("failed to update cleanup run state: %w", err)
+			return fmt.Errorf("failed to delete records from fts_operation_data: %w", err)
 		}
-
+		deletedItems.FTSOperationData = ftsResult.RowsAffected()
 		return nil
-	})
-
-	return deletedArchives, err
+	}
+	return u.deleteUnreferencedContent(ctx, runId, operationDataContent, batchSize, deleteRelatedData,
+		func(deletedItems *entity.DeletedItemsCounts, deleted int) { deletedItems.OperationData = deleted })
 }
 
-func (u unreferencedDataCleanupRepositoryImpl) DeleteUnreferencedPublishedData(ctx context.Context, runId string, batchSize int) (int, error) {
-	var deletedPublishData int
-
-	err := u.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
-		deleteUnreferencedPublishDataQuery := `
-			DELETE FROM published_data pd
-			USING (
-  				SELECT checksum
-  				FROM published_data pd2
-  				WHERE NOT EXISTS (
-    				SELECT 1 FROM published_version_revision_content pvrc WHERE pvrc.checksum = pd2.checksum
-  				)
-  				ORDER BY pd2.checksum
-  				LIMIT ?
-			) del
-			WHERE pd.checksum = del.checksum;`
-		res, err := tx.ExecContext(ctx, deleteUnreferencedPublishDataQuery, batchSize)
-		if err != nil {
-			return fmt.Errorf("failed to delete unreferenced publish data: %w", err)
-		}
-
-		logger.Debugf(ctx, "Deleted %d unreferenced publish data in current batch", res.RowsAffected())
-		if res.RowsAffected() == 0 {
-			return nil
-		}
-		deletedPublishData = res.RowsAffected()
-
-		var cleanupRun entity.UnreferencedDataCleanupEntity
-		err = tx.Model(&cleanupRun).
-			Where("run_id = ?", runId).
-			Select()
-		if err != nil {
-			return fmt.Errorf("failed to get current state of cleanup run: %w", err)
-		}
-		if cleanupRun.DeletedItems == nil {
-			cleanupRun.DeletedItems = &entity.DeletedItemsCounts{}
-		}
-		cleanupRun.DeletedItems.PublishedData += deletedPublishData
-		_, err = tx.Model(&cleanupRun).
-			Column("deleted_items").
-			WherePK().
-			Update()
-		if err != nil {
-			return fmt.Errorf("failed to update cleanup run state: %w", err)
-		}
+func (u unreferencedDataCleanupRepositoryImpl) DeleteUnreferencedOperationGroupTemplates(ctx context.Context, runId string, batchSize int) (int, error) {
+	return u.deleteUnreferencedContent(ctx, runId, operationGroupTemplateContent, batchSize, nil,
+		func(deletedItems *entity.DeletedItemsCounts, deleted int) {
+			deletedItems.OperationGroupTemplate = deleted
+		})
+}
 
-		return nil
-	})
+func (u unreferencedDataCleanupRepositoryImpl) DeleteUnreferencedSrcArchives(ctx context.Context, runId string, batchSize int) (int, error) {
+	return u.deleteUnreferencedContent(ctx, runId, srcArchivesContent, batchSize, nil,
+		func(deletedItems *entity.DeletedItemsCounts, deleted int) {
+			deletedItems.PublishedSrcArchives = deleted
+		})
+}
 
-	return deletedPublishData, err
+func (u unreferencedDataCleanupRepositoryImpl) DeleteUnreferencedPublishedData(ctx context.Context, runId string, batchSize int) (int, error) {
+	return u.deleteUnreferencedContent(ctx, runId, publishedDataContent, batchSize, nil,
+		func(deletedItems *entity.DeletedItemsCounts, deleted int) { deletedItems.PublishedData = deleted })
 }
 
 func (u unreferencedDataCleanupRepositoryImpl) DeleteUnreferencedVersionInternalDocumentData(ctx context.Context, runId string, batchSize int) (int, error) {
-	var deletedInternalDocData int
-
-	err := u.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
-		deleteUnreferencedVersionInternalDocumentDataQuery := `
-			DELETE FROM version_internal_document_data vidd
-			USING (
-  				SELECT hash
-  				FROM version_internal_document_data vidd2
-  				WHERE NOT EXISTS (
-    				SELECT 1 FROM version_internal_document vid WHERE vid.hash = vidd2.hash
-  				)
-  				ORDER BY vidd2.hash
-  				LIMIT ?
-			) del
-			WHERE vidd.hash = del.hash;`
-		res, err := tx.ExecContext(ctx, deleteUnreferencedVersionInternalDocumentDataQuery, batchSize)
-		if err != nil {
-			return fmt.Errorf("failed to delete unreferenced version internal document data: %w", err)
-		}
-
-		logger.Debugf(ctx, "Deleted %d unreferenced version internal document data in current batch", res.RowsAffected())
-		if res.RowsAffected() == 0 {
-			return nil
-		}
-		deletedInternalDocData = res.RowsAffected()
-
-		var cleanupRun entity.UnreferencedDataCleanupEntity
-		err = tx.Model(&cleanupRun).
-			Where("run_id = ?", runId).
-			Select()
-		if err != nil {
-			return fmt.Errorf("failed to get current state of cleanup run: %w", err)
-		}
-		if cleanupRun.DeletedItems == nil {
-			cleanupRun.DeletedItems = &entity.DeletedItemsCounts{}
-		}
-		cleanupRun.DeletedItems.VersionInternalDocumentData += deletedInternalDocData
-		_, err = tx.Model(&cleanupRun).
-			Column("deleted_items").
-			WherePK().
-			Update()
-		if err != nil {
-			return fmt.Errorf("failed to update cleanup run state: %w", err)
-		}
-
-		return nil
-	})
-
-	return deletedInternalDocData, err
+	return u.deleteUnreferencedContent(ctx, runId, versionInternalDocumentDataContent, batchSize, nil,
+		func(deletedItems *entity.DeletedItemsCounts, deleted int) {
+			deletedItems.VersionInternalDocumentData = deleted
+		})
 }
 
 func (u unreferencedDataCleanupRepositoryImpl) DeleteUnreferencedComparisonInternalDocumentData(ctx context.Context, runId string, batchSize int) (int, error) {
-	var deletedInternalDocData int
-
-	err := u.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
-		deleteUnreferencedComparisonInternalDocumentDataQuery := `
-			DELETE FROM comparison_internal_document_data cidd
-			USING (
-  				SELECT hash
-  				FROM comparison_internal_document_data cidd2
-  				WHERE NOT EXISTS (
-    				SELECT 1 FROM comparison_internal_document cid WHERE cid.hash = cidd2.hash
-  				)
-  				ORDER BY cidd2.hash
-  				LIMIT ?
-			) del
-			WHERE cidd.hash = del.hash;`
-		res, err := tx.ExecContext(ctx, deleteUnreferencedComparisonInternalDocumentDataQuery, batchSize)
-		if err != nil {
-			return fmt.Errorf("failed to delete unreferenced comparison internal document data: %w", err)
-		}
-
-		logger.Debugf(ctx, "Deleted %d unreferenced comparison internal document data in current batch", res.RowsAffected())
-		if res.RowsAffected() == 0 {
-			return nil
-		}
-		deletedInternalDocData = res.RowsAffected()
-
-		var cleanupRun entity.UnreferencedDataCleanupEntity
-		err = tx.Model(&cleanupRun).
-			Where("run_id = ?", runId).
-			Select()
-		if err != nil {
-			return fmt.Errorf("failed to get current state of cleanup run: %w", err)
-		}
-		if cleanupRun.DeletedItems == nil {
-			cleanupRun.DeletedItems = &entity.DeletedItemsCounts{}
-		}
-		cleanupRun.DeletedItems.ComparisonInternalDocumentData += deletedInternalDocData
-		_, err = tx.Model(&cleanupRun).
-			Column("deleted_items").
-			WherePK().
-			Update()
-		if err != nil {
-			return fmt.Errorf("failed to update cleanup run state: %w", err)
-		}
-
-		return nil
-	})
-
-	return deletedInternalDocData, err
+	return u.deleteUnreferencedContent(ctx, runId, comparisonInternalDocumentDataContent, batchSize, nil,
+		func(deletedItems *entity.DeletedItemsCounts, deleted int) {
+			deletedItems.ComparisonInternalDocumentData = deleted
+		})
 }
 
 func (u unreferencedDataCleanupRepositoryImpl) VacuumAffectedTables(ctx context.Context, runId string) error {
@@ -421,9 +407,9 @@ func (u unreferencedDataCleanupRepositoryImpl) VacuumAffectedTables(ctx context.
 				logger.Warn(ctx, errorMsg)
 				vacuumErrors = append(vacuumErrors, errorMsg)
 			} else {
-			logger.Trace(ctx, "Successfully vacuumed 'operation_data' table")
+				logger.Trace(ctx, "Successfully vacuumed 'operation_data' table")
+			}
 		}
-	}
 		if deletedItems.TSOperationData > 0 {
 			logger.Debugf(ctx, "Vacuuming 'ts_operation_data' table for %d deleted entries", deletedItems.TSOperationData)
 			_, err = u.cp.GetConnection().ExecContext(ctx, "VACUUM FULL ts_operation_data")
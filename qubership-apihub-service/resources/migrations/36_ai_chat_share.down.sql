@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ai_chat_share_file;
+DROP TABLE IF EXISTS ai_chat_share;
@@ -0,0 +1,26 @@
+CREATE TABLE ai_chat_share (
+    id           uuid        PRIMARY KEY,
+    chat_id      uuid        NOT NULL
+        CONSTRAINT ai_chat_share_chat_fk REFERENCES ai_chat(id) ON DELETE CASCADE,
+    user_id      varchar     NOT NULL,
+    title        text        NOT NULL DEFAULT '',
+    messages     jsonb       NOT NULL,
+    package_ids  varchar[],
+    created_at   timestamp without time zone NOT NULL
+);
+
+CREATE INDEX ai_chat_share_chat_idx
+    ON ai_chat_share (chat_id, created_at DESC);
+
+-- Generated files are copied into the snapshot: ephemeral files expire and are
+-- bound to the owner's download tokens, so the share must not depend on them.
+CREATE TABLE ai_chat_share_file (
+    share_id   uuid        NOT NULL
+        CONSTRAINT ai_chat_share_file_share_fk REFERENCES ai_chat_share(id) ON DELETE CASCADE,
+    file_id    uuid        NOT NULL,
+    filename   text        NOT NULL,
+    mime_type  varchar,
+    size_bytes bigint      NOT NULL,
+    data       bytea       NOT NULL,
+    PRIMARY KEY (share_id, file_id)
+);
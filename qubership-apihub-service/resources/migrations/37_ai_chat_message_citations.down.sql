@@ -0,0 +1 @@
+ALTER TABLE ai_chat_message DROP COLUMN IF EXISTS citations;
@@ -0,0 +1 @@
+ALTER TABLE ai_chat_message ADD COLUMN IF NOT EXISTS citations jsonb;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS ai_spec_review_notification;
+DROP TABLE IF EXISTS ai_spec_review;
+DROP TABLE IF EXISTS ai_spec_review_config;
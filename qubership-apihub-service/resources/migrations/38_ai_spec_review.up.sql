@@ -0,0 +1,44 @@
+CREATE TABLE ai_spec_review_config (
+    package_id    varchar     PRIMARY KEY
+        CONSTRAINT ai_spec_review_config_package_fk REFERENCES package_group(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    enabled       boolean     NOT NULL DEFAULT false,
+    token_budget  integer,
+    updated_by    varchar,
+    updated_at    timestamp without time zone NOT NULL
+);
+
+-- One review per published revision; the primary key doubles as a cluster-wide guard,
+-- since every instance receives the 'version published' event.
+CREATE TABLE ai_spec_review (
+    package_id           varchar     NOT NULL,
+    version              varchar     NOT NULL,
+    revision             integer     NOT NULL,
+    status               varchar     NOT NULL,
+    details              text,
+    operations_total     integer     NOT NULL DEFAULT 0,
+    operations_reviewed  integer     NOT NULL DEFAULT 0,
+    token_budget         integer     NOT NULL,
+    tokens_used          integer     NOT NULL DEFAULT 0,
+    started_at           timestamp without time zone NOT NULL,
+    finished_at          timestamp without time zone,
+    PRIMARY KEY (package_id, version, revision),
+    CONSTRAINT ai_spec_review_version_fk FOREIGN KEY (package_id, version, revision)
+        REFERENCES published_version(package_id, version, revision) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+-- Same shape as builder_notifications (severity/message) plus the reviewed operation and finding category.
+CREATE TABLE ai_spec_review_notification (
+    package_id    varchar     NOT NULL,
+    version       varchar     NOT NULL,
+    revision      integer     NOT NULL,
+    severity      integer     NOT NULL,
+    category      varchar     NOT NULL,
+    message       varchar     NOT NULL,
+    api_type      varchar,
+    operation_id  varchar,
+    CONSTRAINT ai_spec_review_notification_review_fk FOREIGN KEY (package_id, version, revision)
+        REFERENCES ai_spec_review(package_id, version, revision) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX ai_spec_review_notification_version_idx
+    ON ai_spec_review_notification (package_id, version, revision);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS cleanup_dry_run;
+DROP TABLE IF EXISTS cleanup_retention_policy;
@@ -0,0 +1,26 @@
+CREATE TABLE cleanup_retention_policy
+(
+    package_id                VARCHAR                     NOT NULL,
+    data_class                VARCHAR                     NOT NULL,
+    ttl_days                  INTEGER,
+    keep_last_draft_revisions INTEGER,
+    keep_release_revisions    BOOLEAN,
+    archived_ttl_days         INTEGER,
+    updated_by                VARCHAR                     NOT NULL,
+    updated_at                TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    CONSTRAINT cleanup_retention_policy_pk PRIMARY KEY (package_id, data_class),
+    CONSTRAINT cleanup_retention_policy_package_group_id_fk FOREIGN KEY (package_id) REFERENCES package_group (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE cleanup_dry_run
+(
+    run_id      UUID PRIMARY KEY,
+    job_type    VARCHAR                     NOT NULL,
+    package_id  VARCHAR,
+    status      VARCHAR                     NOT NULL,
+    details     VARCHAR,
+    created_by  VARCHAR                     NOT NULL,
+    started_at  TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITHOUT TIME ZONE,
+    report      JSONB
+);
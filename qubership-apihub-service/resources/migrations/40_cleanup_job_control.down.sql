@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS cleanup_job_run_info;
+DROP TABLE IF EXISTS cleanup_job_state;
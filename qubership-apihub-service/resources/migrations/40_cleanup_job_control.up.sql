@@ -0,0 +1,24 @@
+CREATE TABLE cleanup_job_state
+(
+    job_type   VARCHAR PRIMARY KEY,
+    paused     BOOLEAN                     NOT NULL,
+    updated_by VARCHAR                     NOT NULL,
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE TABLE cleanup_job_run_info
+(
+    run_id              UUID PRIMARY KEY,
+    job_type            VARCHAR                     NOT NULL,
+    instance_id         UUID                        NOT NULL,
+    triggered_by        VARCHAR,
+    status              VARCHAR                     NOT NULL,
+    details             VARCHAR,
+    started_at          TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    finished_at         TIMESTAMP WITHOUT TIME ZONE,
+    vacuum_started_at   TIMESTAMP WITHOUT TIME ZONE,
+    vacuum_finished_at  TIMESTAMP WITHOUT TIME ZONE,
+    cancel_requested_by VARCHAR
+);
+
+CREATE INDEX cleanup_job_run_info_job_type_started_at_idx ON cleanup_job_run_info (job_type, started_at DESC);
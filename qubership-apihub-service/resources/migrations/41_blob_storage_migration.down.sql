@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS blob_migration_run;
+
+DELETE FROM export_result WHERE data IS NULL;
+ALTER TABLE export_result ALTER COLUMN data SET NOT NULL;
@@ -0,0 +1,22 @@
+ALTER TABLE export_result ALTER COLUMN data DROP NOT NULL;
+
+CREATE TABLE blob_migration_run
+(
+    run_id              UUID PRIMARY KEY,
+    source_backend      VARCHAR                     NOT NULL,
+    target_backend      VARCHAR                     NOT NULL,
+    namespaces          VARCHAR[]                   NOT NULL,
+    delete_source       BOOLEAN                     NOT NULL,
+    status              VARCHAR                     NOT NULL,
+    details             VARCHAR,
+    progress            JSONB                       NOT NULL,
+    instance_id         UUID,
+    created_by          VARCHAR                     NOT NULL,
+    created_at          TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    last_active         TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    finished_at         TIMESTAMP WITHOUT TIME ZONE,
+    cancel_requested_by VARCHAR
+);
+
+-- only one blob migration could be running at a time
+CREATE UNIQUE INDEX blob_migration_run_running_idx ON blob_migration_run ((true)) WHERE status = 'running';
@@ -0,0 +1,36 @@
+DROP TRIGGER IF EXISTS operation_data_ref_insert ON operation;
+DROP TRIGGER IF EXISTS operation_data_ref_delete ON operation;
+DROP TRIGGER IF EXISTS operation_data_ref_update ON operation;
+DROP TRIGGER IF EXISTS operation_group_template_ref_insert ON operation_group;
+DROP TRIGGER IF EXISTS operation_group_template_ref_delete ON operation_group;
+DROP TRIGGER IF EXISTS operation_group_template_ref_update ON operation_group;
+DROP TRIGGER IF EXISTS published_sources_archives_ref_insert ON published_sources;
+DROP TRIGGER IF EXISTS published_sources_archives_ref_delete ON published_sources;
+DROP TRIGGER IF EXISTS published_sources_archives_ref_update ON published_sources;
+DROP TRIGGER IF EXISTS published_data_ref_insert ON published_version_revision_content;
+DROP TRIGGER IF EXISTS published_data_ref_delete ON published_version_revision_content;
+DROP TRIGGER IF EXISTS published_data_ref_update ON published_version_revision_content;
+DROP TRIGGER IF EXISTS published_data_key_update ON published_data;
+DROP TRIGGER IF EXISTS version_internal_document_data_ref_insert ON version_internal_document;
+DROP TRIGGER IF EXISTS version_internal_document_data_ref_delete ON version_internal_document;
+DROP TRIGGER IF EXISTS version_internal_document_data_ref_update ON version_internal_document;
+DROP TRIGGER IF EXISTS comparison_internal_document_data_ref_insert ON comparison_internal_document;
+DROP TRIGGER IF EXISTS comparison_internal_document_data_ref_delete ON comparison_internal_document;
+DROP TRIGGER IF EXISTS comparison_internal_document_data_ref_update ON comparison_internal_document;
+
+DROP FUNCTION IF EXISTS operation_data_ref_delta();
+DROP FUNCTION IF EXISTS operation_group_template_ref_delta();
+DROP FUNCTION IF EXISTS published_sources_archives_ref_delta();
+DROP FUNCTION IF EXISTS published_data_ref_delta();
+DROP FUNCTION IF EXISTS published_data_reset_ref_count();
+DROP FUNCTION IF EXISTS version_internal_document_data_ref_delta();
+DROP FUNCTION IF EXISTS comparison_internal_document_data_ref_delta();
+
+ALTER TABLE operation_data DROP COLUMN IF EXISTS ref_count;
+ALTER TABLE operation_group_template DROP COLUMN IF EXISTS ref_count;
+ALTER TABLE published_sources_archives DROP COLUMN IF EXISTS ref_count;
+ALTER TABLE published_data DROP COLUMN IF EXISTS ref_count;
+ALTER TABLE version_internal_document_data DROP COLUMN IF EXISTS ref_count;
+ALTER TABLE comparison_internal_document_data DROP COLUMN IF EXISTS ref_count;
+
+DROP TABLE IF EXISTS content_ref_delta;
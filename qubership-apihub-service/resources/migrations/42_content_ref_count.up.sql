@@ -0,0 +1,236 @@
+-- Reference counters of the content-addressed tables.
+-- Referencing tables append the changes of their references to content_ref_delta instead of updating the counters directly,
+-- so that publications which share the same content do not lock each other. The changes are applied to ref_count
+-- by the unreferenced data cleanup job before it deletes the content with no references.
+CREATE TABLE content_ref_delta
+(
+    id            BIGSERIAL PRIMARY KEY,
+    content_table VARCHAR NOT NULL,
+    package_id    VARCHAR NOT NULL DEFAULT '',
+    content_key   VARCHAR NOT NULL,
+    delta         INTEGER NOT NULL
+);
+
+CREATE INDEX content_ref_delta_content_table_id_idx ON content_ref_delta (content_table, id);
+
+ALTER TABLE operation_data ADD COLUMN ref_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE operation_group_template ADD COLUMN ref_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE published_sources_archives ADD COLUMN ref_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE published_data ADD COLUMN ref_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE version_internal_document_data ADD COLUMN ref_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE comparison_internal_document_data ADD COLUMN ref_count INTEGER NOT NULL DEFAULT 0;
+
+-- operation -> operation_data
+CREATE OR REPLACE FUNCTION operation_data_ref_delta() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+BEGIN
+    IF TG_LEVEL = 'ROW' THEN
+        INSERT INTO content_ref_delta (content_table, content_key, delta)
+        SELECT 'operation_data', k.content_key, k.delta
+        FROM (VALUES (OLD.data_hash, -1), (NEW.data_hash, 1)) AS k(content_key, delta)
+        WHERE k.content_key IS NOT NULL;
+    ELSIF TG_OP = 'INSERT' THEN
+        INSERT INTO content_ref_delta (content_table, content_key, delta)
+        SELECT 'operation_data', data_hash, count(*) FROM new_refs WHERE data_hash IS NOT NULL GROUP BY data_hash;
+    ELSE
+        INSERT INTO content_ref_delta (content_table, content_key, delta)
+        SELECT 'operation_data', data_hash, -count(*) FROM old_refs WHERE data_hash IS NOT NULL GROUP BY data_hash;
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER operation_data_ref_insert AFTER INSERT ON operation
+    REFERENCING NEW TABLE AS new_refs FOR EACH STATEMENT EXECUTE PROCEDURE operation_data_ref_delta();
+CREATE TRIGGER operation_data_ref_delete AFTER DELETE ON operation
+    REFERENCING OLD TABLE AS old_refs FOR EACH STATEMENT EXECUTE PROCEDURE operation_data_ref_delta();
+CREATE TRIGGER operation_data_ref_update AFTER UPDATE OF data_hash ON operation
+    FOR EACH ROW WHEN (OLD.data_hash IS DISTINCT FROM NEW.data_hash) EXECUTE PROCEDURE operation_data_ref_delta();
+
+-- operation_group -> operation_group_template
+CREATE OR REPLACE FUNCTION operation_group_template_ref_delta() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+BEGIN
+    IF TG_LEVEL = 'ROW' THEN
+        INSERT INTO content_ref_delta (content_table, content_key, delta)
+        SELECT 'operation_group_template', k.content_key, k.delta
+        FROM (VALUES (OLD.template_checksum, -1), (NEW.template_checksum, 1)) AS k(content_key, delta)
+        WHERE k.content_key IS NOT NULL;
+    ELSIF TG_OP = 'INSERT' THEN
+        INSERT INTO content_ref_delta (content_table, content_key, delta)
+        SELECT 'operation_group_template', template_checksum, count(*) FROM new_refs WHERE template_checksum IS NOT NULL GROUP BY template_checksum;
+    ELSE
+        INSERT INTO content_ref_delta (content_table, content_key, delta)
+        SELECT 'operation_group_template', template_checksum, -count(*) FROM old_refs WHERE template_checksum IS NOT NULL GROUP BY template_checksum;
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER operation_group_template_ref_insert AFTER INSERT ON operation_group
+    REFERENCING NEW TABLE AS new_refs FOR EACH STATEMENT EXECUTE PROCEDURE operation_group_template_ref_delta();
+CREATE TRIGGER operation_group_template_ref_delete AFTER DELETE ON operation_group
+    REFERENCING OLD TABLE AS old_refs FOR EACH STATEMENT EXECUTE PROCEDURE operation_group_template_ref_delta();
+CREATE TRIGGER operation_group_template_ref_update AFTER UPDATE OF template_checksum ON operation_group
+    FOR EACH ROW WHEN (OLD.template_checksum IS DISTINCT FROM NEW.template_checksum) EXECUTE PROCEDURE operation_group_template_ref_delta();
+
+-- published_sources -> published_sources_archives
+CREATE OR REPLACE FUNCTION published_sources_archives_ref_delta() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+BEGIN
+    IF TG_LEVEL = 'ROW' THEN
+        INSERT INTO content_ref_delta (content_table, content_key, delta)
+        SELECT 'published_sources_archives', k.content_key, k.delta
+        FROM (VALUES (OLD.archive_checksum, -1), (NEW.archive_checksum, 1)) AS k(content_key, delta)
+        WHERE k.content_key IS NOT NULL;
+    ELSIF TG_OP = 'INSERT' THEN
+        INSERT INTO content_ref_delta (content_table, content_key, delta)
+        SELECT 'published_sources_archives', archive_checksum, count(*) FROM new_refs WHERE archive_checksum IS NOT NULL GROUP BY archive_checksum;
+    ELSE
+        INSERT INTO content_ref_delta (content_table, content_key, delta)
+        SELECT 'published_sources_archives', archive_checksum, -count(*) FROM old_refs WHERE archive_checksum IS NOT NULL GROUP BY archive_checksum;
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER published_sources_archives_ref_insert AFTER INSERT ON published_sources
+    REFERENCING NEW TABLE AS new_refs FOR EACH STATEMENT EXECUTE PROCEDURE published_sources_archives_ref_delta();
+CREATE TRIGGER published_sources_archives_ref_delete AFTER DELETE ON published_sources
+    REFERENCING OLD TABLE AS old_refs FOR EACH STATEMENT EXECUTE PROCEDURE published_sources_archives_ref_delta();
+CREATE TRIGGER published_sources_archives_ref_update AFTER UPDATE OF archive_checksum ON published_sources
+    FOR EACH ROW WHEN (OLD.archive_checksum IS DISTINCT FROM NEW.archive_checksum) EXECUTE PROCEDURE published_sources_archives_ref_delta();
+
+-- published_version_revision_content -> published_data. Documents are content-addressed within the package.
+CREATE OR REPLACE FUNCTION published_data_ref_delta() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+BEGIN
+    IF TG_LEVEL = 'ROW' THEN
+        INSERT INTO content_ref_delta (content_table, package_id, content_key, delta)
+        SELECT 'published_data', k.package_id, k.content_key, k.delta
+        FROM (VALUES (OLD.package_id, OLD.checksum, -1), (NEW.package_id, NEW.checksum, 1)) AS k(package_id, content_key, delta)
+        WHERE k.package_id IS NOT NULL AND k.content_key IS NOT NULL;
+    ELSIF TG_OP = 'INSERT' THEN
+        INSERT INTO content_ref_delta (content_table, package_id, content_key, delta)
+        SELECT 'published_data', package_id, checksum, count(*) FROM new_refs WHERE checksum IS NOT NULL GROUP BY package_id, checksum;
+    ELSE
+        INSERT INTO content_ref_delta (content_table, package_id, content_key, delta)
+        SELECT 'published_data', package_id, checksum, -count(*) FROM old_refs WHERE checksum IS NOT NULL GROUP BY package_id, checksum;
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER published_data_ref_insert AFTER INSERT ON published_version_revision_content
+    REFERENCING NEW TABLE AS new_refs FOR EACH STATEMENT EXECUTE PROCEDURE published_data_ref_delta();
+CREATE TRIGGER published_data_ref_delete AFTER DELETE ON published_version_revision_content
+    REFERENCING OLD TABLE AS old_refs FOR EACH STATEMENT EXECUTE PROCEDURE published_data_ref_delta();
+CREATE TRIGGER published_data_ref_update AFTER UPDATE OF package_id, checksum ON published_version_revision_content
+    FOR EACH ROW WHEN (OLD.package_id IS DISTINCT FROM NEW.package_id OR OLD.checksum IS DISTINCT FROM NEW.checksum)
+    EXECUTE PROCEDURE published_data_ref_delta();
+
+-- documents are moved together with their references when a package is moved, the references produce the changes for the new key
+CREATE OR REPLACE FUNCTION published_data_reset_ref_count() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+BEGIN
+    NEW.ref_count := 0;
+    RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER published_data_key_update BEFORE UPDATE OF package_id, checksum ON published_data
+    FOR EACH ROW WHEN (OLD.package_id IS DISTINCT FROM NEW.package_id OR OLD.checksum IS DISTINCT FROM NEW.checksum)
+    EXECUTE PROCEDURE published_data_reset_ref_count();
+
+-- version_internal_document -> version_internal_document_data
+CREATE OR REPLACE FUNCTION version_internal_document_data_ref_delta() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+BEGIN
+    IF TG_LEVEL = 'ROW' THEN
+        INSERT INTO content_ref_delta (content_table, content_key, delta)
+        SELECT 'version_internal_document_data', k.content_key, k.delta
+        FROM (VALUES (OLD.hash, -1), (NEW.hash, 1)) AS k(content_key, delta)
+        WHERE k.content_key IS NOT NULL;
+    ELSIF TG_OP = 'INSERT' THEN
+        INSERT INTO content_ref_delta (content_table, content_key, delta)
+        SELECT 'version_internal_document_data', hash, count(*) FROM new_refs WHERE hash IS NOT NULL GROUP BY hash;
+    ELSE
+        INSERT INTO content_ref_delta (content_table, content_key, delta)
+        SELECT 'version_internal_document_data', hash, -count(*) FROM old_refs WHERE hash IS NOT NULL GROUP BY hash;
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER version_internal_document_data_ref_insert AFTER INSERT ON version_internal_document
+    REFERENCING NEW TABLE AS new_refs FOR EACH STATEMENT EXECUTE PROCEDURE version_internal_document_data_ref_delta();
+CREATE TRIGGER version_internal_document_data_ref_delete AFTER DELETE ON version_internal_document
+    REFERENCING OLD TABLE AS old_refs FOR EACH STATEMENT EXECUTE PROCEDURE version_internal_document_data_ref_delta();
+CREATE TRIGGER version_internal_document_data_ref_update AFTER UPDATE OF hash ON version_internal_document
+    FOR EACH ROW WHEN (OLD.hash IS DISTINCT FROM NEW.hash) EXECUTE PROCEDURE version_internal_document_data_ref_delta();
+
+-- comparison_internal_document -> comparison_internal_document_data
+CREATE OR REPLACE FUNCTION comparison_internal_document_data_ref_delta() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+BEGIN
+    IF TG_LEVEL = 'ROW' THEN
+        INSERT INTO content_ref_delta (content_table, content_key, delta)
+        SELECT 'comparison_internal_document_data', k.content_key, k.delta
+        FROM (VALUES (OLD.hash, -1), (NEW.hash, 1)) AS k(content_key, delta)
+        WHERE k.content_key IS NOT NULL;
+    ELSIF TG_OP = 'INSERT' THEN
+        INSERT INTO content_ref_delta (content_table, content_key, delta)
+        SELECT 'comparison_internal_document_data', hash, count(*) FROM new_refs WHERE hash IS NOT NULL GROUP BY hash;
+    ELSE
+        INSERT INTO content_ref_delta (content_table, content_key, delta)
+        SELECT 'comparison_internal_document_data', hash, -count(*) FROM old_refs WHERE hash IS NOT NULL GROUP BY hash;
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER comparison_internal_document_data_ref_insert AFTER INSERT ON comparison_internal_document
+    REFERENCING NEW TABLE AS new_refs FOR EACH STATEMENT EXECUTE PROCEDURE comparison_internal_document_data_ref_delta();
+CREATE TRIGGER comparison_internal_document_data_ref_delete AFTER DELETE ON comparison_internal_document
+    REFERENCING OLD TABLE AS old_refs FOR EACH STATEMENT EXECUTE PROCEDURE comparison_internal_document_data_ref_delta();
+CREATE TRIGGER comparison_internal_document_data_ref_update AFTER UPDATE OF hash ON comparison_internal_document
+    FOR EACH ROW WHEN (OLD.hash IS DISTINCT FROM NEW.hash) EXECUTE PROCEDURE comparison_internal_document_data_ref_delta();
+
+-- initial values of the counters
+UPDATE operation_data od SET ref_count = r.cnt
+FROM (SELECT data_hash, count(*) AS cnt FROM operation GROUP BY data_hash) r
+WHERE od.data_hash = r.data_hash;
+
+UPDATE operation_group_template ogt SET ref_count = r.cnt
+FROM (SELECT template_checksum, count(*) AS cnt FROM operation_group GROUP BY template_checksum) r
+WHERE ogt.checksum = r.template_checksum;
+
+UPDATE published_sources_archives psa SET ref_count = r.cnt
+FROM (SELECT archive_checksum, count(*) AS cnt FROM published_sources GROUP BY archive_checksum) r
+WHERE psa.checksum = r.archive_checksum;
+
+UPDATE published_data pd SET ref_count = r.cnt
+FROM (SELECT package_id, checksum, count(*) AS cnt FROM published_version_revision_content GROUP BY package_id, checksum) r
+WHERE pd.package_id = r.package_id AND pd.checksum = r.checksum;
+
+UPDATE version_internal_document_data vidd SET ref_count = r.cnt
+FROM (SELECT hash, count(*) AS cnt FROM version_internal_document GROUP BY hash) r
+WHERE vidd.hash = r.hash;
+
+UPDATE comparison_internal_document_data cidd SET ref_count = r.cnt
+FROM (SELECT hash, count(*) AS cnt FROM comparison_internal_document GROUP BY hash) r
+WHERE cidd.hash = r.hash;
+
+CREATE INDEX operation_data_unreferenced_idx ON operation_data (data_hash) WHERE ref_count <= 0;
+CREATE INDEX operation_group_template_unreferenced_idx ON operation_group_template (checksum) WHERE ref_count <= 0;
+CREATE INDEX published_sources_archives_unreferenced_idx ON published_sources_archives (checksum) WHERE ref_count <= 0;
+CREATE INDEX published_data_unreferenced_idx ON published_data (package_id, checksum) WHERE ref_count <= 0;
+CREATE INDEX version_internal_document_data_unreferenced_idx ON version_internal_document_data (hash) WHERE ref_count <= 0;
+CREATE INDEX comparison_internal_document_data_unreferenced_idx ON comparison_internal_document_data (hash) WHERE ref_count <= 0;
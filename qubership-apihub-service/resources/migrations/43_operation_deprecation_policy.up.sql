@@ -0,0 +1,22 @@
+-- Deprecation schedule of an operation. It is kept per package, not per version, since the sunset applies to all releases
+-- which still contain the operation. Policies with source 'spec' are captured from x-* extensions on publish,
+-- policies with source 'manual' are set via API and are not overwritten by publications.
+CREATE TABLE operation_deprecation_policy (
+    package_id               varchar     NOT NULL
+        CONSTRAINT operation_deprecation_policy_package_fk REFERENCES package_group(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    operation_id             varchar     NOT NULL,
+    api_type                 varchar     NOT NULL,
+    deprecated_since         varchar,
+    sunset_date              date,
+    replacement_package_id   varchar,
+    replacement_operation_id varchar,
+    description              varchar,
+    source                   varchar     NOT NULL,
+    updated_by               varchar,
+    updated_at               timestamp without time zone NOT NULL,
+    sunset_alerted_at        timestamp without time zone,
+    PRIMARY KEY (package_id, operation_id)
+);
+
+CREATE INDEX operation_deprecation_policy_sunset_date_idx
+    ON operation_deprecation_policy (sunset_date) WHERE sunset_date IS NOT NULL;
@@ -0,0 +1,16 @@
+-- Operations declared as consumed by a package or by an external application identified by an api key.
+-- Declarations are kept per producer package, version is empty if the consumer follows the latest version.
+CREATE TABLE operation_consumer (
+    consumer_type varchar NOT NULL,
+    consumer_id   varchar NOT NULL,
+    package_id    varchar NOT NULL
+        CONSTRAINT operation_consumer_package_fk REFERENCES package_group(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    operation_id  varchar NOT NULL,
+    version       varchar NOT NULL DEFAULT '',
+    declared_by   varchar,
+    declared_at   timestamp without time zone NOT NULL,
+    PRIMARY KEY (consumer_type, consumer_id, package_id, operation_id)
+);
+
+CREATE INDEX operation_consumer_package_id_operation_id_idx
+    ON operation_consumer (package_id, operation_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS dashboard_roll_forward;
+DROP TABLE IF EXISTS dashboard_tracking;
@@ -0,0 +1,35 @@
+-- Dashboard versions whose references follow a rule and are rolled forward when referenced packages release.
+CREATE TABLE dashboard_tracking (
+    package_id      varchar NOT NULL
+        CONSTRAINT dashboard_tracking_package_fk REFERENCES package_group(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    version         varchar NOT NULL,
+    rule            varchar NOT NULL,
+    version_pattern varchar NOT NULL DEFAULT '',
+    enabled         boolean NOT NULL DEFAULT true,
+    updated_by      varchar,
+    updated_at      timestamp without time zone NOT NULL,
+    PRIMARY KEY (package_id, version)
+);
+
+-- History of automatic dashboard republications. The trigger columns make the roll forward for one event unique,
+-- since every instance of the cluster receives the 'version published' event.
+CREATE TABLE dashboard_roll_forward (
+    id                 varchar NOT NULL PRIMARY KEY,
+    package_id         varchar NOT NULL
+        CONSTRAINT dashboard_roll_forward_package_fk REFERENCES package_group(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    version            varchar NOT NULL,
+    revision           integer,
+    publish_id         varchar,
+    trigger_package_id varchar NOT NULL,
+    trigger_version    varchar NOT NULL,
+    trigger_revision   integer NOT NULL,
+    moved_refs         jsonb NOT NULL DEFAULT '[]',
+    status             varchar NOT NULL,
+    details            text,
+    created_at         timestamp without time zone NOT NULL,
+    finished_at        timestamp without time zone,
+    CONSTRAINT dashboard_roll_forward_trigger_unique UNIQUE (package_id, version, trigger_package_id, trigger_version, trigger_revision)
+);
+
+CREATE INDEX dashboard_roll_forward_package_id_created_at_idx
+    ON dashboard_roll_forward (package_id, created_at DESC);
@@ -0,0 +1,26 @@
+-- Named comparisons of two package versions. The comparison is recalculated when a new revision of either side is published,
+-- comparison_id points to the version_comparison of the revisions it was calculated for and is kept by the comparisons cleanup.
+CREATE TABLE saved_comparison (
+    id                  varchar NOT NULL PRIMARY KEY,
+    name                varchar NOT NULL,
+    package_id          varchar NOT NULL
+        CONSTRAINT saved_comparison_package_fk REFERENCES package_group(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    version             varchar NOT NULL,
+    revision            integer NOT NULL,
+    previous_package_id varchar NOT NULL
+        CONSTRAINT saved_comparison_previous_package_fk REFERENCES package_group(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    previous_version    varchar NOT NULL,
+    previous_revision   integer NOT NULL,
+    comparison_id       varchar NOT NULL,
+    status              varchar NOT NULL,
+    details             text,
+    build_id            varchar,
+    created_by          varchar NOT NULL,
+    created_at          timestamp without time zone NOT NULL,
+    calculated_at       timestamp without time zone
+);
+
+CREATE INDEX saved_comparison_created_by_idx ON saved_comparison (created_by);
+CREATE INDEX saved_comparison_package_id_version_idx ON saved_comparison (package_id, version);
+CREATE INDEX saved_comparison_previous_package_id_version_idx ON saved_comparison (previous_package_id, previous_version);
+CREATE INDEX saved_comparison_comparison_id_idx ON saved_comparison (comparison_id);
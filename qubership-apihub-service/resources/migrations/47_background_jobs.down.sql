@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS background_job_run;
+DROP TABLE IF EXISTS background_job_state;
@@ -0,0 +1,24 @@
+-- State and run history of background jobs registered in BackgroundJobService.
+-- Cleanup jobs keep their own tables (see 40_cleanup_job_control), they are not listed here.
+CREATE TABLE background_job_state
+(
+    job_name   VARCHAR PRIMARY KEY,
+    disabled   BOOLEAN                     NOT NULL,
+    updated_by VARCHAR                     NOT NULL,
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE TABLE background_job_run
+(
+    run_id       UUID PRIMARY KEY,
+    job_name     VARCHAR                     NOT NULL,
+    instance_id  UUID                        NOT NULL,
+    triggered_by VARCHAR,
+    status       VARCHAR                     NOT NULL,
+    attempts     INTEGER                     NOT NULL,
+    details      VARCHAR,
+    started_at   TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    finished_at  TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE INDEX background_job_run_job_name_started_at_idx ON background_job_run (job_name, started_at DESC);
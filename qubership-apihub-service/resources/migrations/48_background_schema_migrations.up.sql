@@ -0,0 +1,22 @@
+-- Background (online) schema migrations: batched data migrations declared in N_name.background.sql files.
+-- They are executed by the backgroundSchemaMigrations job after the schema migration N is applied.
+CREATE TABLE background_schema_migration
+(
+    num            INTEGER PRIMARY KEY,
+    name           VARCHAR                     NOT NULL,
+    hash           VARCHAR                     NOT NULL,
+    sql_batch      VARCHAR                     NOT NULL,
+    sql_count      VARCHAR,
+    batch_size     INTEGER                     NOT NULL,
+    batch_delay_ms INTEGER                     NOT NULL,
+    status         VARCHAR                     NOT NULL,
+    batches_done   BIGINT                      NOT NULL DEFAULT 0,
+    rows_processed BIGINT                      NOT NULL DEFAULT 0,
+    total_rows     BIGINT,
+    details        VARCHAR,
+    paused_by      VARCHAR,
+    created_at     TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    started_at     TIMESTAMP WITHOUT TIME ZONE,
+    last_active    TIMESTAMP WITHOUT TIME ZONE,
+    finished_at    TIMESTAMP WITHOUT TIME ZONE
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS build_created_at_idx;
+DROP TABLE IF EXISTS workspace_storage_usage;
+DROP TABLE IF EXISTS workspace_tenant_admin;
+DROP TABLE IF EXISTS workspace_tenant;
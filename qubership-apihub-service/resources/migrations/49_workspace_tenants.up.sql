@@ -0,0 +1,36 @@
+-- Workspaces are tenants: per-workspace quotas and overrides of the instance-wide configuration.
+-- Null quota means the workspace is not limited, null override means the instance-wide value is used.
+CREATE TABLE workspace_tenant (
+    workspace_id              varchar NOT NULL PRIMARY KEY
+        CONSTRAINT workspace_tenant_workspace_fk REFERENCES package_group(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    storage_limit_mb          bigint,
+    max_packages              integer,
+    max_versions              integer,
+    builds_per_hour           integer,
+    ai_tokens_per_month       bigint,
+    release_version_pattern   varchar,
+    default_role              varchar,
+    validation_rules_severity jsonb,
+    updated_by                varchar,
+    updated_at                timestamp without time zone NOT NULL
+);
+
+-- Workspace administrators have all permissions in the packages of the workspace and manage its tenant settings.
+CREATE TABLE workspace_tenant_admin (
+    workspace_id varchar NOT NULL
+        CONSTRAINT workspace_tenant_admin_workspace_fk REFERENCES package_group(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    user_id      varchar NOT NULL
+        CONSTRAINT workspace_tenant_admin_user_fk REFERENCES user_data(user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    added_by     varchar,
+    added_at     timestamp without time zone NOT NULL,
+    PRIMARY KEY (workspace_id, user_id)
+);
+
+-- Storage usage is expensive to calculate, so it is stored by the tenantStorageUsage background job and used for the quota check.
+CREATE TABLE workspace_storage_usage (
+    workspace_id  varchar NOT NULL PRIMARY KEY,
+    total_size    bigint  NOT NULL,
+    calculated_at timestamp without time zone NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS build_created_at_idx ON build (created_at);
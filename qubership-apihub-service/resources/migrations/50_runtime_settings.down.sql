@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS system_notification;
+DROP TABLE IF EXISTS runtime_setting_history;
+DROP TABLE IF EXISTS runtime_setting;
@@ -0,0 +1,33 @@
+-- Runtime settings override the values of the configuration file, they are edited by system administrators without restart.
+-- key is the path of the setting in the configuration file, e.g. businessParameters.releaseVersionPattern.
+CREATE TABLE runtime_setting (
+    key        varchar NOT NULL PRIMARY KEY,
+    value      jsonb   NOT NULL,
+    updated_by varchar NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+-- Every change of a runtime setting, null value means the override was removed.
+CREATE TABLE runtime_setting_history (
+    id         varchar NOT NULL PRIMARY KEY,
+    key        varchar NOT NULL,
+    old_value  jsonb,
+    new_value  jsonb,
+    changed_by varchar NOT NULL,
+    changed_at timestamp without time zone NOT NULL
+);
+
+CREATE INDEX runtime_setting_history_key_changed_at_idx ON runtime_setting_history (key, changed_at DESC);
+
+-- System notifications are shown to all users between start_at and end_at, null end_at means until deleted.
+CREATE TABLE system_notification (
+    id         varchar NOT NULL PRIMARY KEY,
+    message    text    NOT NULL,
+    severity   varchar NOT NULL,
+    start_at   timestamp without time zone NOT NULL,
+    end_at     timestamp without time zone,
+    created_by varchar NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    updated_by varchar,
+    updated_at timestamp without time zone
+);
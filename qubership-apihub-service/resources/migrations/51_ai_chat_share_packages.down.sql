@@ -0,0 +1 @@
+ALTER TABLE ai_chat_share DROP COLUMN IF EXISTS package_ids_complete;
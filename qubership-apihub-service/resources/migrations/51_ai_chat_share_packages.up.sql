@@ -0,0 +1,3 @@
+-- Shares created before the packages from the tool results were recorded reference unknown packages,
+-- so they stay available to their owners only.
+ALTER TABLE ai_chat_share ADD COLUMN package_ids_complete boolean NOT NULL DEFAULT false;
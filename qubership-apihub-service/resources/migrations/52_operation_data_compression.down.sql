@@ -0,0 +1,2 @@
+-- Compressed operation data stays compressed, it is read by the versions of the service with compression support only.
+DROP TABLE IF EXISTS operation_data_compression;
@@ -0,0 +1,9 @@
+-- Progress of the background job which compresses operation data stored before the compression was introduced.
+CREATE TABLE operation_data_compression
+(
+    id             integer PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    last_data_hash varchar NOT NULL DEFAULT '',
+    finished_at    timestamp without time zone
+);
+
+INSERT INTO operation_data_compression (id) VALUES (1);
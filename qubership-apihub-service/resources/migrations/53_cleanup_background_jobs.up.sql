@@ -0,0 +1,33 @@
+-- Cleanup jobs are executed by BackgroundJobService, their state and runs are moved to the background job tables.
+ALTER TABLE background_job_run ADD COLUMN cancel_requested_by VARCHAR;
+
+INSERT INTO background_job_state (job_name, disabled, updated_by, updated_at)
+SELECT CASE job_type
+           WHEN 'revisions' THEN 'revisionsCleanup'
+           WHEN 'comparisons' THEN 'comparisonsCleanup'
+           WHEN 'softDeletedData' THEN 'softDeletedDataCleanup'
+           WHEN 'unreferencedData' THEN 'unreferencedDataCleanup'
+           ELSE job_type
+           END,
+       paused, updated_by, updated_at
+FROM cleanup_job_state
+ON CONFLICT (job_name) DO NOTHING;
+
+INSERT INTO background_job_run (run_id, job_name, instance_id, triggered_by, status, attempts, details, started_at, finished_at, cancel_requested_by)
+SELECT run_id,
+       CASE job_type
+           WHEN 'revisions' THEN 'revisionsCleanup'
+           WHEN 'comparisons' THEN 'comparisonsCleanup'
+           WHEN 'softDeletedData' THEN 'softDeletedDataCleanup'
+           WHEN 'unreferencedData' THEN 'unreferencedDataCleanup'
+           ELSE job_type
+           END,
+       instance_id, NULLIF(triggered_by, ''),
+       CASE status WHEN 'running' THEN 'interrupted' ELSE status END,
+       1, details, started_at, coalesce(finished_at, CASE WHEN status = 'running' THEN now() at time zone 'UTC' END),
+       NULLIF(cancel_requested_by, '')
+FROM cleanup_job_run_info
+ON CONFLICT (run_id) DO NOTHING;
+
+DROP TABLE cleanup_job_run_info;
+DROP TABLE cleanup_job_state;
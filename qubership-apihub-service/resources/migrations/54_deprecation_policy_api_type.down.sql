@@ -0,0 +1,10 @@
+-- keep the most recently updated policy of the operations with the same id
+DELETE FROM operation_deprecation_policy p
+USING operation_deprecation_policy other
+WHERE p.package_id = other.package_id
+  AND p.operation_id = other.operation_id
+  AND p.api_type <> other.api_type
+  AND (p.updated_at, p.api_type) < (other.updated_at, other.api_type);
+
+ALTER TABLE operation_deprecation_policy DROP CONSTRAINT IF EXISTS operation_deprecation_policy_pkey;
+ALTER TABLE operation_deprecation_policy ADD CONSTRAINT operation_deprecation_policy_pkey PRIMARY KEY (package_id, operation_id);
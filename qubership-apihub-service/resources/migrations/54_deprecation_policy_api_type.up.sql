@@ -0,0 +1,3 @@
+-- Operations of different api types may have the same id, so the policy is kept per api type.
+ALTER TABLE operation_deprecation_policy DROP CONSTRAINT IF EXISTS operation_deprecation_policy_pkey;
+ALTER TABLE operation_deprecation_policy ADD CONSTRAINT operation_deprecation_policy_pkey PRIMARY KEY (package_id, api_type, operation_id);
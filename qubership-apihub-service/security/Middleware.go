@@ -5,7 +5,9 @@ import (
 	"net/http"
 	"runtime/debug"
 
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/logging"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
 	"github.com/shaj13/go-guardian/v2/auth"
 	"github.com/shaj13/go-guardian/v2/auth/strategies/union"
@@ -16,7 +18,7 @@ func Secure(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Errorf("Request failed with panic: %v", err)
+				log.WithContext(r.Context()).Errorf("Request failed with panic: %v", err)
 				log.Tracef("Stacktrace: %v", string(debug.Stack()))
 				debug.PrintStack()
 				utils.RespondWithCustomError(w, &exception.CustomError{
@@ -43,7 +45,7 @@ func Secure(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		r = auth.RequestWithUser(user, r)
+		r = requestWithUser(user, r)
 		next.ServeHTTP(w, r)
 	}
 }
@@ -52,7 +54,7 @@ func SecureUser(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Errorf("Request failed with panic: %v", err)
+				log.WithContext(r.Context()).Errorf("Request failed with panic: %v", err)
 				log.Tracef("Stacktrace: %v", string(debug.Stack()))
 				debug.PrintStack()
 				utils.RespondWithCustomError(w, &exception.CustomError{
@@ -69,7 +71,7 @@ func SecureUser(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		r = auth.RequestWithUser(user, r)
+		r = requestWithUser(user, r)
 		next.ServeHTTP(w, r)
 	}
 }
@@ -78,7 +80,7 @@ func SecureJWT(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Errorf("Request failed with panic: %v", err)
+				log.WithContext(r.Context()).Errorf("Request failed with panic: %v", err)
 				log.Tracef("Stacktrace: %v", string(debug.Stack()))
 				debug.PrintStack()
 				utils.RespondWithCustomError(w, &exception.CustomError{
@@ -95,7 +97,7 @@ func SecureJWT(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		r = auth.RequestWithUser(user, r)
+		r = requestWithUser(user, r)
 		next.ServeHTTP(w, r)
 	}
 }
@@ -104,7 +106,7 @@ func NoSecure(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Errorf("Request failed with panic: %v", err)
+				log.WithContext(r.Context()).Errorf("Request failed with panic: %v", err)
 				log.Tracef("Stacktrace: %v", string(debug.Stack()))
 				debug.PrintStack()
 				utils.RespondWithCustomError(w, &exception.CustomError{
@@ -123,7 +125,7 @@ func SecureProxy(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Errorf("Request failed with panic: %v", err)
+				log.WithContext(r.Context()).Errorf("Request failed with panic: %v", err)
 				log.Tracef("Stacktrace: %v", string(debug.Stack()))
 				debug.PrintStack()
 				utils.RespondWithCustomError(w, &exception.CustomError{
@@ -140,7 +142,7 @@ func SecureProxy(next http.HandlerFunc) http.HandlerFunc {
 			respondWithAuthFailedError(w, err)
 			return
 		}
-		r = auth.RequestWithUser(user, r)
+		r = requestWithUser(user, r)
 		//TODO: remove after frontend testing
 		r.Header.Del(CustomJwtAuthHeader)
 
@@ -159,7 +161,7 @@ func RefreshToken(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Errorf("Request failed with panic: %v", err)
+				log.WithContext(r.Context()).Errorf("Request failed with panic: %v", err)
 				log.Tracef("Stacktrace: %v", string(debug.Stack()))
 				debug.PrintStack()
 				utils.RespondWithCustomError(w, &exception.CustomError{
@@ -194,7 +196,7 @@ func SecureMCP(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Errorf("Request failed with panic: %v", err)
+				log.WithContext(r.Context()).Errorf("Request failed with panic: %v", err)
 				log.Tracef("Stacktrace: %v", string(debug.Stack()))
 				debug.PrintStack()
 				utils.RespondWithCustomError(w, &exception.CustomError{
@@ -211,11 +213,20 @@ func SecureMCP(next http.Handler) http.Handler {
 			return
 		}
 
-		r = auth.RequestWithUser(user, r)
+		r = requestWithUser(user, r)
 		next.ServeHTTP(w, r)
 	})
 }
 
+// requestWithUser stores the authenticated user in the request and adds user or API key id to the request log fields
+func requestWithUser(user auth.Info, r *http.Request) *http.Request {
+	logging.AddFields(r.Context(), log.Fields{
+		logging.UserIdField:   user.GetID(),
+		logging.ApiKeyIdField: user.GetExtensions().Get(context.ApikeyIdExt),
+	})
+	return auth.RequestWithUser(user, r)
+}
+
 func respondWithAuthFailedError(w http.ResponseWriter, err error) {
 	log.Tracef("Authentication failed: %+v", err)
 	customErr := &exception.CustomError{
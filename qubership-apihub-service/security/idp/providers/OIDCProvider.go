@@ -26,26 +26,27 @@ import (
 const SSOLoginRefreshPathTemplate = "/api/v1/login/sso/%s"
 
 type oidcProvider struct {
-	config         idp.IDP
-	provider       *oidc.Provider
-	verifier       *oidc.IDTokenVerifier
-	oAuth2Config   oauth2.Config
-	userService    service.UserService
-	allowedHosts   []string
-	apihubHost     string
-	productionMode bool
+	config       idp.IDP
+	provider     *oidc.Provider
+	verifier     *oidc.IDTokenVerifier
+	oAuth2Config oauth2.Config
+	userService  service.UserService
+	// allowedHosts are read on each redirect since they are changed by the runtime settings
+	getAllowedHosts func() []string
+	apihubHost      string
+	productionMode  bool
 }
 
-func newOIDCProvider(config idp.IDP, provider *oidc.Provider, verifier *oidc.IDTokenVerifier, oAuth2Config oauth2.Config, userService service.UserService, allowedHosts []string, apihubHost string, productionMode bool) idp.Provider {
+func newOIDCProvider(config idp.IDP, provider *oidc.Provider, verifier *oidc.IDTokenVerifier, oAuth2Config oauth2.Config, userService service.UserService, getAllowedHosts func() []string, apihubHost string, productionMode bool) idp.Provider {
 	return &oidcProvider{
-		config:         config,
-		provider:       provider,
-		verifier:       verifier,
-		oAuth2Config:   oAuth2Config,
-		userService:    userService,
-		allowedHosts:   allowedHosts,
-		apihubHost:     apihubHost,
-		productionMode: productionMode,
+		config:          config,
+		provider:        provider,
+		verifier:        verifier,
+		oAuth2Config:    oAuth2Config,
+		userService:     userService,
+		getAllowedHosts: getAllowedHosts,
+		apihubHost:      apihubHost,
+		productionMode:  productionMode,
 	}
 }
 
@@ -362,7 +363,7 @@ func (o oidcProvider) downloadAvatar(ctx context.Context, avatarURL string, toke
 		return nil
 	}
 
-	if err := utils.IsHostValid(parsedURL, o.allowedHosts); err != nil {
+	if err := utils.IsHostValid(parsedURL, o.getAllowedHosts()); err != nil {
 		log.Warnf("Avatar URL host not allowed: %s", parsedURL.Hostname())
 		return nil
 	}
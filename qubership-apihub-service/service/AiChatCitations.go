@@ -0,0 +1,103 @@
+package service
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+)
+
+// aiChatPackageDataTools return data of the packages, the packages found in their results restrict access to the chat shares
+var aiChatPackageDataTools = map[string]bool{
+	ToolNameSearchOperations: true,
+	ToolNameGetOperationSpec: true,
+	ToolNameGetOperationDiff: true,
+	ToolNameGetDocument:      true,
+}
+
+// makeAiChatCitation derives a citation from a successful tool call that read a concrete operation or document.
+// Search results are not cited: they are only candidates the model may or may not use for the answer.
+func makeAiChatCitation(toolName string, args map[string]interface{}) *view.AiChatCitation {
+	arg := func(name string) string {
+		v, _ := args[name].(string)
+		return strings.TrimSpace(v)
+	}
+	citation := view.AiChatCitation{
+		PackageId: arg("packageId"),
+		Version:   arg("version"),
+		ApiType:   arg("apiType"),
+	}
+	switch toolName {
+	case ToolNameGetOperationSpec, ToolNameGetOperationDiff:
+		citation.OperationId = arg("operationId")
+		if citation.OperationId == "" {
+			return nil
+		}
+	case ToolNameGetDocument:
+		citation.DocumentSlug = arg("slug")
+		if citation.DocumentSlug == "" {
+			return nil
+		}
+	default:
+		return nil
+	}
+	if citation.PackageId == "" || citation.Version == "" {
+		return nil
+	}
+	return &citation
+}
+
+func collectAiChatCitations(records []toolCallRecord) []view.AiChatCitation {
+	var citations []view.AiChatCitation
+	seen := map[view.AiChatCitation]bool{}
+	for _, rec := range records {
+		if rec.Citation == nil || seen[*rec.Citation] {
+			continue
+		}
+		seen[*rec.Citation] = true
+		citations = append(citations, *rec.Citation)
+	}
+	return citations
+}
+
+// collectAiChatToolResultPackages returns all package ids found in the tool result, nil if the result is not a JSON
+func collectAiChatToolResultPackages(result *mcpgo.CallToolResult) *[]string {
+	set := map[string]bool{}
+	for _, content := range result.Content {
+		textContent, ok := content.(mcpgo.TextContent)
+		if !ok {
+			return nil
+		}
+		var data interface{}
+		if err := json.Unmarshal([]byte(textContent.Text), &data); err != nil {
+			return nil
+		}
+		collectJsonPackageIds(data, set)
+	}
+	packageIds := make([]string, 0, len(set))
+	for packageId := range set {
+		packageIds = append(packageIds, packageId)
+	}
+	sort.Strings(packageIds)
+	return &packageIds
+}
+
+// collectJsonPackageIds collects values of "packageId" and "...PackageId" fields, e.g. previousVersionPackageId
+func collectJsonPackageIds(data interface{}, set map[string]bool) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if packageId, ok := value.(string); ok && packageId != "" && (key == "packageId" || strings.HasSuffix(key, "PackageId")) {
+				set[packageId] = true
+				continue
+			}
+			collectJsonPackageIds(value, set)
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectJsonPackageIds(item, set)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeAiChatCitation(t *testing.T) {
+	tests := []struct {
+		name     string
+		tool     string
+		args     map[string]interface{}
+		expected *view.AiChatCitation
+	}{
+		{
+			name:     "operation specification",
+			tool:     ToolNameGetOperationSpec,
+			args:     map[string]interface{}{"apiType": "rest", "packageId": "PKG", "version": "2026.1", "operationId": "get-items"},
+			expected: &view.AiChatCitation{PackageId: "PKG", Version: "2026.1", ApiType: "rest", OperationId: "get-items"},
+		},
+		{
+			name:     "operation diff",
+			tool:     ToolNameGetOperationDiff,
+			args:     map[string]interface{}{"apiType": "rest", "packageId": "PKG", "version": "2026.2", "previousVersion": "2026.1", "operationId": "get-items"},
+			expected: &view.AiChatCitation{PackageId: "PKG", Version: "2026.2", ApiType: "rest", OperationId: "get-items"},
+		},
+		{
+			name:     "document",
+			tool:     ToolNameGetDocument,
+			args:     map[string]interface{}{"apiType": "asyncapi", "packageId": "PKG", "version": "2026.1", "slug": "events-yaml"},
+			expected: &view.AiChatCitation{PackageId: "PKG", Version: "2026.1", ApiType: "asyncapi", DocumentSlug: "events-yaml"},
+		},
+		{
+			name: "search is not cited",
+			tool: ToolNameSearchOperations,
+			args: map[string]interface{}{"query": "items", "packageId": "PKG", "version": "2026.1"},
+		},
+		{
+			name: "missing operation id",
+			tool: ToolNameGetOperationSpec,
+			args: map[string]interface{}{"apiType": "rest", "packageId": "PKG", "version": "2026.1"},
+		},
+		{
+			name: "missing version",
+			tool: ToolNameGetDocument,
+			args: map[string]interface{}{"apiType": "rest", "packageId": "PKG", "slug": "doc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, makeAiChatCitation(tt.tool, tt.args))
+		})
+	}
+}
+
+func TestCollectAiChatCitations(t *testing.T) {
+	a := &view.AiChatCitation{PackageId: "PKG", Version: "1", ApiType: "rest", OperationId: "a"}
+	b := &view.AiChatCitation{PackageId: "PKG", Version: "1", ApiType: "rest", OperationId: "b"}
+	records := []toolCallRecord{
+		{ToolCallID: "1", Citation: a},
+		{ToolCallID: "2"},
+		{ToolCallID: "3", Citation: b},
+		{ToolCallID: "4", Citation: &view.AiChatCitation{PackageId: "PKG", Version: "1", ApiType: "rest", OperationId: "a"}},
+	}
+	require.Equal(t, []view.AiChatCitation{*a, *b}, collectAiChatCitations(records))
+	require.Nil(t, collectAiChatCitations(nil))
+}
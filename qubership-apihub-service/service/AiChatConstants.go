@@ -16,6 +16,7 @@ const (
 	MaxCompactionMessageRunes      = 4000
 	MaxClarificationLogPreviewRunes = 120
 	MaxGeneratedFilenameRunes      = 200
+	MaxAiChatExportMessages        = 10000
 
 	AiChatToolStatusOK    = "ok"
 	AiChatToolStatusError = "error"
@@ -94,33 +94,34 @@ func (s *aiChatTurnServiceImpl) executeStartIDSGeneration(_ context.Context, arg
 	return mcpgo.NewToolResultText(kit), nil
 }
 
-func (s *aiChatTurnServiceImpl) executeSaveGeneratedFile(ctx context.Context, args map[string]interface{}) (*mcpgo.CallToolResult, error) {
+// Returns the id of the stored file alongside the tool result so that the invocation can reference it.
+func (s *aiChatTurnServiceImpl) executeSaveGeneratedFile(ctx context.Context, args map[string]interface{}) (*mcpgo.CallToolResult, string, error) {
 	if s.generatedFiles == nil || s.mintFileToken == nil {
-		return mcpgo.NewToolResultError("generated file service is not configured"), nil
+		return mcpgo.NewToolResultError("generated file service is not configured"), "", nil
 	}
 	turn, ok := AiChatTurnFromContext(ctx)
 	if !ok || turn.UserID == "" {
-		return mcpgo.NewToolResultError("no user context available for save_generated_file"), nil
+		return mcpgo.NewToolResultError("no user context available for save_generated_file"), "", nil
 	}
 
 	filename, _ := args["filename"].(string)
 	content, _ := args["content"].(string)
 	filename = strings.TrimSpace(filename)
 	if filename == "" {
-		return mcpgo.NewToolResultError("filename is required"), nil
+		return mcpgo.NewToolResultError("filename is required"), "", nil
 	}
 	if utf8.RuneCountInString(filename) > MaxGeneratedFilenameRunes {
-		return mcpgo.NewToolResultError(fmt.Sprintf("filename is too long (max %d characters)", MaxGeneratedFilenameRunes)), nil
+		return mcpgo.NewToolResultError(fmt.Sprintf("filename is too long (max %d characters)", MaxGeneratedFilenameRunes)), "", nil
 	}
 	filename = sanitizeChatToolFilename(filename)
 	if filename == "" {
-		return mcpgo.NewToolResultError("filename must contain ASCII letters/digits"), nil
+		return mcpgo.NewToolResultError("filename must contain ASCII letters/digits"), "", nil
 	}
 	if content == "" {
-		return mcpgo.NewToolResultError("content is required"), nil
+		return mcpgo.NewToolResultError("content is required"), "", nil
 	}
 	if len(content) > maxSavedGeneratedFileSize {
-		return mcpgo.NewToolResultError(fmt.Sprintf("content is too large (%d bytes, max %d)", len(content), maxSavedGeneratedFileSize)), nil
+		return mcpgo.NewToolResultError(fmt.Sprintf("content is too large (%d bytes, max %d)", len(content), maxSavedGeneratedFileSize)), "", nil
 	}
 
 	row, relURL, err := s.generatedFiles.SaveFile(ctx, EphemeralFileSaveInput{
@@ -130,16 +131,16 @@ func (s *aiChatTurnServiceImpl) executeSaveGeneratedFile(ctx context.Context, ar
 		Reader:   strings.NewReader(content),
 	})
 	if err != nil {
-		return mcpgo.NewToolResultError(fmt.Sprintf("failed to save generated file: %v", err)), nil
+		return mcpgo.NewToolResultError(fmt.Sprintf("failed to save generated file: %v", err)), "", nil
 	}
 
 	ttl := time.Until(row.ExpiresAt)
 	if ttl <= 0 {
-		return mcpgo.NewToolResultError("generated file already expired"), nil
+		return mcpgo.NewToolResultError("generated file already expired"), "", nil
 	}
 	tok, err := s.mintFileToken(turn.UserID, row.ID, ttl)
 	if err != nil {
-		return mcpgo.NewToolResultError(fmt.Sprintf("failed to mint download token: %v", err)), nil
+		return mcpgo.NewToolResultError(fmt.Sprintf("failed to mint download token: %v", err)), "", nil
 	}
 
 	link := fmt.Sprintf("[%s](%s?token=%s)", row.Filename, relURL, tok)
@@ -154,9 +155,9 @@ func (s *aiChatTurnServiceImpl) executeSaveGeneratedFile(ctx context.Context, ar
 	}
 	body, err := json.Marshal(payload)
 	if err != nil {
-		return mcpgo.NewToolResultError(fmt.Sprintf("failed to marshal save_generated_file result: %v", err)), nil
+		return mcpgo.NewToolResultError(fmt.Sprintf("failed to marshal save_generated_file result: %v", err)), "", nil
 	}
-	return mcpgo.NewToolResultText(string(body)), nil
+	return mcpgo.NewToolResultText(string(body)), row.ID, nil
 }
 
 func sanitizeChatToolFilename(name string) string {
@@ -0,0 +1,382 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	secctx "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/google/uuid"
+	"github.com/gosimple/slug"
+	log "github.com/sirupsen/logrus"
+)
+
+type AiChatShareService interface {
+	CreateShare(ctx context.Context, userID, chatID string) (*view.AiChatShare, error)
+	ListChatShares(ctx context.Context, userID, chatID string) (*view.AiChatSharesListResponse, error)
+	DeleteShare(ctx context.Context, userID, shareID string) error
+	GetSharedChat(ctx context.Context, secCtx secctx.SecurityContext, shareID string) (*view.AiChatSharedSnapshot, error)
+	GetSharedFile(ctx context.Context, secCtx secctx.SecurityContext, shareID, fileID string) (*entity.AiChatShareFileEntity, error)
+	ExportChat(ctx context.Context, userID, chatID, format string) (*AiChatExportResult, error)
+	ExportSharedChat(ctx context.Context, secCtx secctx.SecurityContext, shareID, format string) (*AiChatExportResult, error)
+}
+
+type AiChatExportResult struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Download links minted for the chat owner carry the owner's token, so they are rewritten to the share endpoint.
+var ephemeralFileLinkRe = regexp.MustCompile(`/api/v1/ephemeral-files/([0-9a-fA-F-]{36})(\?token=[^)\s"]*)?`)
+
+func NewAiChatShareService(repo repository.AiChatRepository, roleService RoleService, generatedFiles EphemeralFileService) AiChatShareService {
+	return &aiChatShareServiceImpl{repo: repo, roleService: roleService, generatedFiles: generatedFiles}
+}
+
+type aiChatShareServiceImpl struct {
+	repo           repository.AiChatRepository
+	roleService    RoleService
+	generatedFiles EphemeralFileService
+}
+
+func (s *aiChatShareServiceImpl) CreateShare(ctx context.Context, userID, chatID string) (*view.AiChatShare, error) {
+	chat, err := mustGetAiChat(ctx, s.repo, userID, chatID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.ListMessagesChronological(ctx, chatID, MaxAiChatExportMessages)
+	if err != nil {
+		return nil, err
+	}
+
+	shareID := uuid.NewString()
+	messages := make([]view.AiChatMessage, 0, len(rows))
+	for i := range rows {
+		msg := entity.MakeAiChatMessageView(&rows[i])
+		msg.ClientMessageID = nil
+		msg.Content = ephemeralFileLinkRe.ReplaceAllString(msg.Content, "/api/v1/ai-chat/shares/"+shareID+"/files/$1")
+		messages = append(messages, *msg)
+	}
+
+	packageIds, packageIdsComplete := collectAiChatReferencedPackages(messages)
+	share := &entity.AiChatShareEntity{
+		ID:                 shareID,
+		ChatID:             chat.ID,
+		UserID:             userID,
+		Title:              chat.Title,
+		Messages:           messages,
+		PackageIDs:         packageIds,
+		PackageIDsComplete: packageIdsComplete,
+		CreatedAt:          time.Now().UTC(),
+	}
+	files := s.copyGeneratedFiles(ctx, userID, shareID, messages)
+	if err := s.repo.CreateShare(ctx, share, files); err != nil {
+		return nil, err
+	}
+	log.WithFields(log.Fields{
+		"chatId":  chatID,
+		"shareId": shareID,
+		"userId":  userID,
+	}).Infof("ai-chat: chat shared with %d message(s), %d file(s) and %d referenced package(s)", len(messages), len(files), len(share.PackageIDs))
+	if !packageIdsComplete {
+		log.WithField("shareId", shareID).Warn("ai-chat: packages referenced by the chat are unknown, the share is available to its owner only")
+	}
+	return entity.MakeAiChatShareView(share), nil
+}
+
+func (s *aiChatShareServiceImpl) ListChatShares(ctx context.Context, userID, chatID string) (*view.AiChatSharesListResponse, error) {
+	if _, err := mustGetAiChat(ctx, s.repo, userID, chatID); err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.ListChatShares(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]view.AiChatShare, 0, len(rows))
+	for i := range rows {
+		out = append(out, *entity.MakeAiChatShareView(&rows[i]))
+	}
+	return &view.AiChatSharesListResponse{Shares: out}, nil
+}
+
+func (s *aiChatShareServiceImpl) DeleteShare(ctx context.Context, userID, shareID string) error {
+	n, err := s.repo.DeleteShare(ctx, shareID, userID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errAiShareNotFound(shareID)
+	}
+	log.WithFields(log.Fields{"shareId": shareID, "userId": userID}).Info("ai-chat: chat share revoked")
+	return nil
+}
+
+func (s *aiChatShareServiceImpl) GetSharedChat(ctx context.Context, secCtx secctx.SecurityContext, shareID string) (*view.AiChatSharedSnapshot, error) {
+	share, err := s.getAccessibleShare(ctx, secCtx, shareID)
+	if err != nil {
+		return nil, err
+	}
+	files, err := s.repo.ListShareFiles(ctx, shareID)
+	if err != nil {
+		return nil, err
+	}
+	fileViews := make([]view.AiChatSharedFile, 0, len(files))
+	for i := range files {
+		fileViews = append(fileViews, entity.MakeAiChatShareFileView(&files[i]))
+	}
+	return &view.AiChatSharedSnapshot{
+		AiChatShare: *entity.MakeAiChatShareView(share),
+		Messages:    share.Messages,
+		Files:       fileViews,
+	}, nil
+}
+
+func (s *aiChatShareServiceImpl) GetSharedFile(ctx context.Context, secCtx secctx.SecurityContext, shareID, fileID string) (*entity.AiChatShareFileEntity, error) {
+	if _, err := s.getAccessibleShare(ctx, secCtx, shareID); err != nil {
+		return nil, err
+	}
+	file, err := s.repo.GetShareFile(ctx, shareID, fileID)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.AiChatShareNotFound,
+			Message: exception.AiChatShareFileNotFoundMsg,
+			Params:  map[string]interface{}{"shareId": shareID, "fileId": fileID},
+		}
+	}
+	return file, nil
+}
+
+func (s *aiChatShareServiceImpl) ExportChat(ctx context.Context, userID, chatID, format string) (*AiChatExportResult, error) {
+	if err := validateAiChatExportFormat(format); err != nil {
+		return nil, err
+	}
+	chat, err := mustGetAiChat(ctx, s.repo, userID, chatID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.ListMessagesChronological(ctx, chatID, MaxAiChatExportMessages)
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]view.AiChatMessage, 0, len(rows))
+	for i := range rows {
+		messages = append(messages, *entity.MakeAiChatMessageView(&rows[i]))
+	}
+	return makeAiChatExport(chat.ID, chat.Title, messages, format)
+}
+
+func (s *aiChatShareServiceImpl) ExportSharedChat(ctx context.Context, secCtx secctx.SecurityContext, shareID, format string) (*AiChatExportResult, error) {
+	if err := validateAiChatExportFormat(format); err != nil {
+		return nil, err
+	}
+	share, err := s.getAccessibleShare(ctx, secCtx, shareID)
+	if err != nil {
+		return nil, err
+	}
+	return makeAiChatExport(share.ChatID, share.Title, share.Messages, format)
+}
+
+// The owner always sees the share; anyone else needs read access to every package the chat referenced,
+// so the share is not available to others if the referenced packages are unknown.
+func (s *aiChatShareServiceImpl) getAccessibleShare(ctx context.Context, secCtx secctx.SecurityContext, shareID string) (*entity.AiChatShareEntity, error) {
+	share, err := s.repo.GetShare(ctx, shareID)
+	if err != nil {
+		return nil, err
+	}
+	if share == nil {
+		return nil, errAiShareNotFound(shareID)
+	}
+	if share.UserID == secCtx.GetUserId() {
+		return share, nil
+	}
+	if !share.PackageIDsComplete {
+		return nil, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.AiChatShareAccessDenied,
+			Message: exception.AiChatShareAccessDeniedMsg,
+			Debug:   "packages referenced by the chat are unknown",
+		}
+	}
+	for _, packageId := range share.PackageIDs {
+		allowed, err := s.roleService.HasRequiredPermissions(secCtx, packageId, view.ReadPermission)
+		if err != nil || !allowed {
+			debug := ""
+			if err != nil {
+				debug = err.Error()
+			}
+			return nil, &exception.CustomError{
+				Status:  http.StatusForbidden,
+				Code:    exception.AiChatShareAccessDenied,
+				Message: exception.AiChatShareAccessDeniedMsg,
+				Debug:   debug,
+			}
+		}
+	}
+	return share, nil
+}
+
+func (s *aiChatShareServiceImpl) copyGeneratedFiles(ctx context.Context, userID, shareID string, messages []view.AiChatMessage) []entity.AiChatShareFileEntity {
+	var files []entity.AiChatShareFileEntity
+	seen := map[string]bool{}
+	for _, msg := range messages {
+		for _, inv := range msg.ToolInvocations {
+			if inv.FileId == "" || seen[inv.FileId] {
+				continue
+			}
+			seen[inv.FileId] = true
+			f, err := s.generatedFiles.GetFileForUser(ctx, inv.FileId, userID)
+			if err != nil {
+				log.WithField("shareId", shareID).Warnf("ai-chat: failed to get generated file %s: %v", inv.FileId, err)
+				continue
+			}
+			if f == nil || f.ExpiresAt.Before(time.Now().UTC()) {
+				log.WithField("shareId", shareID).Debugf("ai-chat: generated file %s is expired and is not included into the share", inv.FileId)
+				continue
+			}
+			data, err := s.generatedFiles.ReadFileData(ctx, f)
+			if err != nil {
+				log.WithField("shareId", shareID).Warnf("ai-chat: failed to read generated file %s: %v", inv.FileId, err)
+				continue
+			}
+			files = append(files, entity.AiChatShareFileEntity{
+				ShareID:   shareID,
+				FileID:    f.ID,
+				Filename:  f.Filename,
+				MimeType:  f.MimeType,
+				SizeBytes: int64(len(data)),
+				Data:      data,
+			})
+		}
+	}
+	return files
+}
+
+// collectAiChatReferencedPackages returns the packages referenced by the messages and false if the results of some tools were not inspected
+func collectAiChatReferencedPackages(messages []view.AiChatMessage) ([]string, bool) {
+	set := map[string]bool{}
+	complete := true
+	for _, msg := range messages {
+		for _, inv := range msg.ToolInvocations {
+			if inv.PackageId != "" {
+				set[inv.PackageId] = true
+			}
+			if inv.ResultPackageIds != nil {
+				for _, packageId := range *inv.ResultPackageIds {
+					set[packageId] = true
+				}
+			} else if inv.Status == AiChatToolStatusOK && aiChatPackageDataTools[inv.Name] {
+				complete = false
+			}
+		}
+		for _, citation := range msg.Citations {
+			set[citation.PackageId] = true
+		}
+	}
+	packageIds := make([]string, 0, len(set))
+	for packageId := range set {
+		packageIds = append(packageIds, packageId)
+	}
+	sort.Strings(packageIds)
+	return packageIds, complete
+}
+
+func validateAiChatExportFormat(format string) error {
+	if format == view.AiChatExportFormatMarkdown || format == view.AiChatExportFormatJson {
+		return nil
+	}
+	return &exception.CustomError{
+		Status:  http.StatusBadRequest,
+		Code:    exception.AiChatExportFormatNotSupported,
+		Message: exception.AiChatExportFormatNotSupportedMsg,
+		Params:  map[string]interface{}{"format": format, "formats": view.AiChatExportFormatMarkdown + ", " + view.AiChatExportFormatJson},
+	}
+}
+
+func makeAiChatExport(chatID, title string, messages []view.AiChatMessage, format string) (*AiChatExportResult, error) {
+	exportedAt := time.Now().UTC()
+	baseName := slug.Make(title)
+	if baseName == "" {
+		baseName = "ai-chat-" + chatID
+	}
+	if format == view.AiChatExportFormatJson {
+		data, err := json.MarshalIndent(view.AiChatExport{
+			ChatID:     chatID,
+			Title:      title,
+			ExportedAt: exportedAt.Format(time.RFC3339),
+			Messages:   messages,
+		}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return &AiChatExportResult{Filename: baseName + ".json", ContentType: "application/json", Data: data}, nil
+	}
+	return &AiChatExportResult{
+		Filename:    baseName + ".md",
+		ContentType: MimeTypeMarkdown,
+		Data:        []byte(renderAiChatMarkdown(title, exportedAt, messages)),
+	}, nil
+}
+
+func renderAiChatMarkdown(title string, exportedAt time.Time, messages []view.AiChatMessage) string {
+	var b strings.Builder
+	if strings.TrimSpace(title) == "" {
+		title = "AI chat"
+	}
+	b.WriteString("# " + title + "\n\n")
+	b.WriteString("_Exported at " + exportedAt.Format(time.RFC3339) + "_\n")
+	for _, msg := range messages {
+		author := "User"
+		if msg.Role == ChatRoleAssistant {
+			author = "Assistant"
+		}
+		b.WriteString("\n---\n\n")
+		b.WriteString(fmt.Sprintf("### %s (%s)\n\n", author, msg.CreatedAt))
+		b.WriteString(strings.TrimSpace(msg.Content))
+		b.WriteString("\n")
+		if len(msg.ToolInvocations) > 0 {
+			tools := make([]string, 0, len(msg.ToolInvocations))
+			for _, inv := range msg.ToolInvocations {
+				tool := "`" + inv.Name + "` " + inv.Status
+				if inv.PackageId != "" {
+					tool += " (" + inv.PackageId + ")"
+				}
+				tools = append(tools, tool)
+			}
+			b.WriteString("\n_Tools used: " + strings.Join(tools, ", ") + "_\n")
+		}
+		if len(msg.Citations) > 0 {
+			b.WriteString("\nSources:\n")
+			for _, citation := range msg.Citations {
+				ref := citation.OperationId
+				if ref == "" {
+					ref = citation.DocumentSlug
+				}
+				b.WriteString(fmt.Sprintf("- %s `%s` (%s %s)\n", citation.ApiType, ref, citation.PackageId, citation.Version))
+			}
+		}
+	}
+	return b.String()
+}
+
+func errAiShareNotFound(shareID string) *exception.CustomError {
+	return &exception.CustomError{
+		Status:  http.StatusNotFound,
+		Code:    exception.AiChatShareNotFound,
+		Message: exception.AiChatShareNotFoundMsg,
+		Params:  map[string]interface{}{"shareId": shareID},
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEphemeralFileLinkRewrite(t *testing.T) {
+	content := "Report: [ops.csv](/api/v1/ephemeral-files/7b6f4f87-4c8f-4d69-a66e-4a3c8a1b2c55?token=eyJhbGciOi.abc_def) done"
+	rewritten := ephemeralFileLinkRe.ReplaceAllString(content, "/api/v1/ai-chat/shares/share-1/files/$1")
+	require.Equal(t, "Report: [ops.csv](/api/v1/ai-chat/shares/share-1/files/7b6f4f87-4c8f-4d69-a66e-4a3c8a1b2c55) done", rewritten)
+}
+
+func TestCollectAiChatReferencedPackages(t *testing.T) {
+	messages := []view.AiChatMessage{
+		{Role: ChatRoleUser, Content: "hi"},
+		{Role: ChatRoleAssistant, ToolInvocations: []view.AiChatToolInvocation{
+			{Name: ToolNameGetOperationSpec, PackageId: "PKG.B"},
+			{Name: ToolNameSearchOperations},
+		}},
+		{Role: ChatRoleAssistant, ToolInvocations: []view.AiChatToolInvocation{
+			{Name: ToolNameGetOperationDiff, PackageId: "PKG.A"},
+			{Name: ToolNameGetOperationSpec, PackageId: "PKG.B"},
+		}, Citations: []view.AiChatCitation{
+			{PackageId: "PKG.C", Version: "1", ApiType: "rest", OperationId: "op"},
+		}},
+	}
+	packageIds, complete := collectAiChatReferencedPackages(messages)
+	require.Equal(t, []string{"PKG.A", "PKG.B", "PKG.C"}, packageIds)
+	require.True(t, complete)
+	packageIds, complete = collectAiChatReferencedPackages(nil)
+	require.Empty(t, packageIds)
+	require.True(t, complete)
+
+	searchResult := &[]string{"PKG.D", "PKG.E"}
+	messages = append(messages, view.AiChatMessage{Role: ChatRoleAssistant, ToolInvocations: []view.AiChatToolInvocation{
+		{Name: ToolNameSearchOperations, Status: AiChatToolStatusOK, ResultPackageIds: searchResult},
+		{Name: toolNameSaveGeneratedFile, Status: AiChatToolStatusOK},
+	}})
+	packageIds, complete = collectAiChatReferencedPackages(messages)
+	require.Equal(t, []string{"PKG.A", "PKG.B", "PKG.C", "PKG.D", "PKG.E"}, packageIds)
+	require.True(t, complete)
+
+	// search results of the messages stored before the packages were recorded are unknown
+	messages = append(messages, view.AiChatMessage{Role: ChatRoleAssistant, ToolInvocations: []view.AiChatToolInvocation{
+		{Name: ToolNameSearchOperations, Status: AiChatToolStatusOK},
+	}})
+	_, complete = collectAiChatReferencedPackages(messages)
+	require.False(t, complete)
+}
+
+func TestCollectAiChatToolResultPackages(t *testing.T) {
+	result := mcpgo.NewToolResultStructuredOnly(map[string]any{"items": []map[string]any{
+		{"packageId": "PKG.B", "operationId": "get-a"},
+		{"packageId": "PKG.A", "previousVersionPackageId": "PKG.C"},
+		{"packageId": "PKG.A"},
+	}})
+	require.Equal(t, []string{"PKG.A", "PKG.B", "PKG.C"}, *collectAiChatToolResultPackages(result))
+	require.Empty(t, *collectAiChatToolResultPackages(mcpgo.NewToolResultStructuredOnly(map[string]any{"items": []any{}})))
+	require.Nil(t, collectAiChatToolResultPackages(mcpgo.NewToolResultText("not a json")))
+}
+
+func TestMakeAiChatExport(t *testing.T) {
+	messages := []view.AiChatMessage{
+		{Role: ChatRoleUser, Content: "List operations", CreatedAt: "2026-04-19T15:44:03Z"},
+		{Role: ChatRoleAssistant, Content: "Here they are", CreatedAt: "2026-04-19T15:44:12Z", ToolInvocations: []view.AiChatToolInvocation{
+			{Name: ToolNameSearchOperations, Status: AiChatToolStatusOK, PackageId: "PKG.A"},
+		}, Citations: []view.AiChatCitation{
+			{PackageId: "PKG.A", Version: "2026.1", ApiType: "rest", OperationId: "get-items"},
+		}},
+	}
+
+	md, err := makeAiChatExport("chat-1", "Paginate REST operations", messages, view.AiChatExportFormatMarkdown)
+	require.NoError(t, err)
+	require.Equal(t, "paginate-rest-operations.md", md.Filename)
+	require.Equal(t, MimeTypeMarkdown, md.ContentType)
+	text := string(md.Data)
+	require.True(t, strings.HasPrefix(text, "# Paginate REST operations\n"))
+	require.Contains(t, text, "### User (2026-04-19T15:44:03Z)\n\nList operations\n")
+	require.Contains(t, text, "### Assistant (2026-04-19T15:44:12Z)\n\nHere they are\n")
+	require.Contains(t, text, "_Tools used: `search_api_operations` ok (PKG.A)_")
+	require.Contains(t, text, "Sources:\n- rest `get-items` (PKG.A 2026.1)\n")
+
+	js, err := makeAiChatExport("chat-1", "", messages, view.AiChatExportFormatJson)
+	require.NoError(t, err)
+	require.Equal(t, "ai-chat-chat-1.json", js.Filename)
+	require.Contains(t, string(js.Data), `"chatId": "chat-1"`)
+
+	require.Error(t, validateAiChatExportFormat("pdf"))
+	require.NoError(t, validateAiChatExportFormat(view.AiChatExportFormatJson))
+}
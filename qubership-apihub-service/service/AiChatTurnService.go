@@ -97,6 +97,7 @@ func truncateRunes(s string, n int) string {
 type toolCallRecord struct {
 	ToolCallID string
 	Inv        view.AiChatToolInvocation
+	Citation   *view.AiChatCitation
 }
 
 type chatTurnResult struct {
@@ -104,6 +105,7 @@ type chatTurnResult struct {
 	Usage            *client.ChatUsage
 	ToolInvocations  []view.AiChatToolInvocation
 	ToolCallRecords  []toolCallRecord
+	Citations        []view.AiChatCitation
 }
 
 type chatStreamHooks struct {
@@ -356,13 +358,17 @@ func (s *aiChatTurnServiceImpl) runLLMTurn(ctx context.Context, userID string, c
 				})
 			},
 			OnToolCompleted: func(rec toolCallRecord) {
-				_ = s.emitStream(ctx, stream, aiChatSSEToolCompleted, map[string]interface{}{
+				evt := map[string]interface{}{
 					aiChatSSEFieldType: aiChatSSEToolCompleted,
 					"toolCallId": rec.ToolCallID,
 					"name":       rec.Inv.Name,
 					"status":     rec.Inv.Status,
 					"durationMs": rec.Inv.DurationMs,
-				})
+				}
+				if rec.Citation != nil {
+					evt["citation"] = rec.Citation
+				}
+				_ = s.emitStream(ctx, stream, aiChatSSEToolCompleted, evt)
 			},
 		}
 	}
@@ -379,6 +385,7 @@ func (s *aiChatTurnServiceImpl) runLLMTurn(ctx context.Context, userID string, c
 		Role:            ChatRoleAssistant,
 		Content:         turn.AssistantContent,
 		ToolInvocations: turn.ToolInvocations,
+		Citations:       turn.Citations,
 		CreatedAt:       createdA,
 	}
 	if err := s.repo.InsertMessage(ctx, assistantEnt); err != nil {
@@ -495,13 +502,13 @@ func (s *aiChatTurnServiceImpl) runToolLoop(ctx context.Context, history []clien
 		totalUsage.TotalTokens += resp.Usage.TotalTokens
 
 		if len(resp.ToolCalls) == 0 {
-			log.Debugf("Tool loop done after %d iteration(s) (streaming=%v)", iteration+1, streaming)
+			log.WithContext(ctx).Debugf("Tool loop done after %d iteration(s) (streaming=%v)", iteration+1, streaming)
 			break
 		}
 
 		// ask_clarification: final text only; do not append assistant tool_calls (dangling call on next turn).
 		if question, isClarification := extractClarificationQuestion(resp.ToolCalls); isClarification {
-			log.Debugf("Model requested clarification: %q", truncateRunes(question, MaxClarificationLogPreviewRunes))
+			log.WithContext(ctx).Debugf("Model requested clarification: %q", truncateRunes(question, MaxClarificationLogPreviewRunes))
 			assistantText.WriteString(question)
 			if streaming && hooks.OnTextDelta != nil {
 				hooks.OnTextDelta(question)
@@ -517,7 +524,7 @@ func (s *aiChatTurnServiceImpl) runToolLoop(ctx context.Context, history []clien
 
 		toolResultStrs, invocations, recs, err := s.executeToolCalls(ctx, resp.ToolCalls)
 		if err != nil {
-			log.Errorf("Tool execution failed: %v", err)
+			log.WithContext(ctx).Errorf("Tool execution failed: %v", err)
 			toolResultStrs = make([]string, len(resp.ToolCalls))
 		}
 		allToolInvocations = append(allToolInvocations, invocations...)
@@ -547,6 +554,7 @@ func (s *aiChatTurnServiceImpl) runToolLoop(ctx context.Context, history []clien
 		AssistantContent: assistantText.String(),
 		ToolInvocations:  allToolInvocations,
 		ToolCallRecords:  allToolCallRecords,
+		Citations:        collectAiChatCitations(allToolCallRecords),
 		Usage:            &usage,
 	}, nil
 }
@@ -579,12 +587,12 @@ func (s *aiChatTurnServiceImpl) executeToolCalls(ctx context.Context, toolCalls
 	records := make([]toolCallRecord, 0, len(toolCalls))
 
 	for i, toolCall := range toolCalls {
-		log.Debugf("Executing tool call: %s with args: %s", toolCall.Name, toolCall.Arguments)
+		log.WithContext(ctx).Debugf("Executing tool call: %s with args: %s", toolCall.Name, toolCall.Arguments)
 		started := time.Now()
 
 		var args map[string]interface{}
 		if err := json.Unmarshal([]byte(toolCall.Arguments), &args); err != nil {
-			log.Errorf("Failed to parse tool arguments: %v", err)
+			log.WithContext(ctx).Errorf("Failed to parse tool arguments: %v", err)
 			results[i] = fmt.Sprintf("Error parsing arguments: %v", err)
 			ms := int(time.Since(started).Milliseconds())
 			inv := view.AiChatToolInvocation{Name: toolCall.Name, Status: AiChatToolStatusError, DurationMs: &ms}
@@ -598,6 +606,7 @@ func (s *aiChatTurnServiceImpl) executeToolCalls(ctx context.Context, toolCalls
 		mcpReq := mcpReqWrapper.ToCallToolRequest()
 
 		var result *mcpgo.CallToolResult
+		var fileID string
 		var err error
 		switch toolCall.Name {
 		case ToolNameSearchOperations:
@@ -611,7 +620,7 @@ func (s *aiChatTurnServiceImpl) executeToolCalls(ctx context.Context, toolCalls
 		case toolNameStartIDSGeneration:
 			result, err = s.executeStartIDSGeneration(ctx, args)
 		case toolNameSaveGeneratedFile:
-			result, err = s.executeSaveGeneratedFile(ctx, args)
+			result, fileID, err = s.executeSaveGeneratedFile(ctx, args)
 		default:
 			results[i] = fmt.Sprintf("Unknown tool: %s", toolCall.Name)
 			ms := int(time.Since(started).Milliseconds())
@@ -623,7 +632,7 @@ func (s *aiChatTurnServiceImpl) executeToolCalls(ctx context.Context, toolCalls
 
 		ms := int(time.Since(started).Milliseconds())
 		if err != nil {
-			log.Errorf("MCP tool execution failed: %v", err)
+			log.WithContext(ctx).Errorf("MCP tool execution failed: %v", err)
 			results[i] = fmt.Sprintf("Error: %v", err)
 			inv := view.AiChatToolInvocation{Name: toolCall.Name, Status: AiChatToolStatusError, DurationMs: &ms}
 			invocations = append(invocations, inv)
@@ -636,9 +645,18 @@ func (s *aiChatTurnServiceImpl) executeToolCalls(ctx context.Context, toolCalls
 			status = AiChatToolStatusError
 		}
 		inv := view.AiChatToolInvocation{Name: toolCall.Name, Status: status, DurationMs: &ms}
+		var citation *view.AiChatCitation
+		if status == AiChatToolStatusOK {
+			inv.PackageId, _ = args["packageId"].(string)
+			inv.FileId = fileID
+			citation = makeAiChatCitation(toolCall.Name, args)
+			if aiChatPackageDataTools[toolCall.Name] && result != nil {
+				inv.ResultPackageIds = collectAiChatToolResultPackages(result)
+			}
+		}
 		if result != nil {
 			invocations = append(invocations, inv)
-			records = append(records, toolCallRecord{ToolCallID: toolCall.ID, Inv: inv})
+			records = append(records, toolCallRecord{ToolCallID: toolCall.ID, Inv: inv, Citation: citation})
 		}
 
 		if result == nil {
@@ -647,7 +665,7 @@ func (s *aiChatTurnServiceImpl) executeToolCalls(ctx context.Context, toolCalls
 
 		var resultLogBytes []byte
 		resultLogBytes, _ = json.Marshal(result.Content)
-		log.Debugf("MCP tool %s returned result (IsError=%v, Content length=%d): %s",
+		log.WithContext(ctx).Debugf("MCP tool %s returned result (IsError=%v, Content length=%d): %s",
 			toolCall.Name, result.IsError, len(result.Content), string(resultLogBytes))
 
 		if result.IsError {
@@ -660,16 +678,16 @@ func (s *aiChatTurnServiceImpl) executeToolCalls(ctx context.Context, toolCalls
 			if contentStr == "" {
 				contentStr = "Unknown error from tool"
 			}
-			log.Warnf("MCP tool returned error: %s", contentStr)
+			log.WithContext(ctx).Warnf("MCP tool returned error: %s", contentStr)
 			results[i] = contentStr
 		} else {
 			resultJSON, err := json.Marshal(result.Content)
 			if err != nil {
-				log.Errorf("Failed to marshal tool result: %v", err)
+				log.WithContext(ctx).Errorf("Failed to marshal tool result: %v", err)
 				results[i] = fmt.Sprintf("Error marshaling result: %v", err)
 			} else {
 				results[i] = string(resultJSON)
-				log.Debugf("Tool %s executed successfully, result length: %d", toolCall.Name, len(results[i]))
+				log.WithContext(ctx).Debugf("Tool %s executed successfully, result length: %d", toolCall.Name, len(results[i]))
 			}
 		}
 	}
@@ -769,16 +787,16 @@ func (s *aiChatTurnServiceImpl) buildSystemMessage(ctx context.Context) string {
 	s.packagesListCache.mu.RUnlock()
 
 	if cachedData != "" && !cacheExpired {
-		log.Debugf("Using cached api-packages-list resource (expires at: %v)", s.packagesListCache.expiresAt)
+		log.WithContext(ctx).Debugf("Using cached api-packages-list resource (expires at: %v)", s.packagesListCache.expiresAt)
 		return systemMessageBaseContent + "\n\nCURRENT WORKSPACE PACKAGES (from api-packages-list resource):\n" + cachedData
 	}
 
-	log.Debugf("Cache expired or empty, fetching fresh api-packages-list resource")
+	log.WithContext(ctx).Debugf("Cache expired or empty, fetching fresh api-packages-list resource")
 	resourceContents, err := s.mcpService.GetPackagesList(ctx, mcpWorkspace)
 	if err != nil {
-		log.Warnf("Failed to read api-packages-list resource: %v", err)
+		log.WithContext(ctx).Warnf("Failed to read api-packages-list resource: %v", err)
 		if cachedData != "" {
-			log.Debugf("Using expired cache as fallback")
+			log.WithContext(ctx).Debugf("Using expired cache as fallback")
 			return systemMessageBaseContent + "\n\nCURRENT WORKSPACE PACKAGES (from api-packages-list resource):\n" + cachedData
 		}
 		return systemMessageBaseContent
@@ -796,7 +814,7 @@ func (s *aiChatTurnServiceImpl) buildSystemMessage(ctx context.Context) string {
 		s.packagesListCache.data = resourceData
 		s.packagesListCache.expiresAt = time.Now().Add(PackagesListCacheTTL)
 		s.packagesListCache.mu.Unlock()
-		log.Debugf("Updated api-packages-list cache (expires at: %v)", s.packagesListCache.expiresAt)
+		log.WithContext(ctx).Debugf("Updated api-packages-list cache (expires at: %v)", s.packagesListCache.expiresAt)
 		return systemMessageBaseContent + "\n\nCURRENT WORKSPACE PACKAGES (from api-packages-list resource):\n" + resourceData
 	}
 	return systemMessageBaseContent
@@ -815,7 +833,7 @@ Capture the main topic or task of the conversation.`
 		CorrelationID: AiChatCorrelationIDFromContext(ctx),
 	})
 	if err != nil || resp == nil {
-		log.Warnf("ai-chat: generateChatTitle LLM call failed: %v", err)
+		log.WithContext(ctx).Warnf("ai-chat: generateChatTitle LLM call failed: %v", err)
 		return ""
 	}
 	title := strings.TrimSpace(resp.AssistantText)
@@ -864,7 +882,7 @@ Return plain text, 4-12 sentences, no markdown headings.`
 		CorrelationID: AiChatCorrelationIDFromContext(ctx),
 	})
 	if err != nil || resp == nil {
-		log.Warnf("ai-chat: summarizeForCompaction failed: %v", err)
+		log.WithContext(ctx).Warnf("ai-chat: summarizeForCompaction failed: %v", err)
 		if prior != nil {
 			return *prior
 		}
@@ -0,0 +1,478 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/client"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/config"
+	secctx "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/metrics"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	AiSpecReviewTimeout          = 30 * time.Minute
+	AiSpecReviewSaveTimeout      = time.Minute
+	AiSpecReviewMaxParallel      = 2
+	MaxAiSpecReviewBatchRunes    = 60000
+	MaxAiSpecReviewMessageRunes  = 1000
+	AiSpecReviewRunesPerToken    = 4
+	AiSpecReviewPromptTokensSlop = 2000
+)
+
+type AiSpecReviewService interface {
+	GetConfig(ctx context.Context, packageId string) (*view.AiSpecReviewConfig, error)
+	UpdateConfig(ctx context.Context, secCtx secctx.SecurityContext, packageId string, req view.AiSpecReviewConfigUpdateReq) (*view.AiSpecReviewConfig, error)
+	GetReview(ctx context.Context, packageId string, version string) (*view.AiSpecReview, error)
+	StartReview(ctx context.Context, packageId string, version string) (*view.AiSpecReview, error)
+	// ListenVersionPublished subscribes the review to 'version published' events.
+	ListenVersionPublished(publishNotificationService PublishNotificationService)
+}
+
+func NewAiSpecReviewService(cfg config.SpecReviewConfig, repo repository.AiSpecReviewRepository, publishedRepo repository.PublishedRepository,
+	packageService PackageService, tenantService TenantService, llm client.LlmClient) AiSpecReviewService {
+	return &aiSpecReviewServiceImpl{
+		cfg:            cfg,
+		repo:           repo,
+		publishedRepo:  publishedRepo,
+		packageService: packageService,
+		tenantService:  tenantService,
+		llm:            llm,
+		slots:          make(chan struct{}, AiSpecReviewMaxParallel),
+	}
+}
+
+type aiSpecReviewServiceImpl struct {
+	cfg            config.SpecReviewConfig
+	repo           repository.AiSpecReviewRepository
+	publishedRepo  repository.PublishedRepository
+	packageService PackageService
+	tenantService  TenantService
+	llm            client.LlmClient
+	slots          chan struct{}
+}
+
+func (s *aiSpecReviewServiceImpl) GetConfig(ctx context.Context, packageId string) (*view.AiSpecReviewConfig, error) {
+	if err := s.checkPackageExistence(packageId); err != nil {
+		return nil, err
+	}
+	ent, err := s.repo.GetConfig(ctx, packageId)
+	if err != nil {
+		return nil, err
+	}
+	return s.makeConfigView(packageId, ent), nil
+}
+
+func (s *aiSpecReviewServiceImpl) UpdateConfig(ctx context.Context, secCtx secctx.SecurityContext, packageId string, req view.AiSpecReviewConfigUpdateReq) (*view.AiSpecReviewConfig, error) {
+	if err := s.checkPackageExistence(packageId); err != nil {
+		return nil, err
+	}
+	ent, err := s.repo.GetConfig(ctx, packageId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		ent = &entity.AiSpecReviewConfigEntity{PackageId: packageId}
+	}
+	if req.Enabled != nil {
+		ent.Enabled = *req.Enabled
+	}
+	if req.TokenBudget != nil {
+		ent.TokenBudget = req.TokenBudget
+	}
+	ent.UpdatedBy = secCtx.GetUserId()
+	ent.UpdatedAt = time.Now()
+	if err = s.repo.SaveConfig(ctx, ent); err != nil {
+		return nil, err
+	}
+	return s.makeConfigView(packageId, ent), nil
+}
+
+func (s *aiSpecReviewServiceImpl) GetReview(ctx context.Context, packageId string, version string) (*view.AiSpecReview, error) {
+	versionEnt, err := s.getVersion(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	review, err := s.repo.GetReview(ctx, versionEnt.PackageId, versionEnt.Version, versionEnt.Revision)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.AiSpecReviewNotFound,
+			Message: exception.AiSpecReviewNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId, "version": version},
+		}
+	}
+	notifications, err := s.repo.GetReviewNotifications(ctx, review.PackageId, review.Version, review.Revision)
+	if err != nil {
+		return nil, err
+	}
+	return entity.MakeAiSpecReviewView(review, notifications), nil
+}
+
+func (s *aiSpecReviewServiceImpl) StartReview(ctx context.Context, packageId string, version string) (*view.AiSpecReview, error) {
+	versionEnt, err := s.getVersion(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := s.repo.GetConfig(ctx, packageId)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil || !cfg.Enabled {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.AiSpecReviewNotEnabled,
+			Message: exception.AiSpecReviewNotEnabledMsg,
+			Params:  map[string]interface{}{"packageId": packageId},
+		}
+	}
+	review, err := s.newReview(ctx, versionEnt, cfg)
+	if err != nil {
+		return nil, err
+	}
+	restarted, err := s.repo.RestartReview(ctx, review, time.Now().Add(-AiSpecReviewTimeout))
+	if err != nil {
+		return nil, err
+	}
+	if !restarted {
+		return nil, &exception.CustomError{
+			Status:  http.StatusConflict,
+			Code:    exception.AiSpecReviewInProgress,
+			Message: exception.AiSpecReviewInProgressMsg,
+			Params:  map[string]interface{}{"packageId": packageId, "version": version},
+		}
+	}
+	utils.SafeAsync(func() {
+		s.runReview(review, versionEnt)
+	})
+	return entity.MakeAiSpecReviewView(review, nil), nil
+}
+
+func (s *aiSpecReviewServiceImpl) ListenVersionPublished(publishNotificationService PublishNotificationService) {
+	utils.SafeAsync(func() {
+		err := publishNotificationService.Subscribe(func(notification view.PublishNotification) {
+			utils.SafeAsync(func() {
+				s.onVersionPublished(notification)
+			})
+		})
+		if err != nil {
+			log.Errorf("ai-spec-review: failed to subscribe to version published events: %v", err)
+			return
+		}
+		log.Info("ai-spec-review: subscribed to version published events")
+	})
+}
+
+func (s *aiSpecReviewServiceImpl) onVersionPublished(notification view.PublishNotification) {
+	ctx := context.Background()
+	cfg, err := s.repo.GetConfig(ctx, notification.PackageId)
+	if err != nil {
+		log.Errorf("ai-spec-review: failed to get config for package %s: %v", notification.PackageId, err)
+		return
+	}
+	if cfg == nil || !cfg.Enabled {
+		return
+	}
+	versionEnt, err := s.getVersion(notification.PackageId, fmt.Sprintf("%s@%d", notification.Version, notification.Revision))
+	if err != nil {
+		log.Errorf("ai-spec-review: failed to get version %s@%d of package %s: %v", notification.Version, notification.Revision, notification.PackageId, err)
+		return
+	}
+	review, err := s.newReview(ctx, versionEnt, cfg)
+	if err != nil {
+		log.Warnf("ai-spec-review: review of %s@%d of package %s is skipped: %v", versionEnt.Version, versionEnt.Revision, versionEnt.PackageId, err)
+		return
+	}
+	// Every instance receives the event, only the one which managed to insert the review row runs it.
+	created, err := s.repo.CreateReview(ctx, review)
+	if err != nil {
+		log.Errorf("ai-spec-review: failed to create review for %s@%d of package %s: %v", review.Version, review.Revision, review.PackageId, err)
+		return
+	}
+	if !created {
+		return
+	}
+	s.runReview(review, versionEnt)
+}
+
+// newReview caps the token budget of the review by the AI tokens quota of the workspace
+func (s *aiSpecReviewServiceImpl) newReview(ctx context.Context, versionEnt *entity.PublishedVersionEntity, cfg *entity.AiSpecReviewConfigEntity) (*entity.AiSpecReviewEntity, error) {
+	budget := s.cfg.TokenBudget
+	if cfg.TokenBudget != nil {
+		budget = *cfg.TokenBudget
+	}
+	tokensLeft, err := s.tenantService.GetAiTokensLeft(ctx, versionEnt.PackageId)
+	if err != nil {
+		return nil, err
+	}
+	if tokensLeft != nil && int64(budget) > *tokensLeft {
+		budget = int(*tokensLeft)
+	}
+	return &entity.AiSpecReviewEntity{
+		PackageId:   versionEnt.PackageId,
+		Version:     versionEnt.Version,
+		Revision:    versionEnt.Revision,
+		Status:      view.AiSpecReviewStatusRunning,
+		TokenBudget: budget,
+		StartedAt:   time.Now(),
+	}, nil
+}
+
+func (s *aiSpecReviewServiceImpl) runReview(review *entity.AiSpecReviewEntity, versionEnt *entity.PublishedVersionEntity) {
+	s.slots <- struct{}{}
+	defer func() { <-s.slots }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), AiSpecReviewTimeout)
+	defer cancel()
+
+	logger := log.WithFields(log.Fields{"packageId": review.PackageId, "version": review.Version, "revision": review.Revision})
+	notifications, err := s.reviewOperations(ctx, review, versionEnt)
+	if err != nil {
+		logger.Errorf("ai-spec-review: review failed: %v", err)
+		review.Status = view.AiSpecReviewStatusError
+		review.Details = err.Error()
+	}
+	finishedAt := time.Now()
+	review.FinishedAt = &finishedAt
+	// the review context may be already expired, the result must be saved anyway
+	saveCtx, saveCancel := context.WithTimeout(context.Background(), AiSpecReviewSaveTimeout)
+	defer saveCancel()
+	if err = s.repo.FinishReview(saveCtx, review, notifications); err != nil {
+		logger.Errorf("ai-spec-review: failed to save review result: %v", err)
+		return
+	}
+	metrics.AiSpecReviewsTotal.WithLabelValues(review.Status).Inc()
+	metrics.AiSpecReviewTokens.Add(float64(review.TokensUsed))
+	logger.Infof("ai-spec-review: review finished with status %s: %d/%d operation(s) reviewed, %d finding(s), %d token(s) used",
+		review.Status, review.OperationsReviewed, review.OperationsTotal, len(notifications), review.TokensUsed)
+}
+
+func (s *aiSpecReviewServiceImpl) reviewOperations(ctx context.Context, review *entity.AiSpecReviewEntity, versionEnt *entity.PublishedVersionEntity) ([]entity.AiSpecReviewNotificationEntity, error) {
+	previousVersion, _, err := SplitVersionRevision(versionEnt.PreviousVersion)
+	if err != nil {
+		return nil, err
+	}
+	previousPackageId := versionEnt.PreviousVersionPackageId
+	if previousPackageId == "" {
+		previousPackageId = versionEnt.PackageId
+	}
+	operations, total, err := s.repo.GetChangedOperations(ctx, review.PackageId, review.Version, review.Revision, previousPackageId, previousVersion, s.cfg.MaxOperations)
+	if err != nil {
+		return nil, err
+	}
+	review.OperationsTotal = total
+	review.Status = view.AiSpecReviewStatusComplete
+	if total > len(operations) {
+		review.Status = view.AiSpecReviewStatusPartial
+		review.Details = fmt.Sprintf("only the first %d of %d changed operations were reviewed", len(operations), total)
+	}
+
+	var notifications []entity.AiSpecReviewNotificationEntity
+	for _, batch := range makeAiSpecReviewBatches(operations, s.cfg.MaxOperationSpecLength) {
+		estimate := utf8.RuneCountInString(batch.prompt)/AiSpecReviewRunesPerToken + AiSpecReviewPromptTokensSlop
+		if review.TokensUsed+estimate > review.TokenBudget {
+			review.Status = view.AiSpecReviewStatusPartial
+			review.Details = fmt.Sprintf("token budget %d is exhausted, %d of %d changed operations were reviewed", review.TokenBudget, review.OperationsReviewed, total)
+			break
+		}
+		resp, err := s.llm.Execute(ctx, client.LLMRequest{
+			SystemMessage: aiSpecReviewSystemPrompt,
+			Messages:      []client.ChatMessage{{Role: ChatRoleUser, Content: batch.prompt}},
+		})
+		if err != nil {
+			return notifications, fmt.Errorf("LLM call failed: %w", err)
+		}
+		review.TokensUsed += resp.Usage.TotalTokens
+		review.OperationsReviewed += len(batch.operations)
+		notifications = append(notifications, parseAiSpecReviewFindings(review, batch.operations, resp.AssistantText)...)
+	}
+	return notifications, nil
+}
+
+const aiSpecReviewSystemPrompt = `You review changes of API specifications published to an API registry.
+For every operation you receive, check:
+- naming: consistent naming of paths, operation ids, parameters and schema properties (same casing style, no abbreviations mixed with full words);
+- description: missing or meaningless summaries/descriptions of operations, parameters and schemas;
+- errorModel: error responses are declared and use one consistent error schema;
+- pagination: list operations accept pagination parameters and return pagination metadata consistently.
+Report only real problems, at most 5 per operation. Do not report anything that is fine.
+Return ONLY JSON without markdown, in the form:
+{"findings":[{"apiType":"<apiType from the input>","operationId":"<operationId from the input>","severity":"error|warning|info","category":"naming|description|errorModel|pagination","message":"<one or two sentences>"}]}
+Return {"findings":[]} if there are no problems.`
+
+// operation ids are unique within the api type only
+type aiSpecReviewOperationKey struct {
+	apiType     string
+	operationId string
+}
+
+type aiSpecReviewBatch struct {
+	operations map[aiSpecReviewOperationKey]entity.AiSpecReviewOperationEntity
+	prompt     string
+}
+
+func makeAiSpecReviewBatches(operations []entity.AiSpecReviewOperationEntity, maxSpecLength int) []aiSpecReviewBatch {
+	var batches []aiSpecReviewBatch
+	var current *aiSpecReviewBatch
+	var b strings.Builder
+	flush := func() {
+		if current != nil {
+			current.prompt = b.String()
+			batches = append(batches, *current)
+			current = nil
+			b.Reset()
+		}
+	}
+	for _, op := range operations {
+		section := fmt.Sprintf("### operationId: %s (apiType: %s, title: %s)\n%s\n\n", op.OperationId, op.ApiType, op.Title, truncateRunes(string(op.Data), maxSpecLength))
+		if current != nil && utf8.RuneCountInString(b.String())+utf8.RuneCountInString(section) > MaxAiSpecReviewBatchRunes {
+			flush()
+		}
+		if current == nil {
+			current = &aiSpecReviewBatch{operations: map[aiSpecReviewOperationKey]entity.AiSpecReviewOperationEntity{}}
+			b.WriteString("Review the following operations:\n\n")
+		}
+		current.operations[aiSpecReviewOperationKey{apiType: op.ApiType, operationId: op.OperationId}] = op
+		b.WriteString(section)
+	}
+	flush()
+	return batches
+}
+
+func parseAiSpecReviewFindings(review *entity.AiSpecReviewEntity, operations map[aiSpecReviewOperationKey]entity.AiSpecReviewOperationEntity, text string) []entity.AiSpecReviewNotificationEntity {
+	text = strings.TrimSpace(text)
+	text = strings.TrimPrefix(text, "```json")
+	text = strings.TrimPrefix(text, "```")
+	text = strings.TrimSuffix(text, "```")
+	var resp struct {
+		Findings []struct {
+			ApiType     string `json:"apiType"`
+			OperationId string `json:"operationId"`
+			Severity    string `json:"severity"`
+			Category    string `json:"category"`
+			Message     string `json:"message"`
+		} `json:"findings"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(text)), &resp); err != nil {
+		log.Warnf("ai-spec-review: failed to parse LLM response for %s@%d of package %s: %v", review.Version, review.Revision, review.PackageId, err)
+		return nil
+	}
+	var result []entity.AiSpecReviewNotificationEntity
+	for _, f := range resp.Findings {
+		op, exists := findAiSpecReviewOperation(operations, f.ApiType, f.OperationId)
+		message := strings.TrimSpace(f.Message)
+		if !exists || message == "" || !isAiSpecReviewCategory(f.Category) {
+			continue
+		}
+		result = append(result, entity.AiSpecReviewNotificationEntity{
+			PackageId:   review.PackageId,
+			Version:     review.Version,
+			Revision:    review.Revision,
+			Severity:    makeAiSpecReviewSeverity(f.Severity),
+			Category:    f.Category,
+			Message:     truncateRunes(message, MaxAiSpecReviewMessageRunes),
+			ApiType:     op.ApiType,
+			OperationId: op.OperationId,
+		})
+	}
+	return result
+}
+
+// findAiSpecReviewOperation finds the operation of the finding, a finding without api type is accepted
+// only if the operation id is unambiguous within the batch
+func findAiSpecReviewOperation(operations map[aiSpecReviewOperationKey]entity.AiSpecReviewOperationEntity, apiType string, operationId string) (entity.AiSpecReviewOperationEntity, bool) {
+	if apiType != "" {
+		op, exists := operations[aiSpecReviewOperationKey{apiType: apiType, operationId: operationId}]
+		return op, exists
+	}
+	var result entity.AiSpecReviewOperationEntity
+	found := 0
+	for key, op := range operations {
+		if key.operationId == operationId {
+			result = op
+			found++
+		}
+	}
+	return result, found == 1
+}
+
+func isAiSpecReviewCategory(category string) bool {
+	switch category {
+	case view.AiSpecReviewCategoryNaming, view.AiSpecReviewCategoryDescription, view.AiSpecReviewCategoryErrorModel, view.AiSpecReviewCategoryPagination:
+		return true
+	}
+	return false
+}
+
+func makeAiSpecReviewSeverity(severity string) int {
+	switch strings.ToLower(severity) {
+	case "error":
+		return view.AiSpecReviewSeverityError
+	case "info":
+		return view.AiSpecReviewSeverityInfo
+	default:
+		return view.AiSpecReviewSeverityWarning
+	}
+}
+
+func (s *aiSpecReviewServiceImpl) makeConfigView(packageId string, ent *entity.AiSpecReviewConfigEntity) *view.AiSpecReviewConfig {
+	result := &view.AiSpecReviewConfig{
+		PackageId:          packageId,
+		TokenBudget:        s.cfg.TokenBudget,
+		DefaultTokenBudget: true,
+	}
+	if ent != nil {
+		result.Enabled = ent.Enabled
+		if ent.TokenBudget != nil {
+			result.TokenBudget = *ent.TokenBudget
+			result.DefaultTokenBudget = false
+		}
+	}
+	return result
+}
+
+func (s *aiSpecReviewServiceImpl) getVersion(packageId string, version string) (*entity.PublishedVersionEntity, error) {
+	versionEnt, err := s.publishedRepo.GetVersion(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	if versionEnt == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PublishedPackageVersionNotFound,
+			Message: exception.PublishedPackageVersionNotFoundMsg,
+			Params:  map[string]interface{}{"version": version, "packageId": packageId},
+		}
+	}
+	return versionEnt, nil
+}
+
+func (s *aiSpecReviewServiceImpl) checkPackageExistence(packageId string) error {
+	exists, err := s.packageService.PackageExists(packageId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PackageNotFound,
+			Message: exception.PackageNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId},
+		}
+	}
+	return nil
+}
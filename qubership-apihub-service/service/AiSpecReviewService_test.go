@@ -0,0 +1,71 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeAiSpecReviewBatches(t *testing.T) {
+	require.Empty(t, makeAiSpecReviewBatches(nil, 100))
+
+	big := strings.Repeat("x", MaxAiSpecReviewBatchRunes/2)
+	operations := []entity.AiSpecReviewOperationEntity{
+		{OperationId: "op-1", ApiType: "rest", Title: "Op 1", Data: []byte(big)},
+		{OperationId: "op-2", ApiType: "rest", Title: "Op 2", Data: []byte(big)},
+		{OperationId: "op-3", ApiType: "graphql", Title: "Op 3", Data: []byte("short")},
+	}
+	batches := makeAiSpecReviewBatches(operations, MaxAiSpecReviewBatchRunes)
+	require.Len(t, batches, 2)
+	require.Len(t, batches[0].operations, 1)
+	require.Contains(t, batches[0].prompt, "### operationId: op-1 (apiType: rest, title: Op 1)")
+	require.Len(t, batches[1].operations, 2)
+	require.Contains(t, batches[1].prompt, "### operationId: op-3 (apiType: graphql, title: Op 3)\nshort")
+
+	truncated := makeAiSpecReviewBatches(operations[:1], 10)
+	require.Len(t, truncated, 1)
+	require.Less(t, len(truncated[0].prompt), 200)
+}
+
+func TestParseAiSpecReviewFindings(t *testing.T) {
+	review := &entity.AiSpecReviewEntity{PackageId: "PKG.A", Version: "2026.1", Revision: 2}
+	operations := map[aiSpecReviewOperationKey]entity.AiSpecReviewOperationEntity{
+		{apiType: "rest", operationId: "get-items"}: {OperationId: "get-items", ApiType: "rest"},
+	}
+	text := "```json\n" + `{"findings":[
+		{"apiType":"rest","operationId":"get-items","severity":"error","category":"errorModel","message":"404 response has no schema"},
+		{"operationId":"get-items","severity":"hint","category":"pagination","message":"No limit parameter"},
+		{"operationId":"unknown","severity":"info","category":"naming","message":"Not from the batch"},
+		{"operationId":"get-items","severity":"info","category":"security","message":"Unsupported category"},
+		{"operationId":"get-items","severity":"info","category":"naming","message":"  "}
+	]}` + "\n```"
+
+	findings := parseAiSpecReviewFindings(review, operations, text)
+	require.Len(t, findings, 2)
+	require.Equal(t, entity.AiSpecReviewNotificationEntity{
+		PackageId:   "PKG.A",
+		Version:     "2026.1",
+		Revision:    2,
+		Severity:    view.AiSpecReviewSeverityError,
+		Category:    view.AiSpecReviewCategoryErrorModel,
+		Message:     "404 response has no schema",
+		ApiType:     "rest",
+		OperationId: "get-items",
+	}, findings[0])
+	require.Equal(t, view.AiSpecReviewSeverityWarning, findings[1].Severity)
+
+	require.Empty(t, parseAiSpecReviewFindings(review, operations, "not a json"))
+
+	// the same operation id in different api types
+	operations[aiSpecReviewOperationKey{apiType: "graphql", operationId: "get-items"}] = entity.AiSpecReviewOperationEntity{OperationId: "get-items", ApiType: "graphql"}
+	findings = parseAiSpecReviewFindings(review, operations, `{"findings":[
+		{"apiType":"graphql","operationId":"get-items","severity":"info","category":"naming","message":"Mixed casing"},
+		{"apiType":"asyncapi","operationId":"get-items","severity":"info","category":"naming","message":"Unknown api type"},
+		{"operationId":"get-items","severity":"info","category":"naming","message":"Ambiguous operation"}
+	]}`)
+	require.Len(t, findings, 1)
+	require.Equal(t, "graphql", findings[0].ApiType)
+}
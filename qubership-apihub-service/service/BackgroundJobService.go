@@ -0,0 +1,647 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/metrics"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/tracing"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	backgroundJobLockPrefix           = "background_job_"
+	backgroundJobLockLeaseSeconds     = 120
+	backgroundJobLockHeartbeatSeconds = 30
+
+	defaultBackgroundJobTimeout     = time.Hour
+	defaultBackgroundJobRetryDelay  = 30 * time.Second
+	backgroundJobUpdateTimeout      = 10 * time.Second
+	backgroundJobCancelPollInterval = 10 * time.Second
+	maxBackgroundJobDetailsLength   = 1000
+
+	// backgroundJobStatusSkipped is reported only to metrics, skipped runs are not stored
+	backgroundJobStatusSkipped = "skipped"
+
+	backgroundJobRunsCleanupJobName  = "backgroundJobRunsCleanup"
+	backgroundJobRunsCleanupSchedule = "30 2 * * *"
+	backgroundJobRunsRetentionDays   = 30
+)
+
+var (
+	errBackgroundJobRunning   = errors.New("background job is running on this or another instance")
+	errBackgroundJobLockLost  = errors.New("background job lock was lost")
+	errBackgroundJobStopped   = errors.New("background jobs are stopped since the instance is shutting down")
+	errBackgroundJobCancelled = errors.New("cancelled by user")
+)
+
+type backgroundJobRunIdKey struct{}
+
+// GetBackgroundJobRunId returns the id of the background job run executing with the ctx, jobs may use it to store their own run details
+func GetBackgroundJobRunId(ctx context.Context) string {
+	runId, _ := ctx.Value(backgroundJobRunIdKey{}).(string)
+	return runId
+}
+
+// IsBackgroundJobCancelled reports whether the run executing with the ctx was cancelled via CancelJob
+func IsBackgroundJobCancelled(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errBackgroundJobCancelled)
+}
+
+// BackgroundJob is a unit of scheduled work executed by BackgroundJobService. Run must honor ctx cancellation.
+type BackgroundJob interface {
+	Run(ctx context.Context) error
+}
+
+type BackgroundJobFunc func(ctx context.Context) error
+
+func (f BackgroundJobFunc) Run(ctx context.Context) error {
+	return f(ctx)
+}
+
+type BackgroundJobDefinition struct {
+	Name        string
+	Description string
+	// Schedule is a cron expression or descriptor (e.g. @every 10m). A job without schedule can only be triggered manually.
+	Schedule string
+	// Distributed job is executed by a single instance of the cluster at a time under a distributed lock.
+	// Jobs which process the data of the instance itself (e.g. in-memory counters) must not be distributed.
+	Distributed bool
+	// LockName is the name of the distributed lock, it defaults to the job name.
+	// Distributed jobs which share the lock name are never executed concurrently across the cluster.
+	LockName string
+	Timeout  time.Duration
+	// MaxRetries is the number of additional attempts made when the job fails. Timed out runs are not retried.
+	MaxRetries int
+	RetryDelay time.Duration
+	Job        BackgroundJob
+}
+
+// BackgroundJobService is a registry of scheduled jobs which takes care of locking, run history, retries and metrics
+type BackgroundJobService interface {
+	RegisterJob(def BackgroundJobDefinition) error
+	GetJobs(ctx context.Context) (*view.BackgroundJobs, error)
+	GetJobRuns(ctx context.Context, req view.BackgroundJobRunsReq) (*view.BackgroundJobRuns, error)
+	// TriggerJob starts the job immediately even if it is disabled. The run is executed asynchronously.
+	TriggerJob(ctx context.Context, jobName string, userId string) (*view.BackgroundJobRun, error)
+	SetJobDisabled(ctx context.Context, jobName string, disabled bool, userId string) (*view.BackgroundJob, error)
+	// CancelJob interrupts the running runs of the job, the runs executed by other instances are cancelled within backgroundJobCancelPollInterval
+	CancelJob(ctx context.Context, jobName string, userId string) (*view.BackgroundJobRun, error)
+}
+
+func NewBackgroundJobService(repo repository.BackgroundJobRepository, lockService LockService, shutdownService ShutdownService, instanceId string) BackgroundJobService {
+	s := &backgroundJobServiceImpl{
+		repo:            repo,
+		lockService:     lockService,
+		shutdownService: shutdownService,
+		instanceId:      instanceId,
+		cron:            cron.New(cron.WithLocation(time.UTC)),
+		jobs:            make(map[string]*registeredBackgroundJob),
+	}
+	s.cron.Start()
+	// runs in progress are tracked by the shutdown service and are either awaited or interrupted
+	context.AfterFunc(shutdownService.StoppingContext(), func() { s.cron.Stop() })
+	err := s.RegisterJob(BackgroundJobDefinition{
+		Name:        backgroundJobRunsCleanupJobName,
+		Description: fmt.Sprintf("Deletes background job runs older than %d days", backgroundJobRunsRetentionDays),
+		Schedule:    backgroundJobRunsCleanupSchedule,
+		Distributed: true,
+		Timeout:     10 * time.Minute,
+		Job:         BackgroundJobFunc(s.deleteOldRuns),
+	})
+	if err != nil {
+		log.Errorf("Failed to register %s job: %v", backgroundJobRunsCleanupJobName, err)
+	}
+	return s
+}
+
+type backgroundJobServiceImpl struct {
+	repo            repository.BackgroundJobRepository
+	lockService     LockService
+	shutdownService ShutdownService
+	instanceId      string
+	cron            *cron.Cron
+
+	mutex sync.RWMutex
+	jobs  map[string]*registeredBackgroundJob
+	// jobNames keeps the registration order for listing
+	jobNames []string
+}
+
+type registeredBackgroundJob struct {
+	def     BackgroundJobDefinition
+	entryId cron.EntryID
+	// running guards against concurrent runs of the job on this instance, the lock does the same across the cluster
+	running atomic.Bool
+	// current is the run executed by this instance
+	current atomic.Pointer[backgroundJobRun]
+}
+
+// backgroundJobRun is a single run of the job which has been stored and holds the locks
+type backgroundJobRun struct {
+	service  *backgroundJobServiceImpl
+	job      *registeredBackgroundJob
+	ent      entity.BackgroundJobRunEntity
+	ctx      context.Context
+	cancel   context.CancelCauseFunc
+	workDone func()
+}
+
+func (s *backgroundJobServiceImpl) RegisterJob(def BackgroundJobDefinition) error {
+	if def.Name == "" || def.Job == nil {
+		return fmt.Errorf("background job name and job must be set")
+	}
+	if def.Timeout <= 0 {
+		def.Timeout = defaultBackgroundJobTimeout
+	}
+	if def.RetryDelay <= 0 {
+		def.RetryDelay = defaultBackgroundJobRetryDelay
+	}
+	if def.LockName == "" {
+		def.LockName = def.Name
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, exists := s.jobs[def.Name]; exists {
+		return fmt.Errorf("background job %s is already registered", def.Name)
+	}
+	job := &registeredBackgroundJob{def: def}
+	if strings.TrimSpace(def.Schedule) != "" {
+		entryId, err := s.cron.AddFunc(def.Schedule, func() { s.runScheduled(job) })
+		if err != nil {
+			return fmt.Errorf("invalid schedule '%s' of background job %s: %w", def.Schedule, def.Name, err)
+		}
+		job.entryId = entryId
+		log.Infof("Background job %s scheduled with %s", def.Name, def.Schedule)
+	} else {
+		log.Infof("Background job %s is registered without schedule, it can only be triggered manually", def.Name)
+	}
+	s.jobs[def.Name] = job
+	s.jobNames = append(s.jobNames, def.Name)
+	return nil
+}
+
+func (s *backgroundJobServiceImpl) getJob(jobName string) (*registeredBackgroundJob, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	job, exists := s.jobs[jobName]
+	if !exists {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.BackgroundJobNotFound,
+			Message: exception.BackgroundJobNotFoundMsg,
+			Params:  map[string]interface{}{"jobName": jobName},
+		}
+	}
+	return job, nil
+}
+
+func (s *backgroundJobServiceImpl) runScheduled(job *registeredBackgroundJob) {
+	if s.isDisabled(job) {
+		return
+	}
+	run, err := s.start(job, "")
+	if err != nil {
+		if errors.Is(err, errBackgroundJobRunning) {
+			metrics.BackgroundJobRuns.WithLabelValues(job.def.Name, backgroundJobStatusSkipped).Inc()
+			log.Debugf("Background job %s was skipped since it is already running", job.def.Name)
+			return
+		}
+		if errors.Is(err, errBackgroundJobStopped) {
+			log.Debugf("Background job %s was skipped: %v", job.def.Name, err)
+			return
+		}
+		log.Errorf("Failed to start background job %s: %v", job.def.Name, err)
+		return
+	}
+	run.execute()
+}
+
+func (s *backgroundJobServiceImpl) isDisabled(job *registeredBackgroundJob) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), backgroundJobUpdateTimeout)
+	defer cancel()
+	state, err := s.repo.GetJobState(ctx, job.def.Name)
+	if err != nil {
+		log.Warnf("Failed to check if background job %s is disabled, the job will run: %v", job.def.Name, err)
+		return false
+	}
+	if state != nil && state.Disabled {
+		log.Debugf("Background job %s was skipped since it is disabled by %s", job.def.Name, state.UpdatedBy)
+		return true
+	}
+	return false
+}
+
+func (s *backgroundJobServiceImpl) start(job *registeredBackgroundJob, triggeredBy string) (*backgroundJobRun, error) {
+	if s.shutdownService.IsShuttingDown() {
+		return nil, errBackgroundJobStopped
+	}
+	if !job.running.CompareAndSwap(false, true) {
+		return nil, errBackgroundJobRunning
+	}
+	def := job.def
+	workDone := s.shutdownService.TrackWork("background job")
+	// the run is cancelled with ErrServiceShuttingDown cause if it is not finished within the shutdown drain timeout
+	ctx, cancel := context.WithCancelCause(s.shutdownService.AbortContext())
+	abort := func(err error) (*backgroundJobRun, error) {
+		cancel(nil)
+		job.running.Store(false)
+		workDone()
+		return nil, err
+	}
+
+	interruptInstanceId := s.instanceId
+	if def.Distributed {
+		acquired, lostCh, err := s.lockService.AcquireLock(ctx, backgroundJobLockPrefix+def.LockName, LockOptions{
+			LeaseSeconds:             backgroundJobLockLeaseSeconds,
+			HeartbeatIntervalSeconds: backgroundJobLockHeartbeatSeconds,
+			NotifyOnLoss:             true,
+		})
+		if err != nil {
+			return abort(err)
+		}
+		if !acquired {
+			return abort(errBackgroundJobRunning)
+		}
+		if lostCh != nil {
+			utils.SafeAsync(func() {
+				ev, ok := <-lostCh
+				if !ok {
+					return
+				}
+				log.Warnf("Lock %s of background job %s was lost: %s", ev.LockName, def.Name, ev.Reason)
+				cancel(errBackgroundJobLockLost)
+			})
+		}
+		// the lock guarantees that no other instance executes the job, so all running runs are left by stopped instances
+		interruptInstanceId = ""
+	}
+
+	if interrupted, err := s.repo.InterruptRunningRuns(ctx, def.Name, interruptInstanceId); err != nil {
+		log.Warnf("Failed to mark previous runs of background job %s as interrupted: %v", def.Name, err)
+	} else if interrupted > 0 {
+		log.Warnf("%d previous runs of background job %s were marked as interrupted", interrupted, def.Name)
+	}
+
+	runId := uuid.NewString()
+	ctx = context.WithValue(ctx, backgroundJobRunIdKey{}, runId)
+	run := &backgroundJobRun{
+		service: s,
+		job:     job,
+		ent: entity.BackgroundJobRunEntity{
+			RunId:       runId,
+			JobName:     def.Name,
+			InstanceId:  s.instanceId,
+			TriggeredBy: triggeredBy,
+			Status:      view.BackgroundJobStatusRunning,
+			Attempts:    1,
+			StartedAt:   time.Now(),
+		},
+		ctx:      ctx,
+		cancel:   cancel,
+		workDone: workDone,
+	}
+	if err := s.repo.StoreRun(ctx, &run.ent); err != nil {
+		if def.Distributed {
+			s.releaseLock(def)
+		}
+		return abort(fmt.Errorf("failed to store background job run: %w", err))
+	}
+	job.current.Store(run)
+	return run, nil
+}
+
+func (s *backgroundJobServiceImpl) releaseLock(def BackgroundJobDefinition) {
+	ctx, cancel := context.WithTimeout(context.Background(), backgroundJobUpdateTimeout)
+	defer cancel()
+	if err := s.lockService.ReleaseLock(ctx, backgroundJobLockPrefix+def.LockName); err != nil {
+		log.Warnf("Failed to release lock of background job %s: %v", def.Name, err)
+	}
+}
+
+func (r *backgroundJobRun) execute() {
+	def := r.job.def
+	defer func() {
+		r.cancel(nil)
+		if def.Distributed {
+			r.service.releaseLock(def)
+		}
+		r.job.current.Store(nil)
+		r.job.running.Store(false)
+		r.workDone()
+	}()
+	metrics.BackgroundJobRunning.WithLabelValues(def.Name).Inc()
+	defer metrics.BackgroundJobRunning.WithLabelValues(def.Name).Dec()
+
+	ctx, cancel := context.WithTimeout(r.ctx, def.Timeout)
+	defer cancel()
+	pollCtx, pollCancel := context.WithCancel(ctx)
+	defer pollCancel()
+	utils.SafeAsync(func() {
+		r.pollCancelRequest(pollCtx)
+	})
+	ctx, span := tracing.StartSpan(ctx, "background_job.run",
+		attribute.String("job.name", def.Name),
+		attribute.String("job.run_id", r.ent.RunId))
+	logEntry := log.WithContext(ctx).WithFields(log.Fields{"job": def.Name, "runId": r.ent.RunId})
+	if r.ent.TriggeredBy != "" {
+		logEntry.Infof("Background job was triggered by %s", r.ent.TriggeredBy)
+	} else {
+		logEntry.Debug("Background job started")
+	}
+
+	err := r.runAttempts(ctx, logEntry)
+	tracing.EndSpan(span, err)
+
+	status := view.BackgroundJobStatusComplete
+	details := ""
+	if err != nil {
+		switch {
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			status = view.BackgroundJobStatusTimeout
+			details = fmt.Sprintf("job timed out after %v: %v", def.Timeout, err)
+		case errors.Is(context.Cause(ctx), ErrServiceShuttingDown):
+			status = view.BackgroundJobStatusInterrupted
+			details = fmt.Sprintf("%v: %v", ErrServiceShuttingDown, err)
+		case errors.Is(context.Cause(ctx), errBackgroundJobCancelled):
+			status = view.BackgroundJobStatusCancelled
+			details = fmt.Sprintf("%v: %v", errBackgroundJobCancelled, err)
+		case errors.Is(context.Cause(ctx), errBackgroundJobLockLost):
+			status = view.BackgroundJobStatusError
+			details = fmt.Sprintf("%v: %v", errBackgroundJobLockLost, err)
+		default:
+			status = view.BackgroundJobStatusError
+			details = err.Error()
+		}
+		if len(details) > maxBackgroundJobDetailsLength {
+			details = details[:maxBackgroundJobDetailsLength]
+		}
+	}
+	duration := time.Since(r.ent.StartedAt)
+	metrics.BackgroundJobRuns.WithLabelValues(def.Name, status).Inc()
+	metrics.BackgroundJobDuration.WithLabelValues(def.Name).Observe(duration.Seconds())
+
+	updateCtx, updateCancel := context.WithTimeout(context.Background(), backgroundJobUpdateTimeout)
+	defer updateCancel()
+	finishedAt := time.Now()
+	if uErr := r.service.repo.UpdateRunStatus(updateCtx, r.ent.RunId, status, details, &finishedAt); uErr != nil {
+		logEntry.Errorf("Failed to update status of background job run: %v", uErr)
+	}
+	if err != nil {
+		logEntry.Errorf("Background job finished with status %s in %v: %s", status, duration, details)
+	} else {
+		logEntry.Debugf("Background job finished in %v", duration)
+	}
+}
+
+// pollCancelRequest cancels the run when cancellation was requested via another instance
+func (r *backgroundJobRun) pollCancelRequest(ctx context.Context) {
+	ticker := time.NewTicker(backgroundJobCancelPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			requested, err := r.service.repo.IsRunCancelRequested(ctx, r.ent.RunId)
+			if err != nil {
+				log.Debugf("Failed to check cancel request of background job %s run %s: %v", r.job.def.Name, r.ent.RunId, err)
+				continue
+			}
+			if requested {
+				log.Infof("Cancel of background job %s run %s was requested", r.job.def.Name, r.ent.RunId)
+				r.cancel(errBackgroundJobCancelled)
+				return
+			}
+		}
+	}
+}
+
+func (r *backgroundJobRun) runAttempts(ctx context.Context, logEntry *log.Entry) error {
+	def := r.job.def
+	for attempt := 1; ; attempt++ {
+		err := runBackgroundJobAttempt(ctx, def.Job)
+		if err == nil || ctx.Err() != nil || attempt > def.MaxRetries {
+			return err
+		}
+		logEntry.Warnf("Attempt %d of background job failed, going to retry in %v: %v", attempt, def.RetryDelay, err)
+		metrics.BackgroundJobRetries.WithLabelValues(def.Name).Inc()
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(def.RetryDelay):
+		}
+		if uErr := r.service.repo.UpdateRunAttempts(ctx, r.ent.RunId, attempt+1); uErr != nil {
+			logEntry.Warnf("Failed to update attempts of background job run: %v", uErr)
+		}
+	}
+}
+
+func runBackgroundJobAttempt(ctx context.Context, job BackgroundJob) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			log.Errorf("Background job failed with panic: %v", rec)
+			log.Tracef("Stacktrace: %v", string(debug.Stack()))
+			err = fmt.Errorf("panic: %v", rec)
+		}
+	}()
+	return job.Run(ctx)
+}
+
+func (s *backgroundJobServiceImpl) deleteOldRuns(ctx context.Context) error {
+	deleted, err := s.repo.DeleteRunsBefore(ctx, time.Now().AddDate(0, 0, -backgroundJobRunsRetentionDays))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Infof("Deleted %d background job runs older than %d days", deleted, backgroundJobRunsRetentionDays)
+	}
+	return nil
+}
+
+func (s *backgroundJobServiceImpl) GetJobs(ctx context.Context) (*view.BackgroundJobs, error) {
+	states, err := s.getJobStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lastRuns, err := s.getLastRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	result := &view.BackgroundJobs{Jobs: make([]view.BackgroundJob, 0, len(s.jobNames))}
+	for _, jobName := range s.jobNames {
+		result.Jobs = append(result.Jobs, s.makeJobView(s.jobs[jobName], states[jobName], lastRuns[jobName]))
+	}
+	return result, nil
+}
+
+func (s *backgroundJobServiceImpl) getJobStates(ctx context.Context) (map[string]entity.BackgroundJobStateEntity, error) {
+	states, err := s.repo.GetJobStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]entity.BackgroundJobStateEntity, len(states))
+	for _, state := range states {
+		result[state.JobName] = state
+	}
+	return result, nil
+}
+
+func (s *backgroundJobServiceImpl) getLastRuns(ctx context.Context) (map[string]*entity.BackgroundJobRunEntity, error) {
+	runs, err := s.repo.GetLastRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]*entity.BackgroundJobRunEntity, len(runs))
+	for i := range runs {
+		result[runs[i].JobName] = &runs[i]
+	}
+	return result, nil
+}
+
+func (s *backgroundJobServiceImpl) makeJobView(job *registeredBackgroundJob, state entity.BackgroundJobStateEntity, lastRun *entity.BackgroundJobRunEntity) view.BackgroundJob {
+	def := job.def
+	result := view.BackgroundJob{
+		Name:           def.Name,
+		Description:    def.Description,
+		Schedule:       def.Schedule,
+		Distributed:    def.Distributed,
+		TimeoutSeconds: int(def.Timeout.Seconds()),
+		MaxRetries:     def.MaxRetries,
+		Disabled:       state.Disabled,
+	}
+	if state.Disabled {
+		result.DisabledBy = state.UpdatedBy
+		disabledAt := state.UpdatedAt
+		result.DisabledAt = &disabledAt
+	} else if job.entryId != 0 {
+		if entry := s.cron.Entry(job.entryId); entry.Valid() {
+			nextRunAt := entry.Next
+			result.NextRunAt = &nextRunAt
+		}
+	}
+	if lastRun != nil {
+		lastRunView := entity.MakeBackgroundJobRunView(*lastRun)
+		result.LastRun = &lastRunView
+	}
+	return result
+}
+
+func (s *backgroundJobServiceImpl) GetJobRuns(ctx context.Context, req view.BackgroundJobRunsReq) (*view.BackgroundJobRuns, error) {
+	ents, err := s.repo.GetJobRuns(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.BackgroundJobRuns{Runs: make([]view.BackgroundJobRun, 0, len(ents))}
+	for _, ent := range ents {
+		result.Runs = append(result.Runs, entity.MakeBackgroundJobRunView(ent))
+	}
+	return result, nil
+}
+
+func (s *backgroundJobServiceImpl) TriggerJob(ctx context.Context, jobName string, userId string) (*view.BackgroundJobRun, error) {
+	job, err := s.getJob(jobName)
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.start(job, userId)
+	if err != nil {
+		if errors.Is(err, errBackgroundJobRunning) {
+			return nil, &exception.CustomError{
+				Status:  http.StatusConflict,
+				Code:    exception.BackgroundJobAlreadyRunning,
+				Message: exception.BackgroundJobAlreadyRunningMsg,
+				Params:  map[string]interface{}{"jobName": jobName},
+			}
+		}
+		if errors.Is(err, errBackgroundJobStopped) {
+			return nil, &exception.CustomError{
+				Status:  http.StatusServiceUnavailable,
+				Code:    exception.ServiceShuttingDown,
+				Message: exception.ServiceShuttingDownMsg,
+			}
+		}
+		return nil, err
+	}
+	// the view is made before the run is started since the run updates its entity
+	result := entity.MakeBackgroundJobRunView(run.ent)
+	utils.SafeAsync(run.execute)
+	log.WithContext(ctx).Infof("Background job %s was triggered by %s, run id %s", jobName, userId, result.RunId)
+	return &result, nil
+}
+
+func (s *backgroundJobServiceImpl) SetJobDisabled(ctx context.Context, jobName string, disabled bool, userId string) (*view.BackgroundJob, error) {
+	job, err := s.getJob(jobName)
+	if err != nil {
+		return nil, err
+	}
+	state := entity.BackgroundJobStateEntity{
+		JobName:   jobName,
+		Disabled:  disabled,
+		UpdatedBy: userId,
+		UpdatedAt: time.Now(),
+	}
+	if err = s.repo.SaveJobState(ctx, &state); err != nil {
+		return nil, err
+	}
+	lastRuns, err := s.getLastRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if disabled {
+		log.WithContext(ctx).Infof("Background job %s was disabled by %s", jobName, userId)
+	} else {
+		log.WithContext(ctx).Infof("Background job %s was enabled by %s", jobName, userId)
+	}
+	result := s.makeJobView(job, state, lastRuns[jobName])
+	return &result, nil
+}
+
+func (s *backgroundJobServiceImpl) CancelJob(ctx context.Context, jobName string, userId string) (*view.BackgroundJobRun, error) {
+	job, err := s.getJob(jobName)
+	if err != nil {
+		return nil, err
+	}
+	// the request is stored for the runs executed by other instances, they check it periodically
+	runs, err := s.repo.RequestRunCancel(ctx, jobName, userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.BackgroundJobNotRunning,
+			Message: exception.BackgroundJobNotRunningMsg,
+			Params:  map[string]interface{}{"jobName": jobName},
+		}
+	}
+	if run := job.current.Load(); run != nil {
+		run.cancel(errBackgroundJobCancelled)
+	}
+	latest := runs[0]
+	for _, run := range runs[1:] {
+		if run.StartedAt.After(latest.StartedAt) {
+			latest = run
+		}
+	}
+	log.WithContext(ctx).Infof("Cancel of background job %s was requested by %s, %d runs are cancelled", jobName, userId, len(runs))
+	result := entity.MakeBackgroundJobRunView(latest)
+	return &result, nil
+}
@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/config"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackgroundJobRetries(t *testing.T) {
+	repo := newTestBackgroundJobRepository()
+	s := newTestBackgroundJobService(repo, &testLockService{})
+
+	attempts := 0
+	job := s.addTestJob(t, BackgroundJobDefinition{
+		Name:       "flaky",
+		MaxRetries: 2,
+		RetryDelay: time.Millisecond,
+		Job: BackgroundJobFunc(func(ctx context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("temporary failure")
+			}
+			return nil
+		}),
+	})
+	s.runScheduled(job)
+	run := repo.lastRun("flaky")
+	require.Equal(t, view.BackgroundJobStatusComplete, run.Status)
+	require.Equal(t, 3, run.Attempts)
+
+	attempts = -10
+	s.runScheduled(job)
+	run = repo.lastRun("flaky")
+	require.Equal(t, view.BackgroundJobStatusError, run.Status)
+	require.Equal(t, 3, run.Attempts)
+	require.Equal(t, "temporary failure", run.Details)
+}
+
+func TestBackgroundJobPanicAndTimeout(t *testing.T) {
+	repo := newTestBackgroundJobRepository()
+	s := newTestBackgroundJobService(repo, &testLockService{})
+
+	panicking := s.addTestJob(t, BackgroundJobDefinition{
+		Name: "panicking",
+		Job:  BackgroundJobFunc(func(ctx context.Context) error { panic("boom") }),
+	})
+	s.runScheduled(panicking)
+	require.Equal(t, view.BackgroundJobStatusError, repo.lastRun("panicking").Status)
+	require.Equal(t, "panic: boom", repo.lastRun("panicking").Details)
+
+	slow := s.addTestJob(t, BackgroundJobDefinition{
+		Name:       "slow",
+		Timeout:    10 * time.Millisecond,
+		MaxRetries: 3,
+		Job: BackgroundJobFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+	})
+	s.runScheduled(slow)
+	run := repo.lastRun("slow")
+	require.Equal(t, view.BackgroundJobStatusTimeout, run.Status)
+	require.Equal(t, 1, run.Attempts, "timed out run must not be retried")
+}
+
+func TestBackgroundJobSkippedRuns(t *testing.T) {
+	repo := newTestBackgroundJobRepository()
+	lockService := &testLockService{}
+	s := newTestBackgroundJobService(repo, lockService)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s.addTestJob(t, BackgroundJobDefinition{
+		Name: "local",
+		Job: BackgroundJobFunc(func(ctx context.Context) error {
+			close(started)
+			<-release
+			return nil
+		}),
+	})
+	run, err := s.TriggerJob(context.Background(), "local", "admin")
+	require.NoError(t, err)
+	require.Equal(t, "admin", run.TriggeredBy)
+	<-started
+	_, err = s.TriggerJob(context.Background(), "local", "admin")
+	var customErr *exception.CustomError
+	require.ErrorAs(t, err, &customErr)
+	require.Equal(t, exception.BackgroundJobAlreadyRunning, customErr.Code)
+	close(release)
+
+	_, err = s.TriggerJob(context.Background(), "unknown", "admin")
+	require.ErrorAs(t, err, &customErr)
+	require.Equal(t, exception.BackgroundJobNotFound, customErr.Code)
+
+	executed := false
+	distributed := s.addTestJob(t, BackgroundJobDefinition{
+		Name:        "distributed",
+		Distributed: true,
+		Job: BackgroundJobFunc(func(ctx context.Context) error {
+			executed = true
+			return nil
+		}),
+	})
+	lockService.busy = true
+	s.runScheduled(distributed)
+	require.False(t, executed, "job must not run while the lock is held by another instance")
+	require.Nil(t, repo.lastRun("distributed"))
+
+	lockService.busy = false
+	_, err = s.SetJobDisabled(context.Background(), "distributed", true, "admin")
+	require.NoError(t, err)
+	s.runScheduled(distributed)
+	require.False(t, executed, "disabled job must not run by schedule")
+
+	_, err = s.SetJobDisabled(context.Background(), "distributed", false, "admin")
+	require.NoError(t, err)
+	s.runScheduled(distributed)
+	require.True(t, executed)
+	require.Equal(t, []string{backgroundJobLockPrefix + "distributed"}, lockService.released)
+
+	shared := s.addTestJob(t, BackgroundJobDefinition{
+		Name:        "shared",
+		Distributed: true,
+		LockName:    "cleanup",
+		Job:         BackgroundJobFunc(func(ctx context.Context) error { return nil }),
+	})
+	s.runScheduled(shared)
+	require.Equal(t, backgroundJobLockPrefix+"cleanup", lockService.released[len(lockService.released)-1])
+}
+
+func TestBackgroundJobCancel(t *testing.T) {
+	repo := newTestBackgroundJobRepository()
+	s := newTestBackgroundJobService(repo, &testLockService{})
+
+	started := make(chan string)
+	cancelled := make(chan bool, 1)
+	s.addTestJob(t, BackgroundJobDefinition{
+		Name:       "endless",
+		MaxRetries: 3,
+		Job: BackgroundJobFunc(func(ctx context.Context) error {
+			started <- GetBackgroundJobRunId(ctx)
+			<-ctx.Done()
+			cancelled <- IsBackgroundJobCancelled(ctx)
+			return ctx.Err()
+		}),
+	})
+	triggered, err := s.TriggerJob(context.Background(), "endless", "admin")
+	require.NoError(t, err)
+	require.Equal(t, triggered.RunId, <-started)
+
+	run, err := s.CancelJob(context.Background(), "endless", "admin")
+	require.NoError(t, err)
+	require.Equal(t, triggered.RunId, run.RunId)
+	require.Equal(t, "admin", run.CancelRequestedBy)
+	require.True(t, <-cancelled)
+	require.Eventually(t, func() bool {
+		return repo.lastRun("endless").Status == view.BackgroundJobStatusCancelled
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, 1, repo.lastRun("endless").Attempts, "cancelled run must not be retried")
+
+	_, err = s.CancelJob(context.Background(), "endless", "admin")
+	var customErr *exception.CustomError
+	require.ErrorAs(t, err, &customErr)
+	require.Equal(t, exception.BackgroundJobNotRunning, customErr.Code)
+}
+
+func TestBackgroundJobShutdown(t *testing.T) {
+	repo := newTestBackgroundJobRepository()
+	s := newTestBackgroundJobService(repo, &testLockService{})
+
+	started := make(chan struct{})
+	s.addTestJob(t, BackgroundJobDefinition{
+		Name: "endless",
+		Job: BackgroundJobFunc(func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+	})
+	_, err := s.TriggerJob(context.Background(), "endless", "admin")
+	require.NoError(t, err)
+	<-started
+
+	require.NoError(t, s.shutdownService.Shutdown(nil))
+	run := repo.lastRun("endless")
+	require.Equal(t, view.BackgroundJobStatusInterrupted, run.Status)
+	require.NotNil(t, run.FinishedAt)
+
+	_, err = s.TriggerJob(context.Background(), "endless", "admin")
+	var customErr *exception.CustomError
+	require.ErrorAs(t, err, &customErr)
+	require.Equal(t, exception.ServiceShuttingDown, customErr.Code)
+}
+
+func newTestBackgroundJobService(repo *testBackgroundJobRepository, lockService LockService) *backgroundJobServiceImpl {
+	return NewBackgroundJobService(repo, lockService, NewShutdownService(config.ShutdownConfig{DrainTimeoutSec: 1, HooksTimeoutSec: 1}), "instance-1").(*backgroundJobServiceImpl)
+}
+
+func (s *backgroundJobServiceImpl) addTestJob(t *testing.T, def BackgroundJobDefinition) *registeredBackgroundJob {
+	require.NoError(t, s.RegisterJob(def))
+	job, err := s.getJob(def.Name)
+	require.NoError(t, err)
+	return job
+}
+
+type testLockService struct {
+	busy     bool
+	released []string
+}
+
+func (l *testLockService) AcquireLock(ctx context.Context, lockName string, options LockOptions) (bool, <-chan LockLostEvent, error) {
+	return !l.busy, nil, nil
+}
+
+func (l *testLockService) ReleaseLock(ctx context.Context, lockName string) error {
+	l.released = append(l.released, lockName)
+	return nil
+}
+
+func (l *testLockService) ReleaseAllLocks(ctx context.Context) error {
+	return nil
+}
+
+type testBackgroundJobRepository struct {
+	mutex  sync.Mutex
+	states map[string]entity.BackgroundJobStateEntity
+	runs   []*entity.BackgroundJobRunEntity
+}
+
+func newTestBackgroundJobRepository() *testBackgroundJobRepository {
+	return &testBackgroundJobRepository{states: make(map[string]entity.BackgroundJobStateEntity)}
+}
+
+func (r *testBackgroundJobRepository) lastRun(jobName string) *entity.BackgroundJobRunEntity {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for i := len(r.runs) - 1; i >= 0; i-- {
+		if r.runs[i].JobName == jobName {
+			run := *r.runs[i]
+			return &run
+		}
+	}
+	return nil
+}
+
+func (r *testBackgroundJobRepository) findRun(runId string) *entity.BackgroundJobRunEntity {
+	for _, run := range r.runs {
+		if run.RunId == runId {
+			return run
+		}
+	}
+	return &entity.BackgroundJobRunEntity{}
+}
+
+func (r *testBackgroundJobRepository) GetJobStates(ctx context.Context) ([]entity.BackgroundJobStateEntity, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	result := make([]entity.BackgroundJobStateEntity, 0, len(r.states))
+	for _, state := range r.states {
+		result = append(result, state)
+	}
+	return result, nil
+}
+
+func (r *testBackgroundJobRepository) GetJobState(ctx context.Context, jobName string) (*entity.BackgroundJobStateEntity, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	state, exists := r.states[jobName]
+	if !exists {
+		return nil, nil
+	}
+	return &state, nil
+}
+
+func (r *testBackgroundJobRepository) SaveJobState(ctx context.Context, ent *entity.BackgroundJobStateEntity) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.states[ent.JobName] = *ent
+	return nil
+}
+
+func (r *testBackgroundJobRepository) StoreRun(ctx context.Context, ent *entity.BackgroundJobRunEntity) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	run := *ent
+	r.runs = append(r.runs, &run)
+	return nil
+}
+
+func (r *testBackgroundJobRepository) UpdateRunAttempts(ctx context.Context, runId string, attempts int) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.findRun(runId).Attempts = attempts
+	return nil
+}
+
+func (r *testBackgroundJobRepository) UpdateRunStatus(ctx context.Context, runId string, status string, details string, finishedAt *time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	run := r.findRun(runId)
+	run.Status = status
+	run.Details = details
+	run.FinishedAt = finishedAt
+	return nil
+}
+
+func (r *testBackgroundJobRepository) InterruptRunningRuns(ctx context.Context, jobName string, instanceId string) (int, error) {
+	return 0, nil
+}
+
+func (r *testBackgroundJobRepository) RequestRunCancel(ctx context.Context, jobName string, userId string) ([]entity.BackgroundJobRunEntity, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var result []entity.BackgroundJobRunEntity
+	for _, run := range r.runs {
+		if run.JobName == jobName && run.Status == view.BackgroundJobStatusRunning {
+			run.CancelRequestedBy = userId
+			result = append(result, *run)
+		}
+	}
+	return result, nil
+}
+
+func (r *testBackgroundJobRepository) IsRunCancelRequested(ctx context.Context, runId string) (bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.findRun(runId).CancelRequestedBy != "", nil
+}
+
+func (r *testBackgroundJobRepository) GetLastRuns(ctx context.Context) ([]entity.BackgroundJobRunEntity, error) {
+	return nil, nil
+}
+
+func (r *testBackgroundJobRepository) GetJobRuns(ctx context.Context, req view.BackgroundJobRunsReq) ([]entity.BackgroundJobRunEntity, error) {
+	return nil, nil
+}
+
+func (r *testBackgroundJobRepository) DeleteRunsBefore(ctx context.Context, before time.Time) (int, error) {
+	return 0, nil
+}
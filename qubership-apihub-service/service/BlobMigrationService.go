@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service/blobstore"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	blobMigrationBatchSize     = 100
+	blobMigrationMaxFailedKeys = 100
+	// a running migration which was not active for this period is considered interrupted (e.g. the instance was restarted) and could be resumed
+	blobMigrationStaleTimeout = 10 * time.Minute
+)
+
+var errBlobMigrationCancelled = errors.New("cancelled by user")
+
+// BlobMigrationService moves blobs between blob storage backends without downtime.
+// The migration processes keys of each namespace in ascending order and saves the last processed key after each batch,
+// so an interrupted migration is resumed from the place where it stopped. Every copied blob is verified by SHA-256 hash.
+type BlobMigrationService interface {
+	StartMigration(ctx context.Context, req view.BlobMigrationReq, userId string) (*view.BlobMigration, error)
+	ResumeMigration(ctx context.Context, runId string) (*view.BlobMigration, error)
+	CancelMigration(ctx context.Context, runId string, userId string) error
+	GetMigration(ctx context.Context, runId string) (*view.BlobMigration, error)
+	GetMigrations(ctx context.Context, limit int, page int) (*view.BlobMigrations, error)
+}
+
+func NewBlobMigrationService(repo repository.BlobMigrationRepository, blobStorageService BlobStorageService, systemInfoService SystemInfoService) BlobMigrationService {
+	return &blobMigrationServiceImpl{
+		repo:               repo,
+		blobStorageService: blobStorageService,
+		systemInfoService:  systemInfoService,
+		cancelFuncs:        make(map[string]context.CancelFunc),
+	}
+}
+
+type blobMigrationServiceImpl struct {
+	repo               repository.BlobMigrationRepository
+	blobStorageService BlobStorageService
+	systemInfoService  SystemInfoService
+
+	mutex       sync.Mutex
+	cancelFuncs map[string]context.CancelFunc
+}
+
+func (b *blobMigrationServiceImpl) StartMigration(ctx context.Context, req view.BlobMigrationReq, userId string) (*view.BlobMigration, error) {
+	namespaces := req.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = view.MigratableBlobNamespaces
+	}
+	if err := b.validateMigration(req.SourceBackend, req.TargetBackend, namespaces, req.DeleteSource); err != nil {
+		return nil, err
+	}
+	progress := make([]view.BlobMigrationNamespaceProgress, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		progress = append(progress, view.BlobMigrationNamespaceProgress{Namespace: namespace})
+	}
+	now := time.Now()
+	ent := entity.BlobMigrationRunEntity{
+		RunId:         uuid.New().String(),
+		SourceBackend: req.SourceBackend,
+		TargetBackend: req.TargetBackend,
+		Namespaces:    namespaces,
+		DeleteSource:  req.DeleteSource,
+		Status:        view.BlobMigrationStatusRunning,
+		Progress:      progress,
+		InstanceId:    b.systemInfoService.GetInstanceId(),
+		CreatedBy:     userId,
+		CreatedAt:     now,
+		LastActive:    now,
+	}
+	created, err := b.repo.CreateRun(ctx, &ent)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, &exception.CustomError{
+			Status:  http.StatusConflict,
+			Code:    exception.BlobMigrationAlreadyRunning,
+			Message: exception.BlobMigrationAlreadyRunningMsg,
+		}
+	}
+	utils.SafeAsync(func() {
+		b.runMigration(ent)
+	})
+	result := entity.MakeBlobMigrationView(ent)
+	return &result, nil
+}
+
+func (b *blobMigrationServiceImpl) ResumeMigration(ctx context.Context, runId string) (*view.BlobMigration, error) {
+	ent, err := b.getRun(ctx, runId)
+	if err != nil {
+		return nil, err
+	}
+	if ent.Status == view.BlobMigrationStatusComplete {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BlobMigrationNotResumable,
+			Message: exception.BlobMigrationNotResumableMsg,
+			Params:  map[string]interface{}{"runId": runId, "status": ent.Status},
+		}
+	}
+	// configuration could be changed since the migration was started
+	if err = b.validateMigration(ent.SourceBackend, ent.TargetBackend, ent.Namespaces, ent.DeleteSource); err != nil {
+		return nil, err
+	}
+	claimed, err := b.repo.ClaimRun(ctx, runId, b.systemInfoService.GetInstanceId(),
+		[]string{view.BlobMigrationStatusRunning, view.BlobMigrationStatusError, view.BlobMigrationStatusCancelled},
+		time.Now().Add(-blobMigrationStaleTimeout))
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, &exception.CustomError{
+			Status:  http.StatusConflict,
+			Code:    exception.BlobMigrationAlreadyRunning,
+			Message: exception.BlobMigrationAlreadyRunningMsg,
+		}
+	}
+	if ent, err = b.getRun(ctx, runId); err != nil {
+		return nil, err
+	}
+	resumed := *ent
+	utils.SafeAsync(func() {
+		b.runMigration(resumed)
+	})
+	result := entity.MakeBlobMigrationView(*ent)
+	return &result, nil
+}
+
+func (b *blobMigrationServiceImpl) CancelMigration(ctx context.Context, runId string, userId string) error {
+	requested, err := b.repo.RequestCancel(ctx, runId, userId)
+	if err != nil {
+		return err
+	}
+	if !requested {
+		if _, err = b.getRun(ctx, runId); err != nil {
+			return err
+		}
+		return &exception.CustomError{
+			Status:  http.StatusConflict,
+			Code:    exception.BlobMigrationNotRunning,
+			Message: exception.BlobMigrationNotRunningMsg,
+			Params:  map[string]interface{}{"runId": runId},
+		}
+	}
+	// the migration running on another instance will notice the cancel request before the next batch
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if cancel, exists := b.cancelFuncs[runId]; exists {
+		cancel()
+	}
+	return nil
+}
+
+func (b *blobMigrationServiceImpl) GetMigration(ctx context.Context, runId string) (*view.BlobMigration, error) {
+	ent, err := b.getRun(ctx, runId)
+	if err != nil {
+		return nil, err
+	}
+	result := entity.MakeBlobMigrationView(*ent)
+	return &result, nil
+}
+
+func (b *blobMigrationServiceImpl) GetMigrations(ctx context.Context, limit int, page int) (*view.BlobMigrations, error) {
+	ents, err := b.repo.GetRuns(ctx, limit, page)
+	if err != nil {
+		return nil, err
+	}
+	result := view.BlobMigrations{Migrations: make([]view.BlobMigration, 0, len(ents))}
+	for _, ent := range ents {
+		result.Migrations = append(result.Migrations, entity.MakeBlobMigrationView(ent))
+	}
+	return &result, nil
+}
+
+func (b *blobMigrationServiceImpl) getRun(ctx context.Context, runId string) (*entity.BlobMigrationRunEntity, error) {
+	ent, err := b.repo.GetRun(ctx, runId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.BlobMigrationNotFound,
+			Message: exception.BlobMigrationNotFoundMsg,
+			Params:  map[string]interface{}{"runId": runId},
+		}
+	}
+	return ent, nil
+}
+
+func (b *blobMigrationServiceImpl) validateMigration(sourceBackend string, targetBackend string, namespaces []string, deleteSource bool) error {
+	source, err := b.blobStorageService.GetBackendStore(sourceBackend)
+	if err != nil {
+		return err
+	}
+	target, err := b.blobStorageService.GetBackendStore(targetBackend)
+	if err != nil {
+		return err
+	}
+	for _, namespace := range namespaces {
+		if !utils.SliceContains(view.MigratableBlobNamespaces, namespace) || !source.SupportsNamespace(namespace) || !target.SupportsNamespace(namespace) {
+			return &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.BlobMigrationNamespaceNotSupported,
+				Message: exception.BlobMigrationNamespaceNotSupportedMsg,
+				Params:  map[string]interface{}{"namespace": namespace, "sourceBackend": sourceBackend, "targetBackend": targetBackend},
+			}
+		}
+		// otherwise the blobs would become unreachable after deletion from the source
+		if backend := b.blobStorageService.GetNamespaceBackend(namespace); deleteSource && backend != targetBackend {
+			return &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.BlobMigrationDeleteSourceNotAllowed,
+				Message: exception.BlobMigrationDeleteSourceNotAllowedMsg,
+				Params:  map[string]interface{}{"namespace": namespace, "backend": backend, "targetBackend": targetBackend},
+			}
+		}
+	}
+	return nil
+}
+
+func (b *blobMigrationServiceImpl) runMigration(ent entity.BlobMigrationRunEntity) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b.mutex.Lock()
+	b.cancelFuncs[ent.RunId] = cancel
+	b.mutex.Unlock()
+	defer func() {
+		b.mutex.Lock()
+		delete(b.cancelFuncs, ent.RunId)
+		b.mutex.Unlock()
+	}()
+
+	log.Infof("[BlobMigration] run %s: migrating %v from %s to %s", ent.RunId, ent.Namespaces, ent.SourceBackend, ent.TargetBackend)
+	err := b.migrate(ctx, &ent)
+	status, details := view.BlobMigrationStatusComplete, ""
+	if err != nil {
+		if errors.Is(err, errBlobMigrationCancelled) || errors.Is(err, context.Canceled) {
+			status, details = view.BlobMigrationStatusCancelled, errBlobMigrationCancelled.Error()
+		} else {
+			status, details = view.BlobMigrationStatusError, err.Error()
+		}
+	}
+	log.Infof("[BlobMigration] run %s: finished with status %s %s", ent.RunId, status, details)
+
+	updateCtx, updateCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer updateCancel()
+	finishedAt := time.Now()
+	if err = b.repo.UpdateStatus(updateCtx, ent.RunId, status, details, &finishedAt); err != nil {
+		log.Errorf("[BlobMigration] run %s: failed to update status: %v", ent.RunId, err)
+	}
+}
+
+func (b *blobMigrationServiceImpl) migrate(ctx context.Context, ent *entity.BlobMigrationRunEntity) error {
+	source, err := b.blobStorageService.GetBackendStore(ent.SourceBackend)
+	if err != nil {
+		return err
+	}
+	target, err := b.blobStorageService.GetBackendStore(ent.TargetBackend)
+	if err != nil {
+		return err
+	}
+	for i := range ent.Progress {
+		progress := &ent.Progress[i]
+		for !progress.Done {
+			cancelRequested, err := b.repo.IsCancelRequested(ctx, ent.RunId)
+			if err != nil {
+				return err
+			}
+			if cancelRequested {
+				return errBlobMigrationCancelled
+			}
+			err = migrateBlobsBatch(ctx, source, target, ent.DeleteSource, progress)
+			// progress is saved even if the migration is cancelled in the middle of the batch
+			if saveErr := b.repo.UpdateProgress(context.WithoutCancel(ctx), ent.RunId, ent.Progress); saveErr != nil && err == nil {
+				err = saveErr
+			}
+			if err != nil {
+				return err
+			}
+		}
+		log.Infof("[BlobMigration] run %s: %s migrated: copied %d (%d bytes), skipped %d, failed %d",
+			ent.RunId, progress.Namespace, progress.Copied, progress.Bytes, progress.Skipped, progress.Failed)
+	}
+	return nil
+}
+
+// migrateBlobsBatch copies the next batch of blobs after progress.LastKey and updates progress.
+// Blobs which failed to copy are skipped and not deleted from the source.
+func migrateBlobsBatch(ctx context.Context, source blobstore.BlobStore, target blobstore.BlobStore, deleteSource bool, progress *view.BlobMigrationNamespaceProgress) error {
+	keys, err := source.List(ctx, progress.Namespace, progress.LastKey, blobMigrationBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list %s blobs in %s: %w", progress.Namespace, source.Backend(), err)
+	}
+	if len(keys) == 0 {
+		progress.Done = true
+		return nil
+	}
+	migratedKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		result, size, err := blobstore.Copy(ctx, source, target, progress.Namespace, key)
+		if ctx.Err() != nil {
+			// the key will be processed again after resume
+			break
+		}
+		progress.LastKey = key
+		if err != nil {
+			log.Warnf("[BlobMigration] failed to migrate %s/%s: %v", progress.Namespace, key, err)
+			progress.Failed++
+			if len(progress.FailedKeys) < blobMigrationMaxFailedKeys {
+				progress.FailedKeys = append(progress.FailedKeys, key)
+			}
+			continue
+		}
+		switch result {
+		case blobstore.CopyResultCopied:
+			progress.Copied++
+			progress.Bytes += size
+			migratedKeys = append(migratedKeys, key)
+		case blobstore.CopyResultSkipped:
+			progress.Skipped++
+			migratedKeys = append(migratedKeys, key)
+		}
+	}
+	if deleteSource && len(migratedKeys) > 0 {
+		if err = source.Delete(context.WithoutCancel(ctx), progress.Namespace, migratedKeys); err != nil {
+			return fmt.Errorf("failed to delete migrated %s blobs from %s: %w", progress.Namespace, source.Backend(), err)
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service/blobstore"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+// BlobStorageService routes blobs of each namespace to the configured blob storage backend.
+// Blobs are compressed with zstd before they are passed to the backend, the same way as entity.CompressedData columns are.
+// Blobs which are kept in the database are written by the owning repositories in the same transaction with their metadata,
+// so callers should check IsDatabaseBackend before writing and IsDatabaseOnly before reading via this service.
+type BlobStorageService interface {
+	// IsDatabaseBackend returns true if new blobs of the namespace are written to the database
+	IsDatabaseBackend(namespace string) bool
+	// IsDatabaseOnly returns true if blobs of the namespace are written to and read from the database only, i.e. there is no fallback backend
+	IsDatabaseOnly(namespace string) bool
+	// GetBlob reads the blob from the main backend and then from the fallback one. Returns nil if the blob is not found
+	GetBlob(ctx context.Context, namespace string, key string) ([]byte, error)
+	PutBlob(ctx context.Context, namespace string, key string, data []byte) error
+	// DeleteBlobs removes blobs from both the main and the fallback backends
+	DeleteBlobs(ctx context.Context, namespace string, keys []string) error
+	GetBackendStore(backend string) (blobstore.BlobStore, error)
+	GetNamespaceBackend(namespace string) string
+	GetBlobStorageInfo() view.BlobStorageInfo
+}
+
+var blobStorageNamespaces = []string{
+	view.BlobNamespaceBuildResults,
+	view.BlobNamespaceSourcesArchives,
+	view.BlobNamespaceExportResults,
+	view.BlobNamespaceEphemeralFiles,
+}
+
+func NewBlobStorageService(systemInfoService SystemInfoService, blobRepository repository.BlobRepository) BlobStorageService {
+	return &blobStorageServiceImpl{
+		systemInfoService: systemInfoService,
+		blobRepository:    blobRepository,
+		stores:            make(map[string]blobstore.BlobStore),
+	}
+}
+
+type blobStorageServiceImpl struct {
+	systemInfoService SystemInfoService
+	blobRepository    repository.BlobRepository
+
+	mutex          sync.Mutex
+	stores         map[string]blobstore.BlobStore
+	ephemeralStore blobstore.BlobStore
+}
+
+func (b *blobStorageServiceImpl) IsDatabaseBackend(namespace string) bool {
+	return b.GetNamespaceBackend(namespace) == view.BlobStorageBackendPostgres
+}
+
+func (b *blobStorageServiceImpl) IsDatabaseOnly(namespace string) bool {
+	return b.IsDatabaseBackend(namespace) && b.getFallbackBackend(namespace) == ""
+}
+
+func (b *blobStorageServiceImpl) GetBlob(ctx context.Context, namespace string, key string) ([]byte, error) {
+	store, err := b.getNamespaceStore(namespace)
+	if err != nil {
+		return nil, err
+	}
+	data, err := store.Get(ctx, namespace, key)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		if fallbackBackend := b.getFallbackBackend(namespace); fallbackBackend != "" {
+			var fallbackStore blobstore.BlobStore
+			if fallbackStore, err = b.GetBackendStore(fallbackBackend); err != nil {
+				return nil, err
+			}
+			data, err = fallbackStore.Get(ctx, namespace, key)
+		}
+	}
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get blob %s/%s: %w", namespace, key, err)
+	}
+	if data, err = utils.DecompressData(data); err != nil {
+		return nil, fmt.Errorf("failed to decompress blob %s/%s: %w", namespace, key, err)
+	}
+	return data, nil
+}
+
+func (b *blobStorageServiceImpl) PutBlob(ctx context.Context, namespace string, key string, data []byte) error {
+	store, err := b.getNamespaceStore(namespace)
+	if err != nil {
+		return err
+	}
+	if err = store.Put(ctx, namespace, key, utils.CompressData(data)); err != nil {
+		return fmt.Errorf("failed to store blob %s/%s: %w", namespace, key, err)
+	}
+	return nil
+}
+
+func (b *blobStorageServiceImpl) DeleteBlobs(ctx context.Context, namespace string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	store, err := b.getNamespaceStore(namespace)
+	if err != nil {
+		return err
+	}
+	if err = store.Delete(ctx, namespace, keys); err != nil {
+		return fmt.Errorf("failed to delete blobs from %s: %w", namespace, err)
+	}
+	if fallbackBackend := b.getFallbackBackend(namespace); fallbackBackend != "" {
+		fallbackStore, err := b.GetBackendStore(fallbackBackend)
+		if err != nil {
+			return err
+		}
+		if err = fallbackStore.Delete(ctx, namespace, keys); err != nil {
+			return fmt.Errorf("failed to delete blobs from %s in fallback backend: %w", namespace, err)
+		}
+	}
+	return nil
+}
+
+func (b *blobStorageServiceImpl) GetBackendStore(backend string) (blobstore.BlobStore, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if store, exists := b.stores[backend]; exists {
+		return store, nil
+	}
+	var store blobstore.BlobStore
+	switch backend {
+	case view.BlobStorageBackendPostgres:
+		store = blobstore.NewPostgresStore(b.blobRepository)
+	case view.BlobStorageBackendS3:
+		store = blobstore.NewS3Store(b.systemInfoService.GetMinioStorageCreds(), blobStorageNamespaces)
+	case view.BlobStorageBackendFilesystem:
+		store = blobstore.NewFilesystemStore(b.systemInfoService.GetBlobStorageConfig().Filesystem.RootDirectory, blobStorageNamespaces)
+	default:
+		return nil, fmt.Errorf("unknown blob storage backend '%s'", backend)
+	}
+	b.stores[backend] = store
+	return store, nil
+}
+
+// GetNamespaceBackend returns the main backend for the namespace. If blobStorage.backend is not configured,
+// the legacy s3Storage settings are applied: S3 keeps build results and (unless storeOnlyBuildResult is set) sources archives.
+func (b *blobStorageServiceImpl) GetNamespaceBackend(namespace string) string {
+	if backend := b.systemInfoService.GetBlobStorageConfig().Backend; backend != "" {
+		return backend
+	}
+	if b.systemInfoService.IsMinioStorageActive() {
+		switch namespace {
+		case view.BlobNamespaceBuildResults:
+			return view.BlobStorageBackendS3
+		case view.BlobNamespaceSourcesArchives:
+			if !b.systemInfoService.IsMinioStoreOnlyBuildResult() {
+				return view.BlobStorageBackendS3
+			}
+		}
+	}
+	return view.BlobStorageBackendPostgres
+}
+
+func (b *blobStorageServiceImpl) GetBlobStorageInfo() view.BlobStorageInfo {
+	cfg := b.systemInfoService.GetBlobStorageConfig()
+	info := view.BlobStorageInfo{
+		Backend:         cfg.Backend,
+		FallbackBackend: cfg.FallbackBackend,
+		Namespaces:      make([]view.BlobStorageNamespaceInfo, 0, len(blobStorageNamespaces)),
+	}
+	for _, namespace := range blobStorageNamespaces {
+		nsBackend := b.GetNamespaceBackend(namespace)
+		if namespace == view.BlobNamespaceEphemeralFiles && nsBackend == view.BlobStorageBackendPostgres {
+			nsBackend = view.BlobStorageBackendFilesystem
+		}
+		info.Namespaces = append(info.Namespaces, view.BlobStorageNamespaceInfo{
+			Namespace:       namespace,
+			Backend:         nsBackend,
+			FallbackBackend: b.getFallbackBackend(namespace),
+		})
+	}
+	return info
+}
+
+func (b *blobStorageServiceImpl) getFallbackBackend(namespace string) string {
+	fallbackBackend := b.systemInfoService.GetBlobStorageConfig().FallbackBackend
+	if fallbackBackend == "" || fallbackBackend == b.GetNamespaceBackend(namespace) || namespace == view.BlobNamespaceEphemeralFiles {
+		return ""
+	}
+	if fallbackBackend == view.BlobStorageBackendPostgres && !b.blobRepository.IsBlobTable(namespace) {
+		return ""
+	}
+	return fallbackBackend
+}
+
+func (b *blobStorageServiceImpl) getNamespaceStore(namespace string) (blobstore.BlobStore, error) {
+	backend := b.GetNamespaceBackend(namespace)
+	if namespace == view.BlobNamespaceEphemeralFiles && backend == view.BlobStorageBackendPostgres {
+		// ephemeral files are not kept in the database, the local ephemeral files directory is used instead
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		if b.ephemeralStore == nil {
+			b.ephemeralStore = blobstore.NewFilesystemStore(b.systemInfoService.GetEphemeralFileDirectory(), []string{view.BlobNamespaceEphemeralFiles})
+		}
+		return b.ephemeralStore, nil
+	}
+	return b.GetBackendStore(backend)
+}
@@ -2,9 +2,9 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
-	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
 	mRepository "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/migration/repository"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
@@ -13,93 +13,87 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+const buildsCleanupJobName = "buildsCleanup"
+
 type DBCleanupService interface {
 	CreateCleanupJob(schedule string) error
 }
 
 func NewDBCleanupService(cleanUpRepository repository.BuildCleanupRepository,
 	migrationRepository mRepository.MigrationRunRepository,
-	minioStorageService MinioStorageService,
-	infoService SystemInfoService) DBCleanupService {
+	blobStorageService BlobStorageService,
+	infoService SystemInfoService,
+	backgroundJobService BackgroundJobService) DBCleanupService {
 	return &dbCleanupServiceImpl{
-		cleanUpRepository:   cleanUpRepository,
-		migrationRepository: migrationRepository,
-		cron:                cron.New(),
-		systemInfoService:   infoService,
-		minioStorageService: minioStorageService,
+		cleanUpRepository:    cleanUpRepository,
+		migrationRepository:  migrationRepository,
+		systemInfoService:    infoService,
+		blobStorageService:   blobStorageService,
+		backgroundJobService: backgroundJobService,
 	}
 }
 
 type dbCleanupServiceImpl struct {
-	cleanUpRepository   repository.BuildCleanupRepository
-	migrationRepository mRepository.MigrationRunRepository
-	connectionProvider  db.ConnectionProvider
-	cron                *cron.Cron
-	minioStorageService MinioStorageService
-	systemInfoService   SystemInfoService
+	cleanUpRepository    repository.BuildCleanupRepository
+	migrationRepository  mRepository.MigrationRunRepository
+	blobStorageService   BlobStorageService
+	systemInfoService    SystemInfoService
+	backgroundJobService BackgroundJobService
 }
 
 func (c *dbCleanupServiceImpl) CreateCleanupJob(schedule string) error {
 	job := BuildCleanupJob{
 		schedule:               schedule,
 		buildCleanupRepository: c.cleanUpRepository,
-		minioStorageService:    c.minioStorageService,
+		blobStorageService:     c.blobStorageService,
 		systemInfoService:      c.systemInfoService,
 		migrationRepository:    c.migrationRepository,
 	}
-
-	if len(c.cron.Entries()) == 0 {
-		location, err := time.LoadLocation("")
-		if err != nil {
-			return err
-		}
-		c.cron = cron.New(cron.WithLocation(location))
-		c.cron.Start()
-	}
-
-	_, err := c.cron.AddJob(schedule, &job)
+	err := c.backgroundJobService.RegisterJob(BackgroundJobDefinition{
+		Name:        buildsCleanupJobName,
+		Description: "Deletes sources and results of old builds",
+		Schedule:    schedule,
+		Distributed: true,
+		Timeout:     2 * time.Hour,
+		Job:         job,
+	})
 	if err != nil {
 		log.Warnf("[DBCleanupService] Job wasn't added for schedule - %s. With error - %s", schedule, err)
 		return err
 	}
-	log.Infof("[DBCleanupService] Job was created with schedule - %s", schedule)
-
 	return nil
 }
 
 type BuildCleanupJob struct {
 	schedule               string
 	buildCleanupRepository repository.BuildCleanupRepository
-	minioStorageService    MinioStorageService
+	blobStorageService     BlobStorageService
 	systemInfoService      SystemInfoService
 	migrationRepository    mRepository.MigrationRunRepository
 }
 
-func (j BuildCleanupJob) Run() {
+func (j BuildCleanupJob) Run(ctx context.Context) error {
 	scheduledAt := time.Now().Round(time.Second)
 
 	migrations, err := j.migrationRepository.GetRunningMigrations()
 	if err != nil {
-		log.Error("Failed to check for running migrations for build cleanup job")
-		return
+		return fmt.Errorf("failed to check for running migrations for build cleanup job: %w", err)
 	}
 	if migrations != nil && len(migrations) != 0 {
 		log.Infof("Cleanup was skipped at %s due to migration run", scheduledAt)
-		return
+		return nil
 	}
 
 	var runCleanup bool
 	var lockId int
 	lastCleanup, err := j.buildCleanupRepository.GetLastCleanup()
 	if err != nil {
-		log.Errorf("Failed to get last cleanup: %v", err)
-		return
+		return fmt.Errorf("failed to get last cleanup: %w", err)
 	}
 	if lastCleanup != nil {
 		schedule, err := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow).Parse(j.schedule)
 		if err != nil {
-			log.Errorf("Failed to parse schedule for cleaning job: %v", err)
-			return
+			return fmt.Errorf("failed to parse schedule for cleaning job: %w", err)
 		}
 		currentTime := time.Now().UTC()
 		nextRun := schedule.Next(currentTime)
@@ -118,46 +112,40 @@ func (j BuildCleanupJob) Run() {
 			ScheduledAt: scheduledAt,
 		})
 		if err != nil {
-			log.Errorf("Failed to store cleanup entity: %v", err)
-			return
+			return fmt.Errorf("failed to store cleanup entity: %w", err)
 		}
-		if j.systemInfoService.IsMinioStorageActive() {
-			ctx := context.Background()
+		if !j.blobStorageService.IsDatabaseOnly(view.BlobNamespaceBuildResults) {
 			ids, err := j.buildCleanupRepository.GetRemoveCandidateOldBuildEntitiesIds()
 			if err != nil {
-				log.Errorf("Failed to get up remove candidate old build ids: %v", err)
-				return
+				return fmt.Errorf("failed to get remove candidate old build ids: %w", err)
 			}
 			if len(ids) == 0 {
 				log.Info("No old build entities to clean up")
 			} else {
-				err = j.minioStorageService.RemoveFiles(ctx, view.BUILD_RESULT_TABLE, ids)
+				err = j.blobStorageService.DeleteBlobs(ctx, view.BlobNamespaceBuildResults, ids)
 				if err != nil {
-					log.Errorf("Failed to remove old build results from minio storage: %v", err)
-					return
+					return fmt.Errorf("failed to remove old build results from blob storage: %w", err)
 				}
 
 				err = j.buildCleanupRepository.RemoveOldBuildSourcesByIds(ctx, ids, lockId, scheduledAt)
 				if err != nil {
-					log.Errorf("Failed to clean up old builds sources: %v", err)
-					return
+					return fmt.Errorf("failed to clean up old builds sources: %w", err)
 				}
 			}
 		} else {
 			err = j.buildCleanupRepository.RemoveOldBuildEntities(lockId, scheduledAt)
 			if err != nil {
-				log.Errorf("Failed to clean up old builds: %v", err)
-				return
+				return fmt.Errorf("failed to clean up old builds: %w", err)
 			}
 		}
 
 		cleanupEnt, err := j.buildCleanupRepository.GetCleanup(lockId)
 		if err != nil {
-			log.Errorf("Failed to get cleanup run entity with id %d", lockId)
-			return
+			return fmt.Errorf("failed to get cleanup run entity with id %d: %w", lockId, err)
 		}
 		log.Infof("Cleanup was performed at %s with results: %v", scheduledAt, *cleanupEnt)
 	} else {
 		log.Infof("Cleanup was skipped at %s", scheduledAt)
 	}
+	return nil
 }
@@ -28,13 +28,13 @@ type BuildResultService interface {
 }
 
 func NewBuildResultService(buildResultRepository repository.BuildResultRepository, buildRepository repository.BuildRepository,
-	publishedRepository repository.PublishedRepository, systemInfoService SystemInfoService, minioStorageService MinioStorageService,
+	publishedRepository repository.PublishedRepository, systemInfoService SystemInfoService, blobStorageService BlobStorageService,
 	publishService PublishedService, exportService ExportService) BuildResultService {
 	return &buildResultServiceImpl{
 		buildResultRepository: buildResultRepository,
 		buildRepository:       buildRepository,
 		publishedRepository:   publishedRepository,
-		minioStorageService:   minioStorageService,
+		blobStorageService:    blobStorageService,
 		systemInfoService:     systemInfoService,
 		publishService:        publishService,
 		exportService:         exportService,
@@ -46,7 +46,7 @@ type buildResultServiceImpl struct {
 	buildResultRepository repository.BuildResultRepository
 	buildRepository       repository.BuildRepository
 	publishedRepository   repository.PublishedRepository
-	minioStorageService   MinioStorageService
+	blobStorageService    BlobStorageService
 	systemInfoService     SystemInfoService
 	publishService        PublishedService
 	exportService         ExportService
@@ -55,9 +55,8 @@ type buildResultServiceImpl struct {
 }
 
 func (b buildResultServiceImpl) GetBuildResultData(buildId string) ([]byte, error) {
-	if b.systemInfoService.IsMinioStorageActive() {
-		ctx := context.Background()
-		return b.minioStorageService.GetFile(ctx, view.BUILD_RESULT_TABLE, buildId)
+	if !b.blobStorageService.IsDatabaseOnly(view.BlobNamespaceBuildResults) {
+		return b.blobStorageService.GetBlob(context.Background(), view.BlobNamespaceBuildResults, buildId)
 	}
 	ent, err := b.buildResultRepository.GetBuildResult(buildId)
 	if err != nil {
@@ -70,13 +69,8 @@ func (b buildResultServiceImpl) GetBuildResultData(buildId string) ([]byte, erro
 }
 
 func (b buildResultServiceImpl) StoreBuildResult(buildId string, result []byte) error {
-	if b.systemInfoService.IsMinioStorageActive() {
-		ctx := context.Background()
-		err := b.minioStorageService.UploadFile(ctx, view.BUILD_RESULT_TABLE, buildId, result)
-		if err != nil {
-			return err
-		}
-		return nil
+	if !b.blobStorageService.IsDatabaseBackend(view.BlobNamespaceBuildResults) {
+		return b.blobStorageService.PutBlob(context.Background(), view.BlobNamespaceBuildResults, buildId, result)
 	}
 	return b.buildResultRepository.StoreBuildResult(entity.BuildResultEntity{
 		BuildId: buildId,
@@ -121,7 +115,7 @@ func (p buildResultServiceImpl) SaveBuildResult(packageId string, data []byte, f
 	utils.PerfLog(time.Since(start).Milliseconds(), 200, "SaveBuildResult: get build src")
 
 	switch buildConfig.BuildType {
-	case view.ExportVersion, view.ExportRestDocument, view.ExportRestOperationsGroup, view.ExportGraphqlOperationsGroup, view.ExportAsyncapiOperationsGroup:
+	case view.ExportVersion, view.ExportRestDocument, view.ExportRestOperationsGroup, view.ExportGraphqlOperationsGroup, view.ExportAsyncapiOperationsGroup, view.ExportProtobufOperationsGroup:
 		return p.exportService.StoreExportResult(buildConfig.CreatedBy, publishId, data, fileName, *buildConfig)
 	}
 
@@ -3,6 +3,7 @@ package service
 import (
 	"archive/zip"
 	"bytes"
+	stdctx "context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,6 +17,7 @@ import (
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service/validation"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/tracing"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
 	"github.com/google/uuid"
@@ -27,7 +29,7 @@ type BuildService interface {
 	GetStatus(buildId string) (string, string, error)
 	GetStatuses(buildIds []string) ([]view.PublishStatusResponse, error)
 	UpdateBuildStatus(buildId string, status view.BuildStatusEnum, details string) error
-	GetFreeBuild(builderId string) ([]byte, error)
+	GetFreeBuild(ctx stdctx.Context, builderId string) ([]byte, error)
 	CreateChangelogBuild(config view.BuildConfig, isExternal bool, builderId string) (string, view.BuildConfig, error) //deprecated
 	GetBuildViewByChangelogSearchQuery(searchRequest view.ChangelogBuildSearchRequest) (*view.BuildView, error)
 	GetBuildViewByDocumentGroupSearchQuery(searchRequest view.DocumentGroupBuildSearchRequest) (*view.BuildView, error)
@@ -46,7 +48,9 @@ func NewBuildService(
 	publishService PublishedService,
 	systemInfoService SystemInfoService,
 	packageService PackageService,
-	refResolverService RefResolverService) BuildService {
+	refResolverService RefResolverService,
+	shutdownService ShutdownService,
+	tenantService TenantService) BuildService {
 	return &buildServiceImpl{
 		buildRepository:    buildRepository,
 		buildProcessor:     buildProcessor,
@@ -54,6 +58,8 @@ func NewBuildService(
 		systemInfoService:  systemInfoService,
 		packageService:     packageService,
 		refResolverService: refResolverService,
+		shutdownService:    shutdownService,
+		tenantService:      tenantService,
 	}
 }
 
@@ -64,6 +70,8 @@ type buildServiceImpl struct {
 	systemInfoService  SystemInfoService
 	packageService     PackageService
 	refResolverService RefResolverService
+	shutdownService    ShutdownService
+	tenantService      TenantService
 }
 
 func (b *buildServiceImpl) PublishVersion(ctx context.SecurityContext, config view.BuildConfig, src []byte, clientBuild bool, builderId string, dependencies []string, resolveRefs bool, resolveConflicts bool) (*view.PublishV2Response, error) {
@@ -85,6 +93,11 @@ func (b *buildServiceImpl) PublishVersion(ctx context.SecurityContext, config vi
 		return nil, versionNameValidationError
 	}
 
+	err = b.tenantService.CheckPublishQuotas(stdctx.Background(), config.PackageId, config.Version)
+	if err != nil {
+		return nil, err
+	}
+
 	if config.MigrationBuild == true || config.NoChangelog == true || !config.PublishedAt.IsZero() {
 		return nil, &exception.CustomError{
 			Status:  http.StatusBadRequest,
@@ -223,7 +236,15 @@ func (b *buildServiceImpl) PublishVersion(ctx context.SecurityContext, config vi
 	}
 }
 
-func (b *buildServiceImpl) setValidationRulesSeverity(config view.BuildConfig) view.BuildConfig {
+func (b *buildServiceImpl) setValidationRulesSeverity(config view.BuildConfig) (view.BuildConfig, error) {
+	tenantSettings, err := b.tenantService.GetSettings(stdctx.Background(), config.PackageId)
+	if err != nil {
+		return config, err
+	}
+	if tenantSettings.ValidationRulesSeverity != nil {
+		config.ValidationRulesSeverity = *tenantSettings.ValidationRulesSeverity
+		return config, nil
+	}
 	var severity string
 	if b.systemInfoService.FailBuildOnBrokenRefs() {
 		severity = view.BrokenRefsSeverityError
@@ -233,12 +254,15 @@ func (b *buildServiceImpl) setValidationRulesSeverity(config view.BuildConfig) v
 	config.ValidationRulesSeverity = view.ValidationRulesSeverity{
 		BrokenRefs: severity,
 	}
-	return config
+	return config, nil
 }
 
 // CreateChangelogBuild deprecated. use to CreateBuildWithoutDependencies
 func (b *buildServiceImpl) CreateChangelogBuild(config view.BuildConfig, isExternal bool, builderId string) (string, view.BuildConfig, error) {
-	config = b.setValidationRulesSeverity(config)
+	config, err := b.setValidationRulesSeverity(config)
+	if err != nil {
+		return "", config, err
+	}
 
 	status := view.StatusNotStarted
 	if isExternal {
@@ -282,7 +306,10 @@ func (b *buildServiceImpl) CreateChangelogBuild(config view.BuildConfig, isExter
 }
 
 func (b *buildServiceImpl) CreateBuildWithoutDependencies(config view.BuildConfig, clientBuild bool, builderId string) (string, view.BuildConfig, error) {
-	config = b.setValidationRulesSeverity(config)
+	config, err := b.setValidationRulesSeverity(config)
+	if err != nil {
+		return "", config, err
+	}
 
 	status := view.StatusNotStarted
 	if clientBuild {
@@ -330,7 +357,10 @@ func (b *buildServiceImpl) CreateBuildWithoutDependencies(config view.BuildConfi
 }
 
 func (b *buildServiceImpl) addBuild(ctx context.SecurityContext, config view.BuildConfig, src []byte, clientBuild bool, builderId string, dependencies []string) (string, view.BuildConfig, error) {
-	config = b.setValidationRulesSeverity(config)
+	config, err := b.setValidationRulesSeverity(config)
+	if err != nil {
+		return "", config, err
+	}
 
 	status := view.StatusNotStarted
 	var timeNow time.Time
@@ -428,14 +458,30 @@ func (b *buildServiceImpl) UpdateBuildStatus(buildId string, status view.BuildSt
 	return nil
 }
 
-func (b *buildServiceImpl) GetFreeBuild(builderId string) ([]byte, error) {
+func (b *buildServiceImpl) GetFreeBuild(ctx stdctx.Context, builderId string) ([]byte, error) {
+	_, span := tracing.StartSpan(ctx, "build.fetch", tracing.BuilderIdKey.String(builderId))
 	config, src, err := b.buildProcessor.GetFreeBuild(builderId)
 	if err != nil {
+		tracing.EndSpan(span, err)
 		return nil, err
 	}
 	if config == nil && src == nil {
+		span.End()
 		return nil, nil
 	}
+	if config != nil {
+		span.SetAttributes(tracing.BuildIdKey.String(config.PublishId), tracing.PackageIdKey.String(config.PackageId), tracing.VersionKey.String(config.Version))
+		// build is fetched in another trace than the publish request, so they are linked
+		if link, ok := tracing.LinkFromContext(config.TraceContext); ok {
+			span.AddLink(link)
+		}
+	}
+	result, err := makeFreeBuildArchive(config, src)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+func makeFreeBuildArchive(config *view.BuildConfig, src []byte) ([]byte, error) {
 	result := bytes.Buffer{}
 	zw := zip.NewWriter(&result)
 	if src != nil {
@@ -559,6 +605,8 @@ func (b *buildServiceImpl) ValidateBuildOwnership(buildId string, builderId stri
 }
 
 func (b *buildServiceImpl) AwaitBuildCompletion(buildId string) error {
+	// the awaiting process is drained on shutdown, it is aborted if the build is not completed within the drain timeout
+	defer b.shutdownService.TrackWork("awaited build")()
 	start := time.Now()
 	for {
 		build, err := b.buildRepository.GetBuild(buildId)
@@ -574,7 +622,11 @@ func (b *buildServiceImpl) AwaitBuildCompletion(buildId string) error {
 		if time.Since(start) > time.Minute*10 {
 			return fmt.Errorf("deadline exceeded")
 		}
-		time.Sleep(time.Second * 5)
+		select {
+		case <-b.shutdownService.AbortContext().Done():
+			return fmt.Errorf("build %s is not completed: %w", buildId, stdctx.Cause(b.shutdownService.AbortContext()))
+		case <-time.After(time.Second * 5):
+		}
 	}
 }
 
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type ChangelogReportService interface {
+	ExportChangelogReport(packageId, version, apiType string, severities []string, format string, req view.ExportApiChangesRequestView) ([]byte, string, error)
+}
+
+func NewChangelogReportService(publishedRepo repository.PublishedRepository, versionService VersionService) ChangelogReportService {
+	return &changelogReportServiceImpl{publishedRepo: publishedRepo, versionService: versionService}
+}
+
+type changelogReportServiceImpl struct {
+	publishedRepo  repository.PublishedRepository
+	versionService VersionService
+}
+
+func (c changelogReportServiceImpl) ExportChangelogReport(packageId, version, apiType string, severities []string, format string, req view.ExportApiChangesRequestView) ([]byte, string, error) {
+	changelog, err := c.versionService.GetVersionChanges(packageId, version, apiType, severities, makeVersionChangesReq(req))
+	if err != nil {
+		return nil, "", err
+	}
+	if changelog == nil {
+		changelog = &view.VersionChangesView{}
+	}
+	versionName, err := getVersionNameForAttachmentName(c.publishedRepo, packageId, version)
+	if err != nil {
+		return nil, "", err
+	}
+	report := makeChangelogReport(changelog, packageId, versionName, apiType)
+	var content []byte
+	switch format {
+	case view.ExportFormatMarkdown:
+		content = MakeChangelogMarkdown(report)
+	case view.ExportFormatJunit:
+		content, err = MakeChangelogJUnit(report)
+	case view.ExportFormatSarif:
+		content, err = MakeChangelogSarif(report)
+	default:
+		content, err = json.MarshalIndent(report, "", "  ")
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return content, versionName, nil
+}
+
+func makeChangelogReport(changelog *view.VersionChangesView, packageId, version, apiType string) *view.ChangelogReport {
+	report := &view.ChangelogReport{
+		SchemaVersion:            view.ChangelogReportSchemaVersion,
+		PackageId:                packageId,
+		Version:                  version,
+		PreviousVersionPackageId: changelog.PreviousVersionPackageId,
+		PreviousVersion:          changelog.PreviousVersion,
+		ApiType:                  apiType,
+		Operations:               make([]view.ChangelogReportOperation, 0),
+	}
+	for _, operation := range changelog.Operations {
+		reportOperation, ok := makeChangelogReportOperation(operation)
+		if !ok {
+			continue
+		}
+		packageRef := reportOperation.PackageId
+		if ref, exists := changelog.Packages[packageRef]; exists {
+			reportOperation.PackageId = ref.RefPackageId
+			reportOperation.Version = ref.RefPackageVersion
+		}
+		addChangeSummary(&report.Summary, reportOperation.Summary)
+		report.Operations = append(report.Operations, reportOperation)
+	}
+	sort.SliceStable(report.Operations, func(i, j int) bool {
+		if report.Operations[i].PackageId != report.Operations[j].PackageId {
+			return report.Operations[i].PackageId < report.Operations[j].PackageId
+		}
+		return report.Operations[i].OperationId < report.Operations[j].OperationId
+	})
+	report.Breaking = report.Summary.Breaking > 0
+	return report
+}
+
+// makeChangelogReportOperation returns the operation with package ref key in PackageId field
+func makeChangelogReportOperation(operation interface{}) (view.ChangelogReportOperation, bool) {
+	changes, apiType, ok := view.GetOperationComparisonChangesCommon(operation)
+	if !ok {
+		return view.ChangelogReportOperation{}, false
+	}
+	packageRef := changes.PackageRef
+	if packageRef == "" {
+		packageRef = changes.PreviousVersionPackageRef
+	}
+	result := view.ChangelogReportOperation{
+		OperationId: changes.OperationId,
+		Title:       changes.Title,
+		Label:       getChangelogReportOperationLabel(operation),
+		ApiType:     string(apiType),
+		ApiKind:     changes.ApiKind,
+		PackageId:   packageRef,
+		Action:      changes.Action,
+		Changes:     make([]view.ChangelogReportChange, 0, len(changes.Changes)),
+	}
+	for _, change := range changes.Changes {
+		common := view.GetSingleOperationChangeCommon(change)
+		result.Changes = append(result.Changes, view.ChangelogReportChange{
+			Action:      common.Action,
+			Severity:    common.Severity,
+			Description: common.Description,
+		})
+		incrementChangeSummary(&result.Summary, common.Severity)
+	}
+	return result, true
+}
+
+func getChangelogReportOperationLabel(operation interface{}) string {
+	var label string
+	switch op := operation.(type) {
+	case view.RestOperationComparisonChangesView:
+		label = strings.ToUpper(op.Method) + " " + op.Path
+	case view.GraphQLOperationComparisonChangesView:
+		label = op.Type + " " + op.Method
+	case view.ProtobufOperationComparisonChangesView:
+		label = op.Type + " " + op.Method
+	case view.AsyncAPIOperationComparisonChangesView:
+		label = op.AsyncAPIOperationMetadata.Action + " " + op.Channel
+	}
+	return strings.TrimSpace(label)
+}
+
+func incrementChangeSummary(summary *view.ChangeSummary, severity string) {
+	switch view.Severity(severity) {
+	case view.Breaking:
+		summary.Breaking++
+	case view.SemiBreaking:
+		summary.SemiBreaking++
+	case view.Deprecated:
+		summary.Deprecated++
+	case view.NonBreaking:
+		summary.NonBreaking++
+	case view.Annotation:
+		summary.Annotation++
+	default:
+		summary.Unclassified++
+	}
+}
+
+func addChangeSummary(summary *view.ChangeSummary, other view.ChangeSummary) {
+	summary.Breaking += other.Breaking
+	summary.SemiBreaking += other.SemiBreaking
+	summary.Deprecated += other.Deprecated
+	summary.NonBreaking += other.NonBreaking
+	summary.Annotation += other.Annotation
+	summary.Unclassified += other.Unclassified
+}
+
+func MakeChangelogMarkdown(report *view.ChangelogReport) []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# API changes: %s %s vs %s %s\n\n", report.PackageId, report.Version, report.PreviousVersionPackageId, report.PreviousVersion)
+	sb.WriteString("| Severity | Changes |\n|---|---|\n")
+	fmt.Fprintf(&sb, "| %s | %d |\n", view.Breaking, report.Summary.Breaking)
+	fmt.Fprintf(&sb, "| %s | %d |\n", view.SemiBreaking, report.Summary.SemiBreaking)
+	fmt.Fprintf(&sb, "| %s | %d |\n", view.Deprecated, report.Summary.Deprecated)
+	fmt.Fprintf(&sb, "| %s | %d |\n", view.NonBreaking, report.Summary.NonBreaking)
+	fmt.Fprintf(&sb, "| %s | %d |\n", view.Annotation, report.Summary.Annotation)
+	fmt.Fprintf(&sb, "| %s | %d |\n", view.Unclassified, report.Summary.Unclassified)
+	if len(report.Operations) == 0 {
+		sb.WriteString("\nNo changes.\n")
+		return []byte(sb.String())
+	}
+	for _, operation := range report.Operations {
+		fmt.Fprintf(&sb, "\n## %s\n\n", markdownEscape(operation.Title))
+		fmt.Fprintf(&sb, "`%s` (%s, %s)", operation.Label, operation.ApiType, operation.Action)
+		if operation.PackageId != report.PackageId && operation.PackageId != "" {
+			fmt.Fprintf(&sb, " in %s %s", operation.PackageId, operation.Version)
+		}
+		sb.WriteString("\n\n")
+		for _, change := range operation.Changes {
+			fmt.Fprintf(&sb, "- **%s**: %s\n", change.Severity, markdownEscape(change.Description))
+		}
+	}
+	return []byte(sb.String())
+}
+
+func markdownEscape(s string) string {
+	return strings.NewReplacer("\n", " ", "|", "\\|", "*", "\\*", "_", "\\_", "`", "\\`").Replace(s)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// MakeChangelogJUnit makes one test case per changed operation, operations with breaking changes are failed
+func MakeChangelogJUnit(report *view.ChangelogReport) ([]byte, error) {
+	suiteName := fmt.Sprintf("%s %s vs %s %s", report.PackageId, report.Version, report.PreviousVersionPackageId, report.PreviousVersion)
+	doc := junitTestSuites{Name: "APIHUB changelog"}
+	suiteIndex := map[string]int{}
+	for _, operation := range report.Operations {
+		idx, exists := suiteIndex[operation.ApiType]
+		if !exists {
+			idx = len(doc.Suites)
+			suiteIndex[operation.ApiType] = idx
+			doc.Suites = append(doc.Suites, junitTestSuite{Name: suiteName + " (" + operation.ApiType + ")"})
+		}
+		suite := &doc.Suites[idx]
+		testCase := junitTestCase{
+			Name:      operation.Label,
+			ClassName: operation.PackageId + "." + operation.OperationId,
+		}
+		var breaking, other []string
+		for _, change := range operation.Changes {
+			line := fmt.Sprintf("[%s] %s", change.Severity, change.Description)
+			if change.Severity == string(view.Breaking) {
+				breaking = append(breaking, line)
+			} else {
+				other = append(other, line)
+			}
+		}
+		if len(breaking) > 0 {
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d breaking change(s) in %s", len(breaking), operation.Title),
+				Type:    string(view.Breaking),
+				Text:    strings.Join(breaking, "\n"),
+			}
+			suite.Failures++
+			doc.Failures++
+		}
+		testCase.SystemOut = strings.Join(other, "\n")
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+		doc.Tests++
+	}
+	if len(doc.Suites) == 0 {
+		doc.Suites = append(doc.Suites, junitTestSuite{Name: suiteName})
+	}
+	content, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), content...), nil
+}
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+const sarifVersion = "2.1.0"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleId     string                 `json:"ruleId"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+var sarifRules = []view.Severity{view.Breaking, view.SemiBreaking, view.Deprecated, view.NonBreaking, view.Annotation, view.Unclassified}
+
+// MakeChangelogSarif makes one SARIF result per change, the change severity is used as rule id
+func MakeChangelogSarif(report *view.ChangelogReport) ([]byte, error) {
+	driver := sarifDriver{Name: "APIHUB", InformationUri: "https://github.com/Netcracker/qubership-apihub"}
+	for _, severity := range sarifRules {
+		driver.Rules = append(driver.Rules, sarifRule{
+			Id:               string(severity),
+			ShortDescription: sarifMessage{Text: fmt.Sprintf("API change classified as %s", severity)},
+		})
+	}
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: make([]sarifResult, 0)}
+	for _, operation := range report.Operations {
+		for _, change := range operation.Changes {
+			run.Results = append(run.Results, sarifResult{
+				RuleId:  change.Severity,
+				Level:   sarifLevel(change.Severity),
+				Message: sarifMessage{Text: fmt.Sprintf("%s: %s", operation.Label, change.Description)},
+				Locations: []sarifLocation{{LogicalLocations: []sarifLogicalLocation{{
+					Name:               operation.OperationId,
+					FullyQualifiedName: operation.PackageId + "/" + operation.OperationId,
+					Kind:               "function",
+				}}}},
+				Properties: map[string]interface{}{
+					"apiType": operation.ApiType,
+					"title":   operation.Title,
+				},
+			})
+		}
+	}
+	return json.MarshalIndent(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}, "", "  ")
+}
+
+func sarifLevel(severity string) string {
+	switch view.Severity(severity) {
+	case view.Breaking:
+		return "error"
+	case view.SemiBreaking, view.Deprecated:
+		return "warning"
+	default:
+		return "note"
+	}
+}
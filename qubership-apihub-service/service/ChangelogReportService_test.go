@@ -0,0 +1,92 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestChangelog() *view.VersionChangesView {
+	return &view.VersionChangesView{
+		PreviousVersion:          "2024.1",
+		PreviousVersionPackageId: "QS.CORE.QUOTE",
+		Packages: map[string]view.PackageVersionRef{
+			"QS.CORE.QUOTE@2024.2@1": {RefPackageId: "QS.CORE.QUOTE", RefPackageVersion: "2024.2@1"},
+		},
+		Operations: []interface{}{
+			view.RestOperationComparisonChangesView{
+				OperationComparisonChangesView: view.OperationComparisonChangesView{
+					OperationId: "quotes-post",
+					Title:       "Create quote",
+					PackageRef:  "QS.CORE.QUOTE@2024.2@1",
+					Action:      "change",
+					Changes: []interface{}{
+						view.SingleOperationChangeCommon{Action: "remove", Severity: string(view.Breaking), Description: "[Removed] property `id`"},
+						view.SingleOperationChangeCommon{Action: "add", Severity: string(view.NonBreaking), Description: "[Added] property name"},
+					},
+				},
+				RestOperationMetadata: view.RestOperationMetadata{Method: "post", Path: "/quotes"},
+			},
+			view.RestOperationComparisonChangesView{
+				OperationComparisonChangesView: view.OperationComparisonChangesView{
+					OperationId:               "quotes-get",
+					Title:                     "Get quotes",
+					PreviousVersionPackageRef: "QS.CORE.QUOTE@2024.2@1",
+					Action:                    "change",
+					Changes: []interface{}{
+						view.SingleOperationChangeCommon{Action: "replace", Severity: string(view.Deprecated), Description: "[Deprecated] operation"},
+					},
+				},
+				RestOperationMetadata: view.RestOperationMetadata{Method: "get", Path: "/quotes"},
+			},
+		},
+	}
+}
+
+func TestMakeChangelogReport(t *testing.T) {
+	report := makeChangelogReport(makeTestChangelog(), "QS.CORE.QUOTE", "2024.2", "rest")
+
+	require.True(t, report.Breaking)
+	require.Equal(t, view.ChangeSummary{Breaking: 1, NonBreaking: 1, Deprecated: 1}, report.Summary)
+	require.Len(t, report.Operations, 2)
+	require.Equal(t, "quotes-get", report.Operations[0].OperationId)
+	require.Equal(t, "GET /quotes", report.Operations[0].Label)
+	require.Equal(t, "QS.CORE.QUOTE", report.Operations[0].PackageId)
+	require.Equal(t, "2024.2@1", report.Operations[0].Version)
+	require.Equal(t, "quotes-post", report.Operations[1].OperationId)
+	require.Len(t, report.Operations[1].Changes, 2)
+
+	empty := makeChangelogReport(&view.VersionChangesView{}, "QS.CORE.QUOTE", "2024.2", "rest")
+	require.False(t, empty.Breaking)
+	require.NotNil(t, empty.Operations)
+}
+
+func TestMakeChangelogReportFormats(t *testing.T) {
+	report := makeChangelogReport(makeTestChangelog(), "QS.CORE.QUOTE", "2024.2", "rest")
+
+	markdown := string(MakeChangelogMarkdown(report))
+	require.Contains(t, markdown, "| breaking | 1 |")
+	require.Contains(t, markdown, "- **breaking**: [Removed] property \\`id\\`")
+
+	junit, err := MakeChangelogJUnit(report)
+	require.NoError(t, err)
+	require.Contains(t, string(junit), `<testsuites name="APIHUB changelog" tests="2" failures="1">`)
+	require.Contains(t, string(junit), `<failure message="1 breaking change(s) in Create quote" type="breaking">`)
+	require.Equal(t, 1, strings.Count(string(junit), "<failure"))
+
+	sarif, err := MakeChangelogSarif(report)
+	require.NoError(t, err)
+	var sarifLog sarifLog
+	require.NoError(t, json.Unmarshal(sarif, &sarifLog))
+	require.Equal(t, sarifVersion, sarifLog.Version)
+	require.Len(t, sarifLog.Runs, 1)
+	require.Len(t, sarifLog.Runs[0].Results, 3)
+	levels := map[string]string{}
+	for _, result := range sarifLog.Runs[0].Results {
+		levels[result.RuleId] = result.Level
+	}
+	require.Equal(t, map[string]string{"breaking": "error", "non-breaking": "note", "deprecated": "warning"}, levels)
+}
@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"time"
+
+	secctx "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// roll forwards still running after this timeout were interrupted by an instance restart
+const dashboardRollForwardTimeout = time.Hour
+
+type DashboardTrackingService interface {
+	GetTracking(ctx context.Context, packageId string, version string) (*view.DashboardTracking, error)
+	SetTracking(ctx context.Context, secCtx secctx.SecurityContext, packageId string, version string, req view.DashboardTrackingReq) (*view.DashboardTracking, error)
+	DeleteTracking(ctx context.Context, packageId string, version string) error
+	GetRollForwards(ctx context.Context, packageId string, req view.DashboardRollForwardsReq) (*view.DashboardRollForwards, error)
+	// ListenVersionPublished rolls tracking dashboards forward when a referenced package publishes a release
+	ListenVersionPublished(publishNotificationService PublishNotificationService)
+}
+
+func NewDashboardTrackingService(repo repository.DashboardTrackingRepository,
+	publishedRepo repository.PublishedRepository,
+	buildService BuildService) DashboardTrackingService {
+	return &dashboardTrackingServiceImpl{
+		repo:          repo,
+		publishedRepo: publishedRepo,
+		buildService:  buildService,
+	}
+}
+
+type dashboardTrackingServiceImpl struct {
+	repo          repository.DashboardTrackingRepository
+	publishedRepo repository.PublishedRepository
+	buildService  BuildService
+}
+
+func (d *dashboardTrackingServiceImpl) GetTracking(ctx context.Context, packageId string, version string) (*view.DashboardTracking, error) {
+	versionName, err := d.getVersionName(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	ent, err := d.repo.GetTracking(ctx, packageId, versionName)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.DashboardTrackingNotFound,
+			Message: exception.DashboardTrackingNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId, "version": versionName},
+		}
+	}
+	result := entity.MakeDashboardTrackingView(*ent)
+	return &result, nil
+}
+
+func (d *dashboardTrackingServiceImpl) SetTracking(ctx context.Context, secCtx secctx.SecurityContext, packageId string, version string, req view.DashboardTrackingReq) (*view.DashboardTracking, error) {
+	packageEnt, err := d.publishedRepo.GetPackage(packageId)
+	if err != nil {
+		return nil, err
+	}
+	if packageEnt == nil || packageEnt.DeletedAt != nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PackageNotFound,
+			Message: exception.PackageNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId},
+		}
+	}
+	if packageEnt.Kind != entity.KIND_DASHBOARD {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidPackageKind,
+			Message: exception.InvalidPackageKindMsg,
+			Params:  map[string]interface{}{"kind": packageEnt.Kind, "allowedKind": entity.KIND_DASHBOARD},
+		}
+	}
+	versionName, err := d.getVersionName(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	versionPattern := ""
+	if req.Rule == view.DashboardTrackingRuleVersionPattern {
+		if _, err := regexp.Compile(req.VersionPattern); err != nil {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.InvalidTrackingVersionPattern,
+				Message: exception.InvalidTrackingVersionPatternMsg,
+				Params:  map[string]interface{}{"pattern": req.VersionPattern},
+				Debug:   err.Error(),
+			}
+		}
+		versionPattern = req.VersionPattern
+	}
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	ent := &entity.DashboardTrackingEntity{
+		PackageId:      packageId,
+		Version:        versionName,
+		Rule:           req.Rule,
+		VersionPattern: versionPattern,
+		Enabled:        enabled,
+		UpdatedBy:      secCtx.GetUserId(),
+		UpdatedAt:      time.Now(),
+	}
+	if err = d.repo.SaveTracking(ctx, ent); err != nil {
+		return nil, err
+	}
+	result := entity.MakeDashboardTrackingView(*ent)
+	return &result, nil
+}
+
+func (d *dashboardTrackingServiceImpl) DeleteTracking(ctx context.Context, packageId string, version string) error {
+	versionName, _, err := SplitVersionRevision(version)
+	if err != nil {
+		return err
+	}
+	deleted, err := d.repo.DeleteTracking(ctx, packageId, versionName)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.DashboardTrackingNotFound,
+			Message: exception.DashboardTrackingNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId, "version": versionName},
+		}
+	}
+	return nil
+}
+
+func (d *dashboardTrackingServiceImpl) GetRollForwards(ctx context.Context, packageId string, req view.DashboardRollForwardsReq) (*view.DashboardRollForwards, error) {
+	versionName, _, err := SplitVersionRevision(req.Version)
+	if err != nil {
+		return nil, err
+	}
+	ents, err := d.repo.GetRollForwards(ctx, packageId, versionName, req.Limit, req.Page)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.DashboardRollForwards{RollForwards: make([]view.DashboardRollForward, 0, len(ents))}
+	for _, ent := range ents {
+		result.RollForwards = append(result.RollForwards, entity.MakeDashboardRollForwardView(ent))
+	}
+	return result, nil
+}
+
+// getVersionName checks that the version is published and returns its name without revision
+func (d *dashboardTrackingServiceImpl) getVersionName(packageId string, version string) (string, error) {
+	versionEnt, err := d.publishedRepo.GetVersion(packageId, version)
+	if err != nil {
+		return "", err
+	}
+	if versionEnt == nil {
+		return "", &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PublishedPackageVersionNotFound,
+			Message: exception.PublishedPackageVersionNotFoundMsg,
+			Params:  map[string]interface{}{"version": version, "packageId": packageId},
+		}
+	}
+	return versionEnt.Version, nil
+}
+
+func (d *dashboardTrackingServiceImpl) ListenVersionPublished(publishNotificationService PublishNotificationService) {
+	utils.SafeAsync(func() {
+		count, err := d.repo.FailStaleRollForwards(context.Background(), time.Now().Add(-dashboardRollForwardTimeout), "roll forward was interrupted")
+		if err != nil {
+			log.Errorf("dashboard-tracking: failed to clean up interrupted roll forwards: %v", err)
+		} else if count > 0 {
+			log.Infof("dashboard-tracking: %d interrupted roll forward(s) marked as failed", count)
+		}
+		err = publishNotificationService.Subscribe(func(notification view.PublishNotification) {
+			utils.SafeAsync(func() {
+				d.onVersionPublished(notification)
+			})
+		})
+		if err != nil {
+			log.Errorf("dashboard-tracking: failed to subscribe to version published events: %v", err)
+			return
+		}
+		log.Info("dashboard-tracking: subscribed to version published events")
+	})
+}
+
+func (d *dashboardTrackingServiceImpl) onVersionPublished(notification view.PublishNotification) {
+	ctx := context.Background()
+	versionEnt, err := d.publishedRepo.GetVersion(notification.PackageId, view.MakeVersionRefKey(notification.Version, notification.Revision))
+	if err != nil {
+		log.Errorf("dashboard-tracking: failed to get version %s@%d of package %s: %v", notification.Version, notification.Revision, notification.PackageId, err)
+		return
+	}
+	// tracking rules follow releases only, it also stops the chain on the draft revisions created by roll forwards
+	if versionEnt == nil || versionEnt.Status != string(view.Release) {
+		return
+	}
+	trackings, err := d.repo.GetTrackingDashboards(ctx, notification.PackageId)
+	if err != nil {
+		log.Errorf("dashboard-tracking: failed to get dashboards tracking package %s: %v", notification.PackageId, err)
+		return
+	}
+	for _, tracking := range trackings {
+		if err = d.rollForward(ctx, tracking, notification); err != nil {
+			log.Errorf("dashboard-tracking: failed to roll forward version %s of dashboard %s: %v", tracking.Version, tracking.PackageId, err)
+		}
+	}
+}
+
+func (d *dashboardTrackingServiceImpl) rollForward(ctx context.Context, tracking entity.DashboardTrackingEntity, notification view.PublishNotification) error {
+	dashboardVersion, err := d.publishedRepo.GetVersion(tracking.PackageId, tracking.Version)
+	if err != nil {
+		return err
+	}
+	if dashboardVersion == nil {
+		return nil
+	}
+	refEnts, err := d.publishedRepo.GetVersionRefsV3(dashboardVersion.PackageId, dashboardVersion.Version, dashboardVersion.Revision)
+	if err != nil {
+		return err
+	}
+	var pattern *regexp.Regexp
+	if tracking.Rule == view.DashboardTrackingRuleVersionPattern {
+		pattern, err = regexp.Compile(tracking.VersionPattern)
+		if err != nil {
+			return fmt.Errorf("invalid version pattern '%s': %v", tracking.VersionPattern, err)
+		}
+	}
+	// every direct ref follows the rule, so concurrent roll forwards of the same dashboard converge to the same refs
+	refs := make([]view.BCRef, 0)
+	targets := make(map[string]string)
+	for _, refEnt := range refEnts {
+		if refEnt.ParentRefPackageId != "" {
+			continue
+		}
+		refs = append(refs, view.BCRef{RefId: refEnt.RefPackageId, Version: view.MakeVersionRefKey(refEnt.RefVersion, refEnt.RefRevision)})
+		if _, exists := targets[refEnt.RefPackageId]; exists {
+			continue
+		}
+		releaseVersions, err := d.repo.GetReleaseVersions(ctx, refEnt.RefPackageId)
+		if err != nil {
+			return err
+		}
+		targets[refEnt.RefPackageId], _ = selectTrackedVersion(releaseVersions, pattern)
+	}
+	newRefs, movedRefs := rollForwardRefs(refs, targets)
+	if len(movedRefs) == 0 {
+		return nil
+	}
+
+	rollForwardEnt := &entity.DashboardRollForwardEntity{
+		Id:               uuid.NewString(),
+		PackageId:        tracking.PackageId,
+		Version:          tracking.Version,
+		TriggerPackageId: notification.PackageId,
+		TriggerVersion:   notification.Version,
+		TriggerRevision:  notification.Revision,
+		MovedRefs:        movedRefs,
+		Status:           view.DashboardRollForwardStatusRunning,
+		CreatedAt:        time.Now(),
+	}
+	// Every instance receives the event, only the one which managed to insert the roll forward row publishes the dashboard.
+	created, err := d.repo.CreateRollForward(ctx, rollForwardEnt)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return nil
+	}
+	log.WithContext(ctx).Infof("dashboard-tracking: rolling forward version %s of dashboard %s, %d ref(s) moved", tracking.Version, tracking.PackageId, len(movedRefs))
+
+	err = d.publishRollForward(tracking, dashboardVersion, newRefs, rollForwardEnt)
+	finishedAt := time.Now()
+	rollForwardEnt.FinishedAt = &finishedAt
+	rollForwardEnt.Status = view.DashboardRollForwardStatusComplete
+	if err != nil {
+		rollForwardEnt.Status = view.DashboardRollForwardStatusError
+		rollForwardEnt.Details = err.Error()
+	}
+	return d.repo.UpdateRollForward(ctx, rollForwardEnt)
+}
+
+func (d *dashboardTrackingServiceImpl) publishRollForward(tracking entity.DashboardTrackingEntity, dashboardVersion *entity.PublishedVersionEntity, refs []view.BCRef, rollForwardEnt *entity.DashboardRollForwardEntity) error {
+	previousVersion, _, err := SplitVersionRevision(dashboardVersion.PreviousVersion)
+	if err != nil {
+		return err
+	}
+	previousVersionPackageId := dashboardVersion.PreviousVersionPackageId
+	if previousVersionPackageId == dashboardVersion.PackageId {
+		previousVersionPackageId = ""
+	}
+	config := view.BuildConfig{
+		PackageId:                dashboardVersion.PackageId,
+		Version:                  dashboardVersion.Version,
+		BuildType:                view.PublishType,
+		PreviousVersion:          previousVersion,
+		PreviousVersionPackageId: previousVersionPackageId,
+		Status:                   string(view.Draft),
+		Refs:                     refs,
+		CreatedBy:                tracking.UpdatedBy,
+		Metadata: view.BuildConfigMetadata{
+			VersionLabels: dashboardVersion.Labels,
+		},
+	}
+	build, err := d.buildService.PublishVersion(secctx.CreateFromId(tracking.UpdatedBy), config, nil, false, "", nil, true, true)
+	if err != nil {
+		return fmt.Errorf("failed to start dashboard publish: %v", err)
+	}
+	rollForwardEnt.PublishId = build.PublishId
+	if err = d.buildService.AwaitBuildCompletion(build.PublishId); err != nil {
+		return fmt.Errorf("failed to publish dashboard: %v", err)
+	}
+	publishedVersion, err := d.publishedRepo.GetVersion(dashboardVersion.PackageId, dashboardVersion.Version)
+	if err != nil {
+		return err
+	}
+	if publishedVersion != nil {
+		rollForwardEnt.Revision = publishedVersion.Revision
+	}
+	return nil
+}
+
+// selectTrackedVersion returns the first release version matching the pattern, all versions match if the pattern is nil
+func selectTrackedVersion(releaseVersions []entity.ReleaseVersionEntity, pattern *regexp.Regexp) (string, bool) {
+	for _, releaseVersion := range releaseVersions {
+		if pattern == nil || pattern.MatchString(releaseVersion.Version) {
+			return view.MakeVersionRefKey(releaseVersion.Version, releaseVersion.Revision), true
+		}
+	}
+	return "", false
+}
+
+// rollForwardRefs moves the refs to the target versions, refs without a target keep their versions
+func rollForwardRefs(refs []view.BCRef, targets map[string]string) ([]view.BCRef, []view.MovedRef) {
+	result := make([]view.BCRef, 0, len(refs))
+	movedRefs := make([]view.MovedRef, 0)
+	for _, ref := range refs {
+		target := targets[ref.RefId]
+		if target != "" && target != ref.Version {
+			movedRefs = append(movedRefs, view.MovedRef{RefId: ref.RefId, FromVersion: ref.Version, ToVersion: target})
+			ref.Version = target
+		}
+		result = append(result, ref)
+	}
+	// the same package could be referenced in several versions, they are moved to the same target
+	result = uniqueBCRefs(result)
+	sort.Slice(movedRefs, func(i, j int) bool {
+		if movedRefs[i].RefId != movedRefs[j].RefId {
+			return movedRefs[i].RefId < movedRefs[j].RefId
+		}
+		return movedRefs[i].FromVersion < movedRefs[j].FromVersion
+	})
+	return result, movedRefs
+}
+
+func uniqueBCRefs(refs []view.BCRef) []view.BCRef {
+	result := make([]view.BCRef, 0, len(refs))
+	unique := make(map[string]struct{}, len(refs))
+	for _, ref := range refs {
+		key := makeConfigRefUniqueKey(ref)
+		if _, exists := unique[key]; exists {
+			continue
+		}
+		unique[key] = struct{}{}
+		result = append(result, ref)
+	}
+	return result
+}
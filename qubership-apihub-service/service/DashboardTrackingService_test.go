@@ -0,0 +1,56 @@
+package service
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectTrackedVersion(t *testing.T) {
+	releaseVersions := []entity.ReleaseVersionEntity{
+		{Version: "2025.1-hotfix", Revision: 1},
+		{Version: "2024.4", Revision: 3},
+		{Version: "2024.3", Revision: 1},
+	}
+	version, found := selectTrackedVersion(releaseVersions, nil)
+	require.True(t, found)
+	require.Equal(t, "2025.1-hotfix@1", version)
+
+	version, found = selectTrackedVersion(releaseVersions, regexp.MustCompile(`^[0-9]{4}\.[1-4]$`))
+	require.True(t, found)
+	require.Equal(t, "2024.4@3", version)
+
+	_, found = selectTrackedVersion(releaseVersions, regexp.MustCompile(`^2023\.`))
+	require.False(t, found)
+	_, found = selectTrackedVersion(nil, nil)
+	require.False(t, found)
+}
+
+func TestRollForwardRefs(t *testing.T) {
+	refs := []view.BCRef{
+		{RefId: "QS.CORE.QUOTE", Version: "2024.3@1"},
+		{RefId: "QS.CORE.ORDER", Version: "2024.4@2"},
+		{RefId: "QS.CORE.CART", Version: "2024.1@1"},
+		{RefId: "QS.CORE.QUOTE", Version: "2024.2@1"},
+	}
+	targets := map[string]string{
+		"QS.CORE.QUOTE": "2024.4@3",
+		"QS.CORE.ORDER": "2024.4@2",
+	}
+	newRefs, movedRefs := rollForwardRefs(refs, targets)
+	require.Equal(t, []view.BCRef{
+		{RefId: "QS.CORE.QUOTE", Version: "2024.4@3"},
+		{RefId: "QS.CORE.ORDER", Version: "2024.4@2"},
+		{RefId: "QS.CORE.CART", Version: "2024.1@1"},
+	}, newRefs)
+	require.Equal(t, []view.MovedRef{
+		{RefId: "QS.CORE.QUOTE", FromVersion: "2024.2@1", ToVersion: "2024.4@3"},
+		{RefId: "QS.CORE.QUOTE", FromVersion: "2024.3@1", ToVersion: "2024.4@3"},
+	}, movedRefs)
+
+	_, movedRefs = rollForwardRefs(refs[1:3], targets)
+	require.Empty(t, movedRefs)
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	secctx "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type DependencyGraphService interface {
+	// GetDependencyGraph returns the transitive references graph of the package version
+	GetDependencyGraph(packageId string, version string) (*view.DependencyGraph, error)
+	// GetDependents returns dashboards which include the package version, dashboards not readable by the user are skipped
+	GetDependents(ctx context.Context, secCtx secctx.SecurityContext, packageId string, version string, req view.PackageVersionDependentsReq) (*view.PackageVersionDependents, error)
+}
+
+func NewDependencyGraphService(repo repository.DependencyGraphRepository,
+	publishedRepo repository.PublishedRepository,
+	packageVersionEnrichmentService PackageVersionEnrichmentService,
+	roleService RoleService) DependencyGraphService {
+	return &dependencyGraphServiceImpl{
+		repo:                            repo,
+		publishedRepo:                   publishedRepo,
+		packageVersionEnrichmentService: packageVersionEnrichmentService,
+		roleService:                     roleService,
+	}
+}
+
+type dependencyGraphServiceImpl struct {
+	repo                            repository.DependencyGraphRepository
+	publishedRepo                   repository.PublishedRepository
+	packageVersionEnrichmentService PackageVersionEnrichmentService
+	roleService                     RoleService
+}
+
+func (d *dependencyGraphServiceImpl) GetDependencyGraph(packageId string, version string) (*view.DependencyGraph, error) {
+	versionEnt, err := d.publishedRepo.GetVersion(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	if versionEnt == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PublishedVersionNotFound,
+			Message: exception.PublishedVersionNotFoundMsg,
+			Params:  map[string]interface{}{"version": version},
+		}
+	}
+	refEnts, err := d.publishedRepo.GetVersionRefsV3(versionEnt.PackageId, versionEnt.Version, versionEnt.Revision)
+	if err != nil {
+		return nil, err
+	}
+	packageVersions := map[string][]string{
+		versionEnt.PackageId: {view.MakeVersionRefKey(versionEnt.Version, versionEnt.Revision)},
+	}
+	for _, refEnt := range refEnts {
+		packageVersions[refEnt.RefPackageId] = append(packageVersions[refEnt.RefPackageId], view.MakeVersionRefKey(refEnt.RefVersion, refEnt.RefRevision))
+	}
+	packageRefs, err := d.packageVersionEnrichmentService.GetPackageVersionRefsMap(packageVersions)
+	if err != nil {
+		return nil, err
+	}
+	return makeDependencyGraph(versionEnt.PackageId, versionEnt.Version, versionEnt.Revision, refEnts, packageRefs), nil
+}
+
+func makeDependencyGraph(packageId string, version string, revision int, refEnts []entity.PublishedReferenceEntity, packageRefs map[string]view.PackageVersionRef) *view.DependencyGraph {
+	graph := &view.DependencyGraph{
+		Nodes:     make([]view.DependencyGraphNode, 0),
+		Edges:     make([]view.DependencyGraphEdge, 0),
+		Conflicts: make([]view.DependencyGraphConflict, 0),
+	}
+	nodeIndex := make(map[string]int)
+	addNode := func(nodePackageId string, nodeVersion string, nodeRevision int) string {
+		id := view.MakePackageRefKey(nodePackageId, nodeVersion, nodeRevision)
+		if _, exists := nodeIndex[id]; exists {
+			return id
+		}
+		node := view.DependencyGraphNode{
+			Id:        id,
+			PackageId: nodePackageId,
+			Version:   view.MakeVersionRefKey(nodeVersion, nodeRevision),
+			Excluded:  true,
+		}
+		if ref, exists := packageRefs[id]; exists {
+			node.Name = ref.RefPackageName
+			node.Kind = ref.Kind
+			node.Status = ref.Status
+			node.NotLatestRevision = ref.NotLatestRevision
+		}
+		nodeIndex[id] = len(graph.Nodes)
+		graph.Nodes = append(graph.Nodes, node)
+		return id
+	}
+	rootId := addNode(packageId, version, revision)
+	graph.Nodes[nodeIndex[rootId]].Root = true
+	graph.Nodes[nodeIndex[rootId]].Excluded = false
+
+	edges := make(map[string]int)
+	packageVersions := make(map[string][]string)
+	selectedVersions := make(map[string]string)
+	for _, refEnt := range refEnts {
+		sourceId := rootId
+		if refEnt.ParentRefPackageId != "" {
+			sourceId = addNode(refEnt.ParentRefPackageId, refEnt.ParentRefVersion, refEnt.ParentRefRevision)
+		}
+		targetId := addNode(refEnt.RefPackageId, refEnt.RefVersion, refEnt.RefRevision)
+		if !refEnt.Excluded {
+			graph.Nodes[nodeIndex[targetId]].Excluded = false
+			selectedVersions[refEnt.RefPackageId] = view.MakeVersionRefKey(refEnt.RefVersion, refEnt.RefRevision)
+		}
+		edgeKey := sourceId + "|" + targetId
+		if i, exists := edges[edgeKey]; exists {
+			graph.Edges[i].Excluded = graph.Edges[i].Excluded && refEnt.Excluded
+		} else {
+			edges[edgeKey] = len(graph.Edges)
+			graph.Edges = append(graph.Edges, view.DependencyGraphEdge{Source: sourceId, Target: targetId, Excluded: refEnt.Excluded})
+		}
+		refVersion := view.MakeVersionRefKey(refEnt.RefVersion, refEnt.RefRevision)
+		if !utils.SliceContains(packageVersions[refEnt.RefPackageId], refVersion) {
+			packageVersions[refEnt.RefPackageId] = append(packageVersions[refEnt.RefPackageId], refVersion)
+		}
+	}
+
+	// the same package referenced in different versions, conflicts are resolved on publish by excluding all versions except the first one
+	for refPackageId, versions := range packageVersions {
+		if len(versions) < 2 {
+			continue
+		}
+		sort.Strings(versions)
+		graph.Conflicts = append(graph.Conflicts, view.DependencyGraphConflict{
+			PackageId:       refPackageId,
+			Versions:        versions,
+			SelectedVersion: selectedVersions[refPackageId],
+		})
+	}
+
+	sort.SliceStable(graph.Nodes[1:], func(i, j int) bool {
+		return graph.Nodes[i+1].Id < graph.Nodes[j+1].Id
+	})
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].Source != graph.Edges[j].Source {
+			return graph.Edges[i].Source < graph.Edges[j].Source
+		}
+		return graph.Edges[i].Target < graph.Edges[j].Target
+	})
+	sort.Slice(graph.Conflicts, func(i, j int) bool {
+		return graph.Conflicts[i].PackageId < graph.Conflicts[j].PackageId
+	})
+	return graph
+}
+
+func (d *dependencyGraphServiceImpl) GetDependents(ctx context.Context, secCtx secctx.SecurityContext, packageId string, version string, req view.PackageVersionDependentsReq) (*view.PackageVersionDependents, error) {
+	versionName, revision, err := SplitVersionRevision(version)
+	if err != nil {
+		return nil, err
+	}
+	ents, err := d.repo.GetDependents(ctx, packageId, versionName, revision, req.AllRevisions, req.Limit, req.Page)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.PackageVersionDependents{Dependents: make([]view.PackageVersionDependent, 0, len(ents))}
+	permissions := make(map[string]bool)
+	for _, ent := range ents {
+		readable, checked := permissions[ent.PackageId]
+		if !checked {
+			readable, err = d.roleService.HasRequiredPermissions(secCtx, ent.PackageId, view.ReadPermission)
+			if err != nil {
+				return nil, err
+			}
+			permissions[ent.PackageId] = readable
+		}
+		if readable {
+			result.Dependents = append(result.Dependents, entity.MakePackageVersionDependentView(ent))
+		}
+	}
+	return result, nil
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	Id       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	Id          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	Id   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// MakeDependencyGraphML renders the graph in GraphML format
+func MakeDependencyGraphML(graph *view.DependencyGraph) ([]byte, error) {
+	doc := graphML{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{Id: "packageId", For: "node", AttrName: "packageId", AttrType: "string"},
+			{Id: "name", For: "node", AttrName: "name", AttrType: "string"},
+			{Id: "kind", For: "node", AttrName: "kind", AttrType: "string"},
+			{Id: "version", For: "node", AttrName: "version", AttrType: "string"},
+			{Id: "status", For: "node", AttrName: "status", AttrType: "string"},
+			{Id: "root", For: "node", AttrName: "root", AttrType: "boolean"},
+			{Id: "notLatestRevision", For: "node", AttrName: "notLatestRevision", AttrType: "boolean"},
+			{Id: "excluded", For: "all", AttrName: "excluded", AttrType: "boolean"},
+		},
+		Graph: graphMLGraph{Id: "dependencies", EdgeDefault: "directed"},
+	}
+	for _, node := range graph.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			Id: node.Id,
+			Data: []graphMLData{
+				{Key: "packageId", Value: node.PackageId},
+				{Key: "name", Value: node.Name},
+				{Key: "kind", Value: node.Kind},
+				{Key: "version", Value: node.Version},
+				{Key: "status", Value: node.Status},
+				{Key: "root", Value: fmt.Sprint(node.Root)},
+				{Key: "notLatestRevision", Value: fmt.Sprint(node.NotLatestRevision)},
+				{Key: "excluded", Value: fmt.Sprint(node.Excluded)},
+			},
+		})
+	}
+	for _, edge := range graph.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: edge.Source,
+			Target: edge.Target,
+			Data:   []graphMLData{{Key: "excluded", Value: fmt.Sprint(edge.Excluded)}},
+		})
+	}
+	content, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), content...), nil
+}
+
+// MakeDependencyGraphDot renders the graph in Graphviz DOT format, dashboards are drawn as boxes and excluded references as dashed lines
+func MakeDependencyGraphDot(graph *view.DependencyGraph) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("digraph dependencies {\n")
+	for _, node := range graph.Nodes {
+		name := node.Name
+		if name == "" {
+			name = node.PackageId
+		}
+		attrs := []string{fmt.Sprintf("label=%s", dotQuote(name+"\n"+node.Version))}
+		if node.Kind == entity.KIND_DASHBOARD {
+			attrs = append(attrs, "shape=box")
+		}
+		if node.Root {
+			attrs = append(attrs, "penwidth=2")
+		}
+		if node.Excluded {
+			attrs = append(attrs, "style=dashed")
+		}
+		buf.WriteString(fmt.Sprintf("  %s [%s];\n", dotQuote(node.Id), strings.Join(attrs, ", ")))
+	}
+	for _, edge := range graph.Edges {
+		if edge.Excluded {
+			buf.WriteString(fmt.Sprintf("  %s -> %s [style=dashed];\n", dotQuote(edge.Source), dotQuote(edge.Target)))
+		} else {
+			buf.WriteString(fmt.Sprintf("  %s -> %s;\n", dotQuote(edge.Source), dotQuote(edge.Target)))
+		}
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+func dotQuote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	return `"` + value + `"`
+}
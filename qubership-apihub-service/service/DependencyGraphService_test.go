@@ -0,0 +1,70 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeDependencyGraph(t *testing.T) {
+	refEnts := []entity.PublishedReferenceEntity{
+		{RefPackageId: "QS.CORE.DSH", RefVersion: "2024.1", RefRevision: 1},
+		{RefPackageId: "QS.CORE.QUOTE", RefVersion: "2024.1", RefRevision: 2},
+		{RefPackageId: "QS.CORE.QUOTE", RefVersion: "2023.4", RefRevision: 1, ParentRefPackageId: "QS.CORE.DSH", ParentRefVersion: "2024.1", ParentRefRevision: 1, Excluded: true},
+	}
+	packageRefs := map[string]view.PackageVersionRef{
+		"QS.ROOT@2024.1@3":       {RefPackageName: "root", Kind: entity.KIND_DASHBOARD, Status: "release"},
+		"QS.CORE.DSH@2024.1@1":   {RefPackageName: "core", Kind: entity.KIND_DASHBOARD, Status: "release"},
+		"QS.CORE.QUOTE@2024.1@2": {RefPackageName: "quote", Kind: entity.KIND_PACKAGE, Status: "release", NotLatestRevision: true},
+	}
+	graph := makeDependencyGraph("QS.ROOT", "2024.1", 3, refEnts, packageRefs)
+
+	require.Len(t, graph.Nodes, 4)
+	require.Equal(t, "QS.ROOT@2024.1@3", graph.Nodes[0].Id)
+	require.True(t, graph.Nodes[0].Root)
+	require.False(t, graph.Nodes[0].Excluded)
+	require.Equal(t, "QS.CORE.DSH@2024.1@1", graph.Nodes[1].Id)
+	require.Equal(t, "QS.CORE.QUOTE@2023.4@1", graph.Nodes[2].Id)
+	require.True(t, graph.Nodes[2].Excluded)
+	require.Equal(t, "QS.CORE.QUOTE@2024.1@2", graph.Nodes[3].Id)
+	require.False(t, graph.Nodes[3].Excluded)
+	require.True(t, graph.Nodes[3].NotLatestRevision)
+	require.Equal(t, "quote", graph.Nodes[3].Name)
+
+	require.Equal(t, []view.DependencyGraphEdge{
+		{Source: "QS.CORE.DSH@2024.1@1", Target: "QS.CORE.QUOTE@2023.4@1", Excluded: true},
+		{Source: "QS.ROOT@2024.1@3", Target: "QS.CORE.DSH@2024.1@1"},
+		{Source: "QS.ROOT@2024.1@3", Target: "QS.CORE.QUOTE@2024.1@2"},
+	}, graph.Edges)
+
+	require.Equal(t, []view.DependencyGraphConflict{
+		{PackageId: "QS.CORE.QUOTE", Versions: []string{"2023.4@1", "2024.1@2"}, SelectedVersion: "2024.1@2"},
+	}, graph.Conflicts)
+}
+
+func TestMakeDependencyGraphExport(t *testing.T) {
+	graph := &view.DependencyGraph{
+		Nodes: []view.DependencyGraphNode{
+			{Id: "QS.ROOT@1@1", PackageId: "QS.ROOT", Name: `root "dashboard"`, Kind: entity.KIND_DASHBOARD, Version: "1@1", Root: true},
+			{Id: "QS.PKG@2@1", PackageId: "QS.PKG", Name: "pkg & co", Kind: entity.KIND_PACKAGE, Version: "2@1", Excluded: true},
+		},
+		Edges: []view.DependencyGraphEdge{{Source: "QS.ROOT@1@1", Target: "QS.PKG@2@1", Excluded: true}},
+	}
+
+	dot := string(MakeDependencyGraphDot(graph))
+	require.True(t, strings.HasPrefix(dot, "digraph dependencies {\n"))
+	require.Contains(t, dot, `"QS.ROOT@1@1" [label="root \"dashboard\"\n1@1", shape=box, penwidth=2];`)
+	require.Contains(t, dot, `"QS.PKG@2@1" [label="pkg & co\n2@1", style=dashed];`)
+	require.Contains(t, dot, `"QS.ROOT@1@1" -> "QS.PKG@2@1" [style=dashed];`)
+
+	graphML, err := MakeDependencyGraphML(graph)
+	require.NoError(t, err)
+	content := string(graphML)
+	require.Contains(t, content, `<graph id="dependencies" edgedefault="directed">`)
+	require.Contains(t, content, `<node id="QS.ROOT@1@1">`)
+	require.Contains(t, content, `<data key="name">pkg &amp; co</data>`)
+	require.Contains(t, content, `<edge source="QS.ROOT@1@1" target="QS.PKG@2@1">`)
+}
@@ -0,0 +1,365 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	secctx "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	sunsetAlertsJobName = "sunsetAlerts"
+	sunsetAlertsTimeout = 10 * time.Minute
+)
+
+type DeprecationService interface {
+	GetPolicy(ctx context.Context, packageId string, apiType string, operationId string) (*view.OperationDeprecationPolicy, error)
+	SetPolicy(ctx context.Context, secCtx secctx.SecurityContext, packageId string, apiType string, operationId string, req view.OperationDeprecationPolicyReq) (*view.OperationDeprecationPolicy, error)
+	DeletePolicy(ctx context.Context, packageId string, apiType string, operationId string) error
+	// GetPolicies returns policies of the packages mapped by package id, api type and operation id
+	GetPolicies(ctx context.Context, packageIds []string) (map[string]map[string]map[string]view.OperationDeprecationPolicy, error)
+	GetSunsetCalendar(ctx context.Context, workspaceId string, req view.SunsetCalendarReq) (*view.SunsetCalendar, error)
+	// CaptureSpecPolicies saves deprecation policies declared by x-sunset, x-deprecated-since and x-replaced-by extensions of the published operations
+	// and deletes the captured policies of the operations which are neither deprecated nor declare the extensions anymore.
+	// Policies are kept per package, so they are captured only from the latest release of the package.
+	CaptureSpecPolicies(ctx context.Context, packageId string, version string, status string, publishedBy string, operations []*entity.OperationEntity) error
+	// StartSunsetAlertsJob schedules a job which tracks 'sunset_date_passed' event for operations which are still present in a release after the sunset date
+	StartSunsetAlertsJob(schedule string) error
+}
+
+func NewDeprecationService(repo repository.DeprecationPolicyRepository, publishedRepo repository.PublishedRepository, atService ActivityTrackingService, backgroundJobService BackgroundJobService) DeprecationService {
+	return &deprecationServiceImpl{
+		repo:                 repo,
+		publishedRepo:        publishedRepo,
+		atService:            atService,
+		backgroundJobService: backgroundJobService,
+	}
+}
+
+type deprecationServiceImpl struct {
+	repo                 repository.DeprecationPolicyRepository
+	publishedRepo        repository.PublishedRepository
+	atService            ActivityTrackingService
+	backgroundJobService BackgroundJobService
+}
+
+func (d *deprecationServiceImpl) GetPolicy(ctx context.Context, packageId string, apiType string, operationId string) (*view.OperationDeprecationPolicy, error) {
+	ent, err := d.repo.GetPolicy(ctx, packageId, apiType, operationId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.DeprecationPolicyNotFound,
+			Message: exception.DeprecationPolicyNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId, "apiType": apiType, "operationId": operationId},
+		}
+	}
+	policy := entity.MakeOperationDeprecationPolicyView(*ent)
+	return &policy, nil
+}
+
+func (d *deprecationServiceImpl) SetPolicy(ctx context.Context, secCtx secctx.SecurityContext, packageId string, apiType string, operationId string, req view.OperationDeprecationPolicyReq) (*view.OperationDeprecationPolicy, error) {
+	exists, err := d.repo.OperationExists(ctx, packageId, apiType, operationId)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PackageOperationNotFound,
+			Message: exception.PackageOperationNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId, "operationId": operationId},
+		}
+	}
+	ent := &entity.OperationDeprecationPolicyEntity{
+		PackageId:       packageId,
+		OperationId:     operationId,
+		ApiType:         apiType,
+		DeprecatedSince: req.DeprecatedSince,
+		Description:     req.Description,
+		Source:          view.DeprecationPolicySourceManual,
+		UpdatedBy:       secCtx.GetUserId(),
+		UpdatedAt:       time.Now(),
+	}
+	if req.SunsetDate != "" {
+		sunsetDate, err := time.Parse(view.SunsetDateFormat, req.SunsetDate)
+		if err != nil {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.InvalidSunsetDate,
+				Message: exception.InvalidSunsetDateMsg,
+				Params:  map[string]interface{}{"value": req.SunsetDate},
+				Debug:   err.Error(),
+			}
+		}
+		ent.SunsetDate = &sunsetDate
+	}
+	if req.Replacement != nil {
+		ent.ReplacementPackageId = req.Replacement.PackageId
+		ent.ReplacementOperationId = req.Replacement.OperationId
+	}
+	if err = d.repo.SavePolicy(ctx, ent); err != nil {
+		return nil, err
+	}
+	policy := entity.MakeOperationDeprecationPolicyView(*ent)
+	return &policy, nil
+}
+
+func (d *deprecationServiceImpl) DeletePolicy(ctx context.Context, packageId string, apiType string, operationId string) error {
+	deleted, err := d.repo.DeletePolicy(ctx, packageId, apiType, operationId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.DeprecationPolicyNotFound,
+			Message: exception.DeprecationPolicyNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId, "apiType": apiType, "operationId": operationId},
+		}
+	}
+	return nil
+}
+
+func (d *deprecationServiceImpl) GetPolicies(ctx context.Context, packageIds []string) (map[string]map[string]map[string]view.OperationDeprecationPolicy, error) {
+	ents, err := d.repo.GetPolicies(ctx, packageIds)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]map[string]map[string]view.OperationDeprecationPolicy)
+	for _, ent := range ents {
+		if result[ent.PackageId] == nil {
+			result[ent.PackageId] = make(map[string]map[string]view.OperationDeprecationPolicy)
+		}
+		if result[ent.PackageId][ent.ApiType] == nil {
+			result[ent.PackageId][ent.ApiType] = make(map[string]view.OperationDeprecationPolicy)
+		}
+		result[ent.PackageId][ent.ApiType][ent.OperationId] = entity.MakeOperationDeprecationPolicyView(ent)
+	}
+	return result, nil
+}
+
+func (d *deprecationServiceImpl) GetSunsetCalendar(ctx context.Context, workspaceId string, req view.SunsetCalendarReq) (*view.SunsetCalendar, error) {
+	workspace, err := d.publishedRepo.GetPackage(workspaceId)
+	if err != nil {
+		return nil, err
+	}
+	if workspace == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PackageNotFound,
+			Message: exception.PackageNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": workspaceId},
+		}
+	}
+	ents, err := d.repo.GetSunsetCalendar(ctx, workspaceId, req.From, req.To, req.Limit, req.Page)
+	if err != nil {
+		return nil, err
+	}
+	today := currentDate()
+	result := &view.SunsetCalendar{Operations: make([]view.SunsetCalendarItem, 0, len(ents))}
+	for _, ent := range ents {
+		result.Operations = append(result.Operations, entity.MakeSunsetCalendarItemView(ent, today))
+	}
+	return result, nil
+}
+
+func (d *deprecationServiceImpl) CaptureSpecPolicies(ctx context.Context, packageId string, version string, status string, publishedBy string, operations []*entity.OperationEntity) error {
+	if status != string(view.Release) {
+		return nil
+	}
+	releases, err := d.repo.GetReleaseVersions(ctx, packageId)
+	if err != nil {
+		return err
+	}
+	if !isLatestRelease(packageId, version, releases) {
+		log.WithContext(ctx).Debugf("Deprecation policies are not captured from version %s of package %s since it is not the latest release", version, packageId)
+		return nil
+	}
+	existingEnts, err := d.repo.GetPolicies(ctx, []string{packageId})
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]entity.OperationDeprecationPolicyEntity, len(existingEnts))
+	for _, ent := range existingEnts {
+		existing[makeDeprecationPolicyKey(ent.ApiType, ent.OperationId)] = ent
+	}
+
+	now := time.Now()
+	policies := make([]entity.OperationDeprecationPolicyEntity, 0)
+	// operation ids of the outdated captured policies by api type
+	outdated := make(map[string][]string)
+	for _, operation := range operations {
+		specPolicy, declared := extractSpecDeprecationPolicy(operation.CustomTags)
+		current, exists := existing[makeDeprecationPolicyKey(operation.Type, operation.OperationId)]
+		if exists && current.Source == view.DeprecationPolicySourceManual {
+			continue
+		}
+		if !declared && !operation.Deprecated {
+			if exists {
+				outdated[operation.Type] = append(outdated[operation.Type], operation.OperationId)
+			}
+			continue
+		}
+		specPolicy.PackageId = packageId
+		specPolicy.OperationId = operation.OperationId
+		specPolicy.ApiType = operation.Type
+		specPolicy.Description = operation.DeprecatedInfo
+		specPolicy.Source = view.DeprecationPolicySourceSpec
+		if specPolicy.DeprecatedSince == "" {
+			if exists && current.DeprecatedSince != "" {
+				specPolicy.DeprecatedSince = current.DeprecatedSince
+			} else if operation.Deprecated {
+				specPolicy.DeprecatedSince = version
+			}
+		}
+		if exists && sameDeprecationPolicy(current, specPolicy) {
+			continue
+		}
+		specPolicy.UpdatedBy = publishedBy
+		specPolicy.UpdatedAt = now
+		policies = append(policies, specPolicy)
+	}
+	for apiType, operationIds := range outdated {
+		log.WithContext(ctx).Debugf("Deleting %d %s deprecation policies which are not declared in version %s of package %s anymore", len(operationIds), apiType, version, packageId)
+		if err = d.repo.DeleteSpecPolicies(ctx, packageId, apiType, operationIds); err != nil {
+			return err
+		}
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+	log.WithContext(ctx).Debugf("Saving %d deprecation policies captured from version %s of package %s", len(policies), version, packageId)
+	return d.repo.SaveSpecPolicies(ctx, policies)
+}
+
+// isLatestRelease checks that the version is the most recently created release of the package and it is based on the previous one,
+// so new revisions of older releases and patches of older releases published as new versions are not the latest
+func isLatestRelease(packageId string, version string, releases []entity.PolicyReleaseVersionEntity) bool {
+	if len(releases) == 0 || releases[0].Version != version {
+		return false
+	}
+	latest := releases[0]
+	if len(releases) == 1 || latest.PreviousVersion == "" ||
+		(latest.PreviousVersionPackageId != "" && latest.PreviousVersionPackageId != packageId) {
+		return true
+	}
+	return latest.PreviousVersion == releases[1].Version
+}
+
+func makeDeprecationPolicyKey(apiType string, operationId string) string {
+	return apiType + ":" + operationId
+}
+
+// extractSpecDeprecationPolicy reads the deprecation schedule from operation extensions. Returns false if none of them is declared.
+// Values which could not be parsed are ignored.
+func extractSpecDeprecationPolicy(customTags map[string]interface{}) (entity.OperationDeprecationPolicyEntity, bool) {
+	var policy entity.OperationDeprecationPolicyEntity
+	declared := false
+	if deprecatedSince, ok := customTags[view.DeprecatedSinceExtension].(string); ok && deprecatedSince != "" {
+		policy.DeprecatedSince = deprecatedSince
+		declared = true
+	}
+	if sunset, ok := customTags[view.SunsetExtension].(string); ok {
+		if sunsetDate, ok := parseSunsetDate(sunset); ok {
+			policy.SunsetDate = &sunsetDate
+			declared = true
+		}
+	}
+	switch replacedBy := customTags[view.ReplacedByExtension].(type) {
+	case string:
+		if replacedBy != "" {
+			policy.ReplacementOperationId = replacedBy
+			declared = true
+		}
+	case map[string]interface{}:
+		if operationId, ok := replacedBy["operationId"].(string); ok && operationId != "" {
+			policy.ReplacementOperationId = operationId
+			policy.ReplacementPackageId, _ = replacedBy["packageId"].(string)
+			declared = true
+		}
+	}
+	return policy, declared
+}
+
+// parseSunsetDate accepts a date, a RFC 3339 timestamp or a HTTP date as used by the Sunset header (RFC 8594)
+func parseSunsetDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{view.SunsetDateFormat, time.RFC3339, http.TimeFormat} {
+		if t, err := time.Parse(layout, value); err == nil {
+			y, m, d := t.UTC().Date()
+			return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), true
+		}
+	}
+	return time.Time{}, false
+}
+
+func sameDeprecationPolicy(a entity.OperationDeprecationPolicyEntity, b entity.OperationDeprecationPolicyEntity) bool {
+	sameSunset := (a.SunsetDate == nil && b.SunsetDate == nil) ||
+		(a.SunsetDate != nil && b.SunsetDate != nil && a.SunsetDate.Equal(*b.SunsetDate))
+	return sameSunset &&
+		a.ApiType == b.ApiType &&
+		a.DeprecatedSince == b.DeprecatedSince &&
+		a.ReplacementPackageId == b.ReplacementPackageId &&
+		a.ReplacementOperationId == b.ReplacementOperationId &&
+		a.Description == b.Description &&
+		a.Source == b.Source
+}
+
+func currentDate() time.Time {
+	y, m, d := time.Now().UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func (d *deprecationServiceImpl) StartSunsetAlertsJob(schedule string) error {
+	if strings.TrimSpace(schedule) == "" {
+		log.Info("Sunset alerts job is not scheduled (empty schedule)")
+		return nil
+	}
+	return d.backgroundJobService.RegisterJob(BackgroundJobDefinition{
+		Name:        sunsetAlertsJobName,
+		Description: "Tracks sunset date passed events for operations which are still present in a release after the sunset date",
+		Schedule:    schedule,
+		Distributed: true,
+		Timeout:     sunsetAlertsTimeout,
+		MaxRetries:  2,
+		Job:         BackgroundJobFunc(d.sendSunsetAlerts),
+	})
+}
+
+func (d *deprecationServiceImpl) sendSunsetAlerts(ctx context.Context) error {
+	passed, err := d.repo.MarkPassedSunsets(ctx, currentDate())
+	if err != nil {
+		return fmt.Errorf("failed to check passed sunset dates: %w", err)
+	}
+	for _, p := range passed {
+		log.Warnf("Sunset date %s of operation %s has passed, but the operation is still present in release %s@%d of package %s",
+			p.SunsetDate.Format(view.SunsetDateFormat), p.OperationId, p.ReleaseVersion, p.ReleaseRevision, p.PackageId)
+		d.atService.TrackEvent(view.ActivityTrackingEvent{
+			Type: view.ATETSunsetDatePassed,
+			Data: map[string]interface{}{
+				"operationId": p.OperationId,
+				"apiType":     p.ApiType,
+				"sunsetDate":  p.SunsetDate.Format(view.SunsetDateFormat),
+				"version":     p.ReleaseVersion,
+				"revision":    p.ReleaseRevision,
+			},
+			PackageId: p.PackageId,
+			Date:      time.Now(),
+		})
+	}
+	if len(passed) > 0 {
+		log.Infof("Sunset alerts sent for %d operations", len(passed))
+	}
+	return nil
+}
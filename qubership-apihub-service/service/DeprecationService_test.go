@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSunsetDate(t *testing.T) {
+	expected := time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC)
+	for _, value := range []string{"2025-06-30", " 2025-06-30 ", "2025-06-30T23:00:00Z", "2025-06-30T10:00:00+03:00", "Mon, 30 Jun 2025 23:59:59 GMT"} {
+		date, ok := parseSunsetDate(value)
+		require.True(t, ok, value)
+		require.Equal(t, expected, date, value)
+	}
+	for _, value := range []string{"", "30.06.2025", "soon"} {
+		_, ok := parseSunsetDate(value)
+		require.False(t, ok, value)
+	}
+}
+
+func TestExtractSpecDeprecationPolicy(t *testing.T) {
+	_, declared := extractSpecDeprecationPolicy(nil)
+	require.False(t, declared)
+	_, declared = extractSpecDeprecationPolicy(map[string]interface{}{view.SunsetExtension: "not a date", "x-other": "value"})
+	require.False(t, declared)
+
+	policy, declared := extractSpecDeprecationPolicy(map[string]interface{}{
+		view.DeprecatedSinceExtension: "2024.1",
+		view.SunsetExtension:          "2025-06-30",
+		view.ReplacedByExtension:      "get-v2-quote",
+	})
+	require.True(t, declared)
+	require.Equal(t, "2024.1", policy.DeprecatedSince)
+	require.NotNil(t, policy.SunsetDate)
+	require.Equal(t, "2025-06-30", policy.SunsetDate.Format(view.SunsetDateFormat))
+	require.Equal(t, "get-v2-quote", policy.ReplacementOperationId)
+	require.Empty(t, policy.ReplacementPackageId)
+
+	policy, declared = extractSpecDeprecationPolicy(map[string]interface{}{
+		view.ReplacedByExtension: map[string]interface{}{"packageId": "QS.CORE.QUOTE", "operationId": "get-v2-quote"},
+	})
+	require.True(t, declared)
+	require.Nil(t, policy.SunsetDate)
+	require.Equal(t, "QS.CORE.QUOTE", policy.ReplacementPackageId)
+	require.Equal(t, "get-v2-quote", policy.ReplacementOperationId)
+}
+
+func TestCaptureSpecPolicies(t *testing.T) {
+	repo := &testDeprecationPolicyRepository{releases: []entity.PolicyReleaseVersionEntity{
+		{Version: "2024.2", PreviousVersion: "2024.1"},
+		{Version: "2024.1"},
+	}, policies: []entity.OperationDeprecationPolicyEntity{
+		{PackageId: "QS.QUOTE", ApiType: "rest", OperationId: "quote-get", DeprecatedSince: "2024.1", Source: view.DeprecationPolicySourceSpec},
+		{PackageId: "QS.QUOTE", ApiType: "rest", OperationId: "quote-post", DeprecatedSince: "2024.1", Source: view.DeprecationPolicySourceManual},
+	}}
+	d := &deprecationServiceImpl{repo: repo}
+	operations := []*entity.OperationEntity{
+		// the same operation id of other api type does not affect the rest policy
+		{OperationId: "quote-get", Type: "graphql", Deprecated: true},
+		// extensions are removed from the rest operation
+		{OperationId: "quote-get", Type: "rest"},
+		{OperationId: "quote-post", Type: "rest"},
+	}
+	require.NoError(t, d.CaptureSpecPolicies(context.Background(), "QS.QUOTE", "2024.2", string(view.Release), "user", operations))
+
+	require.Len(t, repo.saved, 1)
+	require.Equal(t, "graphql", repo.saved[0].ApiType)
+	require.Equal(t, "2024.2", repo.saved[0].DeprecatedSince)
+	require.Equal(t, map[string][]string{"rest": {"quote-get"}}, repo.deleted, "manual policy is kept")
+}
+
+func TestCaptureSpecPoliciesNotLatestRelease(t *testing.T) {
+	sunsetDate := time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC)
+	repo := &testDeprecationPolicyRepository{releases: []entity.PolicyReleaseVersionEntity{
+		{Version: "2024.2", PreviousVersion: "2024.1"},
+		{Version: "2024.1"},
+	}, policies: []entity.OperationDeprecationPolicyEntity{
+		{PackageId: "QS.QUOTE", ApiType: "rest", OperationId: "quote-get", DeprecatedSince: "2024.2", SunsetDate: &sunsetDate, Source: view.DeprecationPolicySourceSpec},
+	}}
+	d := &deprecationServiceImpl{repo: repo}
+	ctx := context.Background()
+
+	// the draft neither declares the sunset nor deprecates the operation
+	require.NoError(t, d.CaptureSpecPolicies(ctx, "QS.QUOTE", "2024.3", string(view.Draft), "user", []*entity.OperationEntity{
+		{OperationId: "quote-get", Type: "rest"},
+	}))
+	// the draft deprecates the operation without the extensions
+	require.NoError(t, d.CaptureSpecPolicies(ctx, "QS.QUOTE", "2024.3", string(view.Draft), "user", []*entity.OperationEntity{
+		{OperationId: "quote-get", Type: "rest", Deprecated: true},
+	}))
+	// new revision of the older release
+	require.NoError(t, d.CaptureSpecPolicies(ctx, "QS.QUOTE", "2024.1", string(view.Release), "user", []*entity.OperationEntity{
+		{OperationId: "quote-get", Type: "rest"},
+	}))
+	require.Empty(t, repo.saved)
+	require.Empty(t, repo.deleted)
+}
+
+func TestIsLatestRelease(t *testing.T) {
+	require.False(t, isLatestRelease("QS.QUOTE", "2024.1", nil))
+	require.True(t, isLatestRelease("QS.QUOTE", "2024.1", []entity.PolicyReleaseVersionEntity{{Version: "2024.1"}}))
+
+	releases := []entity.PolicyReleaseVersionEntity{{Version: "2024.2", PreviousVersion: "2024.1"}, {Version: "2024.1"}}
+	require.True(t, isLatestRelease("QS.QUOTE", "2024.2", releases))
+	require.False(t, isLatestRelease("QS.QUOTE", "2024.1", releases), "new revision of the older release")
+
+	// patch of the older release published as a new version
+	releases = append([]entity.PolicyReleaseVersionEntity{{Version: "2024.1.1", PreviousVersion: "2024.1"}}, releases...)
+	require.False(t, isLatestRelease("QS.QUOTE", "2024.1.1", releases))
+
+	releases = []entity.PolicyReleaseVersionEntity{{Version: "2024.2", PreviousVersion: "2024.1", PreviousVersionPackageId: "QS.OLD.QUOTE"}, {Version: "2024.1"}}
+	require.True(t, isLatestRelease("QS.QUOTE", "2024.2", releases), "previous version of the other package")
+}
+
+type testDeprecationPolicyRepository struct {
+	repository.DeprecationPolicyRepository
+	releases []entity.PolicyReleaseVersionEntity
+	policies []entity.OperationDeprecationPolicyEntity
+	saved    []entity.OperationDeprecationPolicyEntity
+	deleted  map[string][]string
+}
+
+func (r *testDeprecationPolicyRepository) GetReleaseVersions(ctx context.Context, packageId string) ([]entity.PolicyReleaseVersionEntity, error) {
+	return r.releases, nil
+}
+
+func (r *testDeprecationPolicyRepository) GetPolicies(ctx context.Context, packageIds []string) ([]entity.OperationDeprecationPolicyEntity, error) {
+	return r.policies, nil
+}
+
+func (r *testDeprecationPolicyRepository) SaveSpecPolicies(ctx context.Context, ents []entity.OperationDeprecationPolicyEntity) error {
+	r.saved = append(r.saved, ents...)
+	return nil
+}
+
+func (r *testDeprecationPolicyRepository) DeleteSpecPolicies(ctx context.Context, packageId string, apiType string, operationIds []string) error {
+	if r.deleted == nil {
+		r.deleted = make(map[string][]string)
+	}
+	r.deleted[apiType] = append(r.deleted[apiType], operationIds...)
+	return nil
+}
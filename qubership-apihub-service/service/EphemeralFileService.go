@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,6 +13,7 @@ import (
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/metrics"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
 	"github.com/google/uuid"
 )
 
@@ -22,6 +22,7 @@ type EphemeralFileService interface {
 	// No ownership check — used so download can 404 before JWT validation.
 	GetFileByID(ctx context.Context, fileID string) (*entity.EphemeralFileEntity, error)
 	GetFileForUser(ctx context.Context, fileID, userID string) (*entity.EphemeralFileEntity, error)
+	ReadFileData(ctx context.Context, f *entity.EphemeralFileEntity) ([]byte, error)
 }
 
 type EphemeralFileSaveInput struct {
@@ -31,13 +32,14 @@ type EphemeralFileSaveInput struct {
 	Reader   io.Reader
 }
 
-func NewEphemeralFileService(sis SystemInfoService, repo repository.EphemeralFileRepository) EphemeralFileService {
-	return &ephemeralFileServiceImpl{sis: sis, repo: repo}
+func NewEphemeralFileService(sis SystemInfoService, repo repository.EphemeralFileRepository, blobStorageService BlobStorageService) EphemeralFileService {
+	return &ephemeralFileServiceImpl{sis: sis, repo: repo, blobStorageService: blobStorageService}
 }
 
 type ephemeralFileServiceImpl struct {
-	sis  SystemInfoService
-	repo repository.EphemeralFileRepository
+	sis                SystemInfoService
+	repo               repository.EphemeralFileRepository
+	blobStorageService BlobStorageService
 }
 
 func (s *ephemeralFileServiceImpl) SaveFile(ctx context.Context, in EphemeralFileSaveInput) (*entity.EphemeralFileEntity, string, error) {
@@ -47,22 +49,18 @@ func (s *ephemeralFileServiceImpl) SaveFile(ctx context.Context, in EphemeralFil
 	if strings.TrimSpace(in.UserID) == "" {
 		return nil, "", errors.New("userID is required")
 	}
-	baseDir := strings.TrimSpace(s.sis.GetEphemeralFileDirectory())
 	maxBytes := int64(s.sis.GetEphemeralFileMaxSizeMb()) * 1024 * 1024
 	ttl := time.Duration(s.sis.GetEphemeralFileTTLMinutes()) * time.Minute
 
 	id := uuid.NewString()
-	userDir := filepath.Join(baseDir, sanitizeUserID(in.UserID))
-	if err := os.MkdirAll(userDir, 0o700); err != nil {
-		return nil, "", fmt.Errorf("create user dir: %w", err)
-	}
-	storagePath := filepath.Join(userDir, id)
-
-	written, err := streamToFile(storagePath, in.Reader, maxBytes)
+	data, err := readLimited(in.Reader, maxBytes)
 	if err != nil {
-		_ = os.Remove(storagePath)
 		return nil, "", err
 	}
+	if err = s.blobStorageService.PutBlob(ctx, view.BlobNamespaceEphemeralFiles, id, data); err != nil {
+		return nil, "", err
+	}
+	size := int64(len(data))
 
 	now := time.Now().UTC()
 	mime := strings.TrimSpace(in.MimeType)
@@ -70,23 +68,22 @@ func (s *ephemeralFileServiceImpl) SaveFile(ctx context.Context, in EphemeralFil
 	if mime != "" {
 		mimePtr = &mime
 	}
-	size := written
 	row := &entity.EphemeralFileEntity{
 		ID:          id,
 		UserID:      in.UserID,
 		Filename:    sanitizeFilename(in.Filename, id),
-		StoragePath: storagePath,
+		StoragePath: id,
 		MimeType:    mimePtr,
 		SizeBytes:   &size,
 		CreatedAt:   now,
 		ExpiresAt:   now.Add(ttl),
 	}
 	if err := s.repo.Insert(ctx, row); err != nil {
-		_ = os.Remove(storagePath)
+		_ = s.blobStorageService.DeleteBlobs(ctx, view.BlobNamespaceEphemeralFiles, []string{id})
 		return nil, "", fmt.Errorf("insert file row: %w", err)
 	}
 	metrics.EphemeralFilesTotal.Inc()
-	metrics.EphemeralFileBytes.Observe(float64(written))
+	metrics.EphemeralFileBytes.Observe(float64(size))
 
 	return row, "/api/v1/ephemeral-files/" + id, nil
 }
@@ -99,33 +96,35 @@ func (s *ephemeralFileServiceImpl) GetFileForUser(ctx context.Context, fileID, u
 	return s.repo.GetByIDForUser(ctx, fileID, userID)
 }
 
-func streamToFile(path string, r io.Reader, maxBytes int64) (int64, error) {
-	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
-	if err != nil {
-		return 0, fmt.Errorf("open output file: %w", err)
+// ReadFileData reads the file content from the blob storage. Files saved before the blob storage was introduced
+// keep an absolute local path in StoragePath and are read from the file system.
+func (s *ephemeralFileServiceImpl) ReadFileData(ctx context.Context, f *entity.EphemeralFileEntity) ([]byte, error) {
+	if isLocalEphemeralFile(f) {
+		return os.ReadFile(f.StoragePath)
 	}
-	defer out.Close()
-	limited := io.LimitReader(r, maxBytes+1)
-	written, err := io.Copy(out, limited)
+	data, err := s.blobStorageService.GetBlob(ctx, view.BlobNamespaceEphemeralFiles, f.StoragePath)
 	if err != nil {
-		return written, fmt.Errorf("write file: %w", err)
+		return nil, err
 	}
-	if written > maxBytes {
-		return written, fmt.Errorf("file exceeds maximum size of %d bytes", maxBytes)
+	if data == nil {
+		return nil, fmt.Errorf("ephemeral file %s data not found", f.ID)
 	}
-	return written, nil
+	return data, nil
+}
+
+func isLocalEphemeralFile(f *entity.EphemeralFileEntity) bool {
+	return filepath.IsAbs(f.StoragePath)
 }
 
-func sanitizeUserID(uid string) string {
-	uid = url.PathEscape(uid)
-	uid = strings.ReplaceAll(uid, string(os.PathSeparator), "_")
-	if uid == "" {
-		uid = "anon"
+func readLimited(r io.Reader, maxBytes int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
 	}
-	if len(uid) > 64 {
-		uid = uid[:64]
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("file exceeds maximum size of %d bytes", maxBytes)
 	}
-	return uid
+	return data, nil
 }
 
 func sanitizeFilename(name, fallback string) string {
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -22,46 +23,33 @@ const ExcelTemplatePath = "static/templates/resources/ExcelExportTemplate.xlsx"
 type ExcelService interface {
 	ExportDeprecatedOperations(packageId, version, apiType string, req view.ExportOperationRequestView) (*excelize.File, string, error)
 	ExportApiChanges(packageId, version, apiType string, severities []string, req view.ExportApiChangesRequestView) (*excelize.File, string, error)
-	ExportOperations(packageId, version, apiType string, req view.ExportOperationRequestView) (*excelize.File, string, error)
+	ExportOperations(ctx context.Context, packageId, version, apiType string, req view.ExportOperationRequestView) (*excelize.File, string, error)
 	ExportBusinessMetrics(businessMetrics []view.BusinessMetric) (*excelize.File, string, error)
 	BuildShareabilityReport(groupId, versionName string) (*excelize.File, string, error)
 	ParseShareabilityReport(in io.Reader) ([]view.ShareabilityReportRow, error)
 }
 
-func NewExcelService(publishedRepo repository.PublishedRepository, versionService VersionService, operationService OperationService, packageService PackageService) ExcelService {
-	return &excelServiceImpl{publishedRepo: publishedRepo, versionService: versionService, operationService: operationService, packageService: packageService}
+func NewExcelService(publishedRepo repository.PublishedRepository, versionService VersionService, operationService OperationService, packageService PackageService, deprecationService DeprecationService) ExcelService {
+	return &excelServiceImpl{publishedRepo: publishedRepo, versionService: versionService, operationService: operationService, packageService: packageService, deprecationService: deprecationService}
 }
 
 type excelServiceImpl struct {
-	publishedRepo    repository.PublishedRepository
-	versionService   VersionService
-	operationService OperationService
-	packageService   PackageService
+	publishedRepo      repository.PublishedRepository
+	versionService     VersionService
+	operationService   OperationService
+	packageService     PackageService
+	deprecationService DeprecationService
 }
 
 func (e excelServiceImpl) ExportApiChanges(packageId, version, apiType string, severities []string, req view.ExportApiChangesRequestView) (*excelize.File, string, error) {
-	versionChangesSearchReq := view.VersionChangesReq{
-		PreviousVersion:          req.PreviousVersion,
-		PreviousVersionPackageId: req.PreviousVersionPackageId,
-		ApiKind:                  req.ApiKind,
-		EmptyTag:                 req.EmptyTag,
-		RefPackageId:             req.RefPackageId,
-		Tags:                     req.Tags,
-		TextFilter:               req.TextFilter,
-		Group:                    req.Group,
-		EmptyGroup:               req.EmptyGroup,
-		ApiAudience:              req.ApiAudience,
-		AsyncapiChannel:          req.AsyncapiChannel,
-		AsyncapiProtocol:         req.AsyncapiProtocol,
-	}
-	changelog, err := e.versionService.GetVersionChanges(packageId, version, apiType, severities, versionChangesSearchReq)
+	changelog, err := e.versionService.GetVersionChanges(packageId, version, apiType, severities, makeVersionChangesReq(req))
 	if err != nil {
 		return nil, "", err
 	}
 	if changelog == nil || len(changelog.Operations) == 0 {
 		return nil, "", nil
 	}
-	versionName, err := e.getVersionNameForAttachmentName(packageId, version)
+	versionName, err := getVersionNameForAttachmentName(e.publishedRepo, packageId, version)
 	if err != nil {
 		return nil, "", err
 	}
@@ -85,7 +73,7 @@ type OperationsReport struct {
 	columnDefaultWidth float64
 }
 
-func (e excelServiceImpl) ExportOperations(packageId, version, apiType string, req view.ExportOperationRequestView) (*excelize.File, string, error) {
+func (e excelServiceImpl) ExportOperations(ctx context.Context, packageId, version, apiType string, req view.ExportOperationRequestView) (*excelize.File, string, error) {
 	restOperationListReq := view.OperationListReq{
 		Kind:             req.Kind,
 		EmptyTag:         req.EmptyTag,
@@ -99,14 +87,14 @@ func (e excelServiceImpl) ExportOperations(packageId, version, apiType string, r
 		AsyncapiChannel:  req.AsyncapiChannel,
 		AsyncapiProtocol: req.AsyncapiProtocol,
 	}
-	operations, err := e.operationService.GetOperations(packageId, version, false, restOperationListReq)
+	operations, err := e.operationService.GetOperations(ctx, packageId, version, false, restOperationListReq)
 	if err != nil {
 		return nil, "", err
 	}
 	if operations == nil || len(operations.Operations) == 0 {
 		return nil, "", nil
 	}
-	versionName, err := e.getVersionNameForAttachmentName(packageId, version)
+	versionName, err := getVersionNameForAttachmentName(e.publishedRepo, packageId, version)
 	if err != nil {
 		return nil, "", err
 	}
@@ -153,7 +141,7 @@ func (e excelServiceImpl) ExportDeprecatedOperations(packageId, version, apiType
 		return nil, "", nil
 	}
 
-	versionName, err := e.getVersionNameForAttachmentName(packageId, version)
+	versionName, err := getVersionNameForAttachmentName(e.publishedRepo, packageId, version)
 	if err != nil {
 		return nil, "", err
 	}
@@ -166,11 +154,23 @@ func (e excelServiceImpl) ExportDeprecatedOperations(packageId, version, apiType
 	if err != nil {
 		return nil, "", err
 	}
-	file, err := buildDeprecatedOperationsWorkbook(deprecatedOperations, packageName, versionName, versionStatus)
+	refPackageIds := make([]string, 0, len(deprecatedOperations.Packages))
+	for _, refPackage := range deprecatedOperations.Packages {
+		refPackageIds = append(refPackageIds, refPackage.RefPackageId)
+	}
+	if len(refPackageIds) == 0 {
+		refPackageIds = append(refPackageIds, packageId)
+	}
+	policies, err := e.deprecationService.GetPolicies(context.Background(), refPackageIds)
+	if err != nil {
+		return nil, "", err
+	}
+	file, err := buildDeprecatedOperationsWorkbook(deprecatedOperations, policies, packageId, packageName, versionName, versionStatus)
 	return file, versionName, err
 }
 
-func buildDeprecatedOperationsWorkbook(deprecatedOperations *view.Operations, packageName, versionName, versionStatus string) (*excelize.File, error) {
+// policies are mapped by package id, api type and operation id, packageId is used for operations which are not from the referenced packages
+func buildDeprecatedOperationsWorkbook(deprecatedOperations *view.Operations, policies map[string]map[string]map[string]view.OperationDeprecationPolicy, packageId, packageName, versionName, versionStatus string) (*excelize.File, error) {
 	var err error
 	deprecatedOperationsReport, err := excelize.OpenFile(ExcelTemplatePath)
 	defer func() {
@@ -186,7 +186,7 @@ func buildDeprecatedOperationsWorkbook(deprecatedOperations *view.Operations, pa
 	report := DeprecatedOperationsReport{
 		workbook:           deprecatedOperationsReport,
 		startColumn:        "A",
-		endColumn:          "M",
+		endColumn:          "Q",
 		columnDefaultWidth: 35,
 	}
 
@@ -251,14 +251,15 @@ func buildDeprecatedOperationsWorkbook(deprecatedOperations *view.Operations, pa
 				if deprecatedItem.DeprecatedInfo != "" {
 					cellsValues[fmt.Sprintf("L%d", rowIndex)] = deprecatedItem.DeprecatedInfo
 				}
+				setDeprecationPolicyCellsValues(cellsValues, policies, refPackageIdOrDefault(deprecatedOperations.Packages[packageRef].RefPackageId, packageId), string(view.RestApiType), operationView.OperationId, "M", "N", rowIndex)
 				err := setCellsValues(report.workbook, view.RestAPISheetName, cellsValues)
 				if err != nil {
 					return nil, err
 				}
 				if rowIndex%2 == 0 {
-					err = report.workbook.SetCellStyle(view.RestAPISheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("L%d", rowIndex), evenCellStyle)
+					err = report.workbook.SetCellStyle(view.RestAPISheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("N%d", rowIndex), evenCellStyle)
 				} else {
-					err = report.workbook.SetCellStyle(view.RestAPISheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("L%d", rowIndex), oddCellStyle)
+					err = report.workbook.SetCellStyle(view.RestAPISheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("N%d", rowIndex), oddCellStyle)
 				}
 				if err != nil {
 					return nil, err
@@ -304,14 +305,15 @@ func buildDeprecatedOperationsWorkbook(deprecatedOperations *view.Operations, pa
 				if deprecatedItem.DeprecatedInfo != "" {
 					cellsValues[fmt.Sprintf("L%d", rowIndex)] = deprecatedItem.DeprecatedInfo
 				}
+				setDeprecationPolicyCellsValues(cellsValues, policies, refPackageIdOrDefault(deprecatedOperations.Packages[packageRef].RefPackageId, packageId), string(view.GraphqlApiType), operationView.OperationId, "M", "N", rowIndex)
 				err := setCellsValues(report.workbook, view.GraphQLSheetName, cellsValues)
 				if err != nil {
 					return nil, err
 				}
 				if rowIndex%2 == 0 {
-					err = report.workbook.SetCellStyle(view.GraphQLSheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("L%d", rowIndex), evenCellStyle)
+					err = report.workbook.SetCellStyle(view.GraphQLSheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("N%d", rowIndex), evenCellStyle)
 				} else {
-					err = report.workbook.SetCellStyle(view.GraphQLSheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("L%d", rowIndex), oddCellStyle)
+					err = report.workbook.SetCellStyle(view.GraphQLSheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("N%d", rowIndex), oddCellStyle)
 				}
 				if err != nil {
 					return nil, err
@@ -356,14 +358,15 @@ func buildDeprecatedOperationsWorkbook(deprecatedOperations *view.Operations, pa
 				if deprecatedItem.DeprecatedInfo != "" {
 					cellsValues[fmt.Sprintf("K%d", rowIndex)] = deprecatedItem.DeprecatedInfo
 				}
+				setDeprecationPolicyCellsValues(cellsValues, policies, refPackageIdOrDefault(deprecatedOperations.Packages[packageRef].RefPackageId, packageId), string(view.ProtobufApiType), operationView.OperationId, "L", "M", rowIndex)
 				err := setCellsValues(report.workbook, view.ProtobufSheetName, cellsValues)
 				if err != nil {
 					return nil, err
 				}
 				if rowIndex%2 == 0 {
-					err = report.workbook.SetCellStyle(view.ProtobufSheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("K%d", rowIndex), evenCellStyle)
+					err = report.workbook.SetCellStyle(view.ProtobufSheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("M%d", rowIndex), evenCellStyle)
 				} else {
-					err = report.workbook.SetCellStyle(view.ProtobufSheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("K%d", rowIndex), oddCellStyle)
+					err = report.workbook.SetCellStyle(view.ProtobufSheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("M%d", rowIndex), oddCellStyle)
 				}
 				if err != nil {
 					return nil, err
@@ -412,14 +415,15 @@ func buildDeprecatedOperationsWorkbook(deprecatedOperations *view.Operations, pa
 				if deprecatedItem.DeprecatedInfo != "" {
 					cellsValues[fmt.Sprintf("O%d", rowIndex)] = deprecatedItem.DeprecatedInfo
 				}
+				setDeprecationPolicyCellsValues(cellsValues, policies, refPackageIdOrDefault(deprecatedOperations.Packages[packageRef].RefPackageId, packageId), string(view.AsyncapiApiType), operationView.OperationId, "P", "Q", rowIndex)
 				err := setCellsValues(report.workbook, view.AsyncAPISheetName, cellsValues)
 				if err != nil {
 					return nil, err
 				}
 				if rowIndex%2 == 0 {
-					err = report.workbook.SetCellStyle(view.AsyncAPISheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("O%d", rowIndex), evenCellStyle)
+					err = report.workbook.SetCellStyle(view.AsyncAPISheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("Q%d", rowIndex), evenCellStyle)
 				} else {
-					err = report.workbook.SetCellStyle(view.AsyncAPISheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("O%d", rowIndex), oddCellStyle)
+					err = report.workbook.SetCellStyle(view.AsyncAPISheetName, fmt.Sprintf("A%d", rowIndex), fmt.Sprintf("Q%d", rowIndex), oddCellStyle)
 				}
 				if err != nil {
 					return nil, err
@@ -1853,15 +1857,17 @@ func (o *DeprecatedOperationsReport) createRestSheet() error {
 	cellsValues[fmt.Sprintf("J%d", headerRowIndex)] = view.DeprecatedSinceColumnName
 	cellsValues[fmt.Sprintf("K%d", headerRowIndex)] = view.DeprecatedDescriptionColumnName
 	cellsValues[fmt.Sprintf("L%d", headerRowIndex)] = view.AdditionalInformationColumnName
+	cellsValues[fmt.Sprintf("M%d", headerRowIndex)] = view.PlannedSunsetDateColumnName
+	cellsValues[fmt.Sprintf("N%d", headerRowIndex)] = view.ReplacementOperationColumnName
 	err = setCellsValues(o.workbook, view.RestAPISheetName, cellsValues)
 	if err != nil {
 		return err
 	}
-	err = o.workbook.SetCellStyle(view.RestAPISheetName, fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("L%d", headerRowIndex), headerStyle)
+	err = o.workbook.SetCellStyle(view.RestAPISheetName, fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("N%d", headerRowIndex), headerStyle)
 	if err != nil {
 		return err
 	}
-	err = o.workbook.AutoFilter(view.RestAPISheetName, fmt.Sprintf("%s:%s", fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("L%d", headerRowIndex)), []excelize.AutoFilterOptions{})
+	err = o.workbook.AutoFilter(view.RestAPISheetName, fmt.Sprintf("%s:%s", fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("N%d", headerRowIndex)), []excelize.AutoFilterOptions{})
 	if err != nil {
 		return err
 	}
@@ -1893,15 +1899,17 @@ func (o *DeprecatedOperationsReport) createGraphQLSheet() error {
 	cellsValues[fmt.Sprintf("J%d", headerRowIndex)] = view.DeprecatedSinceColumnName
 	cellsValues[fmt.Sprintf("K%d", headerRowIndex)] = view.DeprecatedDescriptionColumnName
 	cellsValues[fmt.Sprintf("L%d", headerRowIndex)] = view.AdditionalInformationColumnName
+	cellsValues[fmt.Sprintf("M%d", headerRowIndex)] = view.PlannedSunsetDateColumnName
+	cellsValues[fmt.Sprintf("N%d", headerRowIndex)] = view.ReplacementOperationColumnName
 	err = setCellsValues(o.workbook, view.GraphQLSheetName, cellsValues)
 	if err != nil {
 		return err
 	}
-	err = o.workbook.SetCellStyle(view.GraphQLSheetName, fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("L%d", headerRowIndex), headerStyle)
+	err = o.workbook.SetCellStyle(view.GraphQLSheetName, fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("N%d", headerRowIndex), headerStyle)
 	if err != nil {
 		return err
 	}
-	err = o.workbook.AutoFilter(view.GraphQLSheetName, fmt.Sprintf("%s:%s", fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("L%d", headerRowIndex)), []excelize.AutoFilterOptions{})
+	err = o.workbook.AutoFilter(view.GraphQLSheetName, fmt.Sprintf("%s:%s", fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("N%d", headerRowIndex)), []excelize.AutoFilterOptions{})
 	if err != nil {
 		return err
 	}
@@ -1932,15 +1940,17 @@ func (o *DeprecatedOperationsReport) createProtobufSheet() error {
 	cellsValues[fmt.Sprintf("I%d", headerRowIndex)] = view.DeprecatedSinceColumnName
 	cellsValues[fmt.Sprintf("J%d", headerRowIndex)] = view.DeprecatedDescriptionColumnName
 	cellsValues[fmt.Sprintf("K%d", headerRowIndex)] = view.AdditionalInformationColumnName
+	cellsValues[fmt.Sprintf("L%d", headerRowIndex)] = view.PlannedSunsetDateColumnName
+	cellsValues[fmt.Sprintf("M%d", headerRowIndex)] = view.ReplacementOperationColumnName
 	err = setCellsValues(o.workbook, view.ProtobufSheetName, cellsValues)
 	if err != nil {
 		return err
 	}
-	err = o.workbook.SetCellStyle(view.ProtobufSheetName, fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("K%d", headerRowIndex), headerStyle)
+	err = o.workbook.SetCellStyle(view.ProtobufSheetName, fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("M%d", headerRowIndex), headerStyle)
 	if err != nil {
 		return err
 	}
-	err = o.workbook.AutoFilter(view.ProtobufSheetName, fmt.Sprintf("%s:%s", fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("K%d", headerRowIndex)), []excelize.AutoFilterOptions{})
+	err = o.workbook.AutoFilter(view.ProtobufSheetName, fmt.Sprintf("%s:%s", fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("M%d", headerRowIndex)), []excelize.AutoFilterOptions{})
 	if err != nil {
 		return err
 	}
@@ -1975,23 +1985,50 @@ func (o *DeprecatedOperationsReport) createAsyncAPISheet() error {
 	cellsValues[fmt.Sprintf("M%d", headerRowIndex)] = view.DeprecatedSinceColumnName
 	cellsValues[fmt.Sprintf("N%d", headerRowIndex)] = view.DeprecatedDescriptionColumnName
 	cellsValues[fmt.Sprintf("O%d", headerRowIndex)] = view.AdditionalInformationColumnName
+	cellsValues[fmt.Sprintf("P%d", headerRowIndex)] = view.PlannedSunsetDateColumnName
+	cellsValues[fmt.Sprintf("Q%d", headerRowIndex)] = view.ReplacementOperationColumnName
 	err = setCellsValues(o.workbook, view.AsyncAPISheetName, cellsValues)
 	if err != nil {
 		return err
 	}
-	err = o.workbook.SetCellStyle(view.AsyncAPISheetName, fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("O%d", headerRowIndex), headerStyle)
+	err = o.workbook.SetCellStyle(view.AsyncAPISheetName, fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("Q%d", headerRowIndex), headerStyle)
 	if err != nil {
 		return err
 	}
-	err = o.workbook.AutoFilter(view.AsyncAPISheetName, fmt.Sprintf("%s:%s", fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("O%d", headerRowIndex)), []excelize.AutoFilterOptions{})
+	err = o.workbook.AutoFilter(view.AsyncAPISheetName, fmt.Sprintf("%s:%s", fmt.Sprintf("A%d", headerRowIndex), fmt.Sprintf("Q%d", headerRowIndex)), []excelize.AutoFilterOptions{})
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (e excelServiceImpl) getVersionNameForAttachmentName(packageId, version string) (string, error) {
-	latestRevision, err := e.publishedRepo.GetLatestRevision(packageId, version)
+func setDeprecationPolicyCellsValues(cellsValues map[string]interface{}, policies map[string]map[string]map[string]view.OperationDeprecationPolicy, packageId, apiType, operationId, sunsetDateColumn, replacementColumn string, rowIndex int) {
+	policy, exists := policies[packageId][apiType][operationId]
+	if !exists {
+		return
+	}
+	if policy.SunsetDate != "" {
+		cellsValues[fmt.Sprintf("%s%d", sunsetDateColumn, rowIndex)] = policy.SunsetDate
+	}
+	if policy.Replacement != nil {
+		replacement := policy.Replacement.OperationId
+		if policy.Replacement.PackageId != "" && policy.Replacement.PackageId != packageId {
+			replacement = fmt.Sprintf("%s: %s", policy.Replacement.PackageId, replacement)
+		}
+		cellsValues[fmt.Sprintf("%s%d", replacementColumn, rowIndex)] = replacement
+	}
+}
+
+func refPackageIdOrDefault(refPackageId, packageId string) string {
+	if refPackageId == "" {
+		return packageId
+	}
+	return refPackageId
+}
+
+// getVersionNameForAttachmentName returns the version without revision if it is the latest revision of the version
+func getVersionNameForAttachmentName(publishedRepo repository.PublishedRepository, packageId, version string) (string, error) {
+	latestRevision, err := publishedRepo.GetLatestRevision(packageId, version)
 	if err != nil {
 		return "", err
 	}
@@ -2005,6 +2042,24 @@ func (e excelServiceImpl) getVersionNameForAttachmentName(packageId, version str
 	return version, nil
 }
 
+// makeVersionChangesReq makes the search request of the version changes by the changes export request
+func makeVersionChangesReq(req view.ExportApiChangesRequestView) view.VersionChangesReq {
+	return view.VersionChangesReq{
+		PreviousVersion:          req.PreviousVersion,
+		PreviousVersionPackageId: req.PreviousVersionPackageId,
+		ApiKind:                  req.ApiKind,
+		EmptyTag:                 req.EmptyTag,
+		RefPackageId:             req.RefPackageId,
+		Tags:                     req.Tags,
+		TextFilter:               req.TextFilter,
+		Group:                    req.Group,
+		EmptyGroup:               req.EmptyGroup,
+		ApiAudience:              req.ApiAudience,
+		AsyncapiChannel:          req.AsyncapiChannel,
+		AsyncapiProtocol:         req.AsyncapiProtocol,
+	}
+}
+
 func getVersionNameFromVersionWithRevision(version string) (string, error) {
 	versionName, _, err := SplitVersionRevision(version)
 	if err != nil {
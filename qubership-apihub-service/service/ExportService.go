@@ -1,6 +1,7 @@
 package service
 
 import (
+	stdctx "context"
 	"fmt"
 	"net/http"
 	"time"
@@ -12,7 +13,6 @@ import (
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service/validation"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
-	log "github.com/sirupsen/logrus"
 )
 
 type ExportService interface {
@@ -21,20 +21,24 @@ type ExportService interface {
 	StartRESTOpGroupExport(ctx context.SecurityContext, req view.ExportRestOperationsGroupReq) (string, error)
 	StartGraphQLOpGroupExport(ctx context.SecurityContext, req view.ExportGraphqlOperationsGroupReq) (string, error)
 	StartAsyncAPIOpGroupExport(ctx context.SecurityContext, req view.ExportAsyncapiOperationsGroupReq) (string, error)
+	StartProtobufOpGroupExport(ctx context.SecurityContext, req view.ExportProtobufOperationsGroupReq) (string, error)
 
 	GetAsyncExportStatus(exportId string) (*view.ExportStatus, *view.ExportResult, string, error)
 
-	StartCleanupOldResultsJob()
+	StartCleanupOldResultsJob() error
 
 	PublishTransformedDocuments(buildArc *archive.BuildResultArchive, publishId string) error // deprecated
 	StoreExportResult(userId string, exportId string, buildResult []byte, fileName string, buildConfig view.BuildConfig) error
 }
 
-func NewExportService(exportRepository repository.ExportResultRepository, buildService BuildService, packageExportConfigService PackageExportConfigService) ExportService {
+func NewExportService(exportRepository repository.ExportResultRepository, buildService BuildService, packageExportConfigService PackageExportConfigService, blobStorageService BlobStorageService, backgroundJobService BackgroundJobService, operationGroupService OperationGroupService) ExportService {
 	return &exportServiceImpl{
 		exportRepository:           exportRepository,
 		packageExportConfigService: packageExportConfigService,
 		buildService:               buildService,
+		blobStorageService:         blobStorageService,
+		backgroundJobService:       backgroundJobService,
+		operationGroupService:      operationGroupService,
 	}
 }
 
@@ -43,6 +47,9 @@ type exportServiceImpl struct {
 
 	packageExportConfigService PackageExportConfigService
 	buildService               BuildService
+	blobStorageService         BlobStorageService
+	backgroundJobService       BackgroundJobService
+	operationGroupService      OperationGroupService
 }
 
 func (e exportServiceImpl) StoreExportResult(userId string, exportId string, buildResult []byte, fileName string, buildConfig view.BuildConfig) error {
@@ -54,10 +61,30 @@ func (e exportServiceImpl) StoreExportResult(userId string, exportId string, bui
 		Data:      buildResult,
 		Filename:  fileName,
 	}
+	if !e.blobStorageService.IsDatabaseBackend(view.BlobNamespaceExportResults) {
+		err := e.blobStorageService.PutBlob(stdctx.Background(), view.BlobNamespaceExportResults, exportId, buildResult)
+		if err != nil {
+			return err
+		}
+		ent.Data = nil
+	}
 	err := e.exportRepository.SaveExportResult(ent)
 	return err
 }
 
+// loadExportResultData reads the data of the export result from the blob storage if it is not kept in the database
+func loadExportResultData(blobStorageService BlobStorageService, ent *entity.ExportResultEntity) error {
+	if ent.Data != nil || blobStorageService.IsDatabaseOnly(view.BlobNamespaceExportResults) {
+		return nil
+	}
+	data, err := blobStorageService.GetBlob(stdctx.Background(), view.BlobNamespaceExportResults, ent.ExportId)
+	if err != nil {
+		return err
+	}
+	ent.Data = data
+	return nil
+}
+
 func (e exportServiceImpl) StartVersionExport(ctx context.SecurityContext, req view.ExportVersionReq) (string, error) {
 	err := validateFormat(req.Format)
 	if err != nil {
@@ -220,6 +247,39 @@ func (e exportServiceImpl) StartAsyncAPIOpGroupExport(ctx context.SecurityContex
 	return exportId, nil
 }
 
+func (e exportServiceImpl) StartProtobufOpGroupExport(ctx context.SecurityContext, req view.ExportProtobufOperationsGroupReq) (string, error) {
+	exists, err := e.operationGroupService.CheckOperationGroupExists(req.PackageId, req.Version, string(view.ProtobufApiType), req.GroupName)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.OperationGroupNotFound,
+			Message: exception.OperationGroupNotFoundMsg,
+			Params:  map[string]interface{}{"groupName": req.GroupName},
+		}
+	}
+
+	buildConfig := view.BuildConfig{
+		PackageId:                    req.PackageId,
+		Version:                      req.Version,
+		BuildType:                    view.ExportProtobufOperationsGroup,
+		CreatedBy:                    ctx.GetUserId(),
+		ApiType:                      string(view.ProtobufApiType),
+		GroupName:                    req.GroupName,
+		OperationsSpecTransformation: view.TransformationReducedSource,
+		Format:                       view.ProtobufFormat,
+	}
+
+	exportId, _, err := e.buildService.CreateBuildWithoutDependencies(buildConfig, false, "")
+	if err != nil {
+		return "", err
+	}
+
+	return exportId, nil
+}
+
 func (e exportServiceImpl) makeAllowedOasExtensions(removeOasExtensions bool, packageId string) (*[]string, error) {
 	var allowedOasExtensions *[]string
 
@@ -279,22 +339,39 @@ func (e exportServiceImpl) GetAsyncExportStatus(exportId string) (*view.ExportSt
 		// most probably export result was already cleaned up
 		return nil, nil, "", nil
 	}
+	if err = loadExportResultData(e.blobStorageService, resultEnt); err != nil {
+		return nil, nil, "", fmt.Errorf("failed to get export result %s data: %w", exportId, err)
+	}
 
 	return nil, &view.ExportResult{Data: resultEnt.Data, FileName: resultEnt.Filename}, build.PackageId, nil
 }
 
-func (e exportServiceImpl) StartCleanupOldResultsJob() {
-	cleanupTime := time.Minute * 10
+const (
+	exportResultsCleanupJobName = "exportResultsCleanup"
+	exportResultsTTL            = time.Minute * 10
+)
 
-	ticker := time.NewTicker(cleanupTime)
-	for range ticker.C {
-		err := e.exportRepository.CleanupExportResults(cleanupTime)
-		if err != nil {
-			log.Warnf("Failed to run export result cleanup job: %s", err.Error())
-		} else {
-			log.Tracef("Export result cleanup job finished successfully")
-		}
+func (e exportServiceImpl) StartCleanupOldResultsJob() error {
+	return e.backgroundJobService.RegisterJob(BackgroundJobDefinition{
+		Name:        exportResultsCleanupJobName,
+		Description: "Deletes export results which were not downloaded in time",
+		Schedule:    "@every 10m",
+		Distributed: true,
+		Timeout:     5 * time.Minute,
+		MaxRetries:  1,
+		Job:         BackgroundJobFunc(e.cleanupOldResults),
+	})
+}
+
+func (e exportServiceImpl) cleanupOldResults(ctx stdctx.Context) error {
+	exportIds, err := e.exportRepository.CleanupExportResults(exportResultsTTL)
+	if err != nil {
+		return err
 	}
+	if !e.blobStorageService.IsDatabaseOnly(view.BlobNamespaceExportResults) {
+		return e.blobStorageService.DeleteBlobs(ctx, view.BlobNamespaceExportResults, exportIds)
+	}
+	return nil
 }
 
 // deprecated
@@ -2,9 +2,13 @@ package service
 
 import (
 	"fmt"
-	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"net/http"
 	"testing"
 
+	secctx "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -114,3 +118,34 @@ func (m mockPackageExportConfigService) GetConfig(packageId string) (*view.Packa
 func (m mockPackageExportConfigService) SetConfig(packageId string, AllowedOasExtensions []string) error {
 	return nil
 }
+
+func TestStartProtobufOpGroupExport(t *testing.T) {
+	operationGroupService := &testExportOperationGroupService{groups: map[string]bool{"QS.QUOTE@2024.1@protobuf@grpc-group": true}}
+	buildService := &testBuildService{}
+	service := &exportServiceImpl{buildService: buildService, operationGroupService: operationGroupService}
+	secCtx := secctx.CreateFromId("user1")
+
+	exportId, err := service.StartProtobufOpGroupExport(secCtx, view.ExportProtobufOperationsGroupReq{PackageId: "QS.QUOTE", Version: "2024.1", GroupName: "grpc-group"})
+	assert.NoError(t, err)
+	assert.Equal(t, "new-build", exportId)
+	assert.Len(t, buildService.created, 1)
+	assert.Equal(t, view.ExportProtobufOperationsGroup, buildService.created[0].BuildType)
+	assert.Equal(t, "grpc-group", buildService.created[0].GroupName)
+
+	buildService.created = nil
+	_, err = service.StartProtobufOpGroupExport(secCtx, view.ExportProtobufOperationsGroupReq{PackageId: "QS.QUOTE", Version: "2024.1", GroupName: "rest-group"})
+	var customError *exception.CustomError
+	assert.ErrorAs(t, err, &customError)
+	assert.Equal(t, http.StatusNotFound, customError.Status)
+	assert.Equal(t, exception.OperationGroupNotFound, customError.Code)
+	assert.Empty(t, buildService.created, "export is not started for a group which does not exist")
+}
+
+type testExportOperationGroupService struct {
+	OperationGroupService
+	groups map[string]bool
+}
+
+func (o *testExportOperationGroupService) CheckOperationGroupExists(packageId string, version string, apiType string, groupName string) (bool, error) {
+	return o.groups[packageId+"@"+version+"@"+apiType+"@"+groupName], nil
+}
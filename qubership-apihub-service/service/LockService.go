@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -33,6 +34,8 @@ type LockOptions struct {
 type LockService interface {
 	AcquireLock(ctx context.Context, lockName string, options LockOptions) (bool, <-chan LockLostEvent, error)
 	ReleaseLock(ctx context.Context, lockName string) error
+	// ReleaseAllLocks releases all locks held by the instance, it is used on shutdown to let other instances take over
+	ReleaseAllLocks(ctx context.Context) error
 }
 
 type lockServiceImpl struct {
@@ -137,6 +140,25 @@ func (s *lockServiceImpl) ReleaseLock(ctx context.Context, lockName string) erro
 	return s.tryReleaseLock(ctx, lockName, lockInfo)
 }
 
+func (s *lockServiceImpl) ReleaseAllLocks(ctx context.Context) error {
+	s.mu.Lock()
+	lockNames := make([]string, 0, len(s.heartbeatCancelers))
+	for lockName := range s.heartbeatCancelers {
+		lockNames = append(lockNames, lockName)
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	for _, lockName := range lockNames {
+		if err := s.ReleaseLock(ctx, lockName); err != nil {
+			errs = append(errs, fmt.Errorf("failed to release lock %s: %w", lockName, err))
+			continue
+		}
+		log.Infof("Lock %s was released", lockName)
+	}
+	return errors.Join(errs...)
+}
+
 func (s *lockServiceImpl) cleanupLockResources(lockName string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -49,7 +49,7 @@ func (m mcpService) ExecuteLegacyRestSearchTool(ctx context.Context, req mcp.Cal
 		metrics.MCPLegacySearchToolCalled,
 		mcpLegacyMetricKey(ctx, group),
 	)
-	log.Infof("%s: delegating to %s with apiType=rest", LegacyToolNameSearchRestOperations, ToolNameSearchOperations)
+	log.WithContext(ctx).Infof("%s: delegating to %s with apiType=rest", LegacyToolNameSearchRestOperations, ToolNameSearchOperations)
 	return m.ExecuteSearchTool(ctx, withInjectedMCPArg(req, "apiType", string(view.RestApiType)))
 }
 
@@ -61,7 +61,7 @@ func (m mcpService) ExecuteLegacyRestGetSpecTool(ctx context.Context, req mcp.Ca
 			mcpLegacyMetricKey(ctx, packageId),
 		)
 	}
-	log.Infof("%s: delegating to %s with apiType=rest", LegacyToolNameGetRestOperationSpec, ToolNameGetOperationSpec)
+	log.WithContext(ctx).Infof("%s: delegating to %s with apiType=rest", LegacyToolNameGetRestOperationSpec, ToolNameGetOperationSpec)
 	return m.ExecuteGetSpecTool(ctx, withInjectedMCPArg(req, "apiType", string(view.RestApiType)))
 }
 
@@ -73,7 +73,7 @@ func (m mcpService) ExecuteLegacyRestGetOperationDiffTool(ctx context.Context, r
 			mcpLegacyMetricKey(ctx, packageId),
 		)
 	}
-	log.Infof("%s: delegating to %s with apiType=rest", LegacyToolNameGetRestOperationDiff, ToolNameGetOperationDiff)
+	log.WithContext(ctx).Infof("%s: delegating to %s with apiType=rest", LegacyToolNameGetRestOperationDiff, ToolNameGetOperationDiff)
 	return m.ExecuteGetOperationDiffTool(ctx, withInjectedMCPArg(req, "apiType", string(view.RestApiType)))
 }
 
@@ -90,7 +90,7 @@ func (m mcpService) ExecuteGetSpecTool(ctx context.Context, req mcp.CallToolRequ
 	if !sufficientPrivileges {
 		return mcp.NewToolResultError(exception.InsufficientPrivilegesMsg), nil
 	}
-	apiType, err := requireMCPApiType(req, view.RestApiType, view.AsyncapiApiType)
+	apiType, err := requireMCPApiType(req, view.RestApiType, view.AsyncapiApiType, view.ProtobufApiType)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
@@ -105,7 +105,7 @@ func (m mcpService) ExecuteGetSpecTool(ctx context.Context, req mcp.CallToolRequ
 
 	m.monitoringService.IncreaseBusinessMetricCounter(UserIDFromMCPCtx(ctx), metrics.MCPGetSpecToolCalled, mcpMetricKey(ctx, apiType, packageId))
 
-	log.Infof("get_api_operation_specification: apiType=%s, operationId=%s, packageId=%s, version=%s", apiType, operationId, packageId, version)
+	log.WithContext(ctx).Infof("get_api_operation_specification: apiType=%s, operationId=%s, packageId=%s, version=%s", apiType, operationId, packageId, version)
 
 	searchReq := view.OperationBasicSearchReq{
 		PackageId:   packageId,
@@ -115,7 +115,7 @@ func (m mcpService) ExecuteGetSpecTool(ctx context.Context, req mcp.CallToolRequ
 		IncludeData: true,
 	}
 
-	operationViewInterface, err := m.operationService.GetOperation(searchReq)
+	operationViewInterface, err := m.operationService.GetOperation(ctx, searchReq)
 	if err != nil {
 		return nil, err
 	}
@@ -129,14 +129,14 @@ func (m mcpService) ExecuteGetSpecTool(ctx context.Context, req mcp.CallToolRequ
 
 	// Log MCP tool response at debug level
 	payloadJSON, _ := json.Marshal(payload)
-	log.Debugf("MCP tool get_api_operation_specification response: %s", string(payloadJSON))
+	log.WithContext(ctx).Debugf("MCP tool get_api_operation_specification response: %s", string(payloadJSON))
 
 	return mcp.NewToolResultStructuredOnly(payload), nil
 }
 
 // ExecuteSearchTool executes the search_api_operations tool
 func (m mcpService) ExecuteSearchTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	apiType, err := requireMCPApiType(req, view.RestApiType, view.GraphqlApiType, view.AsyncapiApiType)
+	apiType, err := requireMCPApiType(req, view.RestApiType, view.GraphqlApiType, view.AsyncapiApiType, view.ProtobufApiType)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
@@ -155,7 +155,7 @@ func (m mcpService) ExecuteSearchTool(ctx context.Context, req mcp.CallToolReque
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	log.Infof("search_api_operations: apiType=%s, query=%s, limit=%d, page=%d, group=%s, releaseVersion=%s", apiType, q, limit, page, group, releaseVersion)
+	log.WithContext(ctx).Infof("search_api_operations: apiType=%s, query=%s, limit=%d, page=%d, group=%s, releaseVersion=%s", apiType, q, limit, page, group, releaseVersion)
 
 	m.monitoringService.IncreaseBusinessMetricCounter(UserIDFromMCPCtx(ctx), metrics.MCPSearchToolCalled, mcpMetricKey(ctx, apiType, group))
 
@@ -188,7 +188,7 @@ func (m mcpService) ExecuteSearchTool(ctx context.Context, req mcp.CallToolReque
 
 	// Log MCP tool response at debug level
 	payloadJSON, _ := json.Marshal(payload)
-	log.Debugf("MCP tool search_api_operations response: %s", string(payloadJSON))
+	log.WithContext(ctx).Debugf("MCP tool search_api_operations response: %s", string(payloadJSON))
 
 	return mcp.NewToolResultStructuredOnly(payload), nil
 }
@@ -226,7 +226,7 @@ func (m mcpService) ExecuteGetOperationDiffTool(ctx context.Context, req mcp.Cal
 
 	m.monitoringService.IncreaseBusinessMetricCounter(UserIDFromMCPCtx(ctx), metrics.MCPGetDiffToolCalled, mcpMetricKey(ctx, apiType, packageId))
 
-	log.Infof("get_api_operation_diff: apiType=%s, operationId=%s, packageId=%s, version=%s, previousVersion=%s", apiType, operationId, packageId, version, previousVersion)
+	log.WithContext(ctx).Infof("get_api_operation_diff: apiType=%s, operationId=%s, packageId=%s, version=%s, previousVersion=%s", apiType, operationId, packageId, version, previousVersion)
 
 	operationChangesView, err := m.operationService.GetOperationChanges(packageId, version, operationId, packageId, previousVersion, []string{})
 	if err != nil {
@@ -237,7 +237,7 @@ func (m mcpService) ExecuteGetOperationDiffTool(ctx context.Context, req mcp.Cal
 
 	// Log MCP tool response at debug level
 	payloadJSON, _ := json.Marshal(payload)
-	log.Debugf("MCP tool get_api_operation_diff response: %s", string(payloadJSON))
+	log.WithContext(ctx).Debugf("MCP tool get_api_operation_diff response: %s", string(payloadJSON))
 
 	return mcp.NewToolResultStructuredOnly(payload), nil
 }
@@ -255,7 +255,7 @@ func (m mcpService) ExecuteGetDocumentTool(ctx context.Context, req mcp.CallTool
 	if !sufficientPrivileges {
 		return mcp.NewToolResultError(exception.InsufficientPrivilegesMsg), nil
 	}
-	apiType, err := requireMCPApiType(req, view.RestApiType, view.GraphqlApiType, view.AsyncapiApiType)
+	apiType, err := requireMCPApiType(req, view.RestApiType, view.GraphqlApiType, view.AsyncapiApiType, view.ProtobufApiType)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
@@ -270,7 +270,7 @@ func (m mcpService) ExecuteGetDocumentTool(ctx context.Context, req mcp.CallTool
 
 	m.monitoringService.IncreaseBusinessMetricCounter(UserIDFromMCPCtx(ctx), metrics.MCPGetDocumentToolCalled, mcpMetricKey(ctx, apiType, packageId))
 
-	log.Infof("get_document: apiType=%s, packageId=%s, version=%s, slug=%s", apiType, packageId, version, slug)
+	log.WithContext(ctx).Infof("get_document: apiType=%s, packageId=%s, version=%s, slug=%s", apiType, packageId, version, slug)
 
 	document, documentData, err := m.versionService.GetLatestContentDataBySlug(packageId, version, slug)
 	if err != nil {
@@ -281,7 +281,7 @@ func (m mcpService) ExecuteGetDocumentTool(ctx context.Context, req mcp.CallTool
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	log.Debugf("MCP tool get_document response: packageId=%s, version=%s, slug=%s, dataBytes=%d", packageId, version, slug, len(documentData.Data))
+	log.WithContext(ctx).Debugf("MCP tool get_document response: packageId=%s, version=%s, slug=%s, dataBytes=%d", packageId, version, slug, len(documentData.Data))
 
 	return mcp.NewToolResultStructuredOnly(payload), nil
 }
@@ -9,10 +9,13 @@ import (
 	secctx "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/metrics"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/tracing"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
 	"github.com/mark3labs/mcp-go/mcp"
 	mcpserver "github.com/mark3labs/mcp-go/server"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 type MCPService interface {
@@ -97,7 +100,7 @@ func (m mcpService) MakeMCPServer() *mcpserver.MCPServer {
 			Name:           meta.Name,
 			Description:    meta.DescriptionMCP,
 			RawInputSchema: meta.Schema,
-		}, handler)
+		}, traceMCPTool(meta.Name, handler))
 	}
 
 	mcpWorkspace := m.systemInfoService.GetAiMCPConfig().Workspace
@@ -171,6 +174,21 @@ func (m mcpService) MakeMCPServer() *mcpserver.MCPServer {
 	return s
 }
 
+var mcpToolNameKey = attribute.Key("mcp.tool.name")
+
+// traceMCPTool wraps the tool handler with a span, tool errors returned in the result are recorded as span errors as well
+func traceMCPTool(name string, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, span := tracing.StartSpan(ctx, "mcp.tool "+name, mcpToolNameKey.String(name))
+		result, err := handler(ctx, req)
+		if err == nil && result != nil && result.IsError {
+			span.SetStatus(codes.Error, "tool returned error result")
+		}
+		tracing.EndSpan(span, err)
+		return result, err
+	}
+}
+
 // mcpResourceURI builds the canonical URI for a bundled MCP resource asset.
 func mcpResourceURI(filename string) string {
 	return "apihub://mcp/resources/" + filename
@@ -198,7 +216,7 @@ func (m mcpService) MakeLLMTools() []client.LLMTool {
 
 // GetPackagesList retrieves the list of packages from the workspace
 func (m mcpService) GetPackagesList(ctx context.Context, workspaceId string) ([]mcp.ResourceContents, error) {
-	log.Infof("Getting packages list for workspace: %s", workspaceId)
+	log.WithContext(ctx).Infof("Getting packages list for workspace: %s", workspaceId)
 
 	// TODO: should be retrieved from the request
 	// Create system context for service calls
@@ -215,7 +233,7 @@ func (m mcpService) GetPackagesList(ctx context.Context, workspaceId string) ([]
 	// Get all packages from workspace
 	packages, err := m.packageService.GetPackagesList(secCtx, packageListReq, false)
 	if err != nil {
-		log.Errorf("Failed to get packages list: %v", err)
+		log.WithContext(ctx).Errorf("Failed to get packages list: %v", err)
 		return nil, fmt.Errorf("failed to get packages list: %w", err)
 	}
 
@@ -233,7 +251,7 @@ func (m mcpService) GetPackagesList(ctx context.Context, workspaceId string) ([]
 		}
 		versionsView, err := m.versionService.GetPackageVersionsView(versionsReq, false)
 		if err != nil {
-			log.Errorf("Failed to get versions list for package %s: %v", packageInfo.Id, err)
+			log.WithContext(ctx).Errorf("Failed to get versions list for package %s: %v", packageInfo.Id, err)
 			return nil, fmt.Errorf("failed to get versions list for package %s: %w", packageInfo.Id, err)
 		}
 		if versionsView != nil {
@@ -243,11 +261,11 @@ func (m mcpService) GetPackagesList(ctx context.Context, workspaceId string) ([]
 
 	jsonData, err := json.Marshal(packagesMCP)
 	if err != nil {
-		log.Errorf("Failed to marshal packages list: %v", err)
+		log.WithContext(ctx).Errorf("Failed to marshal packages list: %v", err)
 		return nil, fmt.Errorf("failed to marshal packages list: %w", err)
 	}
 
-	log.Debugf("Packages list retrieved: %s", jsonData)
+	log.WithContext(ctx).Debugf("Packages list retrieved: %s", jsonData)
 
 	return []mcp.ResourceContents{
 		&mcp.TextResourceContents{
@@ -319,16 +337,16 @@ DATA STRUCTURE:
 
 WHEN TO USE THIS SERVER:
 Use apihub-mcp when the user asks about:
-- REST, GraphQL, or AsyncAPI operations
+- REST, GraphQL, AsyncAPI, or Protobuf (gRPC) operations
 - Available API specifications
 - How APIs expose behavior, including REST resource operations, GraphQL queries/mutations/subscriptions, and AsyncAPI message publishing, sending, receiving, or consuming
 - Detailed information about specific API operations
 
 AVAILABLE TOOLS:
-1. search_api_operations - search for REST, GraphQL, or AsyncAPI operations (see tool description for details)
-2. get_api_operation_specification - get operation-level specification data extracted from an OpenAPI or AsyncAPI specification (use only when user explicitly requests details)
+1. search_api_operations - search for REST, GraphQL, AsyncAPI, or Protobuf operations (see tool description for details)
+2. get_api_operation_specification - get operation-level specification data extracted from an OpenAPI, AsyncAPI or Protobuf specification (use only when user explicitly requests details)
 3. get_api_operation_diff - get list of changes of the specific operation from OpenAPI or AsyncAPI specification from the specific package and version to the previous version (use then user asks for changes of the specific operation)
-4. get_document - get a source API specification by slug for REST, GraphQL, or AsyncAPI (use this tool when the user needs the source API specification or a document-level diff built by comparing two fetched versions)
+4. get_document - get a source API specification by slug for REST, GraphQL, AsyncAPI, or Protobuf (use this tool when the user needs the source API specification or a document-level diff built by comparing two fetched versions)
 
 AVAILABLE RESOURCES:
 - api-packages-list - list of all packages in the system. Use this resource when:
@@ -343,7 +361,7 @@ RESPONSES:
 - Return all metadata that MCP returns in responses, including documentId from search results
 - When using get_document, use documentData as the source specification content; documentType identifies the specification type and format describes its syntax
 - First show a list of operations to choose from, even if only one operation is found
-- Use get_api_operation_specification only when user explicitly requests details about a REST or AsyncAPI operation
+- Use get_api_operation_specification only when user explicitly requests details about a REST, AsyncAPI or Protobuf operation
 - Do not ask the user for a specification slug after search; use the selected result's documentId as get_document.slug
 
 ACCESS CONTROL AND AUTHORIZATION ERRORS:
@@ -370,7 +388,7 @@ const (
 const (
 	ToolDescriptionSearchOperationsMCP = `Search for API operations by text query.
 
-Supported apiType values: rest, graphql, asyncapi.
+Supported apiType values: rest, graphql, asyncapi, protobuf.
 
 IMPORTANT: Search is lexical full-text search, not semantic, fuzzy, or substring search. Plain words are treated as required terms, so try shorter and longer query variations.
 IMPORTANT: Search matches only terms included in the operation search index. If a query returns too few or irrelevant results, retry with alternative terms such as operation names, titles, REST path segments, AsyncAPI channel/message names, GraphQL input/output type names, or domain keywords.
@@ -382,6 +400,7 @@ LLM INSTRUCTIONS:
 - For REST, search by HTTP method, operation path, distinctive path segment, title, summary/description terms, and domain nouns. If a full path or server-base-prefixed path fails, retry with the operation path only or shorter path segments
 - For AsyncAPI, search by operation id, action (send/receive), channel address, message name/title, payload/schema name, and important payload field names. If the first query fails, retry with shorter terms from the user request
 - For GraphQL, search by operation name, operation type (query/mutation/subscription), description terms, input/output type names, and domain nouns. If the first query fails, retry with shorter terms from the user request
+- For Protobuf (gRPC), search by rpc method name, service name, request/response message names, and domain nouns. If the first query fails, retry with shorter terms from the user request
 - Query string has special features: -word to force exclude a word from the search - it can help if search results are flooded with irrelevant results; "something certain" - double quotes to strict search of a phrase/word
 - Group results by packageId when displaying
 - Return all metadata that MCP returns (operationId, packageId, packageName, version, title, apiKind, apiType, apiAudience, documentId, and API-specific fields)
@@ -394,19 +413,19 @@ LLM INSTRUCTIONS:
 	* If default version (omit release) and query variations still return nothing: read api-packages-list, find the target package's actual 'versions' list, and retry with explicit 'release' set to the newest (or user-mentioned) version from that list. Packages may use YYYY.Q (e.g., 2024.3), semver (0.0.1, 0.1.0), or other schemes — never assume the calendar default exists for every package
 - If user asks for more results - increment page, simplify query, or search in other packages/versions
 - DO NOT use get_api_operation_specification in advance - first show a list of operations to choose from, even if only one is found
-- Use get_api_operation_specification only when user explicitly requests details about a REST or AsyncAPI operation
+- Use get_api_operation_specification only when user explicitly requests details about a REST, AsyncAPI or Protobuf operation
 - VERSION — IMPORTANT: when 'release' is omitted, the tool uses the nearest completed calendar quarter (e.g., 2026.2), NOT the latest version actually published in the system. Many packages do not have that calendar version yet; some use semver or other schemes. If the user mentions any version number, ALWAYS pass it explicitly as 'release'. When search without 'release' returns no results even after query/synonym retries, consult api-packages-list for the package's real versions and repeat search with 'release' set explicitly
 - If user requests results from a specific package - use 'group' parameter with packageId (not packageName)`
 
-	ToolDescriptionGetOperationSpecMCP = `Get operation-level specification data extracted from an OpenAPI or AsyncAPI specification.
+	ToolDescriptionGetOperationSpecMCP = `Get operation-level specification data extracted from an OpenAPI, AsyncAPI or Protobuf specification.
 
-Supported apiType values: rest, asyncapi.
+Supported apiType values: rest, asyncapi, protobuf.
 
-Use this tool ONLY when the user explicitly requests details about a specific REST or AsyncAPI operation.
+Use this tool ONLY when the user explicitly requests details about a specific REST, AsyncAPI or Protobuf operation.
 
 LLM INSTRUCTIONS:
 - Always pass apiType from the selected search_api_operations result
-- The response contains JSON with REST or Async API specification - in your user-facing reply put the full JSON inside a fenced markdown code block with the json language tag (not inline prose)
+- The response contains JSON with REST, Async API or Protobuf specification - in your user-facing reply put the full JSON inside a fenced markdown code block with the json language tag (not inline prose)
 - After the code block, add a human-readable description:
 	* Purpose and meaning of the operation
 	* Description of request, response, message, or channel structure
@@ -429,7 +448,7 @@ LLM INSTRUCTIONS:
 
 	ToolDescriptionGetDocumentMCP = `Get a source API specification by slug.
 
-Supported apiType values: rest, graphql, asyncapi.
+Supported apiType values: rest, graphql, asyncapi, protobuf.
 
 Use this tool when the user needs the source API specification or a document-level diff built by comparing two fetched versions.
 The response contains documentType, format, and documentData with the full source specification. JSON specifications are returned as structured JSON; non-JSON specifications are returned as text.
@@ -462,7 +481,7 @@ It behaves like get_api_operation_diff with apiType=rest and should not be used
 const (
 	ToolDescriptionSearchOperationsOpenAI = `Search for API operations by text query.
 
-Supported apiType values: rest, graphql, asyncapi.
+Supported apiType values: rest, graphql, asyncapi, protobuf.
 
 IMPORTANT: Search is lexical full-text search, not semantic, fuzzy, or substring search. Plain words are treated as required terms, so try shorter and longer query variations.
 IMPORTANT: Search matches only terms included in the operation search index. If a query returns too few or irrelevant results, retry with alternative terms such as operation names, titles, REST path segments, AsyncAPI channel/message names, GraphQL input/output type names, or domain keywords.
@@ -474,6 +493,7 @@ LLM INSTRUCTIONS:
 - For REST, search by HTTP method, operation path, distinctive path segment, title, summary/description terms, and domain nouns. If a full path or server-base-prefixed path fails, retry with the operation path only or shorter path segments
 - For AsyncAPI, search by operation id, action (send/receive), channel address, message name/title, payload/schema name, and important payload field names. If the first query fails, retry with shorter terms from the user request
 - For GraphQL, search by operation name, operation type (query/mutation/subscription), description terms, input/output type names, and domain nouns. If the first query fails, retry with shorter terms from the user request
+- For Protobuf (gRPC), search by rpc method name, service name, request/response message names, and domain nouns. If the first query fails, retry with shorter terms from the user request
 - Query string has special features: -word to force exclude a word from the search - it can help if search results are flooded with irrelevant results; "something certain" - double quotes to strict search of a phrase/word
 - Group results by packageId when displaying in markdown format
 - Return all metadata that MCP returns (operationId, packageId, packageName, version, title, apiKind, apiType, apiAudience, documentId, and API-specific fields)
@@ -486,7 +506,7 @@ LLM INSTRUCTIONS:
 	* If default version (omit release) and query variations still return nothing: read api-packages-list, find the target package's actual 'versions' list, and retry with explicit 'release' set to the newest (or user-mentioned) version from that list. Packages may use YYYY.Q (e.g., 2024.3), semver (0.0.1, 0.1.0), or other schemes — never assume the calendar default exists for every package
 - If user asks for more results - increment page, simplify query, or search in other packages/versions
 - DO NOT use get_api_operation_specification in advance - first show a list of operations to choose from in markdown format, even if only one is found
-- Use get_api_operation_specification only when user explicitly requests details about a REST or AsyncAPI operation
+- Use get_api_operation_specification only when user explicitly requests details about a REST, AsyncAPI or Protobuf operation
 - VERSION — IMPORTANT: when 'release' is omitted, the tool uses the nearest completed calendar quarter (e.g., 2026.2), NOT the latest version actually published in the system. Many packages do not have that calendar version yet; some use semver or other schemes. If the user mentions any version number, ALWAYS pass it explicitly as 'release'. When search without 'release' returns no results even after query/synonym retries, consult api-packages-list for the package's real versions and repeat search with 'release' set explicitly
 - If user requests results from a specific package - use 'group' parameter with packageId (not packageName)
 - REQUIRED: Convert metadata to markdown links (relative, without baseUrl):
@@ -494,15 +514,15 @@ LLM INSTRUCTIONS:
 	* operationId -> [operationId](/portal/packages/<packageId>/<version>/operations/<apiType>/<operationId>)
 - Format responses in markdown with well-readable markup (headings, lists, tables)`
 
-	ToolDescriptionGetOperationSpecOpenAI = `Get operation-level specification data extracted from an OpenAPI or AsyncAPI specification.
+	ToolDescriptionGetOperationSpecOpenAI = `Get operation-level specification data extracted from an OpenAPI, AsyncAPI or Protobuf specification.
 
-Supported apiType values: rest, asyncapi.
+Supported apiType values: rest, asyncapi, protobuf.
 
-Use this tool ONLY when the user explicitly requests details about a specific REST or AsyncAPI operation.
+Use this tool ONLY when the user explicitly requests details about a specific REST, AsyncAPI or Protobuf operation.
 
 LLM INSTRUCTIONS:
 - Always pass apiType from the selected search_api_operations result
-- The response contains JSON with REST or Async API specification - in your user-facing reply put the full JSON inside a fenced markdown code block with the json language tag (not inline prose)
+- The response contains JSON with REST, Async API or Protobuf specification - in your user-facing reply put the full JSON inside a fenced markdown code block with the json language tag (not inline prose)
 - After the code block, add a human-readable description in markdown format:
 	* Purpose and meaning of the operation
 	* Description of request, response, message, or channel structure
@@ -527,7 +547,7 @@ LLM INSTRUCTIONS:
 
 	ToolDescriptionGetDocumentOpenAI = `Get a source API specification by slug.
 
-Supported apiType values: rest, graphql, asyncapi.
+Supported apiType values: rest, graphql, asyncapi, protobuf.
 
 Use this tool when the user needs the source API specification, and especially for GraphQL details where operation-level specification and diff tools are not supported.
 The response contains documentType, format, and documentData with the full source specification. JSON specifications are returned as structured JSON; non-JSON specifications are returned as text.
@@ -548,7 +568,7 @@ var (
 		"properties": {
 			"apiType": {
 				"type": "string",
-				"enum": ["rest", "graphql", "asyncapi"]
+				"enum": ["rest", "graphql", "asyncapi", "protobuf"]
 			},
 			"query": {
 				"type": "string"
@@ -577,7 +597,7 @@ var (
 		"properties": {
 			"apiType": {
 				"type": "string",
-				"enum": ["rest", "asyncapi"]
+				"enum": ["rest", "asyncapi", "protobuf"]
 			},
 			"operationId": {
 				"type": "string"
@@ -620,7 +640,7 @@ var (
 		"properties": {
 			"apiType": {
 				"type": "string",
-				"enum": ["rest", "graphql", "asyncapi"]
+				"enum": ["rest", "graphql", "asyncapi", "protobuf"]
 			},
 			"packageId": {
 				"type": "string"
@@ -782,7 +802,7 @@ func GetToolsForOpenAI() []map[string]interface{} {
 func getParameterDescription(toolName, paramName string) string {
 	descriptions := map[string]map[string]string{
 		ToolNameSearchOperations: {
-			"apiType": "API type to search. Allowed values: rest, graphql, asyncapi",
+			"apiType": "API type to search. Allowed values: rest, graphql, asyncapi, protobuf",
 			"query":   "Text search query for finding API operations. Important: search is lexical and index-bound, so try different query variations (simplified, with keywords)",
 			"limit":   "Maximum number of results to return (10-100). For the first search, it's recommended to use 100",
 			"page":    "Page number for pagination (starts from 0). Use to get additional results",
@@ -790,7 +810,7 @@ func getParameterDescription(toolName, paramName string) string {
 			"group":   "Package ID (packageId) to filter search by a specific package. Use packageId from api-packages-list resource, not packageName",
 		},
 		ToolNameGetOperationSpec: {
-			"apiType":     "API type for operation-level specification data. Allowed values: rest, asyncapi. GraphQL is unsupported",
+			"apiType":     "API type for operation-level specification data. Allowed values: rest, asyncapi, protobuf. GraphQL is unsupported",
 			"operationId": "Unique operation identifier (operationId) from search results",
 			"packageId":   "Package ID (packageId) where the operation is located. Use packageId from search results or api-packages-list resource",
 			"version":     "Package version in YYYY.Q format (e.g., 2024.3) where the operation is located",
@@ -803,7 +823,7 @@ func getParameterDescription(toolName, paramName string) string {
 			"previousVersion": "Package version in YYYY.Q format (e.g., 2024.2) where the operation was located",
 		},
 		ToolNameGetDocument: {
-			"apiType":   "API type for the specification. Allowed values: rest, graphql, asyncapi",
+			"apiType":   "API type for the specification. Allowed values: rest, graphql, asyncapi, protobuf",
 			"packageId": "Package ID (packageId) where the specification is located. Use packageId from search results or api-packages-list resource",
 			"version":   "Package version in YYYY.Q format (e.g., 2024.3) where the specification is located",
 			"slug":      "Specification slug. Use documentId returned by search_api_operations; do not invent this value",
@@ -122,7 +122,7 @@ func transformOperation(item interface{}) (view.TransformedOperation, bool) {
 		return result, true
 	case view.GraphQLOperationSearchResult:
 		result := transformCommonOperation(op.CommonOperationSearchResult, op.CommonOperationView)
-		result.GraphQLOperationType = op.Type
+		result.OperationType = op.Type
 		result.Method = op.Method
 		return result, true
 	case view.AsyncAPIOperationSearchResult:
@@ -133,6 +133,11 @@ func transformOperation(item interface{}) (view.TransformedOperation, bool) {
 		result.AsyncOperationId = op.AsyncOperationId
 		result.MessageId = op.MessageId
 		return result, true
+	case view.ProtobufOperationSearchResult:
+		result := transformCommonOperation(op.CommonOperationSearchResult, op.CommonOperationView)
+		result.OperationType = op.Type
+		result.Method = op.Method
+		return result, true
 	case view.CommonOperationSearchResult:
 		return view.TransformedOperation{
 			PackageId:   op.PackageId,
@@ -169,6 +174,8 @@ func extractOperationData(operationViewInterface interface{}) (interface{}, erro
 		return op.Data, nil
 	case view.AsyncAPIOperationSingleView:
 		return op.Data, nil
+	case view.ProtobufOperationSingleView:
+		return op.Data, nil
 	default:
 		return nil, fmt.Errorf("operation specification is not supported for returned operation type %T", op)
 	}
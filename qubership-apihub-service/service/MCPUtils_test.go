@@ -132,19 +132,45 @@ func TestTransformOperations(t *testing.T) {
 				Title:       "Pet created event",
 			},
 		},
+		view.ProtobufOperationSearchResult{
+			ProtobufOperationView: view.ProtobufOperationView{
+				OperationListView: view.OperationListView{
+					CommonOperationView: view.CommonOperationView{
+						OperationId: "protobuf-op",
+						ApiKind:     "bwc",
+						ApiType:     string(view.ProtobufApiType),
+						ApiAudience: "public",
+						DocumentId:  "protobuf-doc",
+					},
+				},
+				ProtobufOperationMetadata: view.ProtobufOperationMetadata{
+					Type:   view.UnaryType,
+					Method: "GetPet",
+				},
+			},
+			CommonOperationSearchResult: view.CommonOperationSearchResult{
+				PackageId:   "pkg",
+				PackageName: "Package",
+				Version:     "2026.1@1",
+				Title:       "Get pet",
+			},
+		},
 	}
 
 	actual := transformOperations(operations)
 
-	require.Len(t, actual, 3)
+	require.Len(t, actual, 4)
 	require.Equal(t, "rest-doc", actual[0].DocumentId)
 	require.Equal(t, "/pets", actual[0].Path)
 	require.Equal(t, "GET", actual[0].Method)
 	require.Equal(t, "graphql-doc", actual[1].DocumentId)
-	require.Equal(t, view.QueryType, actual[1].GraphQLOperationType)
+	require.Equal(t, view.QueryType, actual[1].OperationType)
 	require.Equal(t, "async-doc", actual[2].DocumentId)
 	require.Equal(t, "pet.created", actual[2].Channel)
 	require.Equal(t, "sendPetCreated", actual[2].AsyncOperationId)
+	require.Equal(t, "protobuf-doc", actual[3].DocumentId)
+	require.Equal(t, view.UnaryType, actual[3].OperationType)
+	require.Equal(t, "GetPet", actual[3].Method)
 }
 
 func TestExtractOperationData(t *testing.T) {
@@ -1,30 +1,30 @@
 package service
 
 import (
+	"context"
 	"time"
 
-	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
-	"github.com/robfig/cron/v3"
 	log "github.com/sirupsen/logrus"
 )
 
+const metricsGetterJobName = "metricsGetter"
+
 // FIXME: not used!!!
 type MetricsService interface {
 	CreateJob(schedule string) error
 }
 
-func NewMetricsService(metricsRepository repository.MetricsRepository) MetricsService {
+func NewMetricsService(metricsRepository repository.MetricsRepository, backgroundJobService BackgroundJobService) MetricsService {
 	return &metricsServiceImpl{
-		metricsRepository: metricsRepository,
-		cron:              cron.New(),
+		metricsRepository:    metricsRepository,
+		backgroundJobService: backgroundJobService,
 	}
 }
 
 type metricsServiceImpl struct {
-	metricsRepository  repository.MetricsRepository
-	connectionProvider db.ConnectionProvider
-	cron               *cron.Cron
+	metricsRepository    repository.MetricsRepository
+	backgroundJobService BackgroundJobService
 }
 
 func (c *metricsServiceImpl) CreateJob(schedule string) error {
@@ -32,23 +32,18 @@ func (c *metricsServiceImpl) CreateJob(schedule string) error {
 		schedule:          schedule,
 		metricsRepository: c.metricsRepository,
 	}
-
-	if len(c.cron.Entries()) == 0 {
-		location, err := time.LoadLocation("")
-		if err != nil {
-			return err
-		}
-		c.cron = cron.New(cron.WithLocation(location))
-		c.cron.Start()
-	}
-
-	_, err := c.cron.AddJob(schedule, &job)
+	err := c.backgroundJobService.RegisterJob(BackgroundJobDefinition{
+		Name:        metricsGetterJobName,
+		Description: "Collects metrics of the service",
+		Schedule:    schedule,
+		Distributed: true,
+		Timeout:     30 * time.Minute,
+		Job:         job,
+	})
 	if err != nil {
 		log.Warnf("[Metrics service] Job wasn't added for schedule - %s. With error - %s", schedule, err)
 		return err
 	}
-	log.Infof("[Metrics service] Job was created with schedule - %s", schedule)
-
 	return nil
 }
 
@@ -57,9 +52,6 @@ type MetricsGetterJob struct {
 	metricsRepository repository.MetricsRepository
 }
 
-func (j MetricsGetterJob) Run() {
-	err := j.metricsRepository.StartGetMetricsProcess()
-	if err != nil {
-		log.Errorf("[MetricsGetterJob-Run]  err - %s", err.Error())
-	}
+func (j MetricsGetterJob) Run(ctx context.Context) error {
+	return j.metricsRepository.StartGetMetricsProcess()
 }
@@ -3,21 +3,18 @@ package service
 import (
 	"bytes"
 	"context"
-	"crypto/x509"
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service/blobstore"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
 	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -177,7 +174,7 @@ func (m minioStorageServiceImpl) createBucketIfNotExists(ctx context.Context) er
 		return err
 	}
 	if exists {
-		log.Infof("Minio bucket - %s exists", m.creds.BucketName)
+		log.WithContext(ctx).Infof("Minio bucket - %s exists", m.creds.BucketName)
 	} else {
 		err = m.minioClient.client.MakeBucket(ctx, m.creds.BucketName, minio.MakeBucketOptions{})
 		if err != nil {
@@ -188,58 +185,15 @@ func (m minioStorageServiceImpl) createBucketIfNotExists(ctx context.Context) er
 			return err
 		}
 		if exists {
-			log.Infof("Minio bucket - %s was created", m.creds.BucketName)
+			log.WithContext(ctx).Infof("Minio bucket - %s was created", m.creds.BucketName)
 		}
 	}
 	return nil
 }
 
 func createMinioClient(creds *view.MinioStorageCreds) *minioClient {
-	client := new(minioClient)
-	var err error
-	tr, err := minio.DefaultTransport(true)
-	if err != nil {
-		log.Warnf("error creating the minio connection: error creating the default transport layer: %v", err)
-		client.error = err
-		return client
-	}
-	crt, err := os.CreateTemp("", "minio.cert")
-	if err != nil {
-		log.Warn(err.Error())
-		client.error = err
-		return client
-	}
-	decodeSamlCert, err := base64.StdEncoding.DecodeString(creds.Crt)
-	if err != nil {
-		log.Warn(err.Error())
-		client.error = err
-		return client
-	}
-
-	_, err = crt.WriteString(string(decodeSamlCert))
-	rootCAs := mustGetSystemCertPool()
-	data, err := os.ReadFile(crt.Name())
-	if err == nil {
-		rootCAs.AppendCertsFromPEM(data)
-	}
-	tr.TLSClientConfig.RootCAs = rootCAs
-
-	minioClient, err := minio.New(creds.Endpoint, &minio.Options{
-		Creds:     credentials.NewStaticV4(creds.AccessKeyId, creds.SecretAccessKey, ""),
-		Secure:    true,
-		Transport: tr,
-	})
-	if err != nil {
-		if strings.Contains(err.Error(), "endpoint") {
-			err = errors.New("invalid storage URL")
-		}
-		log.Warn(err.Error())
-		client.error = err
-		return client
-	}
-	log.Infof("MINIO instance initialized")
-	client.client = minioClient
-	return client
+	client, err := blobstore.NewMinioClient(creds)
+	return &minioClient{client: client, error: err}
 }
 
 func (m minioStorageServiceImpl) uploadBuildResults(ctx context.Context) ([]string, error) {
@@ -250,16 +204,16 @@ func (m minioStorageServiceImpl) uploadBuildResults(ctx context.Context) ([]stri
 	for {
 		buildResult, err = m.buildRepository.GetBuildResultWithOffset(offset)
 		if err != nil {
-			log.Infof("%d build_results were ulpoaded to minio storage, until got error", offset)
+			log.WithContext(ctx).Infof("%d build_results were ulpoaded to minio storage, until got error", offset)
 			break
 		}
 		if buildResult == nil {
-			log.Infof("%d build_results were ulpoaded to minio storage, until buildResult is null", offset)
+			log.WithContext(ctx).Infof("%d build_results were ulpoaded to minio storage, until buildResult is null", offset)
 			break
 		}
 		err = m.putObject(ctx, buildFileName(view.BUILD_RESULT_TABLE, buildResult.BuildId), buildResult.Data)
 		if err != nil {
-			log.Infof("%d build_results were ulpoaded to minio storage, until got error", offset)
+			log.WithContext(ctx).Infof("%d build_results were ulpoaded to minio storage, until got error", offset)
 			break
 		}
 		ids = append(ids, buildResult.BuildId)
@@ -275,16 +229,16 @@ func (m minioStorageServiceImpl) uploadPublishedSourcesArchives(ctx context.Cont
 	for {
 		publishedSourceArchive, err := m.publishRepo.GetPublishedSourcesArchives(offset)
 		if err != nil {
-			log.Infof("%d published_sources_archives were uploaded to minio storage, before error was received", offset)
+			log.WithContext(ctx).Infof("%d published_sources_archives were uploaded to minio storage, before error was received", offset)
 			break
 		}
 		if publishedSourceArchive == nil {
-			log.Infof("%d published_sources_archives were uploaded to minio storage, before publishedSourceArchive became null", offset)
+			log.WithContext(ctx).Infof("%d published_sources_archives were uploaded to minio storage, before publishedSourceArchive became null", offset)
 			break
 		}
 		err = m.putObject(ctx, buildFileName(view.PUBLISHED_SOURCES_ARCHIVES_TABLE, publishedSourceArchive.Checksum), publishedSourceArchive.Data)
 		if err != nil {
-			log.Infof("%d published_sources_archives were uploaded to minio storage, before error was received", offset)
+			log.WithContext(ctx).Infof("%d published_sources_archives were uploaded to minio storage, before error was received", offset)
 			break
 		}
 		checksums = append(checksums, publishedSourceArchive.Checksum)
@@ -303,7 +257,9 @@ func (m minioStorageServiceImpl) UploadFile(ctx context.Context, tableName, enti
 	return nil
 }
 
+// putObject compresses the content the same way as BlobStorageService does
 func (m minioStorageServiceImpl) putObject(ctx context.Context, fileName string, content []byte) error {
+	content = utils.CompressData(content)
 	_, err := m.minioClient.client.PutObject(ctx, m.creds.BucketName, fileName, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{})
 	if err != nil {
 		return err
@@ -319,11 +275,14 @@ func (m minioStorageServiceImpl) GetFile(ctx context.Context, tableName, entityI
 func (m minioStorageServiceImpl) getFile(ctx context.Context, fullFileName string) ([]byte, error) {
 	minioObject, err := m.minioClient.client.GetObject(ctx, m.creds.BucketName, fullFileName, minio.GetObjectOptions{})
 	if err != nil {
-		log.Warn(err)
+		log.WithContext(ctx).Warn(err)
 		return nil, err
 	}
 	minioObjectContent, err := io.ReadAll(minioObject)
-	return minioObjectContent, err
+	if err != nil {
+		return nil, err
+	}
+	return utils.DecompressData(minioObjectContent)
 }
 
 func (m minioStorageServiceImpl) RemoveFiles(ctx context.Context, tableName string, entityIds []string) error {
@@ -352,14 +311,6 @@ func bucketExists(ctx context.Context, minioClient *minio.Client, bucketName str
 	}
 	return exists, nil
 }
-func mustGetSystemCertPool() *x509.CertPool {
-	pool, err := x509.SystemCertPool()
-	if err != nil {
-		return x509.NewCertPool()
-	}
-	return pool
-}
-
 func buildFileName(tableName, entityId string) string {
 	return fmt.Sprintf("%s/%s.zip", tableName, entityId)
 }
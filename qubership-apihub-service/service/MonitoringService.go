@@ -5,6 +5,7 @@ import (
 	"crypto/sha1"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -16,7 +17,10 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-const stringSeparator = "|@@|"
+const (
+	stringSeparator        = "|@@|"
+	monitoringFlushJobName = "monitoringFlush"
+)
 
 type MonitoringService interface {
 	AddVersionOpenCount(packageId string, version string)
@@ -26,9 +30,11 @@ type MonitoringService interface {
 	IncreaseBusinessMetricCounterForDate(userId string, metric string, key string, date time.Time) error
 	DecreaseBusinessMetricCounterForDate(userId string, metric string, key string, date time.Time) error
 	AddEndpointCall(path string, options interface{})
+	// Flush stores the counters collected in memory to the database
+	Flush(ctx context.Context) error
 }
 
-func NewMonitoringService(cp db.ConnectionProvider) MonitoringService {
+func NewMonitoringService(cp db.ConnectionProvider, backgroundJobService BackgroundJobService) MonitoringService {
 	monitoringService := &monitoringServiceImpl{
 		versionOpenCount:     make(map[string]int),
 		documentOpenCount:    make(map[string]int),
@@ -43,7 +49,18 @@ func NewMonitoringService(cp db.ConnectionProvider) MonitoringService {
 		endpointCallsMutex:   &sync.RWMutex{},
 		cp:                   cp,
 	}
-	monitoringService.startPeriodicFlushJob(time.Minute * 5)
+	err := backgroundJobService.RegisterJob(BackgroundJobDefinition{
+		Name:        monitoringFlushJobName,
+		Description: "Flushes open counts, business metrics and endpoint calls collected by the instance to the database",
+		Schedule:    "@every 5m",
+		// the counters are kept in memory of each instance
+		Distributed: false,
+		Timeout:     4 * time.Minute,
+		Job:         BackgroundJobFunc(monitoringService.Flush),
+	})
+	if err != nil {
+		log.Errorf("Failed to register %s job: %v", monitoringFlushJobName, err)
+	}
 	return monitoringService
 }
 
@@ -125,24 +142,18 @@ func splitOperationKey(operationKey string) (string, string, string) {
 	return operationKeySplit[0], operationKeySplit[1], operationKeySplit[2]
 }
 
-func (m *monitoringServiceImpl) startPeriodicFlushJob(interval time.Duration) {
-	utils.SafeAsync(func() {
-		for {
-			time.Sleep(interval)
-			err := m.flushOpenCount()
-			if err != nil {
-				log.Errorf("PeriodicFlushJob failed to flush open count to db: %v", err.Error())
-			}
-			err = m.flushBusinessMetrics()
-			if err != nil {
-				log.Errorf("PeriodicFlushJob failed to flush business metrics to db: %v", err.Error())
-			}
-			err = m.flushEndpointCalls()
-			if err != nil {
-				log.Errorf("PeriodicFlushJob failed to flush endpoint calls to db: %v", err.Error())
-			}
-		}
-	})
+func (m *monitoringServiceImpl) Flush(ctx context.Context) error {
+	var errs []error
+	if err := m.flushOpenCount(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to flush open count to db: %w", err))
+	}
+	if err := m.flushBusinessMetrics(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to flush business metrics to db: %w", err))
+	}
+	if err := m.flushEndpointCalls(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to flush endpoint calls to db: %w", err))
+	}
+	return errors.Join(errs...)
 }
 
 func (m *monitoringServiceImpl) flushOpenCount() error {
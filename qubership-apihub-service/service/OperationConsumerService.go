@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	secctx "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type OperationConsumerService interface {
+	GetConsumedOperations(ctx context.Context, consumerType string, consumerId string) (*view.ConsumedOperations, error)
+	// SetConsumedOperations replaces all operations declared by the consumer
+	SetConsumedOperations(ctx context.Context, secCtx secctx.SecurityContext, consumerType string, consumerId string, req view.ConsumedOperationsReq) (*view.ConsumedOperations, error)
+	GetOperationConsumers(ctx context.Context, packageId string, req view.OperationConsumersReq) (*view.OperationConsumers, error)
+	// GetConsumerImpact lists consumers of the operations with breaking changes between the version and the previous one
+	GetConsumerImpact(ctx context.Context, packageId string, version string, req view.ConsumerImpactReq) (*view.ConsumerImpact, error)
+	// CheckApiKeyConsumer checks that the api key exists and belongs to the package
+	CheckApiKeyConsumer(packageId string, apiKeyId string) error
+}
+
+func NewOperationConsumerService(repo repository.OperationConsumerRepository,
+	apiKeyRepo repository.ApihubApiKeyRepository,
+	versionService VersionService) OperationConsumerService {
+	return &operationConsumerServiceImpl{
+		repo:           repo,
+		apiKeyRepo:     apiKeyRepo,
+		versionService: versionService,
+	}
+}
+
+type operationConsumerServiceImpl struct {
+	repo           repository.OperationConsumerRepository
+	apiKeyRepo     repository.ApihubApiKeyRepository
+	versionService VersionService
+}
+
+func (o *operationConsumerServiceImpl) GetConsumedOperations(ctx context.Context, consumerType string, consumerId string) (*view.ConsumedOperations, error) {
+	ents, err := o.repo.GetConsumedOperations(ctx, consumerType, consumerId)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.ConsumedOperations{Operations: make([]view.ConsumedOperation, 0, len(ents))}
+	for _, ent := range ents {
+		result.Operations = append(result.Operations, entity.MakeConsumedOperationView(ent))
+	}
+	return result, nil
+}
+
+func (o *operationConsumerServiceImpl) SetConsumedOperations(ctx context.Context, secCtx secctx.SecurityContext, consumerType string, consumerId string, req view.ConsumedOperationsReq) (*view.ConsumedOperations, error) {
+	now := time.Now()
+	ents := make([]entity.OperationConsumerEntity, 0, len(req.Operations))
+	index := make(map[string]int, len(req.Operations))
+	for _, operation := range req.Operations {
+		version := operation.Version
+		if strings.Contains(version, "@") {
+			versionName, _, err := SplitVersionRevision(version)
+			if err != nil {
+				return nil, err
+			}
+			version = versionName
+		}
+		ent := entity.OperationConsumerEntity{
+			ConsumerType: consumerType,
+			ConsumerId:   consumerId,
+			PackageId:    operation.PackageId,
+			OperationId:  operation.OperationId,
+			Version:      version,
+			DeclaredBy:   secCtx.GetUserId(),
+			DeclaredAt:   now,
+		}
+		// the same operation could be declared only once, the last declaration wins
+		key := operation.PackageId + "|" + operation.OperationId
+		if i, exists := index[key]; exists {
+			ents[i] = ent
+			continue
+		}
+		index[key] = len(ents)
+		ents = append(ents, ent)
+	}
+
+	missing, err := o.repo.GetMissingOperations(ctx, ents)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) > 0 {
+		if missing[0].Version != "" {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.ConsumedOperationVersionNotFound,
+				Message: exception.ConsumedOperationVersionNotFoundMsg,
+				Params:  map[string]interface{}{"packageId": missing[0].PackageId, "version": missing[0].Version, "operationId": missing[0].OperationId},
+			}
+		}
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.ConsumedOperationNotFound,
+			Message: exception.ConsumedOperationNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": missing[0].PackageId, "operationId": missing[0].OperationId},
+		}
+	}
+
+	err = o.repo.ReplaceConsumedOperations(ctx, consumerType, consumerId, ents)
+	if err != nil {
+		return nil, err
+	}
+	return o.GetConsumedOperations(ctx, consumerType, consumerId)
+}
+
+func (o *operationConsumerServiceImpl) GetOperationConsumers(ctx context.Context, packageId string, req view.OperationConsumersReq) (*view.OperationConsumers, error) {
+	var operationIds []string
+	if req.OperationId != "" {
+		operationIds = []string{req.OperationId}
+	}
+	ents, err := o.repo.GetConsumers(ctx, packageId, operationIds, req.Limit, req.Page)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.OperationConsumers{Consumers: make([]view.OperationConsumer, 0, len(ents))}
+	for _, ent := range ents {
+		result.Consumers = append(result.Consumers, entity.MakeOperationConsumerView(ent))
+	}
+	return result, nil
+}
+
+func (o *operationConsumerServiceImpl) GetConsumerImpact(ctx context.Context, packageId string, version string, req view.ConsumerImpactReq) (*view.ConsumerImpact, error) {
+	changes, err := o.versionService.GetVersionChanges(packageId, version, "", []string{string(view.Breaking)}, view.VersionChangesReq{
+		PreviousVersion:          req.PreviousVersion,
+		PreviousVersionPackageId: req.PreviousVersionPackageId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// breaking operations grouped by the package which owns them, it differs from packageId for dashboards
+	breakingOperations := make(map[string]map[string]view.ImpactedOperation)
+	for _, operation := range changes.Operations {
+		impactedOperation, packageRef, ok := makeImpactedOperation(operation)
+		if !ok {
+			continue
+		}
+		impactedOperation.PackageId = packageId
+		if ref, exists := changes.Packages[packageRef]; exists && ref.RefPackageId != "" {
+			impactedOperation.PackageId = ref.RefPackageId
+		}
+		if breakingOperations[impactedOperation.PackageId] == nil {
+			breakingOperations[impactedOperation.PackageId] = make(map[string]view.ImpactedOperation)
+		}
+		breakingOperations[impactedOperation.PackageId][impactedOperation.OperationId] = impactedOperation
+	}
+
+	result := &view.ConsumerImpact{
+		PreviousVersion:          changes.PreviousVersion,
+		PreviousVersionPackageId: changes.PreviousVersionPackageId,
+		Consumers:                make([]view.ImpactedConsumer, 0),
+	}
+	consumerIndex := make(map[string]int)
+	for producerPackageId, operations := range breakingOperations {
+		result.BreakingOperationsCount += len(operations)
+		operationIds := make([]string, 0, len(operations))
+		for operationId := range operations {
+			operationIds = append(operationIds, operationId)
+		}
+		consumerEnts, err := o.repo.GetConsumers(ctx, producerPackageId, operationIds, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, consumerEnt := range consumerEnts {
+			key := consumerEnt.ConsumerType + "|" + consumerEnt.ConsumerId
+			i, exists := consumerIndex[key]
+			if !exists {
+				i = len(result.Consumers)
+				consumerIndex[key] = i
+				result.Consumers = append(result.Consumers, view.ImpactedConsumer{
+					Consumer:   entity.MakeConsumerView(consumerEnt),
+					Operations: make([]view.ImpactedOperation, 0),
+				})
+			}
+			impactedOperation := operations[consumerEnt.OperationId]
+			impactedOperation.ConsumedVersion = consumerEnt.Version
+			result.Consumers[i].Operations = append(result.Consumers[i].Operations, impactedOperation)
+		}
+	}
+	sort.Slice(result.Consumers, func(i, j int) bool {
+		if result.Consumers[i].ConsumerType != result.Consumers[j].ConsumerType {
+			return result.Consumers[i].ConsumerType < result.Consumers[j].ConsumerType
+		}
+		return result.Consumers[i].ConsumerId < result.Consumers[j].ConsumerId
+	})
+	return result, nil
+}
+
+// makeImpactedOperation returns the operation and the ref of the package version which contains it
+func makeImpactedOperation(operation interface{}) (view.ImpactedOperation, string, bool) {
+	changes, apiType, ok := view.GetOperationComparisonChangesCommon(operation)
+	if !ok {
+		return view.ImpactedOperation{}, "", false
+	}
+	packageRef := changes.PackageRef
+	if changes.Action == view.ChangelogActionRemove {
+		packageRef = changes.PreviousVersionPackageRef
+	}
+	return view.ImpactedOperation{
+		OperationId:   changes.OperationId,
+		Title:         changes.Title,
+		ApiType:       string(apiType),
+		Action:        changes.Action,
+		ChangeSummary: changes.ChangeSummary,
+	}, packageRef, true
+}
+
+func (o *operationConsumerServiceImpl) CheckApiKeyConsumer(packageId string, apiKeyId string) error {
+	apiKey, err := o.apiKeyRepo.GetPackageApiKey(apiKeyId, packageId)
+	if err != nil {
+		return err
+	}
+	if apiKey == nil || apiKey.DeletedAt != nil {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PackageApiKeyNotFound,
+			Message: exception.PackageApiKeyNotFoundMsg,
+			Params:  map[string]interface{}{"apiKeyId": apiKeyId, "packageId": packageId},
+		}
+	}
+	return nil
+}
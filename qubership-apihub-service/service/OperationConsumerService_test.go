@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeImpactedOperation(t *testing.T) {
+	changed := view.RestOperationComparisonChangesView{
+		OperationComparisonChangesView: view.OperationComparisonChangesView{
+			OperationId:               "get-quote",
+			Title:                     "Get quote",
+			ChangeSummary:             view.ChangeSummary{Breaking: 2},
+			PackageRef:                "QS.CORE.QUOTE@2024.2@1",
+			PreviousVersionPackageRef: "QS.CORE.QUOTE@2024.1@3",
+			Action:                    view.ChangelogActionChange,
+		},
+	}
+	operation, packageRef, ok := makeImpactedOperation(changed)
+	require.True(t, ok)
+	require.Equal(t, "QS.CORE.QUOTE@2024.2@1", packageRef)
+	require.Equal(t, "get-quote", operation.OperationId)
+	require.Equal(t, "Get quote", operation.Title)
+	require.Equal(t, string(view.RestApiType), operation.ApiType)
+	require.Equal(t, 2, operation.ChangeSummary.Breaking)
+
+	removed := view.AsyncAPIOperationComparisonChangesView{
+		OperationComparisonChangesView: view.OperationComparisonChangesView{
+			OperationId:               "send-quote-event",
+			PreviousVersionPackageRef: "QS.CORE.QUOTE@2024.1@3",
+			Action:                    view.ChangelogActionRemove,
+		},
+	}
+	operation, packageRef, ok = makeImpactedOperation(removed)
+	require.True(t, ok)
+	require.Equal(t, "QS.CORE.QUOTE@2024.1@3", packageRef)
+	require.Equal(t, string(view.AsyncapiApiType), operation.ApiType)
+	require.Equal(t, view.ChangelogActionRemove, operation.Action)
+
+	_, _, ok = makeImpactedOperation(view.OperationComparisonChangesView{})
+	require.False(t, ok)
+}
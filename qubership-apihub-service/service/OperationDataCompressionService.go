@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	operationDataCompressionJobName   = "operationDataCompression"
+	operationDataCompressionBatchSize = 100
+	operationDataCompressionDelay     = 100 * time.Millisecond
+)
+
+type OperationDataCompressionService interface {
+	StartCompressionJob() error
+}
+
+func NewOperationDataCompressionService(repo repository.OperationDataCompressionRepository, backgroundJobService BackgroundJobService) OperationDataCompressionService {
+	return &operationDataCompressionServiceImpl{
+		repo:                 repo,
+		backgroundJobService: backgroundJobService,
+	}
+}
+
+type operationDataCompressionServiceImpl struct {
+	repo                 repository.OperationDataCompressionRepository
+	backgroundJobService BackgroundJobService
+}
+
+func (s *operationDataCompressionServiceImpl) StartCompressionJob() error {
+	return s.backgroundJobService.RegisterJob(BackgroundJobDefinition{
+		Name:        operationDataCompressionJobName,
+		Description: "Compresses operation data stored before the compression was introduced",
+		Schedule:    "@every 10m",
+		Distributed: true,
+		Timeout:     9 * time.Minute,
+		Job:         BackgroundJobFunc(s.compressOperationData),
+	})
+}
+
+// compressOperationData continues the compression from the last processed data hash until all operation data is processed
+func (s *operationDataCompressionServiceImpl) compressOperationData(ctx context.Context) error {
+	progress, err := s.repo.GetProgress(ctx)
+	if err != nil {
+		return err
+	}
+	if progress == nil || progress.FinishedAt != nil {
+		return nil
+	}
+	lastDataHash := progress.LastDataHash
+	compressedCount := 0
+	for ctx.Err() == nil {
+		data, err := s.repo.GetOperationData(ctx, lastDataHash, operationDataCompressionBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(data) == 0 {
+			log.Infof("[OperationDataCompression] compression of operation data is finished, %d rows compressed by the last run", compressedCount)
+			return s.repo.SetFinished(ctx)
+		}
+		compressed := compressOperationDataBatch(data)
+		lastDataHash = data[len(data)-1].DataHash
+		if err = s.repo.SaveCompressedOperationData(ctx, compressed, lastDataHash); err != nil {
+			return err
+		}
+		compressedCount += len(compressed)
+		select {
+		case <-ctx.Done():
+		case <-time.After(operationDataCompressionDelay):
+		}
+	}
+	log.Infof("[OperationDataCompression] %d rows compressed, the compression will be continued by the next run", compressedCount)
+	return nil
+}
+
+// compressOperationDataBatch returns the rows which become smaller after the compression, with the compressed data.
+// Rows which are already compressed are skipped.
+func compressOperationDataBatch(data []entity.RawOperationDataEntity) []entity.RawOperationDataEntity {
+	result := make([]entity.RawOperationDataEntity, 0, len(data))
+	for _, d := range data {
+		if utils.IsCompressedData(d.Data) {
+			continue
+		}
+		compressed := utils.CompressData(d.Data)
+		if !utils.IsCompressedData(compressed) {
+			continue
+		}
+		result = append(result, entity.RawOperationDataEntity{DataHash: d.DataHash, Data: compressed})
+	}
+	return result
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressOperationDataBatch(t *testing.T) {
+	large := bytes.Repeat([]byte(`{"operationId":"get-pets"}`), 100)
+	data := []entity.RawOperationDataEntity{
+		{DataHash: "small", Data: []byte(`{"a":1}`)},
+		{DataHash: "large", Data: large},
+		{DataHash: "compressed", Data: utils.CompressData(large)},
+	}
+	result := compressOperationDataBatch(data)
+	require.Len(t, result, 1)
+	require.Equal(t, "large", result[0].DataHash)
+	decompressed, err := utils.DecompressData(result[0].Data)
+	require.NoError(t, err)
+	require.Equal(t, large, decompressed)
+}
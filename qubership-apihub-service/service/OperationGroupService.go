@@ -34,13 +34,14 @@ type OperationGroupService interface {
 }
 
 func NewOperationGroupService(operationRepository repository.OperationRepository, publishedRepo repository.PublishedRepository, exportRepository repository.ExportResultRepository,
-	packageVersionEnrichmentService PackageVersionEnrichmentService, activityTrackingService ActivityTrackingService) OperationGroupService {
+	packageVersionEnrichmentService PackageVersionEnrichmentService, activityTrackingService ActivityTrackingService, blobStorageService BlobStorageService) OperationGroupService {
 	return &operationGroupServiceImpl{
 		operationRepo:                   operationRepository,
 		publishedRepo:                   publishedRepo,
 		exportRepository:                exportRepository,
 		packageVersionEnrichmentService: packageVersionEnrichmentService,
 		atService:                       activityTrackingService,
+		blobStorageService:              blobStorageService,
 	}
 }
 
@@ -51,6 +52,7 @@ type operationGroupServiceImpl struct {
 	packageVersionEnrichmentService PackageVersionEnrichmentService
 	atService                       ActivityTrackingService
 	buildService                    BuildService
+	blobStorageService              BlobStorageService
 }
 
 func (o *operationGroupServiceImpl) SetBuildService(buildService BuildService) {
@@ -627,6 +629,17 @@ func (o operationGroupServiceImpl) publishOperationGroup(ctx context.SecurityCon
 			OperationsSpecTransformation: view.TransformationReducedSource,
 			Format:                       view.FormatJSON,
 		}
+	} else if apiType == string(view.ProtobufApiType) {
+		buildConfig = view.BuildConfig{
+			PackageId:                    version.PackageId,
+			Version:                      view.MakeVersionRefKey(version.Version, version.Revision),
+			BuildType:                    view.ExportProtobufOperationsGroup,
+			CreatedBy:                    ctx.GetUserId(),
+			ApiType:                      string(view.ProtobufApiType),
+			GroupName:                    groupName,
+			OperationsSpecTransformation: view.TransformationReducedSource,
+			Format:                       view.ProtobufFormat,
+		}
 	} else {
 		o.updatePublishProcess(publishEnt, string(view.StatusError), fmt.Sprintf("unsupported API type: %s", apiType))
 		return
@@ -651,6 +664,10 @@ func (o operationGroupServiceImpl) publishOperationGroup(ctx context.SecurityCon
 		o.updatePublishProcess(publishEnt, string(view.StatusError), "export result not found")
 		return
 	}
+	if err = loadExportResultData(o.blobStorageService, exportResult); err != nil {
+		o.updatePublishProcess(publishEnt, string(view.StatusError), fmt.Sprintf("failed to get export result data: %v", err.Error()))
+		return
+	}
 
 	files := make([]view.BCFile, 0)
 	publishFile := true
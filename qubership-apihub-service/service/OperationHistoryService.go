@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type OperationHistoryService interface {
+	// GetOperationHistory walks published versions of the package and returns the events of the operation in the order of publication.
+	// Each version is compared with the version it is based on: the previous revision of the same version or the previous version
+	GetOperationHistory(ctx context.Context, packageId string, operationId string, req view.OperationHistoryReq) (*view.OperationHistory, error)
+}
+
+func NewOperationHistoryService(repo repository.OperationHistoryRepository, publishedRepo repository.PublishedRepository) OperationHistoryService {
+	return &operationHistoryServiceImpl{repo: repo, publishedRepo: publishedRepo}
+}
+
+type operationHistoryServiceImpl struct {
+	repo          repository.OperationHistoryRepository
+	publishedRepo repository.PublishedRepository
+}
+
+func (o *operationHistoryServiceImpl) GetOperationHistory(ctx context.Context, packageId string, operationId string, req view.OperationHistoryReq) (*view.OperationHistory, error) {
+	packageEnt, err := o.publishedRepo.GetPackage(packageId)
+	if err != nil {
+		return nil, err
+	}
+	if packageEnt == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PackageNotFound,
+			Message: exception.PackageNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId},
+		}
+	}
+	states, err := o.repo.GetOperationStates(ctx, packageId, operationId)
+	if err != nil {
+		return nil, err
+	}
+	if len(states) == 0 {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.OperationHistoryNotFound,
+			Message: exception.OperationHistoryNotFoundMsg,
+			Params:  map[string]interface{}{"operationId": operationId, "packageId": packageId},
+		}
+	}
+	versions, err := o.repo.GetPublishedVersions(ctx, packageId, req.AllRevisions)
+	if err != nil {
+		return nil, err
+	}
+	comparisons, err := o.repo.GetOperationComparisons(ctx, packageId, operationId)
+	if err != nil {
+		return nil, err
+	}
+	return makeOperationHistory(packageId, operationId, versions, states, comparisons, req.Severities), nil
+}
+
+func makeOperationHistory(packageId string, operationId string,
+	versions []entity.OperationHistoryVersionEntity,
+	states []entity.OperationHistoryStateEntity,
+	comparisons []entity.OperationHistoryComparisonEntity,
+	severities []string) *view.OperationHistory {
+	statesMap := make(map[string]*entity.OperationHistoryStateEntity, len(states))
+	for i := range states {
+		statesMap[view.MakeVersionRefKey(states[i].Version, states[i].Revision)] = &states[i]
+	}
+	comparisonsMap := make(map[string][]entity.OperationHistoryComparisonEntity)
+	for _, comparison := range comparisons {
+		key := view.MakeVersionRefKey(comparison.Version, comparison.Revision)
+		comparisonsMap[key] = append(comparisonsMap[key], comparison)
+	}
+
+	latestRevisions := make(map[string]int)
+	for _, version := range versions {
+		if version.Revision > latestRevisions[version.Version] {
+			latestRevisions[version.Version] = version.Revision
+		}
+	}
+
+	result := &view.OperationHistory{
+		PackageId:   packageId,
+		OperationId: operationId,
+		Events:      make([]view.OperationHistoryEvent, 0),
+	}
+	walkedRevisions := make(map[string]int)
+	for _, version := range versions {
+		key := view.MakeVersionRefKey(version.Version, version.Revision)
+		previousPackageId, previousKey := getOperationHistoryPreviousVersion(packageId, version, walkedRevisions, latestRevisions, comparisonsMap[key])
+		walkedRevisions[version.Version] = version.Revision
+		var previous *entity.OperationHistoryStateEntity
+		if previousPackageId == packageId {
+			previous = statesMap[previousKey]
+		}
+		current := statesMap[key]
+		if current == nil && previous == nil {
+			continue
+		}
+		newEvent := func(eventType string) view.OperationHistoryEvent {
+			return view.OperationHistoryEvent{
+				Type:        eventType,
+				Version:     key,
+				Status:      version.Status,
+				PublishedAt: version.PublishedAt,
+			}
+		}
+		var events []view.OperationHistoryEvent
+		switch {
+		case previous == nil:
+			event := newEvent(view.OperationHistoryEventAdded)
+			event.ApiAudience = current.ApiAudience
+			setStoredChanges(&event, previousPackageId, previousKey, comparisonsMap[key], severities)
+			events = append(events, event)
+		case current == nil:
+			event := newEvent(view.OperationHistoryEventRemoved)
+			if !setStoredChanges(&event, previousPackageId, previousKey, comparisonsMap[key], severities) {
+				// removal of the deprecated operation is not breaking
+				event.Breaking = !previous.Deprecated
+			}
+			events = append(events, event)
+		default:
+			if current.DataHash != previous.DataHash {
+				event := newEvent(view.OperationHistoryEventChanged)
+				if setStoredChanges(&event, previousPackageId, previousKey, comparisonsMap[key], severities) || len(severities) == 0 {
+					events = append(events, event)
+				}
+			}
+			if current.Deprecated != previous.Deprecated {
+				if current.Deprecated {
+					event := newEvent(view.OperationHistoryEventDeprecated)
+					event.DeprecatedInfo = current.DeprecatedInfo
+					event.DeprecatedInPreviousVersions = current.PreviousReleaseVersions
+					events = append(events, event)
+				} else {
+					events = append(events, newEvent(view.OperationHistoryEventDeprecationRemoved))
+				}
+			}
+			if current.ApiAudience != previous.ApiAudience {
+				event := newEvent(view.OperationHistoryEventAudienceChanged)
+				event.ApiAudience = current.ApiAudience
+				event.PreviousApiAudience = previous.ApiAudience
+				events = append(events, event)
+			}
+		}
+		for _, event := range events {
+			result.Events = append(result.Events, event)
+			if event.Breaking {
+				lastBreaking := event
+				result.LastBreakingChange = &lastBreaking
+			}
+		}
+		if current != nil {
+			result.ApiType = current.ApiType
+			result.Title = current.Title
+		}
+	}
+	return result
+}
+
+// getOperationHistoryPreviousVersion returns the package and the version key the given version is based on:
+// the previous walked revision of the same version or the previous version of the published version.
+// The revision of the previous version is the one it was compared with on publication, if it is known.
+func getOperationHistoryPreviousVersion(packageId string, version entity.OperationHistoryVersionEntity,
+	walkedRevisions map[string]int, latestRevisions map[string]int,
+	comparisons []entity.OperationHistoryComparisonEntity) (string, string) {
+	if revision, exists := walkedRevisions[version.Version]; exists {
+		return packageId, view.MakeVersionRefKey(version.Version, revision)
+	}
+	if version.PreviousVersion == "" {
+		return "", ""
+	}
+	previousPackageId := version.PreviousVersionPackageId
+	if previousPackageId == "" {
+		previousPackageId = packageId
+	}
+	previousRevision := 0
+	for _, comparison := range comparisons {
+		if comparison.PreviousPackageId == previousPackageId && comparison.PreviousVersion == version.PreviousVersion &&
+			comparison.PreviousRevision > previousRevision {
+			previousRevision = comparison.PreviousRevision
+		}
+	}
+	if previousRevision == 0 && previousPackageId == packageId {
+		if revision, exists := walkedRevisions[version.PreviousVersion]; exists {
+			previousRevision = revision
+		} else {
+			previousRevision = latestRevisions[version.PreviousVersion]
+		}
+	}
+	return previousPackageId, view.MakeVersionRefKey(version.PreviousVersion, previousRevision)
+}
+
+// setStoredChanges fills the event with the changes stored for the comparison with the previous version.
+// Returns false if there is no such comparison or no stored changes matching the severities.
+func setStoredChanges(event *view.OperationHistoryEvent, previousPackageId string, previousKey string, comparisons []entity.OperationHistoryComparisonEntity, severities []string) bool {
+	if previousKey == "" {
+		return false
+	}
+	var comparison *entity.OperationHistoryComparisonEntity
+	for i := range comparisons {
+		if comparisons[i].PreviousPackageId == previousPackageId && view.MakeVersionRefKey(comparisons[i].PreviousVersion, comparisons[i].PreviousRevision) == previousKey {
+			comparison = &comparisons[i]
+			break
+		}
+	}
+	if comparison == nil {
+		return false
+	}
+	changes := make([]interface{}, 0)
+	for _, change := range entity.MakeOperationChangesListView(entity.OperationComparisonEntity{Changes: comparison.Changes}) {
+		if len(severities) == 0 || utils.SliceContains(severities, view.GetSingleOperationChangeCommon(change).Severity) {
+			changes = append(changes, change)
+		}
+	}
+	if len(severities) != 0 && len(changes) == 0 {
+		return false
+	}
+	summary := comparison.ChangesSummary
+	event.PreviousVersion = view.MakeVersionRefKey(comparison.PreviousVersion, comparison.PreviousRevision)
+	event.PreviousVersionPackageId = comparison.PreviousPackageId
+	event.ChangeSummary = &summary
+	event.Changes = changes
+	event.Breaking = summary.Breaking > 0
+	return true
+}
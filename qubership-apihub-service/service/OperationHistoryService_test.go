@@ -0,0 +1,77 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeOperationHistory(t *testing.T) {
+	publishedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	versions := []entity.OperationHistoryVersionEntity{
+		{Version: "2023.4", Revision: 1, Status: "release", PublishedAt: publishedAt},
+		{Version: "2024.1", Revision: 1, Status: "release", PublishedAt: publishedAt.AddDate(0, 3, 0), PreviousVersion: "2023.4"},
+		{Version: "2024.2", Revision: 1, Status: "release", PublishedAt: publishedAt.AddDate(0, 6, 0), PreviousVersion: "2024.1"},
+		{Version: "2024.3", Revision: 2, Status: "release", PublishedAt: publishedAt.AddDate(0, 9, 0), PreviousVersion: "2024.2"},
+		{Version: "2024.4", Revision: 1, Status: "draft", PublishedAt: publishedAt.AddDate(0, 12, 0), PreviousVersion: "2024.3"},
+		// patch of the older release is compared with the release, not with the latest published version
+		{Version: "2024.1.1", Revision: 1, Status: "release", PublishedAt: publishedAt.AddDate(0, 13, 0), PreviousVersion: "2024.1"},
+		{Version: "2023.4.1", Revision: 1, Status: "release", PublishedAt: publishedAt.AddDate(0, 14, 0), PreviousVersion: "2023.4"},
+	}
+	states := []entity.OperationHistoryStateEntity{
+		{Version: "2024.1", Revision: 1, ApiType: "rest", Title: "Get quote", DataHash: "a", ApiAudience: "external"},
+		{Version: "2024.2", Revision: 1, ApiType: "rest", Title: "Get quote", DataHash: "b", ApiAudience: "external"},
+		{Version: "2024.3", Revision: 2, ApiType: "rest", Title: "Get quote v2", DataHash: "b", ApiAudience: "internal", Deprecated: true, PreviousReleaseVersions: []string{"2024.2"}},
+		{Version: "2024.1.1", Revision: 1, ApiType: "rest", Title: "Get quote v2", DataHash: "c", ApiAudience: "external"},
+	}
+	comparisons := []entity.OperationHistoryComparisonEntity{
+		{Version: "2024.2", Revision: 1, PreviousPackageId: "QS.QUOTE", PreviousVersion: "2023.4", PreviousRevision: 1},
+		{
+			Version: "2024.2", Revision: 1, PreviousPackageId: "QS.QUOTE", PreviousVersion: "2024.1", PreviousRevision: 1,
+			ChangesSummary: view.ChangeSummary{Breaking: 1, NonBreaking: 1},
+			Changes: map[string]interface{}{"changes": []interface{}{
+				map[string]interface{}{"action": "remove", "severity": "breaking", "description": "[Removed] parameter"},
+				map[string]interface{}{"action": "add", "severity": "non-breaking", "description": "[Added] property"},
+			}},
+		},
+	}
+
+	history := makeOperationHistory("QS.QUOTE", "quote-get", versions, states, comparisons, nil)
+	require.Equal(t, "rest", history.ApiType)
+	require.Equal(t, "Get quote v2", history.Title)
+	eventTypes := make([]string, 0)
+	for _, event := range history.Events {
+		eventTypes = append(eventTypes, event.Type+"@"+event.Version)
+	}
+	require.Equal(t, []string{
+		"added@2024.1@1",
+		"changed@2024.2@1",
+		"deprecated@2024.3@2",
+		"audienceChanged@2024.3@2",
+		"removed@2024.4@1",
+		"changed@2024.1.1@1",
+	}, eventTypes)
+
+	changed := history.Events[1]
+	require.True(t, changed.Breaking)
+	require.Equal(t, "2024.1@1", changed.PreviousVersion)
+	require.Len(t, changed.Changes, 2)
+	require.Equal(t, []string{"2024.2"}, history.Events[2].DeprecatedInPreviousVersions)
+	require.Equal(t, "internal", history.Events[3].ApiAudience)
+	require.Equal(t, "external", history.Events[3].PreviousApiAudience)
+	require.False(t, history.Events[4].Breaking, "removal of deprecated operation is not breaking")
+	require.NotNil(t, history.LastBreakingChange)
+	require.Equal(t, "2024.2@1", history.LastBreakingChange.Version)
+	require.Empty(t, history.Events[5].PreviousVersion, "there is no stored comparison of the patch")
+
+	filtered := makeOperationHistory("QS.QUOTE", "quote-get", versions, states, comparisons, []string{string(view.NonBreaking)})
+	require.Len(t, filtered.Events[1].Changes, 1)
+
+	annotations := makeOperationHistory("QS.QUOTE", "quote-get", versions, states, comparisons, []string{string(view.Annotation)})
+	for _, event := range annotations.Events {
+		require.NotEqual(t, view.OperationHistoryEventChanged, event.Type)
+	}
+}
@@ -18,8 +18,10 @@ import (
 )
 
 type OperationService interface {
-	GetOperations(packageId string, version string, skipRefs bool, searchReq view.OperationListReq) (*view.Operations, error)
-	GetOperation(searchReq view.OperationBasicSearchReq) (interface{}, error)
+	GetOperations(ctx context.Context, packageId string, version string, skipRefs bool, searchReq view.OperationListReq) (*view.Operations, error)
+	GetOperation(ctx context.Context, searchReq view.OperationBasicSearchReq) (interface{}, error)
+	// GetOperationCacheInfo returns the data identifying the published operation for HTTP conditional requests without reading operation data
+	GetOperationCacheInfo(ctx context.Context, searchReq view.OperationBasicSearchReq) (*view.PublishedCacheInfo, error)
 	GetOperationsTags(searchReq view.OperationBasicSearchReq, skipRefs bool) (*view.OperationTags, error)
 	GetOperationChanges(packageId string, version string, operationId string, previousPackageId string, previousVersion string, severities []string) (*view.OperationChangesView, error)
 	GetVersionChanges(packageId string, version string, apiType string, searchReq view.VersionChangesReq) (*view.VersionChangesView, error)
@@ -29,7 +31,7 @@ type OperationService interface {
 	GetDeprecatedOperations(packageId string, version string, searchReq view.DeprecatedOperationListReq) (*view.Operations, error)
 	GetOperationDeprecatedItems(searchReq view.OperationBasicSearchReq) (*view.DeprecatedItems, error)
 	GetDeprecatedOperationsSummary(packageId string, version string) (*view.DeprecatedOperationsSummary, error)
-	GetOperationModelUsages(packageId string, version string, apiType string, operationId string, modelName string) (*view.OperationModelUsages, error)
+	GetOperationModelUsages(ctx context.Context, packageId string, version string, apiType string, operationId string, modelName string) (*view.OperationModelUsages, error)
 	GetOperationChangesSummary(packageId string, version string, operationId string, previousPackageId string, previousVersion string, refPackageId string) (*view.ChangeSummary, error)
 }
 
@@ -178,7 +180,7 @@ func (o operationServiceImpl) GetDeprecatedOperations(packageId string, version
 	return &operations, nil
 }
 
-func (o operationServiceImpl) GetOperations(packageId string, version string, skipRefs bool, searchReq view.OperationListReq) (*view.Operations, error) {
+func (o operationServiceImpl) GetOperations(ctx context.Context, packageId string, version string, skipRefs bool, searchReq view.OperationListReq) (*view.Operations, error) {
 	if searchReq.RefPackageId != "" {
 		packageEnt, err := o.publishedRepo.GetPackage(packageId)
 		if err != nil {
@@ -220,7 +222,7 @@ func (o operationServiceImpl) GetOperations(packageId string, version string, sk
 	if err != nil {
 		return nil, err
 	}
-	operationEnts, err := o.operationRepository.GetOperations(packageId, versionEnt.Version, versionEnt.Revision, searchReq.ApiType, skipRefs, searchReq)
+	operationEnts, err := o.operationRepository.GetOperations(ctx, packageId, versionEnt.Version, versionEnt.Revision, searchReq.ApiType, skipRefs, searchReq)
 	if err != nil {
 		return nil, err
 	}
@@ -262,7 +264,7 @@ func parseTextFilterToCustomTagKeyValue(textFilter string) (string, string, erro
 	return "", "", nil
 }
 
-func (o operationServiceImpl) GetOperation(searchReq view.OperationBasicSearchReq) (interface{}, error) {
+func (o operationServiceImpl) GetOperation(ctx context.Context, searchReq view.OperationBasicSearchReq) (interface{}, error) {
 	versionEnt, err := o.publishedRepo.GetVersion(searchReq.PackageId, searchReq.Version)
 	if err != nil {
 		return nil, err
@@ -275,7 +277,7 @@ func (o operationServiceImpl) GetOperation(searchReq view.OperationBasicSearchRe
 			Params:  map[string]interface{}{"version": searchReq.Version, "packageId": searchReq.PackageId},
 		}
 	}
-	operationEnt, err := o.operationRepository.GetOperationById(searchReq.PackageId, versionEnt.Version, versionEnt.Revision, searchReq.ApiType, searchReq.OperationId, searchReq.IncludeData)
+	operationEnt, err := o.operationRepository.GetOperationById(ctx, searchReq.PackageId, versionEnt.Version, versionEnt.Revision, searchReq.ApiType, searchReq.OperationId, searchReq.IncludeData)
 	if err != nil {
 		return nil, err
 	}
@@ -292,6 +294,38 @@ func (o operationServiceImpl) GetOperation(searchReq view.OperationBasicSearchRe
 	return &operationView, nil
 }
 
+func (o operationServiceImpl) GetOperationCacheInfo(ctx context.Context, searchReq view.OperationBasicSearchReq) (*view.PublishedCacheInfo, error) {
+	versionEnt, err := o.publishedRepo.GetVersion(searchReq.PackageId, searchReq.Version)
+	if err != nil {
+		return nil, err
+	}
+	if versionEnt == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PublishedPackageVersionNotFound,
+			Message: exception.PublishedPackageVersionNotFoundMsg,
+			Params:  map[string]interface{}{"version": searchReq.Version, "packageId": searchReq.PackageId},
+		}
+	}
+	operationEnt, err := o.operationRepository.GetOperationById(ctx, searchReq.PackageId, versionEnt.Version, versionEnt.Revision, searchReq.ApiType, searchReq.OperationId, false)
+	if err != nil {
+		return nil, err
+	}
+	if operationEnt == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.OperationNotFound,
+			Message: exception.OperationNotFoundMsg,
+			Params:  map[string]interface{}{"operationId": searchReq.OperationId, "version": searchReq.Version, "packageId": searchReq.PackageId},
+		}
+	}
+	dataHash := ""
+	if operationEnt.DataHash != nil {
+		dataHash = *operationEnt.DataHash
+	}
+	return makePublishedCacheInfo(versionEnt, searchReq.Version, dataHash), nil
+}
+
 func (o operationServiceImpl) GetOperationDeprecatedItems(searchReq view.OperationBasicSearchReq) (*view.DeprecatedItems, error) {
 	versionEnt, err := o.publishedRepo.GetVersion(searchReq.PackageId, searchReq.Version)
 	if err != nil {
@@ -652,7 +686,7 @@ func (o operationServiceImpl) LiteSearchForOperations(searchReq view.SearchQuery
 }
 
 func (o operationServiceImpl) GlobalSearchForOperations(ctx context.Context, searchReq view.SearchQueryReq) (*view.SearchResult, error) {
-	log.Debugf(
+	log.WithContext(ctx).Debugf(
 		"GlobalSearchForOperations called: searchString=%q apiType=%s workspace=%s status=%s packageIds=%v versions=%v startDate=%v endDate=%v limit=%d page=%d",
 		searchReq.SearchString,
 		searchReq.ApiType,
@@ -700,10 +734,10 @@ func (o operationServiceImpl) GlobalSearchForOperations(ctx context.Context, sea
 	operationEntities, err := o.operationRepository.GlobalSearchForOperations(ctx, searchQuery)
 	repoSearchElapsed := time.Since(repoSearchStart)
 	if err != nil {
-		log.Debugf("GlobalSearchForOperations: repository search finished with error after %s: %v", repoSearchElapsed, err)
+		log.WithContext(ctx).Debugf("GlobalSearchForOperations: repository search finished with error after %s: %v", repoSearchElapsed, err)
 		return nil, err
 	}
-	log.Debugf("GlobalSearchForOperations: repository search finished in %s, resultCount=%d", repoSearchElapsed, len(operationEntities))
+	log.WithContext(ctx).Debugf("GlobalSearchForOperations: repository search finished in %s, resultCount=%d", repoSearchElapsed, len(operationEntities))
 	operations := make([]interface{}, 0)
 	for _, ent := range operationEntities {
 		operations = append(operations, entity.MakeGlobalOperationSearchResultView(ent))
@@ -721,6 +755,8 @@ func setOperationSearchParams(operationParams *view.OperationSearchParams, searc
 		return setRestOperationSearchParams(operationParams, searchQuery)
 	case string(view.GraphqlApiType):
 		return setGraphqlOperationSearchParams(operationParams, searchQuery)
+	case string(view.ProtobufApiType):
+		return setProtobufOperationSearchParams(operationParams, searchQuery)
 	default:
 		return &exception.CustomError{
 			Status:  http.StatusBadRequest,
@@ -753,7 +789,24 @@ func setGraphqlOperationSearchParams(graphqlOperationParams *view.OperationSearc
 	return nil
 }
 
-func (o operationServiceImpl) GetOperationModelUsages(packageId string, version string, apiType string, operationId string, modelName string) (*view.OperationModelUsages, error) {
+func setProtobufOperationSearchParams(protobufOperationParams *view.OperationSearchParams, searchQuery *entity.OperationSearchQuery) error {
+	searchQuery.ApiType = protobufOperationParams.ApiType
+	for _, operationType := range protobufOperationParams.OperationTypes {
+		if !view.ValidProtobufOperationType(operationType) {
+			return &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.InvalidSearchParameters,
+				Message: exception.InvalidSearchParametersMsg,
+				Params:  map[string]interface{}{"error": fmt.Sprintf("operation type %v is invalid for %v apiType", operationType, protobufOperationParams.ApiType)},
+			}
+		}
+	}
+	searchQuery.Methods = append(searchQuery.Methods, protobufOperationParams.Methods...)
+	searchQuery.OperationTypes = append(searchQuery.OperationTypes, protobufOperationParams.OperationTypes...)
+	return nil
+}
+
+func (o operationServiceImpl) GetOperationModelUsages(ctx context.Context, packageId string, version string, apiType string, operationId string, modelName string) (*view.OperationModelUsages, error) {
 	versionEnt, err := o.publishedRepo.GetVersion(packageId, version)
 	if err != nil {
 		return nil, err
@@ -766,7 +819,7 @@ func (o operationServiceImpl) GetOperationModelUsages(packageId string, version
 			Params:  map[string]interface{}{"version": version, "packageId": packageId},
 		}
 	}
-	operationEnt, err := o.operationRepository.GetOperationById(versionEnt.PackageId, versionEnt.Version, versionEnt.Revision, apiType, operationId, false)
+	operationEnt, err := o.operationRepository.GetOperationById(ctx, versionEnt.PackageId, versionEnt.Version, versionEnt.Revision, apiType, operationId, false)
 	if err != nil {
 		return nil, err
 	}
@@ -44,32 +44,38 @@ func NewPackageService(favoritesRepo repository.FavoritesRepository,
 	operationGroupService OperationGroupService,
 	userRepo repository.UserRepository,
 	ptHandler PackageTransitionHandler,
-	systemInfoService SystemInfoService) PackageService {
+	systemInfoService SystemInfoService,
+	publishNotificationService PublishNotificationService,
+	tenantService TenantService) PackageService {
 	return &packageServiceImpl{
-		favoritesRepo:         favoritesRepo,
-		publishedRepo:         publishedRepo,
-		versionService:        versionService,
-		roleService:           roleService,
-		atService:             atService,
-		monitoringService:     monitoringService,
-		operationGroupService: operationGroupService,
-		userRepo:              userRepo,
-		ptHandler:             ptHandler,
-		systemInfoService:     systemInfoService,
+		favoritesRepo:              favoritesRepo,
+		publishedRepo:              publishedRepo,
+		versionService:             versionService,
+		roleService:                roleService,
+		atService:                  atService,
+		monitoringService:          monitoringService,
+		operationGroupService:      operationGroupService,
+		userRepo:                   userRepo,
+		ptHandler:                  ptHandler,
+		systemInfoService:          systemInfoService,
+		publishNotificationService: publishNotificationService,
+		tenantService:              tenantService,
 	}
 }
 
 type packageServiceImpl struct {
-	favoritesRepo         repository.FavoritesRepository
-	publishedRepo         repository.PublishedRepository
-	versionService        VersionService
-	roleService           RoleService
-	atService             ActivityTrackingService
-	monitoringService     MonitoringService
-	operationGroupService OperationGroupService
-	userRepo              repository.UserRepository
-	ptHandler             PackageTransitionHandler
-	systemInfoService     SystemInfoService
+	favoritesRepo              repository.FavoritesRepository
+	publishedRepo              repository.PublishedRepository
+	versionService             VersionService
+	roleService                RoleService
+	atService                  ActivityTrackingService
+	monitoringService          MonitoringService
+	operationGroupService      OperationGroupService
+	userRepo                   repository.UserRepository
+	ptHandler                  PackageTransitionHandler
+	systemInfoService          SystemInfoService
+	publishNotificationService PublishNotificationService
+	tenantService              TenantService
 }
 
 func (p packageServiceImpl) CreatePackage(ctx context.SecurityContext, packg view.SimplePackage) (*view.SimplePackage, error) {
@@ -161,6 +167,20 @@ func (p packageServiceImpl) CreatePackage(ctx context.SecurityContext, packg vie
 		}
 	}
 
+	if packg.Kind == entity.KIND_PACKAGE || packg.Kind == entity.KIND_DASHBOARD {
+		err = p.tenantService.CheckPackageQuota(stdctx.Background(), packg.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	tenantSettings := &view.TenantSettings{}
+	if packg.ParentId != "" {
+		tenantSettings, err = p.tenantService.GetSettings(stdctx.Background(), packg.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if packg.Kind == entity.KIND_GROUP || packg.Kind == entity.KIND_WORKSPACE {
 		packg.ServiceName = ""
 	}
@@ -179,6 +199,9 @@ func (p packageServiceImpl) CreatePackage(ctx context.SecurityContext, packg vie
 
 	packg.CreatedAt = time.Now()
 	packg.CreatedBy = ctx.GetUserId()
+	if packg.DefaultRole == "" {
+		packg.DefaultRole = tenantSettings.DefaultRole
+	}
 	if packg.DefaultRole == "" {
 		packg.DefaultRole = view.ViewerRoleId
 	}
@@ -212,6 +235,8 @@ func (p packageServiceImpl) CreatePackage(ctx context.SecurityContext, packg vie
 				Debug:   err.Error(),
 			}
 		}
+	} else if tenantSettings.ReleaseVersionPattern != "" {
+		packg.ReleaseVersionPattern = tenantSettings.ReleaseVersionPattern
 	} else {
 		packg.ReleaseVersionPattern = p.systemInfoService.GetReleaseVersionPattern()
 	}
@@ -639,6 +664,12 @@ func (p packageServiceImpl) DeletePackage(ctx context.SecurityContext, id string
 		}
 	}
 
+	utils.SafeAsync(func() {
+		if err := p.publishNotificationService.SendDeletionNotification(id, ""); err != nil {
+			log.Errorf("failed to send deleted package notification: %v", err)
+		}
+	})
+
 	p.atService.TrackEvent(view.ActivityTrackingEvent{
 		Type:      view.ATETDeletePackage,
 		Data:      nil,
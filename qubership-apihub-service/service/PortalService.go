@@ -298,7 +298,7 @@ func (p portalServiceImpl) GenerateInteractivePageForPublishedVersion(packageId
 		return nil, "", err
 	}
 
-	attachmentVersionName, err := p.getVersionNameForAttachmentName(packageId, versionName)
+	attachmentVersionName, err := getVersionNameForAttachmentName(p.publishedRepository, packageId, versionName)
 	if err != nil {
 		return nil, "", err
 	}
@@ -545,18 +545,3 @@ func addFileToZip(zw *zip.Writer, name string, content []byte) error {
 func makeProjectTitle(projectName string, version string) string {
 	return projectName + " " + version
 }
-
-func (p portalServiceImpl) getVersionNameForAttachmentName(packageId, version string) (string, error) {
-	latestRevision, err := p.publishedRepository.GetLatestRevision(packageId, version)
-	if err != nil {
-		return "", err
-	}
-	versionName, versionRevision, err := SplitVersionRevision(version)
-	if err != nil {
-		return "", err
-	}
-	if latestRevision == versionRevision {
-		return versionName, nil
-	}
-	return version, nil
-}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
@@ -10,11 +11,13 @@ import (
 	"sync"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/cache"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/tracing"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
 
 	"github.com/buraksezer/olric"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 func NewPublishNotificationService(op cache.OlricProvider) PublishNotificationService {
@@ -30,7 +33,13 @@ func NewPublishNotificationService(op cache.OlricProvider) PublishNotificationSe
 }
 
 type PublishNotificationService interface {
-	SendNotification(packageId string, version string, revision int) error
+	SendNotification(packageId string, version string, revision int, publishedBy string) error
+	// SendDeletionNotification notifies the cluster that the version (or the whole package if version is empty) was deleted
+	SendDeletionNotification(packageId string, version string) error
+	// Subscribe registers a listener for 'version published' events. Events are delivered to every instance of the cluster.
+	Subscribe(listener func(notification view.PublishNotification)) error
+	// SubscribeDeletion registers a listener for 'version deleted' events sent to the same topic
+	SubscribeDeletion(listener func(notification view.PublishNotification)) error
 }
 
 type publishNotificationServiceImpl struct {
@@ -42,26 +51,47 @@ type publishNotificationServiceImpl struct {
 
 const VersionPublishedTopicName = "version-published"
 
-func (t *publishNotificationServiceImpl) SendNotification(packageId string, version string, revision int) error {
-	t.isReadyWg.Wait()
+var dtopicNameKey = attribute.Key("olric.dtopic.name")
 
-	if t.versionPublishedTopic == nil {
-		return fmt.Errorf("failed to publish message to %s DTopic since it's not initialized", VersionPublishedTopicName)
-	}
+func (t *publishNotificationServiceImpl) SendNotification(packageId string, version string, revision int, publishedBy string) error {
+	return t.send(view.PublishNotification{
+		EventId:     uuid.NewString(),
+		PackageId:   packageId,
+		Version:     version,
+		Revision:    revision,
+		PublishedBy: publishedBy,
+	})
+}
 
-	msg := view.PublishNotification{
+func (t *publishNotificationServiceImpl) SendDeletionNotification(packageId string, version string) error {
+	return t.send(view.PublishNotification{
 		EventId:   uuid.NewString(),
 		PackageId: packageId,
 		Version:   version,
-		Revision:  revision,
+		Deleted:   true,
+	})
+}
+
+func (t *publishNotificationServiceImpl) send(msg view.PublishNotification) error {
+	t.isReadyWg.Wait()
+
+	if t.versionPublishedTopic == nil {
+		return fmt.Errorf("failed to publish message to %s DTopic since it's not initialized", VersionPublishedTopicName)
 	}
 
+	ctx, span := tracing.StartSpan(context.Background(), "olric.dtopic.publish",
+		dtopicNameKey.String(VersionPublishedTopicName), tracing.PackageIdKey.String(msg.PackageId), tracing.VersionKey.String(msg.Version))
+	// listeners on all instances continue the trace of the event
+	msg.TraceContext = tracing.InjectContext(ctx)
+
 	jsonMsg, err := json.Marshal(msg)
 	if err != nil {
+		tracing.EndSpan(span, err)
 		return err
 	}
 
 	err = t.versionPublishedTopic.Publish(string(jsonMsg))
+	tracing.EndSpan(span, err)
 	if err != nil {
 		log.Errorf("Failed to send 'version published' event: %s", err)
 		return err
@@ -69,6 +99,43 @@ func (t *publishNotificationServiceImpl) SendNotification(packageId string, vers
 	return nil
 }
 
+func (t *publishNotificationServiceImpl) Subscribe(listener func(notification view.PublishNotification)) error {
+	return t.addListener(false, listener)
+}
+
+func (t *publishNotificationServiceImpl) SubscribeDeletion(listener func(notification view.PublishNotification)) error {
+	return t.addListener(true, listener)
+}
+
+func (t *publishNotificationServiceImpl) addListener(deleted bool, listener func(notification view.PublishNotification)) error {
+	t.isReadyWg.Wait()
+
+	if t.versionPublishedTopic == nil {
+		return fmt.Errorf("failed to subscribe to %s DTopic since it's not initialized", VersionPublishedTopicName)
+	}
+
+	_, err := t.versionPublishedTopic.AddListener(func(topicMsg olric.DTopicMessage) {
+		msgStr, ok := topicMsg.Message.(string)
+		if !ok {
+			log.Errorf("Unexpected message type in %s DTopic: %T", VersionPublishedTopicName, topicMsg.Message)
+			return
+		}
+		var msg view.PublishNotification
+		if err := json.Unmarshal([]byte(msgStr), &msg); err != nil {
+			log.Errorf("Failed to unmarshal 'version published' event: %s", err)
+			return
+		}
+		if msg.Deleted != deleted {
+			return
+		}
+		_, span := tracing.StartSpan(tracing.ExtractContext(context.Background(), msg.TraceContext), "olric.dtopic.receive",
+			dtopicNameKey.String(VersionPublishedTopicName), tracing.PackageIdKey.String(msg.PackageId), tracing.VersionKey.String(msg.Version))
+		defer span.End()
+		listener(msg)
+	})
+	return err
+}
+
 func (t *publishNotificationServiceImpl) initVersionPublishedDTopic() {
 	var err error
 	for attempt := 1; attempt < 4; attempt++ {
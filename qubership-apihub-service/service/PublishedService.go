@@ -60,9 +60,10 @@ func NewPublishedService(versionRepo repository.PublishedRepository,
 	operationRepo repository.OperationRepository,
 	atService ActivityTrackingService,
 	monitoringService MonitoringService,
-	minioStorageService MinioStorageService,
+	blobStorageService BlobStorageService,
 	systemInfoService SystemInfoService,
-	publishNotificationService PublishNotificationService) PublishedService {
+	publishNotificationService PublishNotificationService,
+	deprecationService DeprecationService) PublishedService {
 	return &publishedServiceImpl{
 		publishedRepo:              versionRepo,
 		buildRepository:            buildRepository,
@@ -70,10 +71,11 @@ func NewPublishedService(versionRepo repository.PublishedRepository,
 		operationRepo:              operationRepo,
 		atService:                  atService,
 		monitoringService:          monitoringService,
-		minioStorageService:        minioStorageService,
+		blobStorageService:         blobStorageService,
 		systemInfoService:          systemInfoService,
 		publishedValidator:         validation.NewPublishedValidator(versionRepo),
 		publishNotificationService: publishNotificationService,
+		deprecationService:         deprecationService,
 	}
 }
 
@@ -84,10 +86,11 @@ type publishedServiceImpl struct {
 	operationRepo              repository.OperationRepository
 	atService                  ActivityTrackingService
 	monitoringService          MonitoringService
-	minioStorageService        MinioStorageService
+	blobStorageService         BlobStorageService
 	systemInfoService          SystemInfoService
 	publishedValidator         validation.PublishedValidator
 	publishNotificationService PublishNotificationService
+	deprecationService         DeprecationService
 }
 
 func (p publishedServiceImpl) GetVersionSources(packageId string, versionName string) ([]byte, error) {
@@ -104,7 +107,7 @@ func (p publishedServiceImpl) GetVersionSources(packageId string, versionName st
 		}
 	}
 	var srcArchive []byte
-	if p.systemInfoService.IsMinioStorageActive() && !p.systemInfoService.IsMinioStoreOnlyBuildResult() {
+	if !p.blobStorageService.IsDatabaseOnly(view.BlobNamespaceSourcesArchives) {
 		publishedSrc, err := p.publishedRepo.GetPublishedSources(packageId, version.Version, version.Revision)
 		if err != nil {
 			return nil, err
@@ -118,7 +121,7 @@ func (p publishedServiceImpl) GetVersionSources(packageId string, versionName st
 			}
 		}
 		if publishedSrc.ArchiveChecksum != "" {
-			file, err := p.minioStorageService.GetFile(ctx.Background(), view.PUBLISHED_SOURCES_ARCHIVES_TABLE, publishedSrc.ArchiveChecksum)
+			file, err := p.blobStorageService.GetBlob(ctx.Background(), view.BlobNamespaceSourcesArchives, publishedSrc.ArchiveChecksum)
 			if err != nil {
 				return nil, err
 			}
@@ -167,7 +170,7 @@ func (p publishedServiceImpl) GetPublishedVersionSourceDataConfig(packageId stri
 		}
 	}
 	srcData := new(entity.PublishedSrcDataConfigEntity)
-	if p.systemInfoService.IsMinioStorageActive() && !p.systemInfoService.IsMinioStoreOnlyBuildResult() {
+	if !p.blobStorageService.IsDatabaseOnly(view.BlobNamespaceSourcesArchives) {
 		publishedSrc, err := p.publishedRepo.GetPublishedSources(packageId, version.Version, version.Revision)
 		if err != nil {
 			return nil, err
@@ -186,7 +189,7 @@ func (p publishedServiceImpl) GetPublishedVersionSourceDataConfig(packageId stri
 			Config:          publishedSrc.Config,
 		}
 		if publishedSrc.ArchiveChecksum != "" {
-			src, err := p.minioStorageService.GetFile(ctx.Background(), view.PUBLISHED_SOURCES_ARCHIVES_TABLE, publishedSrc.ArchiveChecksum)
+			src, err := p.blobStorageService.GetBlob(ctx.Background(), view.BlobNamespaceSourcesArchives, publishedSrc.ArchiveChecksum)
 			if err != nil {
 				return nil, err
 			}
@@ -332,6 +335,11 @@ func (p publishedServiceImpl) DeleteVersion(ctx context.SecurityContext, package
 			p.monitoringService.IncreaseBusinessMetricCounter(ctx.GetUserId(), metrics.ReleaseVersionsDeleted, packageId)
 		}
 	}
+	utils.SafeAsync(func() {
+		if err := p.publishNotificationService.SendDeletionNotification(packageId, versionName); err != nil {
+			log.Errorf("failed to send deleted version notification: %v", err)
+		}
+	})
 	return nil
 }
 
@@ -555,13 +563,13 @@ func (p publishedServiceImpl) PublishPackage(buildArc *archive.BuildResultArchiv
 		Config:          cfgBytes,
 		ArchiveChecksum: archiveCSStr,
 	}
-	if p.systemInfoService.IsMinioStorageActive() && !p.systemInfoService.IsMinioStoreOnlyBuildResult() {
-		minioUploadStart := time.Now()
-		err = p.minioStorageService.UploadFile(ctx.Background(), view.PUBLISHED_SOURCES_ARCHIVES_TABLE, archiveCSStr, buildSrcEnt.Source)
+	if !p.blobStorageService.IsDatabaseBackend(view.BlobNamespaceSourcesArchives) {
+		blobUploadStart := time.Now()
+		err = p.blobStorageService.PutBlob(ctx.Background(), view.BlobNamespaceSourcesArchives, archiveCSStr, buildSrcEnt.Source)
 		if err != nil {
 			return err
 		}
-		utils.PerfLog(time.Since(minioUploadStart).Milliseconds(), 100, "publishPackage: upload sources to minio")
+		utils.PerfLog(time.Since(blobUploadStart).Milliseconds(), 100, "publishPackage: upload sources to blob storage")
 	} else {
 		publishedSrcArchiveEntity = &entity.PublishedSrcArchiveEntity{
 			Checksum: archiveCSStr,
@@ -724,7 +732,12 @@ func (p publishedServiceImpl) PublishPackage(buildArc *archive.BuildResultArchiv
 			UserId:    versionEnt.CreatedBy,
 		})
 
-		err = p.publishNotificationService.SendNotification(versionEnt.PackageId, versionEnt.Version, versionEnt.Revision)
+		err = p.deprecationService.CaptureSpecPolicies(ctx.Background(), versionEnt.PackageId, versionEnt.Version, versionEnt.Status, versionEnt.CreatedBy, operationEntities)
+		if err != nil {
+			log.Errorf("failed to capture deprecation policies for version %s@%d of package %s: %v", versionEnt.Version, versionEnt.Revision, versionEnt.PackageId, err)
+		}
+
+		err = p.publishNotificationService.SendNotification(versionEnt.PackageId, versionEnt.Version, versionEnt.Revision, versionEnt.CreatedBy)
 		if err != nil {
 			log.Errorf("failed to send published version notification: %v", err)
 		}
@@ -1279,8 +1292,8 @@ func (p publishedServiceImpl) ReplaceVersionSources(secCtx context.SecurityConte
 		PerformedAt: time.Now(),
 	}
 
-	if p.systemInfoService.IsMinioStorageActive() && !p.systemInfoService.IsMinioStoreOnlyBuildResult() {
-		err = p.minioStorageService.UploadFile(ctx.Background(), view.PUBLISHED_SOURCES_ARCHIVES_TABLE, newChecksum, zipData)
+	if !p.blobStorageService.IsDatabaseBackend(view.BlobNamespaceSourcesArchives) {
+		err = p.blobStorageService.PutBlob(ctx.Background(), view.BlobNamespaceSourcesArchives, newChecksum, zipData)
 		if err != nil {
 			return err
 		}
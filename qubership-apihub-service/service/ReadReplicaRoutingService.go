@@ -0,0 +1,35 @@
+package service
+
+import (
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	log "github.com/sirupsen/logrus"
+)
+
+type ReadReplicaRoutingService interface {
+	// ListenVersionPublished routes reads of the publishing user to the primary database for a while,
+	// so the user sees the published version even if read replicas are lagging
+	ListenVersionPublished(publishNotificationService PublishNotificationService)
+}
+
+func NewReadReplicaRoutingService(cp db.ConnectionProvider) ReadReplicaRoutingService {
+	return &readReplicaRoutingServiceImpl{cp: cp}
+}
+
+type readReplicaRoutingServiceImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (r *readReplicaRoutingServiceImpl) ListenVersionPublished(publishNotificationService PublishNotificationService) {
+	utils.SafeAsync(func() {
+		err := publishNotificationService.Subscribe(func(notification view.PublishNotification) {
+			r.cp.MarkUserWrite(notification.PublishedBy)
+		})
+		if err != nil {
+			log.Errorf("read-replica-routing: failed to subscribe to version published events: %v", err)
+			return
+		}
+		log.Info("read-replica-routing: subscribed to version published events")
+	})
+}
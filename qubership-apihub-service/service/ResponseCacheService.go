@@ -0,0 +1,40 @@
+package service
+
+import (
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/cache"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	log "github.com/sirupsen/logrus"
+)
+
+type ResponseCacheService interface {
+	// ListenVersionEvents invalidates cached data of the package when its version is published or deleted
+	ListenVersionEvents(publishNotificationService PublishNotificationService)
+}
+
+func NewResponseCacheService(responseCache cache.ResponseCache) ResponseCacheService {
+	return &responseCacheServiceImpl{responseCache: responseCache}
+}
+
+type responseCacheServiceImpl struct {
+	responseCache cache.ResponseCache
+}
+
+func (r *responseCacheServiceImpl) ListenVersionEvents(publishNotificationService PublishNotificationService) {
+	utils.SafeAsync(func() {
+		if err := publishNotificationService.Subscribe(r.invalidate); err != nil {
+			log.Errorf("response-cache: failed to subscribe to version published events: %v", err)
+			return
+		}
+		if err := publishNotificationService.SubscribeDeletion(r.invalidate); err != nil {
+			log.Errorf("response-cache: failed to subscribe to version deleted events: %v", err)
+			return
+		}
+		log.Info("response-cache: subscribed to version published and deleted events")
+	})
+}
+
+func (r *responseCacheServiceImpl) invalidate(notification view.PublishNotification) {
+	// every instance receives the event, event id is used as the new generation to keep invalidation idempotent
+	r.responseCache.InvalidatePackage(notification.PackageId, notification.EventId)
+}
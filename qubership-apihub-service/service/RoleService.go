@@ -45,15 +45,16 @@ type RoleService interface {
 	DeleteSystemAdministrator(userId string) error
 }
 
-func NewRoleService(roleRepository repository.RoleRepository, userService UserService, atService ActivityTrackingService, publishedRepo repository.PublishedRepository) RoleService {
-	return roleServiceImpl{roleRepository: roleRepository, userService: userService, atService: atService, publishedRepo: publishedRepo}
+func NewRoleService(roleRepository repository.RoleRepository, tenantRepository repository.TenantRepository, userService UserService, atService ActivityTrackingService, publishedRepo repository.PublishedRepository) RoleService {
+	return roleServiceImpl{roleRepository: roleRepository, tenantRepository: tenantRepository, userService: userService, atService: atService, publishedRepo: publishedRepo}
 }
 
 type roleServiceImpl struct {
-	roleRepository repository.RoleRepository
-	userService    UserService
-	atService      ActivityTrackingService
-	publishedRepo  repository.PublishedRepository
+	roleRepository   repository.RoleRepository
+	tenantRepository repository.TenantRepository
+	userService      UserService
+	atService        ActivityTrackingService
+	publishedRepo    repository.PublishedRepository
 }
 
 func (r roleServiceImpl) AddPackageMembers(ctx context.SecurityContext, packageId string, emails []string, roleIds []string) (*view.PackageMembers, error) {
@@ -70,7 +71,11 @@ func (r roleServiceImpl) AddPackageMembers(ctx context.SecurityContext, packageI
 		}
 	}
 	if packageEnt.DefaultRole == view.NoneRoleId && packageEnt.ParentId == "" {
-		if !r.IsSysadm(ctx) {
+		tenantAdmin, err := r.isTenantAdmin(ctx, packageId)
+		if err != nil {
+			return nil, err
+		}
+		if !r.IsSysadm(ctx) && !tenantAdmin {
 			return nil, &exception.CustomError{
 				Status:  http.StatusForbidden,
 				Code:    exception.InsufficientPrivileges,
@@ -188,7 +193,11 @@ func (r roleServiceImpl) UpdatePackageMember(ctx context.SecurityContext, packag
 		}
 	}
 	if packageEnt.DefaultRole == view.NoneRoleId && packageEnt.ParentId == "" {
-		if !r.IsSysadm(ctx) {
+		tenantAdmin, err := r.isTenantAdmin(ctx, packageId)
+		if err != nil {
+			return err
+		}
+		if !r.IsSysadm(ctx) && !tenantAdmin {
 			return &exception.CustomError{
 				Status:  http.StatusForbidden,
 				Code:    exception.InsufficientPrivileges,
@@ -252,7 +261,11 @@ func (r roleServiceImpl) DeletePackageMember(ctx context.SecurityContext, packag
 		}
 	}
 	if packageEnt.DefaultRole == view.NoneRoleId && packageEnt.ParentId == "" {
-		if !r.IsSysadm(ctx) {
+		tenantAdmin, err := r.isTenantAdmin(ctx, packageId)
+		if err != nil {
+			return nil, err
+		}
+		if !r.IsSysadm(ctx) && !tenantAdmin {
 			return nil, &exception.CustomError{
 				Status:  http.StatusForbidden,
 				Code:    exception.InsufficientPrivileges,
@@ -565,6 +578,18 @@ func (r roleServiceImpl) GetPermissionsForPackage(ctx context.SecurityContext, p
 }
 
 func (r roleServiceImpl) getUserPermissionsForPackage(packageId string, userId string) ([]string, error) {
+	// workspace administrators have all permissions for the workspace and its child packages
+	tenantAdmin, err := r.tenantRepository.IsAdmin(utils.GetPackageWorkspaceId(packageId), userId)
+	if err != nil {
+		return nil, err
+	}
+	if tenantAdmin {
+		allPermissions := make([]string, 0)
+		for _, permission := range view.GetAllRolePermissions() {
+			allPermissions = append(allPermissions, permission.Id())
+		}
+		return allPermissions, nil
+	}
 	userPermissions, err := r.roleRepository.GetUserPermissions(packageId, userId)
 	if err != nil {
 		return nil, err
@@ -639,6 +664,15 @@ func (r roleServiceImpl) HasRequiredPermissionsAcrossAllPackages(ctx context.Sec
 		return true, nil
 	}
 
+	// workspace administrators have all permissions in the packages of their workspaces
+	tenantAdmin, err := r.tenantRepository.IsAdminOfAnyWorkspace(ctx.GetUserId())
+	if err != nil {
+		return false, err
+	}
+	if tenantAdmin {
+		return true, nil
+	}
+
 	userPermissions, err := r.roleRepository.GetAllUserPermissions(ctx.GetUserId())
 	if err != nil {
 		return false, err
@@ -693,6 +727,14 @@ func (r roleServiceImpl) IsSysadm(ctx context.SecurityContext) bool {
 	return ctx.GetUserSystemRole() == view.SysadmRole
 }
 
+// isTenantAdmin checks if the user is an administrator of the workspace of the package, api keys are not workspace administrators
+func (r roleServiceImpl) isTenantAdmin(ctx context.SecurityContext, packageId string) (bool, error) {
+	if ctx.GetApikeyPackageId() != "" || ctx.GetUserId() == "" {
+		return false, nil
+	}
+	return r.tenantRepository.IsAdmin(utils.GetPackageWorkspaceId(packageId), ctx.GetUserId())
+}
+
 func (r roleServiceImpl) ValidateDefaultRole(ctx context.SecurityContext, packageId string, roleId string) error {
 	return r.validatePackageMemberRoles(ctx, packageId, []string{roleId})
 }
@@ -851,10 +893,19 @@ func (r roleServiceImpl) GetAvailablePackageRoles(ctx context.SecurityContext, p
 			}
 		}
 	} else {
-		availableRoles, err = r.roleRepository.GetAvailablePackageRoles(packageId, userId)
+		tenantAdmin, err := r.isTenantAdmin(ctx, packageId)
 		if err != nil {
 			return nil, err
 		}
+		if tenantAdmin {
+			// workspace administrators have all permissions, so they may assign any role as system administrators
+			availableRoles = allRoles
+		} else {
+			availableRoles, err = r.roleRepository.GetAvailablePackageRoles(packageId, userId)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 	result := make([]view.PackageRole, 0)
 	for _, roleEnt := range availableRoles {
@@ -0,0 +1,573 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/cache"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/config"
+	secctx "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/buraksezer/olric"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	RuntimeSettingsTopicName = "runtime-settings-changed"
+
+	runtimeSettingsReloadJobName     = "runtimeSettingsReload"
+	runtimeSettingsReloadJobSchedule = "@every 5m"
+)
+
+type runtimeSettingDefinition struct {
+	// key is the path of the setting in the configuration file
+	key         string
+	description string
+	// validate checks the value in addition to the validate tags of the config field
+	validate func(value interface{}) error
+}
+
+var runtimeSettingDefinitions = []runtimeSettingDefinition{
+	{
+		key:         "businessParameters.externalLinks",
+		description: "Links shown in the portal header",
+	},
+	{
+		key:         "businessParameters.releaseVersionPattern",
+		description: "Default release version pattern of new packages",
+		validate: func(value interface{}) error {
+			_, err := regexp.Compile(value.(string))
+			return err
+		},
+	},
+	{
+		key:         "businessParameters.failBuildOnBrokenRefs",
+		description: "Fail builds of the specifications with broken references instead of reporting a warning",
+	},
+	{
+		key:         "businessParameters.publishArchiveSizeLimitMb",
+		description: "Maximum size of the published archive in megabytes",
+	},
+	{
+		key:         "security.allowedHostsForProxy",
+		description: "Hosts available via the playground proxy",
+	},
+}
+
+func findRuntimeSettingDefinition(key string) *runtimeSettingDefinition {
+	for i := range runtimeSettingDefinitions {
+		if runtimeSettingDefinitions[i].key == key {
+			return &runtimeSettingDefinitions[i]
+		}
+	}
+	return nil
+}
+
+// RuntimeSettingsService manages the settings which override the configuration file without restart and the scheduled system notifications.
+// Both are stored in the database and cached by every instance, the changes are propagated to other instances via Olric DTopic.
+type RuntimeSettingsService interface {
+	GetSettings() *view.RuntimeSettings
+	UpdateSetting(ctx context.Context, secCtx secctx.SecurityContext, key string, value json.RawMessage) (*view.RuntimeSetting, error)
+	// ResetSetting removes the override, so the value from the configuration file is used
+	ResetSetting(ctx context.Context, secCtx secctx.SecurityContext, key string) (*view.RuntimeSetting, error)
+	GetHistory(ctx context.Context, key string, limit int, page int) (*view.RuntimeSettingsHistory, error)
+
+	GetNotifications(ctx context.Context) (*view.SystemNotifications, error)
+	CreateNotification(ctx context.Context, secCtx secctx.SecurityContext, req view.SystemNotificationReq) (*view.SystemNotification, error)
+	UpdateNotification(ctx context.Context, secCtx secctx.SecurityContext, id string, req view.SystemNotificationReq) (*view.SystemNotification, error)
+	DeleteNotification(ctx context.Context, id string) error
+	// GetActiveNotifications returns the notifications to be shown at the moment, the most severe first
+	GetActiveNotifications() []view.ActiveSystemNotification
+
+	// StartReloadJob registers the job which reloads the cache in case a change event was missed
+	StartReloadJob(backgroundJobService BackgroundJobService) error
+}
+
+func NewRuntimeSettingsService(repo repository.RuntimeSettingsRepository, systemInfoService SystemInfoService, op cache.OlricProvider) RuntimeSettingsService {
+	s := &runtimeSettingsServiceImpl{
+		repo:              repo,
+		systemInfoService: systemInfoService,
+		op:                op,
+		isReadyWg:         sync.WaitGroup{},
+		config:            systemInfoService.GetFileConfig(),
+		settings:          map[string]entity.RuntimeSettingEntity{},
+	}
+	if err := s.reload(context.Background()); err != nil {
+		log.Errorf("Failed to load runtime settings, values from the configuration file are used: %v", err)
+	}
+	s.isReadyWg.Add(1)
+	utils.SafeAsync(func() {
+		s.initRuntimeSettingsDTopic()
+	})
+	return s
+}
+
+type runtimeSettingsServiceImpl struct {
+	repo              repository.RuntimeSettingsRepository
+	systemInfoService SystemInfoService
+	op                cache.OlricProvider
+	topic             *olric.DTopic
+	isReadyWg         sync.WaitGroup
+
+	mutex         sync.RWMutex
+	config        config.Config
+	settings      map[string]entity.RuntimeSettingEntity
+	notifications []entity.SystemNotificationEntity
+}
+
+type runtimeSettingsEvent struct {
+	InstanceId string `json:"instanceId"`
+}
+
+func (s *runtimeSettingsServiceImpl) GetSettings() *view.RuntimeSettings {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	result := &view.RuntimeSettings{Settings: make([]view.RuntimeSetting, 0, len(runtimeSettingDefinitions))}
+	for _, def := range runtimeSettingDefinitions {
+		result.Settings = append(result.Settings, s.makeSettingView(def))
+	}
+	return result
+}
+
+func (s *runtimeSettingsServiceImpl) UpdateSetting(ctx context.Context, secCtx secctx.SecurityContext, key string, value json.RawMessage) (*view.RuntimeSetting, error) {
+	def, err := getRuntimeSettingDefinition(key)
+	if err != nil {
+		return nil, err
+	}
+	s.mutex.RLock()
+	candidate := s.config
+	s.mutex.RUnlock()
+	normalizedValue, err := applyRuntimeSetting(&candidate, def, value)
+	if err == nil {
+		err = utils.ValidateConfig(candidate)
+	}
+	if err != nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidRuntimeSettingValue,
+			Message: exception.InvalidRuntimeSettingValueMsg,
+			Params:  map[string]interface{}{"key": key, "error": err.Error()},
+		}
+	}
+
+	now := time.Now()
+	err = s.repo.SaveSetting(ctx,
+		&entity.RuntimeSettingEntity{
+			Key:       key,
+			Value:     normalizedValue,
+			UpdatedBy: secCtx.GetUserId(),
+			UpdatedAt: now,
+		},
+		&entity.RuntimeSettingHistoryEntity{
+			Id:        uuid.NewString(),
+			Key:       key,
+			OldValue:  s.getStoredValue(key),
+			NewValue:  normalizedValue,
+			ChangedBy: secCtx.GetUserId(),
+			ChangedAt: now,
+		})
+	if err != nil {
+		return nil, err
+	}
+	log.WithContext(ctx).Infof("Runtime setting %s is set to %s by %s", key, normalizedValue, secCtx.GetUserId())
+	if err = s.applyChange(ctx); err != nil {
+		return nil, err
+	}
+	return s.getSettingView(*def), nil
+}
+
+func (s *runtimeSettingsServiceImpl) ResetSetting(ctx context.Context, secCtx secctx.SecurityContext, key string) (*view.RuntimeSetting, error) {
+	def, err := getRuntimeSettingDefinition(key)
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := s.repo.DeleteSetting(ctx, key, &entity.RuntimeSettingHistoryEntity{
+		Id:        uuid.NewString(),
+		Key:       key,
+		OldValue:  s.getStoredValue(key),
+		ChangedBy: secCtx.GetUserId(),
+		ChangedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if deleted {
+		log.WithContext(ctx).Infof("Runtime setting %s is reset by %s", key, secCtx.GetUserId())
+		if err = s.applyChange(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return s.getSettingView(*def), nil
+}
+
+func (s *runtimeSettingsServiceImpl) GetHistory(ctx context.Context, key string, limit int, page int) (*view.RuntimeSettingsHistory, error) {
+	if key != "" {
+		if _, err := getRuntimeSettingDefinition(key); err != nil {
+			return nil, err
+		}
+	}
+	ents, err := s.repo.GetHistory(ctx, key, limit, page)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.RuntimeSettingsHistory{Changes: make([]view.RuntimeSettingChange, 0, len(ents))}
+	for _, ent := range ents {
+		result.Changes = append(result.Changes, entity.MakeRuntimeSettingChangeView(ent))
+	}
+	return result, nil
+}
+
+func (s *runtimeSettingsServiceImpl) GetNotifications(ctx context.Context) (*view.SystemNotifications, error) {
+	ents, err := s.repo.GetNotifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.SystemNotifications{Notifications: make([]view.SystemNotification, 0, len(ents))}
+	for _, ent := range ents {
+		result.Notifications = append(result.Notifications, entity.MakeSystemNotificationView(ent))
+	}
+	return result, nil
+}
+
+func (s *runtimeSettingsServiceImpl) CreateNotification(ctx context.Context, secCtx secctx.SecurityContext, req view.SystemNotificationReq) (*view.SystemNotification, error) {
+	now := time.Now()
+	startAt, err := getSystemNotificationPeriod(req, now)
+	if err != nil {
+		return nil, err
+	}
+	ent := &entity.SystemNotificationEntity{
+		Id:        uuid.NewString(),
+		Message:   req.Message,
+		Severity:  req.Severity,
+		StartAt:   startAt,
+		EndAt:     req.EndAt,
+		CreatedBy: secCtx.GetUserId(),
+		CreatedAt: now,
+	}
+	if err = s.repo.CreateNotification(ctx, ent); err != nil {
+		return nil, err
+	}
+	log.WithContext(ctx).Infof("System notification %s is created by %s", ent.Id, secCtx.GetUserId())
+	if err = s.applyChange(ctx); err != nil {
+		return nil, err
+	}
+	result := entity.MakeSystemNotificationView(*ent)
+	return &result, nil
+}
+
+func (s *runtimeSettingsServiceImpl) UpdateNotification(ctx context.Context, secCtx secctx.SecurityContext, id string, req view.SystemNotificationReq) (*view.SystemNotification, error) {
+	ent, err := s.repo.GetNotification(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return nil, systemNotificationNotFound(id)
+	}
+	now := time.Now()
+	if req.StartAt == nil {
+		req.StartAt = &ent.StartAt
+	}
+	startAt, err := getSystemNotificationPeriod(req, now)
+	if err != nil {
+		return nil, err
+	}
+	ent.Message = req.Message
+	ent.Severity = req.Severity
+	ent.StartAt = startAt
+	ent.EndAt = req.EndAt
+	ent.UpdatedBy = secCtx.GetUserId()
+	ent.UpdatedAt = &now
+	updated, err := s.repo.UpdateNotification(ctx, ent)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, systemNotificationNotFound(id)
+	}
+	log.WithContext(ctx).Infof("System notification %s is updated by %s", id, secCtx.GetUserId())
+	if err = s.applyChange(ctx); err != nil {
+		return nil, err
+	}
+	result := entity.MakeSystemNotificationView(*ent)
+	return &result, nil
+}
+
+func (s *runtimeSettingsServiceImpl) DeleteNotification(ctx context.Context, id string) error {
+	deleted, err := s.repo.DeleteNotification(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return systemNotificationNotFound(id)
+	}
+	log.WithContext(ctx).Infof("System notification %s is deleted", id)
+	return s.applyChange(ctx)
+}
+
+func (s *runtimeSettingsServiceImpl) GetActiveNotifications() []view.ActiveSystemNotification {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return getActiveSystemNotifications(s.notifications, time.Now())
+}
+
+func (s *runtimeSettingsServiceImpl) StartReloadJob(backgroundJobService BackgroundJobService) error {
+	return backgroundJobService.RegisterJob(BackgroundJobDefinition{
+		Name:        runtimeSettingsReloadJobName,
+		Description: "Reloads runtime settings and system notifications of the instance in case a change event was missed",
+		Schedule:    runtimeSettingsReloadJobSchedule,
+		Distributed: false,
+		Timeout:     time.Minute,
+		Job:         BackgroundJobFunc(s.reload),
+	})
+}
+
+// applyChange reloads the local cache and notifies other instances
+func (s *runtimeSettingsServiceImpl) applyChange(ctx context.Context) error {
+	if err := s.reload(ctx); err != nil {
+		return err
+	}
+	utils.SafeAsync(func() {
+		s.sendChangeEvent()
+	})
+	return nil
+}
+
+func (s *runtimeSettingsServiceImpl) reload(ctx context.Context) error {
+	settingEnts, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get runtime settings: %w", err)
+	}
+	notificationEnts, err := s.repo.GetNotificationsEndingAfter(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to get system notifications: %w", err)
+	}
+
+	cfg := s.systemInfoService.GetFileConfig()
+	settings := make(map[string]entity.RuntimeSettingEntity, len(settingEnts))
+	for _, ent := range settingEnts {
+		def := findRuntimeSettingDefinition(ent.Key)
+		if def == nil {
+			log.WithContext(ctx).Warnf("Unknown runtime setting %s is ignored", ent.Key)
+			continue
+		}
+		// the setting is applied to a copy to keep the other settings if it is not valid for the current configuration file
+		candidate := cfg
+		if _, err = applyRuntimeSetting(&candidate, def, ent.Value); err == nil {
+			err = utils.ValidateConfig(candidate)
+		}
+		if err != nil {
+			log.WithContext(ctx).Errorf("Invalid runtime setting %s is ignored, the value from the configuration file is used: %v", ent.Key, err)
+			continue
+		}
+		cfg = candidate
+		settings[ent.Key] = ent
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.config = cfg
+	s.settings = settings
+	s.notifications = notificationEnts
+	s.systemInfoService.SetRuntimeConfig(cfg)
+	return nil
+}
+
+func (s *runtimeSettingsServiceImpl) getStoredValue(key string) json.RawMessage {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if ent, exists := s.settings[key]; exists {
+		return ent.Value
+	}
+	return nil
+}
+
+func (s *runtimeSettingsServiceImpl) getSettingView(def runtimeSettingDefinition) *view.RuntimeSetting {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	result := s.makeSettingView(def)
+	return &result
+}
+
+func (s *runtimeSettingsServiceImpl) makeSettingView(def runtimeSettingDefinition) view.RuntimeSetting {
+	fileConfig := s.systemInfoService.GetFileConfig()
+	result := view.RuntimeSetting{
+		Key:          def.key,
+		Description:  def.description,
+		Value:        getConfigValue(&s.config, def.key),
+		DefaultValue: getConfigValue(&fileConfig, def.key),
+	}
+	if ent, exists := s.settings[def.key]; exists {
+		result.Overridden = true
+		result.UpdatedBy = ent.UpdatedBy
+		updatedAt := ent.UpdatedAt
+		result.UpdatedAt = &updatedAt
+	}
+	return result
+}
+
+func (s *runtimeSettingsServiceImpl) sendChangeEvent() {
+	s.isReadyWg.Wait()
+	if s.topic == nil {
+		log.Errorf("Failed to publish message to %s DTopic since it's not initialized", RuntimeSettingsTopicName)
+		return
+	}
+	msg, err := json.Marshal(runtimeSettingsEvent{InstanceId: s.systemInfoService.GetInstanceId()})
+	if err != nil {
+		log.Errorf("Failed to marshal runtime settings change event: %v", err)
+		return
+	}
+	if err = s.topic.Publish(string(msg)); err != nil {
+		log.Errorf("Failed to send runtime settings change event: %v", err)
+	}
+}
+
+func (s *runtimeSettingsServiceImpl) onChangeEvent(topicMsg olric.DTopicMessage) {
+	msgStr, ok := topicMsg.Message.(string)
+	if !ok {
+		log.Errorf("Unexpected message type in %s DTopic: %T", RuntimeSettingsTopicName, topicMsg.Message)
+		return
+	}
+	var event runtimeSettingsEvent
+	if err := json.Unmarshal([]byte(msgStr), &event); err != nil {
+		log.Errorf("Failed to unmarshal runtime settings change event: %v", err)
+		return
+	}
+	if event.InstanceId == s.systemInfoService.GetInstanceId() {
+		return
+	}
+	if err := s.reload(context.Background()); err != nil {
+		log.Errorf("Failed to reload runtime settings changed by instance %s: %v", event.InstanceId, err)
+	}
+}
+
+func (s *runtimeSettingsServiceImpl) initRuntimeSettingsDTopic() {
+	defer s.isReadyWg.Done()
+	var err error
+	for attempt := 1; attempt < 4; attempt++ {
+		s.topic, err = s.op.Get().NewDTopic(RuntimeSettingsTopicName, 10000, olric.UnorderedDelivery)
+		if err != nil {
+			log.Errorf("Failed to create DTopic %s (attempt %d): %s", RuntimeSettingsTopicName, attempt, err.Error())
+			time.Sleep(10 * time.Second)
+			continue
+		}
+		break
+	}
+	if s.topic == nil {
+		return
+	}
+	if _, err = s.topic.AddListener(s.onChangeEvent); err != nil {
+		log.Errorf("Failed to subscribe to %s DTopic, runtime settings changed by other instances are applied by %s job: %v",
+			RuntimeSettingsTopicName, runtimeSettingsReloadJobName, err)
+	}
+}
+
+func getRuntimeSettingDefinition(key string) (*runtimeSettingDefinition, error) {
+	def := findRuntimeSettingDefinition(key)
+	if def == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.RuntimeSettingNotFound,
+			Message: exception.RuntimeSettingNotFoundMsg,
+			Params:  map[string]interface{}{"key": key},
+		}
+	}
+	return def, nil
+}
+
+// applyRuntimeSetting sets the value to the config field of the setting and returns the normalized value.
+// The config is not validated, validate tags are checked by utils.ValidateConfig for the whole config.
+func applyRuntimeSetting(cfg *config.Config, def *runtimeSettingDefinition, value json.RawMessage) (json.RawMessage, error) {
+	field, ok := utils.FindConfigField(cfg, def.key)
+	if !ok {
+		return nil, fmt.Errorf("config field %s not found", def.key)
+	}
+	if len(value) == 0 || string(value) == "null" {
+		return nil, fmt.Errorf("value is required")
+	}
+	newValue := reflect.New(field.Type())
+	if err := json.Unmarshal(value, newValue.Interface()); err != nil {
+		return nil, fmt.Errorf("value is not %s: %w", field.Type(), err)
+	}
+	if def.validate != nil {
+		if err := def.validate(newValue.Elem().Interface()); err != nil {
+			return nil, err
+		}
+	}
+	field.Set(newValue.Elem())
+	return json.Marshal(newValue.Elem().Interface())
+}
+
+func getConfigValue(cfg *config.Config, key string) interface{} {
+	field, ok := utils.FindConfigField(cfg, key)
+	if !ok {
+		return nil
+	}
+	return field.Interface()
+}
+
+func getSystemNotificationPeriod(req view.SystemNotificationReq, now time.Time) (time.Time, error) {
+	startAt := now
+	if req.StartAt != nil {
+		startAt = *req.StartAt
+	}
+	if req.EndAt != nil && !req.EndAt.After(startAt) {
+		return startAt, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidSystemNotificationPeriod,
+			Message: exception.InvalidSystemNotificationPeriodMsg,
+		}
+	}
+	return startAt, nil
+}
+
+var systemNotificationSeverityRank = map[string]int{
+	view.SystemNotificationSeverityError:   2,
+	view.SystemNotificationSeverityWarning: 1,
+	view.SystemNotificationSeverityInfo:    0,
+}
+
+func getActiveSystemNotifications(ents []entity.SystemNotificationEntity, now time.Time) []view.ActiveSystemNotification {
+	active := make([]entity.SystemNotificationEntity, 0)
+	for _, ent := range ents {
+		if !ent.StartAt.After(now) && (ent.EndAt == nil || ent.EndAt.After(now)) {
+			active = append(active, ent)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool {
+		if active[i].Severity != active[j].Severity {
+			return systemNotificationSeverityRank[active[i].Severity] > systemNotificationSeverityRank[active[j].Severity]
+		}
+		return active[i].StartAt.After(active[j].StartAt)
+	})
+	result := make([]view.ActiveSystemNotification, 0, len(active))
+	for _, ent := range active {
+		result = append(result, view.ActiveSystemNotification{
+			Id:       ent.Id,
+			Message:  ent.Message,
+			Severity: ent.Severity,
+			EndAt:    ent.EndAt,
+		})
+	}
+	return result
+}
+
+func systemNotificationNotFound(id string) error {
+	return &exception.CustomError{
+		Status:  http.StatusNotFound,
+		Code:    exception.SystemNotificationNotFound,
+		Message: exception.SystemNotificationNotFoundMsg,
+		Params:  map[string]interface{}{"id": id},
+	}
+}
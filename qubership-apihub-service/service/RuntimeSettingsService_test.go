@@ -0,0 +1,69 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/config"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyRuntimeSetting(t *testing.T) {
+	for _, def := range runtimeSettingDefinitions {
+		_, ok := utils.FindConfigField(&config.Config{}, def.key)
+		require.True(t, ok, "config field of runtime setting %s not found", def.key)
+	}
+
+	cfg := config.Config{}
+	cfg.BusinessParameters.ExternalLinks = []string{"https://example.com"}
+	fileLinks := cfg.BusinessParameters.ExternalLinks
+
+	value, err := applyRuntimeSetting(&cfg, findRuntimeSettingDefinition("businessParameters.externalLinks"), json.RawMessage(`[ "https://a.com", "https://b.com" ]`))
+	require.NoError(t, err)
+	require.JSONEq(t, `["https://a.com","https://b.com"]`, string(value))
+	require.Equal(t, []string{"https://a.com", "https://b.com"}, cfg.BusinessParameters.ExternalLinks)
+	require.Equal(t, []string{"https://example.com"}, fileLinks)
+
+	_, err = applyRuntimeSetting(&cfg, findRuntimeSettingDefinition("businessParameters.failBuildOnBrokenRefs"), json.RawMessage(`"yes"`))
+	require.ErrorContains(t, err, "bool")
+
+	_, err = applyRuntimeSetting(&cfg, findRuntimeSettingDefinition("businessParameters.releaseVersionPattern"), json.RawMessage(`"[0-9"`))
+	require.Error(t, err)
+
+	_, err = applyRuntimeSetting(&cfg, findRuntimeSettingDefinition("businessParameters.releaseVersionPattern"), json.RawMessage(`null`))
+	require.ErrorContains(t, err, "required")
+
+	// validate tags of the config field are checked for the config
+	_, err = applyRuntimeSetting(&cfg, findRuntimeSettingDefinition("businessParameters.publishArchiveSizeLimitMb"), json.RawMessage(`0`))
+	require.NoError(t, err)
+	require.Error(t, utils.ValidateConfig(cfg.BusinessParameters))
+}
+
+func TestGetActiveSystemNotifications(t *testing.T) {
+	now := time.Date(2026, time.March, 17, 12, 0, 0, 0, time.UTC)
+	hourAgo := now.Add(-time.Hour)
+	inHour := now.Add(time.Hour)
+	ents := []entity.SystemNotificationEntity{
+		{Id: "finished", Severity: view.SystemNotificationSeverityError, StartAt: hourAgo.Add(-time.Hour), EndAt: &hourAgo},
+		{Id: "scheduled", Severity: view.SystemNotificationSeverityError, StartAt: inHour},
+		{Id: "info", Severity: view.SystemNotificationSeverityInfo, StartAt: now},
+		{Id: "old warning", Severity: view.SystemNotificationSeverityWarning, StartAt: hourAgo, EndAt: &inHour},
+		{Id: "new warning", Severity: view.SystemNotificationSeverityWarning, StartAt: now.Add(-time.Minute)},
+	}
+	active := getActiveSystemNotifications(ents, now)
+	ids := make([]string, 0, len(active))
+	for _, n := range active {
+		ids = append(ids, n.Id)
+	}
+	require.Equal(t, []string{"new warning", "old warning", "info"}, ids)
+
+	_, err := getSystemNotificationPeriod(view.SystemNotificationReq{StartAt: &inHour, EndAt: &hourAgo}, now)
+	require.Error(t, err)
+	startAt, err := getSystemNotificationPeriod(view.SystemNotificationReq{EndAt: &inHour}, now)
+	require.NoError(t, err)
+	require.Equal(t, now, startAt)
+}
@@ -0,0 +1,393 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	secctx "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type SavedComparisonService interface {
+	CreateSavedComparison(ctx context.Context, secCtx secctx.SecurityContext, req view.SavedComparisonReq) (*view.SavedComparison, error)
+	// GetSavedComparisons skips comparisons with any side not readable by the user
+	GetSavedComparisons(ctx context.Context, secCtx secctx.SecurityContext, req view.SavedComparisonsReq) (*view.SavedComparisons, error)
+	// GetSavedComparison returns the comparison with the summary if it is calculated
+	GetSavedComparison(ctx context.Context, secCtx secctx.SecurityContext, id string) (*view.SavedComparison, error)
+	UpdateSavedComparison(ctx context.Context, secCtx secctx.SecurityContext, id string, req view.SavedComparisonUpdateReq) (*view.SavedComparison, error)
+	DeleteSavedComparison(ctx context.Context, secCtx secctx.SecurityContext, id string) error
+	GetSavedComparisonChanges(ctx context.Context, secCtx secctx.SecurityContext, id string, apiType string, severities []string) (*view.VersionChangesView, error)
+	// ListenVersionPublished recalculates saved comparisons when a new revision of any side is published
+	ListenVersionPublished(publishNotificationService PublishNotificationService)
+}
+
+func NewSavedComparisonService(repo repository.SavedComparisonRepository,
+	publishedRepo repository.PublishedRepository,
+	comparisonService ComparisonService,
+	buildService BuildService,
+	versionService VersionService,
+	roleService RoleService) SavedComparisonService {
+	return &savedComparisonServiceImpl{
+		repo:              repo,
+		publishedRepo:     publishedRepo,
+		comparisonService: comparisonService,
+		buildService:      buildService,
+		versionService:    versionService,
+		roleService:       roleService,
+	}
+}
+
+type savedComparisonServiceImpl struct {
+	repo              repository.SavedComparisonRepository
+	publishedRepo     repository.PublishedRepository
+	comparisonService ComparisonService
+	buildService      BuildService
+	versionService    VersionService
+	roleService       RoleService
+}
+
+func (s *savedComparisonServiceImpl) CreateSavedComparison(ctx context.Context, secCtx secctx.SecurityContext, req view.SavedComparisonReq) (*view.SavedComparison, error) {
+	if err := s.checkReadPermission(secCtx, req.PackageId, req.PreviousVersionPackageId); err != nil {
+		return nil, err
+	}
+	versionEnt, err := s.getVersion(req.PackageId, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	previousVersionEnt, err := s.getVersion(req.PreviousVersionPackageId, req.PreviousVersion)
+	if err != nil {
+		return nil, err
+	}
+	ent := &entity.SavedComparisonEntity{
+		Id:                uuid.NewString(),
+		Name:              req.Name,
+		PackageId:         versionEnt.PackageId,
+		Version:           versionEnt.Version,
+		Revision:          versionEnt.Revision,
+		PreviousPackageId: previousVersionEnt.PackageId,
+		PreviousVersion:   previousVersionEnt.Version,
+		PreviousRevision:  previousVersionEnt.Revision,
+		CreatedBy:         secCtx.GetUserId(),
+		CreatedAt:         time.Now(),
+	}
+	ent.ComparisonId = makeSavedComparisonId(ent)
+	if err = s.startCalculation(ent); err != nil {
+		return nil, err
+	}
+	if err = s.repo.CreateSavedComparison(ctx, ent); err != nil {
+		return nil, err
+	}
+	result := entity.MakeSavedComparisonView(*ent)
+	return &result, nil
+}
+
+func (s *savedComparisonServiceImpl) GetSavedComparisons(ctx context.Context, secCtx secctx.SecurityContext, req view.SavedComparisonsReq) (*view.SavedComparisons, error) {
+	ents, err := s.repo.GetSavedComparisons(ctx, secCtx.GetUserId(), req.PackageId, req.Limit, req.Page)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.SavedComparisons{Comparisons: make([]view.SavedComparison, 0, len(ents))}
+	permissions := make(map[string]bool)
+	for _, ent := range ents {
+		readable := true
+		for _, packageId := range []string{ent.PackageId, ent.PreviousPackageId} {
+			if _, checked := permissions[packageId]; !checked {
+				permissions[packageId], err = s.roleService.HasRequiredPermissions(secCtx, packageId, view.ReadPermission)
+				if err != nil {
+					return nil, err
+				}
+			}
+			readable = readable && permissions[packageId]
+		}
+		if !readable {
+			continue
+		}
+		if err = s.refreshStatus(ctx, &ent); err != nil {
+			return nil, err
+		}
+		result.Comparisons = append(result.Comparisons, entity.MakeSavedComparisonView(ent))
+	}
+	return result, nil
+}
+
+func (s *savedComparisonServiceImpl) GetSavedComparison(ctx context.Context, secCtx secctx.SecurityContext, id string) (*view.SavedComparison, error) {
+	ent, err := s.getSavedComparison(ctx, secCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	result := entity.MakeSavedComparisonView(*ent)
+	if ent.Status == view.SavedComparisonStatusComplete {
+		result.Summary, err = s.comparisonService.GetComparisonResult(ent.PackageId, view.MakeVersionRefKey(ent.Version, ent.Revision),
+			ent.PreviousPackageId, view.MakeVersionRefKey(ent.PreviousVersion, ent.PreviousRevision))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &result, nil
+}
+
+func (s *savedComparisonServiceImpl) UpdateSavedComparison(ctx context.Context, secCtx secctx.SecurityContext, id string, req view.SavedComparisonUpdateReq) (*view.SavedComparison, error) {
+	ent, err := s.getOwnSavedComparison(ctx, secCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.repo.UpdateSavedComparisonName(ctx, id, req.Name); err != nil {
+		return nil, err
+	}
+	ent.Name = req.Name
+	result := entity.MakeSavedComparisonView(*ent)
+	return &result, nil
+}
+
+func (s *savedComparisonServiceImpl) DeleteSavedComparison(ctx context.Context, secCtx secctx.SecurityContext, id string) error {
+	if _, err := s.getOwnSavedComparison(ctx, secCtx, id); err != nil {
+		return err
+	}
+	_, err := s.repo.DeleteSavedComparison(ctx, id)
+	return err
+}
+
+func (s *savedComparisonServiceImpl) GetSavedComparisonChanges(ctx context.Context, secCtx secctx.SecurityContext, id string, apiType string, severities []string) (*view.VersionChangesView, error) {
+	ent, err := s.getSavedComparison(ctx, secCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ent.Status != view.SavedComparisonStatusComplete {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.SavedComparisonNotCalculated,
+			Message: exception.SavedComparisonNotCalculatedMsg,
+			Params:  map[string]interface{}{"id": id, "status": ent.Status},
+		}
+	}
+	return s.versionService.GetVersionChanges(ent.PackageId, view.MakeVersionRefKey(ent.Version, ent.Revision), apiType, severities, view.VersionChangesReq{
+		PreviousVersion:          view.MakeVersionRefKey(ent.PreviousVersion, ent.PreviousRevision),
+		PreviousVersionPackageId: ent.PreviousPackageId,
+	})
+}
+
+// getSavedComparison returns the comparison with the actual calculation status if both sides are readable by the user
+func (s *savedComparisonServiceImpl) getSavedComparison(ctx context.Context, secCtx secctx.SecurityContext, id string) (*entity.SavedComparisonEntity, error) {
+	ent, err := s.repo.GetSavedComparison(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.SavedComparisonNotFound,
+			Message: exception.SavedComparisonNotFoundMsg,
+			Params:  map[string]interface{}{"id": id},
+		}
+	}
+	if err = s.checkReadPermission(secCtx, ent.PackageId, ent.PreviousPackageId); err != nil {
+		return nil, err
+	}
+	if err = s.refreshStatus(ctx, ent); err != nil {
+		return nil, err
+	}
+	return ent, nil
+}
+
+// getOwnSavedComparison returns the comparison if it is created by the user or the user is a system administrator
+func (s *savedComparisonServiceImpl) getOwnSavedComparison(ctx context.Context, secCtx secctx.SecurityContext, id string) (*entity.SavedComparisonEntity, error) {
+	ent, err := s.getSavedComparison(ctx, secCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ent.CreatedBy != secCtx.GetUserId() && !s.roleService.IsSysadm(secCtx) {
+		return nil, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		}
+	}
+	return ent, nil
+}
+
+func (s *savedComparisonServiceImpl) checkReadPermission(secCtx secctx.SecurityContext, packageIds ...string) error {
+	for _, packageId := range packageIds {
+		sufficientPrivileges, err := s.roleService.HasRequiredPermissions(secCtx, packageId, view.ReadPermission)
+		if err != nil {
+			return err
+		}
+		if !sufficientPrivileges {
+			return &exception.CustomError{
+				Status:  http.StatusForbidden,
+				Code:    exception.InsufficientPrivileges,
+				Message: exception.InsufficientPrivilegesMsg,
+			}
+		}
+	}
+	return nil
+}
+
+func (s *savedComparisonServiceImpl) getVersion(packageId string, version string) (*entity.PublishedVersionEntity, error) {
+	versionEnt, err := s.publishedRepo.GetVersion(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	if versionEnt == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PublishedPackageVersionNotFound,
+			Message: exception.PublishedPackageVersionNotFoundMsg,
+			Params:  map[string]interface{}{"version": version, "packageId": packageId},
+		}
+	}
+	return versionEnt, nil
+}
+
+// startCalculation starts the changelog build unless the comparison result already exists
+func (s *savedComparisonServiceImpl) startCalculation(ent *entity.SavedComparisonEntity) error {
+	version := view.MakeVersionRefKey(ent.Version, ent.Revision)
+	previousVersion := view.MakeVersionRefKey(ent.PreviousVersion, ent.PreviousRevision)
+	ent.Details = ""
+	ent.BuildId = ""
+	ent.CalculatedAt = nil
+	exists, err := s.comparisonService.ValidComparisonResultExists(ent.PackageId, version, ent.PreviousPackageId, previousVersion)
+	if err != nil {
+		return err
+	}
+	if exists {
+		now := time.Now()
+		ent.Status = view.SavedComparisonStatusComplete
+		ent.CalculatedAt = &now
+		return nil
+	}
+	ent.Status = view.SavedComparisonStatusRunning
+	buildView, err := s.buildService.GetBuildViewByChangelogSearchQuery(view.ChangelogBuildSearchRequest{
+		PackageId:                ent.PackageId,
+		Version:                  ent.Version,
+		PreviousVersionPackageId: ent.PreviousPackageId,
+		PreviousVersion:          ent.PreviousVersion,
+		BuildType:                view.ChangelogType,
+		ComparisonRevision:       ent.Revision,
+		ComparisonPrevRevision:   ent.PreviousRevision,
+	})
+	if err == nil && buildView.Status != string(view.StatusError) && buildView.Status != string(view.StatusComplete) {
+		ent.BuildId = buildView.BuildId
+		return nil
+	}
+	if customError, ok := err.(*exception.CustomError); err != nil && (!ok || customError.Status != http.StatusNotFound) {
+		return err
+	}
+	ent.BuildId, _, err = s.buildService.CreateBuildWithoutDependencies(view.BuildConfig{
+		PackageId:                ent.PackageId,
+		Version:                  ent.Version,
+		PreviousVersionPackageId: ent.PreviousPackageId,
+		PreviousVersion:          ent.PreviousVersion,
+		BuildType:                view.ChangelogType,
+		CreatedBy:                ent.CreatedBy,
+		ComparisonRevision:       ent.Revision,
+		ComparisonPrevRevision:   ent.PreviousRevision,
+	}, false, "")
+	return err
+}
+
+// refreshStatus completes the running calculation if its build is finished
+func (s *savedComparisonServiceImpl) refreshStatus(ctx context.Context, ent *entity.SavedComparisonEntity) error {
+	if ent.Status != view.SavedComparisonStatusRunning || ent.BuildId == "" {
+		return nil
+	}
+	buildView, err := s.buildService.GetBuild(ent.BuildId)
+	if err != nil {
+		return err
+	}
+	switch {
+	case buildView == nil:
+		ent.Status = view.SavedComparisonStatusError
+		ent.Details = fmt.Sprintf("build %s not found", ent.BuildId)
+	case buildView.Status == string(view.StatusError):
+		ent.Status = view.SavedComparisonStatusError
+		ent.Details = buildView.Details
+	case buildView.Status == string(view.StatusComplete):
+		exists, err := s.comparisonService.ValidComparisonResultExists(ent.PackageId, view.MakeVersionRefKey(ent.Version, ent.Revision),
+			ent.PreviousPackageId, view.MakeVersionRefKey(ent.PreviousVersion, ent.PreviousRevision))
+		if err != nil {
+			return err
+		}
+		ent.Status = view.SavedComparisonStatusComplete
+		if !exists {
+			ent.Status = view.SavedComparisonStatusError
+			ent.Details = "comparison result not found"
+		}
+	default:
+		return nil
+	}
+	now := time.Now()
+	ent.CalculatedAt = &now
+	return s.repo.UpdateSavedComparisonStatus(ctx, ent)
+}
+
+func (s *savedComparisonServiceImpl) ListenVersionPublished(publishNotificationService PublishNotificationService) {
+	utils.SafeAsync(func() {
+		err := publishNotificationService.Subscribe(func(notification view.PublishNotification) {
+			utils.SafeAsync(func() {
+				s.onVersionPublished(notification)
+			})
+		})
+		if err != nil {
+			log.Errorf("saved-comparison: failed to subscribe to version published events: %v", err)
+			return
+		}
+		log.Info("saved-comparison: subscribed to version published events")
+	})
+}
+
+func (s *savedComparisonServiceImpl) onVersionPublished(notification view.PublishNotification) {
+	ctx := context.Background()
+	ents, err := s.repo.GetSavedComparisonsByVersion(ctx, notification.PackageId, notification.Version)
+	if err != nil {
+		log.Errorf("saved-comparison: failed to get saved comparisons of version %s of package %s: %v", notification.Version, notification.PackageId, err)
+		return
+	}
+	for _, ent := range ents {
+		if err = s.recalculate(ctx, ent); err != nil {
+			log.Errorf("saved-comparison: failed to recalculate saved comparison %s: %v", ent.Id, err)
+		}
+	}
+}
+
+// recalculate moves the comparison to the latest revisions of both sides
+func (s *savedComparisonServiceImpl) recalculate(ctx context.Context, ent entity.SavedComparisonEntity) error {
+	versionEnt, err := s.getVersion(ent.PackageId, ent.Version)
+	if err != nil {
+		return err
+	}
+	previousVersionEnt, err := s.getVersion(ent.PreviousPackageId, ent.PreviousVersion)
+	if err != nil {
+		return err
+	}
+	ent.Revision = versionEnt.Revision
+	ent.PreviousRevision = previousVersionEnt.Revision
+	comparisonId := makeSavedComparisonId(&ent)
+	if comparisonId == ent.ComparisonId {
+		return nil
+	}
+	ent.ComparisonId = comparisonId
+	ent.Status = view.SavedComparisonStatusRunning
+	// Every instance receives the event, only the one which managed to move the comparison to the new revisions calculates it.
+	started, err := s.repo.StartSavedComparisonCalculation(ctx, &ent)
+	if err != nil || !started {
+		return err
+	}
+	log.WithContext(ctx).Infof("saved-comparison: recalculating saved comparison %s for revisions %d and %d", ent.Id, ent.Revision, ent.PreviousRevision)
+	if err = s.startCalculation(&ent); err != nil {
+		ent.Status = view.SavedComparisonStatusError
+		ent.Details = err.Error()
+	}
+	return s.repo.UpdateSavedComparisonStatus(ctx, &ent)
+}
+
+func makeSavedComparisonId(ent *entity.SavedComparisonEntity) string {
+	return view.MakeVersionComparisonId(ent.PackageId, ent.Version, ent.Revision, ent.PreviousPackageId, ent.PreviousVersion, ent.PreviousRevision)
+}
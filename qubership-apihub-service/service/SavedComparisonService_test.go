@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	secctx "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedComparisonStartCalculationResultExists(t *testing.T) {
+	comparisonService := &testComparisonService{exists: true}
+	buildService := &testBuildService{}
+	s := &savedComparisonServiceImpl{comparisonService: comparisonService, buildService: buildService}
+	ent := newTestSavedComparison()
+	ent.BuildId = "old-build"
+	ent.Details = "old error"
+
+	require.NoError(t, s.startCalculation(ent))
+	require.Equal(t, view.SavedComparisonStatusComplete, ent.Status)
+	require.NotNil(t, ent.CalculatedAt)
+	require.Empty(t, ent.BuildId)
+	require.Empty(t, ent.Details)
+	require.Equal(t, []string{"QS.QUOTE@2024.2@3 QS.QUOTE@2024.1@1"}, comparisonService.checked)
+	require.Empty(t, buildService.searches, "no build is needed when the result exists")
+	require.Empty(t, buildService.created)
+}
+
+func TestSavedComparisonStartCalculationBuildRunning(t *testing.T) {
+	buildService := &testBuildService{found: &view.BuildView{BuildId: "running-build", Status: string(view.StatusRunning)}}
+	s := &savedComparisonServiceImpl{comparisonService: &testComparisonService{}, buildService: buildService}
+	ent := newTestSavedComparison()
+
+	require.NoError(t, s.startCalculation(ent))
+	require.Equal(t, view.SavedComparisonStatusRunning, ent.Status)
+	require.Equal(t, "running-build", ent.BuildId)
+	require.Nil(t, ent.CalculatedAt)
+	require.Equal(t, []view.ChangelogBuildSearchRequest{{
+		PackageId:                "QS.QUOTE",
+		Version:                  "2024.2",
+		PreviousVersionPackageId: "QS.QUOTE",
+		PreviousVersion:          "2024.1",
+		BuildType:                view.ChangelogType,
+		ComparisonRevision:       3,
+		ComparisonPrevRevision:   1,
+	}}, buildService.searches)
+	require.Empty(t, buildService.created, "the running build is reused")
+}
+
+func TestSavedComparisonStartCalculationNewBuild(t *testing.T) {
+	for name, buildService := range map[string]*testBuildService{
+		"build not found":       {},
+		"previous build failed": {found: &view.BuildView{BuildId: "failed-build", Status: string(view.StatusError)}},
+		"result is not valid":   {found: &view.BuildView{BuildId: "complete-build", Status: string(view.StatusComplete)}},
+	} {
+		s := &savedComparisonServiceImpl{comparisonService: &testComparisonService{}, buildService: buildService}
+		ent := newTestSavedComparison()
+
+		require.NoError(t, s.startCalculation(ent), name)
+		require.Equal(t, view.SavedComparisonStatusRunning, ent.Status, name)
+		require.Equal(t, "new-build", ent.BuildId, name)
+		require.Equal(t, []view.BuildConfig{{
+			PackageId:                "QS.QUOTE",
+			Version:                  "2024.2",
+			PreviousVersionPackageId: "QS.QUOTE",
+			PreviousVersion:          "2024.1",
+			BuildType:                view.ChangelogType,
+			CreatedBy:                "user1",
+			ComparisonRevision:       3,
+			ComparisonPrevRevision:   1,
+		}}, buildService.created, name)
+	}
+
+	buildService := &testBuildService{searchErr: &exception.CustomError{Status: http.StatusInternalServerError, Message: "db is down"}}
+	s := &savedComparisonServiceImpl{comparisonService: &testComparisonService{}, buildService: buildService}
+	require.Error(t, s.startCalculation(newTestSavedComparison()))
+	require.Empty(t, buildService.created, "a build is not created if the search failed")
+}
+
+func TestSavedComparisonRecalculateOnNewRevision(t *testing.T) {
+	repo := &testSavedComparisonRepository{started: true}
+	publishedRepo := &testSavedComparisonPublishedRepository{revisions: map[string]int{"QS.QUOTE@2024.2": 4, "QS.QUOTE@2024.1": 1}}
+	buildService := &testBuildService{}
+	s := &savedComparisonServiceImpl{repo: repo, publishedRepo: publishedRepo, comparisonService: &testComparisonService{}, buildService: buildService}
+	ent := newTestSavedComparison()
+	ent.Status = view.SavedComparisonStatusComplete
+
+	require.NoError(t, s.recalculate(context.Background(), *ent))
+	require.Len(t, repo.startedCalculations, 1)
+	require.Equal(t, 4, repo.startedCalculations[0].Revision)
+	require.Equal(t, 1, repo.startedCalculations[0].PreviousRevision)
+	require.Equal(t, view.MakeVersionComparisonId("QS.QUOTE", "2024.2", 4, "QS.QUOTE", "2024.1", 1), repo.startedCalculations[0].ComparisonId)
+	require.Len(t, repo.updatedStatuses, 1)
+	require.Equal(t, view.SavedComparisonStatusRunning, repo.updatedStatuses[0].Status)
+	require.Equal(t, "new-build", repo.updatedStatuses[0].BuildId)
+	require.Len(t, buildService.created, 1)
+	require.Equal(t, 4, buildService.created[0].ComparisonRevision)
+
+	// another instance has already moved the comparison to the new revisions
+	repo = &testSavedComparisonRepository{started: false}
+	buildService = &testBuildService{}
+	s = &savedComparisonServiceImpl{repo: repo, publishedRepo: publishedRepo, comparisonService: &testComparisonService{}, buildService: buildService}
+	require.NoError(t, s.recalculate(context.Background(), *ent))
+	require.Len(t, repo.startedCalculations, 1)
+	require.Empty(t, repo.updatedStatuses)
+	require.Empty(t, buildService.created)
+
+	// the revisions are not changed
+	repo = &testSavedComparisonRepository{started: true}
+	publishedRepo.revisions["QS.QUOTE@2024.2"] = 3
+	s = &savedComparisonServiceImpl{repo: repo, publishedRepo: publishedRepo, comparisonService: &testComparisonService{}, buildService: buildService}
+	require.NoError(t, s.recalculate(context.Background(), *ent))
+	require.Empty(t, repo.startedCalculations)
+	require.Empty(t, repo.updatedStatuses)
+}
+
+func TestSavedComparisonCheckReadPermission(t *testing.T) {
+	secCtx := secctx.CreateFromId("user1")
+	for name, readable := range map[string]map[string]bool{
+		"both readable":             {"QS.QUOTE": true, "QS.CORE.QUOTE": true},
+		"package not readable":      {"QS.CORE.QUOTE": true},
+		"previous not readable":     {"QS.QUOTE": true},
+		"none of packages readable": {},
+	} {
+		roleService := &testSavedComparisonRoleService{readable: readable}
+		s := &savedComparisonServiceImpl{roleService: roleService}
+		err := s.checkReadPermission(secCtx, "QS.QUOTE", "QS.CORE.QUOTE")
+		if readable["QS.QUOTE"] && readable["QS.CORE.QUOTE"] {
+			require.NoError(t, err, name)
+			require.Equal(t, []string{"QS.QUOTE", "QS.CORE.QUOTE"}, roleService.checked, name)
+			continue
+		}
+		var customError *exception.CustomError
+		require.ErrorAs(t, err, &customError, name)
+		require.Equal(t, http.StatusForbidden, customError.Status, name)
+		require.Equal(t, exception.InsufficientPrivileges, customError.Code, name)
+	}
+}
+
+func newTestSavedComparison() *entity.SavedComparisonEntity {
+	ent := &entity.SavedComparisonEntity{
+		Id:                "comparison1",
+		PackageId:         "QS.QUOTE",
+		Version:           "2024.2",
+		Revision:          3,
+		PreviousPackageId: "QS.QUOTE",
+		PreviousVersion:   "2024.1",
+		PreviousRevision:  1,
+		CreatedBy:         "user1",
+	}
+	ent.ComparisonId = makeSavedComparisonId(ent)
+	return ent
+}
+
+type testSavedComparisonRepository struct {
+	repository.SavedComparisonRepository
+	started             bool
+	startedCalculations []entity.SavedComparisonEntity
+	updatedStatuses     []entity.SavedComparisonEntity
+}
+
+func (r *testSavedComparisonRepository) StartSavedComparisonCalculation(ctx context.Context, ent *entity.SavedComparisonEntity) (bool, error) {
+	r.startedCalculations = append(r.startedCalculations, *ent)
+	return r.started, nil
+}
+
+func (r *testSavedComparisonRepository) UpdateSavedComparisonStatus(ctx context.Context, ent *entity.SavedComparisonEntity) error {
+	r.updatedStatuses = append(r.updatedStatuses, *ent)
+	return nil
+}
+
+type testSavedComparisonPublishedRepository struct {
+	repository.PublishedRepository
+	revisions map[string]int
+}
+
+func (r *testSavedComparisonPublishedRepository) GetVersion(packageId string, versionName string) (*entity.PublishedVersionEntity, error) {
+	revision, exists := r.revisions[packageId+"@"+versionName]
+	if !exists {
+		return nil, nil
+	}
+	return &entity.PublishedVersionEntity{PackageId: packageId, Version: versionName, Revision: revision}, nil
+}
+
+type testComparisonService struct {
+	ComparisonService
+	exists  bool
+	checked []string
+}
+
+func (c *testComparisonService) ValidComparisonResultExists(packageId string, version string, previousVersionPackageId string, previousVersion string) (bool, error) {
+	c.checked = append(c.checked, packageId+"@"+version+" "+previousVersionPackageId+"@"+previousVersion)
+	return c.exists, nil
+}
+
+type testBuildService struct {
+	BuildService
+	found     *view.BuildView
+	searchErr error
+	searches  []view.ChangelogBuildSearchRequest
+	created   []view.BuildConfig
+}
+
+func (b *testBuildService) GetBuildViewByChangelogSearchQuery(searchRequest view.ChangelogBuildSearchRequest) (*view.BuildView, error) {
+	b.searches = append(b.searches, searchRequest)
+	if b.searchErr != nil {
+		return nil, b.searchErr
+	}
+	if b.found == nil {
+		return nil, &exception.CustomError{Status: http.StatusNotFound, Code: exception.BuildNotFoundByQuery, Message: exception.BuildNotFoundByQueryMsg}
+	}
+	return b.found, nil
+}
+
+func (b *testBuildService) CreateBuildWithoutDependencies(config view.BuildConfig, isExternal bool, builderId string) (string, view.BuildConfig, error) {
+	b.created = append(b.created, config)
+	return "new-build", config, nil
+}
+
+type testSavedComparisonRoleService struct {
+	RoleService
+	readable map[string]bool
+	checked  []string
+}
+
+func (r *testSavedComparisonRoleService) HasRequiredPermissions(ctx secctx.SecurityContext, packageId string, requiredPermissions ...view.RolePermission) (bool, error) {
+	r.checked = append(r.checked, packageId)
+	return r.readable[packageId], nil
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/config"
+	log "github.com/sirupsen/logrus"
+)
+
+// shutdownAbortGracePeriod is the time given to aborted requests to write their final response before connections are closed
+const shutdownAbortGracePeriod = 3 * time.Second
+
+// ErrServiceShuttingDown is the cause of AbortContext cancellation
+var ErrServiceShuttingDown = errors.New("service instance is shutting down")
+
+type ShutdownHook func(ctx context.Context) error
+
+// ShutdownService coordinates the graceful shutdown of the instance:
+//  1. readiness probe starts failing and new work is not accepted (StoppingContext is cancelled);
+//  2. after the readiness delay the HTTP listener is closed, in-flight requests, streams and tracked work are drained;
+//  3. when the drain timeout is exceeded AbortContext is cancelled and the remaining connections are closed;
+//  4. registered hooks are executed in the registration order.
+type ShutdownService interface {
+	IsShuttingDown() bool
+	// StoppingContext is cancelled when the shutdown starts. Long-living loops which pick up new work must stop on it.
+	StoppingContext() context.Context
+	// AbortContext is cancelled with ErrServiceShuttingDown cause when the drain timeout is exceeded. In-flight work must stop on it.
+	AbortContext() context.Context
+	// TrackWork registers in-flight work which is awaited during the drain. The returned function must be called when the work is finished.
+	TrackWork(kind string) func()
+	RegisterHook(name string, hook ShutdownHook)
+	// Shutdown is executed once, subsequent calls return immediately
+	Shutdown(srv *http.Server) error
+}
+
+func NewShutdownService(shutdownConfig config.ShutdownConfig) ShutdownService {
+	stoppingCtx, stop := context.WithCancel(context.Background())
+	abortCtx, abort := context.WithCancelCause(context.Background())
+	return &shutdownServiceImpl{
+		config:      shutdownConfig,
+		stoppingCtx: stoppingCtx,
+		stop:        stop,
+		abortCtx:    abortCtx,
+		abort:       abort,
+		inFlight:    make(map[string]int),
+		workDone:    make(chan struct{}, 1),
+	}
+}
+
+type shutdownServiceImpl struct {
+	config       config.ShutdownConfig
+	shuttingDown atomic.Bool
+	stoppingCtx  context.Context
+	stop         context.CancelFunc
+	abortCtx     context.Context
+	abort        context.CancelCauseFunc
+
+	mutex    sync.Mutex
+	inFlight map[string]int
+	// workDone is signalled when tracked work is finished to re-check the in-flight counters
+	workDone chan struct{}
+	hooks    []namedShutdownHook
+}
+
+type namedShutdownHook struct {
+	name string
+	hook ShutdownHook
+}
+
+func (s *shutdownServiceImpl) IsShuttingDown() bool {
+	return s.shuttingDown.Load()
+}
+
+func (s *shutdownServiceImpl) StoppingContext() context.Context {
+	return s.stoppingCtx
+}
+
+func (s *shutdownServiceImpl) AbortContext() context.Context {
+	return s.abortCtx
+}
+
+func (s *shutdownServiceImpl) TrackWork(kind string) func() {
+	s.mutex.Lock()
+	s.inFlight[kind]++
+	s.mutex.Unlock()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mutex.Lock()
+			s.inFlight[kind]--
+			if s.inFlight[kind] <= 0 {
+				delete(s.inFlight, kind)
+			}
+			s.mutex.Unlock()
+			select {
+			case s.workDone <- struct{}{}:
+			default:
+			}
+		})
+	}
+}
+
+func (s *shutdownServiceImpl) RegisterHook(name string, hook ShutdownHook) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.hooks = append(s.hooks, namedShutdownHook{name: name, hook: hook})
+}
+
+func (s *shutdownServiceImpl) Shutdown(srv *http.Server) error {
+	if !s.shuttingDown.CompareAndSwap(false, true) {
+		return nil
+	}
+	started := time.Now()
+	log.Infof("Graceful shutdown started: readiness delay %ds, drain timeout %ds, hooks timeout %ds",
+		s.config.ReadinessDelaySec, s.config.DrainTimeoutSec, s.config.HooksTimeoutSec)
+	s.stop()
+
+	// the instance still serves requests while the load balancer notices failed readiness probe
+	time.Sleep(time.Duration(s.config.ReadinessDelaySec) * time.Second)
+
+	drainCtx, drainCancel := context.WithTimeout(context.Background(), time.Duration(s.config.DrainTimeoutSec)*time.Second)
+	defer drainCancel()
+	var errs []error
+	if err := s.drain(drainCtx, srv); err != nil {
+		log.Warnf("Drain was not completed in %ds, in-flight work is aborted: %v", s.config.DrainTimeoutSec, err)
+		s.abort(ErrServiceShuttingDown)
+		if err = s.closeServer(srv); err != nil {
+			errs = append(errs, err)
+		}
+	} else {
+		s.abort(ErrServiceShuttingDown)
+		log.Infof("Drain completed in %v", time.Since(started))
+	}
+
+	hooksCtx, hooksCancel := context.WithTimeout(context.Background(), time.Duration(s.config.HooksTimeoutSec)*time.Second)
+	defer hooksCancel()
+	if err := s.runHooks(hooksCtx); err != nil {
+		errs = append(errs, err)
+	}
+	log.Infof("Graceful shutdown finished in %v", time.Since(started))
+	return errors.Join(errs...)
+}
+
+func (s *shutdownServiceImpl) drain(ctx context.Context, srv *http.Server) error {
+	// http.Server.Shutdown closes the listener and idle connections and waits for active ones, including SSE streams
+	if srv != nil {
+		if err := srv.Shutdown(ctx); err != nil {
+			return fmt.Errorf("http server shutdown: %w", err)
+		}
+	}
+	return s.awaitWork(ctx)
+}
+
+func (s *shutdownServiceImpl) awaitWork(ctx context.Context) error {
+	for {
+		s.mutex.Lock()
+		remaining := s.describeInFlight()
+		s.mutex.Unlock()
+		if remaining == "" {
+			return nil
+		}
+		log.Infof("Waiting for in-flight work to finish: %s", remaining)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("in-flight work is not finished: %s", remaining)
+		case <-s.workDone:
+		}
+	}
+}
+
+func (s *shutdownServiceImpl) describeInFlight() string {
+	kinds := make([]string, 0, len(s.inFlight))
+	for kind, count := range s.inFlight {
+		kinds = append(kinds, fmt.Sprintf("%s=%d", kind, count))
+	}
+	sort.Strings(kinds)
+	return strings.Join(kinds, ", ")
+}
+
+func (s *shutdownServiceImpl) closeServer(srv *http.Server) error {
+	// aborted work gets a chance to report its state before the connections are closed
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownAbortGracePeriod)
+	defer cancel()
+	var err error
+	if srv != nil && srv.Shutdown(ctx) != nil {
+		if closeErr := srv.Close(); closeErr != nil {
+			err = fmt.Errorf("failed to close http server: %w", closeErr)
+		}
+	}
+	if workErr := s.awaitWork(ctx); workErr != nil {
+		log.Warnf("Aborted work is not finished: %v", workErr)
+	}
+	return err
+}
+
+func (s *shutdownServiceImpl) runHooks(ctx context.Context) error {
+	s.mutex.Lock()
+	hooks := append([]namedShutdownHook{}, s.hooks...)
+	s.mutex.Unlock()
+	var errs []error
+	for _, h := range hooks {
+		hookStarted := time.Now()
+		if err := h.hook(ctx); err != nil {
+			log.Errorf("Shutdown hook '%s' failed: %v", h.name, err)
+			errs = append(errs, fmt.Errorf("%s: %w", h.name, err))
+			continue
+		}
+		log.Infof("Shutdown hook '%s' completed in %v", h.name, time.Since(hookStarted))
+	}
+	return errors.Join(errs...)
+}
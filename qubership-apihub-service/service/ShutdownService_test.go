@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShutdownDrainsTrackedWork(t *testing.T) {
+	s := NewShutdownService(config.ShutdownConfig{DrainTimeoutSec: 5, HooksTimeoutSec: 1})
+
+	workDone := s.TrackWork("test work")
+	workFinished := make(chan struct{})
+	go func() {
+		<-s.StoppingContext().Done()
+		time.Sleep(50 * time.Millisecond)
+		close(workFinished)
+		workDone()
+	}()
+
+	var hooks []string
+	s.RegisterHook("first", func(ctx context.Context) error {
+		select {
+		case <-workFinished:
+		default:
+			t.Error("hook must be executed after the drain")
+		}
+		hooks = append(hooks, "first")
+		return nil
+	})
+	s.RegisterHook("failing", func(ctx context.Context) error {
+		return errors.New("hook failure")
+	})
+	s.RegisterHook("last", func(ctx context.Context) error {
+		hooks = append(hooks, "last")
+		return nil
+	})
+
+	require.False(t, s.IsShuttingDown())
+	err := s.Shutdown(nil)
+	require.ErrorContains(t, err, "failing: hook failure")
+	require.True(t, s.IsShuttingDown())
+	require.Equal(t, []string{"first", "last"}, hooks, "failed hook must not stop the next ones")
+	require.ErrorIs(t, context.Cause(s.AbortContext()), ErrServiceShuttingDown)
+
+	require.NoError(t, s.Shutdown(nil), "repeated shutdown must be a no-op")
+}
+
+func TestShutdownAbortsWorkAfterDrainTimeout(t *testing.T) {
+	s := NewShutdownService(config.ShutdownConfig{DrainTimeoutSec: 1, HooksTimeoutSec: 1})
+
+	aborted := make(chan error, 1)
+	workDone := s.TrackWork("test work")
+	go func() {
+		defer workDone()
+		<-s.AbortContext().Done()
+		aborted <- context.Cause(s.AbortContext())
+	}()
+
+	started := time.Now()
+	require.NoError(t, s.Shutdown(nil))
+	require.Less(t, time.Since(started), 1*time.Second+shutdownAbortGracePeriod)
+	require.ErrorIs(t, <-aborted, ErrServiceShuttingDown)
+}
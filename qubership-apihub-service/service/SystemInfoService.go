@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sync/atomic"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/config"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/security/idp"
@@ -40,6 +41,9 @@ type SystemInfoService interface {
 	GetPGDB() string
 	GetPGUser() string
 	GetPGPassword() string
+	GetPGReadReplicaUrls() []string
+	GetPGReplicaMaxLagSec() int
+	GetPGPrimaryStickinessSec() int
 	GetAPIHubUrl() string
 	GetPublishArchiveSizeLimitMB() int64
 	GetPublishFileSizeLimitMB() int64
@@ -56,6 +60,8 @@ type SystemInfoService interface {
 	GetBuildsCleanupSchedule() string
 	GetMetricsGetterSchedule() string
 	MonitoringEnabled() bool
+	GetTracingConfig() config.TracingConfig
+	GetShutdownConfig() config.ShutdownConfig
 	GetMinioAccessKeyId() string
 	GetMinioSecretAccessKey() string
 	GetMinioCrt() string
@@ -64,6 +70,7 @@ type SystemInfoService interface {
 	IsMinioStorageActive() bool
 	GetMinioStorageCreds() *view.MinioStorageCreds
 	IsMinioStoreOnlyBuildResult() bool
+	GetBlobStorageConfig() config.BlobStorageConfig
 	GetExternalLinks() []string
 	GetDefaultWorkspaceId() string
 	GetAllowedHosts() []string
@@ -93,6 +100,7 @@ type SystemInfoService interface {
 	GetMaintenanceVacuumCleanupTimeout() int
 	GetExtensions() []view.Extension
 	GetAiChatConfig() config.ChatConfig
+	GetAiSpecReviewConfig() config.SpecReviewConfig
 	GetAiMCPConfig() config.MCPConfig
 	GetApiSpecDirectory() string
 	GetFeatureFlags() view.FeatureFlags
@@ -101,6 +109,11 @@ type SystemInfoService interface {
 	GetEphemeralFileMaxSizeMb() int
 	GetEphemeralFileTTLMinutes() int
 	GetEphemeralFilesCleanupSchedule() string
+	GetSunsetAlertsSchedule() string
+	// GetFileConfig returns the configuration read from the configuration file, without runtime settings
+	GetFileConfig() config.Config
+	// SetRuntimeConfig replaces the configuration used by the getters of runtime settings
+	SetRuntimeConfig(cfg config.Config)
 }
 
 func (g *systemInfoServiceImpl) GetCredsFromEnv() *view.DbCredentials {
@@ -137,6 +150,8 @@ func NewSystemInfoService() (SystemInfoService, error) {
 type systemInfoServiceImpl struct {
 	config     config.Config
 	authConfig idp.AuthConfig
+	// runtimeConfig is the file configuration with runtime settings applied, only the getters of runtime settings use it
+	runtimeConfig atomic.Pointer[config.Config]
 }
 
 func (g *systemInfoServiceImpl) GetClientSecret() string {
@@ -180,10 +195,19 @@ func (g *systemInfoServiceImpl) Init() error {
 		return err
 	}
 	g.authConfig = authConfig
+	g.SetRuntimeConfig(g.config)
 
 	return nil
 }
 
+func (g *systemInfoServiceImpl) GetFileConfig() config.Config {
+	return g.config
+}
+
+func (g *systemInfoServiceImpl) SetRuntimeConfig(cfg config.Config) {
+	g.runtimeConfig.Store(&cfg)
+}
+
 func base64EncodedStringDecodeHook() mapstructure.DecodeHookFunc {
 	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
 		if f.Kind() != reflect.String {
@@ -208,6 +232,9 @@ func (g *systemInfoServiceImpl) setDefaults() {
 	viper.SetDefault("database.name", "apihub")
 	viper.SetDefault("database.username", "apihub")
 	viper.SetDefault("database.password", "apihub")
+	viper.SetDefault("database.readReplicaUrls", []string{})
+	viper.SetDefault("database.replicaMaxLagSec", 5)
+	viper.SetDefault("database.primaryStickinessSec", 30)
 	viper.SetDefault("security.productionMode", true)
 	viper.SetDefault("security.jwt.accessTokenDurationSec", 1800)
 	viper.SetDefault("security.jwt.refreshTokenDurationSec", 43200)
@@ -228,10 +255,17 @@ func (g *systemInfoServiceImpl) setDefaults() {
 	viper.SetDefault("businessParameters.externalLinks", []string{})
 	viper.SetDefault("businessParameters.failBuildOnBrokenRefs", true)
 	viper.SetDefault("monitoring.enabled", false)
+	viper.SetDefault("monitoring.tracing.enabled", false)
+	viper.SetDefault("monitoring.tracing.otlpEndpoint", "")
+	viper.SetDefault("monitoring.tracing.serviceName", "apihub-backend")
+	viper.SetDefault("monitoring.tracing.sampleRatio", 1.0)
 	viper.SetDefault("s3Storage.enabled", false)
 	viper.SetDefault("s3Storage.storeOnlyBuildResult", false)
+	viper.SetDefault("blobStorage.filesystem.rootDirectory", "/data/apihub-blobs")
 	viper.SetDefault("olric.discoveryMode", "local")
 	viper.SetDefault("olric.replicaCount", 1)
+	viper.SetDefault("olric.responseCache.enabled", false)
+	viper.SetDefault("olric.responseCache.ttlSec", 3600)
 	viper.SetDefault("cleanup.builds.schedule", "0 1 * * 0")     // at 01:00 AM on Sunday
 	viper.SetDefault("cleanup.revisions.schedule", "0 21 * * 0") // at 9:00 PM on Sunday
 	viper.SetDefault("cleanup.revisions.deleteLastRevision", false)
@@ -256,9 +290,17 @@ func (g *systemInfoServiceImpl) setDefaults() {
 	viper.SetDefault("ai.chat.pinnedForeverCount", 10)
 	viper.SetDefault("ai.chat.compactAtContextPercent", 80)
 	viper.SetDefault("ai.chat.cleanupSchedule", "15 3 * * *")
+	viper.SetDefault("ai.specReview.enabled", false)
+	viper.SetDefault("ai.specReview.tokenBudget", 200000)
+	viper.SetDefault("ai.specReview.maxOperations", 200)
+	viper.SetDefault("ai.specReview.maxOperationSpecLength", 20000)
 	viper.SetDefault("technicalParameters.ephemeralFileDirectory", "/tmp/apihub-ephemeral-files")
+	viper.SetDefault("technicalParameters.shutdown.readinessDelaySec", 5)
+	viper.SetDefault("technicalParameters.shutdown.drainTimeoutSec", 12)
+	viper.SetDefault("technicalParameters.shutdown.hooksTimeoutSec", 8)
 	viper.SetDefault("businessParameters.ephemeralFileMaxSizeMb", 50)
 	viper.SetDefault("businessParameters.ephemeralFileTTLMinutes", 30)
+	viper.SetDefault("businessParameters.sunsetAlertsSchedule", "0 6 * * *")
 	viper.SetDefault("cleanup.ephemeralFiles.schedule", "*/5 * * * *")
 }
 
@@ -362,16 +404,28 @@ func (g *systemInfoServiceImpl) GetPGPassword() string {
 	return g.config.Database.Password
 }
 
+func (g *systemInfoServiceImpl) GetPGReadReplicaUrls() []string {
+	return g.config.Database.ReadReplicaUrls
+}
+
+func (g *systemInfoServiceImpl) GetPGReplicaMaxLagSec() int {
+	return g.config.Database.ReplicaMaxLagSec
+}
+
+func (g *systemInfoServiceImpl) GetPGPrimaryStickinessSec() int {
+	return g.config.Database.PrimaryStickinessSec
+}
+
 func (g *systemInfoServiceImpl) GetAPIHubUrl() string {
 	return g.config.Security.ApihubExternalUrl
 }
 
 func (g *systemInfoServiceImpl) GetPublishArchiveSizeLimitMB() int64 {
-	return int64(g.config.BusinessParameters.PublishArchiveSizeLimitMb * bytesInMb)
+	return int64(g.runtimeConfig.Load().BusinessParameters.PublishArchiveSizeLimitMb * bytesInMb)
 }
 
 func (g *systemInfoServiceImpl) GetPublishFileSizeLimitMB() int64 {
-	return int64(g.config.BusinessParameters.PublishFileSizeLimitMb * bytesInMb)
+	return int64(g.runtimeConfig.Load().BusinessParameters.PublishFileSizeLimitMb * bytesInMb)
 }
 
 func (g *systemInfoServiceImpl) GetTemplateSizeLimitMB() int64 {
@@ -383,7 +437,7 @@ func (g *systemInfoServiceImpl) GetShareabilityReportSizeLimitMB() int64 {
 }
 
 func (g *systemInfoServiceImpl) GetReleaseVersionPattern() string {
-	return g.config.BusinessParameters.ReleaseVersionPattern
+	return g.runtimeConfig.Load().BusinessParameters.ReleaseVersionPattern
 }
 
 func (g *systemInfoServiceImpl) GetLdapServer() string {
@@ -426,6 +480,14 @@ func (g *systemInfoServiceImpl) MonitoringEnabled() bool {
 	return g.config.Monitoring.Enabled
 }
 
+func (g *systemInfoServiceImpl) GetTracingConfig() config.TracingConfig {
+	return g.config.Monitoring.Tracing
+}
+
+func (g *systemInfoServiceImpl) GetShutdownConfig() config.ShutdownConfig {
+	return g.config.TechnicalParameters.Shutdown
+}
+
 func (g *systemInfoServiceImpl) GetMinioAccessKeyId() string {
 	return g.config.S3Storage.Username
 }
@@ -454,8 +516,12 @@ func (g *systemInfoServiceImpl) IsMinioStoreOnlyBuildResult() bool {
 	return g.config.S3Storage.StoreOnlyBuildResult
 }
 
+func (g *systemInfoServiceImpl) GetBlobStorageConfig() config.BlobStorageConfig {
+	return g.config.BlobStorage
+}
+
 func (g *systemInfoServiceImpl) GetExternalLinks() []string {
-	return g.config.BusinessParameters.ExternalLinks
+	return g.runtimeConfig.Load().BusinessParameters.ExternalLinks
 }
 
 func (g *systemInfoServiceImpl) GetDefaultWorkspaceId() string {
@@ -463,7 +529,7 @@ func (g *systemInfoServiceImpl) GetDefaultWorkspaceId() string {
 }
 
 func (g *systemInfoServiceImpl) GetAllowedHosts() []string {
-	return g.config.Security.AllowedHostsForProxy
+	return g.runtimeConfig.Load().Security.AllowedHostsForProxy
 }
 
 func (g *systemInfoServiceImpl) GetZeroDayAdminCreds() (string, string) {
@@ -475,7 +541,7 @@ func (g *systemInfoServiceImpl) GetSystemApiKey() string {
 }
 
 func (g *systemInfoServiceImpl) FailBuildOnBrokenRefs() bool {
-	return g.config.BusinessParameters.FailBuildOnBrokenRefs
+	return g.runtimeConfig.Load().BusinessParameters.FailBuildOnBrokenRefs
 }
 
 func (g *systemInfoServiceImpl) GetAccessTokenDurationSec() int {
@@ -650,6 +716,10 @@ func (g *systemInfoServiceImpl) GetAiChatConfig() config.ChatConfig {
 	return g.config.Ai.Chat
 }
 
+func (g *systemInfoServiceImpl) GetAiSpecReviewConfig() config.SpecReviewConfig {
+	return g.config.Ai.SpecReview
+}
+
 func (g *systemInfoServiceImpl) GetAiMCPConfig() config.MCPConfig {
 	return g.config.Ai.MCP
 }
@@ -670,6 +740,10 @@ func (g *systemInfoServiceImpl) GetEphemeralFilesCleanupSchedule() string {
 	return g.config.Cleanup.EphemeralFiles.Schedule
 }
 
+func (g *systemInfoServiceImpl) GetSunsetAlertsSchedule() string {
+	return g.config.BusinessParameters.SunsetAlertsSchedule
+}
+
 func (g *systemInfoServiceImpl) GetFeatureFlags() view.FeatureFlags {
 	return view.FeatureFlags{
 		UseV3Search: g.config.FeatureFlags.UseV3Search,
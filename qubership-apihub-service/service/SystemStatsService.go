@@ -12,6 +12,7 @@ import (
 
 type SystemStatsService interface {
 	GetSystemStats(ctx context.Context) (*view.SystemStats, error)
+	GetStorageUsage(ctx context.Context, groupBy string, limit int, page int) (*view.StorageUsage, error)
 }
 
 func NewSystemStatsService(statsRepository repository.SystemStatsRepository) SystemStatsService {
@@ -37,7 +38,7 @@ func (s *systemStatsServiceImpl) GetSystemStats(ctx context.Context) (*view.Syst
 		var err error
 		packageGroupCounts, err = s.statsRepository.GetPackageGroupCounts(ctx)
 		if err != nil {
-			log.Errorf("Failed to get workspaces, groups, packages counts: %v", err)
+			log.WithContext(ctx).Errorf("Failed to get workspaces, groups, packages counts: %v", err)
 		}
 		return err
 	})
@@ -46,7 +47,7 @@ func (s *systemStatsServiceImpl) GetSystemStats(ctx context.Context) (*view.Syst
 		var err error
 		revisionsCount, err = s.statsRepository.GetRevisionsCount(ctx)
 		if err != nil {
-			log.Errorf("Failed to get revisions count: %v", err)
+			log.WithContext(ctx).Errorf("Failed to get revisions count: %v", err)
 		}
 		return err
 	})
@@ -55,7 +56,7 @@ func (s *systemStatsServiceImpl) GetSystemStats(ctx context.Context) (*view.Syst
 		var err error
 		documentsCount, err = s.statsRepository.GetDocumentsCount(ctx)
 		if err != nil {
-			log.Errorf("Failed to get documents count: %v", err)
+			log.WithContext(ctx).Errorf("Failed to get documents count: %v", err)
 		}
 		return err
 	})
@@ -64,7 +65,7 @@ func (s *systemStatsServiceImpl) GetSystemStats(ctx context.Context) (*view.Syst
 		var err error
 		operationsCount, err = s.statsRepository.GetOperationsCount(ctx)
 		if err != nil {
-			log.Errorf("Failed to get operations count: %v", err)
+			log.WithContext(ctx).Errorf("Failed to get operations count: %v", err)
 		}
 		return err
 	})
@@ -73,7 +74,7 @@ func (s *systemStatsServiceImpl) GetSystemStats(ctx context.Context) (*view.Syst
 		var err error
 		versionComparisonsCount, err = s.statsRepository.GetVersionComparisonsCount(ctx)
 		if err != nil {
-			log.Errorf("Failed to get version comparisons count: %v", err)
+			log.WithContext(ctx).Errorf("Failed to get version comparisons count: %v", err)
 		}
 		return err
 	})
@@ -82,7 +83,7 @@ func (s *systemStatsServiceImpl) GetSystemStats(ctx context.Context) (*view.Syst
 		var err error
 		buildsCountEntities, err = s.statsRepository.GetBuildsCountByType(ctx)
 		if err != nil {
-			log.Errorf("Failed to get builds count by type: %v", err)
+			log.WithContext(ctx).Errorf("Failed to get builds count by type: %v", err)
 		}
 		return err
 	})
@@ -91,7 +92,7 @@ func (s *systemStatsServiceImpl) GetSystemStats(ctx context.Context) (*view.Syst
 		var err error
 		tableSizeEntities, err = s.statsRepository.GetDatabaseSizePerTable(ctx)
 		if err != nil {
-			log.Errorf("Failed to get database size: %v", err)
+			log.WithContext(ctx).Errorf("Failed to get database size: %v", err)
 		}
 		return err
 	})
@@ -132,3 +133,47 @@ func (s *systemStatsServiceImpl) GetSystemStats(ctx context.Context) (*view.Syst
 		DatabaseSize:     databaseSize,
 	}, nil
 }
+
+func (s *systemStatsServiceImpl) GetStorageUsage(ctx context.Context, groupBy string, limit int, page int) (*view.StorageUsage, error) {
+	g, ctx := errgroup.WithContext(ctx)
+
+	var contentUsageEntities []entity.ContentStorageUsageEntity
+	var packageUsageEntities []entity.PackageStorageUsageEntity
+
+	g.Go(func() error {
+		var err error
+		contentUsageEntities, err = s.statsRepository.GetContentStorageUsage(ctx)
+		if err != nil {
+			log.WithContext(ctx).Errorf("Failed to get content storage usage: %v", err)
+		}
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		packageUsageEntities, err = s.statsRepository.GetPackageStorageUsage(ctx, groupBy == view.StorageUsageGroupByWorkspace, limit, page)
+		if err != nil {
+			log.WithContext(ctx).Errorf("Failed to get storage usage by %s: %v", groupBy, err)
+		}
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	content := make([]view.ContentStorageUsage, len(contentUsageEntities))
+	for i, entity := range contentUsageEntities {
+		content[i] = entity.MakeContentStorageUsageView()
+	}
+
+	packages := make([]view.PackageStorageUsage, len(packageUsageEntities))
+	for i, entity := range packageUsageEntities {
+		packages[i] = entity.MakePackageStorageUsageView()
+	}
+
+	return &view.StorageUsage{
+		Content:  content,
+		Packages: packages,
+	}, nil
+}
@@ -0,0 +1,474 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	secctx "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	tenantStorageUsageJobName     = "tenantStorageUsage"
+	tenantStorageUsageJobSchedule = "@every 1h"
+	// maxStorageUsageWorkspaces limits the storage usage calculation, it is far above the expected number of workspaces
+	maxStorageUsageWorkspaces = 100000
+)
+
+// TenantService treats workspaces as tenants: checks their quotas, provides overrides of the instance-wide configuration
+// and manages workspace administrators. A workspace without stored quotas and settings is not limited and uses the instance-wide configuration.
+type TenantService interface {
+	GetTenant(ctx context.Context, workspaceId string) (*view.WorkspaceTenant, error)
+	UpdateQuotas(ctx context.Context, secCtx secctx.SecurityContext, workspaceId string, quotas view.TenantQuotas) (*view.WorkspaceTenant, error)
+	UpdateSettings(ctx context.Context, secCtx secctx.SecurityContext, workspaceId string, settings view.TenantSettings) (*view.WorkspaceTenant, error)
+	GetAdmins(ctx context.Context, workspaceId string) (*view.Admins, error)
+	AddAdmins(ctx context.Context, secCtx secctx.SecurityContext, workspaceId string, userIds []string) (*view.Admins, error)
+	DeleteAdmin(ctx context.Context, workspaceId string, userId string) error
+	// IsTenantAdmin checks if the user is a sysadmin or an administrator of the workspace
+	IsTenantAdmin(secCtx secctx.SecurityContext, workspaceId string) (bool, error)
+	GetUsage(ctx context.Context, workspaceId string) (*view.TenantUsage, error)
+	GetTenantsUsage(ctx context.Context) (*view.TenantsUsage, error)
+
+	// CheckPackageQuota returns an error if a new package or dashboard could not be created in the workspace of packageId
+	CheckPackageQuota(ctx context.Context, packageId string) error
+	// CheckPublishQuotas returns an error if the version could not be published to the package
+	CheckPublishQuotas(ctx context.Context, packageId string, version string) error
+	// GetAiTokensLeft returns the number of AI tokens available to the workspace of packageId till the end of the month, nil if it is not limited.
+	// Returns an error if the quota is exhausted.
+	GetAiTokensLeft(ctx context.Context, packageId string) (*int64, error)
+	// GetSettings returns the overrides of the workspace of packageId
+	GetSettings(ctx context.Context, packageId string) (*view.TenantSettings, error)
+
+	StartStorageUsageJob(backgroundJobService BackgroundJobService) error
+}
+
+func NewTenantService(tenantRepo repository.TenantRepository, publishedRepo repository.PublishedRepository,
+	statsRepository repository.SystemStatsRepository, roleService RoleService, userService UserService) TenantService {
+	return &tenantServiceImpl{
+		tenantRepo:      tenantRepo,
+		publishedRepo:   publishedRepo,
+		statsRepository: statsRepository,
+		roleService:     roleService,
+		userService:     userService,
+	}
+}
+
+type tenantServiceImpl struct {
+	tenantRepo      repository.TenantRepository
+	publishedRepo   repository.PublishedRepository
+	statsRepository repository.SystemStatsRepository
+	roleService     RoleService
+	userService     UserService
+}
+
+func (t *tenantServiceImpl) GetTenant(ctx context.Context, workspaceId string) (*view.WorkspaceTenant, error) {
+	if err := t.checkWorkspace(workspaceId); err != nil {
+		return nil, err
+	}
+	ent, err := t.tenantRepo.GetTenant(ctx, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+	return entity.MakeWorkspaceTenantView(workspaceId, ent), nil
+}
+
+func (t *tenantServiceImpl) UpdateQuotas(ctx context.Context, secCtx secctx.SecurityContext, workspaceId string, quotas view.TenantQuotas) (*view.WorkspaceTenant, error) {
+	if err := t.checkWorkspace(workspaceId); err != nil {
+		return nil, err
+	}
+	err := t.tenantRepo.SaveQuotas(ctx, &entity.WorkspaceTenantEntity{
+		WorkspaceId:      workspaceId,
+		StorageLimitMb:   quotas.StorageLimitMb,
+		MaxPackages:      quotas.MaxPackages,
+		MaxVersions:      quotas.MaxVersions,
+		BuildsPerHour:    quotas.BuildsPerHour,
+		AiTokensPerMonth: quotas.AiTokensPerMonth,
+		UpdatedBy:        secCtx.GetUserId(),
+		UpdatedAt:        time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.WithContext(ctx).Infof("Quotas of workspace %s are updated by %s: %+v", workspaceId, secCtx.GetUserId(), quotas)
+	return t.GetTenant(ctx, workspaceId)
+}
+
+func (t *tenantServiceImpl) UpdateSettings(ctx context.Context, secCtx secctx.SecurityContext, workspaceId string, settings view.TenantSettings) (*view.WorkspaceTenant, error) {
+	if err := t.checkWorkspace(workspaceId); err != nil {
+		return nil, err
+	}
+	if settings.ReleaseVersionPattern != "" {
+		if _, err := regexp.Compile(settings.ReleaseVersionPattern); err != nil {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.InvalidReleaseVersionPatternFormat,
+				Message: exception.InvalidReleaseVersionPatternFormatMsg,
+				Params:  map[string]interface{}{"pattern": settings.ReleaseVersionPattern},
+				Debug:   err.Error(),
+			}
+		}
+	}
+	if settings.DefaultRole != "" {
+		roleExists, err := t.roleService.PackageRoleExists(settings.DefaultRole)
+		if err != nil {
+			return nil, err
+		}
+		if !roleExists {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.RoleNotFound,
+				Message: exception.RoleNotFoundMsg,
+				Params:  map[string]interface{}{"role": settings.DefaultRole},
+			}
+		}
+	}
+	if settings.ValidationRulesSeverity != nil {
+		brokenRefs := settings.ValidationRulesSeverity.BrokenRefs
+		if brokenRefs != view.BrokenRefsSeverityError && brokenRefs != view.BrokenRefsSeverityWarning {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.InvalidParameterValue,
+				Message: exception.InvalidParameterValueMsg,
+				Params:  map[string]interface{}{"param": "validationRulesSeverity.brokenRefs", "value": brokenRefs},
+			}
+		}
+	}
+	err := t.tenantRepo.SaveSettings(ctx, &entity.WorkspaceTenantEntity{
+		WorkspaceId:             workspaceId,
+		ReleaseVersionPattern:   settings.ReleaseVersionPattern,
+		DefaultRole:             settings.DefaultRole,
+		ValidationRulesSeverity: settings.ValidationRulesSeverity,
+		UpdatedBy:               secCtx.GetUserId(),
+		UpdatedAt:               time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.WithContext(ctx).Infof("Settings of workspace %s are updated by %s", workspaceId, secCtx.GetUserId())
+	return t.GetTenant(ctx, workspaceId)
+}
+
+func (t *tenantServiceImpl) GetAdmins(ctx context.Context, workspaceId string) (*view.Admins, error) {
+	if err := t.checkWorkspace(workspaceId); err != nil {
+		return nil, err
+	}
+	userEnts, err := t.tenantRepo.GetAdmins(ctx, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]view.User, 0, len(userEnts))
+	for _, ent := range userEnts {
+		users = append(users, *entity.MakeUserV2View(&ent))
+	}
+	return &view.Admins{Admins: users}, nil
+}
+
+func (t *tenantServiceImpl) AddAdmins(ctx context.Context, secCtx secctx.SecurityContext, workspaceId string, userIds []string) (*view.Admins, error) {
+	if err := t.checkWorkspace(workspaceId); err != nil {
+		return nil, err
+	}
+	ents := make([]entity.WorkspaceTenantAdminEntity, 0, len(userIds))
+	for _, userId := range userIds {
+		user, err := t.userService.GetUserFromDB(userId)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, &exception.CustomError{
+				Status:  http.StatusNotFound,
+				Code:    exception.UserNotFound,
+				Message: exception.UserNotFoundMsg,
+				Params:  map[string]interface{}{"userId": userId},
+			}
+		}
+		ents = append(ents, entity.WorkspaceTenantAdminEntity{
+			WorkspaceId: workspaceId,
+			UserId:      userId,
+			AddedBy:     secCtx.GetUserId(),
+			AddedAt:     time.Now(),
+		})
+	}
+	if err := t.tenantRepo.AddAdmins(ctx, ents); err != nil {
+		return nil, err
+	}
+	log.WithContext(ctx).Infof("Users %v are added to administrators of workspace %s by %s", userIds, workspaceId, secCtx.GetUserId())
+	return t.GetAdmins(ctx, workspaceId)
+}
+
+func (t *tenantServiceImpl) DeleteAdmin(ctx context.Context, workspaceId string, userId string) error {
+	deleted, err := t.tenantRepo.DeleteAdmin(ctx, workspaceId, userId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.TenantAdminNotFound,
+			Message: exception.TenantAdminNotFoundMsg,
+			Params:  map[string]interface{}{"userId": userId, "workspaceId": workspaceId},
+		}
+	}
+	log.WithContext(ctx).Infof("User %s is removed from administrators of workspace %s", userId, workspaceId)
+	return nil
+}
+
+func (t *tenantServiceImpl) IsTenantAdmin(secCtx secctx.SecurityContext, workspaceId string) (bool, error) {
+	if t.roleService.IsSysadm(secCtx) {
+		return true, nil
+	}
+	if secCtx.GetUserId() == "" || secCtx.GetApikeyPackageId() != "" {
+		return false, nil
+	}
+	return t.tenantRepo.IsAdmin(workspaceId, secCtx.GetUserId())
+}
+
+func (t *tenantServiceImpl) GetUsage(ctx context.Context, workspaceId string) (*view.TenantUsage, error) {
+	if err := t.checkWorkspace(workspaceId); err != nil {
+		return nil, err
+	}
+	ent, err := t.tenantRepo.GetTenant(ctx, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+	return t.calculateUsage(ctx, workspaceId, ent)
+}
+
+func (t *tenantServiceImpl) GetTenantsUsage(ctx context.Context) (*view.TenantsUsage, error) {
+	ents, err := t.tenantRepo.GetTenants(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.TenantsUsage{Tenants: make([]view.TenantUsage, 0, len(ents))}
+	for i := range ents {
+		usage, err := t.calculateUsage(ctx, ents[i].WorkspaceId, &ents[i])
+		if err != nil {
+			return nil, err
+		}
+		result.Tenants = append(result.Tenants, *usage)
+	}
+	return result, nil
+}
+
+func (t *tenantServiceImpl) calculateUsage(ctx context.Context, workspaceId string, ent *entity.WorkspaceTenantEntity) (*view.TenantUsage, error) {
+	quotas := entity.MakeWorkspaceTenantView(workspaceId, ent).Quotas
+	result := &view.TenantUsage{WorkspaceId: workspaceId}
+
+	storage, err := t.tenantRepo.GetStorageUsage(ctx, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+	var storageMb int64
+	if storage != nil {
+		storageMb = bytesToMb(storage.TotalSize)
+		result.StorageCalculatedAt = &storage.CalculatedAt
+	}
+	result.StorageMb = makeTenantQuotaUsage(storageMb, quotas.StorageLimitMb)
+
+	packages, err := t.tenantRepo.CountPackages(ctx, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+	result.Packages = makeTenantQuotaUsage(packages, intLimit(quotas.MaxPackages))
+
+	versions, err := t.tenantRepo.CountVersions(ctx, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+	result.Versions = makeTenantQuotaUsage(versions, intLimit(quotas.MaxVersions))
+
+	builds, err := t.tenantRepo.CountBuildsSince(ctx, workspaceId, time.Now().Add(-time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	result.BuildsPerHour = makeTenantQuotaUsage(builds, intLimit(quotas.BuildsPerHour))
+
+	tokens, err := t.tenantRepo.SumAiTokensSince(ctx, workspaceId, startOfMonth(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	result.AiTokensPerMonth = makeTenantQuotaUsage(tokens, quotas.AiTokensPerMonth)
+	return result, nil
+}
+
+func (t *tenantServiceImpl) CheckPackageQuota(ctx context.Context, packageId string) error {
+	workspaceId := utils.GetPackageWorkspaceId(packageId)
+	ent, err := t.tenantRepo.GetTenant(ctx, workspaceId)
+	if err != nil || ent == nil || ent.MaxPackages == nil {
+		return err
+	}
+	packages, err := t.tenantRepo.CountPackages(ctx, workspaceId)
+	if err != nil {
+		return err
+	}
+	return checkTenantQuota(workspaceId, view.TenantQuotaPackages, packages, int64(*ent.MaxPackages), http.StatusForbidden)
+}
+
+func (t *tenantServiceImpl) CheckPublishQuotas(ctx context.Context, packageId string, version string) error {
+	workspaceId := utils.GetPackageWorkspaceId(packageId)
+	ent, err := t.tenantRepo.GetTenant(ctx, workspaceId)
+	if err != nil || ent == nil {
+		return err
+	}
+	if ent.BuildsPerHour != nil {
+		builds, err := t.tenantRepo.CountBuildsSince(ctx, workspaceId, time.Now().Add(-time.Hour))
+		if err != nil {
+			return err
+		}
+		if err = checkTenantQuota(workspaceId, view.TenantQuotaBuildsPerHour, builds, int64(*ent.BuildsPerHour), http.StatusTooManyRequests); err != nil {
+			return err
+		}
+	}
+	if ent.StorageLimitMb != nil {
+		storage, err := t.tenantRepo.GetStorageUsage(ctx, workspaceId)
+		if err != nil {
+			return err
+		}
+		if storage != nil {
+			if err = checkTenantQuota(workspaceId, view.TenantQuotaStorage, bytesToMb(storage.TotalSize), *ent.StorageLimitMb, http.StatusForbidden); err != nil {
+				return err
+			}
+		}
+	}
+	if ent.MaxVersions != nil {
+		// new revisions of the existing versions are not limited
+		versionEnt, err := t.publishedRepo.GetVersion(packageId, version)
+		if err != nil {
+			return err
+		}
+		if versionEnt == nil {
+			versions, err := t.tenantRepo.CountVersions(ctx, workspaceId)
+			if err != nil {
+				return err
+			}
+			if err = checkTenantQuota(workspaceId, view.TenantQuotaVersions, versions, int64(*ent.MaxVersions), http.StatusForbidden); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *tenantServiceImpl) GetAiTokensLeft(ctx context.Context, packageId string) (*int64, error) {
+	workspaceId := utils.GetPackageWorkspaceId(packageId)
+	ent, err := t.tenantRepo.GetTenant(ctx, workspaceId)
+	if err != nil || ent == nil || ent.AiTokensPerMonth == nil {
+		return nil, err
+	}
+	used, err := t.tenantRepo.SumAiTokensSince(ctx, workspaceId, startOfMonth(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	if err = checkTenantQuota(workspaceId, view.TenantQuotaAiTokens, used, *ent.AiTokensPerMonth, http.StatusForbidden); err != nil {
+		return nil, err
+	}
+	left := *ent.AiTokensPerMonth - used
+	return &left, nil
+}
+
+func (t *tenantServiceImpl) GetSettings(ctx context.Context, packageId string) (*view.TenantSettings, error) {
+	ent, err := t.tenantRepo.GetTenant(ctx, utils.GetPackageWorkspaceId(packageId))
+	if err != nil {
+		return nil, err
+	}
+	return &entity.MakeWorkspaceTenantView(utils.GetPackageWorkspaceId(packageId), ent).Settings, nil
+}
+
+func (t *tenantServiceImpl) StartStorageUsageJob(backgroundJobService BackgroundJobService) error {
+	return backgroundJobService.RegisterJob(BackgroundJobDefinition{
+		Name:        tenantStorageUsageJobName,
+		Description: "Calculates storage usage of workspaces for the storage quota",
+		Schedule:    tenantStorageUsageJobSchedule,
+		Distributed: true,
+		Timeout:     30 * time.Minute,
+		Job:         BackgroundJobFunc(t.calculateStorageUsage),
+	})
+}
+
+func (t *tenantServiceImpl) calculateStorageUsage(ctx context.Context) error {
+	usageEnts, err := t.statsRepository.GetPackageStorageUsage(ctx, true, maxStorageUsageWorkspaces, 0)
+	if err != nil {
+		return fmt.Errorf("failed to calculate storage usage of workspaces: %w", err)
+	}
+	calculatedAt := time.Now()
+	ents := make([]entity.WorkspaceStorageUsageEntity, 0, len(usageEnts))
+	for _, usageEnt := range usageEnts {
+		ents = append(ents, entity.WorkspaceStorageUsageEntity{
+			WorkspaceId:  usageEnt.Id,
+			TotalSize:    usageEnt.TotalSize,
+			CalculatedAt: calculatedAt,
+		})
+	}
+	if err = t.tenantRepo.ReplaceStorageUsage(ctx, ents); err != nil {
+		return fmt.Errorf("failed to store storage usage of workspaces: %w", err)
+	}
+	log.WithContext(ctx).Infof("Storage usage of %d workspaces is calculated in %v", len(ents), time.Since(calculatedAt))
+	return nil
+}
+
+func (t *tenantServiceImpl) checkWorkspace(workspaceId string) error {
+	ent, err := t.publishedRepo.GetPackage(workspaceId)
+	if err != nil {
+		return err
+	}
+	if ent == nil {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PackageNotFound,
+			Message: exception.PackageNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": workspaceId},
+		}
+	}
+	if ent.Kind != entity.KIND_WORKSPACE {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.PackageIsNotWorkspace,
+			Message: exception.PackageIsNotWorkspaceMsg,
+			Params:  map[string]interface{}{"packageId": workspaceId},
+		}
+	}
+	return nil
+}
+
+func checkTenantQuota(workspaceId string, quota string, used int64, limit int64, status int) error {
+	if used < limit {
+		return nil
+	}
+	return &exception.CustomError{
+		Status:  status,
+		Code:    exception.TenantQuotaExceeded,
+		Message: exception.TenantQuotaExceededMsg,
+		Params:  map[string]interface{}{"quota": quota, "workspaceId": workspaceId, "used": used, "limit": limit},
+	}
+}
+
+func makeTenantQuotaUsage(used int64, limit *int64) view.TenantQuotaUsage {
+	return view.TenantQuotaUsage{
+		Used:     used,
+		Limit:    limit,
+		Exceeded: limit != nil && used >= *limit,
+	}
+}
+
+func intLimit(limit *int) *int64 {
+	if limit == nil {
+		return nil
+	}
+	result := int64(*limit)
+	return &result
+}
+
+func bytesToMb(size int64) int64 {
+	return (size + bytesInMb - 1) / bytesInMb
+}
+
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeTenantQuotaUsage(t *testing.T) {
+	limit := int64(10)
+
+	usage := makeTenantQuotaUsage(5, nil)
+	require.Equal(t, view.TenantQuotaUsage{Used: 5}, usage)
+
+	usage = makeTenantQuotaUsage(9, &limit)
+	require.False(t, usage.Exceeded)
+	require.Equal(t, limit, *usage.Limit)
+
+	usage = makeTenantQuotaUsage(10, &limit)
+	require.True(t, usage.Exceeded)
+
+	require.Nil(t, intLimit(nil))
+	maxPackages := 3
+	require.Equal(t, int64(3), *intLimit(&maxPackages))
+}
+
+func TestCheckTenantQuota(t *testing.T) {
+	require.NoError(t, checkTenantQuota("WS", view.TenantQuotaPackages, 2, 3, http.StatusForbidden))
+
+	err := checkTenantQuota("WS", view.TenantQuotaBuildsPerHour, 3, 3, http.StatusTooManyRequests)
+	var customErr *exception.CustomError
+	require.True(t, errors.As(err, &customErr))
+	require.Equal(t, http.StatusTooManyRequests, customErr.Status)
+	require.Equal(t, exception.TenantQuotaExceeded, customErr.Code)
+	require.Equal(t, view.TenantQuotaBuildsPerHour, customErr.Params["quota"])
+	require.Equal(t, "WS", customErr.Params["workspaceId"])
+}
+
+func TestTenantUsageUnits(t *testing.T) {
+	require.Equal(t, int64(0), bytesToMb(0))
+	require.Equal(t, int64(1), bytesToMb(1))
+	require.Equal(t, int64(1), bytesToMb(bytesInMb))
+	require.Equal(t, int64(2), bytesToMb(bytesInMb+1))
+
+	now := time.Date(2026, time.March, 17, 15, 4, 5, 0, time.UTC)
+	require.Equal(t, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), startOfMonth(now))
+}
@@ -33,6 +33,8 @@ type VersionService interface {
 	DeleteVersion(ctx context.SecurityContext, packageId string, versionName string) error
 	PatchVersion(ctx context.SecurityContext, packageId string, versionName string, status *string, versionLabels *[]string) (*view.VersionContent, error)
 	GetLatestContentDataBySlug(packageId string, versionName string, slug string) (*view.PublishedContent, *view.ContentData, error)
+	// GetContentCacheInfo returns the data identifying the published document for HTTP conditional requests without reading document data
+	GetContentCacheInfo(packageId string, versionName string, slug string) (*view.PublishedCacheInfo, error)
 	GetLatestDocumentBySlug(packageId string, versionName string, slug string) (*view.PublishedDocument, error)
 	GetLatestDocuments(packageId string, versionName string, skipRefs bool, filterReq view.DocumentsFilterReq) (*view.VersionDocuments, error)
 	GetSharedFile(sharedFileId string) ([]byte, string, error)
@@ -73,7 +75,8 @@ func NewVersionService(favoritesRepo repository.FavoritesRepository,
 	versionCleanupRepository repository.VersionCleanupRepository,
 	operationGroupService OperationGroupService,
 	monitoringService MonitoringService,
-	roleService RoleService) VersionService {
+	roleService RoleService,
+	aiSpecReviewRepo repository.AiSpecReviewRepository) VersionService {
 	return &versionServiceImpl{
 		favoritesRepo:                   favoritesRepo,
 		publishedRepo:                   publishedRepo,
@@ -89,6 +92,7 @@ func NewVersionService(favoritesRepo repository.FavoritesRepository,
 		operationGroupService:           operationGroupService,
 		monitoringService:               monitoringService,
 		roleService:                     roleService,
+		aiSpecReviewRepo:                aiSpecReviewRepo,
 	}
 }
 
@@ -108,6 +112,7 @@ type versionServiceImpl struct {
 	operationGroupService           OperationGroupService
 	monitoringService               MonitoringService
 	roleService                     RoleService
+	aiSpecReviewRepo                repository.AiSpecReviewRepository
 }
 
 func (v *versionServiceImpl) SetBuildService(buildService BuildService) {
@@ -391,6 +396,49 @@ func (v versionServiceImpl) GetLatestContentDataBySlug(packageId string, version
 	return entity.MakePublishedContentView(content), entity.MakeContentDataViewPub(content, pce), nil
 }
 
+func (v versionServiceImpl) GetContentCacheInfo(packageId string, versionName string, slug string) (*view.PublishedCacheInfo, error) {
+	ent, err := v.publishedRepo.GetVersion(packageId, versionName)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PublishedVersionNotFound,
+			Message: exception.PublishedVersionNotFoundMsg,
+			Params:  map[string]interface{}{"version": versionName},
+		}
+	}
+	content, err := v.publishedRepo.GetRevisionContentBySlug(packageId, ent.Version, slug, ent.Revision)
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.ContentSlugNotFound,
+			Message: exception.ContentSlugNotFoundMsg,
+			Params:  map[string]interface{}{"contentSlug": slug},
+		}
+	}
+	cacheInfo := makePublishedCacheInfo(ent, versionName, content.Checksum)
+	cacheInfo.Shareability = content.Shareability
+	return cacheInfo, nil
+}
+
+func makePublishedCacheInfo(versionEnt *entity.PublishedVersionEntity, requestedVersion string, dataHash string) *view.PublishedCacheInfo {
+	_, requestedRevision, _ := repository.SplitVersionRevision(requestedVersion)
+	return &view.PublishedCacheInfo{
+		PackageId:      versionEnt.PackageId,
+		Version:        versionEnt.Version,
+		Revision:       versionEnt.Revision,
+		Status:         versionEnt.Status,
+		PublishedAt:    versionEnt.PublishedAt,
+		DataHash:       dataHash,
+		RevisionPinned: requestedRevision != 0,
+	}
+}
+
 func (v versionServiceImpl) DeleteVersion(ctx context.SecurityContext, packageId string, versionName string) error {
 	version, revision, err := repository.SplitVersionRevision(versionName)
 	if err != nil {
@@ -1025,6 +1073,13 @@ func (p versionServiceImpl) GetVersionValidationProblems_deprecated(packageId st
 			spectral = versionProblems.Spectral.Data
 		}
 	}
+	aiSpecReviewNotifications, err := p.aiSpecReviewRepo.GetReviewNotifications(stdctx.Background(), packageId, version.Version, version.Revision)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range aiSpecReviewNotifications {
+		spectral = append(spectral, entity.MakeAiSpecReviewProblemView(n))
+	}
 	return &view.VersionValidationProblems_deprecated{
 		Spectral: spectral,
 	}, nil
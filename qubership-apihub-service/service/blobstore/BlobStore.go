@@ -0,0 +1,43 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNotFound             = errors.New("blob not found")
+	ErrNamespaceUnsupported = errors.New("namespace is not supported by the blob storage backend")
+)
+
+// BlobStore keeps large binary objects (build results, sources archives, etc.) grouped by namespace.
+// Keys are unique within a namespace.
+type BlobStore interface {
+	// Backend returns the backend name, one of view.BlobStorageBackend* values
+	Backend() string
+	SupportsNamespace(namespace string) bool
+	// Get returns ErrNotFound if there is no blob with the key in the namespace
+	Get(ctx context.Context, namespace string, key string) ([]byte, error)
+	Put(ctx context.Context, namespace string, key string, data []byte) error
+	// Delete ignores keys which do not exist
+	Delete(ctx context.Context, namespace string, keys []string) error
+	// List returns up to limit keys which are greater than afterKey in ascending order
+	List(ctx context.Context, namespace string, afterKey string, limit int) ([]string, error)
+}
+
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// validateKey makes sure that the key could be safely used as a file or object name
+func validateKey(key string) error {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, "/\\\x00") {
+		return fmt.Errorf("invalid blob key '%s'", key)
+	}
+	return nil
+}
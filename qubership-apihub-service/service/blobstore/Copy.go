@@ -0,0 +1,49 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type CopyResult int
+
+const (
+	CopyResultCopied CopyResult = iota
+	// CopyResultSkipped means that the target already contains the blob with the same hash
+	CopyResultSkipped
+	// CopyResultMissing means that the blob was removed from the source before it was copied
+	CopyResultMissing
+)
+
+// Copy copies the blob from source to target and verifies the copy by reading it back and comparing SHA-256 hashes
+func Copy(ctx context.Context, source BlobStore, target BlobStore, namespace string, key string) (CopyResult, int64, error) {
+	data, err := source.Get(ctx, namespace, key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return CopyResultMissing, 0, nil
+		}
+		return 0, 0, fmt.Errorf("failed to read blob from %s: %w", source.Backend(), err)
+	}
+	hash := Hash(data)
+
+	existing, err := target.Get(ctx, namespace, key)
+	if err == nil && Hash(existing) == hash {
+		return CopyResultSkipped, 0, nil
+	}
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return 0, 0, fmt.Errorf("failed to read blob from %s: %w", target.Backend(), err)
+	}
+
+	if err = target.Put(ctx, namespace, key, data); err != nil {
+		return 0, 0, fmt.Errorf("failed to write blob to %s: %w", target.Backend(), err)
+	}
+	stored, err := target.Get(ctx, namespace, key)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read blob from %s after copy: %w", target.Backend(), err)
+	}
+	if storedHash := Hash(stored); storedHash != hash {
+		return 0, 0, fmt.Errorf("hash mismatch after copy to %s: expected %s, got %s", target.Backend(), hash, storedHash)
+	}
+	return CopyResultCopied, int64(len(data)), nil
+}
@@ -0,0 +1,136 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+// NewFilesystemStore creates a store which keeps blobs as files rootDir/namespace/key.
+// rootDir could be a local directory or a mounted network file system (e.g. NFS) shared by all instances.
+func NewFilesystemStore(rootDir string, namespaces []string) BlobStore {
+	supported := make(map[string]bool, len(namespaces))
+	for _, ns := range namespaces {
+		supported[ns] = true
+	}
+	return &filesystemStore{rootDir: rootDir, namespaces: supported}
+}
+
+type filesystemStore struct {
+	rootDir    string
+	namespaces map[string]bool
+}
+
+func (f *filesystemStore) Backend() string {
+	return view.BlobStorageBackendFilesystem
+}
+
+func (f *filesystemStore) SupportsNamespace(namespace string) bool {
+	return f.namespaces[namespace]
+}
+
+func (f *filesystemStore) Get(ctx context.Context, namespace string, key string) ([]byte, error) {
+	path, err := f.path(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// Put writes the blob to a temporary file first and renames it, so readers never see partially written blobs
+func (f *filesystemStore) Put(ctx context.Context, namespace string, key string, data []byte) error {
+	path, err := f.path(namespace, key)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".tmp-"+key+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary blob file: %w", err)
+	}
+	tmpName := tmp.Name()
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpName, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to write blob file: %w", err)
+	}
+	return nil
+}
+
+func (f *filesystemStore) Delete(ctx context.Context, namespace string, keys []string) error {
+	for _, key := range keys {
+		path, err := f.path(namespace, key)
+		if err != nil {
+			return err
+		}
+		if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *filesystemStore) List(ctx context.Context, namespace string, afterKey string, limit int) ([]string, error) {
+	if !f.namespaces[namespace] {
+		return nil, ErrNamespaceUnsupported
+	}
+	entries, err := os.ReadDir(filepath.Join(f.rootDir, namespace))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	keys := make([]string, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name <= afterKey || validateKey(name) != nil || isTemporaryFile(name) {
+			continue
+		}
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys, nil
+}
+
+func (f *filesystemStore) path(namespace string, key string) (string, error) {
+	if !f.namespaces[namespace] {
+		return "", ErrNamespaceUnsupported
+	}
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(f.rootDir, namespace, key), nil
+}
+
+func isTemporaryFile(name string) bool {
+	return strings.HasPrefix(name, ".tmp-")
+}
@@ -0,0 +1,88 @@
+package blobstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testNamespace = "build_result"
+
+func TestFilesystemStorePutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	store := NewFilesystemStore(t.TempDir(), []string{testNamespace})
+
+	_, err := store.Get(ctx, testNamespace, "build-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Put(ctx, testNamespace, "build-1", []byte("data-1")))
+	require.NoError(t, store.Put(ctx, testNamespace, "build-1", []byte("data-2")))
+	data, err := store.Get(ctx, testNamespace, "build-1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data-2"), data)
+
+	require.NoError(t, store.Delete(ctx, testNamespace, []string{"build-1", "missing"}))
+	_, err = store.Get(ctx, testNamespace, "build-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestFilesystemStoreList(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store := NewFilesystemStore(root, []string{testNamespace})
+
+	keys, err := store.List(ctx, testNamespace, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	for _, key := range []string{"c", "a", "b", "d"} {
+		require.NoError(t, store.Put(ctx, testNamespace, key, []byte(key)))
+	}
+	// leftover of an interrupted write must not be listed
+	require.NoError(t, os.WriteFile(filepath.Join(root, testNamespace, ".tmp-e"), []byte("e"), 0o600))
+
+	keys, err = store.List(ctx, testNamespace, "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, keys)
+	keys, err = store.List(ctx, testNamespace, "b", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "d"}, keys)
+}
+
+func TestFilesystemStoreInvalidKeys(t *testing.T) {
+	ctx := context.Background()
+	store := NewFilesystemStore(t.TempDir(), []string{testNamespace})
+
+	for _, key := range []string{"", ".", "..", "../escape", "a/b", "a\\b"} {
+		assert.Error(t, store.Put(ctx, testNamespace, key, []byte("data")), "key %q", key)
+	}
+	assert.ErrorIs(t, store.Put(ctx, "unknown", "key", []byte("data")), ErrNamespaceUnsupported)
+	assert.False(t, store.SupportsNamespace("unknown"))
+}
+
+func TestCopy(t *testing.T) {
+	ctx := context.Background()
+	source := NewFilesystemStore(t.TempDir(), []string{testNamespace})
+	target := NewFilesystemStore(t.TempDir(), []string{testNamespace})
+	require.NoError(t, source.Put(ctx, testNamespace, "build-1", []byte("data")))
+
+	result, size, err := Copy(ctx, source, target, testNamespace, "build-1")
+	require.NoError(t, err)
+	assert.Equal(t, CopyResultCopied, result)
+	assert.Equal(t, int64(4), size)
+	data, err := target.Get(ctx, testNamespace, "build-1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+
+	result, _, err = Copy(ctx, source, target, testNamespace, "build-1")
+	require.NoError(t, err)
+	assert.Equal(t, CopyResultSkipped, result)
+
+	result, _, err = Copy(ctx, source, target, testNamespace, "missing")
+	require.NoError(t, err)
+	assert.Equal(t, CopyResultMissing, result)
+}
@@ -0,0 +1,60 @@
+package blobstore
+
+import (
+	"context"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+// NewPostgresStore creates a store which keeps blobs in the data columns of the database tables the blobs belong to
+func NewPostgresStore(blobRepository repository.BlobRepository) BlobStore {
+	return &postgresStore{blobRepository: blobRepository}
+}
+
+type postgresStore struct {
+	blobRepository repository.BlobRepository
+}
+
+func (p *postgresStore) Backend() string {
+	return view.BlobStorageBackendPostgres
+}
+
+func (p *postgresStore) SupportsNamespace(namespace string) bool {
+	return p.blobRepository.IsBlobTable(namespace)
+}
+
+func (p *postgresStore) Get(ctx context.Context, namespace string, key string) ([]byte, error) {
+	if !p.SupportsNamespace(namespace) {
+		return nil, ErrNamespaceUnsupported
+	}
+	data, err := p.blobRepository.GetBlob(ctx, namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, ErrNotFound
+	}
+	return data, nil
+}
+
+func (p *postgresStore) Put(ctx context.Context, namespace string, key string, data []byte) error {
+	if !p.SupportsNamespace(namespace) {
+		return ErrNamespaceUnsupported
+	}
+	return p.blobRepository.PutBlob(ctx, namespace, key, data)
+}
+
+func (p *postgresStore) Delete(ctx context.Context, namespace string, keys []string) error {
+	if !p.SupportsNamespace(namespace) {
+		return ErrNamespaceUnsupported
+	}
+	return p.blobRepository.DeleteBlobs(ctx, namespace, keys)
+}
+
+func (p *postgresStore) List(ctx context.Context, namespace string, afterKey string, limit int) ([]string, error) {
+	if !p.SupportsNamespace(namespace) {
+		return nil, ErrNamespaceUnsupported
+	}
+	return p.blobRepository.ListBlobKeys(ctx, namespace, afterKey, limit)
+}
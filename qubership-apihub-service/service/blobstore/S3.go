@@ -0,0 +1,204 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	log "github.com/sirupsen/logrus"
+)
+
+// NewS3Store creates a store which keeps blobs as objects namespace/key.zip in the configured bucket.
+// The object key format is compatible with the files uploaded by MinioStorageService.
+func NewS3Store(creds *view.MinioStorageCreds, namespaces []string) BlobStore {
+	supported := make(map[string]bool, len(namespaces))
+	for _, ns := range namespaces {
+		supported[ns] = true
+	}
+	client, err := NewMinioClient(creds)
+	return &s3Store{client: client, clientErr: err, bucketName: creds.BucketName, namespaces: supported}
+}
+
+type s3Store struct {
+	client     *minio.Client
+	clientErr  error
+	bucketName string
+	namespaces map[string]bool
+}
+
+func (s *s3Store) Backend() string {
+	return view.BlobStorageBackendS3
+}
+
+func (s *s3Store) SupportsNamespace(namespace string) bool {
+	return s.namespaces[namespace]
+}
+
+func (s *s3Store) Get(ctx context.Context, namespace string, key string) ([]byte, error) {
+	objectName, err := s.objectName(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	object, err := s.client.GetObject(ctx, s.bucketName, objectName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	defer object.Close()
+	data, err := io.ReadAll(object)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return data, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, namespace string, key string, data []byte) error {
+	objectName, err := s.objectName(namespace, key)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	_, err = s.client.PutObject(ctx, s.bucketName, objectName, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{})
+	utils.PerfLog(time.Since(start).Milliseconds(), 500, "S3 blob store: put object "+objectName)
+	return err
+}
+
+func (s *s3Store) Delete(ctx context.Context, namespace string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	objectNames := make([]string, 0, len(keys))
+	for _, key := range keys {
+		objectName, err := s.objectName(namespace, key)
+		if err != nil {
+			return err
+		}
+		objectNames = append(objectNames, objectName)
+	}
+	objectsChan := make(chan minio.ObjectInfo, len(objectNames))
+	for _, objectName := range objectNames {
+		objectsChan <- minio.ObjectInfo{Key: objectName}
+	}
+	close(objectsChan)
+	errMsg := make([]string, 0)
+	for removeError := range s.client.RemoveObjects(ctx, s.bucketName, objectsChan, minio.RemoveObjectsOptions{}) {
+		errMsg = append(errMsg, removeError.Err.Error())
+	}
+	if len(errMsg) > 0 {
+		return errors.New(strings.Join(errMsg, ". "))
+	}
+	return nil
+}
+
+func (s *s3Store) List(ctx context.Context, namespace string, afterKey string, limit int) ([]string, error) {
+	if !s.namespaces[namespace] {
+		return nil, ErrNamespaceUnsupported
+	}
+	if s.clientErr != nil {
+		return nil, s.clientErr
+	}
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	opts := minio.ListObjectsOptions{Prefix: namespace + "/"}
+	if afterKey != "" {
+		opts.StartAfter = makeObjectName(namespace, afterKey)
+	}
+	keys := make([]string, 0)
+	for object := range s.client.ListObjects(listCtx, s.bucketName, opts) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		key := strings.TrimSuffix(strings.TrimPrefix(object.Key, namespace+"/"), ".zip")
+		if validateKey(key) != nil {
+			continue
+		}
+		keys = append(keys, key)
+		if limit > 0 && len(keys) >= limit {
+			break
+		}
+	}
+	return keys, nil
+}
+
+func (s *s3Store) objectName(namespace string, key string) (string, error) {
+	if !s.namespaces[namespace] {
+		return "", ErrNamespaceUnsupported
+	}
+	if s.clientErr != nil {
+		return "", s.clientErr
+	}
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return makeObjectName(namespace, key), nil
+}
+
+func (s *s3Store) mapError(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrNotFound
+	}
+	return err
+}
+
+func makeObjectName(namespace string, key string) string {
+	return fmt.Sprintf("%s/%s.zip", namespace, key)
+}
+
+func NewMinioClient(creds *view.MinioStorageCreds) (*minio.Client, error) {
+	tr, err := minio.DefaultTransport(true)
+	if err != nil {
+		log.Warnf("error creating the minio connection: error creating the default transport layer: %v", err)
+		return nil, err
+	}
+	crt, err := os.CreateTemp("", "minio.cert")
+	if err != nil {
+		log.Warn(err.Error())
+		return nil, err
+	}
+	decodeSamlCert, err := base64.StdEncoding.DecodeString(creds.Crt)
+	if err != nil {
+		log.Warn(err.Error())
+		return nil, err
+	}
+
+	_, err = crt.WriteString(string(decodeSamlCert))
+	rootCAs := mustGetSystemCertPool()
+	data, err := os.ReadFile(crt.Name())
+	if err == nil {
+		rootCAs.AppendCertsFromPEM(data)
+	}
+	tr.TLSClientConfig.RootCAs = rootCAs
+
+	minioClient, err := minio.New(creds.Endpoint, &minio.Options{
+		Creds:     credentials.NewStaticV4(creds.AccessKeyId, creds.SecretAccessKey, ""),
+		Secure:    true,
+		Transport: tr,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "endpoint") {
+			err = errors.New("invalid storage URL")
+		}
+		log.Warn(err.Error())
+		return nil, err
+	}
+	log.Infof("MINIO instance initialized")
+	return minioClient, nil
+}
+
+func mustGetSystemCertPool() *x509.CertPool {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		return x509.NewCertPool()
+	}
+	return pool
+}
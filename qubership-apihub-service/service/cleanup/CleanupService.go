@@ -1,6 +1,8 @@
 package cleanup
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
@@ -9,6 +11,7 @@ import (
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
 	"github.com/robfig/cron/v3"
 	log "github.com/sirupsen/logrus"
 )
@@ -21,23 +24,29 @@ const (
 
 type CleanupService interface {
 	ClearTestData(testId string) error
-	CreateRevisionsCleanupJob(publishedRepo repository.PublishedRepository, migrationRepository mRepository.MigrationRunRepository, versionCleanupRepo repository.VersionCleanupRepository, monitoringService service.MonitoringService, lockService service.LockService, instanceId string, schedule string, deleteLastRevision bool, deleteReleaseRevision bool, ttl int) error
-	CreateComparisonsCleanupJob(publishedRepo repository.PublishedRepository, migrationRepository mRepository.MigrationRunRepository, comparisonCleanupRepo repository.ComparisonCleanupRepository, lockService service.LockService, instanceId string, schedule string, timeoutMinutes int, ttl int) error
-	CreateSoftDeletedDataCleanupJob(publishedRepo repository.PublishedRepository, migrationRepository mRepository.MigrationRunRepository, deletedDataCleanupRepo repository.SoftDeletedDataCleanupRepository, lockService service.LockService, instanceId string, schedule string, timeoutMinutes int, ttl int) error
-	CreateUnreferencedDataCleanupJob(migrationRepository mRepository.MigrationRunRepository, unreferencedDataCleanupRepo repository.UnreferencedDataCleanupRepository, lockService service.LockService, instanceId string, schedule string, timeoutMinutes int) error
-	CreateMaintenanceVacuumCleanupJob(migrationRepository mRepository.MigrationRunRepository, lockService service.LockService, instanceId string, schedule string, timeoutMinutes int) error
+	CreateRevisionsCleanupJob(publishedRepo repository.PublishedRepository, migrationRepository mRepository.MigrationRunRepository, versionCleanupRepo repository.VersionCleanupRepository, monitoringService service.MonitoringService, instanceId string, schedule string, deleteLastRevision bool, deleteReleaseRevision bool, ttl int) error
+	CreateComparisonsCleanupJob(publishedRepo repository.PublishedRepository, migrationRepository mRepository.MigrationRunRepository, comparisonCleanupRepo repository.ComparisonCleanupRepository, instanceId string, schedule string, timeoutMinutes int, ttl int) error
+	CreateSoftDeletedDataCleanupJob(publishedRepo repository.PublishedRepository, migrationRepository mRepository.MigrationRunRepository, deletedDataCleanupRepo repository.SoftDeletedDataCleanupRepository, instanceId string, schedule string, timeoutMinutes int, ttl int) error
+	CreateUnreferencedDataCleanupJob(migrationRepository mRepository.MigrationRunRepository, unreferencedDataCleanupRepo repository.UnreferencedDataCleanupRepository, instanceId string, schedule string, timeoutMinutes int) error
+	CreateMaintenanceVacuumCleanupJob(migrationRepository mRepository.MigrationRunRepository, instanceId string, schedule string, timeoutMinutes int) error
+	StartDryRun(ctx context.Context, jobType string, packageId string, userId string) (*view.CleanupDryRun, error)
+	GetDryRun(ctx context.Context, runId string) (*view.CleanupDryRun, error)
+	GetJobRuns(ctx context.Context, req view.CleanupJobRunsReq) (*view.CleanupJobRuns, error)
 }
 
-func NewCleanupService(cp db.ConnectionProvider) CleanupService {
-	return &cleanupServiceImpl{cp: cp, cron: cron.New()}
+func NewCleanupService(cp db.ConnectionProvider, retentionRepository repository.CleanupRetentionRepository, jobRepository repository.CleanupJobRepository, backgroundJobService service.BackgroundJobService) CleanupService {
+	return &cleanupServiceImpl{cp: cp, retentionRepository: retentionRepository, jobRepository: jobRepository, backgroundJobService: backgroundJobService, jobs: make(map[jobType]*JobRunner)}
 }
 
 type cleanupServiceImpl struct {
-	cp   db.ConnectionProvider
-	cron *cron.Cron
+	cp                   db.ConnectionProvider
+	retentionRepository  repository.CleanupRetentionRepository
+	jobRepository        repository.CleanupJobRepository
+	backgroundJobService service.BackgroundJobService
+	jobs                 map[jobType]*JobRunner
 }
 
-func (c cleanupServiceImpl) ClearTestData(testId string) error {
+func (c *cleanupServiceImpl) ClearTestData(testId string) error {
 	idFilter := "QS%-" + utils.LikeEscaped(testId) + "%"
 	//clear tables: package_group
 	_, err := c.cp.GetConnection().Model(&entity.PackageEntity{}).
@@ -138,7 +147,7 @@ func (c cleanupServiceImpl) ClearTestData(testId string) error {
 	return nil
 }
 
-func (c cleanupServiceImpl) CreateRevisionsCleanupJob(publishedRepository repository.PublishedRepository, migrationRepository mRepository.MigrationRunRepository, versionCleanupRepository repository.VersionCleanupRepository, monitoringService service.MonitoringService, lockService service.LockService, instanceId string, schedule string, deleteLastRevision bool, deleteReleaseRevision bool, ttl int) error {
+func (c *cleanupServiceImpl) CreateRevisionsCleanupJob(publishedRepository repository.PublishedRepository, migrationRepository mRepository.MigrationRunRepository, versionCleanupRepository repository.VersionCleanupRepository, monitoringService service.MonitoringService, instanceId string, schedule string, deleteLastRevision bool, deleteReleaseRevision bool, ttl int) error {
 	timeout := c.calculateCleanupJobTimeout(schedule, revisionsCleanup)
 	config := jobConfig{
 		jobType:    revisionsCleanup,
@@ -149,21 +158,20 @@ func (c cleanupServiceImpl) CreateRevisionsCleanupJob(publishedRepository reposi
 	processor := NewRevisionsCleanupJobProcessor(
 		publishedRepository,
 		versionCleanupRepository,
+		c.retentionRepository,
 		monitoringService,
 		deleteLastRevision,
 		deleteReleaseRevision,
 	)
 	runner := &JobRunner{
-		cp:                  c.cp,
 		migrationRepository: migrationRepository,
-		lockService:         lockService,
 		config:              config,
 		processor:           processor,
 	}
-	return c.addCleanupJob(runner, schedule, revisionsCleanup)
+	return c.addCleanupJob(runner, schedule, fmt.Sprintf("Deletes revisions older than %d days unless a retention policy of the package overrides it", ttl))
 }
 
-func (c cleanupServiceImpl) calculateCleanupJobTimeout(schedule string, jobType jobType) time.Duration {
+func (c *cleanupServiceImpl) calculateCleanupJobTimeout(schedule string, jobType jobType) time.Duration {
 	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
 
 	sched, err := parser.Parse(schedule)
@@ -190,7 +198,7 @@ func (c cleanupServiceImpl) calculateCleanupJobTimeout(schedule string, jobType
 	return c.limitCleanupJobTimeout(jobType, timeout)
 }
 
-func (c cleanupServiceImpl) limitCleanupJobTimeout(jobType jobType, timeout time.Duration) time.Duration {
+func (c *cleanupServiceImpl) limitCleanupJobTimeout(jobType jobType, timeout time.Duration) time.Duration {
 	if jobType == revisionsCleanup && timeout > maxRevisionsJobTimeout {
 		log.Infof("Capping timeout for %s cleanup job from %v to %v", jobType, timeout, maxRevisionsJobTimeout)
 		return maxRevisionsJobTimeout
@@ -198,7 +206,7 @@ func (c cleanupServiceImpl) limitCleanupJobTimeout(jobType jobType, timeout time
 	return timeout
 }
 
-func (c cleanupServiceImpl) CreateComparisonsCleanupJob(publishedRepo repository.PublishedRepository, migrationRepository mRepository.MigrationRunRepository, comparisonCleanupRepo repository.ComparisonCleanupRepository, lockService service.LockService, instanceId string, schedule string, timeoutMinutes int, ttl int) error {
+func (c *cleanupServiceImpl) CreateComparisonsCleanupJob(publishedRepo repository.PublishedRepository, migrationRepository mRepository.MigrationRunRepository, comparisonCleanupRepo repository.ComparisonCleanupRepository, instanceId string, schedule string, timeoutMinutes int, ttl int) error {
 	timeout := time.Duration(timeoutMinutes) * time.Minute
 	config := jobConfig{
 		jobType:    comparisonsCleanup,
@@ -209,18 +217,17 @@ func (c cleanupServiceImpl) CreateComparisonsCleanupJob(publishedRepo repository
 	processor := NewComparisonsCleanupJobProcessor(
 		publishedRepo,
 		comparisonCleanupRepo,
+		c.retentionRepository,
 	)
 	runner := &JobRunner{
-		cp:                  c.cp,
 		migrationRepository: migrationRepository,
-		lockService:         lockService,
 		config:              config,
 		processor:           processor,
 	}
-	return c.addCleanupJob(runner, schedule, comparisonsCleanup)
+	return c.addCleanupJob(runner, schedule, fmt.Sprintf("Deletes irrelevant comparisons and ad-hoc comparisons older than %d days", ttl))
 }
 
-func (c cleanupServiceImpl) CreateSoftDeletedDataCleanupJob(publishedRepo repository.PublishedRepository, migrationRepository mRepository.MigrationRunRepository, deletedDataCleanupRepo repository.SoftDeletedDataCleanupRepository, lockService service.LockService, instanceId string, schedule string, timeoutMinutes int, ttl int) error {
+func (c *cleanupServiceImpl) CreateSoftDeletedDataCleanupJob(publishedRepo repository.PublishedRepository, migrationRepository mRepository.MigrationRunRepository, deletedDataCleanupRepo repository.SoftDeletedDataCleanupRepository, instanceId string, schedule string, timeoutMinutes int, ttl int) error {
 	timeout := time.Duration(timeoutMinutes) * time.Minute
 	config := jobConfig{
 		jobType:    deletedDataCleanup,
@@ -233,16 +240,14 @@ func (c cleanupServiceImpl) CreateSoftDeletedDataCleanupJob(publishedRepo reposi
 		deletedDataCleanupRepo,
 	)
 	runner := &JobRunner{
-		cp:                  c.cp,
 		migrationRepository: migrationRepository,
-		lockService:         lockService,
 		config:              config,
 		processor:           processor,
 	}
-	return c.addCleanupJob(runner, schedule, deletedDataCleanup)
+	return c.addCleanupJob(runner, schedule, fmt.Sprintf("Permanently deletes data soft deleted more than %d days ago", ttl))
 }
 
-func (c cleanupServiceImpl) CreateUnreferencedDataCleanupJob(migrationRepository mRepository.MigrationRunRepository, unreferencedDataCleanupRepo repository.UnreferencedDataCleanupRepository, lockService service.LockService, instanceId string, schedule string, timeoutMinutes int) error {
+func (c *cleanupServiceImpl) CreateUnreferencedDataCleanupJob(migrationRepository mRepository.MigrationRunRepository, unreferencedDataCleanupRepo repository.UnreferencedDataCleanupRepository, instanceId string, schedule string, timeoutMinutes int) error {
 	timeout := time.Duration(timeoutMinutes) * time.Minute
 	config := jobConfig{
 		jobType:    unreferencedDataCleanup,
@@ -254,16 +259,14 @@ func (c cleanupServiceImpl) CreateUnreferencedDataCleanupJob(migrationRepository
 		unreferencedDataCleanupRepo,
 	)
 	runner := &JobRunner{
-		cp:                  c.cp,
 		migrationRepository: migrationRepository,
-		lockService:         lockService,
 		config:              config,
 		processor:           processor,
 	}
-	return c.addCleanupJob(runner, schedule, unreferencedDataCleanup)
+	return c.addCleanupJob(runner, schedule, "Deletes data which is no longer referenced by any published version")
 }
 
-func (c cleanupServiceImpl) CreateMaintenanceVacuumCleanupJob(migrationRepository mRepository.MigrationRunRepository, lockService service.LockService, instanceId string, schedule string, timeoutMinutes int) error {
+func (c *cleanupServiceImpl) CreateMaintenanceVacuumCleanupJob(migrationRepository mRepository.MigrationRunRepository, instanceId string, schedule string, timeoutMinutes int) error {
 	config := jobConfig{
 		jobType:    maintenanceVacuum,
 		instanceId: instanceId,
@@ -272,30 +275,33 @@ func (c cleanupServiceImpl) CreateMaintenanceVacuumCleanupJob(migrationRepositor
 	}
 	processor := NewMaintenanceVacuumCleanupJobProcessor(c.cp, timeoutMinutes)
 	runner := &JobRunner{
-		cp:                  c.cp,
 		migrationRepository: migrationRepository,
-		lockService:         lockService,
 		config:              config,
 		processor:           processor,
 	}
-	return c.addCleanupJob(runner, schedule, maintenanceVacuum)
+	return c.addCleanupJob(runner, schedule, "Executes VACUUM FULL ANALYZE for eligible public tables")
 }
 
-func (c cleanupServiceImpl) addCleanupJob(job cron.Job, schedule string, jobType jobType) error {
-	if len(c.cron.Entries()) == 0 {
-		location, err := time.LoadLocation("")
-		if err != nil {
-			return err
-		}
-		c.cron = cron.New(cron.WithLocation(location))
-		c.cron.Start()
+func (c *cleanupServiceImpl) addCleanupJob(job *JobRunner, schedule string, description string) error {
+	jobType := job.config.jobType
+	if _, supported := job.processor.(DryRunProcessor); supported {
+		description += ", dry run is supported"
 	}
-	wrappedJob := cron.NewChain(cron.SkipIfStillRunning(cron.DefaultLogger)).Then(job)
-	_, err := c.cron.AddJob(schedule, wrappedJob)
+	err := c.backgroundJobService.RegisterJob(service.BackgroundJobDefinition{
+		Name:        getJobName(jobType),
+		Description: description,
+		Schedule:    schedule,
+		Distributed: true,
+		LockName:    lockName,
+		// the configured timeout is used for the main stage of the job, an additional timeout is applied for VACUUM FULL on the affected tables
+		Timeout: job.config.timeout + job.processor.GetVacuumTimeout(),
+		Job:     job,
+	})
 	if err != nil {
 		log.Warnf("%s job wasn't added for schedule - %s. With error - %s", jobType, schedule, err)
 		return err
 	}
+	c.jobs[jobType] = job
 	log.Infof("%s job was created with schedule - %s", jobType, schedule)
 
 	return nil
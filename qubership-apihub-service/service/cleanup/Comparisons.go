@@ -3,11 +3,13 @@ package cleanup
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service/cleanup/logger"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
 )
 
 const (
@@ -18,15 +20,18 @@ const (
 type comparisonsCleanupJobProcessor struct {
 	publishedRepository   repository.PublishedRepository
 	comparisonCleanupRepo repository.ComparisonCleanupRepository
+	retentionRepository   repository.CleanupRetentionRepository
 }
 
 func NewComparisonsCleanupJobProcessor(
 	publishedRepository repository.PublishedRepository,
 	comparisonCleanupRepo repository.ComparisonCleanupRepository,
+	retentionRepository repository.CleanupRetentionRepository,
 ) JobProcessor {
 	return &comparisonsCleanupJobProcessor{
 		publishedRepository:   publishedRepository,
 		comparisonCleanupRepo: comparisonCleanupRepo,
+		retentionRepository:   retentionRepository,
 	}
 }
 
@@ -50,6 +55,13 @@ func (p *comparisonsCleanupJobProcessor) Process(ctx context.Context, jobId stri
 
 	page, limit := 0, comparisonsPageSize
 	var errors []string
+	now := time.Now()
+	policies, err := loadRetentionPolicies(ctx, p.retentionRepository, view.RetentionDataClassComparisons)
+	if err != nil {
+		logger.Errorf(ctx, "Error getting retention policies: %v", err)
+		errors = append(errors, fmt.Sprintf("Error getting retention policies: %v", err))
+		return errors, err
+	}
 	comparisonCount := 0
 	lastUpdateCount := *deletedItems
 
@@ -83,20 +95,10 @@ func (p *comparisonsCleanupJobProcessor) Process(ctx context.Context, jobId stri
 			default:
 			}
 
-			deleteCandidate := false
-			if candidate.RevisionNotPublished {
-				logger.Tracef(ctx, "Deleting comparison %s because revision is not published", candidate.ComparisonId)
-				deleteCandidate = true
-			} else if candidate.LastActive.Before(deleteBefore) && (candidate.ActualPreviousVersion == nil || candidate.ActualPreviousPackageId == nil ||
-				*candidate.ActualPreviousVersion != candidate.PreviousVersion || *candidate.ActualPreviousPackageId != candidate.PreviousPackageId) {
-				logger.Tracef(ctx, "Comparison %s is ad-hoc, deleting", candidate.ComparisonId)
-				deleteCandidate = true
-			} else if candidate.ActualPreviousPackageId != nil && candidate.ActualPreviousVersion != nil &&
-				candidate.PreviousPackageId == *candidate.ActualPreviousPackageId &&
-				candidate.PreviousVersion == *candidate.ActualPreviousVersion &&
-				candidate.PreviousRevision != candidate.PreviousMaxRevision {
-				logger.Tracef(ctx, "Comparison %s is not actual changelog, deleting", candidate.ComparisonId)
-				deleteCandidate = true
+			reason := comparisonCleanupReason(candidate, policies.deleteBefore(candidate.PackageId, now, deleteBefore))
+			deleteCandidate := reason != ""
+			if deleteCandidate {
+				logger.Tracef(ctx, "Deleting comparison %s: %s", candidate.ComparisonId, reason)
 			}
 
 			if deleteCandidate {
@@ -155,3 +157,74 @@ func (p *comparisonsCleanupJobProcessor) PerformVacuum(ctx context.Context, jobI
 	}
 	return nil
 }
+
+// comparisonCleanupReason returns the reason why the comparison must be deleted, or empty string if it must be kept
+func comparisonCleanupReason(candidate entity.VersionComparisonCleanupCandidateEntity, deleteBefore time.Time) string {
+	if candidate.RevisionNotPublished {
+		return "revision is not published"
+	}
+	if candidate.Saved {
+		return ""
+	}
+	if candidate.LastActive.Before(deleteBefore) && (candidate.ActualPreviousVersion == nil || candidate.ActualPreviousPackageId == nil ||
+		*candidate.ActualPreviousVersion != candidate.PreviousVersion || *candidate.ActualPreviousPackageId != candidate.PreviousPackageId) {
+		return "ad-hoc comparison was not used since retention threshold"
+	}
+	if candidate.ActualPreviousPackageId != nil && candidate.ActualPreviousVersion != nil &&
+		candidate.PreviousPackageId == *candidate.ActualPreviousPackageId &&
+		candidate.PreviousVersion == *candidate.ActualPreviousVersion &&
+		candidate.PreviousRevision != candidate.PreviousMaxRevision {
+		return "comparison is not actual changelog"
+	}
+	return ""
+}
+
+func (p *comparisonsCleanupJobProcessor) DryRun(ctx context.Context, packageId string, deleteBefore time.Time, report *dryRunReportBuilder) error {
+	now := time.Now()
+	policies, err := loadRetentionPolicies(ctx, p.retentionRepository, view.RetentionDataClassComparisons)
+	if err != nil {
+		return fmt.Errorf("failed to get retention policies: %s", err.Error())
+	}
+	page, limit := 0, comparisonsPageSize
+	for {
+		if ctx.Err() != nil {
+			return fmt.Errorf("dry run interrupted - %s", getContextCancellationMessage(ctx))
+		}
+		candidates, err := p.publishedRepository.GetVersionComparisonsCleanupCandidates(ctx, limit, page*limit)
+		if err != nil {
+			return fmt.Errorf("failed to get comparison candidates: %s", err.Error())
+		}
+		if len(candidates) == 0 {
+			return nil
+		}
+		for _, candidate := range candidates {
+			if packageId != "" && candidate.PackageId != packageId && !strings.HasPrefix(candidate.PackageId, packageId+".") {
+				continue
+			}
+			reason := comparisonCleanupReason(candidate, policies.deleteBefore(candidate.PackageId, now, deleteBefore))
+			if reason == "" {
+				continue
+			}
+			info, err := p.publishedRepository.GetVersionComparisonCleanupInfo(ctx, candidate.ComparisonId)
+			if err != nil {
+				return err
+			}
+			if info.Referenced {
+				continue //the job doesn't delete comparisons referenced by other comparisons
+			}
+			report.add(view.CleanupDryRunItem{
+				Kind:              view.CleanupItemKindComparison,
+				PackageId:         candidate.PackageId,
+				Version:           candidate.Version,
+				Revision:          candidate.Revision,
+				ComparisonId:      candidate.ComparisonId,
+				PreviousPackageId: candidate.PreviousPackageId,
+				PreviousVersion:   candidate.PreviousVersion,
+				PreviousRevision:  candidate.PreviousRevision,
+				Reason:            reason,
+				Bytes:             info.Bytes,
+			})
+		}
+		page++
+	}
+}
@@ -0,0 +1,128 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service/cleanup/logger"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/google/uuid"
+)
+
+const (
+	dryRunTimeout        = 4 * time.Hour
+	dryRunMaxReportItems = 10000
+	dryRunsRetentionDays = 30
+)
+
+// dryRunReportBuilder accumulates totals over all items, but keeps only the first dryRunMaxReportItems items in the report
+type dryRunReportBuilder struct {
+	report view.CleanupDryRunReport
+}
+
+func newDryRunReportBuilder(deleteBefore time.Time) *dryRunReportBuilder {
+	return &dryRunReportBuilder{
+		report: view.CleanupDryRunReport{
+			DeleteBefore: deleteBefore,
+			Items:        make([]view.CleanupDryRunItem, 0),
+		},
+	}
+}
+
+func (b *dryRunReportBuilder) add(item view.CleanupDryRunItem) {
+	b.report.TotalItems++
+	b.report.BytesToFree += item.Bytes
+	if len(b.report.Items) >= dryRunMaxReportItems {
+		b.report.ItemsTruncated = true
+		return
+	}
+	b.report.Items = append(b.report.Items, item)
+}
+
+func (c *cleanupServiceImpl) StartDryRun(ctx context.Context, apiJobType string, packageId string, userId string) (*view.CleanupDryRun, error) {
+	runner, err := c.getJobRunner(apiJobType)
+	if err != nil {
+		return nil, err
+	}
+	processor, supported := runner.processor.(DryRunProcessor)
+	if !supported {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.CleanupDryRunNotSupported,
+			Message: exception.CleanupDryRunNotSupportedMsg,
+			Params:  map[string]interface{}{"jobType": apiJobType},
+		}
+	}
+
+	now := time.Now()
+	if err = c.retentionRepository.DeleteDryRunsBefore(ctx, now.AddDate(0, 0, -dryRunsRetentionDays)); err != nil {
+		return nil, err
+	}
+	ent := entity.CleanupDryRunEntity{
+		RunId:     uuid.New().String(),
+		JobType:   apiJobType,
+		PackageId: packageId,
+		Status:    view.CleanupDryRunStatusRunning,
+		CreatedBy: userId,
+		StartedAt: now,
+	}
+	if err = c.retentionRepository.CreateDryRun(ctx, &ent); err != nil {
+		return nil, err
+	}
+	deleteBefore := now.AddDate(0, 0, -runner.config.ttl)
+	utils.SafeAsync(func() {
+		c.runDryRun(ent, runner.config.jobType, processor, deleteBefore)
+	})
+	result := entity.MakeCleanupDryRunView(ent)
+	return &result, nil
+}
+
+func (c *cleanupServiceImpl) runDryRun(ent entity.CleanupDryRunEntity, jt jobType, processor DryRunProcessor, deleteBefore time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), dryRunTimeout)
+	defer cancel()
+	ctx = context.WithValue(ctx, "jobType", fmt.Sprintf("%s dry run", jt))
+	ctx = context.WithValue(ctx, "jobId", ent.RunId)
+
+	logger.Infof(ctx, "Starting dry run for package '%s'", ent.PackageId)
+	report := newDryRunReportBuilder(deleteBefore)
+	err := processor.DryRun(ctx, ent.PackageId, deleteBefore, report)
+	finishedAt := time.Now()
+	ent.FinishedAt = &finishedAt
+	ent.Report = &report.report
+	if err != nil {
+		logger.Warnf(ctx, "Dry run failed: %v", err)
+		ent.Status = view.CleanupDryRunStatusError
+		ent.Details = formatErrorMessage(err.Error())
+	} else {
+		ent.Status = view.CleanupDryRunStatusComplete
+		logger.Infof(ctx, "Dry run finished: %d items, %d bytes", report.report.TotalItems, report.report.BytesToFree)
+	}
+
+	updateCtx, updateCancel := createContextForUpdate(ctx)
+	defer updateCancel()
+	if err = c.retentionRepository.UpdateDryRun(updateCtx, &ent); err != nil {
+		logger.Errorf(ctx, "Failed to save dry run result: %v", err)
+	}
+}
+
+func (c *cleanupServiceImpl) GetDryRun(ctx context.Context, runId string) (*view.CleanupDryRun, error) {
+	ent, err := c.retentionRepository.GetDryRun(ctx, runId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.CleanupDryRunNotFound,
+			Message: exception.CleanupDryRunNotFoundMsg,
+			Params:  map[string]interface{}{"runId": runId},
+		}
+	}
+	result := entity.MakeCleanupDryRunView(*ent)
+	return &result, nil
+}
@@ -0,0 +1,84 @@
+package cleanup
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+// apiJobTypes lists cleanup job types as exposed via API, in the order they are listed, with the names of their background jobs
+var apiJobTypes = []struct {
+	apiJobType string
+	jobType    jobType
+	jobName    string
+}{
+	{view.CleanupJobTypeRevisions, revisionsCleanup, "revisionsCleanup"},
+	{view.CleanupJobTypeComparisons, comparisonsCleanup, "comparisonsCleanup"},
+	{view.CleanupJobTypeSoftDeletedData, deletedDataCleanup, "softDeletedDataCleanup"},
+	{view.CleanupJobTypeUnreferencedData, unreferencedDataCleanup, "unreferencedDataCleanup"},
+	{view.CleanupJobTypeMaintenanceVacuum, maintenanceVacuum, "maintenanceVacuum"},
+}
+
+func getJobType(apiJobType string) (jobType, error) {
+	names := make([]string, 0, len(apiJobTypes))
+	for _, jt := range apiJobTypes {
+		if jt.apiJobType == apiJobType {
+			return jt.jobType, nil
+		}
+		names = append(names, jt.apiJobType)
+	}
+	return "", &exception.CustomError{
+		Status:  http.StatusBadRequest,
+		Code:    exception.UnknownCleanupJobType,
+		Message: exception.UnknownCleanupJobTypeMsg,
+		Params:  map[string]interface{}{"jobType": apiJobType, "jobTypes": strings.Join(names, ", ")},
+	}
+}
+
+// getJobName returns the name of the background job which executes the cleanup job
+func getJobName(jobType jobType) string {
+	for _, jt := range apiJobTypes {
+		if jt.jobType == jobType {
+			return jt.jobName
+		}
+	}
+	return string(jobType)
+}
+
+func (c *cleanupServiceImpl) getJobRunner(apiJobType string) (*JobRunner, error) {
+	jt, err := getJobType(apiJobType)
+	if err != nil {
+		return nil, err
+	}
+	runner, exists := c.jobs[jt]
+	if !exists {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.CleanupJobNotConfigured,
+			Message: exception.CleanupJobNotConfiguredMsg,
+			Params:  map[string]interface{}{"jobType": apiJobType},
+		}
+	}
+	return runner, nil
+}
+
+func (c *cleanupServiceImpl) GetJobRuns(ctx context.Context, req view.CleanupJobRunsReq) (*view.CleanupJobRuns, error) {
+	if req.JobType != "" {
+		if _, err := getJobType(req.JobType); err != nil {
+			return nil, err
+		}
+	}
+	ents, err := c.jobRepository.GetJobRuns(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.CleanupJobRuns{Runs: make([]view.CleanupJobRun, 0, len(ents))}
+	for _, ent := range ents {
+		result.Runs = append(result.Runs, entity.MakeCleanupJobRunView(ent))
+	}
+	return result, nil
+}
@@ -0,0 +1,43 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetJobType(t *testing.T) {
+	for _, jt := range apiJobTypes {
+		internal, err := getJobType(jt.apiJobType)
+		assert.NoError(t, err)
+		assert.Equal(t, jt.jobName, getJobName(internal))
+	}
+
+	_, err := getJobType("unknown")
+	customErr, ok := err.(*exception.CustomError)
+	assert.True(t, ok)
+	assert.Equal(t, exception.UnknownCleanupJobType, customErr.Code)
+}
+
+func TestGetContextCancellationMessage(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errors.New("cancelled by user"))
+	assert.Equal(t, "cancelled by user", getContextCancellationMessage(ctx))
+
+	ctx, timeoutCancel := context.WithTimeout(context.Background(), 0)
+	defer timeoutCancel()
+	assert.Equal(t, "timeout", getContextCancellationMessage(ctx))
+}
+
+func TestStartDryRunNotConfiguredJob(t *testing.T) {
+	cleanupService := NewCleanupService(nil, nil, nil, nil)
+
+	_, err := cleanupService.StartDryRun(context.Background(), view.CleanupJobTypeRevisions, "", "user")
+	customErr, ok := err.(*exception.CustomError)
+	assert.True(t, ok)
+	assert.Equal(t, exception.CleanupJobNotConfigured, customErr.Code)
+}
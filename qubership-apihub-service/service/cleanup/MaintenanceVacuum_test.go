@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,11 +29,27 @@ func TestMaintenanceVacuumProcessorTimeout(t *testing.T) {
 }
 
 func TestCreateMaintenanceVacuumCleanupJob(t *testing.T) {
-	cleanupService := NewCleanupService(nil)
+	backgroundJobService := &testBackgroundJobService{}
+	cleanupService := NewCleanupService(nil, nil, nil, backgroundJobService)
 
-	err := cleanupService.CreateMaintenanceVacuumCleanupJob(nil, nil, "instance-1", "0 23 * * 0", 300)
+	err := cleanupService.CreateMaintenanceVacuumCleanupJob(nil, "instance-1", "0 23 * * 0", 300)
 	assert.NoError(t, err)
 
-	cleanupImpl := cleanupService.(*cleanupServiceImpl)
-	assert.Len(t, cleanupImpl.cron.Entries(), 1)
+	assert.Len(t, backgroundJobService.defs, 1)
+	def := backgroundJobService.defs[0]
+	assert.Equal(t, "maintenanceVacuum", def.Name)
+	assert.Equal(t, "0 23 * * 0", def.Schedule)
+	assert.Equal(t, lockName, def.LockName)
+	assert.True(t, def.Distributed)
+	assert.Equal(t, 5*time.Hour, def.Timeout)
+}
+
+type testBackgroundJobService struct {
+	service.BackgroundJobService
+	defs []service.BackgroundJobDefinition
+}
+
+func (s *testBackgroundJobService) RegisterJob(def service.BackgroundJobDefinition) error {
+	s.defs = append(s.defs, def)
+	return nil
 }
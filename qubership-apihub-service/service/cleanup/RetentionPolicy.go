@@ -0,0 +1,229 @@
+package cleanup
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+var retentionDataClasses = []string{view.RetentionDataClassRevisions, view.RetentionDataClassComparisons}
+
+type RetentionPolicyService interface {
+	GetPolicies(ctx context.Context, dataClass string, packageId string) (*view.CleanupRetentionPolicies, error)
+	SetPolicy(ctx context.Context, packageId string, dataClass string, req view.CleanupRetentionPolicyReq, userId string) (*view.CleanupRetentionPolicy, error)
+	DeletePolicy(ctx context.Context, packageId string, dataClass string) error
+}
+
+func NewRetentionPolicyService(retentionRepository repository.CleanupRetentionRepository, publishedRepository repository.PublishedRepository) RetentionPolicyService {
+	return &retentionPolicyServiceImpl{
+		retentionRepository: retentionRepository,
+		publishedRepository: publishedRepository,
+	}
+}
+
+type retentionPolicyServiceImpl struct {
+	retentionRepository repository.CleanupRetentionRepository
+	publishedRepository repository.PublishedRepository
+}
+
+func (r *retentionPolicyServiceImpl) GetPolicies(ctx context.Context, dataClass string, packageId string) (*view.CleanupRetentionPolicies, error) {
+	if dataClass != "" {
+		if err := validateRetentionDataClass(dataClass); err != nil {
+			return nil, err
+		}
+	}
+	ents, err := r.retentionRepository.GetPolicies(ctx, dataClass, packageId)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.CleanupRetentionPolicies{Policies: make([]view.CleanupRetentionPolicy, 0, len(ents))}
+	for _, ent := range ents {
+		result.Policies = append(result.Policies, entity.MakeCleanupRetentionPolicyView(ent))
+	}
+	return result, nil
+}
+
+func (r *retentionPolicyServiceImpl) SetPolicy(ctx context.Context, packageId string, dataClass string, req view.CleanupRetentionPolicyReq, userId string) (*view.CleanupRetentionPolicy, error) {
+	if err := validateRetentionDataClass(dataClass); err != nil {
+		return nil, err
+	}
+	if err := validateRetentionPolicyReq(dataClass, req); err != nil {
+		return nil, err
+	}
+	pkg, err := r.publishedRepository.GetPackage(packageId)
+	if err != nil {
+		return nil, err
+	}
+	if pkg == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PackageNotFound,
+			Message: exception.PackageNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId},
+		}
+	}
+	ent := &entity.CleanupRetentionPolicyEntity{
+		PackageId:              packageId,
+		DataClass:              dataClass,
+		TTLDays:                req.TTLDays,
+		KeepLastDraftRevisions: req.KeepLastDraftRevisions,
+		KeepReleaseRevisions:   req.KeepReleaseRevisions,
+		ArchivedTTLDays:        req.ArchivedTTLDays,
+		UpdatedBy:              userId,
+		UpdatedAt:              time.Now(),
+	}
+	if err = r.retentionRepository.SavePolicy(ctx, ent); err != nil {
+		return nil, err
+	}
+	result := entity.MakeCleanupRetentionPolicyView(*ent)
+	return &result, nil
+}
+
+func (r *retentionPolicyServiceImpl) DeletePolicy(ctx context.Context, packageId string, dataClass string) error {
+	if err := validateRetentionDataClass(dataClass); err != nil {
+		return err
+	}
+	deleted, err := r.retentionRepository.DeletePolicy(ctx, packageId, dataClass)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.RetentionPolicyNotFound,
+			Message: exception.RetentionPolicyNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId, "dataClass": dataClass},
+		}
+	}
+	return nil
+}
+
+func validateRetentionDataClass(dataClass string) error {
+	for _, dc := range retentionDataClasses {
+		if dc == dataClass {
+			return nil
+		}
+	}
+	return &exception.CustomError{
+		Status:  http.StatusBadRequest,
+		Code:    exception.UnknownRetentionDataClass,
+		Message: exception.UnknownRetentionDataClassMsg,
+		Params:  map[string]interface{}{"dataClass": dataClass, "dataClasses": strings.Join(retentionDataClasses, ", ")},
+	}
+}
+
+func validateRetentionPolicyReq(dataClass string, req view.CleanupRetentionPolicyReq) error {
+	if req.TTLDays == nil && req.KeepLastDraftRevisions == nil && req.KeepReleaseRevisions == nil && req.ArchivedTTLDays == nil {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.EmptyRetentionPolicy,
+			Message: exception.EmptyRetentionPolicyMsg,
+		}
+	}
+	if dataClass == view.RetentionDataClassRevisions {
+		return nil
+	}
+	param := ""
+	switch {
+	case req.KeepLastDraftRevisions != nil:
+		param = "keepLastDraftRevisions"
+	case req.KeepReleaseRevisions != nil:
+		param = "keepReleaseRevisions"
+	case req.ArchivedTTLDays != nil:
+		param = "archivedTtlDays"
+	default:
+		return nil
+	}
+	return &exception.CustomError{
+		Status:  http.StatusBadRequest,
+		Code:    exception.RetentionPolicyParamNotApplicable,
+		Message: exception.RetentionPolicyParamNotApplicableMsg,
+		Params:  map[string]interface{}{"param": param, "dataClass": dataClass},
+	}
+}
+
+// retentionPolicies resolves effective retention of packages: parameters of the closest policy in the package hierarchy
+// take precedence, parameters which are not set anywhere in the hierarchy come from the global cleanup configuration.
+type retentionPolicies struct {
+	byPackageId map[string]entity.CleanupRetentionPolicyEntity
+}
+
+func loadRetentionPolicies(ctx context.Context, retentionRepository repository.CleanupRetentionRepository, dataClass string) (*retentionPolicies, error) {
+	ents, err := retentionRepository.GetPolicies(ctx, dataClass, "")
+	if err != nil {
+		return nil, err
+	}
+	return newRetentionPolicies(ents), nil
+}
+
+func newRetentionPolicies(ents []entity.CleanupRetentionPolicyEntity) *retentionPolicies {
+	byPackageId := make(map[string]entity.CleanupRetentionPolicyEntity, len(ents))
+	for _, ent := range ents {
+		byPackageId[ent.PackageId] = ent
+	}
+	return &retentionPolicies{byPackageId: byPackageId}
+}
+
+func (r *retentionPolicies) effective(packageId string) entity.CleanupRetentionPolicyEntity {
+	result := entity.CleanupRetentionPolicyEntity{PackageId: packageId}
+	if len(r.byPackageId) == 0 {
+		return result
+	}
+	for _, id := range utils.GetPackageHierarchy(packageId) {
+		policy, exists := r.byPackageId[id]
+		if !exists {
+			continue
+		}
+		if policy.TTLDays != nil {
+			result.TTLDays = policy.TTLDays
+		}
+		if policy.KeepLastDraftRevisions != nil {
+			result.KeepLastDraftRevisions = policy.KeepLastDraftRevisions
+		}
+		if policy.KeepReleaseRevisions != nil {
+			result.KeepReleaseRevisions = policy.KeepReleaseRevisions
+		}
+		if policy.ArchivedTTLDays != nil {
+			result.ArchivedTTLDays = policy.ArchivedTTLDays
+		}
+	}
+	return result
+}
+
+func (r *retentionPolicies) revisionsRule(packageId string, now time.Time, deleteBefore time.Time, deleteLastRevision bool, deleteReleaseRevision bool) view.RevisionsRetentionRule {
+	policy := r.effective(packageId)
+	rule := view.RevisionsRetentionRule{
+		DeleteBefore:         deleteBefore,
+		DeleteLastRevision:   deleteLastRevision,
+		KeepReleaseRevisions: !deleteReleaseRevision,
+	}
+	if policy.TTLDays != nil {
+		rule.DeleteBefore = now.AddDate(0, 0, -*policy.TTLDays)
+	}
+	if policy.KeepReleaseRevisions != nil {
+		rule.KeepReleaseRevisions = *policy.KeepReleaseRevisions
+	}
+	if policy.KeepLastDraftRevisions != nil {
+		rule.KeepLastDraftRevisions = *policy.KeepLastDraftRevisions
+	}
+	if policy.ArchivedTTLDays != nil {
+		archivedDeleteBefore := now.AddDate(0, 0, -*policy.ArchivedTTLDays)
+		rule.ArchivedDeleteBefore = &archivedDeleteBefore
+	}
+	return rule
+}
+
+func (r *retentionPolicies) deleteBefore(packageId string, now time.Time, deleteBefore time.Time) time.Time {
+	policy := r.effective(packageId)
+	if policy.TTLDays != nil {
+		return now.AddDate(0, 0, -*policy.TTLDays)
+	}
+	return deleteBefore
+}
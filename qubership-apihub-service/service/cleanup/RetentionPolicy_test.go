@@ -0,0 +1,150 @@
+package cleanup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func TestRetentionPoliciesEffective(t *testing.T) {
+	policies := newRetentionPolicies([]entity.CleanupRetentionPolicyEntity{
+		{PackageId: "ws", DataClass: view.RetentionDataClassRevisions, TTLDays: intPtr(30), KeepReleaseRevisions: boolPtr(true)},
+		{PackageId: "ws.group.pkg", DataClass: view.RetentionDataClassRevisions, TTLDays: intPtr(7), KeepLastDraftRevisions: intPtr(3)},
+	})
+
+	pkg := policies.effective("ws.group.pkg")
+	assert.Equal(t, 7, *pkg.TTLDays)
+	assert.Equal(t, 3, *pkg.KeepLastDraftRevisions)
+	assert.True(t, *pkg.KeepReleaseRevisions)
+	assert.Nil(t, pkg.ArchivedTTLDays)
+
+	sibling := policies.effective("ws.group.other")
+	assert.Equal(t, 30, *sibling.TTLDays)
+	assert.Nil(t, sibling.KeepLastDraftRevisions)
+
+	assert.Nil(t, policies.effective("other").TTLDays)
+}
+
+func TestRetentionPoliciesRevisionsRule(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	globalDeleteBefore := now.AddDate(0, 0, -365)
+	policies := newRetentionPolicies([]entity.CleanupRetentionPolicyEntity{
+		{PackageId: "ws", DataClass: view.RetentionDataClassRevisions, TTLDays: intPtr(10), ArchivedTTLDays: intPtr(5)},
+	})
+
+	rule := policies.revisionsRule("ws.pkg", now, globalDeleteBefore, false, true)
+	assert.Equal(t, now.AddDate(0, 0, -10), rule.DeleteBefore)
+	assert.False(t, rule.DeleteLastRevision)
+	assert.False(t, rule.KeepReleaseRevisions)
+	assert.Equal(t, now.AddDate(0, 0, -5), *rule.ArchivedDeleteBefore)
+
+	rule = policies.revisionsRule("other", now, globalDeleteBefore, true, false)
+	assert.Equal(t, globalDeleteBefore, rule.DeleteBefore)
+	assert.True(t, rule.DeleteLastRevision)
+	assert.True(t, rule.KeepReleaseRevisions)
+	assert.Nil(t, rule.ArchivedDeleteBefore)
+
+	assert.Equal(t, now.AddDate(0, 0, -10), policies.deleteBefore("ws.pkg", now, globalDeleteBefore))
+}
+
+func TestCountRevisionsToDelete(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, 0, -100)
+	revisions := []view.RevisionRetentionInfo{
+		{Status: string(view.Draft), PublishedAt: old},
+		{Status: string(view.Draft), PublishedAt: old},
+		{Status: string(view.Release), PublishedAt: old},
+		{Status: string(view.Draft), PublishedAt: old},
+		{Status: string(view.Draft), PublishedAt: now},
+	}
+
+	rule := view.RevisionsRetentionRule{DeleteBefore: now.AddDate(0, 0, -10)}
+	assert.Equal(t, 4, rule.CountRevisionsToDelete(revisions))
+
+	rule.KeepReleaseRevisions = true
+	assert.Equal(t, 2, rule.CountRevisionsToDelete(revisions))
+
+	rule.KeepReleaseRevisions = false
+	rule.KeepLastDraftRevisions = 3
+	assert.Equal(t, 1, rule.CountRevisionsToDelete(revisions))
+
+	archived := []view.RevisionRetentionInfo{
+		{Status: string(view.Release), PublishedAt: old},
+		{Status: string(view.Archived), PublishedAt: old},
+	}
+	archivedDeleteBefore := now.AddDate(0, 0, -50)
+	rule = view.RevisionsRetentionRule{DeleteBefore: now.AddDate(0, 0, -365), KeepReleaseRevisions: true, ArchivedDeleteBefore: &archivedDeleteBefore}
+	assert.Equal(t, 2, rule.CountRevisionsToDelete(archived))
+
+	assert.Equal(t, 0, rule.CountRevisionsToDelete(nil))
+}
+
+func TestComparisonCleanupReason(t *testing.T) {
+	deleteBefore := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	prevPackage, prevVersion := "pkg", "v1"
+
+	assert.NotEmpty(t, comparisonCleanupReason(entity.VersionComparisonCleanupCandidateEntity{RevisionNotPublished: true}, deleteBefore))
+
+	adhoc := entity.VersionComparisonCleanupCandidateEntity{
+		PreviousPackageId: "pkg",
+		PreviousVersion:   "v0",
+		LastActive:        deleteBefore.AddDate(0, 0, -1),
+	}
+	assert.NotEmpty(t, comparisonCleanupReason(adhoc, deleteBefore))
+	adhoc.LastActive = deleteBefore.AddDate(0, 0, 1)
+	assert.Empty(t, comparisonCleanupReason(adhoc, deleteBefore))
+	adhoc.LastActive = deleteBefore.AddDate(0, 0, -1)
+	adhoc.Saved = true
+	assert.Empty(t, comparisonCleanupReason(adhoc, deleteBefore))
+
+	changelog := entity.VersionComparisonCleanupCandidateEntity{
+		PreviousPackageId:       "pkg",
+		PreviousVersion:         "v1",
+		PreviousRevision:        2,
+		PreviousMaxRevision:     2,
+		ActualPreviousPackageId: &prevPackage,
+		ActualPreviousVersion:   &prevVersion,
+		LastActive:              deleteBefore.AddDate(0, 0, -1),
+	}
+	assert.Empty(t, comparisonCleanupReason(changelog, deleteBefore))
+	changelog.PreviousRevision = 1
+	assert.NotEmpty(t, comparisonCleanupReason(changelog, deleteBefore))
+}
+
+func TestDryRunReportBuilderTruncatesItems(t *testing.T) {
+	report := newDryRunReportBuilder(time.Time{})
+	for i := 0; i < dryRunMaxReportItems+5; i++ {
+		report.add(view.CleanupDryRunItem{Kind: view.CleanupItemKindRevision, Bytes: 2})
+	}
+	assert.Equal(t, dryRunMaxReportItems+5, report.report.TotalItems)
+	assert.Equal(t, int64(2*(dryRunMaxReportItems+5)), report.report.BytesToFree)
+	assert.Len(t, report.report.Items, dryRunMaxReportItems)
+	assert.True(t, report.report.ItemsTruncated)
+}
+
+func TestAddUnreferencedContentCandidates(t *testing.T) {
+	report := newDryRunReportBuilder(time.Time{})
+	addUnreferencedContentCandidates(report, "operation_data", nil)
+	assert.Zero(t, report.report.TotalItems)
+
+	addUnreferencedContentCandidates(report, "published_data", []entity.UnreferencedContentCandidateEntity{
+		{PackageId: "PKG", ContentKey: "a", Bytes: 10, TotalCount: 5, TotalBytes: 100},
+		{PackageId: "PKG", ContentKey: "b", Bytes: 20, TotalCount: 5, TotalBytes: 100},
+	})
+	assert.Equal(t, 5, report.report.TotalItems)
+	assert.Equal(t, int64(100), report.report.BytesToFree)
+	assert.Len(t, report.report.Items, 2)
+	assert.Equal(t, "published_data", report.report.Items[1].ContentTable)
+	assert.True(t, report.report.ItemsTruncated)
+}
@@ -21,6 +21,7 @@ const (
 type revisionsCleanupJobProcessor struct {
 	publishedRepository      repository.PublishedRepository
 	versionCleanupRepository repository.VersionCleanupRepository
+	retentionRepository      repository.CleanupRetentionRepository
 	monitoringService        service.MonitoringService
 	deleteLastRevision       bool
 	deleteReleaseRevision    bool
@@ -29,6 +30,7 @@ type revisionsCleanupJobProcessor struct {
 func NewRevisionsCleanupJobProcessor(
 	publishedRepository repository.PublishedRepository,
 	versionCleanupRepository repository.VersionCleanupRepository,
+	retentionRepository repository.CleanupRetentionRepository,
 	monitoringService service.MonitoringService,
 	deleteLastRevision bool,
 	deleteReleaseRevision bool,
@@ -36,6 +38,7 @@ func NewRevisionsCleanupJobProcessor(
 	return &revisionsCleanupJobProcessor{
 		publishedRepository:      publishedRepository,
 		versionCleanupRepository: versionCleanupRepository,
+		retentionRepository:      retentionRepository,
 		monitoringService:        monitoringService,
 		deleteLastRevision:       deleteLastRevision,
 		deleteReleaseRevision:    deleteReleaseRevision,
@@ -60,6 +63,13 @@ func (p *revisionsCleanupJobProcessor) Initialize(ctx context.Context, jobId str
 func (p *revisionsCleanupJobProcessor) Process(ctx context.Context, jobId string, deleteBefore time.Time, deletedItems *int) ([]string, error) {
 	logger.Debugf(ctx, "Will delete revisions older than %s", deleteBefore)
 
+	now := time.Now()
+	policies, err := loadRetentionPolicies(ctx, p.retentionRepository, view.RetentionDataClassRevisions)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get retention policies for revisions cleanup: %s", err.Error())
+		return nil, fmt.Errorf("failed to get retention policies: %s", err.Error())
+	}
+
 	page, limit := 0, revisionsPageSize
 	processingErrors := []string{}
 	packageCount := 0
@@ -105,7 +115,8 @@ func (p *revisionsCleanupJobProcessor) Process(ctx context.Context, jobId string
 			}
 
 			logger.Debugf(ctx, "Processing package %d/%d: %s", idx+1, len(packages), pkg.Id)
-			count, releaseCount, err := p.publishedRepository.DeletePackageRevisionsBeforeDate(ctx, pkg.Id, deleteBefore, p.deleteLastRevision, p.deleteReleaseRevision, "job_revisions_cleanup|"+jobId)
+			rule := policies.revisionsRule(pkg.Id, now, deleteBefore, p.deleteLastRevision, p.deleteReleaseRevision)
+			count, releaseCount, err := p.publishedRepository.DeletePackageRevisions(ctx, pkg.Id, rule, "job_revisions_cleanup|"+jobId)
 			if err != nil {
 				logger.Warnf(ctx, "Failed to delete revisions of package %s during revisions cleanup: %v", pkg.Id, err)
 				processingErrors = append(processingErrors, fmt.Sprintf("package %s: %s", pkg.Id, err.Error()))
@@ -155,3 +166,73 @@ func (p *revisionsCleanupJobProcessor) GetVacuumTimeout() time.Duration {
 func (p *revisionsCleanupJobProcessor) PerformVacuum(ctx context.Context, jobId string) error {
 	return nil //revisions cleanup doesn't need vacuum
 }
+
+func (p *revisionsCleanupJobProcessor) DryRun(ctx context.Context, packageId string, deleteBefore time.Time, report *dryRunReportBuilder) error {
+	now := time.Now()
+	policies, err := loadRetentionPolicies(ctx, p.retentionRepository, view.RetentionDataClassRevisions)
+	if err != nil {
+		return fmt.Errorf("failed to get retention policies: %s", err.Error())
+	}
+	return forEachCleanupPackage(ctx, p.publishedRepository, packageId, func(id string) error {
+		rule := policies.revisionsRule(id, now, deleteBefore, p.deleteLastRevision, p.deleteReleaseRevision)
+		candidates, err := p.publishedRepository.GetPackageRevisionsCleanupCandidates(ctx, id, rule)
+		if err != nil {
+			return fmt.Errorf("package %s: %s", id, err.Error())
+		}
+		for _, candidate := range candidates {
+			report.add(view.CleanupDryRunItem{
+				Kind:      view.CleanupItemKindRevision,
+				PackageId: candidate.PackageId,
+				Version:   candidate.Version,
+				Revision:  candidate.Revision,
+				Status:    candidate.Status,
+				Reason:    candidate.Reason,
+				Bytes:     candidate.Bytes,
+			})
+		}
+		return nil
+	})
+}
+
+// forEachCleanupPackage calls fn for the package and all its children, or for all packages and dashboards if packageId is empty
+func forEachCleanupPackage(ctx context.Context, publishedRepository repository.PublishedRepository, packageId string, fn func(packageId string) error) error {
+	if packageId != "" {
+		ids, err := publishedRepository.GetAllChildPackageIdsIncludingParent(packageId)
+		if err != nil {
+			return fmt.Errorf("failed to get child packages of %s: %s", packageId, err.Error())
+		}
+		for _, id := range ids {
+			if ctx.Err() != nil {
+				return fmt.Errorf("dry run interrupted - %s", getContextCancellationMessage(ctx))
+			}
+			if err = fn(id); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	page, limit := 0, revisionsPageSize
+	for {
+		if ctx.Err() != nil {
+			return fmt.Errorf("dry run interrupted - %s", getContextCancellationMessage(ctx))
+		}
+		packages, err := publishedRepository.GetFilteredPackagesWithOffset(ctx, view.PackageListReq{
+			Kind:     []string{entity.KIND_PACKAGE, entity.KIND_DASHBOARD},
+			Limit:    limit,
+			Offset:   page * limit,
+			ParentId: "*",
+		}, "")
+		if err != nil {
+			return fmt.Errorf("failed to get packages: %s", err.Error())
+		}
+		if len(packages) == 0 {
+			return nil
+		}
+		for _, pkg := range packages {
+			if err = fn(pkg.Id); err != nil {
+				return err
+			}
+		}
+		page++
+	}
+}
@@ -2,90 +2,87 @@ package cleanup
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
-	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
 	mRepository "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/migration/repository"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service/cleanup/logger"
-	"github.com/google/uuid"
 )
 
 const (
-	sharedLockName       = "cleanup_job_lock"
-	lockLeaseSeconds     = 120
-	lockHeartbeatSeconds = 30
+	// lockName is shared by all cleanup jobs, so they are never executed concurrently
+	lockName = "cleanup"
 
 	maxErrorMessageLength = 1000
 	updateContextTimeout  = 10 * time.Second
 )
 
+var errMigrationRunning = errors.New("cleanup job was skipped since migration is running")
+
+// JobRunner executes the cleanup job processor as a background job. Scheduling, locking and run history are provided by BackgroundJobService.
 type JobRunner struct {
-	cp                  db.ConnectionProvider
 	migrationRepository mRepository.MigrationRunRepository
-	lockService         service.LockService
 	config              jobConfig
 	processor           JobProcessor
 }
 
-func (r *JobRunner) Run() {
-	jobId := uuid.New().String()
-	deletedItems := 0
-
-	vacuumTimeout := r.processor.GetVacuumTimeout()
-	jobTimeout := r.config.timeout + vacuumTimeout
-	jobCtx, jobCancel := context.WithTimeout(context.Background(), jobTimeout) //an extended timeout is required to hold the lock for the entire duration of the job; the configured timeout is used for the main stage of the job, and an additional timeout is applied for performing VACUUM FULL on the affected tables
-	defer jobCancel()
-	jobCtx = context.WithValue(jobCtx, "jobType", r.config.jobType)
+func (r *JobRunner) Run(ctx context.Context) error {
+	// the id of the background job run is used for the job specific progress, so both can be matched
+	jobId := service.GetBackgroundJobRunId(ctx)
+	jobCtx := context.WithValue(ctx, "jobType", r.config.jobType)
 	jobCtx = context.WithValue(jobCtx, "jobId", jobId)
 
+	if r.isMigrationRunning(jobCtx) {
+		return errMigrationRunning
+	}
+
+	logger.Infof(jobCtx, "Starting cleanup job, cleanup timeout %v", r.config.timeout)
+
+	return r.execute(jobCtx, jobId)
+}
+
+func (r *JobRunner) execute(jobCtx context.Context, jobId string) (err error) {
+	deletedItems := 0
 	defer func() {
-		if err := recover(); err != nil {
-			errorMsg := fmt.Sprintf("cleanup job failed with panic: %v", err)
+		if rec := recover(); rec != nil {
+			errorMsg := fmt.Sprintf("cleanup job failed with panic: %v", rec)
 			logger.Errorf(jobCtx, "%s", errorMsg)
 			finishedAt := time.Now()
 			_ = r.processor.UpdateProgress(jobCtx, jobId, statusError, errorMsg, deletedItems, &finishedAt)
+			err = errors.New(errorMsg)
 		}
 	}()
 
-	if r.isMigrationRunning(jobCtx) {
-		return
-	}
-
-	logger.Infof(jobCtx, "Starting cleanup job, cleanup timeout %v", r.config.timeout)
-
-	if !r.acquireLock(jobCtx, jobId, jobCancel) {
-		return
-	}
-	defer r.releaseLock(jobCtx)
-
+	vacuumTimeout := r.processor.GetVacuumTimeout()
 	deleteBefore := time.Now().AddDate(0, 0, -r.config.ttl)
 	if err := r.processor.Initialize(jobCtx, jobId, r.config.instanceId, deleteBefore); err != nil {
-		return
+		return err
 	}
 
 	cleanupCtx := jobCtx
 	var cleanupCancel context.CancelFunc
 	if vacuumTimeout > 0 {
+		//the background job timeout includes the vacuum timeout, the configured timeout is used for the main stage of the job
 		cleanupCtx, cleanupCancel = context.WithTimeout(jobCtx, r.config.timeout)
 		defer cleanupCancel()
 	}
 
 	processingErrors, isTimeout := r.executeProcessingPhase(cleanupCtx, jobId, deleteBefore, &deletedItems)
 
-	if vacuumTimeout > 0 {
+	if vacuumTimeout > 0 && !service.IsBackgroundJobCancelled(jobCtx) {
 		vacuumErr, interruptedByTimeout := r.executeVacuumPhase(jobCtx, jobId, vacuumTimeout)
 		if vacuumErr != nil {
-			processingErrors = append(processingErrors)
+			processingErrors = append(processingErrors, fmt.Sprintf("vacuum failed: %s", vacuumErr.Error()))
 			if interruptedByTimeout {
 				isTimeout = true
 			}
 		}
 	}
 
-	r.finishCleanupRun(jobCtx, jobId, processingErrors, isTimeout, deletedItems)
+	return r.finishCleanupRun(jobCtx, jobId, processingErrors, isTimeout, deletedItems)
 }
 
 func (r *JobRunner) isMigrationRunning(ctx context.Context) bool {
@@ -102,58 +99,6 @@ func (r *JobRunner) isMigrationRunning(ctx context.Context) bool {
 	return false
 }
 
-func (r *JobRunner) acquireLock(ctx context.Context, jobId string, cancel context.CancelFunc) bool {
-	lockOptions := service.LockOptions{
-		LeaseSeconds:             lockLeaseSeconds,
-		HeartbeatIntervalSeconds: lockHeartbeatSeconds,
-		NotifyOnLoss:             true,
-	}
-
-	acquired, lockLostCh, err := r.lockService.AcquireLock(ctx, sharedLockName, lockOptions)
-	if err != nil {
-		logger.Errorf(ctx, "Failed to acquire lock: %v", err)
-		return false
-	}
-
-	if !acquired {
-		logger.Info(ctx, "job skipped - lock is held by another instance or job")
-		return false
-	}
-
-	if lockLostCh != nil {
-		go func() {
-			event, ok := <-lockLostCh
-			if !ok {
-				return
-			}
-			logger.Warnf(ctx, "Lock %s lost: %s. Canceling cleanup job", event.LockName, event.Reason)
-			cancel()
-		}()
-	}
-
-	return true
-}
-
-func (r *JobRunner) releaseLock(ctx context.Context) {
-	select {
-	case <-ctx.Done():
-		if ctx.Err() == context.DeadlineExceeded {
-			releaseCtx, releaseCancel := createContextForUpdate(ctx)
-			defer releaseCancel()
-
-			if err := r.lockService.ReleaseLock(releaseCtx, sharedLockName); err != nil {
-				logger.Errorf(ctx, "Failed to release lock: %v", err)
-			}
-		} else {
-			logger.Debug(ctx, "Lock for cleanup job was already lost, skipping release")
-		}
-	default:
-		if err := r.lockService.ReleaseLock(ctx, sharedLockName); err != nil {
-			logger.Errorf(ctx, "Failed to release lock: %v", err)
-		}
-	}
-}
-
 func (r *JobRunner) executeProcessingPhase(cleanupCtx context.Context, jobId string, deleteBefore time.Time, deletedItems *int) ([]string, bool) {
 	isTimeout := false
 	processingErrors, err := r.processor.Process(cleanupCtx, jobId, deleteBefore, deletedItems)
@@ -173,7 +118,9 @@ func (r *JobRunner) executeVacuumPhase(jobCtx context.Context, jobId string, vac
 	defer vacuumCancel()
 
 	logger.Debugf(jobCtx, "Starting vacuum phase with timeout %v", vacuumTimeout)
+	vacuumStartedAt := time.Now()
 	vacuumErr := r.processor.PerformVacuum(vacuumCtx, jobId)
+	logger.Infof(jobCtx, "Vacuum phase took %v", time.Since(vacuumStartedAt))
 	if vacuumErr != nil {
 		logger.Warnf(jobCtx, "Vacuum phase failed: %v", vacuumErr)
 		if vacuumCtx.Err() == context.DeadlineExceeded {
@@ -186,8 +133,11 @@ func (r *JobRunner) executeVacuumPhase(jobCtx context.Context, jobId string, vac
 	return nil, false
 }
 
-func (r *JobRunner) finishCleanupRun(ctx context.Context, jobId string, processingErrors []string, isTimeout bool, deletedItems int) {
+func (r *JobRunner) finishCleanupRun(ctx context.Context, jobId string, processingErrors []string, isTimeout bool, deletedItems int) error {
 	status := determineJobStatus(len(processingErrors) > 0, isTimeout)
+	if service.IsBackgroundJobCancelled(ctx) {
+		status = statusCancelled
+	}
 	errorMessage := formatJobErrors(r.config.jobType, processingErrors)
 
 	finishedAt := time.Now()
@@ -195,10 +145,20 @@ func (r *JobRunner) finishCleanupRun(ctx context.Context, jobId string, processi
 		logErrorMessage := formatErrorMessage(errorMessage)
 		logger.Errorf(ctx, "Failed to save cleanup run state: %v, status: %s, errorMessage: %s, deletedItems: %d",
 			err, status, logErrorMessage, deletedItems)
-		return
+		return err
 	}
 
 	logger.Infof(ctx, "job finished with status '%s'. Deleted %d items.", status, deletedItems)
+	if status == statusComplete {
+		return nil
+	}
+	if status == statusCancelled {
+		return context.Cause(ctx)
+	}
+	if errorMessage == "" {
+		return fmt.Errorf("%s cleanup finished with status %s", r.config.jobType, status)
+	}
+	return errors.New(errorMessage)
 }
 
 func createContextForUpdate(parentCtx context.Context) (context.Context, context.CancelFunc) {
@@ -230,7 +190,11 @@ func getContextCancellationMessage(ctx context.Context) string {
 	if ctx.Err() == context.DeadlineExceeded {
 		return "timeout"
 	}
-	return "distributed lock was lost"
+	// the cause is set by BackgroundJobService when the run is cancelled, the lock is lost or the instance is shutting down
+	if cause := context.Cause(ctx); cause != nil {
+		return cause.Error()
+	}
+	return "interrupted"
 }
 
 func formatJobErrors(jobType jobType, errors []string) string {
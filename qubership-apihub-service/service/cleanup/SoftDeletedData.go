@@ -8,11 +8,13 @@ import (
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service/cleanup/logger"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
 )
 
 const (
 	softDeletedPackagesBatchSize  = 50
 	softDeletedRevisionsBatchSize = 100
+	softDeletedDryRunLimit        = 100000
 )
 
 type softDeletedDataCleanupJobProcessor struct {
@@ -128,3 +130,30 @@ func (p *softDeletedDataCleanupJobProcessor) PerformVacuum(ctx context.Context,
 	}
 	return nil
 }
+
+func (p *softDeletedDataCleanupJobProcessor) DryRun(ctx context.Context, packageId string, deleteBefore time.Time, report *dryRunReportBuilder) error {
+	candidates, err := p.publishedRepository.GetSoftDeletedDataCleanupCandidates(ctx, deleteBefore, packageId, softDeletedDryRunLimit)
+	if err != nil {
+		return err
+	}
+	for _, candidate := range candidates {
+		item := view.CleanupDryRunItem{
+			Kind:      view.CleanupItemKindPackage,
+			PackageId: candidate.PackageId,
+			Reason:    "package deleted before retention threshold",
+			Bytes:     candidate.Bytes,
+		}
+		if candidate.Version != "" {
+			item.Kind = view.CleanupItemKindRevision
+			item.Version = candidate.Version
+			item.Revision = candidate.Revision
+			item.Status = candidate.Status
+			item.Reason = "revision deleted before retention threshold"
+		}
+		report.add(item)
+	}
+	if len(candidates) == softDeletedDryRunLimit {
+		report.report.ItemsTruncated = true
+	}
+	return nil
+}
@@ -15,10 +15,11 @@ const (
 	unreferencedDataCleanup jobType = "unreferenced data cleanup"
 	maintenanceVacuum       jobType = "maintenance vacuum"
 
-	statusRunning  jobStatus = "running"
-	statusComplete jobStatus = "complete"
-	statusError    jobStatus = "error"
-	statusTimeout  jobStatus = "timeout"
+	statusRunning   jobStatus = "running"
+	statusComplete  jobStatus = "complete"
+	statusError     jobStatus = "error"
+	statusTimeout   jobStatus = "timeout"
+	statusCancelled jobStatus = "cancelled"
 )
 
 type JobProcessor interface {
@@ -35,3 +36,8 @@ type jobConfig struct {
 	ttl        int
 	timeout    time.Duration
 }
+
+// DryRunProcessor is implemented by job processors which can report what the job would delete without deleting anything
+type DryRunProcessor interface {
+	DryRun(ctx context.Context, packageId string, deleteBefore time.Time, report *dryRunReportBuilder) error
+}
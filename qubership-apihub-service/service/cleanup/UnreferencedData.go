@@ -8,10 +8,12 @@ import (
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service/cleanup/logger"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
 )
 
 const (
-	unreferencedDataBatchSize = 100
+	unreferencedDataBatchSize      = 100
+	referenceCountChangesBatchSize = 10000
 )
 
 type unreferencedDataCleanupJobProcessor struct {
@@ -44,6 +46,16 @@ func (p *unreferencedDataCleanupJobProcessor) Process(ctx context.Context, jobId
 	processingErrors := []string{}
 	batchSize := unreferencedDataBatchSize
 
+	// ref_count of the content is checked against actual references before deletion, so the cleanup is still safe if the changes are not applied
+	logger.Info(ctx, "Applying reference count changes")
+	appliedChanges, err := p.unreferencedDataCleanupRepo.ApplyReferenceCountChanges(ctx, referenceCountChangesBatchSize)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to apply reference count changes: %v", err)
+		processingErrors = append(processingErrors, fmt.Sprintf("failed to apply reference count changes: %s", err.Error()))
+	} else {
+		logger.Infof(ctx, "Applied %d reference count changes", appliedChanges)
+	}
+
 	logger.Info(ctx, "Starting cleanup of unreferenced operation data")
 	for {
 		select {
@@ -218,3 +230,46 @@ func (p *unreferencedDataCleanupJobProcessor) PerformVacuum(ctx context.Context,
 	}
 	return nil
 }
+
+// DryRun reports the unreferenced content of every content table. The content is shared between packages,
+// so the report can not be limited to a package.
+func (p *unreferencedDataCleanupJobProcessor) DryRun(ctx context.Context, packageId string, _ time.Time, report *dryRunReportBuilder) error {
+	if packageId != "" {
+		return fmt.Errorf("unreferenced data is shared between packages, the report can not be limited to package %s", packageId)
+	}
+	for _, contentTable := range repository.UnreferencedContentTables {
+		if ctx.Err() != nil {
+			return fmt.Errorf("dry run interrupted - %s", getContextCancellationMessage(ctx))
+		}
+		candidates, err := p.unreferencedDataCleanupRepo.GetUnreferencedContentCandidates(ctx, contentTable, dryRunMaxReportItems)
+		if err != nil {
+			return err
+		}
+		addUnreferencedContentCandidates(report, contentTable, candidates)
+	}
+	return nil
+}
+
+// addUnreferencedContentCandidates adds the listed entries to the report and the rest of the entries to the totals
+func addUnreferencedContentCandidates(report *dryRunReportBuilder, contentTable string, candidates []entity.UnreferencedContentCandidateEntity) {
+	if len(candidates) == 0 {
+		return
+	}
+	var listedBytes int64
+	for _, candidate := range candidates {
+		listedBytes += candidate.Bytes
+		report.add(view.CleanupDryRunItem{
+			Kind:         view.CleanupItemKindContent,
+			PackageId:    candidate.PackageId,
+			ContentTable: contentTable,
+			ContentKey:   candidate.ContentKey,
+			Reason:       "content is not referenced",
+			Bytes:        candidate.Bytes,
+		})
+	}
+	if notListed := candidates[0].TotalCount - len(candidates); notListed > 0 {
+		report.report.TotalItems += notListed
+		report.report.BytesToFree += candidates[0].TotalBytes - listedBytes
+		report.report.ItemsTruncated = true
+	}
+}
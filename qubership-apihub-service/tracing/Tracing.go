@@ -0,0 +1,152 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName = "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service"
+
+	// TraceIdHeader is set on every traced response, so the trace can be found by the response (e.g. error) received by the client
+	TraceIdHeader = "X-Trace-Id"
+
+	PackageIdKey   = attribute.Key("apihub.package.id")
+	VersionKey     = attribute.Key("apihub.version")
+	BuildIdKey     = attribute.Key("apihub.build.id")
+	BuilderIdKey   = attribute.Key("apihub.builder.id")
+	BuildStatusKey = attribute.Key("apihub.build.status")
+)
+
+type Options struct {
+	Enabled        bool
+	OtlpEndpoint   string
+	ServiceName    string
+	ServiceVersion string
+	SampleRatio    float64
+}
+
+// Init configures global OpenTelemetry tracer provider and W3C trace context propagation.
+// If tracing is disabled, the global no-op provider is kept and all spans are non-recording and have no trace id.
+// Returned function flushes and stops the exporter.
+func Init(cfg Options) (func(ctx context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if !cfg.Enabled {
+		log.Info("OpenTelemetry tracing is disabled")
+		return func(ctx context.Context) error { return nil }, nil
+	}
+	if cfg.OtlpEndpoint == "" {
+		return nil, fmt.Errorf("OTLP endpoint is required when tracing is enabled")
+	}
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(cfg.OtlpEndpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(cfg.ServiceName),
+			semconv.ServiceVersion(cfg.ServiceVersion),
+		)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	log.AddHook(logHook{})
+	log.Infof("OpenTelemetry tracing is enabled, exporting to %s with sample ratio %v", cfg.OtlpEndpoint, cfg.SampleRatio)
+	return tp.Shutdown, nil
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// StartSpan starts a span which is a child of the span in ctx or a new root span
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartChildSpan starts a span only if ctx already belongs to a trace, so background operations
+// (e.g. queries of scheduled jobs) do not produce a separate trace for each call.
+// Otherwise ctx is returned as is with a non-recording span.
+func StartChildSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return StartSpan(ctx, name, attrs...)
+}
+
+// EndSpan marks the span as failed if err is not nil and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceId returns id of the trace ctx belongs to or empty string
+func TraceId(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// InjectContext serializes trace context of ctx, so it can be passed with the data handed off to other services or instances
+func InjectContext(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// ExtractContext restores trace context serialized by InjectContext
+func ExtractContext(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// LinkFromContext returns link to the span serialized by InjectContext, it is used when the handed off work is processed in another trace
+func LinkFromContext(carrier map[string]string) (trace.Link, bool) {
+	spanContext := trace.SpanContextFromContext(ExtractContext(context.Background(), carrier))
+	if !spanContext.IsValid() {
+		return trace.Link{}, false
+	}
+	return trace.Link{SpanContext: spanContext}, true
+}
+
+// logHook adds trace and span ids to the log entries written with context, e.g. log.WithContext(ctx).Errorf(...)
+type logHook struct{}
+
+func (h logHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h logHook) Fire(entry *log.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	spanContext := trace.SpanContextFromContext(entry.Context)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	entry.Data["traceId"] = spanContext.TraceID().String()
+	entry.Data["spanId"] = spanContext.SpanID().String()
+	return nil
+}
@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestSpansAndContextPropagation(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	ctx, span := StartChildSpan(context.Background(), "orphan")
+	span.End()
+	require.Empty(t, TraceId(ctx), "child span is not started without parent")
+	require.Empty(t, recorder.Ended())
+
+	rootCtx, root := StartSpan(context.Background(), "root")
+	traceId := TraceId(rootCtx)
+	require.NotEmpty(t, traceId)
+
+	childCtx, child := StartChildSpan(rootCtx, "child")
+	require.Equal(t, traceId, TraceId(childCtx))
+	EndSpan(child, nil)
+
+	carrier := InjectContext(rootCtx)
+	require.Contains(t, carrier, "traceparent")
+	require.Equal(t, traceId, TraceId(ExtractContext(context.Background(), carrier)))
+	link, ok := LinkFromContext(carrier)
+	require.True(t, ok)
+	require.Equal(t, traceId, link.SpanContext.TraceID().String())
+	_, ok = LinkFromContext(nil)
+	require.False(t, ok)
+
+	var buf bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&buf)
+	logger.AddHook(logHook{})
+	logger.WithContext(rootCtx).Info("traced")
+	require.Contains(t, buf.String(), "traceId="+traceId)
+	root.End()
+
+	require.Len(t, recorder.Ended(), 2)
+}
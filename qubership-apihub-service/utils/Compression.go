@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"bytes"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// payloads smaller than this are not worth compressing
+const minCompressedDataSize = 512
+
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+var zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+var zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+
+// CompressData compresses the payload with zstd if it makes the payload smaller.
+// Compressed payloads are recognized by the zstd frame magic number, so a payload which already starts with it
+// is always compressed to keep DecompressData unambiguous.
+func CompressData(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+	startsWithMagic := IsCompressedData(data)
+	if len(data) < minCompressedDataSize && !startsWithMagic {
+		return data
+	}
+	compressed := zstdEncoder.EncodeAll(data, make([]byte, 0, len(data)/2))
+	if len(compressed) >= len(data) && !startsWithMagic {
+		return data
+	}
+	return compressed
+}
+
+// DecompressData returns the original payload of data stored by CompressData. Payloads stored before compression was introduced are returned as is.
+func DecompressData(data []byte) ([]byte, error) {
+	if !IsCompressedData(data) {
+		return data, nil
+	}
+	return zstdDecoder.DecodeAll(data, nil)
+}
+
+func IsCompressedData(data []byte) bool {
+	return bytes.HasPrefix(data, zstdMagic)
+}
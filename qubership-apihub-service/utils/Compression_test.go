@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCompressDataRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"openapi":"3.0.0","paths":{}}`), 100)
+
+	compressed := CompressData(data)
+	if !IsCompressedData(compressed) {
+		t.Fatalf("Expected data to be compressed")
+	}
+	if len(compressed) >= len(data) {
+		t.Errorf("Expected compressed size to be less than %d; Got %d", len(data), len(compressed))
+	}
+	decompressed, err := DecompressData(compressed)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal(data, decompressed) {
+		t.Errorf("Decompressed data does not match the original one")
+	}
+}
+
+func TestCompressDataKeepsSmallData(t *testing.T) {
+	data := []byte(`{"openapi":"3.0.0"}`)
+
+	if compressed := CompressData(data); !bytes.Equal(data, compressed) {
+		t.Errorf("Expected small data to be stored as is")
+	}
+	if CompressData(nil) != nil {
+		t.Errorf("Expected nil data to stay nil")
+	}
+}
+
+func TestCompressDataMagicPrefixedData(t *testing.T) {
+	data := append(append([]byte{}, zstdMagic...), []byte("not a zstd frame")...)
+
+	decompressed, err := DecompressData(CompressData(data))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal(data, decompressed) {
+		t.Errorf("Decompressed data does not match the original one")
+	}
+}
+
+func TestDecompressDataLegacyData(t *testing.T) {
+	data := bytes.Repeat([]byte("legacy uncompressed data "), 100)
+
+	decompressed, err := DecompressData(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal(data, decompressed) {
+		t.Errorf("Expected legacy data to be returned as is")
+	}
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MakeStrongETag builds a quoted strong entity tag from the parts which identify the immutable representation
+func MakeStrongETag(parts ...string) string {
+	return `"` + GetEncodedXXHash128([]byte(strings.Join(parts, "|"))) + `"`
+}
+
+// RespondNotModified responds with 304 Not Modified and cache headers if the request preconditions
+// (If-None-Match or If-Modified-Since) match the current representation. Returns true if the response is already written.
+// Otherwise, the caller has to set cache headers with SetCacheHeaders only when the representation is successfully returned.
+func RespondNotModified(w http.ResponseWriter, r *http.Request, etag string, lastModified time.Time, cacheControl string) bool {
+	if !isNotModified(r, etag, lastModified) {
+		return false
+	}
+	SetCacheHeaders(w, etag, lastModified, cacheControl)
+	// Content headers must not be sent with 304
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+func SetCacheHeaders(w http.ResponseWriter, etag string, lastModified time.Time, cacheControl string) {
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	if cacheControl != "" {
+		w.Header().Set("Cache-Control", cacheControl)
+	}
+}
+
+func isNotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	// If-None-Match takes precedence over If-Modified-Since (RFC 9110, 13.2.2)
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return etag != "" && etagMatches(ifNoneMatch, etag)
+	}
+	ifModifiedSince := r.Header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// etagMatches uses weak comparison as required for If-None-Match
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRespondNotModified(t *testing.T) {
+	etag := MakeStrongETag("QS.QUOTE", "2024.1", "2", "hash", "raw", "quote")
+	if etag != MakeStrongETag("QS.QUOTE", "2024.1", "2", "hash", "raw", "quote") {
+		t.Errorf("ETag is not stable")
+	}
+	if etag == MakeStrongETag("QS.QUOTE", "2024.1", "3", "hash", "raw", "quote") {
+		t.Errorf("ETag must differ for different revisions")
+	}
+	publishedAt := time.Date(2024, 3, 1, 10, 0, 0, 500, time.UTC)
+
+	tests := []struct {
+		name        string
+		headers     map[string]string
+		notModified bool
+	}{
+		{name: "no preconditions", headers: map[string]string{}, notModified: false},
+		{name: "matching etag", headers: map[string]string{"If-None-Match": etag}, notModified: true},
+		{name: "matching weak etag in list", headers: map[string]string{"If-None-Match": `"other", W/` + etag}, notModified: true},
+		{name: "any etag", headers: map[string]string{"If-None-Match": "*"}, notModified: true},
+		{name: "different etag", headers: map[string]string{"If-None-Match": `"other"`}, notModified: false},
+		{name: "not modified since", headers: map[string]string{"If-Modified-Since": publishedAt.Format(http.TimeFormat)}, notModified: true},
+		{name: "modified since", headers: map[string]string{"If-Modified-Since": publishedAt.Add(-time.Hour).Format(http.TimeFormat)}, notModified: false},
+		{
+			name:        "etag takes precedence over date",
+			headers:     map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": publishedAt.Format(http.TimeFormat)},
+			notModified: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			if RespondNotModified(w, r, etag, publishedAt, "private, no-cache") != tt.notModified {
+				t.Fatalf("Expected not modified: %v", tt.notModified)
+			}
+			if tt.notModified {
+				if w.Code != http.StatusNotModified {
+					t.Errorf("Expected status 304, got %d", w.Code)
+				}
+				if w.Header().Get("ETag") != etag || w.Header().Get("Cache-Control") != "private, no-cache" {
+					t.Errorf("Expected cache headers in 304 response, got %v", w.Header())
+				}
+			} else if w.Header().Get("ETag") != "" {
+				t.Errorf("Cache headers must be set by the caller on success only")
+			}
+		})
+	}
+}
+
+func TestRespondNotModifiedWithoutLastModified(t *testing.T) {
+	// representations with mutable parts are validated by ETag only
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("If-Modified-Since", time.Now().Format(http.TimeFormat))
+	w := httptest.NewRecorder()
+	if RespondNotModified(w, r, MakeStrongETag("QS.QUOTE", "2024.1", "shared"), time.Time{}, "private, no-cache") {
+		t.Fatalf("If-Modified-Since must be ignored without Last-Modified")
+	}
+	SetCacheHeaders(w, `"etag"`, time.Time{}, "private, no-cache")
+	if w.Header().Get("Last-Modified") != "" {
+		t.Errorf("Last-Modified must not be set, got %v", w.Header())
+	}
+}
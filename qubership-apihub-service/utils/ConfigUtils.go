@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"unicode"
 
 	log "github.com/sirupsen/logrus"
@@ -95,3 +96,31 @@ func printValue(key string, value reflect.Value, isSensitive bool) {
 	}
 	log.Infof("%s=%s", key, valStr)
 }
+
+// FindConfigField returns the field of the config struct by its key, e.g. businessParameters.releaseVersionPattern.
+// The key is made of the field names with the lowercase first letter, the same way as the keys printed by PrintConfig.
+// config must be a pointer to make the returned field settable.
+func FindConfigField(config interface{}, key string) (reflect.Value, bool) {
+	v := reflect.ValueOf(config)
+	for _, name := range strings.Split(key, ".") {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		field, ok := v.Type().FieldByNameFunc(func(fieldName string) bool {
+			runes := []rune(fieldName)
+			runes[0] = unicode.ToLower(runes[0])
+			return string(runes) == name
+		})
+		if !ok || field.PkgPath != "" {
+			return reflect.Value{}, false
+		}
+		v = v.FieldByIndex(field.Index)
+	}
+	return v, true
+}
@@ -3,6 +3,8 @@ package utils
 import (
 	"encoding/json"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/logging"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/tracing"
 	log "github.com/sirupsen/logrus"
 	"net/http"
 	"net/url"
@@ -90,7 +92,11 @@ func RedirectHandler(apihubURLStr string) http.HandlerFunc {
 }
 
 func RespondWithError(w http.ResponseWriter, msg string, err error) {
-	log.Errorf("%s: %s", msg, err.Error())
+	entry := logging.ForResponse(w)
+	if traceId := w.Header().Get(tracing.TraceIdHeader); traceId != "" {
+		entry = entry.WithField("traceId", traceId)
+	}
+	entry.Errorf("%s: %s", msg, err.Error())
 	if customError, ok := err.(*exception.CustomError); ok {
 		RespondWithCustomError(w, customError)
 	} else {
@@ -102,7 +108,16 @@ func RespondWithError(w http.ResponseWriter, msg string, err error) {
 }
 
 func RespondWithCustomError(w http.ResponseWriter, err *exception.CustomError) {
-	log.Debugf("Request failed. Code = %d. Message = %s. Params: %v. Debug: %s", err.Status, err.Message, err.Params, err.Debug)
+	logging.ForResponse(w).Debugf("Request failed. Code = %d. Message = %s. Params: %v. Debug: %s", err.Status, err.Message, err.Params, err.Debug)
+	// request and trace ids are set by RequestIdMiddleware and TracingMiddleware, the error is copied since it may be a shared instance
+	requestId := w.Header().Get(logging.RequestIdHeader)
+	traceId := w.Header().Get(tracing.TraceIdHeader)
+	if requestId != "" || traceId != "" {
+		correlatedErr := *err
+		correlatedErr.RequestId = requestId
+		correlatedErr.TraceId = traceId
+		err = &correlatedErr
+	}
 	RespondWithJson(w, err.Status, err)
 }
 
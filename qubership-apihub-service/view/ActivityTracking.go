@@ -67,6 +67,10 @@ const ATETCreateManualGroup ATEventType = "create_manual_group"
 const ATETDeleteManualGroup ATEventType = "delete_manual_group"
 const ATETOperationsGroupParameters ATEventType = "update_operations_group_parameters"
 
+// deprecation lifecycle
+
+const ATETSunsetDatePassed ATEventType = "sunset_date_passed"
+
 func ConvertEventTypes(input []string) []string {
 	var output []string
 	for _, iType := range input {
@@ -83,6 +87,8 @@ func ConvertEventTypes(input []string) []string {
 			output = append(output, string(ATETPatchPackageMeta), string(ATETCreatePackage), string(ATETDeletePackage))
 		case "operations_group":
 			output = append(output, string(ATETCreateManualGroup), string(ATETDeleteManualGroup), string(ATETOperationsGroupParameters))
+		case "deprecation":
+			output = append(output, string(ATETSunsetDatePassed))
 		}
 	}
 	return output
@@ -25,18 +25,32 @@ type AiChatUpdateRequest struct {
 
 // AiChatMessage is one persisted message
 type AiChatMessage struct {
-	MessageID        string                 `json:"messageId"`
-	ClientMessageID  *string                `json:"clientMessageId,omitempty"`
-	Role             string                 `json:"role"`
-	Content          string                 `json:"content"`
-	CreatedAt        string                 `json:"createdAt"`
-	ToolInvocations  []AiChatToolInvocation `json:"toolInvocations,omitempty"`
+	MessageID       string                 `json:"messageId"`
+	ClientMessageID *string                `json:"clientMessageId,omitempty"`
+	Role            string                 `json:"role"`
+	Content         string                 `json:"content"`
+	CreatedAt       string                 `json:"createdAt"`
+	ToolInvocations []AiChatToolInvocation `json:"toolInvocations,omitempty"`
+	Citations       []AiChatCitation       `json:"citations,omitempty"`
 }
 
 type AiChatToolInvocation struct {
 	Name       string `json:"name"`
 	Status     string `json:"status"`
 	DurationMs *int   `json:"durationMs,omitempty"`
+	PackageId  string `json:"packageId,omitempty"`
+	FileId     string `json:"fileId,omitempty"`
+	// ResultPackageIds are the packages found in the result of the tool which reads package data,
+	// nil if the result was not inspected (messages stored before the packages were recorded)
+	ResultPackageIds *[]string `json:"resultPackageIds,omitempty"`
+}
+
+type AiChatCitation struct {
+	PackageId    string `json:"packageId"`
+	Version      string `json:"version"`
+	ApiType      string `json:"apiType"`
+	OperationId  string `json:"operationId,omitempty"`
+	DocumentSlug string `json:"documentSlug,omitempty"`
 }
 
 type AiChatMessagesListResponse struct {
@@ -53,3 +67,40 @@ type AiChatSendMessageResponse struct {
 	UserMessage      AiChatMessage `json:"userMessage"`
 	AssistantMessage AiChatMessage `json:"assistantMessage"`
 }
+
+// AiChatShare is a read-only snapshot of a chat taken at share time
+type AiChatShare struct {
+	ShareID       string   `json:"shareId"`
+	ChatID        string   `json:"chatId"`
+	Title         string   `json:"title"`
+	SharedBy      string   `json:"sharedBy"`
+	PackageIds    []string `json:"packageIds,omitempty"`
+	MessagesCount int      `json:"messagesCount"`
+	CreatedAt     string   `json:"createdAt"`
+}
+
+type AiChatSharesListResponse struct {
+	Shares []AiChatShare `json:"shares"`
+}
+
+type AiChatSharedFile struct {
+	FileID    string `json:"fileId"`
+	Filename  string `json:"filename"`
+	SizeBytes int64  `json:"sizeBytes"`
+}
+
+type AiChatSharedSnapshot struct {
+	AiChatShare
+	Messages []AiChatMessage    `json:"messages"`
+	Files    []AiChatSharedFile `json:"files,omitempty"`
+}
+
+type AiChatExport struct {
+	ChatID     string          `json:"chatId"`
+	Title      string          `json:"title"`
+	ExportedAt string          `json:"exportedAt"`
+	Messages   []AiChatMessage `json:"messages"`
+}
+
+const AiChatExportFormatMarkdown = "markdown"
+const AiChatExportFormatJson = "json"
@@ -0,0 +1,61 @@
+package view
+
+import "time"
+
+const (
+	AiSpecReviewStatusRunning  = "running"
+	AiSpecReviewStatusComplete = "complete"
+	// AiSpecReviewStatusPartial means the token budget or the operations limit was reached before all changed operations were reviewed.
+	AiSpecReviewStatusPartial = "partial"
+	AiSpecReviewStatusError   = "error"
+)
+
+const (
+	AiSpecReviewCategoryNaming      = "naming"
+	AiSpecReviewCategoryDescription = "description"
+	AiSpecReviewCategoryErrorModel  = "errorModel"
+	AiSpecReviewCategoryPagination  = "pagination"
+)
+
+// Severity values follow the builder notifications scale.
+const (
+	AiSpecReviewSeverityError   = 0
+	AiSpecReviewSeverityWarning = 1
+	AiSpecReviewSeverityInfo    = 2
+)
+
+type AiSpecReviewConfig struct {
+	PackageId   string `json:"packageId"`
+	Enabled     bool   `json:"enabled"`
+	TokenBudget int    `json:"tokenBudget"`
+	// DefaultTokenBudget is true when the package does not override the instance-wide budget.
+	DefaultTokenBudget bool `json:"defaultTokenBudget"`
+}
+
+type AiSpecReviewConfigUpdateReq struct {
+	Enabled     *bool `json:"enabled"`
+	TokenBudget *int  `json:"tokenBudget" validate:"omitempty,gt=0"`
+}
+
+type AiSpecReview struct {
+	PackageId          string                     `json:"packageId"`
+	Version            string                     `json:"version"`
+	Revision           int                        `json:"revision"`
+	Status             string                     `json:"status"`
+	Details            string                     `json:"details,omitempty"`
+	OperationsTotal    int                        `json:"operationsTotal"`
+	OperationsReviewed int                        `json:"operationsReviewed"`
+	TokenBudget        int                        `json:"tokenBudget"`
+	TokensUsed         int                        `json:"tokensUsed"`
+	StartedAt          time.Time                  `json:"startedAt"`
+	FinishedAt         *time.Time                 `json:"finishedAt,omitempty"`
+	Notifications      []AiSpecReviewNotification `json:"notifications"`
+}
+
+type AiSpecReviewNotification struct {
+	Severity    int    `json:"severity"`
+	Category    string `json:"category"`
+	Message     string `json:"message"`
+	ApiType     string `json:"apiType,omitempty"`
+	OperationId string `json:"operationId,omitempty"`
+}
@@ -0,0 +1,58 @@
+package view
+
+import "time"
+
+const (
+	BackgroundJobStatusRunning  = "running"
+	BackgroundJobStatusComplete = "complete"
+	BackgroundJobStatusError    = "error"
+	BackgroundJobStatusTimeout  = "timeout"
+	// BackgroundJobStatusInterrupted is set for runs aborted by the instance shutdown or left running by a stopped instance
+	BackgroundJobStatusInterrupted = "interrupted"
+	BackgroundJobStatusCancelled   = "cancelled"
+)
+
+type BackgroundJob struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Schedule is empty for jobs which can be triggered only manually
+	Schedule       string            `json:"schedule,omitempty"`
+	NextRunAt      *time.Time        `json:"nextRunAt,omitempty"`
+	Distributed    bool              `json:"distributed"`
+	TimeoutSeconds int               `json:"timeoutSeconds"`
+	MaxRetries     int               `json:"maxRetries"`
+	Disabled       bool              `json:"disabled"`
+	DisabledBy     string            `json:"disabledBy,omitempty"`
+	DisabledAt     *time.Time        `json:"disabledAt,omitempty"`
+	LastRun        *BackgroundJobRun `json:"lastRun,omitempty"`
+}
+
+type BackgroundJobs struct {
+	Jobs []BackgroundJob `json:"jobs"`
+}
+
+type BackgroundJobRun struct {
+	RunId      string `json:"runId"`
+	JobName    string `json:"jobName"`
+	InstanceId string `json:"instanceId"`
+	// TriggeredBy is empty for scheduled runs
+	TriggeredBy string     `json:"triggeredBy,omitempty"`
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	Details     string     `json:"details,omitempty"`
+	StartedAt   time.Time  `json:"startedAt"`
+	FinishedAt  *time.Time `json:"finishedAt,omitempty"`
+	// CancelRequestedBy is set when the run was requested to be cancelled via API
+	CancelRequestedBy string `json:"cancelRequestedBy,omitempty"`
+}
+
+type BackgroundJobRuns struct {
+	Runs []BackgroundJobRun `json:"runs"`
+}
+
+type BackgroundJobRunsReq struct {
+	JobName string
+	Status  string
+	Limit   int
+	Page    int
+}
@@ -0,0 +1,85 @@
+package view
+
+import "time"
+
+const (
+	BlobStorageBackendPostgres   = "postgres"
+	BlobStorageBackendS3         = "s3"
+	BlobStorageBackendFilesystem = "filesystem"
+)
+
+// Blob namespaces. Namespaces of blobs that are kept in the database by default match the table names,
+// so that existing S3 object keys (table_name/entity_id.zip) stay valid.
+// Operation data is not a blob namespace: it is kept in the database since it is joined and indexed for the full text search.
+const (
+	BlobNamespaceBuildResults    = BUILD_RESULT_TABLE
+	BlobNamespaceSourcesArchives = PUBLISHED_SOURCES_ARCHIVES_TABLE
+	BlobNamespaceExportResults   = "export_result"
+	BlobNamespaceEphemeralFiles  = "ephemeral_file"
+)
+
+// MigratableBlobNamespaces lists namespaces which could be moved between backends by the blob migration
+var MigratableBlobNamespaces = []string{
+	BlobNamespaceBuildResults,
+	BlobNamespaceSourcesArchives,
+	BlobNamespaceExportResults,
+}
+
+const (
+	BlobMigrationStatusRunning   = "running"
+	BlobMigrationStatusComplete  = "complete"
+	BlobMigrationStatusError     = "error"
+	BlobMigrationStatusCancelled = "cancelled"
+)
+
+type BlobStorageInfo struct {
+	Backend         string                     `json:"backend"`
+	FallbackBackend string                     `json:"fallbackBackend,omitempty"`
+	Namespaces      []BlobStorageNamespaceInfo `json:"namespaces"`
+}
+
+type BlobStorageNamespaceInfo struct {
+	Namespace       string `json:"namespace"`
+	Backend         string `json:"backend"`
+	FallbackBackend string `json:"fallbackBackend,omitempty"`
+}
+
+type BlobMigrationReq struct {
+	SourceBackend string   `json:"sourceBackend" validate:"required,oneof=postgres s3 filesystem"`
+	TargetBackend string   `json:"targetBackend" validate:"required,oneof=postgres s3 filesystem,nefield=SourceBackend"`
+	Namespaces    []string `json:"namespaces"`
+	DeleteSource  bool     `json:"deleteSource"`
+}
+
+type BlobMigration struct {
+	RunId             string                           `json:"runId"`
+	SourceBackend     string                           `json:"sourceBackend"`
+	TargetBackend     string                           `json:"targetBackend"`
+	Namespaces        []string                         `json:"namespaces"`
+	DeleteSource      bool                             `json:"deleteSource"`
+	Status            string                           `json:"status"`
+	Details           string                           `json:"details,omitempty"`
+	Progress          []BlobMigrationNamespaceProgress `json:"progress"`
+	CreatedBy         string                           `json:"createdBy"`
+	CreatedAt         time.Time                        `json:"createdAt"`
+	LastActive        time.Time                        `json:"lastActive"`
+	FinishedAt        *time.Time                       `json:"finishedAt,omitempty"`
+	CancelRequestedBy string                           `json:"cancelRequestedBy,omitempty"`
+}
+
+type BlobMigrations struct {
+	Migrations []BlobMigration `json:"migrations"`
+}
+
+type BlobMigrationNamespaceProgress struct {
+	Namespace string `json:"namespace"`
+	// LastKey is the last processed key, the migration is resumed from the next key
+	LastKey string `json:"lastKey,omitempty"`
+	Done    bool   `json:"done"`
+	Copied  int    `json:"copied"`
+	// Skipped is the number of blobs which were already present in the target backend with the same hash
+	Skipped    int      `json:"skipped"`
+	Failed     int      `json:"failed"`
+	Bytes      int64    `json:"bytes"`
+	FailedKeys []string `json:"failedKeys,omitempty"`
+}
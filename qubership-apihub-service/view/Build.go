@@ -40,6 +40,7 @@ type BuildConfig struct {
 	DocumentId                   string                  `json:"documentId,omitempty"`                   // for export
 	OperationsSpecTransformation string                  `json:"operationsSpecTransformation,omitempty"` // for export
 	AllowedShareabilityStatuses  []string                `json:"allowedShareabilityStatuses,omitempty"`  // for export
+	TraceContext                 map[string]string       `json:"traceContext,omitempty"`                 // W3C trace context of the publish request, builder may continue the trace with it
 }
 
 type BuildConfigMetadata struct {
@@ -88,6 +89,7 @@ const ExportRestDocument BuildType = "exportRestDocument"
 const ExportRestOperationsGroup BuildType = "exportRestOperationsGroup"
 const ExportGraphqlOperationsGroup BuildType = "exportGraphqlOperationsGroup"
 const ExportAsyncapiOperationsGroup BuildType = "exportAsyncapiOperationsGroup"
+const ExportProtobufOperationsGroup BuildType = "exportProtobufOperationsGroup"
 
 // TODO: add new export type here
 
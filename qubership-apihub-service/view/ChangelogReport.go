@@ -0,0 +1,35 @@
+package view
+
+// ChangelogReportSchemaVersion is increased on any incompatible change of the ChangelogReport format
+const ChangelogReportSchemaVersion = "1.0"
+
+type ChangelogReport struct {
+	SchemaVersion            string                     `json:"schemaVersion"`
+	PackageId                string                     `json:"packageId"`
+	Version                  string                     `json:"version"`
+	PreviousVersionPackageId string                     `json:"previousVersionPackageId"`
+	PreviousVersion          string                     `json:"previousVersion"`
+	ApiType                  string                     `json:"apiType"`
+	Breaking                 bool                       `json:"breaking"`
+	Summary                  ChangeSummary              `json:"summary"`
+	Operations               []ChangelogReportOperation `json:"operations"`
+}
+
+type ChangelogReportOperation struct {
+	OperationId string                  `json:"operationId"`
+	Title       string                  `json:"title"`
+	Label       string                  `json:"label"`
+	ApiType     string                  `json:"apiType"`
+	ApiKind     string                  `json:"apiKind,omitempty"`
+	PackageId   string                  `json:"packageId,omitempty"`
+	Version     string                  `json:"version,omitempty"`
+	Action      string                  `json:"action"`
+	Summary     ChangeSummary           `json:"summary"`
+	Changes     []ChangelogReportChange `json:"changes"`
+}
+
+type ChangelogReportChange struct {
+	Action      string `json:"action,omitempty"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+}
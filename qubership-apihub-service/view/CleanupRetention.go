@@ -0,0 +1,182 @@
+package view
+
+import "time"
+
+// Data classes which retention can be configured per workspace/group/package.
+const (
+	RetentionDataClassRevisions   = "revisions"
+	RetentionDataClassComparisons = "comparisons"
+)
+
+// Cleanup job types as exposed via API.
+const (
+	CleanupJobTypeRevisions         = "revisions"
+	CleanupJobTypeComparisons       = "comparisons"
+	CleanupJobTypeSoftDeletedData   = "softDeletedData"
+	CleanupJobTypeUnreferencedData  = "unreferencedData"
+	CleanupJobTypeMaintenanceVacuum = "maintenanceVacuum"
+)
+
+const (
+	CleanupDryRunStatusRunning  = "running"
+	CleanupDryRunStatusComplete = "complete"
+	CleanupDryRunStatusError    = "error"
+)
+
+const (
+	CleanupItemKindRevision   = "revision"
+	CleanupItemKindComparison = "comparison"
+	CleanupItemKindPackage    = "package"
+	CleanupItemKindContent    = "content"
+)
+
+// CleanupRetentionPolicy overrides global cleanup settings for the package and all its children.
+// Nil fields are inherited from the closest parent policy or from the global cleanup configuration.
+type CleanupRetentionPolicy struct {
+	PackageId              string    `json:"packageId"`
+	DataClass              string    `json:"dataClass"`
+	TTLDays                *int      `json:"ttlDays,omitempty"`
+	KeepLastDraftRevisions *int      `json:"keepLastDraftRevisions,omitempty"`
+	KeepReleaseRevisions   *bool     `json:"keepReleaseRevisions,omitempty"`
+	ArchivedTTLDays        *int      `json:"archivedTtlDays,omitempty"`
+	UpdatedBy              string    `json:"updatedBy"`
+	UpdatedAt              time.Time `json:"updatedAt"`
+}
+
+type CleanupRetentionPolicyReq struct {
+	TTLDays                *int  `json:"ttlDays" validate:"omitempty,gt=0"`
+	KeepLastDraftRevisions *int  `json:"keepLastDraftRevisions" validate:"omitempty,gte=0"`
+	KeepReleaseRevisions   *bool `json:"keepReleaseRevisions"`
+	ArchivedTTLDays        *int  `json:"archivedTtlDays" validate:"omitempty,gt=0"`
+}
+
+type CleanupRetentionPolicies struct {
+	Policies []CleanupRetentionPolicy `json:"policies"`
+}
+
+// RevisionsRetentionRule is the effective revisions retention of a single package.
+type RevisionsRetentionRule struct {
+	DeleteBefore           time.Time
+	DeleteLastRevision     bool
+	KeepReleaseRevisions   bool
+	KeepLastDraftRevisions int
+	// ArchivedDeleteBefore, if set, applies to versions which latest revision is archived: all their revisions
+	// published before the date are deleted, including the last and release ones.
+	ArchivedDeleteBefore *time.Time
+}
+
+type RevisionRetentionInfo struct {
+	Status      string
+	PublishedAt time.Time
+}
+
+// CountRevisionsToDelete returns the number of the oldest revisions of a version that must be deleted.
+// Revisions are expected in ascending order; only a contiguous sequence of the oldest revisions is deleted,
+// the first revision that must be kept stops the selection.
+func (r RevisionsRetentionRule) CountRevisionsToDelete(revisions []RevisionRetentionInfo) int {
+	if len(revisions) == 0 {
+		return 0
+	}
+	lastIndex := len(revisions) - 1
+	if r.ArchivedDeleteBefore != nil && revisions[lastIndex].Status == string(Archived) {
+		count := 0
+		for _, revision := range revisions {
+			if !revision.PublishedAt.Before(*r.ArchivedDeleteBefore) {
+				break
+			}
+			count++
+		}
+		return count
+	}
+	keptDrafts := 0
+	firstKeptDraft := len(revisions)
+	for i := lastIndex; i >= 0 && keptDrafts < r.KeepLastDraftRevisions; i-- {
+		if revisions[i].Status == string(Draft) {
+			keptDrafts++
+			firstKeptDraft = i
+		}
+	}
+	count := 0
+	for i, revision := range revisions {
+		if !revision.PublishedAt.Before(r.DeleteBefore) {
+			break
+		}
+		if i == lastIndex && !r.DeleteLastRevision {
+			break
+		}
+		if revision.Status == string(Release) && r.KeepReleaseRevisions {
+			break
+		}
+		if i >= firstKeptDraft && revision.Status == string(Draft) {
+			break
+		}
+		count++
+	}
+	return count
+}
+
+type CleanupDryRunReq struct {
+	PackageId string `json:"packageId"`
+}
+
+type CleanupDryRun struct {
+	RunId      string               `json:"runId"`
+	JobType    string               `json:"jobType"`
+	PackageId  string               `json:"packageId,omitempty"`
+	Status     string               `json:"status"`
+	Details    string               `json:"details,omitempty"`
+	CreatedBy  string               `json:"createdBy"`
+	StartedAt  time.Time            `json:"startedAt"`
+	FinishedAt *time.Time           `json:"finishedAt,omitempty"`
+	Report     *CleanupDryRunReport `json:"report,omitempty"`
+}
+
+type CleanupDryRunReport struct {
+	DeleteBefore   time.Time           `json:"deleteBefore"`
+	TotalItems     int                 `json:"totalItems"`
+	BytesToFree    int64               `json:"bytesToFree"`
+	ItemsTruncated bool                `json:"itemsTruncated"`
+	Items          []CleanupDryRunItem `json:"items"`
+}
+
+type CleanupDryRunItem struct {
+	Kind              string `json:"kind"`
+	PackageId         string `json:"packageId"`
+	Version           string `json:"version,omitempty"`
+	Revision          int    `json:"revision,omitempty"`
+	Status            string `json:"status,omitempty"`
+	ComparisonId      string `json:"comparisonId,omitempty"`
+	PreviousPackageId string `json:"previousPackageId,omitempty"`
+	PreviousVersion   string `json:"previousVersion,omitempty"`
+	PreviousRevision  int    `json:"previousRevision,omitempty"`
+	ContentTable      string `json:"contentTable,omitempty"`
+	ContentKey        string `json:"contentKey,omitempty"`
+	Reason            string `json:"reason"`
+	Bytes             int64  `json:"bytes"`
+}
+
+type CleanupJobRun struct {
+	RunId        string     `json:"runId"`
+	JobType      string     `json:"jobType"`
+	InstanceId   string     `json:"instanceId"`
+	PackageId    string     `json:"packageId,omitempty"`
+	Status       string     `json:"status"`
+	Details      string     `json:"details,omitempty"`
+	StartedAt    time.Time  `json:"startedAt"`
+	FinishedAt   *time.Time `json:"finishedAt,omitempty"`
+	DeleteBefore *time.Time `json:"deleteBefore,omitempty"`
+	DeletedItems int        `json:"deletedItems"`
+	// TriggeredBy is empty for scheduled runs
+	TriggeredBy string `json:"triggeredBy,omitempty"`
+}
+
+type CleanupJobRuns struct {
+	Runs []CleanupJobRun `json:"runs"`
+}
+
+type CleanupJobRunsReq struct {
+	JobType string
+	Status  string
+	Limit   int
+	Page    int
+}
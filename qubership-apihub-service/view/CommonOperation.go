@@ -276,6 +276,21 @@ type OperationComparisonChangesView struct {
 	Action                    string        `json:"action"`
 }
 
+// GetOperationComparisonChangesCommon returns the common part of the operation changes of any api type and the api type
+func GetOperationComparisonChangesCommon(operation interface{}) (OperationComparisonChangesView, ApiType, bool) {
+	switch o := operation.(type) {
+	case RestOperationComparisonChangesView:
+		return o.OperationComparisonChangesView, RestApiType, true
+	case GraphQLOperationComparisonChangesView:
+		return o.OperationComparisonChangesView, GraphqlApiType, true
+	case ProtobufOperationComparisonChangesView:
+		return o.OperationComparisonChangesView, ProtobufApiType, true
+	case AsyncAPIOperationComparisonChangesView:
+		return o.OperationComparisonChangesView, AsyncapiApiType, true
+	}
+	return OperationComparisonChangesView{}, "", false
+}
+
 type OperationChangesView struct {
 	Changes []interface{} `json:"changes"`
 }
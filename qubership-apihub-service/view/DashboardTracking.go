@@ -0,0 +1,63 @@
+package view
+
+import "time"
+
+const (
+	// DashboardTrackingRuleLatestRelease moves each reference to the latest release of the referenced package
+	DashboardTrackingRuleLatestRelease = "latestRelease"
+	// DashboardTrackingRuleVersionPattern moves each reference to the latest release which name matches the version pattern
+	DashboardTrackingRuleVersionPattern = "versionPattern"
+)
+
+const (
+	DashboardRollForwardStatusRunning  = "running"
+	DashboardRollForwardStatusComplete = "complete"
+	DashboardRollForwardStatusError    = "error"
+)
+
+type DashboardTracking struct {
+	PackageId      string    `json:"packageId"`
+	Version        string    `json:"version"`
+	Rule           string    `json:"rule"`
+	VersionPattern string    `json:"versionPattern,omitempty"`
+	Enabled        bool      `json:"enabled"`
+	UpdatedBy      string    `json:"updatedBy,omitempty"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type DashboardTrackingReq struct {
+	Rule           string `json:"rule" validate:"required,oneof=latestRelease versionPattern"`
+	VersionPattern string `json:"versionPattern" validate:"required_if=Rule versionPattern"`
+	Enabled        *bool  `json:"enabled"`
+}
+
+type MovedRef struct {
+	RefId       string `json:"refId"`
+	FromVersion string `json:"fromVersion"`
+	ToVersion   string `json:"toVersion"`
+}
+
+type DashboardRollForward struct {
+	Id               string     `json:"id"`
+	PackageId        string     `json:"packageId"`
+	Version          string     `json:"version"`
+	Revision         int        `json:"revision,omitempty"`
+	PublishId        string     `json:"publishId,omitempty"`
+	TriggerPackageId string     `json:"triggerPackageId"`
+	TriggerVersion   string     `json:"triggerVersion"`
+	MovedRefs        []MovedRef `json:"movedRefs"`
+	Status           string     `json:"status"`
+	Details          string     `json:"details,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	FinishedAt       *time.Time `json:"finishedAt,omitempty"`
+}
+
+type DashboardRollForwardsReq struct {
+	Version string
+	Limit   int
+	Page    int
+}
+
+type DashboardRollForwards struct {
+	RollForwards []DashboardRollForward `json:"rollForwards"`
+}
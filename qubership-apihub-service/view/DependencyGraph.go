@@ -0,0 +1,61 @@
+package view
+
+const DependencyGraphFormatGraphML = "graphml"
+const DependencyGraphFormatDot = "dot"
+
+type DependencyGraphNode struct {
+	// package version ref key: packageId@version@revision
+	Id                string `json:"id"`
+	PackageId         string `json:"packageId"`
+	Name              string `json:"name"`
+	Kind              string `json:"kind"`
+	Version           string `json:"version"`
+	Status            string `json:"status"`
+	NotLatestRevision bool   `json:"notLatestRevision,omitempty"`
+	Root              bool   `json:"root,omitempty"`
+	// true if the package version is referenced only by excluded references
+	Excluded bool `json:"excluded,omitempty"`
+}
+
+type DependencyGraphEdge struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	Excluded bool   `json:"excluded,omitempty"`
+}
+
+// DependencyGraphConflict describes a package referenced in several versions, only the selected version is included into the root version
+type DependencyGraphConflict struct {
+	PackageId       string   `json:"packageId"`
+	Versions        []string `json:"versions"`
+	SelectedVersion string   `json:"selectedVersion,omitempty"`
+}
+
+type DependencyGraph struct {
+	Nodes     []DependencyGraphNode     `json:"nodes"`
+	Edges     []DependencyGraphEdge     `json:"edges"`
+	Conflicts []DependencyGraphConflict `json:"conflicts"`
+}
+
+type PackageVersionDependentsReq struct {
+	AllRevisions bool
+	Limit        int
+	Page         int
+}
+
+type PackageVersionDependent struct {
+	PackageId         string `json:"packageId"`
+	Name              string `json:"name"`
+	Kind              string `json:"kind"`
+	Version           string `json:"version"`
+	Status            string `json:"status"`
+	NotLatestRevision bool   `json:"notLatestRevision,omitempty"`
+	// referenced revision of the requested version
+	ReferencedVersion string `json:"referencedVersion"`
+	// false if the package version is included via another dashboard
+	Direct   bool `json:"direct"`
+	Excluded bool `json:"excluded,omitempty"`
+}
+
+type PackageVersionDependents struct {
+	Dependents []PackageVersionDependent `json:"dependents"`
+}
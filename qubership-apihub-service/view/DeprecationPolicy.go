@@ -0,0 +1,61 @@
+package view
+
+import "time"
+
+const SunsetDateFormat = "2006-01-02"
+
+const DeprecationPolicySourceSpec = "spec"
+const DeprecationPolicySourceManual = "manual"
+
+// Operation extensions which the deprecation policy is captured from on publish.
+const (
+	SunsetExtension          = "x-sunset"
+	DeprecatedSinceExtension = "x-deprecated-since"
+	ReplacedByExtension      = "x-replaced-by"
+)
+
+type DeprecationReplacement struct {
+	// empty if the replacement operation is in the same package
+	PackageId   string `json:"packageId,omitempty"`
+	OperationId string `json:"operationId" validate:"required"`
+}
+
+type OperationDeprecationPolicy struct {
+	PackageId       string                  `json:"packageId"`
+	OperationId     string                  `json:"operationId"`
+	ApiType         string                  `json:"apiType"`
+	DeprecatedSince string                  `json:"deprecatedSince,omitempty"`
+	SunsetDate      string                  `json:"sunsetDate,omitempty"`
+	Replacement     *DeprecationReplacement `json:"replacement,omitempty"`
+	Description     string                  `json:"description,omitempty"`
+	Source          string                  `json:"source"`
+	UpdatedBy       string                  `json:"updatedBy,omitempty"`
+	UpdatedAt       time.Time               `json:"updatedAt"`
+}
+
+type OperationDeprecationPolicyReq struct {
+	DeprecatedSince string                  `json:"deprecatedSince"`
+	SunsetDate      string                  `json:"sunsetDate"`
+	Replacement     *DeprecationReplacement `json:"replacement"`
+	Description     string                  `json:"description"`
+}
+
+type SunsetCalendarReq struct {
+	From  time.Time
+	To    time.Time
+	Limit int
+	Page  int
+}
+
+type SunsetCalendarItem struct {
+	OperationDeprecationPolicy
+	PackageName string `json:"packageName"`
+	Title       string `json:"title,omitempty"`
+	// the latest release version which still contains the operation
+	ReleaseVersion string `json:"releaseVersion,omitempty"`
+	SunsetPassed   bool   `json:"sunsetPassed"`
+}
+
+type SunsetCalendar struct {
+	Operations []SunsetCalendarItem `json:"operations"`
+}
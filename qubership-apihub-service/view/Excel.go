@@ -30,6 +30,8 @@ const OperationProtocolColumnName = "Protocol"
 const AsyncAPIActionColumnName = "Action"
 const AsyncOperationIdColumnName = "Async Operation ID"
 const MessageIdColumnName = "Message ID"
+const PlannedSunsetDateColumnName = "Planned Sunset Date"
+const ReplacementOperationColumnName = "Replacement Operation"
 
 const ShareabilityReportSheetName = "Shareability Report"
 const ShareabilityReportColPackageName = "Package Name"
@@ -49,6 +49,7 @@ const (
 	ExportEntityRestOperationsGroup     ExportedEntity = "restOperationsGroup"
 	ExportEntityGraphqlOperationsGroup  ExportedEntity = "graphqlOperationsGroup"
 	ExportEntityAsyncapiOperationsGroup ExportedEntity = "asyncapiOperationsGroup"
+	ExportEntityProtobufOperationsGroup ExportedEntity = "protobufOperationsGroup"
 )
 
 type ExportRequestDiscriminator struct {
@@ -100,6 +101,13 @@ type ExportAsyncapiOperationsGroupReq struct {
 	Format         string         `json:"format" validate:"required"`
 }
 
+type ExportProtobufOperationsGroupReq struct {
+	ExportedEntity ExportedEntity `json:"exportedEntity" validate:"required"`
+	PackageId      string         `json:"packageId" validate:"required"`
+	Version        string         `json:"version" validate:"required"`
+	GroupName      string         `json:"groupName" validate:"required"`
+}
+
 type ExportResponse struct {
 	ExportID string `json:"exportId"`
 }
@@ -124,3 +132,16 @@ type ExportResult struct {
 	Data     []byte
 	FileName string
 }
+
+const ExportFormatMarkdown = "markdown"
+const ExportFormatJunit = "junit"
+const ExportFormatSarif = "sarif"
+
+func ValidateChangelogReportFormat(format string) bool {
+	switch format {
+	case ExportFormatMarkdown, ExportFormatJunit, ExportFormatSarif, ExportFormatJson:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,74 @@
+package view
+
+import "time"
+
+const ConsumerTypePackage = "package"
+const ConsumerTypeApiKey = "api_key"
+
+type ConsumedOperation struct {
+	PackageId string `json:"packageId" validate:"required"`
+	// empty if the consumer follows the latest version of the package
+	Version     string `json:"version,omitempty"`
+	OperationId string `json:"operationId" validate:"required"`
+}
+
+type ConsumedOperationsReq struct {
+	Operations []ConsumedOperation `json:"operations" validate:"dive"`
+}
+
+type ConsumedOperations struct {
+	Operations []ConsumedOperation `json:"operations"`
+}
+
+type Consumer struct {
+	ConsumerType string `json:"consumerType"`
+	ConsumerId   string `json:"consumerId"`
+	ConsumerName string `json:"consumerName"`
+	// package of the api key, empty for package consumers
+	ConsumerPackageId string `json:"consumerPackageId,omitempty"`
+}
+
+type OperationConsumer struct {
+	Consumer
+	OperationId string    `json:"operationId"`
+	Version     string    `json:"version,omitempty"`
+	DeclaredBy  string    `json:"declaredBy,omitempty"`
+	DeclaredAt  time.Time `json:"declaredAt"`
+}
+
+type OperationConsumersReq struct {
+	OperationId string
+	Limit       int
+	Page        int
+}
+
+type OperationConsumers struct {
+	Consumers []OperationConsumer `json:"consumers"`
+}
+
+type ConsumerImpactReq struct {
+	PreviousVersion          string
+	PreviousVersionPackageId string
+}
+
+type ImpactedOperation struct {
+	PackageId       string        `json:"packageId"`
+	OperationId     string        `json:"operationId"`
+	Title           string        `json:"title"`
+	ApiType         string        `json:"apiType"`
+	Action          string        `json:"action"`
+	ChangeSummary   ChangeSummary `json:"changeSummary"`
+	ConsumedVersion string        `json:"consumedVersion,omitempty"`
+}
+
+type ImpactedConsumer struct {
+	Consumer
+	Operations []ImpactedOperation `json:"operations"`
+}
+
+type ConsumerImpact struct {
+	PreviousVersion          string             `json:"previousVersion"`
+	PreviousVersionPackageId string             `json:"previousVersionPackageId"`
+	BreakingOperationsCount  int                `json:"breakingOperationsCount"`
+	Consumers                []ImpactedConsumer `json:"consumers"`
+}
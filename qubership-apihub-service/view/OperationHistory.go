@@ -0,0 +1,43 @@
+package view
+
+import "time"
+
+const (
+	OperationHistoryEventAdded              = "added"
+	OperationHistoryEventChanged            = "changed"
+	OperationHistoryEventDeprecated         = "deprecated"
+	OperationHistoryEventDeprecationRemoved = "deprecationRemoved"
+	OperationHistoryEventAudienceChanged    = "audienceChanged"
+	OperationHistoryEventRemoved            = "removed"
+)
+
+type OperationHistoryReq struct {
+	AllRevisions bool
+	Severities   []string
+}
+
+type OperationHistory struct {
+	PackageId          string                  `json:"packageId"`
+	OperationId        string                  `json:"operationId"`
+	ApiType            string                  `json:"apiType"`
+	Title              string                  `json:"title"`
+	LastBreakingChange *OperationHistoryEvent  `json:"lastBreakingChange,omitempty"`
+	Events             []OperationHistoryEvent `json:"events"`
+}
+
+type OperationHistoryEvent struct {
+	Type        string    `json:"type"`
+	Version     string    `json:"version"`
+	Status      string    `json:"status"`
+	PublishedAt time.Time `json:"publishedAt"`
+	// version the changes are calculated against
+	PreviousVersion              string         `json:"previousVersion,omitempty"`
+	PreviousVersionPackageId     string         `json:"previousVersionPackageId,omitempty"`
+	Breaking                     bool           `json:"breaking"`
+	ChangeSummary                *ChangeSummary `json:"changeSummary,omitempty"`
+	Changes                      []interface{}  `json:"changes,omitempty"`
+	ApiAudience                  string         `json:"apiAudience,omitempty"`
+	PreviousApiAudience          string         `json:"previousApiAudience,omitempty"`
+	DeprecatedInfo               string         `json:"deprecatedInfo,omitempty"`
+	DeprecatedInPreviousVersions []string       `json:"deprecatedInPreviousVersions,omitempty"`
+}
@@ -1,8 +1,11 @@
 package view
 
 type PublishNotification struct {
-	EventId   string
-	PackageId string
-	Version   string
-	Revision  int
+	EventId      string
+	PackageId    string
+	Version      string
+	Revision     int
+	PublishedBy  string            `json:",omitempty"`
+	Deleted      bool              `json:",omitempty"`
+	TraceContext map[string]string `json:",omitempty"`
 }
@@ -0,0 +1,36 @@
+package view
+
+import "time"
+
+const (
+	// CacheControlImmutable is used for content addressed by hash or by explicit revision
+	CacheControlImmutable = "private, max-age=31536000, immutable"
+	// CacheControlRelease allows short caching since a new revision of the release version is rare
+	CacheControlRelease = "private, max-age=300, must-revalidate"
+	// CacheControlDraft requires revalidation on each request since drafts are republished often
+	CacheControlDraft = "private, no-cache"
+)
+
+// PublishedCacheInfo identifies the published resource for HTTP conditional requests
+type PublishedCacheInfo struct {
+	PackageId   string
+	Version     string
+	Revision    int
+	Status      string
+	PublishedAt time.Time
+	DataHash    string
+	// Shareability is the only mutable attribute of the published document
+	Shareability string
+	// RevisionPinned is true if the resource was requested with the explicit revision, i.e. it can never change
+	RevisionPinned bool
+}
+
+func (c PublishedCacheInfo) GetCacheControl() string {
+	if c.RevisionPinned {
+		return CacheControlImmutable
+	}
+	if c.Status == string(Draft) {
+		return CacheControlDraft
+	}
+	return CacheControlRelease
+}
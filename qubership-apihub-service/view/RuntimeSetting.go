@@ -0,0 +1,75 @@
+package view
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type RuntimeSetting struct {
+	Key         string      `json:"key"`
+	Description string      `json:"description"`
+	Value       interface{} `json:"value"`
+	// DefaultValue is the value from the configuration file which is used when the setting is not overridden
+	DefaultValue interface{} `json:"defaultValue"`
+	Overridden   bool        `json:"overridden"`
+	UpdatedBy    string      `json:"updatedBy,omitempty"`
+	UpdatedAt    *time.Time  `json:"updatedAt,omitempty"`
+}
+
+type RuntimeSettings struct {
+	Settings []RuntimeSetting `json:"settings"`
+}
+
+type RuntimeSettingUpdateReq struct {
+	Value json.RawMessage `json:"value" validate:"required"`
+}
+
+type RuntimeSettingChange struct {
+	Id        string          `json:"id"`
+	Key       string          `json:"key"`
+	OldValue  json.RawMessage `json:"oldValue,omitempty"`
+	NewValue  json.RawMessage `json:"newValue,omitempty"`
+	ChangedBy string          `json:"changedBy"`
+	ChangedAt time.Time       `json:"changedAt"`
+}
+
+type RuntimeSettingsHistory struct {
+	Changes []RuntimeSettingChange `json:"changes"`
+}
+
+const (
+	SystemNotificationSeverityInfo    = "info"
+	SystemNotificationSeverityWarning = "warning"
+	SystemNotificationSeverityError   = "error"
+)
+
+type SystemNotification struct {
+	Id        string     `json:"id"`
+	Message   string     `json:"message"`
+	Severity  string     `json:"severity"`
+	StartAt   time.Time  `json:"startAt"`
+	EndAt     *time.Time `json:"endAt,omitempty"`
+	CreatedBy string     `json:"createdBy"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedBy string     `json:"updatedBy,omitempty"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+type SystemNotifications struct {
+	Notifications []SystemNotification `json:"notifications"`
+}
+
+// SystemNotificationReq describes the notification shown to all users from StartAt (now if not set) till EndAt (till deletion if not set)
+type SystemNotificationReq struct {
+	Message  string     `json:"message" validate:"required"`
+	Severity string     `json:"severity" validate:"required,oneof=info warning error"`
+	StartAt  *time.Time `json:"startAt"`
+	EndAt    *time.Time `json:"endAt"`
+}
+
+type ActiveSystemNotification struct {
+	Id       string     `json:"id"`
+	Message  string     `json:"message"`
+	Severity string     `json:"severity"`
+	EndAt    *time.Time `json:"endAt,omitempty"`
+}
@@ -0,0 +1,47 @@
+package view
+
+import "time"
+
+const (
+	SavedComparisonStatusRunning  = "running"
+	SavedComparisonStatusComplete = "complete"
+	SavedComparisonStatusError    = "error"
+)
+
+type SavedComparisonReq struct {
+	Name                     string `json:"name" validate:"required"`
+	PackageId                string `json:"packageId" validate:"required"`
+	Version                  string `json:"version" validate:"required"`
+	PreviousVersionPackageId string `json:"previousVersionPackageId" validate:"required"`
+	PreviousVersion          string `json:"previousVersion" validate:"required"`
+}
+
+type SavedComparisonUpdateReq struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type SavedComparison struct {
+	Id                       string                    `json:"id"`
+	Name                     string                    `json:"name"`
+	PackageId                string                    `json:"packageId"`
+	Version                  string                    `json:"version"`
+	PreviousVersionPackageId string                    `json:"previousVersionPackageId"`
+	PreviousVersion          string                    `json:"previousVersion"`
+	Status                   string                    `json:"status"`
+	Details                  string                    `json:"details,omitempty"`
+	CreatedBy                string                    `json:"createdBy"`
+	CreatedAt                time.Time                 `json:"createdAt"`
+	CalculatedAt             *time.Time                `json:"calculatedAt,omitempty"`
+	Summary                  *VersionComparisonSummary `json:"summary,omitempty"`
+}
+
+type SavedComparisonsReq struct {
+	// all saved comparisons of the package, otherwise the comparisons created by the user
+	PackageId string
+	Limit     int
+	Page      int
+}
+
+type SavedComparisons struct {
+	Comparisons []SavedComparison `json:"comparisons"`
+}
@@ -114,6 +114,16 @@ type GraphQLOperationSearchResult struct {
 	CommonOperationSearchResult
 }
 
+type ProtobufOperationSearchResult_deprecated struct {
+	ProtobufOperationView
+	CommonOperationSearchResult_deprecated
+}
+
+type ProtobufOperationSearchResult struct {
+	ProtobufOperationView
+	CommonOperationSearchResult
+}
+
 type AsyncAPIOperationSearchResult struct {
 	AsyncAPIOperationView
 	CommonOperationSearchResult
@@ -5,12 +5,13 @@ import (
 )
 
 type SystemInfo struct {
-	BackendVersion      string         `json:"backendVersion"`
-	ProductionMode      bool           `json:"productionMode"`
-	Notification        string         `json:"notification,omitempty"`
-	ExternalLinks       []string       `json:"externalLinks"`
-	MigrationInProgress bool           `json:"migrationInProgress"`
-	FeatureFlags        FeatureFlags `json:"featureFlags"`
+	BackendVersion      string                     `json:"backendVersion"`
+	ProductionMode      bool                       `json:"productionMode"`
+	Notification        string                     `json:"notification,omitempty"`
+	Notifications       []ActiveSystemNotification `json:"notifications"`
+	ExternalLinks       []string                   `json:"externalLinks"`
+	MigrationInProgress bool                       `json:"migrationInProgress"`
+	FeatureFlags        FeatureFlags               `json:"featureFlags"`
 }
 
 type FeatureFlags struct {
@@ -39,3 +39,34 @@ type TableSizeInfo struct {
 	TableSize      string  `json:"tableSize"`
 	TotalSizeShare float64 `json:"totalSizeShare"`
 }
+
+const StorageUsageGroupByWorkspace = "workspace"
+const StorageUsageGroupByPackage = "package"
+
+type StorageUsage struct {
+	Content  []ContentStorageUsage `json:"content"`
+	Packages []PackageStorageUsage `json:"packages"`
+}
+
+// ContentStorageUsage describes a table with content-addressed data. Sizes are in bytes.
+type ContentStorageUsage struct {
+	TableName string `json:"tableName"`
+	Entries   int    `json:"entries"`
+	// size of compressed data in the table
+	StoredSize int64 `json:"storedSize"`
+	// size which the data would take if it was stored for every reference
+	ReferencedSize          int64 `json:"referencedSize"`
+	UnreferencedEntries     int   `json:"unreferencedEntries"`
+	PendingReferenceChanges int   `json:"pendingReferenceChanges"`
+}
+
+// PackageStorageUsage describes the size of the content referenced by a package or by all packages of a workspace. Sizes are in bytes.
+type PackageStorageUsage struct {
+	Id                   string `json:"id"`
+	Documents            int    `json:"documents"`
+	DocumentsSize        int64  `json:"documentsSize"`
+	SourcesSize          int64  `json:"sourcesSize"`
+	OperationDataEntries int    `json:"operationDataEntries"`
+	OperationDataSize    int64  `json:"operationDataSize"`
+	TotalSize            int64  `json:"totalSize"`
+}
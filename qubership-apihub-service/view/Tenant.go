@@ -0,0 +1,60 @@
+package view
+
+import "time"
+
+const (
+	TenantQuotaStorage       = "storageMb"
+	TenantQuotaPackages      = "packages"
+	TenantQuotaVersions      = "versions"
+	TenantQuotaBuildsPerHour = "buildsPerHour"
+	TenantQuotaAiTokens      = "aiTokensPerMonth"
+)
+
+// TenantQuotas limit the usage of the workspace, nil value means the workspace is not limited
+type TenantQuotas struct {
+	StorageLimitMb   *int64 `json:"storageLimitMb" validate:"omitempty,gt=0"`
+	MaxPackages      *int   `json:"maxPackages" validate:"omitempty,gt=0"`
+	MaxVersions      *int   `json:"maxVersions" validate:"omitempty,gt=0"`
+	BuildsPerHour    *int   `json:"buildsPerHour" validate:"omitempty,gt=0"`
+	AiTokensPerMonth *int64 `json:"aiTokensPerMonth" validate:"omitempty,gt=0"`
+}
+
+// TenantSettings override the instance-wide configuration for the workspace, empty value means the instance-wide value is used
+type TenantSettings struct {
+	ReleaseVersionPattern   string                   `json:"releaseVersionPattern,omitempty"`
+	DefaultRole             string                   `json:"defaultRole,omitempty"`
+	ValidationRulesSeverity *ValidationRulesSeverity `json:"validationRulesSeverity,omitempty"`
+}
+
+type WorkspaceTenant struct {
+	WorkspaceId string         `json:"workspaceId"`
+	Quotas      TenantQuotas   `json:"quotas"`
+	Settings    TenantSettings `json:"settings"`
+	UpdatedBy   string         `json:"updatedBy,omitempty"`
+	UpdatedAt   *time.Time     `json:"updatedAt,omitempty"`
+}
+
+type TenantQuotaUsage struct {
+	Used     int64  `json:"used"`
+	Limit    *int64 `json:"limit,omitempty"`
+	Exceeded bool   `json:"exceeded"`
+}
+
+type TenantUsage struct {
+	WorkspaceId      string           `json:"workspaceId"`
+	StorageMb        TenantQuotaUsage `json:"storageMb"`
+	Packages         TenantQuotaUsage `json:"packages"`
+	Versions         TenantQuotaUsage `json:"versions"`
+	BuildsPerHour    TenantQuotaUsage `json:"buildsPerHour"`
+	AiTokensPerMonth TenantQuotaUsage `json:"aiTokensPerMonth"`
+	// StorageCalculatedAt is the time of the last storage usage calculation, storage usage is not available before it
+	StorageCalculatedAt *time.Time `json:"storageCalculatedAt,omitempty"`
+}
+
+type TenantsUsage struct {
+	Tenants []TenantUsage `json:"tenants"`
+}
+
+type AddTenantAdminsReq struct {
+	UserIds []string `json:"userIds" validate:"required,min=1"`
+}